@@ -0,0 +1,57 @@
+package builtin
+
+import (
+	"fmt"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+)
+
+// Structured description of an actor abort, attached by test runtimes to aid in diagnosing failures.
+// Envelopes are captured only in builds with the "abortenvelope" tag (see AbortEnvelopesEnabled),
+// and never by production runtimes.
+type AbortEnvelope struct {
+	Actor    cid.Cid       // Code CID of the aborting actor.
+	Receiver addr.Address  // Address of the aborting actor.
+	Method   abi.MethodNum // Method being invoked, or zero if not known to the runtime.
+	Code     exitcode.ExitCode
+	Message  string
+	// Location in actor code from which the abort was raised, outside runtime implementations and Require* helpers.
+	File string
+	Line int
+	// State CIDs involved in the abort: the aborting actor's state head, followed by the state tree root
+	// if known to the runtime.
+	States []cid.Cid
+}
+
+func (e *AbortEnvelope) String() string {
+	return fmt.Sprintf("abort(%v) by %s %v method %d at %s:%d states %v: %s",
+		e.Code, ActorNameByCode(e.Actor), e.Receiver, e.Method, e.File, e.Line, e.States, e.Message)
+}
+
+// Returns the file and line of the innermost caller of Abortf outside runtime implementations and Require* helpers.
+// This is the location in actor code at which an abort was raised.
+func CaptureAbortSite() (string, int) {
+	builtinPkg := reflect.TypeOf(AbortEnvelope{}).PkgPath()
+	supportPkg := strings.TrimSuffix(builtinPkg, "actors/builtin") + "support/"
+	pcs := make([]uintptr, 32)
+	n := goruntime.Callers(2, pcs)
+	frames := goruntime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, supportPkg) ||
+			strings.HasPrefix(frame.Function, builtinPkg+".Require") ||
+			strings.HasPrefix(frame.Function, builtinPkg+".CaptureAbortSite")
+		if !internal {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "<unknown>", 0
+		}
+	}
+}
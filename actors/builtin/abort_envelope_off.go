@@ -0,0 +1,8 @@
+//go:build !abortenvelope
+// +build !abortenvelope
+
+package builtin
+
+// Whether test runtimes attach an AbortEnvelope to actor aborts.
+// Build with the "abortenvelope" tag to enable them.
+const AbortEnvelopesEnabled = false
@@ -0,0 +1,7 @@
+//go:build abortenvelope
+// +build abortenvelope
+
+package builtin
+
+// Whether test runtimes attach an AbortEnvelope to actor aborts.
+const AbortEnvelopesEnabled = true
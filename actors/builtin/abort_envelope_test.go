@@ -0,0 +1,38 @@
+package builtin_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestAbortEnvelope(t *testing.T) {
+	t.Run("captures the calling site", func(t *testing.T) {
+		file, line := builtin.CaptureAbortSite()
+		assert.Equal(t, "abort_envelope_test.go", filepath.Base(file))
+		assert.NotZero(t, line)
+	})
+
+	t.Run("describes the abort", func(t *testing.T) {
+		receiver := tutil.NewIDAddr(t, 1000)
+		envelope := &builtin.AbortEnvelope{
+			Actor:    builtin.StorageMinerActorCodeID,
+			Receiver: receiver,
+			Method:   7,
+			Code:     exitcode.ErrIllegalArgument,
+			Message:  "bad sector",
+			File:     "miner_actor.go",
+			Line:     42,
+		}
+		s := envelope.String()
+		assert.Contains(t, s, "fil/7/storageminer")
+		assert.Contains(t, s, receiver.String())
+		assert.Contains(t, s, "miner_actor.go:42")
+		assert.Contains(t, s, "bad sector")
+	})
+}
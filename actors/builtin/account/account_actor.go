@@ -4,6 +4,7 @@ import (
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/ipfs/go-cid"
 
@@ -15,8 +16,10 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		1: a.Constructor,
-		2: a.PubkeyAddress,
+		1:                          a.Constructor,
+		2:                          a.PubkeyAddress,
+		3:                          a.AuthenticateMessage,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -30,6 +33,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 type State struct {
 	Address addr.Address
 }
@@ -57,3 +65,31 @@ func (a Actor) PubkeyAddress(rt runtime.Runtime, _ *abi.EmptyValue) *addr.Addres
 	rt.StateReadonly(&st)
 	return &st.Address
 }
+
+type AuthenticateMessageParams struct {
+	Signature []byte
+	Message   []byte
+}
+
+// Authenticates whether the provided signature is valid for the provided message, signed by this account's key.
+// Aborts if the signature is invalid, so other actors may delegate signature validation to the account
+// rather than verifying signatures against its key directly.
+func (a Actor) AuthenticateMessage(rt runtime.Runtime, params *AuthenticateMessageParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+
+	var sigType crypto.SigType
+	switch st.Address.Protocol() {
+	case addr.SECP256K1:
+		sigType = crypto.SigTypeSecp256k1
+	case addr.BLS:
+		sigType = crypto.SigTypeBLS
+	default:
+		rt.Abortf(exitcode.ErrIllegalState, "account address must use BLS or SECP protocol, got %v", st.Address.Protocol())
+	}
+	sig := crypto.Signature{Type: sigType, Data: params.Signature}
+	err := rt.VerifySignature(sig, st.Address, params.Message)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "invalid signature")
+	return nil
+}
@@ -1,10 +1,12 @@
 package account_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,6 +82,47 @@ func TestAccountactor(t *testing.T) {
 	}
 }
 
+func TestAuthenticateMessage(t *testing.T) {
+	actor := account.Actor{}
+	receiver := tutil.NewIDAddr(t, 100)
+	caller := tutil.NewIDAddr(t, 101)
+	message := []byte("message")
+	sigData := []byte("signature")
+
+	for _, tc := range []struct {
+		desc    string
+		addr    address.Address
+		sigType crypto.SigType
+	}{
+		{"SECP256K1 account", tutil.NewSECP256K1Addr(t, "secpaddress"), crypto.SigTypeSecp256k1},
+		{"BLS account", tutil.NewBLSAddr(t, 1), crypto.SigTypeBLS},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			rt := mock.NewBuilder(receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).Build(t)
+			rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+			rt.Call(actor.Constructor, &tc.addr)
+			rt.Verify()
+
+			params := &account.AuthenticateMessageParams{Signature: sigData, Message: message}
+			sig := crypto.Signature{Type: tc.sigType, Data: sigData}
+
+			rt.SetCaller(caller, builtin.StorageMarketActorCodeID)
+			rt.ExpectValidateCallerAny()
+			rt.ExpectVerifySignature(sig, tc.addr, message, nil)
+			rt.Call(actor.AuthenticateMessage, params)
+			rt.Verify()
+
+			rt.ExpectValidateCallerAny()
+			rt.ExpectVerifySignature(sig, tc.addr, message, fmt.Errorf("bad signature"))
+			rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid signature", func() {
+				rt.Call(actor.AuthenticateMessage, params)
+			})
+			rt.Verify()
+			checkState(t, rt)
+		})
+	}
+}
+
 func checkState(t *testing.T, rt *mock.Runtime) {
 	testAddress, err := address.NewIDAddress(1000)
 	require.NoError(t, err)
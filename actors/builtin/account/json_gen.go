@@ -0,0 +1,36 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package account
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Address", Value: &t.Address},
+	}
+}
+
+func (t AuthenticateMessageParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *AuthenticateMessageParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *AuthenticateMessageParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Signature", Value: &t.Signature},
+		{Name: "Message", Value: &t.Message},
+	}
+}
@@ -0,0 +1,52 @@
+package builtin
+
+import (
+	"path"
+	"reflect"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// Version of the actors defined in this package and its sub-packages.
+const ActorsVersion = 7
+
+type GetActorInfoReturn struct {
+	// Semantic name of the actor, e.g. "storageminer".
+	Name string
+	// Version of the actors package in which the actor is defined.
+	Version uint64
+	// Identifies the schema of the actor's state, as computed by StateSchemaID. Actors with the same
+	// state schema identifier have states of the same type.
+	StateSchema cid.Cid
+}
+
+// Implements GetActorInfo for the builtin actor with a code CID and state type.
+// Any caller may call GetActorInfo on any actor.
+func GetActorInfo(rt runtime.Runtime, code cid.Cid, state interface{}) *GetActorInfoReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	info, ok := builtinActors[code]
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalState, "no builtin actor with code %v", code)
+	}
+	schema, err := StateSchemaID(state)
+	RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to compute state schema of %s", info.name)
+	return &GetActorInfoReturn{
+		Name:        path.Base(info.name),
+		Version:     ActorsVersion,
+		StateSchema: schema,
+	}
+}
+
+// Computes the identifier of the schema of an actor state type: the SHA-256 CID of the type's name
+// in the schema followed by the IPLD schema declaring it and every type it references.
+func StateSchemaID(state interface{}) (cid.Cid, error) {
+	schema := NewSchemaBuilder()
+	name := schema.TypeName(reflect.TypeOf(state))
+	builder := cid.V1Builder{Codec: cid.Raw, MhType: mh.SHA2_256}
+	return builder.Sum([]byte(name + "\n" + schema.String()))
+}
@@ -1,23 +1,41 @@
 package cron
 
 import (
+	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	rtt "github.com/filecoin-project/go-state-types/rt"
 	cron0 "github.com/filecoin-project/specs-actors/actors/builtin/cron"
 	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
 
+// The address permitted to add and remove entries.
+var GovernanceAddr = builtin.GovernanceActorAddr // PARAM_SPEC
+
+// The maximum number of entries which may be registered.
+const MaxEntries = 32 // PARAM_SPEC
+
+// The number of most recent epochs for which reports of the calls made by the cron actor are retained.
+const TickReportEpochs = 60
+
+// Bitwidth of the AMT of tick reports, which holds at most TickReportEpochs entries.
+const TickReportsAmtBitwidth = 3
+
 // The cron actor is a built-in singleton that sends messages to other registered actors at the end of each epoch.
 type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.EpochTick,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.EpochTick,
+		3:                          a.AddEntry,
+		4:                          a.RemoveEntry,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -35,6 +53,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 //type ConstructorParams struct {
 //	Entries []Entry
 //}
@@ -46,9 +69,11 @@ func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.E
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 	entries := make([]Entry, len(params.Entries))
 	for i, e := range params.Entries {
-		entries[i] = Entry(e) // Identical
+		entries[i] = Entry{Receiver: e.Receiver, MethodNum: e.MethodNum}
 	}
-	rt.StateCreate(ConstructState(entries))
+	st, err := ConstructState(adt.AsStore(rt), entries)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to construct state")
+	rt.StateCreate(st)
 	return nil
 }
 
@@ -59,13 +84,105 @@ func (a Actor) EpochTick(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue
 	var st State
 
 	rt.StateReadonly(&st)
+	var exhausted []Entry
+	report := TickReport{Epoch: rt.CurrEpoch(), Results: []TickResult{}}
 	for _, entry := range st.Entries {
-		code := rt.Send(entry.Receiver, entry.MethodNum, nil, abi.NewTokenAmount(0), &builtin.Discard{})
-		// Any error and return value are ignored.
-		if code.IsError() {
+		if entry.Flagged {
+			continue
+		}
+		var code exitcode.ExitCode
+		if entry.GasBudget > 0 {
+			code = rt.SendWithGasLimit(entry.Receiver, entry.MethodNum, nil, abi.NewTokenAmount(0), entry.GasBudget, &builtin.Discard{})
+		} else {
+			code = rt.Send(entry.Receiver, entry.MethodNum, nil, abi.NewTokenAmount(0), &builtin.Discard{})
+		}
+		report.Results = append(report.Results, TickResult{
+			Receiver:  entry.Receiver,
+			MethodNum: entry.MethodNum,
+			ExitCode:  code,
+		})
+		// Return values are ignored, and errors only recorded in the epoch's report, except that an entry
+		// exhausting its gas budget is flagged so that it doesn't consume the budget again in every subsequent epoch.
+		if entry.GasBudget > 0 && code == exitcode.SysErrOutOfGas {
+			rt.Log(rtt.WARN, "cron entry to %s method %d exceeded gas budget %d, flagging", entry.Receiver, entry.MethodNum, entry.GasBudget)
+			exhausted = append(exhausted, entry)
+		} else if code.IsError() {
 			rt.Log(rtt.ERROR, "cron failed to send entry to %s, send error code %d", entry.Receiver, code)
 		}
 	}
 
+	rt.StateTransaction(&st, func() {
+		for _, entry := range exhausted {
+			if i := st.findEntry(entry.Receiver, entry.MethodNum); i >= 0 {
+				st.Entries[i].Flagged = true
+			}
+		}
+		err := st.recordTickReport(adt.AsStore(rt), &report)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record tick report")
+	})
+	return nil
+}
+
+type AddEntryParams struct {
+	Receiver  addr.Address
+	MethodNum abi.MethodNum
+	GasBudget int64
+}
+
+// Registers a new entry to be invoked at the end of each epoch, with an optional gas budget.
+// An entry which was flagged for exhausting its budget must be removed before it can be registered again.
+func (a Actor) AddEntry(rt runtime.Runtime, params *AddEntryParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(GovernanceAddr)
+
+	receiver, ok := rt.ResolveAddress(params.Receiver)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "failed to resolve receiver address %v", params.Receiver)
+	}
+	if params.MethodNum <= builtin.MethodConstructor {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid method number %d", params.MethodNum)
+	}
+	if params.GasBudget < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "negative gas budget %d", params.GasBudget)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if st.findEntry(receiver, params.MethodNum) >= 0 {
+			rt.Abortf(exitcode.ErrForbidden, "entry for %v method %d already registered", receiver, params.MethodNum)
+		}
+		if len(st.Entries) >= MaxEntries {
+			rt.Abortf(exitcode.ErrForbidden, "too many entries %d, max %d", len(st.Entries), MaxEntries)
+		}
+		st.Entries = append(st.Entries, Entry{
+			Receiver:  receiver,
+			MethodNum: params.MethodNum,
+			GasBudget: params.GasBudget,
+		})
+	})
+	return nil
+}
+
+type RemoveEntryParams struct {
+	Receiver  addr.Address
+	MethodNum abi.MethodNum
+}
+
+// Deregisters an entry, whether or not it has been flagged.
+func (a Actor) RemoveEntry(rt runtime.Runtime, params *RemoveEntryParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(GovernanceAddr)
+
+	receiver, ok := rt.ResolveAddress(params.Receiver)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "failed to resolve receiver address %v", params.Receiver)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		i := st.findEntry(receiver, params.MethodNum)
+		if i < 0 {
+			rt.Abortf(exitcode.ErrNotFound, "no entry for %v method %d", receiver, params.MethodNum)
+		}
+		st.Entries = append(st.Entries[:i], st.Entries[i+1:]...)
+	})
 	return nil
 }
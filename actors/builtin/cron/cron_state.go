@@ -1,23 +1,116 @@
 package cron
 
 import (
+	"sort"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	cid "github.com/ipfs/go-cid"
+	xerrors "golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
 
 type State struct {
 	Entries []Entry
+	// Reports of the calls made in the most recent TickReportEpochs epochs,
+	// indexed by epoch modulo TickReportEpochs.
+	TickReports cid.Cid // AMT[uint64]TickReport
 }
 
 type Entry struct {
 	Receiver  addr.Address  // The actor to call (must be an ID-address)
 	MethodNum abi.MethodNum // The method number to call (must accept empty parameters)
+	GasBudget int64         // The maximum gas the call may consume, or zero for no limit beyond the epoch tick's own
+	Flagged   bool          // Set when the call exhausted its gas budget. Flagged entries are skipped until re-registered.
+}
+
+// Results of the calls made by the cron actor at the end of one epoch.
+type TickReport struct {
+	Epoch   abi.ChainEpoch
+	Results []TickResult // In order of entries, omitting flagged entries which were skipped
+}
+
+type TickResult struct {
+	Receiver  addr.Address
+	MethodNum abi.MethodNum
+	ExitCode  exitcode.ExitCode
+}
+
+func ConstructState(store adt.Store, entries []Entry) (*State, error) {
+	emptyReportsCid, err := adt.StoreEmptyArray(store, TickReportsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty tick reports: %w", err)
+	}
+	return &State{
+		Entries:     entries,
+		TickReports: emptyReportsCid,
+	}, nil
 }
 
-func ConstructState(entries []Entry) *State {
-	return &State{Entries: entries}
+// Records the report of an epoch's calls, replacing the report from TickReportEpochs epochs earlier.
+func (st *State) recordTickReport(store adt.Store, report *TickReport) error {
+	reports, err := adt.AsArray(store, st.TickReports, TickReportsAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load tick reports: %w", err)
+	}
+	if err := reports.Set(uint64(report.Epoch)%TickReportEpochs, report); err != nil {
+		return xerrors.Errorf("failed to store tick report for epoch %d: %w", report.Epoch, err)
+	}
+	if st.TickReports, err = reports.Root(); err != nil {
+		return xerrors.Errorf("failed to flush tick reports: %w", err)
+	}
+	return nil
+}
+
+// Returns the report of the calls made at the end of an epoch, if it is within the most recent TickReportEpochs
+// epochs in which the cron actor was invoked.
+func (st *State) GetTickReport(store adt.Store, epoch abi.ChainEpoch) (*TickReport, bool, error) {
+	reports, err := adt.AsArray(store, st.TickReports, TickReportsAmtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load tick reports: %w", err)
+	}
+	var report TickReport
+	found, err := reports.Get(uint64(epoch)%TickReportEpochs, &report)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load tick report for epoch %d: %w", epoch, err)
+	}
+	if !found || report.Epoch != epoch {
+		return nil, false, nil
+	}
+	return &report, true, nil
+}
+
+// Returns all retained reports, in order of epoch.
+func (st *State) LoadTickReports(store adt.Store) ([]TickReport, error) {
+	reports, err := adt.AsArray(store, st.TickReports, TickReportsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load tick reports: %w", err)
+	}
+	var out []TickReport
+	var report TickReport
+	if err := reports.ForEach(&report, func(_ int64) error {
+		out = append(out, report)
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate tick reports: %w", err)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Epoch < out[j].Epoch
+	})
+	return out, nil
+}
+
+// Returns the index of the entry calling a method on a receiver, or -1 if there is none.
+func (st *State) findEntry(receiver addr.Address, method abi.MethodNum) int {
+	for i, e := range st.Entries {
+		if e.Receiver == receiver && e.MethodNum == method {
+			return i
+		}
+	}
+	return -1
 }
 
 // The default entries to install in the cron actor's state at genesis.
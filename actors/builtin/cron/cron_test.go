@@ -3,10 +3,12 @@ package cron_test
 import (
 	"testing"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
@@ -52,7 +54,7 @@ func TestConstructor(t *testing.T) {
 		rt.GetState(&st)
 		expectedEntries := make([]cron.Entry, len(entryParams))
 		for i, e := range entryParams {
-			expectedEntries[i] = cron.Entry(e)
+			expectedEntries[i] = cron.Entry{Receiver: e.Receiver, MethodNum: e.MethodNum}
 		}
 		assert.Equal(t, expectedEntries, st.Entries)
 
@@ -94,12 +96,220 @@ func TestEpochTick(t *testing.T) {
 		actor.checkState(rt)
 	})
 
+	t.Run("entry exhausting its gas budget is flagged and skipped", func(t *testing.T) {
+		rt := builder.Build(t)
+		entry1 := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1001), MethodNum: abi.MethodNum(1001)}
+		actor.constructAndVerify(rt, entry1)
+
+		receiver := tutil.NewIDAddr(t, 1002)
+		actor.addEntry(rt, receiver, 2, 1000)
+
+		rt.ExpectSend(entry1.Receiver, entry1.MethodNum, nil, big.Zero(), nil, exitcode.Ok)
+		rt.ExpectSendWithGasLimit(receiver, 2, nil, big.Zero(), 1000, nil, exitcode.SysErrOutOfGas)
+		actor.epochTickAndVerify(rt)
+
+		st := actor.getState(rt)
+		assert.False(t, st.Entries[0].Flagged)
+		assert.True(t, st.Entries[1].Flagged)
+
+		// The flagged entry is no longer invoked.
+		rt.ExpectSend(entry1.Receiver, entry1.MethodNum, nil, big.Zero(), nil, exitcode.Ok)
+		actor.epochTickAndVerify(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("entry failing within its gas budget is not flagged", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		receiver := tutil.NewIDAddr(t, 1002)
+		actor.addEntry(rt, receiver, 2, 1000)
+
+		rt.ExpectSendWithGasLimit(receiver, 2, nil, big.Zero(), 1000, nil, exitcode.ErrIllegalState)
+		actor.epochTickAndVerify(rt)
+		assert.False(t, actor.getState(rt).Entries[0].Flagged)
+		actor.checkState(rt)
+	})
+
+	t.Run("records a report of each epoch's calls", func(t *testing.T) {
+		rt := builder.Build(t)
+		entry1 := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1001), MethodNum: abi.MethodNum(1001)}
+		entry2 := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1002), MethodNum: abi.MethodNum(1002)}
+		actor.constructAndVerify(rt, entry1, entry2)
+
+		rt.SetEpoch(10)
+		rt.ExpectSend(entry1.Receiver, entry1.MethodNum, nil, big.Zero(), nil, exitcode.Ok)
+		rt.ExpectSend(entry2.Receiver, entry2.MethodNum, nil, big.Zero(), nil, exitcode.ErrIllegalState)
+		actor.epochTickAndVerify(rt)
+
+		st := actor.getState(rt)
+		report, found, err := st.GetTickReport(rt.AdtStore(), 10)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, &cron.TickReport{
+			Epoch: 10,
+			Results: []cron.TickResult{
+				{Receiver: entry1.Receiver, MethodNum: entry1.MethodNum, ExitCode: exitcode.Ok},
+				{Receiver: entry2.Receiver, MethodNum: entry2.MethodNum, ExitCode: exitcode.ErrIllegalState},
+			},
+		}, report)
+
+		_, found, err = st.GetTickReport(rt.AdtStore(), 11)
+		require.NoError(t, err)
+		assert.False(t, found)
+		actor.checkState(rt)
+	})
+
+	t.Run("retains reports for a bounded number of epochs", func(t *testing.T) {
+		rt := builder.Build(t)
+		entry1 := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1001), MethodNum: abi.MethodNum(1001)}
+		actor.constructAndVerify(rt, entry1)
+
+		first := abi.ChainEpoch(1)
+		last := first + cron.TickReportEpochs
+		for epoch := first; epoch <= last; epoch++ {
+			rt.SetEpoch(epoch)
+			rt.ExpectSend(entry1.Receiver, entry1.MethodNum, nil, big.Zero(), nil, exitcode.Ok)
+			actor.epochTickAndVerify(rt)
+		}
+
+		st := actor.getState(rt)
+		reports, err := st.LoadTickReports(rt.AdtStore())
+		require.NoError(t, err)
+		require.Len(t, reports, cron.TickReportEpochs)
+		assert.Equal(t, first+1, reports[0].Epoch)
+		assert.Equal(t, last, reports[len(reports)-1].Epoch)
+
+		// The first epoch's report has been overwritten by the last.
+		_, found, err := st.GetTickReport(rt.AdtStore(), first)
+		require.NoError(t, err)
+		assert.False(t, found)
+		actor.checkState(rt)
+	})
+
 	t.Run("built-in entries", func(t *testing.T) {
 		bie := cron.BuiltInEntries()
 		assert.True(t, len(bie) > 0)
 	})
 }
 
+func TestAddRemoveEntry(t *testing.T) {
+	actor := cronHarness{cron.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	builder := mock.NewBuilder(receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	target := tutil.NewIDAddr(t, 1001)
+
+	t.Run("adds and removes entries", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		actor.addEntry(rt, target, 2, 0)
+		actor.addEntry(rt, target, 3, 1000)
+		assert.Equal(t, []cron.Entry{
+			{Receiver: target, MethodNum: 2},
+			{Receiver: target, MethodNum: 3, GasBudget: 1000},
+		}, actor.getState(rt).Entries)
+
+		actor.removeEntry(rt, target, 2)
+		assert.Equal(t, []cron.Entry{
+			{Receiver: target, MethodNum: 3, GasBudget: 1000},
+		}, actor.getState(rt).Entries)
+		actor.checkState(rt)
+	})
+
+	t.Run("resolves receiver address", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		pubkey := tutil.NewBLSAddr(t, 1)
+		rt.AddIDAddress(pubkey, target)
+		actor.addEntry(rt, pubkey, 2, 0)
+		assert.Equal(t, target, actor.getState(rt).Entries[0].Receiver)
+
+		actor.removeEntry(rt, pubkey, 2)
+		assert.Empty(t, actor.getState(rt).Entries)
+		actor.checkState(rt)
+	})
+
+	t.Run("removes a flagged entry so it may be re-registered", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.addEntry(rt, target, 2, 1000)
+
+		rt.ExpectSendWithGasLimit(target, 2, nil, big.Zero(), 1000, nil, exitcode.SysErrOutOfGas)
+		actor.epochTickAndVerify(rt)
+
+		rt.SetCaller(cron.GovernanceAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "already registered", func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: target, MethodNum: 2, GasBudget: 2000})
+		})
+		rt.Verify()
+
+		actor.removeEntry(rt, target, 2)
+		actor.addEntry(rt, target, 2, 2000)
+		assert.False(t, actor.getState(rt).Entries[0].Flagged)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects caller other than governance", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 999), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: target, MethodNum: 2})
+		})
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.RemoveEntry, &cron.RemoveEntryParams{Receiver: target, MethodNum: 2})
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(cron.GovernanceAddr, builtin.MultisigActorCodeID)
+
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "failed to resolve", func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: tutil.NewBLSAddr(t, 2), MethodNum: 2})
+		})
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid method number", func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: target, MethodNum: builtin.MethodConstructor})
+		})
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "negative gas budget", func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: target, MethodNum: 2, GasBudget: -1})
+		})
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no entry", func() {
+			rt.Call(actor.RemoveEntry, &cron.RemoveEntryParams{Receiver: target, MethodNum: 2})
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects entries beyond the maximum", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		for i := 0; i < cron.MaxEntries; i++ {
+			actor.addEntry(rt, target, abi.MethodNum(2+i), 0)
+		}
+
+		rt.SetCaller(cron.GovernanceAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "too many entries", func() {
+			rt.Call(actor.AddEntry, &cron.AddEntryParams{Receiver: target, MethodNum: abi.MethodNum(2 + cron.MaxEntries)})
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+}
+
 type cronHarness struct {
 	cron.Actor
 	t testing.TB
@@ -114,12 +324,35 @@ func (h *cronHarness) constructAndVerify(rt *mock.Runtime, entries ...cron.Entry
 }
 
 func (h *cronHarness) epochTickAndVerify(rt *mock.Runtime) {
+	rt.SetCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
 	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
 	ret := rt.Call(h.EpochTick, nil)
 	assert.Nil(h.t, ret)
 	rt.Verify()
 }
 
+func (h *cronHarness) addEntry(rt *mock.Runtime, receiver address.Address, method abi.MethodNum, gasBudget int64) {
+	rt.SetCaller(cron.GovernanceAddr, builtin.MultisigActorCodeID)
+	rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+	ret := rt.Call(h.AddEntry, &cron.AddEntryParams{Receiver: receiver, MethodNum: method, GasBudget: gasBudget})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *cronHarness) removeEntry(rt *mock.Runtime, receiver address.Address, method abi.MethodNum) {
+	rt.SetCaller(cron.GovernanceAddr, builtin.MultisigActorCodeID)
+	rt.ExpectValidateCallerAddr(cron.GovernanceAddr)
+	ret := rt.Call(h.RemoveEntry, &cron.RemoveEntryParams{Receiver: receiver, MethodNum: method})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *cronHarness) getState(rt *mock.Runtime) *cron.State {
+	var st cron.State
+	rt.GetState(&st)
+	return &st
+}
+
 func (h *cronHarness) checkState(rt *mock.Runtime) {
 	var st cron.State
 	rt.GetState(&st)
@@ -0,0 +1,101 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package cron
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Entries", Value: &t.Entries},
+		{Name: "TickReports", Value: &t.TickReports},
+	}
+}
+
+func (t Entry) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *Entry) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *Entry) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Receiver", Value: &t.Receiver},
+		{Name: "MethodNum", Value: &t.MethodNum},
+		{Name: "GasBudget", Value: &t.GasBudget},
+		{Name: "Flagged", Value: &t.Flagged},
+	}
+}
+
+func (t TickReport) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *TickReport) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *TickReport) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Epoch", Value: &t.Epoch},
+		{Name: "Results", Value: &t.Results},
+	}
+}
+
+func (t TickResult) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *TickResult) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *TickResult) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Receiver", Value: &t.Receiver},
+		{Name: "MethodNum", Value: &t.MethodNum},
+		{Name: "ExitCode", Value: &t.ExitCode},
+	}
+}
+
+func (t AddEntryParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *AddEntryParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *AddEntryParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Receiver", Value: &t.Receiver},
+		{Name: "MethodNum", Value: &t.MethodNum},
+		{Name: "GasBudget", Value: &t.GasBudget},
+	}
+}
+
+func (t RemoveEntryParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *RemoveEntryParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *RemoveEntryParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Receiver", Value: &t.Receiver},
+		{Name: "MethodNum", Value: &t.MethodNum},
+	}
+}
@@ -16,9 +16,26 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	cronSummary := &StateSummary{
 		EntryCount: len(st.Entries),
 	}
+	acc.Require(len(st.Entries) <= MaxEntries, "entry count %d exceeds max %d", len(st.Entries), MaxEntries)
 	for i, e := range st.Entries {
 		acc.Require(e.Receiver.Protocol() == address.ID, "entry %d receiver address %v must be ID protocol", i, e.Receiver)
 		acc.Require(e.MethodNum > 0, "entry %d has invalid method number %d", i, e.MethodNum)
+		acc.Require(e.GasBudget >= 0, "entry %d has negative gas budget %d", i, e.GasBudget)
+		acc.Require(!e.Flagged || e.GasBudget > 0, "entry %d is flagged without a gas budget", i)
+		acc.Require(st.findEntry(e.Receiver, e.MethodNum) == i, "entry %d duplicates an earlier entry for %v method %d", i, e.Receiver, e.MethodNum)
+	}
+
+	if reports, err := adt.AsArray(store, st.TickReports, TickReportsAmtBitwidth); err != nil {
+		acc.Addf("error loading tick reports: %v", err)
+	} else {
+		var report TickReport
+		err = reports.ForEach(&report, func(idx int64) error {
+			acc.Require(uint64(report.Epoch)%TickReportEpochs == uint64(idx), "tick report for epoch %d at wrong index %d", report.Epoch, idx)
+			acc.Require(len(report.Results) <= MaxEntries, "tick report for epoch %d has %d results, max %d",
+				report.Epoch, len(report.Results), MaxEntries)
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating tick reports")
 	}
 	return cronSummary, acc
 }
@@ -0,0 +1,91 @@
+package builtin
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// The key of the first entry of every event emitted by built-in actors, whose value names the event type.
+const EventTypeKey = "$type"
+
+// Builds an event emitted by a built-in actor.
+// The first entry names the event type, and is followed by the event's fields in the order they are added.
+// All entries are flagged for indexing of both keys and values.
+type EventBuilder struct {
+	entries []runtime.EventEntry
+	err     error
+}
+
+// Begins an event of the given type.
+func NewEventBuilder(eventType string) *EventBuilder {
+	b := &EventBuilder{}
+	return b.WithString(EventTypeKey, eventType)
+}
+
+// Adds a field with a string value.
+func (b *EventBuilder) WithString(key string, value string) *EventBuilder {
+	var buf bytes.Buffer
+	if err := cbg.WriteMajorTypeHeader(&buf, cbg.MajTextString, uint64(len(value))); err != nil {
+		b.err = err
+		return b
+	}
+	buf.WriteString(value)
+	b.entries = append(b.entries, runtime.EventEntry{Flags: runtime.EventFlagIndexedAll, Key: key, Value: buf.Bytes()})
+	return b
+}
+
+// Adds a field with an integer value.
+func (b *EventBuilder) WithInt(key string, value int64) *EventBuilder {
+	v := cbg.CborInt(value)
+	return b.with(key, &v)
+}
+
+// Adds a field with an address value.
+func (b *EventBuilder) WithAddress(key string, value addr.Address) *EventBuilder {
+	return b.with(key, &value)
+}
+
+// Adds a field with a CID value.
+func (b *EventBuilder) WithCid(key string, value cid.Cid) *EventBuilder {
+	v := cbg.CborCid(value)
+	return b.with(key, &v)
+}
+
+// Adds a field with a sector number value.
+func (b *EventBuilder) WithSector(key string, value abi.SectorNumber) *EventBuilder {
+	return b.WithInt(key, int64(value))
+}
+
+// Adds a field with a deal ID value.
+func (b *EventBuilder) WithDeal(key string, value abi.DealID) *EventBuilder {
+	return b.WithInt(key, int64(value))
+}
+
+func (b *EventBuilder) with(key string, value cbor.Marshaler) *EventBuilder {
+	var buf bytes.Buffer
+	if err := value.MarshalCBOR(&buf); err != nil {
+		b.err = err
+		return b
+	}
+	b.entries = append(b.entries, runtime.EventEntry{Flags: runtime.EventFlagIndexedAll, Key: key, Value: buf.Bytes()})
+	return b
+}
+
+// Returns the entries of the event built, or the first error encountered encoding them.
+func (b *EventBuilder) Build() ([]runtime.EventEntry, error) {
+	return b.entries, b.err
+}
+
+// Emits the event built, aborting if any of its entries could not be encoded.
+func (b *EventBuilder) Emit(rt runtime.Runtime) {
+	RequireNoErr(rt, b.err, exitcode.ErrSerialization, "failed to encode event")
+	rt.EmitEvent(b.entries...)
+}
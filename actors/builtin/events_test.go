@@ -0,0 +1,76 @@
+package builtin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestEventBuilder(t *testing.T) {
+	t.Run("builds typed entries in order", func(t *testing.T) {
+		addr := tutil.NewIDAddr(t, 1000)
+		entries, err := builtin.NewEventBuilder("sector-activated").
+			WithAddress("miner", addr).
+			WithSector("sector", abi.SectorNumber(7)).
+			Build()
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, builtin.EventTypeKey, entries[0].Key)
+		eventType, err := cbg.ReadString(bytes.NewReader(entries[0].Value))
+		require.NoError(t, err)
+		assert.Equal(t, "sector-activated", eventType)
+
+		assert.Equal(t, "miner", entries[1].Key)
+		var decodedAddr address.Address
+		require.NoError(t, decodedAddr.UnmarshalCBOR(bytes.NewReader(entries[1].Value)))
+		assert.Equal(t, addr, decodedAddr)
+
+		assert.Equal(t, "sector", entries[2].Key)
+		var sectorNo cbg.CborInt
+		require.NoError(t, sectorNo.UnmarshalCBOR(bytes.NewReader(entries[2].Value)))
+		assert.Equal(t, cbg.CborInt(7), sectorNo)
+
+		for _, e := range entries {
+			assert.Equal(t, runtime.EventFlagIndexedAll, e.Flags)
+		}
+	})
+
+	t.Run("emits to the runtime", func(t *testing.T) {
+		receiver := tutil.NewIDAddr(t, 100)
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			builtin.NewEventBuilder("deal-published").WithDeal("id", 3).Emit(rt)
+			return nil
+		}, nil)
+
+		events := rt.Events()
+		require.Len(t, events, 1)
+		assert.Len(t, events[0].Entries, 2)
+		assert.Equal(t, "id", events[0].Entries[1].Key)
+	})
+
+	t.Run("events of aborted calls are discarded", func(t *testing.T) {
+		receiver := tutil.NewIDAddr(t, 100)
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+				builtin.NewEventBuilder("deal-published").Emit(rt)
+				rt.Abortf(exitcode.ErrIllegalArgument, "fail")
+				return nil
+			}, nil)
+		})
+		assert.Empty(t, rt.Events())
+	})
+}
@@ -0,0 +1,109 @@
+package exported
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+)
+
+// A reason for which a builtin actor method aborts, found at an Abortf or Require* call site in the actor's code.
+// The catalog of reasons is generated by gen/aborts, so that an exit code can be presented to users as the
+// conditions that cause it rather than as a number.
+type AbortReason struct {
+	// Stable identifier of the reason, prefixed by the actor's package, e.g. "miner.sector-number-greater-than-maximum".
+	// Identifiers are derived from the message template, so change only when the template changes.
+	ID       string
+	Actor    cid.Cid           // code CID of the actor
+	Methods  []abi.MethodNum   // methods of the actor that may abort for the reason
+	Code     exitcode.ExitCode // exit code of the abort
+	Template string            // format of the abort message, as passed to Abortf
+	Summary  string            // the template without its format verbs
+}
+
+var templateVerb = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+
+// Checks whether an abort message could have been formatted from the reason's template.
+func (r *AbortReason) Matches(message string) bool {
+	return templatePattern(r.Template).MatchString(message)
+}
+
+type abortKey struct {
+	actor  cid.Cid
+	method abi.MethodNum
+}
+
+var (
+	abortReasonsByID     map[string]*AbortReason
+	abortReasonsByMethod map[abortKey][]*AbortReason
+)
+
+func init() {
+	abortReasonsByID = make(map[string]*AbortReason, len(abortReasons))
+	abortReasonsByMethod = make(map[abortKey][]*AbortReason)
+	for _, r := range abortReasons {
+		abortReasonsByID[r.ID] = r
+		for _, m := range r.Methods {
+			key := abortKey{r.Actor, m}
+			abortReasonsByMethod[key] = append(abortReasonsByMethod[key], r)
+		}
+	}
+}
+
+// Returns the reason with an identifier.
+func LookupAbortReason(id string) (*AbortReason, bool) {
+	r, ok := abortReasonsByID[id]
+	return r, ok
+}
+
+// Returns the reasons for which a method of the builtin actor with a code CID may abort with an exit code,
+// in the order of their call sites.
+func AbortReasons(code cid.Cid, method abi.MethodNum, exit exitcode.ExitCode) []*AbortReason {
+	var out []*AbortReason
+	for _, r := range abortReasonsByMethod[abortKey{code, method}] {
+		if r.Code == exit {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Returns all reasons for which a method of the builtin actor with a code CID may abort.
+func MethodAbortReasons(code cid.Cid, method abi.MethodNum) []*AbortReason {
+	reasons := abortReasonsByMethod[abortKey{code, method}]
+	out := make([]*AbortReason, len(reasons))
+	copy(out, reasons)
+	return out
+}
+
+// Returns the reason for an abort of a builtin actor's method with an exit code and message,
+// or false if no reason's template matches the message.
+func MatchAbortReason(code cid.Cid, method abi.MethodNum, exit exitcode.ExitCode, message string) (*AbortReason, bool) {
+	for _, r := range AbortReasons(code, method, exit) {
+		if r.Matches(message) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Returns a pattern matching messages formatted from a template, with each format verb matching any text.
+func templatePattern(template string) *regexp.Regexp {
+	var pattern strings.Builder
+	pattern.WriteString("(?s)^")
+	last := 0
+	for _, loc := range templateVerb.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		if template[loc[1]-1] == '%' {
+			pattern.WriteString("%")
+		} else {
+			pattern.WriteString(".*")
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
@@ -0,0 +1,4264 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen/aborts. DO NOT EDIT.
+
+package exported
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+)
+
+var abortReasons = []*AbortReason{
+	{
+		ID:       "account.address-must-use-bls-or-secp-protocol-got",
+		Actor:    builtin.AccountActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsAccount.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "address must use BLS or SECP protocol, got %v",
+		Summary:  "address must use BLS or SECP protocol, got",
+	},
+	{
+		ID:       "account.account-address-must-use-bls-or-secp-protocol",
+		Actor:    builtin.AccountActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsAccount.AuthenticateMessage},
+		Code:     exitcode.ErrIllegalState,
+		Template: "account address must use BLS or SECP protocol, got %v",
+		Summary:  "account address must use BLS or SECP protocol, got",
+	},
+	{
+		ID:       "account.invalid-signature",
+		Actor:    builtin.AccountActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsAccount.AuthenticateMessage},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid signature: %s",
+		Summary:  "invalid signature",
+	},
+	{
+		ID:       "account.no-builtin-actor-with-code",
+		Actor:    builtin.AccountActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "cron.failed-to-construct-state",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct state: %s",
+		Summary:  "failed to construct state",
+	},
+	{
+		ID:       "cron.failed-to-record-tick-report",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.EpochTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to record tick report: %s",
+		Summary:  "failed to record tick report",
+	},
+	{
+		ID:       "cron.failed-to-resolve-receiver-address",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.AddEntry, builtin.MethodsCron.RemoveEntry},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to resolve receiver address %v",
+		Summary:  "failed to resolve receiver address",
+	},
+	{
+		ID:       "cron.invalid-method-number",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.AddEntry},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid method number %d",
+		Summary:  "invalid method number",
+	},
+	{
+		ID:       "cron.negative-gas-budget",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.AddEntry},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative gas budget %d",
+		Summary:  "negative gas budget",
+	},
+	{
+		ID:       "cron.entry-for-method-already-registered",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.AddEntry},
+		Code:     exitcode.ErrForbidden,
+		Template: "entry for %v method %d already registered",
+		Summary:  "entry for method already registered",
+	},
+	{
+		ID:       "cron.too-many-entries-max",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.AddEntry},
+		Code:     exitcode.ErrForbidden,
+		Template: "too many entries %d, max %d",
+		Summary:  "too many entries , max",
+	},
+	{
+		ID:       "cron.no-entry-for-method",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsCron.RemoveEntry},
+		Code:     exitcode.ErrNotFound,
+		Template: "no entry for %v method %d",
+		Summary:  "no entry for method",
+	},
+	{
+		ID:       "cron.no-builtin-actor-with-code",
+		Actor:    builtin.CronActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "init.failed-to-construct-state",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct state: %s",
+		Summary:  "failed to construct state",
+	},
+	{
+		ID:       "init.no-code-for-caller-at",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.Exec, builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no code for caller at %s",
+		Summary:  "no code for caller at",
+	},
+	{
+		ID:       "init.caller-type-cannot-exec-actor-type",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.Exec, builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrForbidden,
+		Template: "caller type %v cannot exec actor type %v",
+		Summary:  "caller type cannot exec actor type",
+	},
+	{
+		ID:       "init.failed-to-allocate-id-address",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.Exec, builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to allocate ID address: %s",
+		Summary:  "failed to allocate ID address",
+	},
+	{
+		ID:       "init.salt-length-must-be-in-1",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "salt length %d must be in [1, %d]",
+		Summary:  "salt length must be in [1, ]",
+	},
+	{
+		ID:       "init.failed-to-derive-actor-address",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to derive actor address: %s",
+		Summary:  "failed to derive actor address",
+	},
+	{
+		ID:       "init.failed-to-resolve-address",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve address %v: %s",
+		Summary:  "failed to resolve address",
+	},
+	{
+		ID:       "init.address-already-in-use-by-creator-with-salt",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsInit.ExecWithSalt},
+		Code:     exitcode.ErrForbidden,
+		Template: "address %v already in use by creator %v with salt %x",
+		Summary:  "address already in use by creator with salt",
+	},
+	{
+		ID:       "init.no-builtin-actor-with-code",
+		Actor:    builtin.InitActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "market.failed-to-create-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to create state: %s",
+		Summary:  "failed to create state",
+	},
+	{
+		ID:       "market.balance-to-add-must-be-greater-than-zero",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "balance to add must be greater than zero",
+		Summary:  "balance to add must be greater than zero",
+	},
+	{
+		ID:       "market.failed-to-load-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance, builtin.MethodsMarket.WithdrawBalance, builtin.MethodsMarket.PublishStorageDeals, builtin.MethodsMarket.ActivateDeals, builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load state: %s",
+		Summary:  "failed to load state",
+	},
+	{
+		ID:       "market.failed-to-add-balance-to-escrow-table",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add balance to escrow table: %s",
+		Summary:  "failed to add balance to escrow table",
+	},
+	{
+		ID:       "market.failed-to-flush-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance, builtin.MethodsMarket.WithdrawBalance, builtin.MethodsMarket.PublishStorageDeals, builtin.MethodsMarket.ActivateDeals, builtin.MethodsMarket.OnMinerSectorsTerminate, builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush state: %s",
+		Summary:  "failed to flush state",
+	},
+	{
+		ID:       "market.failed-to-resolve-address",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance, builtin.MethodsMarket.WithdrawBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to resolve address %v",
+		Summary:  "failed to resolve address",
+	},
+	{
+		ID:       "market.no-code-for-address",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.AddBalance, builtin.MethodsMarket.WithdrawBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no code for address %v",
+		Summary:  "no code for address",
+	},
+	{
+		ID:       "market.negative-amount",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.WithdrawBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative amount %v",
+		Summary:  "negative amount",
+	},
+	{
+		ID:       "market.failed-to-get-locked-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.WithdrawBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get locked balance: %s",
+		Summary:  "failed to get locked balance",
+	},
+	{
+		ID:       "market.failed-to-subtract-from-escrow-table",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.WithdrawBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to subtract from escrow table: %s",
+		Summary:  "failed to subtract from escrow table",
+	},
+	{
+		ID:       "market.empty-deals-parameter",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "empty deals parameter",
+		Summary:  "empty deals parameter",
+	},
+	{
+		ID:       "market.failed-to-resolve-provider-address",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrNotFound,
+		Template: "failed to resolve provider address %v",
+		Summary:  "failed to resolve provider address",
+	},
+	{
+		ID:       "market.no-codeid-for-address",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no codeId for address %v",
+		Summary:  "no codeId for address",
+	},
+	{
+		ID:       "market.deal-provider-is-not-a-storagemineractor",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "deal provider is not a StorageMinerActor",
+		Summary:  "deal provider is not a StorageMinerActor",
+	},
+	{
+		ID:       "market.caller-is-not-worker-or-control-address-of",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrForbidden,
+		Template: "caller %v is not worker or control address of provider %v",
+		Summary:  "caller is not worker or control address of provider",
+	},
+	{
+		ID:       "market.failed-to-check-client-balance-coverage",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to check client balance coverage: %s",
+		Summary:  "failed to check client balance coverage",
+	},
+	{
+		ID:       "market.failed-to-check-provider-balance-coverage",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to check provider balance coverage: %s",
+		Summary:  "failed to check provider balance coverage",
+	},
+	{
+		ID:       "market.failed-to-take-cid-of-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to take cid of proposal %d: %s",
+		Summary:  "failed to take cid of proposal",
+	},
+	{
+		ID:       "market.failed-to-check-for-existence-of-deal-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to check for existence of deal proposal: %s",
+		Summary:  "failed to check for existence of deal proposal",
+	},
+	{
+		ID:       "market.failed-to-count-valid-deals-in-bitfield",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to count valid deals in bitfield: %s",
+		Summary:  "failed to count valid deals in bitfield",
+	},
+	{
+		ID:       "market.valid-deals-but-valid-proposal-cids",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "%d valid deals but %d valid proposal cids",
+		Summary:  "valid deals but valid proposal cids",
+	},
+	{
+		ID:       "market.valid-deals-but-validdealcount",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "%d valid deals but validDealCount=%d",
+		Summary:  "valid deals but validDealCount=",
+	},
+	{
+		ID:       "market.all-deal-proposals-invalid",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "All deal proposals invalid",
+		Summary:  "All deal proposals invalid",
+	},
+	{
+		ID:       "market.failed-to-lock-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to lock balance: %s",
+		Summary:  "failed to lock balance",
+	},
+	{
+		ID:       "market.failed-to-set-pending-deal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set pending deal: %s",
+		Summary:  "failed to set pending deal",
+	},
+	{
+		ID:       "market.failed-to-set-deal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set deal: %s",
+		Summary:  "failed to set deal",
+	},
+	{
+		ID:       "market.failed-to-set-deal-ops-by-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set deal ops by epoch: %s",
+		Summary:  "failed to set deal ops by epoch",
+	},
+	{
+		ID:       "market.failed-to-record-deal-price",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to record deal price: %s",
+		Summary:  "failed to record deal price",
+	},
+	{
+		ID:       "market.failed-to-prune-deal-prices",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.PublishStorageDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to prune deal prices: %s",
+		Summary:  "failed to prune deal prices",
+	},
+	{
+		ID:       "market.failed-to-load-deal-proposals",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.VerifyDealsForActivation, builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal proposals: %s",
+		Summary:  "failed to load deal proposals",
+	},
+	{
+		ID:       "market.failed-to-validate-deal-proposals-for-activation",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.VerifyDealsForActivation},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to validate deal proposals for activation: %s",
+		Summary:  "failed to validate deal proposals for activation",
+	},
+	{
+		ID:       "market.failed-to-validate-dealproposals-for-activation",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to validate dealProposals for activation: %s",
+		Summary:  "failed to validate dealProposals for activation",
+	},
+	{
+		ID:       "market.failed-to-get-state-for-dealid",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get state for dealId %d: %s",
+		Summary:  "failed to get state for dealId",
+	},
+	{
+		ID:       "market.deal-already-included-in-another-sector",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "deal %d already included in another sector",
+		Summary:  "deal already included in another sector",
+	},
+	{
+		ID:       "market.failed-to-get-dealid",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals, builtin.MethodsMarket.ComputeDataCommitment, builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get dealId %d: %s",
+		Summary:  "failed to get dealId",
+	},
+	{
+		ID:       "market.failed-to-calculate-proposal-cid",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to calculate proposal CID: %s",
+		Summary:  "failed to calculate proposal CID",
+	},
+	{
+		ID:       "market.failed-to-get-pending-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get pending proposal %v: %s",
+		Summary:  "failed to get pending proposal",
+	},
+	{
+		ID:       "market.tried-to-activate-deal-that-was-not-in",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "tried to activate deal that was not in the pending set (%s)",
+		Summary:  "tried to activate deal that was not in the pending set ( )",
+	},
+	{
+		ID:       "market.failed-to-set-deal-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ActivateDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set deal state %d: %s",
+		Summary:  "failed to set deal state",
+	},
+	{
+		ID:       "market.failed-to-load-deal-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal state: %s",
+		Summary:  "failed to load deal state",
+	},
+	{
+		ID:       "market.failed-to-get-deal-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get deal proposal %v: %s",
+		Summary:  "failed to get deal proposal",
+	},
+	{
+		ID:       "market.caller-is-not-the-provider-of-deal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "caller %v is not the provider %v of deal %v",
+		Summary:  "caller is not the provider of deal",
+	},
+	{
+		ID:       "market.failed-to-get-deal-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get deal state %v: %s",
+		Summary:  "failed to get deal state",
+	},
+	{
+		ID:       "market.no-state-for-deal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no state for deal %v",
+		Summary:  "no state for deal",
+	},
+	{
+		ID:       "market.failed-to-set-deal-state-2",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.OnMinerSectorsTerminate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set deal state %v: %s",
+		Summary:  "failed to set deal state",
+	},
+	{
+		ID:       "market.failed-to-load-deal-dealproposals",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ComputeDataCommitment},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal dealProposals: %s",
+		Summary:  "failed to load deal dealProposals",
+	},
+	{
+		ID:       "market.failed-to-compute-unsealed-sectorcid",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.ComputeDataCommitment},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to compute unsealed sectorCID: %s: %s",
+		Summary:  "failed to compute unsealed sectorCID",
+	},
+	{
+		ID:       "market.failed-to-calculate-cid-for-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to calculate CID for proposal %v: %s",
+		Summary:  "failed to calculate CID for proposal",
+	},
+	{
+		ID:       "market.failed-to-get-deal-state-2",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get deal state: %s",
+		Summary:  "failed to get deal state",
+	},
+	{
+		ID:       "market.deal-processed-before-start-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "deal %d processed before start epoch %d",
+		Summary:  "deal processed before start epoch",
+	},
+	{
+		ID:       "market.failed-to-delete-deal-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete deal proposal %d: %s",
+		Summary:  "failed to delete deal proposal",
+	},
+	{
+		ID:       "market.failed-to-delete-pending-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete pending proposal %d (%v): %s",
+		Summary:  "failed to delete pending proposal ( )",
+	},
+	{
+		ID:       "market.failed-to-delete-pending-proposal-2",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete pending proposal %v: %s",
+		Summary:  "failed to delete pending proposal",
+	},
+	{
+		ID:       "market.computed-negative-slash-amount-for-deal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "computed negative slash amount %v for deal %d",
+		Summary:  "computed negative slash amount for deal",
+	},
+	{
+		ID:       "market.removed-deal-should-have-no-scheduled-epoch-got",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "removed deal %d should have no scheduled epoch (got %d)",
+		Summary:  "removed deal should have no scheduled epoch (got )",
+	},
+	{
+		ID:       "market.failed-to-delete-deal-state",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete deal state %d: %s",
+		Summary:  "failed to delete deal state",
+	},
+	{
+		ID:       "market.continuing-deal-next-epoch-should-be-in-future",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "continuing deal %d next epoch %d should be in future",
+		Summary:  "continuing deal next epoch should be in future",
+	},
+	{
+		ID:       "market.continuing-deal-should-not-be-slashed",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "continuing deal %d should not be slashed",
+		Summary:  "continuing deal should not be slashed",
+	},
+	{
+		ID:       "market.failed-to-set-deal-state-3",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to set deal state: %s",
+		Summary:  "failed to set deal state",
+	},
+	{
+		ID:       "market.failed-to-iterate-deal-ops",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to iterate deal ops: %s",
+		Summary:  "failed to iterate deal ops",
+	},
+	{
+		ID:       "market.failed-to-delete-deal-ops-for-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete deal ops for epoch %v: %s",
+		Summary:  "failed to delete deal ops for epoch",
+	},
+	{
+		ID:       "market.failed-to-reinsert-deal-ids-for-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to reinsert deal IDs for epoch %v: %s",
+		Summary:  "failed to reinsert deal IDs for epoch",
+	},
+	{
+		ID:       "market.failure-unlocking-client-storage-fee",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failure unlocking client storage fee: %s",
+		Summary:  "failure unlocking client storage fee",
+	},
+	{
+		ID:       "market.failure-unlocking-client-collateral",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failure unlocking client collateral: %s",
+		Summary:  "failure unlocking client collateral",
+	},
+	{
+		ID:       "market.failed-to-slash-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to slash balance: %s",
+		Summary:  "failed to slash balance",
+	},
+	{
+		ID:       "market.failed-to-unlock-deal-provider-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unlock deal provider balance: %s",
+		Summary:  "failed to unlock deal provider balance",
+	},
+	{
+		ID:       "market.deal-updated-at-future-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "deal updated at future epoch %d",
+		Summary:  "deal updated at future epoch",
+	},
+	{
+		ID:       "market.current-epoch-less-than-deal-slash-epoch",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "current epoch less than deal slash epoch %d",
+		Summary:  "current epoch less than deal slash epoch",
+	},
+	{
+		ID:       "market.deal-slash-epoch-after-deal-end",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "deal slash epoch %d after deal end %d",
+		Summary:  "deal slash epoch after deal end",
+	},
+	{
+		ID:       "market.failed-to-transfer-from-to",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to transfer %v from %v to %v: %s",
+		Summary:  "failed to transfer from to",
+	},
+	{
+		ID:       "market.failed-to-compute-remaining-payment",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to compute remaining payment: %s",
+		Summary:  "failed to compute remaining payment",
+	},
+	{
+		ID:       "market.failed-to-unlock-remaining-client-storage-fee",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unlock remaining client storage fee: %s",
+		Summary:  "failed to unlock remaining client storage fee",
+	},
+	{
+		ID:       "market.failed-to-unlock-client-collateral",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unlock client collateral: %s",
+		Summary:  "failed to unlock client collateral",
+	},
+	{
+		ID:       "market.slashing-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "slashing balance: %s",
+		Summary:  "slashing balance",
+	},
+	{
+		ID:       "market.sector-start-epoch-undefined",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "sector start epoch undefined",
+		Summary:  "sector start epoch undefined",
+	},
+	{
+		ID:       "market.failed-unlocking-deal-provider-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed unlocking deal provider balance: %s",
+		Summary:  "failed unlocking deal provider balance",
+	},
+	{
+		ID:       "market.failed-unlocking-deal-client-balance",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed unlocking deal client balance: %s",
+		Summary:  "failed unlocking deal client balance",
+	},
+	{
+		ID:       "market.failed-to-summarize-deal-prices",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDealPriceStats},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to summarize deal prices: %s",
+		Summary:  "failed to summarize deal prices",
+	},
+	{
+		ID:       "market.batch-empty",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch empty",
+		Summary:  "batch empty",
+	},
+	{
+		ID:       "market.batch-of-too-large-max",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch of %d too large, max %d",
+		Summary:  "batch of too large, max",
+	},
+	{
+		ID:       "market.failed-to-load-deal-states",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal states: %s",
+		Summary:  "failed to load deal states",
+	},
+	{
+		ID:       "market.failed-to-load-deal-proposal",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal proposal %d: %s",
+		Summary:  "failed to load deal proposal",
+	},
+	{
+		ID:       "market.failed-to-load-deal-state-2",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMarket.GetDeals},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deal state %d: %s",
+		Summary:  "failed to load deal state",
+	},
+	{
+		ID:       "market.no-builtin-actor-with-code",
+		Actor:    builtin.StorageMarketActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "miner.proof-type-not-allowed-for-new-miner-actors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "proof type %d not allowed for new miner actors",
+		Summary:  "proof type not allowed for new miner actors",
+	},
+	{
+		ID:       "miner.failed-to-assign-proving-period-offset",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrSerialization,
+		Template: "failed to assign proving period offset: %s",
+		Summary:  "failed to assign proving period offset",
+	},
+	{
+		ID:       "miner.computed-proving-period-start-after-current-epoch",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "computed proving period start %d after current epoch %d",
+		Summary:  "computed proving period start after current epoch",
+	},
+	{
+		ID:       "miner.computed-proving-deadline-index-invalid",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "computed proving deadline index %d invalid",
+		Summary:  "computed proving deadline index invalid",
+	},
+	{
+		ID:       "miner.failed-to-construct-initial-miner-info",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct initial miner info: %s",
+		Summary:  "failed to construct initial miner info",
+	},
+	{
+		ID:       "miner.failed-to-construct-state",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct state: %s",
+		Summary:  "failed to construct state",
+	},
+	{
+		ID:       "miner.control-addresses-length-exceeds-max-control-addresses-length",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "control addresses length %d exceeds max control addresses length %d",
+		Summary:  "control addresses length exceeds max control addresses length",
+	},
+	{
+		ID:       "miner.peer-id-size-of-exceeds-maximum-size-of",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.ChangeMultiaddrs},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "peer ID size of %d exceeds maximum size of %d",
+		Summary:  "peer ID size of exceeds maximum size of",
+	},
+	{
+		ID:       "miner.invalid-empty-multiaddr",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.ChangeMultiaddrs},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid empty multiaddr",
+		Summary:  "invalid empty multiaddr",
+	},
+	{
+		ID:       "miner.multiaddr-size-of-exceeds-maximum-of",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.ChangeMultiaddrs},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "multiaddr size of %d exceeds maximum of %d",
+		Summary:  "multiaddr size of exceeds maximum of",
+	},
+	{
+		ID:       "miner.unable-to-resolve-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress, builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unable to resolve address %v",
+		Summary:  "unable to resolve address",
+	},
+	{
+		ID:       "miner.no-code-for-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no code for address %v",
+		Summary:  "no code for address",
+	},
+	{
+		ID:       "miner.owner-actor-type-must-be-a-principal-was",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "owner actor type must be a principal, was %v",
+		Summary:  "owner actor type must be a principal, was",
+	},
+	{
+		ID:       "miner.worker-actor-type-must-be-an-account-was",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "worker actor type must be an account, was %v",
+		Summary:  "worker actor type must be an account, was",
+	},
+	{
+		ID:       "miner.worker-account-must-have-bls-pubkey-was",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.Constructor, builtin.MethodsMiner.ChangeWorkerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "worker account %v must have BLS pubkey, was %v",
+		Summary:  "worker account must have BLS pubkey, was",
+	},
+	{
+		ID:       "miner.could-not-read-miner-info",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ControlAddresses, builtin.MethodsMiner.ChangeWorkerAddress, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ReportConsensusFault, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ChangeMultiaddrs, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.CompactSectorNumbers, builtin.MethodsMiner.ConfirmUpdateWorkerKey, builtin.MethodsMiner.RepayDebt, builtin.MethodsMiner.ChangeOwnerAddress, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.ProveReplicaUpdates, builtin.MethodsMiner.ChangeBeneficiary, builtin.MethodsMiner.GetBeneficiary, builtin.MethodsMiner.PauseMiner, builtin.MethodsMiner.UnpauseMiner, builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalState,
+		Template: "could not read miner info: %s",
+		Summary:  "could not read miner info",
+	},
+	{
+		ID:       "miner.could-not-save-miner-info",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeWorkerAddress, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.ChangeMultiaddrs, builtin.MethodsMiner.ConfirmUpdateWorkerKey, builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalState,
+		Template: "could not save miner info: %s",
+		Summary:  "could not save miner info",
+	},
+	{
+		ID:       "miner.miner-is-paused",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeWorkerAddress, builtin.MethodsMiner.ChangePeerID, builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ProveCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.ChangeMultiaddrs, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.CompactSectorNumbers, builtin.MethodsMiner.ConfirmUpdateWorkerKey, builtin.MethodsMiner.RepayDebt, builtin.MethodsMiner.ChangeOwnerAddress, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.ProveReplicaUpdates, builtin.MethodsMiner.ChangeBeneficiary, builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     miner.ErrMinerPaused,
+		Template: "miner is paused",
+		Summary:  "miner is paused",
+	},
+	{
+		ID:       "miner.expected-exactly-one-proof-got",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected exactly one proof, got %d",
+		Summary:  "expected exactly one proof, got",
+	},
+	{
+		ID:       "miner.proof-type-not-allowed",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "proof type %d not allowed",
+		Summary:  "proof type not allowed",
+	},
+	{
+		ID:       "miner.invalid-deadline-of",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid deadline %d of %d",
+		Summary:  "invalid deadline of",
+	},
+	{
+		ID:       "miner.expected-at-most-bytes-of-randomness-got",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected at most %d bytes of randomness, got %d",
+		Summary:  "expected at most bytes of randomness, got",
+	},
+	{
+		ID:       "miner.failed-to-determine-max-window-post-proof-size",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to determine max window post proof size: %s",
+		Summary:  "failed to determine max window post proof size",
+	},
+	{
+		ID:       "miner.expected-proof-of-type-got-proof-of-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected proof of type %d, got proof of type %d",
+		Summary:  "expected proof of type , got proof of type",
+	},
+	{
+		ID:       "miner.expected-proof-to-be-smaller-than-bytes",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected proof to be smaller than %d bytes",
+		Summary:  "expected proof to be smaller than bytes",
+	},
+	{
+		ID:       "miner.too-many-partitions-limit",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many partitions %d, limit %d",
+		Summary:  "too many partitions , limit",
+	},
+	{
+		ID:       "miner.proving-period-not-yet-open-at",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "proving period %d not yet open at %d",
+		Summary:  "proving period not yet open at",
+	},
+	{
+		ID:       "miner.invalid-deadline-at-epoch-expected",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid deadline %d at epoch %d, expected %d",
+		Summary:  "invalid deadline at epoch , expected",
+	},
+	{
+		ID:       "miner.expected-chain-commit-epoch-to-be-after",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected chain commit epoch %d to be after %d",
+		Summary:  "expected chain commit epoch to be after",
+	},
+	{
+		ID:       "miner.chain-commit-epoch-must-be-less-than-the",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "chain commit epoch %d must be less than the current epoch %d",
+		Summary:  "chain commit epoch must be less than the current epoch",
+	},
+	{
+		ID:       "miner.post-commit-randomness-mismatched",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "post commit randomness mismatched",
+		Summary:  "post commit randomness mismatched",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.TerminateSectors},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors: %s",
+		Summary:  "failed to load sectors",
+	},
+	{
+		ID:       "miner.failed-to-load-deadlines",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deadlines: %s",
+		Summary:  "failed to load deadlines",
+	},
+	{
+		ID:       "miner.failed-to-load-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deadline %d: %s",
+		Summary:  "failed to load deadline",
+	},
+	{
+		ID:       "miner.failed-to-process-post-submission-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to process post submission for deadline %d: %s",
+		Summary:  "failed to process post submission for deadline",
+	},
+	{
+		ID:       "miner.failed-to-determine-proven-sectors-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to determine proven sectors for deadline %d: %s",
+		Summary:  "failed to determine proven sectors for deadline",
+	},
+	{
+		ID:       "miner.failed-to-determine-if-any-sectors-were-proven",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to determine if any sectors were proven: %s",
+		Summary:  "failed to determine if any sectors were proven",
+	},
+	{
+		ID:       "miner.cannot-prove-partitions-with-no-active-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot prove partitions with no active sectors",
+		Summary:  "cannot prove partitions with no active sectors",
+	},
+	{
+		ID:       "miner.failed-to-record-proof-for-optimistic-verification",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to record proof for optimistic verification: %s",
+		Summary:  "failed to record proof for optimistic verification",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-for-post-verification",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors for post verification: %s",
+		Summary:  "failed to load sectors for post verification",
+	},
+	{
+		ID:       "miner.window-post-failed",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "window post failed: %s",
+		Summary:  "window post failed",
+	},
+	{
+		ID:       "miner.failed-to-update-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update deadline %d: %s",
+		Summary:  "failed to update deadline",
+	},
+	{
+		ID:       "miner.failed-to-save-deadlines",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.CompactPartitions, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save deadlines: %s",
+		Summary:  "failed to save deadlines",
+	},
+	{
+		ID:       "miner.balance-invariants-broken",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ApplyRewards, builtin.MethodsMiner.ReportConsensusFault, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.RepayDebt, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     miner.ErrBalanceInvariantBroken,
+		Template: "balance invariants broken: %s",
+		Summary:  "balance invariants broken",
+	},
+	{
+		ID:       "miner.runtime-provided-bad-receiver-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "runtime provided bad receiver address %v: %s",
+		Summary:  "runtime provided bad receiver address",
+	},
+	{
+		ID:       "miner.failed-to-marshal-address-for-randomness-entropy",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.SubmitWindowedPoSt, builtin.MethodsMiner.ProveCommitSector, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrSerialization,
+		Template: "failed to marshal address %v for randomness entropy: %s",
+		Summary:  "failed to marshal address for randomness entropy",
+	},
+	{
+		ID:       "miner.batch-empty",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch empty",
+		Summary:  "batch empty",
+	},
+	{
+		ID:       "miner.batch-of-too-large-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch of %d too large, max %d",
+		Summary:  "batch of too large, max",
+	},
+	{
+		ID:       "miner.error-checking-sector-number",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "error checking sector number: %s",
+		Summary:  "error checking sector number",
+	},
+	{
+		ID:       "miner.duplicate-sector-number",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "duplicate sector number %d",
+		Summary:  "duplicate sector number",
+	},
+	{
+		ID:       "miner.unsupported-seal-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unsupported seal proof type %v",
+		Summary:  "unsupported seal proof type",
+	},
+	{
+		ID:       "miner.sector-number-out-of-range-0-2-63",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector number %d out of range 0..(2^63-1)",
+		Summary:  "sector number out of range 0..(2^63-1)",
+	},
+	{
+		ID:       "miner.sealed-cid-undefined",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sealed CID undefined",
+		Summary:  "sealed CID undefined",
+	},
+	{
+		ID:       "miner.sealed-cid-had-wrong-prefix",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sealed CID had wrong prefix",
+		Summary:  "sealed CID had wrong prefix",
+	},
+	{
+		ID:       "miner.seal-challenge-epoch-must-be-before-now",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "seal challenge epoch %v must be before now %v",
+		Summary:  "seal challenge epoch must be before now",
+	},
+	{
+		ID:       "miner.seal-challenge-epoch-too-old-must-be-after",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "seal challenge epoch %v too old, must be after %v",
+		Summary:  "seal challenge epoch too old, must be after",
+	},
+	{
+		ID:       "miner.cc-upgrade-through-precommit-discontinued-use-lightweight-cc",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.SysErrForbidden,
+		Template: "cc upgrade through precommit discontinued, use lightweight cc upgrade instead",
+		Summary:  "cc upgrade through precommit discontinued, use lightweight cc upgrade instead",
+	},
+	{
+		ID:       "miner.deal-weight-request-returned-records-expected",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "deal weight request returned %d records, expected %d",
+		Summary:  "deal weight request returned records, expected",
+	},
+	{
+		ID:       "miner.failed-to-apply-penalty",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ApplyRewards, builtin.MethodsMiner.ReportConsensusFault, builtin.MethodsMiner.DisputeWindowedPoSt, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to apply penalty: %s",
+		Summary:  "failed to apply penalty",
+	},
+	{
+		ID:       "miner.failed-to-calculate-available-balance",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to calculate available balance: %s",
+		Summary:  "failed to calculate available balance",
+	},
+	{
+		ID:       "miner.pre-commit-not-allowed-during-active-consensus-fault",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrForbidden,
+		Template: "pre-commit not allowed during active consensus fault",
+		Summary:  "pre-commit not allowed during active consensus fault",
+	},
+	{
+		ID:       "miner.failed-to-lookup-window-post-proof-type-for",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to lookup Window PoSt proof type for sector seal proof %d: %s",
+		Summary:  "failed to lookup Window PoSt proof type for sector seal proof",
+	},
+	{
+		ID:       "miner.sector-window-post-proof-type-must-match-miner",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector Window PoSt proof type %d must match miner Window PoSt proof type %d (seal proof type %d)",
+		Summary:  "sector Window PoSt proof type must match miner Window PoSt proof type (seal proof type )",
+	},
+	{
+		ID:       "miner.too-many-deals-for-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many deals for sector %d > %d",
+		Summary:  "too many deals for sector >",
+	},
+	{
+		ID:       "miner.deals-too-large-to-fit-in-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "deals too large to fit in sector %d > %d",
+		Summary:  "deals too large to fit in sector >",
+	},
+	{
+		ID:       "miner.no-max-seal-duration-set-for-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no max seal duration set for proof type: %d",
+		Summary:  "no max seal duration set for proof type",
+	},
+	{
+		ID:       "miner.insufficient-funds-for-pre-commit-deposit",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "insufficient funds %v for pre-commit deposit: %v",
+		Summary:  "insufficient funds for pre-commit deposit",
+	},
+	{
+		ID:       "miner.failed-to-add-pre-commit-deposit",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.PreCommitSectorBatch, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add pre-commit deposit %v: %s",
+		Summary:  "failed to add pre-commit deposit",
+	},
+	{
+		ID:       "miner.failed-to-allocate-sector-ids",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to allocate sector ids %v: %s",
+		Summary:  "failed to allocate sector ids",
+	},
+	{
+		ID:       "miner.failed-to-write-pre-committed-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to write pre-committed sectors: %s",
+		Summary:  "failed to write pre-committed sectors",
+	},
+	{
+		ID:       "miner.failed-to-add-pre-commit-expiry-to-queue",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add pre-commit expiry to queue: %s",
+		Summary:  "failed to add pre-commit expiry to queue",
+	},
+	{
+		ID:       "miner.sector-expiration-must-be-after-activation",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector expiration %v must be after activation (%v)",
+		Summary:  "sector expiration must be after activation ( )",
+	},
+	{
+		ID:       "miner.invalid-expiration-total-sector-lifetime-must-exceed-after",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid expiration %d, total sector lifetime (%d) must exceed %d after activation %d",
+		Summary:  "invalid expiration , total sector lifetime ( ) must exceed after activation",
+	},
+	{
+		ID:       "miner.invalid-expiration-cannot-be-more-than-past-current",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid expiration %d, cannot be more than %d past current epoch %d",
+		Summary:  "invalid expiration , cannot be more than past current epoch",
+	},
+	{
+		ID:       "miner.unrecognized-seal-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unrecognized seal proof type %d: %s",
+		Summary:  "unrecognized seal proof type",
+	},
+	{
+		ID:       "miner.invalid-expiration-total-sector-lifetime-cannot-exceed-after",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid expiration %d, total sector lifetime (%d) cannot exceed %d after activation %d",
+		Summary:  "invalid expiration , total sector lifetime ( ) cannot exceed after activation",
+	},
+	{
+		ID:       "miner.unlocked-balance-can-not-repay-fee-debt",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.WithdrawBalance, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalState,
+		Template: "unlocked balance can not repay fee debt: %s",
+		Summary:  "unlocked balance can not repay fee debt",
+	},
+	{
+		ID:       "miner.failed-to-serialize-payload",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PreCommitSector, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.PreCommitSectorBatch},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to serialize payload: %v",
+		Summary:  "failed to serialize payload",
+	},
+	{
+		ID:       "miner.sector-number-greater-than-maximum",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector number greater than maximum",
+		Summary:  "sector number greater than maximum",
+	},
+	{
+		ID:       "miner.failed-to-load-pre-committed-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load pre-committed sector %v: %s",
+		Summary:  "failed to load pre-committed sector",
+	},
+	{
+		ID:       "miner.no-pre-committed-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrNotFound,
+		Template: "no pre-committed sector %v",
+		Summary:  "no pre-committed sector",
+	},
+	{
+		ID:       "miner.failed-to-determine-max-proof-size-for-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to determine max proof size for sector %v: %s",
+		Summary:  "failed to determine max proof size for sector",
+	},
+	{
+		ID:       "miner.sector-prove-commit-proof-of-size-exceeds-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector prove-commit proof of size %d exceeds max size of %d",
+		Summary:  "sector prove-commit proof of size exceeds max size of",
+	},
+	{
+		ID:       "miner.no-max-seal-duration-for-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no max seal duration for proof type: %d",
+		Summary:  "no max seal duration for proof type",
+	},
+	{
+		ID:       "miner.commitment-proof-for-too-late-at-due",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "commitment proof for %d too late at %d, due %d",
+		Summary:  "commitment proof for too late at , due",
+	},
+	{
+		ID:       "miner.too-early-to-prove-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrForbidden,
+		Template: "too early to prove sector",
+		Summary:  "too early to prove sector",
+	},
+	{
+		ID:       "miner.runtime-provided-non-id-receiver-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector},
+		Code:     exitcode.ErrIllegalState,
+		Template: "runtime provided non-ID receiver address %v: %s",
+		Summary:  "runtime provided non-ID receiver address",
+	},
+	{
+		ID:       "miner.number-of-data-commitments-computed-does-not-match",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitSector, builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "number of data commitments computed %d does not match number of data commitment inputs %d",
+		Summary:  "number of data commitments computed does not match number of data commitment inputs",
+	},
+	{
+		ID:       "miner.too-many-declarations-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many declarations %d, max %d",
+		Summary:  "too many declarations , max",
+	},
+	{
+		ID:       "miner.deadline-not-in-range-0",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "deadline %d not in range 0..%d",
+		Summary:  "deadline not in range 0",
+	},
+	{
+		ID:       "miner.failed-to-count-sectors-for-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to count sectors for deadline %d, partition %d: %s",
+		Summary:  "failed to count sectors for deadline , partition",
+	},
+	{
+		ID:       "miner.sector-bitfield-integer-overflow",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector bitfield integer overflow",
+		Summary:  "sector bitfield integer overflow",
+	},
+	{
+		ID:       "miner.too-many-sectors-for-declaration-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many sectors for declaration %d, max %d",
+		Summary:  "too many sectors for declaration , max",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-array",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors array: %s",
+		Summary:  "failed to load sectors array",
+	},
+	{
+		ID:       "miner.failed-to-load-partitions-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load partitions for deadline %d: %s",
+		Summary:  "failed to load partitions for deadline",
+	},
+	{
+		ID:       "miner.failed-to-load-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deadline %v partition %v: %s",
+		Summary:  "failed to load deadline partition",
+	},
+	{
+		ID:       "miner.no-such-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrNotFound,
+		Template: "no such deadline %v partition %v",
+		Summary:  "no such deadline partition",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-in-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors in deadline %v partition %v: %s",
+		Summary:  "failed to load sectors in deadline partition",
+	},
+	{
+		ID:       "miner.cannot-extend-expiration-for-sector-with-unsupported-seal",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot extend expiration for sector %v with unsupported seal type %v",
+		Summary:  "cannot extend expiration for sector with unsupported seal type",
+	},
+	{
+		ID:       "miner.cannot-extend-expiration-for-expired-sector-expired-at",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot extend expiration for expired sector %v, expired at %d, now %d",
+		Summary:  "cannot extend expiration for expired sector , expired at , now",
+	},
+	{
+		ID:       "miner.cannot-reduce-sector-s-expiration-to-from",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot reduce sector %v's expiration to %d from %d",
+		Summary:  "cannot reduce sector 's expiration to from",
+	},
+	{
+		ID:       "miner.failed-to-update-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update sectors %v: %s",
+		Summary:  "failed to update sectors",
+	},
+	{
+		ID:       "miner.failed-to-replace-sector-expirations-at-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to replace sector expirations at deadline %v partition %v: %s",
+		Summary:  "failed to replace sector expirations at deadline partition",
+	},
+	{
+		ID:       "miner.failed-to-save-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save deadline %v partition %v: %s",
+		Summary:  "failed to save deadline partition",
+	},
+	{
+		ID:       "miner.failed-to-save-partitions-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save partitions for deadline %d: %s",
+		Summary:  "failed to save partitions for deadline",
+	},
+	{
+		ID:       "miner.failed-to-add-expiration-partitions-to-deadline-epoch",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add expiration partitions to deadline %v epoch %v: %v: %s",
+		Summary:  "failed to add expiration partitions to deadline epoch",
+	},
+	{
+		ID:       "miner.failed-to-save-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save deadline %d: %s",
+		Summary:  "failed to save deadline",
+	},
+	{
+		ID:       "miner.failed-to-save-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ExtendSectorExpiration, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save sectors: %s",
+		Summary:  "failed to save sectors",
+	},
+	{
+		ID:       "miner.too-many-declarations-when-terminating-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many declarations when terminating sectors: %d > %d",
+		Summary:  "too many declarations when terminating sectors: >",
+	},
+	{
+		ID:       "miner.failed-to-process-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to process deadline %d, partition %d: %s",
+		Summary:  "failed to process deadline , partition",
+	},
+	{
+		ID:       "miner.cannot-process-requested-parameters",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaults, builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot process requested parameters: %s",
+		Summary:  "cannot process requested parameters",
+	},
+	{
+		ID:       "miner.cannot-terminate-sectors-in-immutable-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot terminate sectors in immutable deadline %d",
+		Summary:  "cannot terminate sectors in immutable deadline",
+	},
+	{
+		ID:       "miner.failed-to-terminate-sectors-in-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to terminate sectors in deadline %d: %s",
+		Summary:  "failed to terminate sectors in deadline",
+	},
+	{
+		ID:       "miner.failed-to-walk-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to walk sectors: %s",
+		Summary:  "failed to walk sectors",
+	},
+	{
+		ID:       "miner.failed-to-count-early-terminations",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to count early terminations: %s",
+		Summary:  "failed to count early terminations",
+	},
+	{
+		ID:       "miner.failed-to-pop-early-terminations",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to pop early terminations: %s",
+		Summary:  "failed to pop early terminations",
+	},
+	{
+		ID:       "miner.failed-to-load-sector-infos",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sector infos: %s",
+		Summary:  "failed to load sector infos",
+	},
+	{
+		ID:       "miner.failed-to-process-terminations",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to process terminations: %s",
+		Summary:  "failed to process terminations",
+	},
+	{
+		ID:       "miner.failed-to-add-initial-pledge",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add initial pledge %v: %s",
+		Summary:  "failed to add initial pledge",
+	},
+	{
+		ID:       "miner.failed-to-pay-penalty",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to pay penalty: %s",
+		Summary:  "failed to pay penalty",
+	},
+	{
+		ID:       "miner.failed-to-emit-termination-events",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to emit termination events: %s",
+		Summary:  "failed to emit termination events",
+	},
+	{
+		ID:       "miner.failed-to-emit-events",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.TerminateSectors, builtin.MethodsMiner.OnDeferredCronEvent, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to emit %s events: %s",
+		Summary:  "failed to emit events",
+	},
+	{
+		ID:       "miner.too-many-fault-declarations-for-a-single-message",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many fault declarations for a single message: %d > %d",
+		Summary:  "too many fault declarations for a single message: >",
+	},
+	{
+		ID:       "miner.invalid-fault-declaration-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid fault declaration deadline %d: %s",
+		Summary:  "invalid fault declaration deadline",
+	},
+	{
+		ID:       "miner.failed-fault-declaration-at-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed fault declaration at deadline %d: %s",
+		Summary:  "failed fault declaration at deadline",
+	},
+	{
+		ID:       "miner.failed-to-declare-faults-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to declare faults for deadline %d: %s",
+		Summary:  "failed to declare faults for deadline",
+	},
+	{
+		ID:       "miner.failed-to-store-deadline-partitions",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to store deadline %d partitions: %s",
+		Summary:  "failed to store deadline partitions",
+	},
+	{
+		ID:       "miner.failed-to-iterate-deadlines",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaults},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to iterate deadlines: %s",
+		Summary:  "failed to iterate deadlines",
+	},
+	{
+		ID:       "miner.too-many-recovery-declarations-for-a-single-message",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many recovery declarations for a single message: %d > %d",
+		Summary:  "too many recovery declarations for a single message: >",
+	},
+	{
+		ID:       "miner.recovery-not-allowed-during-active-consensus-fault",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrForbidden,
+		Template: "recovery not allowed during active consensus fault",
+		Summary:  "recovery not allowed during active consensus fault",
+	},
+	{
+		ID:       "miner.invalid-recovery-declaration-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid recovery declaration deadline %d: %s",
+		Summary:  "invalid recovery declaration deadline",
+	},
+	{
+		ID:       "miner.failed-recovery-declaration-at-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed recovery declaration at deadline %d: %s",
+		Summary:  "failed recovery declaration at deadline",
+	},
+	{
+		ID:       "miner.failed-to-declare-recoveries-for-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to declare recoveries for deadline %d: %s",
+		Summary:  "failed to declare recoveries for deadline",
+	},
+	{
+		ID:       "miner.failed-to-store-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareFaultsRecovered},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to store deadline %d: %s",
+		Summary:  "failed to store deadline",
+	},
+	{
+		ID:       "miner.failed-to-unmarshal-miner-cron-payload-into-expected",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unmarshal miner cron payload into expected structure: %s",
+		Summary:  "failed to unmarshal miner cron payload into expected structure",
+	},
+	{
+		ID:       "miner.failed-to-vest-funds",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to vest funds: %s",
+		Summary:  "failed to vest funds",
+	},
+	{
+		ID:       "miner.failed-to-expire-pre-committed-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to expire pre-committed sectors: %s",
+		Summary:  "failed to expire pre-committed sectors",
+	},
+	{
+		ID:       "miner.failed-to-advance-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to advance deadline: %s",
+		Summary:  "failed to advance deadline",
+	},
+	{
+		ID:       "miner.failed-to-unlock-penalty",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unlock penalty: %s",
+		Summary:  "failed to unlock penalty",
+	},
+	{
+		ID:       "miner.failed-to-rebalance-partitions",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.OnDeferredCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to rebalance partitions: %s",
+		Summary:  "failed to rebalance partitions",
+	},
+	{
+		ID:       "miner.sector-number-out-of-range",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CheckSectorProven},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector number out of range",
+		Summary:  "sector number out of range",
+	},
+	{
+		ID:       "miner.failed-to-load-proven-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CheckSectorProven},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load proven sector %v",
+		Summary:  "failed to load proven sector",
+	},
+	{
+		ID:       "miner.sector-not-proven",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CheckSectorProven},
+		Code:     exitcode.ErrNotFound,
+		Template: "sector %v not proven",
+		Summary:  "sector not proven",
+	},
+	{
+		ID:       "miner.cannot-lock-up-a-negative-amount-of-funds",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot lock up a negative amount of funds",
+		Summary:  "cannot lock up a negative amount of funds",
+	},
+	{
+		ID:       "miner.cannot-penalize-a-negative-amount-of-funds",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot penalize a negative amount of funds",
+		Summary:  "cannot penalize a negative amount of funds",
+	},
+	{
+		ID:       "miner.failed-to-calculate-unlocked-balance",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards, builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to calculate unlocked balance: %s",
+		Summary:  "failed to calculate unlocked balance",
+	},
+	{
+		ID:       "miner.insufficient-funds-to-lock-available-requested",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "insufficient funds to lock, available: %v, requested: %v",
+		Summary:  "insufficient funds to lock, available: , requested",
+	},
+	{
+		ID:       "miner.failed-to-lock-funds-in-vesting-table",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to lock funds in vesting table: %s",
+		Summary:  "failed to lock funds in vesting table",
+	},
+	{
+		ID:       "miner.failed-to-repay-penalty",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ApplyRewards},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to repay penalty: %s",
+		Summary:  "failed to repay penalty",
+	},
+	{
+		ID:       "miner.fault-not-verified",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "fault not verified: %s",
+		Summary:  "fault not verified",
+	},
+	{
+		ID:       "miner.fault-by-reported-to-miner",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "fault by %v reported to miner %v",
+		Summary:  "fault by reported to miner",
+	},
+	{
+		ID:       "miner.invalid-fault-epoch-ahead-of-current",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid fault epoch %v ahead of current %v",
+		Summary:  "invalid fault epoch ahead of current",
+	},
+	{
+		ID:       "miner.fault-epoch-is-too-old-last-exclusion-period",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrForbidden,
+		Template: "fault epoch %d is too old, last exclusion period ended at %d",
+		Summary:  "fault epoch is too old, last exclusion period ended at",
+	},
+	{
+		ID:       "miner.failed-to-pay-fees",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to pay fees: %s",
+		Summary:  "failed to pay fees",
+	},
+	{
+		ID:       "miner.failed-to-save-miner-info",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ReportConsensusFault},
+		Code:     exitcode.ErrSerialization,
+		Template: "failed to save miner info: %s",
+		Summary:  "failed to save miner info",
+	},
+	{
+		ID:       "miner.negative-fund-requested-for-withdrawal",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative fund requested for withdrawal: %s",
+		Summary:  "negative fund requested for withdrawal",
+	},
+	{
+		ID:       "miner.cannot-withdraw-funds-while-deadlines-have-terminated-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot withdraw funds while %d deadlines have terminated sectors with outstanding fees",
+		Summary:  "cannot withdraw funds while deadlines have terminated sectors with outstanding fees",
+	},
+	{
+		ID:       "miner.failed-to-vest-fund",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to vest fund: %v",
+		Summary:  "failed to vest fund",
+	},
+	{
+		ID:       "miner.negative-amount-to-withdraw",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "negative amount to withdraw: %v",
+		Summary:  "negative amount to withdraw",
+	},
+	{
+		ID:       "miner.beneficiary-quota-used-quota-expiration-epoch-current-epoch",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.WithdrawBalance},
+		Code:     exitcode.ErrForbidden,
+		Template: "beneficiary(%s)  quota %s used quota %s expiration epoch %d  current epoch %d",
+		Summary:  "beneficiary( ) quota used quota expiration epoch current epoch",
+	},
+	{
+		ID:       "miner.failed-to-load-pre-committed-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load pre-committed sectors: %s",
+		Summary:  "failed to load pre-committed sectors",
+	},
+	{
+		ID:       "miner.all-prove-commits-failed-to-validate",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "all prove commits failed to validate",
+		Summary:  "all prove commits failed to validate",
+	},
+	{
+		ID:       "miner.failed-to-put-new-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put new sectors: %s",
+		Summary:  "failed to put new sectors",
+	},
+	{
+		ID:       "miner.failed-to-delete-precommited-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete precommited sectors: %s",
+		Summary:  "failed to delete precommited sectors",
+	},
+	{
+		ID:       "miner.failed-to-assign-new-sectors-to-deadlines",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to assign new sectors to deadlines: %s",
+		Summary:  "failed to assign new sectors to deadlines",
+	},
+	{
+		ID:       "miner.insufficient-funds-for-aggregate-initial-pledge-requirement-available",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ConfirmSectorProofsValid, builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "insufficient funds for aggregate initial pledge requirement %s, available: %s",
+		Summary:  "insufficient funds for aggregate initial pledge requirement , available",
+	},
+	{
+		ID:       "miner.invalid-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid deadline %v",
+		Summary:  "invalid deadline",
+	},
+	{
+		ID:       "miner.failed-to-parse-partitions-bitfield",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to parse partitions bitfield: %s",
+		Summary:  "failed to parse partitions bitfield",
+	},
+	{
+		ID:       "miner.cannot-compact-deadline-during-its-challenge-window-or",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot compact deadline %d during its challenge window, or the prior challenge window, or before %d epochs have passed since its last challenge window ended",
+		Summary:  "cannot compact deadline during its challenge window, or the prior challenge window, or before epochs have passed since its last challenge window ended",
+	},
+	{
+		ID:       "miner.failed-to-remove-partitions-from-deadline",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to remove partitions from deadline %d: %s",
+		Summary:  "failed to remove partitions from deadline",
+	},
+	{
+		ID:       "miner.failed-to-delete-dead-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete dead sectors: %s",
+		Summary:  "failed to delete dead sectors",
+	},
+	{
+		ID:       "miner.failed-to-load-moved-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load moved sectors: %s",
+		Summary:  "failed to load moved sectors",
+	},
+	{
+		ID:       "miner.failed-to-add-back-moved-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add back moved sectors: %s",
+		Summary:  "failed to add back moved sectors",
+	},
+	{
+		ID:       "miner.power-changed-when-compacting-partitions-was-is-now",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactPartitions},
+		Code:     exitcode.ErrIllegalState,
+		Template: "power changed when compacting partitions: was %v, is now %v",
+		Summary:  "power changed when compacting partitions: was , is now",
+	},
+	{
+		ID:       "miner.invalid-mask-bitfield",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactSectorNumbers},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid mask bitfield: %s",
+		Summary:  "invalid mask bitfield",
+	},
+	{
+		ID:       "miner.masked-sector-number-exceeded-max-sector-number",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactSectorNumbers},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "masked sector number %d exceeded max sector number",
+		Summary:  "masked sector number exceeded max sector number",
+	},
+	{
+		ID:       "miner.failed-to-mask-sector-numbers",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.CompactSectorNumbers},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to mask sector numbers: %s",
+		Summary:  "failed to mask sector numbers",
+	},
+	{
+		ID:       "miner.failed-to-unlock-fee-debt",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.RepayDebt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to unlock fee debt: %s",
+		Summary:  "failed to unlock fee debt",
+	},
+	{
+		ID:       "miner.empty-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeOwnerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "empty address",
+		Summary:  "empty address",
+	},
+	{
+		ID:       "miner.owner-address-must-be-an-id-address",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeOwnerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "owner address must be an ID address",
+		Summary:  "owner address must be an ID address",
+	},
+	{
+		ID:       "miner.expected-confirmation-of-got",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeOwnerAddress},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "expected confirmation of %v, got %v",
+		Summary:  "expected confirmation of , got",
+	},
+	{
+		ID:       "miner.failed-to-save-miner-info-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeOwnerAddress},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save miner info: %s",
+		Summary:  "failed to save miner info",
+	},
+	{
+		ID:       "miner.can-only-dispute-window-posts-during-the-dispute",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrForbidden,
+		Template: "can only dispute window posts during the dispute window (%d epochs after the challenge window closes)",
+		Summary:  "can only dispute window posts during the dispute window ( epochs after the challenge window closes)",
+	},
+	{
+		ID:       "miner.failed-to-load-deadline-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load deadline: %s",
+		Summary:  "failed to load deadline",
+	},
+	{
+		ID:       "miner.failed-to-load-proof-for-dispute",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load proof for dispute: %s",
+		Summary:  "failed to load proof for dispute",
+	},
+	{
+		ID:       "miner.failed-to-load-partition-info-for-dispute",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load partition info for dispute: %s",
+		Summary:  "failed to load partition info for dispute",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-snapshot-array",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors snapshot array: %s",
+		Summary:  "failed to load sectors snapshot array",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-to-dispute-window-post",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load sectors to dispute window post: %s",
+		Summary:  "failed to load sectors to dispute window post",
+	},
+	{
+		ID:       "miner.failed-to-dispute-valid-post",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to dispute valid post",
+		Summary:  "failed to dispute valid post",
+	},
+	{
+		ID:       "miner.failed-to-declare-faults",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to declare faults: %s",
+		Summary:  "failed to declare faults",
+	},
+	{
+		ID:       "miner.failed-to-pay-debt",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DisputeWindowedPoSt},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to pay debt: %s",
+		Summary:  "failed to pay debt",
+	},
+	{
+		ID:       "miner.failed-to-count-aggregated-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to count aggregated sectors: %s",
+		Summary:  "failed to count aggregated sectors",
+	},
+	{
+		ID:       "miner.too-many-sectors-addressed-addressed-want",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many sectors addressed, addressed %d want <= %d",
+		Summary:  "too many sectors addressed, addressed want <=",
+	},
+	{
+		ID:       "miner.too-few-sectors-addressed-addressed-want",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too few sectors addressed, addressed %d want >= %d",
+		Summary:  "too few sectors addressed, addressed want >=",
+	},
+	{
+		ID:       "miner.failed-to-get-precommits",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get precommits: %s",
+		Summary:  "failed to get precommits",
+	},
+	{
+		ID:       "miner.aggregate-contains-mismatched-seal-proofs-and",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "aggregate contains mismatched seal proofs %d and %d",
+		Summary:  "aggregate contains mismatched seal proofs and",
+	},
+	{
+		ID:       "miner.runtime-provided-non-id-receiver-address-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "runtime provided non-ID receiver address %s: %s",
+		Summary:  "runtime provided non-ID receiver address",
+	},
+	{
+		ID:       "miner.too-early-to-prove-sector-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrForbidden,
+		Template: "too early to prove sector %d",
+		Summary:  "too early to prove sector",
+	},
+	{
+		ID:       "miner.bitfield-non-empty-but-zero-precommits-read-from",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalState,
+		Template: "bitfield non-empty but zero precommits read from state",
+		Summary:  "bitfield non-empty but zero precommits read from state",
+	},
+	{
+		ID:       "miner.aggregate-seal-verify-failed",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "aggregate seal verify failed: %s",
+		Summary:  "aggregate seal verify failed",
+	},
+	{
+		ID:       "miner.failed-to-determine-unlocked-balance",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate, builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to determine unlocked balance: %s",
+		Summary:  "failed to determine unlocked balance",
+	},
+	{
+		ID:       "miner.remaining-unlocked-funds-after-prove-commit-are-insufficient",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveCommitAggregate},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "remaining unlocked funds after prove-commit (%s) are insufficient to pay aggregation fee of %s",
+		Summary:  "remaining unlocked funds after prove-commit ( ) are insufficient to pay aggregation fee of",
+	},
+	{
+		ID:       "miner.too-many-updates",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many updates (%d > %d)",
+		Summary:  "too many updates ( > )",
+	},
+	{
+		ID:       "miner.error-checking-sector-health",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "error checking sector health: %s",
+		Summary:  "error checking sector health",
+	},
+	{
+		ID:       "miner.no-valid-updates",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no valid updates",
+		Summary:  "no valid updates",
+	},
+	{
+		ID:       "miner.unsealed-sector-cid-request-returned-records-expected",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "unsealed sector cid request returned %d records, expected %d",
+		Summary:  "unsealed sector cid request returned records, expected",
+	},
+	{
+		ID:       "miner.couldn-t-load-update-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "couldn't load update proof type: %s",
+		Summary:  "couldn't load update proof type",
+	},
+	{
+		ID:       "miner.unsupported-update-proof-type",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unsupported update proof type %d",
+		Summary:  "unsupported update proof type",
+	},
+	{
+		ID:       "miner.failed-to-verify-replica-proof-for-sector",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to verify replica proof for sector %d: %s",
+		Summary:  "failed to verify replica proof for sector",
+	},
+	{
+		ID:       "miner.insufficient-funds-for-new-initial-pledge-requirement-available",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "insufficient funds for new initial pledge requirement %s, available: %s, skipping sector %d",
+		Summary:  "insufficient funds for new initial pledge requirement , available: , skipping sector",
+	},
+	{
+		ID:       "miner.failed-to-add-initial-pledge-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add initial pledge: %s",
+		Summary:  "failed to add initial pledge",
+	},
+	{
+		ID:       "miner.failed-to-replace-sector-at-deadline-partition",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to replace sector at deadline %d partition %d: %s",
+		Summary:  "failed to replace sector at deadline partition",
+	},
+	{
+		ID:       "miner.failed-to-count-succeededsectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to count succeededSectors: %s",
+		Summary:  "failed to count succeededSectors",
+	},
+	{
+		ID:       "miner.unexpected-successcount",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "unexpected successcount %d != %d",
+		Summary:  "unexpected successcount !=",
+	},
+	{
+		ID:       "miner.failed-to-update-sector-infos",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ProveReplicaUpdates},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update sector infos: %s",
+		Summary:  "failed to update sector infos",
+	},
+	{
+		ID:       "miner.beneficial-quota-must-bigger-than-zero",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "beneficial quota (%s) must bigger than zero",
+		Summary:  "beneficial quota ( ) must bigger than zero",
+	},
+	{
+		ID:       "miner.owner-beneficial-quota-must-be-zero",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "owner beneficial quota (%s) must be zero",
+		Summary:  "owner beneficial quota ( ) must be zero",
+	},
+	{
+		ID:       "miner.owner-beneficial-expiration-must-be-zero",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "owner beneficial expiration (%d)  must be zero",
+		Summary:  "owner beneficial expiration ( ) must be zero",
+	},
+	{
+		ID:       "miner.new-beneficiary-address-must-be-equal-expect-but",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "new beneficiary address must be equal expect %s, but got %s",
+		Summary:  "new beneficiary address must be equal expect , but got",
+	},
+	{
+		ID:       "miner.new-beneficiary-quota-must-be-equal-expect-but",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "new beneficiary quota must be equal expect %s, but got %s",
+		Summary:  "new beneficiary quota must be equal expect , but got",
+	},
+	{
+		ID:       "miner.new-beneficiary-expiredate-must-be-equal-expect-but",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "new beneficiary expiredate must be equal expect %s, but got %s",
+		Summary:  "new beneficiary expiredate must be equal expect , but got",
+	},
+	{
+		ID:       "miner.no-changebeneficiary-proposal-exists",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.ChangeBeneficiary},
+		Code:     exitcode.ErrForbidden,
+		Template: "No changeBeneficiary proposal exists",
+		Summary:  "No changeBeneficiary proposal exists",
+	},
+	{
+		ID:       "miner.failed-to-load-fee-debt-log",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.GetFeeDebtStatus},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load fee debt log: %s",
+		Summary:  "failed to load fee debt log",
+	},
+	{
+		ID:       "miner.miner-paused-state-is-already",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.PauseMiner, builtin.MethodsMiner.UnpauseMiner},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "miner paused state is already %t",
+		Summary:  "miner paused state is already",
+	},
+	{
+		ID:       "miner.unsealing-window-start-before-current-epoch",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unsealing window start %d before current epoch %d",
+		Summary:  "unsealing window start before current epoch",
+	},
+	{
+		ID:       "miner.unsealing-window-end-not-after-start",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unsealing window end %d not after start %d",
+		Summary:  "unsealing window end not after start",
+	},
+	{
+		ID:       "miner.unsealing-window-duration-exceeds-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unsealing window duration %d exceeds max %d",
+		Summary:  "unsealing window duration exceeds max",
+	},
+	{
+		ID:       "miner.failed-to-count-sectors",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to count sectors: %s",
+		Summary:  "failed to count sectors",
+	},
+	{
+		ID:       "miner.no-sectors-in-unsealing-window",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no sectors in unsealing window",
+		Summary:  "no sectors in unsealing window",
+	},
+	{
+		ID:       "miner.too-many-sectors-in-unsealing-window-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many sectors in unsealing window %d, max %d",
+		Summary:  "too many sectors in unsealing window , max",
+	},
+	{
+		ID:       "miner.failed-to-load-sectors-2",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to load sectors: %s",
+		Summary:  "failed to load sectors",
+	},
+	{
+		ID:       "miner.sector-expires-at-before-unsealing-window-end",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "sector %d expires at %d before unsealing window end %d",
+		Summary:  "sector expires at before unsealing window end",
+	},
+	{
+		ID:       "miner.failed-to-load-unsealing-windows",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow, builtin.MethodsMiner.GetUnsealingWindows},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load unsealing windows: %s",
+		Summary:  "failed to load unsealing windows",
+	},
+	{
+		ID:       "miner.too-many-unsealing-windows-max",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrForbidden,
+		Template: "too many unsealing windows %d, max %d",
+		Summary:  "too many unsealing windows , max",
+	},
+	{
+		ID:       "miner.failed-to-save-unsealing-windows",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save unsealing windows: %s",
+		Summary:  "failed to save unsealing windows",
+	},
+	{
+		ID:       "miner.unlocked-balance-insufficient-to-pay-unsealing-window-fee",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMiner.DeclareUnsealingWindow},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "unlocked balance %v insufficient to pay unsealing window fee %v",
+		Summary:  "unlocked balance insufficient to pay unsealing window fee",
+	},
+	{
+		ID:       "miner.no-builtin-actor-with-code",
+		Actor:    builtin.StorageMinerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "multisig.must-have-at-least-one-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "must have at least one signer",
+		Summary:  "must have at least one signer",
+	},
+	{
+		ID:       "multisig.cannot-add-more-than-signers",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot add more than %d signers",
+		Summary:  "cannot add more than signers",
+	},
+	{
+		ID:       "multisig.failed-to-resolve-addr-to-id-addr",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor, builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve addr %v to ID addr: %s",
+		Summary:  "failed to resolve addr to ID addr",
+	},
+	{
+		ID:       "multisig.duplicate-signer-not-allowed",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "duplicate signer not allowed: %s",
+		Summary:  "duplicate signer not allowed",
+	},
+	{
+		ID:       "multisig.must-not-require-more-approvals-than-signers",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "must not require more approvals than signers",
+		Summary:  "must not require more approvals than signers",
+	},
+	{
+		ID:       "multisig.must-require-at-least-one-approval",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "must require at least one approval",
+		Summary:  "must require at least one approval",
+	},
+	{
+		ID:       "multisig.negative-unlock-duration-disallowed",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative unlock duration disallowed",
+		Summary:  "negative unlock duration disallowed",
+	},
+	{
+		ID:       "multisig.failed-to-create-empty-map",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to create empty map: %v",
+		Summary:  "failed to create empty map",
+	},
+	{
+		ID:       "multisig.proposed-value-must-be-non-negative-was",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "proposed value must be non-negative, was %v",
+		Summary:  "proposed value must be non-negative, was",
+	},
+	{
+		ID:       "multisig.is-not-a-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.Cancel, builtin.MethodsMultisig.RemoveSigner, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrForbidden,
+		Template: "%s is not a signer",
+		Summary:  "is not a signer",
+	},
+	{
+		ID:       "multisig.failed-to-load-pending-transactions",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load pending transactions: %s",
+		Summary:  "failed to load pending transactions",
+	},
+	{
+		ID:       "multisig.failed-to-put-transaction-for-propose",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put transaction for propose: %v",
+		Summary:  "failed to put transaction for propose",
+	},
+	{
+		ID:       "multisig.failed-to-flush-pending-transactions",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.Cancel, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush pending transactions: %s",
+		Summary:  "failed to flush pending transactions",
+	},
+	{
+		ID:       "multisig.failed-to-load-pending-transaction-metadata",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load pending transaction metadata: %s",
+		Summary:  "failed to load pending transaction metadata",
+	},
+	{
+		ID:       "multisig.failed-to-put-metadata-for-transaction",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put metadata for transaction %v: %s",
+		Summary:  "failed to put metadata for transaction",
+	},
+	{
+		ID:       "multisig.failed-to-flush-pending-transaction-metadata",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush pending transaction metadata: %s",
+		Summary:  "failed to flush pending transaction metadata",
+	},
+	{
+		ID:       "multisig.already-approved-this-message",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrForbidden,
+		Template: "%s already approved this message",
+		Summary:  "already approved this message",
+	},
+	{
+		ID:       "multisig.failed-to-put-transaction-for-approval",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put transaction %v for approval: %s",
+		Summary:  "failed to put transaction for approval",
+	},
+	{
+		ID:       "multisig.insufficient-funds-unlocked",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrInsufficientFunds,
+		Template: "insufficient funds unlocked: %v",
+		Summary:  "insufficient funds unlocked",
+	},
+	{
+		ID:       "multisig.failed-to-delete-transaction-for-cleanup",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete transaction for cleanup: %v",
+		Summary:  "failed to delete transaction for cleanup",
+	},
+	{
+		ID:       "multisig.failed-to-delete-metadata-for-transaction",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Propose, builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.Cancel, builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany, builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete metadata for transaction %v: %s",
+		Summary:  "failed to delete metadata for transaction",
+	},
+	{
+		ID:       "multisig.failed-to-load-transaction-for-approval",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load transaction %v for approval: %s",
+		Summary:  "failed to load transaction for approval",
+	},
+	{
+		ID:       "multisig.no-such-transaction-for-approval",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany},
+		Code:     exitcode.ErrNotFound,
+		Template: "no such transaction %v for approval",
+		Summary:  "no such transaction for approval",
+	},
+	{
+		ID:       "multisig.failed-to-compute-proposal-hash-for",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.Cancel, builtin.MethodsMultisig.ApproveMany},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to compute proposal hash for %v: %s",
+		Summary:  "failed to compute proposal hash for",
+	},
+	{
+		ID:       "multisig.hash-does-not-match-proposal-params-ensure-requester",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Approve, builtin.MethodsMultisig.ApproveMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "hash does not match proposal params (ensure requester is an ID address)",
+		Summary:  "hash does not match proposal params (ensure requester is an ID address)",
+	},
+	{
+		ID:       "multisig.failed-to-load-pending-txns",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Cancel},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load pending txns: %s",
+		Summary:  "failed to load pending txns",
+	},
+	{
+		ID:       "multisig.failed-to-pop-transaction-for-cancel",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Cancel},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to pop transaction %v for cancel: %s",
+		Summary:  "failed to pop transaction for cancel",
+	},
+	{
+		ID:       "multisig.no-such-transaction-to-cancel",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Cancel},
+		Code:     exitcode.ErrNotFound,
+		Template: "no such transaction %v to cancel",
+		Summary:  "no such transaction to cancel",
+	},
+	{
+		ID:       "multisig.cannot-cancel-another-signers-transaction",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Cancel},
+		Code:     exitcode.ErrForbidden,
+		Template: "Cannot cancel another signers transaction",
+		Summary:  "Cannot cancel another signers transaction",
+	},
+	{
+		ID:       "multisig.hash-does-not-match-proposal-params-ensure-requester-2",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.Cancel},
+		Code:     exitcode.ErrIllegalState,
+		Template: "hash does not match proposal params (ensure requester is an ID address)",
+		Summary:  "hash does not match proposal params (ensure requester is an ID address)",
+	},
+	{
+		ID:       "multisig.failed-to-resolve-address",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.AddSigner, builtin.MethodsMultisig.RemoveSigner},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve address %v: %s",
+		Summary:  "failed to resolve address",
+	},
+	{
+		ID:       "multisig.cannot-add-more-than-signers-2",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.AddSigner},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot add more than %d signers",
+		Summary:  "cannot add more than signers",
+	},
+	{
+		ID:       "multisig.is-already-a-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.AddSigner},
+		Code:     exitcode.ErrForbidden,
+		Template: "%s is already a signer",
+		Summary:  "is already a signer",
+	},
+	{
+		ID:       "multisig.cannot-remove-only-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.RemoveSigner},
+		Code:     exitcode.ErrForbidden,
+		Template: "cannot remove only signer",
+		Summary:  "cannot remove only signer",
+	},
+	{
+		ID:       "multisig.can-t-reduce-signers-to-below-threshold-with",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.RemoveSigner},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "can't reduce signers to %d below threshold %d with decrease=false",
+		Summary:  "can't reduce signers to below threshold with decrease=false",
+	},
+	{
+		ID:       "multisig.can-t-decrease-approvals-from-to",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.RemoveSigner},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "can't decrease approvals from %d to %d",
+		Summary:  "can't decrease approvals from to",
+	},
+	{
+		ID:       "multisig.failed-to-purge-approvals-of-removed-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.RemoveSigner, builtin.MethodsMultisig.SwapSigner, builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to purge approvals of removed signer: %s",
+		Summary:  "failed to purge approvals of removed signer",
+	},
+	{
+		ID:       "multisig.failed-to-resolve-from-address",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSigner, builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve from address %v: %s",
+		Summary:  "failed to resolve from address",
+	},
+	{
+		ID:       "multisig.failed-to-resolve-to-address",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSigner, builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve to address %v: %s",
+		Summary:  "failed to resolve to address",
+	},
+	{
+		ID:       "multisig.from-addr-is-not-a-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSigner, builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrForbidden,
+		Template: "from addr %s is not a signer",
+		Summary:  "from addr is not a signer",
+	},
+	{
+		ID:       "multisig.already-a-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSigner, builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "%s already a signer",
+		Summary:  "already a signer",
+	},
+	{
+		ID:       "multisig.new-threshold-value-not-supported",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.ChangeNumApprovalsThreshold},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "New threshold value not supported",
+		Summary:  "New threshold value not supported",
+	},
+	{
+		ID:       "multisig.unlock-duration-must-be-positive",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.LockBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "unlock duration must be positive",
+		Summary:  "unlock duration must be positive",
+	},
+	{
+		ID:       "multisig.amount-to-lock-must-be-positive",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.LockBalance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "amount to lock must be positive",
+		Summary:  "amount to lock must be positive",
+	},
+	{
+		ID:       "multisig.modification-of-unlock-disallowed",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.LockBalance},
+		Code:     exitcode.ErrForbidden,
+		Template: "modification of unlock disallowed",
+		Summary:  "modification of unlock disallowed",
+	},
+	{
+		ID:       "multisig.batch-empty",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch empty",
+		Summary:  "batch empty",
+	},
+	{
+		ID:       "multisig.batch-of-too-large-max",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.ApproveMany, builtin.MethodsMultisig.ProposeMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch of %d too large, max %d",
+		Summary:  "batch of too large, max",
+	},
+	{
+		ID:       "multisig.duplicate-transaction-in-batch",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.ApproveMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "duplicate transaction %d in batch",
+		Summary:  "duplicate transaction in batch",
+	},
+	{
+		ID:       "multisig.spending-limit-must-be-non-negative-was",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "spending limit must be non-negative, was %v",
+		Summary:  "spending limit must be non-negative, was",
+	},
+	{
+		ID:       "multisig.spending-limit-window-must-be-positive-was",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "spending limit window must be positive, was %d",
+		Summary:  "spending limit window must be positive, was",
+	},
+	{
+		ID:       "multisig.cannot-permit-more-than-destinations",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot permit more than %d destinations",
+		Summary:  "cannot permit more than destinations",
+	},
+	{
+		ID:       "multisig.duplicate-destination-not-allowed",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "duplicate destination not allowed: %s",
+		Summary:  "duplicate destination not allowed",
+	},
+	{
+		ID:       "multisig.multisig-cannot-be-a-spending-limit-destination",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SetSpendingLimit},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "multisig cannot be a spending limit destination",
+		Summary:  "multisig cannot be a spending limit destination",
+	},
+	{
+		ID:       "multisig.invalid-approval-policy",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid approval policy %d",
+		Summary:  "invalid approval policy",
+	},
+	{
+		ID:       "multisig.failed-to-transfer-approvals-of-removed-signer",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.SwapSignerAndReapprove},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to transfer approvals of removed signer: %s",
+		Summary:  "failed to transfer approvals of removed signer",
+	},
+	{
+		ID:       "multisig.metadata-of-bytes-too-large-max",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsMultisig.ProposeWithMetadata},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "metadata of %d bytes too large, max %d",
+		Summary:  "metadata of bytes too large, max",
+	},
+	{
+		ID:       "multisig.no-builtin-actor-with-code",
+		Actor:    builtin.MultisigActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "paych.too-many-payees-max",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many payees %d, max %d",
+		Summary:  "too many payees , max",
+	},
+	{
+		ID:       "paych.duplicate-payee",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "duplicate payee %v",
+		Summary:  "duplicate payee",
+	},
+	{
+		ID:       "paych.failed-to-create-empty-array",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to create empty array: %s",
+		Summary:  "failed to create empty array",
+	},
+	{
+		ID:       "paych.failed-to-persist-empty-array",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to persist empty array: %s",
+		Summary:  "failed to persist empty array",
+	},
+	{
+		ID:       "paych.settle-delay-shorter-than-minimum",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "settle delay %d shorter than minimum %d",
+		Summary:  "settle delay shorter than minimum",
+	},
+	{
+		ID:       "paych.voucher-has-no-signature",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelState},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "voucher has no signature",
+		Summary:  "voucher has no signature",
+	},
+	{
+		ID:       "paych.no-vouchers-can-be-processed-after-settlingat-epoch",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelState, builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     paych.ErrChannelStateUpdateAfterSettled,
+		Template: "no vouchers can be processed after SettlingAt epoch",
+		Summary:  "no vouchers can be processed after SettlingAt epoch",
+	},
+	{
+		ID:       "paych.failed-to-load-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelState, builtin.MethodsPaych.CompactLanes, builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load lanes: %s",
+		Summary:  "failed to load lanes",
+	},
+	{
+		ID:       "paych.failed-to-save-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelState, builtin.MethodsPaych.CompactLanes, builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to save lanes: %s",
+		Summary:  "failed to save lanes",
+	},
+	{
+		ID:       "paych.channel-already-settling",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Settle},
+		Code:     exitcode.ErrIllegalState,
+		Template: "channel already settling",
+		Summary:  "channel already settling",
+	},
+	{
+		ID:       "paych.payment-channel-not-settling-or-settled",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.Collect},
+		Code:     exitcode.ErrForbidden,
+		Template: "payment channel not settling or settled",
+		Summary:  "payment channel not settling or settled",
+	},
+	{
+		ID:       "paych.no-lanes-can-be-compacted-after-settlingat-epoch",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     paych.ErrChannelStateUpdateAfterSettled,
+		Template: "no lanes can be compacted after SettlingAt epoch",
+		Summary:  "no lanes can be compacted after SettlingAt epoch",
+	},
+	{
+		ID:       "paych.failed-to-count-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to count lanes: %s",
+		Summary:  "failed to count lanes",
+	},
+	{
+		ID:       "paych.no-lanes-to-compact",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no lanes to compact",
+		Summary:  "no lanes to compact",
+	},
+	{
+		ID:       "paych.too-many-lanes-to-compact-max",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "too many lanes to compact %d, max %d",
+		Summary:  "too many lanes to compact , max",
+	},
+	{
+		ID:       "paych.failed-to-check-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to check lanes: %s",
+		Summary:  "failed to check lanes",
+	},
+	{
+		ID:       "paych.cannot-compact-lane-into-itself",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot compact lane %d into itself",
+		Summary:  "cannot compact lane into itself",
+	},
+	{
+		ID:       "paych.failed-to-assign-lane",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to assign lane %d: %s",
+		Summary:  "failed to assign lane",
+	},
+	{
+		ID:       "paych.cannot-compact-unknown-lane",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot compact unknown lane %d",
+		Summary:  "cannot compact unknown lane",
+	},
+	{
+		ID:       "paych.failed-to-delete-compacted-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete compacted lanes: %s",
+		Summary:  "failed to delete compacted lanes",
+	},
+	{
+		ID:       "paych.failed-to-store-lane",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to store lane %d: %s",
+		Summary:  "failed to store lane",
+	},
+	{
+		ID:       "paych.failed-to-record-retired-lanes",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.CompactLanes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to record retired lanes: %s",
+		Summary:  "failed to record retired lanes",
+	},
+	{
+		ID:       "paych.batch-empty",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch empty",
+		Summary:  "batch empty",
+	},
+	{
+		ID:       "paych.batch-of-too-large-max",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "batch of %d too large, max %d",
+		Summary:  "batch of too large, max",
+	},
+	{
+		ID:       "paych.voucher-has-no-signature-2",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPaych.UpdateChannelStateMany},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "voucher %d has no signature",
+		Summary:  "voucher has no signature",
+	},
+	{
+		ID:       "paych.no-builtin-actor-with-code",
+		Actor:    builtin.PaymentChannelActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "power.failed-to-construct-state",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct state: %s",
+		Summary:  "failed to construct state",
+	},
+	{
+		ID:       "power.failed-to-serialize-miner-constructor-params",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CreateMiner},
+		Code:     exitcode.ErrSerialization,
+		Template: "failed to serialize miner constructor params %v: %s",
+		Summary:  "failed to serialize miner constructor params",
+	},
+	{
+		ID:       "power.failed-to-load-claims",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CreateMiner, builtin.MethodsPower.UpdateClaimedPower, builtin.MethodsPower.CronTick, builtin.MethodsPower.UpdatePledgeTotal, builtin.MethodsPower.SubmitPoRepForBulkVerify, builtin.MethodsPower.OnNetworkVersionChange},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load claims: %s",
+		Summary:  "failed to load claims",
+	},
+	{
+		ID:       "power.failed-to-put-power-in-claimed-table-while",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CreateMiner},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put power in claimed table while creating miner: %s",
+		Summary:  "failed to put power in claimed table while creating miner",
+	},
+	{
+		ID:       "power.failed-update-power-stats-for-new-miner",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CreateMiner},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed update power stats for new miner %v: %s",
+		Summary:  "failed update power stats for new miner",
+	},
+	{
+		ID:       "power.failed-to-flush-claims",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CreateMiner, builtin.MethodsPower.UpdateClaimedPower, builtin.MethodsPower.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush claims: %s",
+		Summary:  "failed to flush claims",
+	},
+	{
+		ID:       "power.failed-to-update-power-raw-qa",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.UpdateClaimedPower},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update power raw %s, qa %s: %s",
+		Summary:  "failed to update power raw , qa",
+	},
+	{
+		ID:       "power.cron-event-epoch-cannot-be-less-than-zero",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.EnrollCronEvent},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cron event epoch %d cannot be less than zero",
+		Summary:  "cron event epoch cannot be less than zero",
+	},
+	{
+		ID:       "power.failed-to-load-cron-events",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.EnrollCronEvent, builtin.MethodsPower.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load cron events: %s",
+		Summary:  "failed to load cron events",
+	},
+	{
+		ID:       "power.failed-to-enroll-cron-event",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.EnrollCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to enroll cron event: %s",
+		Summary:  "failed to enroll cron event",
+	},
+	{
+		ID:       "power.failed-to-flush-cron-events",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.EnrollCronEvent},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush cron events: %s",
+		Summary:  "failed to flush cron events",
+	},
+	{
+		ID:       "power.failed-to-load-cron-events-at",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load cron events at %v: %s",
+		Summary:  "failed to load cron events at",
+	},
+	{
+		ID:       "power.failed-to-look-up-claim",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CronTick, builtin.MethodsPower.UpdatePledgeTotal, builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to look up claim: %s",
+		Summary:  "failed to look up claim",
+	},
+	{
+		ID:       "power.failed-to-clear-cron-events-at",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to clear cron events at %v: %s",
+		Summary:  "failed to clear cron events at",
+	},
+	{
+		ID:       "power.failed-to-flush-events",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.CronTick},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush events: %s",
+		Summary:  "failed to flush events",
+	},
+	{
+		ID:       "power.negative-total-pledge-collateral",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.UpdatePledgeTotal},
+		Code:     exitcode.ErrIllegalState,
+		Template: "negative total pledge collateral %v",
+		Summary:  "negative total pledge collateral",
+	},
+	{
+		ID:       "power.unknown-miner-forbidden-to-interact-with-power-actor",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.UpdatePledgeTotal, builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrForbidden,
+		Template: "unknown miner %s forbidden to interact with power actor",
+		Summary:  "unknown miner forbidden to interact with power actor",
+	},
+	{
+		ID:       "power.failed-to-create-empty-proof-validation-set",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to create empty proof validation set: %s",
+		Summary:  "failed to create empty proof validation set",
+	},
+	{
+		ID:       "power.failed-to-load-proof-batch-set",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load proof batch set: %s",
+		Summary:  "failed to load proof batch set",
+	},
+	{
+		ID:       "power.failed-to-get-get-seal-verify-infos-at",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get get seal verify infos at addr %s: %s",
+		Summary:  "failed to get get seal verify infos at addr",
+	},
+	{
+		ID:       "power.miner-attempting-to-prove-commit-over-sectors-in",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     power.ErrTooManyProveCommits,
+		Template: "miner %s attempting to prove commit over %d sectors in epoch",
+		Summary:  "miner attempting to prove commit over sectors in epoch",
+	},
+	{
+		ID:       "power.failed-to-insert-proof-into-batch",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to insert proof into batch: %s",
+		Summary:  "failed to insert proof into batch",
+	},
+	{
+		ID:       "power.failed-to-flush-proof-batch",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.SubmitPoRepForBulkVerify},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush proof batch: %s",
+		Summary:  "failed to flush proof batch",
+	},
+	{
+		ID:       "power.new-network-version-does-not-match-current-network",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.OnNetworkVersionChange},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "new network version %d does not match current network version %d",
+		Summary:  "new network version does not match current network version",
+	},
+	{
+		ID:       "power.failed-to-recompute-claim-totals",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsPower.OnNetworkVersionChange},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to recompute claim totals: %s",
+		Summary:  "failed to recompute claim totals",
+	},
+	{
+		ID:       "power.no-builtin-actor-with-code",
+		Actor:    builtin.StoragePowerActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "reward.argument-should-not-be-nil",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.Constructor, builtin.MethodsReward.UpdateNetworkKPI},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "argument should not be nil",
+		Summary:  "argument should not be nil",
+	},
+	{
+		ID:       "reward.negative-penalty",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative penalty %v",
+		Summary:  "negative penalty",
+	},
+	{
+		ID:       "reward.negative-gas-reward",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "negative gas reward %v",
+		Summary:  "negative gas reward",
+	},
+	{
+		ID:       "reward.actor-current-balance-insufficient-to-pay-gas-reward",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalState,
+		Template: "actor current balance %v insufficient to pay gas reward %v",
+		Summary:  "actor current balance insufficient to pay gas reward",
+	},
+	{
+		ID:       "reward.invalid-win-count",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "invalid win count %d",
+		Summary:  "invalid win count",
+	},
+	{
+		ID:       "reward.failed-to-resolve-given-owner-address",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrNotFound,
+		Template: "failed to resolve given owner address",
+		Summary:  "failed to resolve given owner address",
+	},
+	{
+		ID:       "reward.programming-error-block-reward-below-zero",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalState,
+		Template: "programming error, block reward %v below zero",
+		Summary:  "programming error, block reward below zero",
+	},
+	{
+		ID:       "reward.reward-exceeds-balance",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.AwardBlockReward},
+		Code:     exitcode.ErrIllegalState,
+		Template: "reward %v exceeds balance %v",
+		Summary:  "reward exceeds balance",
+	},
+	{
+		ID:       "reward.non-positive-penalty-to-recycle",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsReward.RecyclePenalty},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "non-positive penalty %v to recycle",
+		Summary:  "non-positive penalty to recycle",
+	},
+	{
+		ID:       "reward.no-builtin-actor-with-code",
+		Actor:    builtin.RewardActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "system.no-builtin-actor-with-code",
+		Actor:    builtin.SystemActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+	{
+		ID:       "verifreg.root-should-be-an-id-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.Constructor},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "root should be an ID address",
+		Summary:  "root should be an ID address",
+	},
+	{
+		ID:       "verifreg.failed-to-construct-state",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.Constructor},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to construct state: %s",
+		Summary:  "failed to construct state",
+	},
+	{
+		ID:       "verifreg.allowance-below-minverifieddealsize-for-add-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "Allowance %d below MinVerifiedDealSize for add verifier %v",
+		Summary:  "Allowance below MinVerifiedDealSize for add verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-verifier-address-to-id-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier, builtin.MethodsVerifiedRegistry.RemoveVerifier},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve verifier address %v to ID address: %s",
+		Summary:  "failed to resolve verifier address to ID address",
+	},
+	{
+		ID:       "verifreg.rootkey-cannot-be-added-as-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "Rootkey cannot be added as verifier",
+		Summary:  "Rootkey cannot be added as verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-load-verifiers",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier, builtin.MethodsVerifiedRegistry.RemoveVerifier, builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load verifiers: %s",
+		Summary:  "failed to load verifiers",
+	},
+	{
+		ID:       "verifreg.failed-to-load-verified-clients",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier, builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.RestoreBytes, builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap, builtin.MethodsVerifiedRegistry.SetDataCapAllowance, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load verified clients: %s",
+		Summary:  "failed to load verified clients",
+	},
+	{
+		ID:       "verifreg.failed-get-verified-client-state-for",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed get verified client state for %v: %s",
+		Summary:  "failed get verified client state for",
+	},
+	{
+		ID:       "verifreg.verified-client-cannot-become-a-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "verified client %v cannot become a verifier",
+		Summary:  "verified client cannot become a verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-add-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add verifier: %s",
+		Summary:  "failed to add verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-flush-verifiers",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifier, builtin.MethodsVerifiedRegistry.RemoveVerifier, builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush verifiers: %s",
+		Summary:  "failed to flush verifiers",
+	},
+	{
+		ID:       "verifreg.failed-to-remove-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifier},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to remove verifier: %s",
+		Summary:  "failed to remove verifier",
+	},
+	{
+		ID:       "verifreg.no-such-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifier},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "no such verifier %v",
+		Summary:  "no such verifier",
+	},
+	{
+		ID:       "verifreg.allowance-below-minverifieddealsize-for-add-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "allowance %d below MinVerifiedDealSize for add verified client %v",
+		Summary:  "allowance below MinVerifiedDealSize for add verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-verified-client-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve verified client address %v: %s",
+		Summary:  "failed to resolve verified client address",
+	},
+	{
+		ID:       "verifreg.rootkey-cannot-be-added-as-a-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "Rootkey cannot be added as a verified client",
+		Summary:  "Rootkey cannot be added as a verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-get-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get verifier %v: %s",
+		Summary:  "failed to get verifier",
+	},
+	{
+		ID:       "verifreg.no-such-verifier-2",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrNotFound,
+		Template: "no such verifier %v",
+		Summary:  "no such verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-get-verifier-2",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get verifier: %s",
+		Summary:  "failed to get verifier",
+	},
+	{
+		ID:       "verifreg.verifier-cannot-be-added-as-a-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "verifier %v cannot be added as a verified client",
+		Summary:  "verifier cannot be added as a verified client",
+	},
+	{
+		ID:       "verifreg.add-more-datacap-for-verifiedclient-than-allocated",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "add more DataCap (%d) for VerifiedClient than allocated %d",
+		Summary:  "add more DataCap ( ) for VerifiedClient than allocated",
+	},
+	{
+		ID:       "verifreg.failed-to-update-new-verifier-cap-for",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update new verifier cap (%d) for %v: %s",
+		Summary:  "failed to update new verifier cap ( ) for",
+	},
+	{
+		ID:       "verifreg.failed-to-get-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.RestoreBytes, builtin.MethodsVerifiedRegistry.SetDataCapAllowance, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get verified client %v: %s",
+		Summary:  "failed to get verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-add-verified-client-with-cap",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to add verified client %v with cap %d: %s",
+		Summary:  "failed to add verified client with cap",
+	},
+	{
+		ID:       "verifreg.failed-to-flush-verified-clients",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.AddVerifiedClient, builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.RestoreBytes, builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush verified clients: %s",
+		Summary:  "failed to flush verified clients",
+	},
+	{
+		ID:       "verifreg.verifieddealsize-below-minimum-in-usebytes",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "VerifiedDealSize: %d below minimum in UseBytes",
+		Summary:  "VerifiedDealSize: below minimum in UseBytes",
+	},
+	{
+		ID:       "verifreg.no-such-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrNotFound,
+		Template: "no such verified client %v",
+		Summary:  "no such verified client",
+	},
+	{
+		ID:       "verifreg.negative-cap-for-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "negative cap for client %v: %v",
+		Summary:  "negative cap for client",
+	},
+	{
+		ID:       "verifreg.dealsize-exceeds-allowable-cap-for-verifiedclient",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "DealSize %d exceeds allowable cap: %d for VerifiedClient %v",
+		Summary:  "DealSize exceeds allowable cap: for VerifiedClient",
+	},
+	{
+		ID:       "verifreg.failed-to-delete-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete verified client %v: %s",
+		Summary:  "failed to delete verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-update-verified-client-with",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytes, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update verified client %v with %v: %s",
+		Summary:  "failed to update verified client with",
+	},
+	{
+		ID:       "verifreg.below-minimum-verifieddealsize-requested-in-restorebytes",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "Below minimum VerifiedDealSize requested in RestoreBytes: %d",
+		Summary:  "Below minimum VerifiedDealSize requested in RestoreBytes",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-verified-client-addr",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve verified client addr %v: %s",
+		Summary:  "failed to resolve verified client addr",
+	},
+	{
+		ID:       "verifreg.cannot-restore-allowance-for-rootkey",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "Cannot restore allowance for Rootkey",
+		Summary:  "Cannot restore allowance for Rootkey",
+	},
+	{
+		ID:       "verifreg.cannot-restore-allowance-for-a-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "cannot restore allowance for a verifier",
+		Summary:  "cannot restore allowance for a verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-put-verified-client-with",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RestoreBytes},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put verified client %v with %v: %s",
+		Summary:  "failed to put verified client with",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-client-address-to-id-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to resolve client address %s to ID address: %s",
+		Summary:  "failed to resolve client address to ID address",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-verifier-address-to-id-address-2",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to resolve verifier address %s to ID address: %s",
+		Summary:  "failed to resolve verifier address to ID address",
+	},
+	{
+		ID:       "verifreg.need-two-different-verifiers-to-send-remove-datacap",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "need two different verifiers to send remove datacap request got %s and %s that are the same accounts",
+		Summary:  "need two different verifiers to send remove datacap request got and that are the same accounts",
+	},
+	{
+		ID:       "verifreg.failed-to-get-verified-client-2",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get verified client %s: %s",
+		Summary:  "failed to get verified client",
+	},
+	{
+		ID:       "verifreg.is-not-a-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrNotFound,
+		Template: "%s is not a verified client",
+		Summary:  "is not a verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-check-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to check verifier %v: %s",
+		Summary:  "failed to check verifier",
+	},
+	{
+		ID:       "verifreg.is-not-a-verifier",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "%s is not a verifier",
+		Summary:  "is not a verifier",
+	},
+	{
+		ID:       "verifreg.failed-to-load-datacap-removal-proposal-ids",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load datacap removal proposal ids: %s",
+		Summary:  "failed to load datacap removal proposal ids",
+	},
+	{
+		ID:       "verifreg.failed-to-delete-verified-client-2",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete verified client %s: %s",
+		Summary:  "failed to delete verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-update-datacap-to-for-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update datacap to %v for verified client %s : %s",
+		Summary:  "failed to update datacap to for verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-flush-proposal-ids",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush proposal ids: %s",
+		Summary:  "failed to flush proposal ids",
+	},
+	{
+		ID:       "verifreg.failed-getting-proposal-id-for-verifier-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed getting proposal id for verifier,client %s,%s: %s",
+		Summary:  "failed getting proposal id for verifier,client",
+	},
+	{
+		ID:       "verifreg.failed-to-update-remove-datacap-proposal-id-for",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update remove datacap proposal id for verifier,client %s,%s: %s",
+		Summary:  "failed to update remove datacap proposal id for verifier,client",
+	},
+	{
+		ID:       "verifreg.remove-datacap-request-failed-to-marshal-request",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrSerialization,
+		Template: "remove datacap request failed to marshal request: %s",
+		Summary:  "remove datacap request failed to marshal request",
+	},
+	{
+		ID:       "verifreg.remove-datacap-request-signature-is-invalid",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "remove datacap request signature is invalid: %s",
+		Summary:  "remove datacap request signature is invalid",
+	},
+	{
+		ID:       "verifreg.allowance-below-minverifieddealsize",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "allowance %d below MinVerifiedDealSize",
+		Summary:  "allowance below MinVerifiedDealSize",
+	},
+	{
+		ID:       "verifreg.allowance-expiration-must-be-after-current-epoch",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "allowance expiration %d must be after current epoch %d",
+		Summary:  "allowance expiration must be after current epoch",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-delegate-address-to-id-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "failed to resolve delegate address %v to ID address: %s",
+		Summary:  "failed to resolve delegate address to ID address",
+	},
+	{
+		ID:       "verifreg.client-cannot-delegate-datacap-to-itself",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "client %v cannot delegate DataCap to itself",
+		Summary:  "client cannot delegate DataCap to itself",
+	},
+	{
+		ID:       "verifreg.caller-is-not-a-verified-client",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrForbidden,
+		Template: "caller %v is not a verified client",
+		Summary:  "caller is not a verified client",
+	},
+	{
+		ID:       "verifreg.failed-to-load-datacap-allowances",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to load datacap allowances: %s",
+		Summary:  "failed to load datacap allowances",
+	},
+	{
+		ID:       "verifreg.failed-to-delete-allowance-for-client-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to delete allowance for client,delegate %v,%v: %s",
+		Summary:  "failed to delete allowance for client,delegate",
+	},
+	{
+		ID:       "verifreg.failed-to-put-allowance-for-client-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to put allowance for client,delegate %v,%v: %s",
+		Summary:  "failed to put allowance for client,delegate",
+	},
+	{
+		ID:       "verifreg.failed-to-flush-datacap-allowances",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.SetDataCapAllowance, builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to flush datacap allowances: %s",
+		Summary:  "failed to flush datacap allowances",
+	},
+	{
+		ID:       "verifreg.failed-to-resolve-delegate-address",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to resolve delegate address %v: %s",
+		Summary:  "failed to resolve delegate address",
+	},
+	{
+		ID:       "verifreg.verifieddealsize-below-minimum-in-usebytesdelegated",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalArgument,
+		Template: "VerifiedDealSize: %d below minimum in UseBytesDelegated",
+		Summary:  "VerifiedDealSize: below minimum in UseBytesDelegated",
+	},
+	{
+		ID:       "verifreg.failed-to-get-allowance-for-client-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to get allowance for client,delegate %v,%v: %s",
+		Summary:  "failed to get allowance for client,delegate",
+	},
+	{
+		ID:       "verifreg.client-has-no-allowance-for-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrForbidden,
+		Template: "client %v has no allowance for delegate %v",
+		Summary:  "client has no allowance for delegate",
+	},
+	{
+		ID:       "verifreg.allowance-from-client-to-delegate-expired-at",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrForbidden,
+		Template: "allowance from client %v to delegate %v expired at %d",
+		Summary:  "allowance from client to delegate expired at",
+	},
+	{
+		ID:       "verifreg.dealsize-exceeds-allowance-from-client-to-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrForbidden,
+		Template: "DealSize %d exceeds allowance %d from client %v to delegate %v",
+		Summary:  "DealSize exceeds allowance from client to delegate",
+	},
+	{
+		ID:       "verifreg.failed-to-update-allowance-for-client-delegate",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodsVerifiedRegistry.UseBytesDelegated},
+		Code:     exitcode.ErrIllegalState,
+		Template: "failed to update allowance for client,delegate %v,%v: %s",
+		Summary:  "failed to update allowance for client,delegate",
+	},
+	{
+		ID:       "verifreg.no-builtin-actor-with-code",
+		Actor:    builtin.VerifiedRegistryActorCodeID,
+		Methods:  []abi.MethodNum{builtin.MethodGetActorInfo},
+		Code:     exitcode.ErrIllegalState,
+		Template: "no builtin actor with code %v",
+		Summary:  "no builtin actor with code",
+	},
+}
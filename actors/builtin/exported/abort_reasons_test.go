@@ -0,0 +1,55 @@
+package exported_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestAbortReasons(t *testing.T) {
+	t.Run("actor specific exit code", func(t *testing.T) {
+		reasons := exported.AbortReasons(builtin.StorageMinerActorCodeID, builtin.MethodsMiner.TerminateSectors, miner.ErrMinerPaused)
+		require.Len(t, reasons, 1)
+		assert.Equal(t, "miner.miner-is-paused", reasons[0].ID)
+		assert.Equal(t, "miner is paused", reasons[0].Summary)
+
+		found, ok := exported.LookupAbortReason(reasons[0].ID)
+		require.True(t, ok)
+		assert.Equal(t, reasons[0], found)
+	})
+
+	t.Run("match message", func(t *testing.T) {
+		addr := tutil.NewIDAddr(t, 100)
+		message := fmt.Sprintf("address must use BLS or SECP protocol, got %v", addr)
+		r, ok := exported.MatchAbortReason(builtin.AccountActorCodeID, builtin.MethodsAccount.Constructor, exitcode.ErrIllegalArgument, message)
+		require.True(t, ok)
+		assert.Equal(t, "account.address-must-use-bls-or-secp-protocol-got", r.ID)
+
+		_, ok = exported.MatchAbortReason(builtin.AccountActorCodeID, builtin.MethodsAccount.Constructor, exitcode.ErrIllegalState, message)
+		assert.False(t, ok)
+		_, ok = exported.MatchAbortReason(builtin.AccountActorCodeID, builtin.MethodsAccount.Constructor, exitcode.ErrIllegalArgument, "something else")
+		assert.False(t, ok)
+	})
+
+	t.Run("every reason belongs to exported methods", func(t *testing.T) {
+		seen := map[string]bool{}
+		for _, m := range exported.BuiltinMethods() {
+			for _, r := range exported.MethodAbortReasons(m.Code, m.Number) {
+				assert.Equal(t, m.Code, r.Actor)
+				assert.NotEmpty(t, r.Template)
+				assert.True(t, r.Code.IsError(), r.ID)
+				seen[r.ID] = true
+			}
+		}
+		// the GetActorInfo abort is reachable from every actor
+		assert.True(t, seen["miner.no-builtin-actor-with-code"])
+	})
+}
@@ -0,0 +1,58 @@
+package exported_test
+
+import (
+	"testing"
+
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestGetActorInfo(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	caller := tutil.NewIDAddr(t, 101)
+	schemas := map[cid.Cid]struct{}{}
+
+	for _, actor := range exported.BuiltinActors() {
+		rt := mock.NewBuilder(receiver).
+			WithCaller(caller, builtin.AccountActorCodeID).
+			Build(t)
+
+		rt.ExpectValidateCallerAny()
+		ret := rt.Call(actor.Exports()[builtin.MethodGetActorInfo], nil).(*builtin.GetActorInfoReturn)
+		rt.Verify()
+
+		assert.Equal(t, builtin.ActorNameByCode(actor.Code()), "fil/7/"+ret.Name)
+		assert.Equal(t, uint64(builtin.ActorsVersion), ret.Version)
+
+		// the state schema is derived from the actor's state type, and is distinct for every actor
+		expectedSchema, err := builtin.StateSchemaID(actor.State())
+		require.NoError(t, err)
+		assert.Equal(t, expectedSchema, ret.StateSchema)
+		_, found := schemas[ret.StateSchema]
+		require.False(t, found, "duplicate state schema %v for %s", ret.StateSchema, ret.Name)
+		schemas[ret.StateSchema] = struct{}{}
+	}
+}
+
+func TestStateSchemaID(t *testing.T) {
+	minerSchema, err := builtin.StateSchemaID(&miner.State{})
+	require.NoError(t, err)
+
+	// the identifier depends only on the state type
+	again, err := builtin.StateSchemaID(miner.State{})
+	require.NoError(t, err)
+	assert.Equal(t, minerSchema, again)
+
+	// a state type referencing a type with a different encoding has a different identifier
+	minerSchema6, err := builtin.StateSchemaID(&miner6.State{})
+	require.NoError(t, err)
+	assert.NotEqual(t, minerSchema, minerSchema6)
+}
@@ -0,0 +1,90 @@
+package exported
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// The immediate callers permitted to invoke a builtin method, as validated by the method with the runtime's
+// ValidateImmediateCaller* calls. A method which validates its caller differently on different paths permits
+// the union of the callers of each validation, while a method that makes no validation call permits any caller.
+// The catalog of policies is generated by gen/callers from the actors' code.
+type CallerPolicy struct {
+	// Whether any caller is permitted.
+	Any bool
+	// Addresses of the permitted callers that are fixed, such as singleton actors or governance addresses.
+	Addresses []addr.Address
+	// Code CIDs of the actors permitted to call, e.g. the signable types of accounts and multisigs.
+	Codes []cid.Cid
+	// Whether the actor itself is permitted to call, as when a multisig executes an approved proposal.
+	Receiver bool
+	// Whether some permitted callers are determined at call time from the actor's state or the parameters,
+	// such as a miner's owner, worker and control addresses, or a payment channel's parties.
+	Dynamic bool
+	// The validation calls from which the policy is derived, as written in the actor's code,
+	// e.g. "rt.ValidateImmediateCallerIs(builtin.CronActorAddr)".
+	Validations []string
+}
+
+// Checks whether an account might be permitted to call the method. Methods that are not callable by accounts
+// may only be invoked by other actors, such as the system, cron or power actors, and so are never the target
+// of a message signed by a user.
+func (p *CallerPolicy) CallableByAccounts() bool {
+	if p.Any || p.Dynamic {
+		return true
+	}
+	for _, c := range p.Codes {
+		if c.Equals(builtin.AccountActorCodeID) {
+			return true
+		}
+	}
+	for _, a := range p.Addresses {
+		if !isSingleton(a) {
+			// a fixed address, such as of a governance key, which may hold an account
+			return true
+		}
+	}
+	return false
+}
+
+// Checks whether a caller is permitted by the policy, given its ID address and code CID.
+// Dynamic callers are not known without the actor's state, so a caller not otherwise permitted is reported
+// as possibly permitted if the policy has any.
+func (p *CallerPolicy) Permits(receiver, caller addr.Address, callerCode cid.Cid) (permitted bool, certain bool) {
+	if p.Any || (p.Receiver && caller == receiver) {
+		return true, true
+	}
+	for _, a := range p.Addresses {
+		if a == caller {
+			return true, true
+		}
+	}
+	for _, c := range p.Codes {
+		if c.Equals(callerCode) {
+			return true, true
+		}
+	}
+	return p.Dynamic, !p.Dynamic
+}
+
+var singletonAddresses = []addr.Address{
+	builtin.SystemActorAddr,
+	builtin.InitActorAddr,
+	builtin.RewardActorAddr,
+	builtin.CronActorAddr,
+	builtin.StoragePowerActorAddr,
+	builtin.StorageMarketActorAddr,
+	builtin.VerifiedRegistryActorAddr,
+	builtin.BurntFundsActorAddr,
+}
+
+func isSingleton(a addr.Address) bool {
+	for _, s := range singletonAddresses {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
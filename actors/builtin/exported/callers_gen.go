@@ -0,0 +1,456 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen/callers. DO NOT EDIT.
+
+package exported
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+)
+
+var methodCallers = map[methodKey]*CallerPolicy{
+	{builtin.AccountActorCodeID, builtin.MethodsAccount.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.AccountActorCodeID, builtin.MethodsAccount.PubkeyAddress}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.AccountActorCodeID, builtin.MethodsAccount.AuthenticateMessage}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.AccountActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.CronActorCodeID, builtin.MethodsCron.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.CronActorCodeID, builtin.MethodsCron.EpochTick}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.CronActorCodeID, builtin.MethodsCron.AddEntry}: {
+		Addresses:   []addr.Address{cron.GovernanceAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(GovernanceAddr)"},
+	},
+	{builtin.CronActorCodeID, builtin.MethodsCron.RemoveEntry}: {
+		Addresses:   []addr.Address{cron.GovernanceAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(GovernanceAddr)"},
+	},
+	{builtin.CronActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.InitActorCodeID, builtin.MethodsInit.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.InitActorCodeID, builtin.MethodsInit.Exec}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.InitActorCodeID, builtin.MethodsInit.ExecWithSalt}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.InitActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.AddBalance}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.WithdrawBalance}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(approvedCallers...)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.PublishStorageDeals}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.VerifyDealsForActivation}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.ActivateDeals}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.OnMinerSectorsTerminate}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.ComputeDataCommitment}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.CronTick}: {
+		Addresses:   []addr.Address{builtin.CronActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.CronActorAddr)"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.GetDealPriceStats}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodsMarket.GetDeals}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMarketActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.Constructor}: {
+		Addresses:   []addr.Address{builtin.InitActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.InitActorAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ControlAddresses}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangeWorkerAddress}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangePeerID}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.SubmitWindowedPoSt}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.PreCommitSector}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ProveCommitSector}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ExtendSectorExpiration}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.TerminateSectors}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.DeclareFaults}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.DeclareFaultsRecovered}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.OnDeferredCronEvent}: {
+		Addresses:   []addr.Address{builtin.StoragePowerActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StoragePowerActorAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.CheckSectorProven}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ApplyRewards}: {
+		Addresses:   []addr.Address{builtin.RewardActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.RewardActorAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ReportConsensusFault}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.WithdrawBalance}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner, info.Beneficiary)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ConfirmSectorProofsValid}: {
+		Addresses:   []addr.Address{builtin.StoragePowerActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StoragePowerActorAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangeMultiaddrs}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.CompactPartitions}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.CompactSectorNumbers}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ConfirmUpdateWorkerKey}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.RepayDebt}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangeOwnerAddress}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner)", "rt.ValidateImmediateCallerIs(*info.PendingOwnerAddress)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.DisputeWindowedPoSt}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.PreCommitSectorBatch}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ProveCommitAggregate}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ProveReplicaUpdates}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangeBeneficiary}: {
+		Any: true,
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.GetBeneficiary}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.GetFeeDebtStatus}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.PauseMiner}: {
+		Addresses:   []addr.Address{miner.PauseGovernanceAddr},
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner, PauseGovernanceAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.UnpauseMiner}: {
+		Addresses:   []addr.Address{miner.PauseGovernanceAddr},
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(info.Owner, PauseGovernanceAddr)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.DeclareUnsealingWindow}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodsMiner.GetUnsealingWindows}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StorageMinerActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.Constructor}: {
+		Addresses:   []addr.Address{builtin.InitActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.InitActorAddr)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.Propose}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.Approve}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.Cancel}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.AddSigner}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.RemoveSigner}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.SwapSigner}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.ChangeNumApprovalsThreshold}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.LockBalance}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.ApproveMany}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.ProposeMany}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.SetSpendingLimit}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.SwapSignerAndReapprove}: {
+		Receiver:    true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(rt.Receiver())"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodsMultisig.ProposeWithMetadata}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.MultisigActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.Constructor}: {
+		Codes:       []cid.Cid{builtin.InitActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.InitActorCodeID)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.UpdateChannelState}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.Parties()...)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.Settle}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.Parties()...)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.Collect}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.Parties()...)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.CompactLanes}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.Payees()...)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodsPaych.UpdateChannelStateMany}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.Parties()...)"},
+	},
+	{builtin.PaymentChannelActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.CreateMiner}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.UpdateClaimedPower}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.EnrollCronEvent}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.CronTick}: {
+		Addresses:   []addr.Address{builtin.CronActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.CronActorAddr)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.UpdatePledgeTotal}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.SubmitPoRepForBulkVerify}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.CurrentTotalPower}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodsPower.OnNetworkVersionChange}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.StoragePowerActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodsReward.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodsReward.AwardBlockReward}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodsReward.ThisEpochReward}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodsReward.UpdateNetworkKPI}: {
+		Addresses:   []addr.Address{builtin.StoragePowerActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StoragePowerActorAddr)"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodsReward.RecyclePenalty}: {
+		Codes:       []cid.Cid{builtin.StorageMinerActorCodeID},
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)"},
+	},
+	{builtin.RewardActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.SystemActorCodeID, builtin.MethodConstructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.SystemActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.Constructor}: {
+		Addresses:   []addr.Address{builtin.SystemActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.AddVerifier}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.RootKey)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.RemoveVerifier}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.RootKey)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.AddVerifiedClient}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.UseBytes}: {
+		Addresses:   []addr.Address{builtin.StorageMarketActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StorageMarketActorAddr)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.RestoreBytes}: {
+		Addresses:   []addr.Address{builtin.StorageMarketActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StorageMarketActorAddr)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.RemoveVerifiedClientDataCap}: {
+		Dynamic:     true,
+		Validations: []string{"rt.ValidateImmediateCallerIs(st.RootKey)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.SetDataCapAllowance}: {
+		Codes:       builtin.CallerTypesSignable,
+		Validations: []string{"rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodsVerifiedRegistry.UseBytesDelegated}: {
+		Addresses:   []addr.Address{builtin.StorageMarketActorAddr},
+		Validations: []string{"rt.ValidateImmediateCallerIs(builtin.StorageMarketActorAddr)"},
+	},
+	{builtin.VerifiedRegistryActorCodeID, builtin.MethodGetActorInfo}: {
+		Any:         true,
+		Validations: []string{"rt.ValidateImmediateCallerAcceptAny()"},
+	},
+}
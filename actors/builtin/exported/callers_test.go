@@ -0,0 +1,89 @@
+package exported_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestCallerPolicy(t *testing.T) {
+	lookup := func(code cid.Cid, method abi.MethodNum) *exported.CallerPolicy {
+		m, ok := exported.LookupMethod(code, method)
+		require.True(t, ok)
+		require.NotNil(t, m.Callers)
+		return m.Callers
+	}
+	receiver := tutil.NewIDAddr(t, 1000)
+	account := tutil.NewIDAddr(t, 1001)
+
+	t.Run("singleton caller", func(t *testing.T) {
+		p := lookup(builtin.StoragePowerActorCodeID, builtin.MethodsPower.CronTick)
+		assert.Equal(t, []string{"rt.ValidateImmediateCallerIs(builtin.CronActorAddr)"}, p.Validations)
+		assert.False(t, p.CallableByAccounts())
+
+		permitted, certain := p.Permits(builtin.StoragePowerActorAddr, builtin.CronActorAddr, builtin.CronActorCodeID)
+		assert.True(t, permitted)
+		assert.True(t, certain)
+		permitted, certain = p.Permits(builtin.StoragePowerActorAddr, account, builtin.AccountActorCodeID)
+		assert.False(t, permitted)
+		assert.True(t, certain)
+	})
+
+	t.Run("caller types", func(t *testing.T) {
+		p := lookup(builtin.StorageMarketActorCodeID, builtin.MethodsMarket.AddBalance)
+		assert.Equal(t, builtin.CallerTypesSignable, p.Codes)
+		assert.True(t, p.CallableByAccounts())
+
+		p = lookup(builtin.StoragePowerActorCodeID, builtin.MethodsPower.UpdateClaimedPower)
+		assert.Equal(t, []cid.Cid{builtin.StorageMinerActorCodeID}, p.Codes)
+		assert.False(t, p.CallableByAccounts())
+		permitted, _ := p.Permits(builtin.StoragePowerActorAddr, account, builtin.AccountActorCodeID)
+		assert.False(t, permitted)
+	})
+
+	t.Run("callers from state", func(t *testing.T) {
+		p := lookup(builtin.StorageMinerActorCodeID, builtin.MethodsMiner.SubmitWindowedPoSt)
+		assert.True(t, p.Dynamic)
+		assert.True(t, p.CallableByAccounts())
+		permitted, certain := p.Permits(receiver, account, builtin.AccountActorCodeID)
+		assert.True(t, permitted)
+		assert.False(t, certain)
+
+		// a method delegating to another takes its policy
+		assert.Equal(t, p.Validations, lookup(builtin.StorageMinerActorCodeID, builtin.MethodsMiner.PreCommitSector).Validations)
+	})
+
+	t.Run("governance and receiver", func(t *testing.T) {
+		p := lookup(builtin.CronActorCodeID, builtin.MethodsCron.AddEntry)
+		assert.Equal(t, cron.GovernanceAddr, p.Addresses[0])
+		assert.True(t, p.CallableByAccounts())
+
+		p = lookup(builtin.MultisigActorCodeID, builtin.MethodsMultisig.AddSigner)
+		assert.True(t, p.Receiver)
+		assert.False(t, p.CallableByAccounts())
+		permitted, certain := p.Permits(receiver, receiver, builtin.MultisigActorCodeID)
+		assert.True(t, permitted)
+		assert.True(t, certain)
+	})
+
+	t.Run("every method validates its caller", func(t *testing.T) {
+		for _, m := range exported.BuiltinMethods() {
+			p := m.Callers
+			if len(p.Validations) == 0 {
+				// permits any caller, checking the caller itself
+				assert.True(t, p.Any, "%s.%s", m.Actor, m.Name)
+				continue
+			}
+			assert.True(t, p.Any || p.Receiver || p.Dynamic || len(p.Addresses) > 0 || len(p.Codes) > 0, "%s.%s", m.Actor, m.Name)
+		}
+		assert.True(t, lookup(builtin.AccountActorCodeID, builtin.MethodGetActorInfo).Any)
+	})
+}
@@ -0,0 +1,200 @@
+package exported
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// Describes a method exported by a builtin actor, so that its messages can be decoded without knowledge of
+// the actor's method numbers or parameter types.
+type MethodInfo struct {
+	Code   cid.Cid       // code CID of the actor exporting the method
+	Actor  string        // name of the actor, e.g. "fil/7/storageminer"
+	Number abi.MethodNum // method number
+	Name   string        // name of the actor method, e.g. "TerminateSectors"
+
+	// Pointer types of the parameters and return value, as unmarshalled by the actor.
+	// Nil if the method takes no parameters or returns no value.
+	Params reflect.Type
+	Return reflect.Type
+
+	// Names of the parameter and return types in the schema returned by MethodSchema.
+	// Empty if the method takes no parameters or returns no value.
+	ParamsSchema string
+	ReturnSchema string
+
+	// The immediate callers permitted to invoke the method.
+	Callers *CallerPolicy
+}
+
+// Returns a new value of the method's parameter type to unmarshal parameters into,
+// or nil if the method takes no parameters.
+func (m *MethodInfo) NewParams() cbor.Unmarshaler {
+	return newValue(m.Params)
+}
+
+// Returns a new value of the method's return type to unmarshal a return value into,
+// or nil if the method returns no value.
+func (m *MethodInfo) NewReturn() cbor.Unmarshaler {
+	return newValue(m.Return)
+}
+
+type methodKey struct {
+	code   cid.Cid
+	method abi.MethodNum
+}
+
+var (
+	methodList   []*MethodInfo
+	methodsByKey map[methodKey]*MethodInfo
+	methodSchema string
+)
+
+func init() {
+	schema := builtin.NewSchemaBuilder()
+	methodsByKey = make(map[methodKey]*MethodInfo)
+	for _, actor := range BuiltinActors() {
+		for num, export := range actor.Exports() {
+			if export == nil {
+				continue
+			}
+			m := newMethodInfo(actor.Code(), abi.MethodNum(num), export, schema)
+			methodList = append(methodList, m)
+			methodsByKey[methodKey{m.Code, m.Number}] = m
+		}
+	}
+	methodSchema = schema.String()
+}
+
+// Returns the method exported at a method number by the builtin actor with a code CID.
+func LookupMethod(code cid.Cid, method abi.MethodNum) (*MethodInfo, bool) {
+	m, ok := methodsByKey[methodKey{code, method}]
+	return m, ok
+}
+
+// Returns the methods exported by all builtin actors, in the order of BuiltinActors and then of method number.
+func BuiltinMethods() []*MethodInfo {
+	out := make([]*MethodInfo, len(methodList))
+	copy(out, methodList)
+	return out
+}
+
+// Returns an IPLD schema, in the schema DSL, declaring the parameter and return types of all builtin methods.
+// Structs are represented as tuples, as encoded by the actors.
+func MethodSchema() string {
+	return methodSchema
+}
+
+type methodRegistryJSON struct {
+	Schema  string           `json:"schema"`
+	Methods []methodInfoJSON `json:"methods"`
+}
+
+type methodInfoJSON struct {
+	Code         cid.Cid       `json:"code"`
+	Actor        string        `json:"actor"`
+	Number       abi.MethodNum `json:"number"`
+	Name         string        `json:"name"`
+	Params       string        `json:"params,omitempty"`
+	Return       string        `json:"return,omitempty"`
+	ParamsSchema string        `json:"paramsSchema,omitempty"`
+	ReturnSchema string        `json:"returnSchema,omitempty"`
+	Callers      callersJSON   `json:"callers"`
+}
+
+type callersJSON struct {
+	Any                bool           `json:"any,omitempty"`
+	Addresses          []addr.Address `json:"addresses,omitempty"`
+	Codes              []cid.Cid      `json:"codes,omitempty"`
+	Receiver           bool           `json:"receiver,omitempty"`
+	Dynamic            bool           `json:"dynamic,omitempty"`
+	CallableByAccounts bool           `json:"callableByAccounts"`
+}
+
+// Writes the registry of builtin methods and their schema as JSON, for decoders in other languages.
+func WriteMethodRegistry(w io.Writer) error {
+	out := methodRegistryJSON{Schema: methodSchema}
+	for _, m := range methodList {
+		out.Methods = append(out.Methods, methodInfoJSON{
+			Code:         m.Code,
+			Actor:        m.Actor,
+			Number:       m.Number,
+			Name:         m.Name,
+			Params:       typeString(m.Params),
+			Return:       typeString(m.Return),
+			ParamsSchema: m.ParamsSchema,
+			ReturnSchema: m.ReturnSchema,
+			Callers: callersJSON{
+				Any:                m.Callers.Any,
+				Addresses:          m.Callers.Addresses,
+				Codes:              m.Callers.Codes,
+				Receiver:           m.Callers.Receiver,
+				Dynamic:            m.Callers.Dynamic,
+				CallableByAccounts: m.Callers.CallableByAccounts(),
+			},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&out); err != nil {
+		return xerrors.Errorf("failed to encode method registry: %w", err)
+	}
+	return nil
+}
+
+var emptyValueType = reflect.TypeOf(&abi.EmptyValue{})
+
+func newMethodInfo(code cid.Cid, num abi.MethodNum, export interface{}, schema *builtin.SchemaBuilder) *MethodInfo {
+	fn := reflect.ValueOf(export)
+	name := goruntime.FuncForPC(fn.Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	name = name[strings.LastIndexByte(name, '.')+1:]
+
+	m := &MethodInfo{
+		Code:   code,
+		Actor:  builtin.ActorNameByCode(code),
+		Number: num,
+		Name:   name,
+	}
+	if p, ok := methodCallers[methodKey{code, num}]; ok {
+		m.Callers = p
+	} else {
+		m.Callers = &CallerPolicy{}
+	}
+	// Methods have the signature func(rt runtime.Runtime, params *P) *R, with params and return optional.
+	if fn.Type().NumIn() > 1 && fn.Type().In(1) != emptyValueType {
+		m.Params = fn.Type().In(1)
+		m.ParamsSchema = schema.TypeName(m.Params)
+	}
+	if fn.Type().NumOut() > 0 && fn.Type().Out(0) != emptyValueType {
+		m.Return = fn.Type().Out(0)
+		m.ReturnSchema = schema.TypeName(m.Return)
+	}
+	return m
+}
+
+func newValue(t reflect.Type) cbor.Unmarshaler {
+	if t == nil {
+		return nil
+	}
+	v, _ := reflect.New(t.Elem()).Interface().(cbor.Unmarshaler)
+	return v
+}
+
+func typeString(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,91 @@
+package exported_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+)
+
+func TestLookupMethod(t *testing.T) {
+	t.Run("params and return", func(t *testing.T) {
+		m, ok := exported.LookupMethod(builtin.StorageMinerActorCodeID, builtin.MethodsMiner.TerminateSectors)
+		require.True(t, ok)
+		assert.Equal(t, "TerminateSectors", m.Name)
+		assert.Equal(t, builtin.ActorNameByCode(builtin.StorageMinerActorCodeID), m.Actor)
+		assert.Equal(t, reflect.TypeOf(&miner.TerminateSectorsParams{}), m.Params)
+		assert.Equal(t, reflect.TypeOf(&miner.TerminateSectorsReturn{}), m.Return)
+		assert.Equal(t, "MinerTerminateSectorsParamsV0", m.ParamsSchema)
+		assert.IsType(t, &miner.TerminateSectorsParams{}, m.NewParams())
+		assert.IsType(t, &miner.TerminateSectorsReturn{}, m.NewReturn())
+	})
+
+	t.Run("no params", func(t *testing.T) {
+		m, ok := exported.LookupMethod(builtin.RewardActorCodeID, builtin.MethodsReward.ThisEpochReward)
+		require.True(t, ok)
+		assert.Nil(t, m.Params)
+		assert.Nil(t, m.NewParams())
+		assert.Empty(t, m.ParamsSchema)
+		assert.IsType(t, &reward.ThisEpochRewardReturn{}, m.NewReturn())
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, ok := exported.LookupMethod(builtin.StorageMinerActorCodeID, abi.MethodNum(1000))
+		assert.False(t, ok)
+		_, ok = exported.LookupMethod(builtin.StorageMinerActorCodeID, builtin.MethodSend)
+		assert.False(t, ok)
+	})
+}
+
+func TestBuiltinMethods(t *testing.T) {
+	schema := exported.MethodSchema()
+	for _, m := range exported.BuiltinMethods() {
+		found, ok := exported.LookupMethod(m.Code, m.Number)
+		require.True(t, ok)
+		assert.Equal(t, m, found)
+
+		// every named parameter and return type is declared in the schema
+		for _, name := range []string{m.ParamsSchema, m.ReturnSchema} {
+			if name == "" || name[0] < 'A' || name[0] > 'Z' {
+				continue
+			}
+			assert.Contains(t, schema, "type "+name+" ", "%s.%s", m.Actor, m.Name)
+		}
+	}
+
+	t.Run("registry JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, exported.WriteMethodRegistry(&buf))
+		var registry struct {
+			Schema  string
+			Methods []struct {
+				Code    map[string]string
+				Number  abi.MethodNum
+				Name    string
+				Params  string
+				Callers struct {
+					CallableByAccounts bool
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &registry))
+		assert.Equal(t, schema, registry.Schema)
+		require.Len(t, registry.Methods, len(exported.BuiltinMethods()))
+		for _, m := range registry.Methods {
+			if m.Code["/"] == builtin.StorageMinerActorCodeID.String() && m.Number == builtin.MethodsMiner.PreCommitSectorBatch {
+				assert.Equal(t, "PreCommitSectorBatch", m.Name)
+				assert.Equal(t, "*miner.PreCommitSectorBatchParams", m.Params)
+				assert.True(t, m.Callers.CallableByAccounts)
+			}
+		}
+	})
+}
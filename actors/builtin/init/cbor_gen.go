@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 
+	address "github.com/filecoin-project/go-address"
 	abi "github.com/filecoin-project/go-state-types/abi"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
@@ -13,7 +14,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{131}
+var lengthBufState = []byte{132}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -49,6 +50,12 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 	if _, err := io.WriteString(w, string(t.NetworkName)); err != nil {
 		return err
 	}
+
+	// t.ActorAddresses (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.ActorAddresses); err != nil {
+		return xerrors.Errorf("failed to write cid field t.ActorAddresses: %w", err)
+	}
 	return nil
 }
 
@@ -66,7 +73,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 3 {
+	if extra != 4 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -106,5 +113,218 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.NetworkName = string(sval)
 	}
+	// t.ActorAddresses (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.ActorAddresses: %w", err)
+		}
+
+		t.ActorAddresses = c
+
+	}
+	return nil
+}
+
+var lengthBufExecWithSaltParams = []byte{131}
+
+func (t *ExecWithSaltParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufExecWithSaltParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.CodeCID (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.CodeCID); err != nil {
+		return xerrors.Errorf("failed to write cid field t.CodeCID: %w", err)
+	}
+
+	// t.ConstructorParams ([]uint8) (slice)
+	if len(t.ConstructorParams) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.ConstructorParams was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.ConstructorParams))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.ConstructorParams[:]); err != nil {
+		return err
+	}
+
+	// t.Salt ([]uint8) (slice)
+	if len(t.Salt) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.Salt was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Salt))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.Salt[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ExecWithSaltParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ExecWithSaltParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.CodeCID (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.CodeCID: %w", err)
+		}
+
+		t.CodeCID = c
+
+	}
+	// t.ConstructorParams ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.ConstructorParams: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.ConstructorParams = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.ConstructorParams[:]); err != nil {
+		return err
+	}
+	// t.Salt ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Salt: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.Salt = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.Salt[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufActorAddresses = []byte{129}
+
+func (t *ActorAddresses) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufActorAddresses); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Addresses ([]address.Address) (slice)
+	if len(t.Addresses) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Addresses was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Addresses))); err != nil {
+		return err
+	}
+	for _, v := range t.Addresses {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ActorAddresses) UnmarshalCBOR(r io.Reader) error {
+	*t = ActorAddresses{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Addresses ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Addresses: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Addresses = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Addresses[i] = v
+	}
+
 	return nil
 }
@@ -1,14 +1,20 @@
 package init
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	init0 "github.com/filecoin-project/specs-actors/actors/builtin/init"
 	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/clients/accountclient"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
@@ -19,8 +25,10 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.Exec,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.Exec,
+		3:                          a.ExecWithSalt,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -36,6 +44,11 @@ func (a Actor) State() cbor.Er { return new(State) }
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 //type ConstructorParams struct {
 //	NetworkName string
 //}
@@ -95,6 +108,63 @@ func (a Actor) Exec(rt runtime.Runtime, params *ExecParams) *ExecReturn {
 	return &ExecReturn{IDAddress: idAddr, RobustAddress: uniqueAddress}
 }
 
+// Maximum length of the salt from which ExecWithSalt derives an actor's address.
+const MaxExecSaltLength = 32
+
+type ExecWithSaltParams struct {
+	CodeCID           cid.Cid `checked:"true"` // invalid CIDs won't get committed to the state tree
+	ConstructorParams []byte
+	Salt              []byte
+}
+
+// Creates an actor, like Exec, but at a robust address derived from the creator and a salt chosen by the caller,
+// rather than from the message being executed.
+// The same creator and salt yield the same address on any network, so the address can be known, and
+// published, before the actor is created. See SaltedActorAddress for the creator of an actor.
+// Each address can be assigned only once: the call aborts if an actor already exists at the address.
+func (a Actor) ExecWithSalt(rt runtime.Runtime, params *ExecWithSaltParams) *ExecReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	callerCodeCID, ok := rt.GetActorCodeCID(rt.Caller())
+	builtin.RequireState(rt, ok, "no code for caller at %s", rt.Caller())
+	if !canExec(callerCodeCID, params.CodeCID) {
+		rt.Abortf(exitcode.ErrForbidden, "caller type %v cannot exec actor type %v", callerCodeCID, params.CodeCID)
+	}
+	if len(params.Salt) == 0 || len(params.Salt) > MaxExecSaltLength {
+		rt.Abortf(exitcode.ErrIllegalArgument, "salt length %d must be in [1, %d]", len(params.Salt), MaxExecSaltLength)
+	}
+
+	// An account is identified by its public key, which is the same on every network,
+	// while other actors can only be identified by their ID.
+	creator := rt.Caller()
+	if callerCodeCID == builtin.AccountActorCodeID {
+		pubkey, code := accountclient.PubkeyAddress(rt, creator, big.Zero())
+		builtin.RequireSuccess(rt, code, "failed to fetch account pubkey from %v", rt.Caller())
+		creator = pubkey
+	}
+	uniqueAddress, err := SaltedActorAddress(creator, params.Salt)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to derive actor address")
+
+	var st State
+	var idAddr addr.Address
+	rt.StateTransaction(&st, func() {
+		store := adt.AsStore(rt)
+		_, found, err := st.ResolveAddress(store, uniqueAddress)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve address %v", uniqueAddress)
+		if found {
+			rt.Abortf(exitcode.ErrForbidden, "address %v already in use by creator %v with salt %x", uniqueAddress, creator, params.Salt)
+		}
+		idAddr, err = st.MapAddressToNewID(store, uniqueAddress)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to allocate ID address")
+	})
+
+	rt.CreateActor(params.CodeCID, idAddr)
+
+	code := rt.Send(idAddr, builtin.MethodConstructor, builtin.CBORBytes(params.ConstructorParams), rt.ValueReceived(), &builtin.Discard{})
+	builtin.RequireSuccess(rt, code, "constructor failed")
+
+	return &ExecReturn{IDAddress: idAddr, RobustAddress: uniqueAddress}
+}
+
 func canExec(callerCodeID cid.Cid, execCodeID cid.Cid) bool {
 	switch execCodeID {
 	case builtin.StorageMinerActorCodeID:
@@ -108,3 +178,52 @@ func canExec(callerCodeID cid.Cid, execCodeID cid.Cid) bool {
 		return false
 	}
 }
+
+// Computes the re-org-stable actor address which the runtime's NewActorAddress, and hence Exec, assigns to
+// an actor created while executing a message.
+// The creator is the stable (public key) address of the message's sender, nonce is the message nonce, and
+// execCount is the number of actor addresses previously generated while executing the same message.
+func ActorAddress(creator addr.Address, nonce uint64, execCount uint64) (addr.Address, error) {
+	var buf bytes.Buffer
+	if err := creator.MarshalCBOR(&buf); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to serialize creator %v: %w", creator, err)
+	}
+	if err := binary.Write(&buf, binary.BigEndian, nonce); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to serialize nonce: %w", err)
+	}
+	if err := binary.Write(&buf, binary.BigEndian, execCount); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to serialize exec count: %w", err)
+	}
+	return addr.NewActorAddress(buf.Bytes())
+}
+
+// Separates the addresses derived from a salt from those derived from a message, which have no prefix.
+var saltedAddressPrefix = []byte("exec-with-salt")
+
+// Computes the robust address which ExecWithSalt assigns to an actor created by a creator with a salt.
+// The creator is the public key address of an account actor calling ExecWithSalt, or the ID address of any other caller.
+func SaltedActorAddress(creator addr.Address, salt []byte) (addr.Address, error) {
+	var buf bytes.Buffer
+	buf.Write(saltedAddressPrefix)
+	if err := creator.MarshalCBOR(&buf); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to serialize creator %v: %w", creator, err)
+	}
+	buf.Write(salt)
+	return addr.NewActorAddress(buf.Bytes())
+}
+
+// Predicts the addresses which Exec will return for an actor created while executing a message,
+// given the init actor state before the message is executed. See ActorAddress for the other parameters.
+// The robust address is certain, while the ID address assumes that the init actor assigns no other
+// IDs, including to the other actors created by the same message, before creating the actor.
+func PredictExecAddresses(st *State, creator addr.Address, nonce uint64, execCount uint64) (*ExecReturn, error) {
+	robust, err := ActorAddress(creator, nonce, execCount)
+	if err != nil {
+		return nil, err
+	}
+	id, err := addr.NewIDAddress(uint64(st.NextID))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to make ID address: %w", err)
+	}
+	return &ExecReturn{IDAddress: id, RobustAddress: robust}, nil
+}
@@ -15,18 +15,32 @@ type State struct {
 	AddressMap  cid.Cid // HAMT[addr.Address]abi.ActorID
 	NextID      abi.ActorID
 	NetworkName string
+	// Reverse index of AddressMap.
+	ActorAddresses cid.Cid // HAMT[abi.ActorID]ActorAddresses
+}
+
+// The addresses mapped to an actor ID, in the order in which they were mapped.
+type ActorAddresses struct {
+	Addresses []addr.Address
+}
+
+// An entry of the address map.
+type AddressMapping struct {
+	Address addr.Address
+	ID      abi.ActorID
 }
 
 func ConstructState(store adt.Store, networkName string) (*State, error) {
-	emptyAddressMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	emptyMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty map: %w", err)
 	}
 
 	return &State{
-		AddressMap:  emptyAddressMapCid,
-		NextID:      abi.ActorID(builtin.FirstNonSingletonActorId),
-		NetworkName: networkName,
+		AddressMap:     emptyMapCid,
+		NextID:         abi.ActorID(builtin.FirstNonSingletonActorId),
+		NetworkName:    networkName,
+		ActorAddresses: emptyMapCid,
 	}, nil
 }
 
@@ -82,6 +96,88 @@ func (s *State) MapAddressToNewID(store adt.Store, address addr.Address) (addr.A
 	}
 	s.AddressMap = amr
 
+	if err := s.addActorAddress(store, abi.ActorID(actorID), address); err != nil {
+		return addr.Undef, err
+	}
+
 	idAddr, err := addr.NewIDAddress(uint64(actorID))
 	return idAddr, err
 }
+
+// Records an address mapped to an actor ID in the reverse index.
+func (s *State) addActorAddress(store adt.Store, id abi.ActorID, address addr.Address) error {
+	m, err := adt.AsMap(store, s.ActorAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load actor addresses: %w", err)
+	}
+	var addrs ActorAddresses
+	if _, err := m.Get(builtin.ActorIDKey(id), &addrs); err != nil {
+		return xerrors.Errorf("failed to get addresses for actor %d: %w", id, err)
+	}
+	addrs.Addresses = append(addrs.Addresses, address)
+	if err := m.Put(builtin.ActorIDKey(id), &addrs); err != nil {
+		return xerrors.Errorf("failed to put addresses for actor %d: %w", id, err)
+	}
+	if s.ActorAddresses, err = m.Root(); err != nil {
+		return xerrors.Errorf("failed to get actor addresses root: %w", err)
+	}
+	return nil
+}
+
+// Returns the (non-ID) addresses mapped to an actor ID, in the order in which they were mapped.
+// Returns an empty slice for a singleton actor, or an ID with no mapped addresses.
+func (s *State) LookupAddresses(store adt.Store, id abi.ActorID) ([]addr.Address, error) {
+	m, err := adt.AsMap(store, s.ActorAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load actor addresses: %w", err)
+	}
+	var addrs ActorAddresses
+	if _, err := m.Get(builtin.ActorIDKey(id), &addrs); err != nil {
+		return nil, xerrors.Errorf("failed to get addresses for actor %d: %w", id, err)
+	}
+	return addrs.Addresses, nil
+}
+
+// Returns up to limit entries of the address map following the entry for an address, and the address
+// from which to continue, or an undefined address if there are no more entries.
+// An undefined after address starts from the first entry. Entries are ordered by the hash of the address, which
+// is stable but not meaningful, and every page traverses the map from the first entry.
+func (s *State) ListAddresses(store adt.Store, after addr.Address, limit int) ([]AddressMapping, addr.Address, error) {
+	if limit <= 0 {
+		return nil, addr.Undef, xerrors.Errorf("invalid page limit %d", limit)
+	}
+	m, err := adt.AsMap(store, s.AddressMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, addr.Undef, xerrors.Errorf("failed to load address map: %w", err)
+	}
+
+	errStop := xerrors.New("stop")
+	var page []AddressMapping
+	next := addr.Undef
+	started := after == addr.Undef
+	afterKey := string(after.Bytes())
+	var actorID cbg.CborInt
+	err = m.ForEach(&actorID, func(key string) error {
+		if !started {
+			started = key == afterKey
+			return nil
+		}
+		a, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		if len(page) == limit {
+			next = page[len(page)-1].Address
+			return errStop
+		}
+		page = append(page, AddressMapping{Address: a, ID: abi.ActorID(actorID)})
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, addr.Undef, xerrors.Errorf("failed to iterate address map: %w", err)
+	}
+	if !started {
+		return nil, addr.Undef, xerrors.Errorf("address %v not found in address map", after)
+	}
+	return page, next, nil
+}
@@ -1,6 +1,8 @@
 package init_test
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -10,10 +12,12 @@ import (
 	"github.com/filecoin-project/go-state-types/exitcode"
 	cid "github.com/ipfs/go-cid"
 	assert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
 	"github.com/filecoin-project/specs-actors/v7/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
 )
@@ -212,6 +216,171 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestExecWithSalt(t *testing.T) {
+	actor := initHarness{init_.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 1000)
+	anne := tutil.NewIDAddr(t, 1001)
+	anneKey := tutil.NewBLSAddr(t, 1001)
+	multisigAddr := tutil.NewIDAddr(t, 1002)
+	builder := mock.NewBuilder(receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	var fakeParams = builtin.CBORBytes([]byte{'D', 'E', 'A', 'D', 'B', 'E', 'E', 'F'})
+	var balance = abi.NewTokenAmount(100)
+	salt := []byte("salt")
+
+	t.Run("account creates actor at address derived from its key and salt", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.SetBalance(balance)
+		rt.SetReceived(balance)
+
+		expectedRobust, err := init_.SaltedActorAddress(anneKey, salt)
+		assert.NoError(t, err)
+		expectedIdAddr := tutil.NewIDAddr(t, 100)
+		rt.ExpectSend(anne, builtin.MethodsAccount.PubkeyAddress, nil, big.Zero(), &anneKey, exitcode.Ok)
+		rt.ExpectCreateActor(builtin.PaymentChannelActorCodeID, expectedIdAddr)
+		rt.ExpectSend(expectedIdAddr, builtin.MethodConstructor, fakeParams, balance, nil, exitcode.Ok)
+		ret := actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, salt)
+		assert.Equal(t, expectedRobust, ret.RobustAddress)
+		assert.Equal(t, expectedIdAddr, ret.IDAddress)
+
+		resolved, found, err := actor.state(rt).ResolveAddress(adt.AsStore(rt), expectedRobust)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, expectedIdAddr, resolved)
+
+		// A different salt yields a different address.
+		rt.SetReceived(big.Zero())
+		otherIdAddr := tutil.NewIDAddr(t, 101)
+		rt.ExpectSend(anne, builtin.MethodsAccount.PubkeyAddress, nil, big.Zero(), &anneKey, exitcode.Ok)
+		rt.ExpectCreateActor(builtin.PaymentChannelActorCodeID, otherIdAddr)
+		rt.ExpectSend(otherIdAddr, builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		other := actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, []byte("pepper"))
+		assert.NotEqual(t, expectedRobust, other.RobustAddress)
+		assert.Equal(t, otherIdAddr, other.IDAddress)
+		actor.checkState(rt)
+	})
+
+	t.Run("non-account creator is identified by its ID address", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(multisigAddr, builtin.MultisigActorCodeID)
+
+		expectedRobust, err := init_.SaltedActorAddress(multisigAddr, salt)
+		assert.NoError(t, err)
+		expectedIdAddr := tutil.NewIDAddr(t, 100)
+		rt.ExpectCreateActor(builtin.MultisigActorCodeID, expectedIdAddr)
+		rt.ExpectSend(expectedIdAddr, builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		ret := actor.execWithSaltAndVerify(rt, builtin.MultisigActorCodeID, fakeParams, salt)
+		assert.Equal(t, expectedRobust, ret.RobustAddress)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails to reuse creator and salt", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(multisigAddr, builtin.MultisigActorCodeID)
+
+		expectedIdAddr := tutil.NewIDAddr(t, 100)
+		rt.ExpectCreateActor(builtin.PaymentChannelActorCodeID, expectedIdAddr)
+		rt.ExpectSend(expectedIdAddr, builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, salt)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "already in use", func() {
+			actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, salt)
+		})
+		assert.Equal(t, abi.ActorID(101), actor.state(rt).NextID)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails with empty or long salt", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(multisigAddr, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "salt length", func() {
+			actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, nil)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "salt length", func() {
+			actor.execWithSaltAndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, make([]byte, init_.MaxExecSaltLength+1))
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for actors that cannot exec", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.execWithSaltAndVerify(rt, builtin.StorageMinerActorCodeID, fakeParams, salt)
+		})
+		actor.checkState(rt)
+	})
+}
+
+func TestAddressLookups(t *testing.T) {
+	store := ipld.NewADTStore(context.Background())
+	st, err := init_.ConstructState(store, "mock")
+	require.NoError(t, err)
+
+	var robust []addr.Address
+	for i := 0; i < 10; i++ {
+		a := tutil.NewActorAddr(t, fmt.Sprintf("actor%d", i))
+		_, err := st.MapAddressToNewID(store, a)
+		require.NoError(t, err)
+		robust = append(robust, a)
+	}
+	_, msgs := init_.CheckStateInvariants(st, store)
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+
+	t.Run("reverse lookup", func(t *testing.T) {
+		for i, a := range robust {
+			addrs, err := st.LookupAddresses(store, abi.ActorID(builtin.FirstNonSingletonActorId+i))
+			require.NoError(t, err)
+			assert.Equal(t, []addr.Address{a}, addrs)
+		}
+
+		addrs, err := st.LookupAddresses(store, abi.ActorID(1)) // Init actor
+		require.NoError(t, err)
+		assert.Empty(t, addrs)
+	})
+
+	t.Run("pages through address map", func(t *testing.T) {
+		found := map[addr.Address]abi.ActorID{}
+		after := addr.Undef
+		pages := 0
+		for {
+			page, next, err := st.ListAddresses(store, after, 3)
+			require.NoError(t, err)
+			pages++
+			for _, entry := range page {
+				_, dup := found[entry.Address]
+				assert.False(t, dup)
+				found[entry.Address] = entry.ID
+			}
+			if next == addr.Undef {
+				break
+			}
+			after = next
+		}
+		assert.Equal(t, 4, pages)
+		require.Equal(t, len(robust), len(found))
+		for i, a := range robust {
+			assert.Equal(t, abi.ActorID(builtin.FirstNonSingletonActorId+i), found[a])
+		}
+	})
+
+	t.Run("fails to page from unknown address", func(t *testing.T) {
+		_, _, err := st.ListAddresses(store, tutil.NewActorAddr(t, "unknown"), 3)
+		assert.Error(t, err)
+		_, _, err = st.ListAddresses(store, addr.Undef, 0)
+		assert.Error(t, err)
+	})
+}
+
 type initHarness struct {
 	init_.Actor
 	t testing.TB
@@ -253,3 +422,14 @@ func (h *initHarness) execAndVerify(rt *mock.Runtime, codeID cid.Cid, constructo
 	rt.Verify()
 	return ret
 }
+
+func (h *initHarness) execWithSaltAndVerify(rt *mock.Runtime, codeID cid.Cid, constructorParams []byte, salt []byte) *init_.ExecReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.ExecWithSalt, &init_.ExecWithSaltParams{
+		CodeCID:           codeID,
+		ConstructorParams: constructorParams,
+		Salt:              salt,
+	}).(*init_.ExecReturn)
+	rt.Verify()
+	return ret
+}
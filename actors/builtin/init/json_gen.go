@@ -0,0 +1,54 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package init
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "AddressMap", Value: &t.AddressMap},
+		{Name: "NextID", Value: &t.NextID},
+		{Name: "NetworkName", Value: &t.NetworkName},
+		{Name: "ActorAddresses", Value: &t.ActorAddresses},
+	}
+}
+
+func (t ActorAddresses) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ActorAddresses) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ActorAddresses) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Addresses", Value: &t.Addresses},
+	}
+}
+
+func (t ExecWithSaltParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ExecWithSaltParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ExecWithSaltParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "CodeCID", Value: &t.CodeCID},
+		{Name: "ConstructorParams", Value: &t.ConstructorParams},
+		{Name: "Salt", Value: &t.Salt},
+	}
+}
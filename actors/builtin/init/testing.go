@@ -55,5 +55,32 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 		return nil
 	})
 	acc.RequireNoError(err, "error iterating address map")
+
+	// Check the reverse index holds exactly the entries of the address map.
+	actorAddrs, err := adt.AsMap(store, st.ActorAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading actor addresses: %v", err)
+		return initSummary, acc
+	}
+	indexedCount := 0
+	var addrs ActorAddresses
+	err = actorAddrs.ForEach(&addrs, func(key string) error {
+		id, err := builtin.ParseActorIDKey(key)
+		if err != nil {
+			return err
+		}
+		acc.Require(len(addrs.Addresses) > 0, "empty addresses for actor %d", id)
+		for _, a := range addrs.Addresses {
+			mappedID, found := initSummary.AddrIDs[a]
+			acc.Require(found, "indexed address %v for actor %d not in address map", a, id)
+			acc.Require(!found || mappedID == abi.ActorID(id), "indexed address %v for actor %d maps to %d", a, id, mappedID)
+		}
+		indexedCount += len(addrs.Addresses)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating actor addresses")
+	acc.Require(indexedCount == len(initSummary.AddrIDs), "actor addresses index %d addresses, address map has %d",
+		indexedCount, len(initSummary.AddrIDs))
+
 	return initSummary, acc
 }
@@ -0,0 +1,106 @@
+package builtin
+
+import (
+	"fmt"
+	"reflect"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+)
+
+// The keys of the HAMTs in builtin actor state, and parsers recovering the typed value from a key's bytes.
+// Maps keyed by the same type do not all use the same encoding, so each key function documents the
+// maps that use it.
+
+// Keys a map by the byte encoding of an address.
+// Used for power claims, balance tables, the init address map and the verified registry maps.
+func AddressKey(a addr.Address) abi.Keyer {
+	return abi.AddrKey(a)
+}
+
+func ParseAddressKey(k string) (addr.Address, error) {
+	return addr.NewFromBytes([]byte(k))
+}
+
+// Keys a map by an epoch, as a signed varint.
+// Used for the power actor's cron event queue.
+func EpochKey(e abi.ChainEpoch) abi.Keyer {
+	return abi.IntKey(int64(e))
+}
+
+func ParseEpochKey(k string) (abi.ChainEpoch, error) {
+	e, err := abi.ParseIntKey(k)
+	return abi.ChainEpoch(e), err
+}
+
+// Keys a map by a non-negative epoch, as an unsigned varint.
+// Used for the market actor's deal operations by epoch.
+func UnsignedEpochKey(e abi.ChainEpoch) abi.Keyer {
+	return abi.UIntKey(uint64(e))
+}
+
+func ParseUnsignedEpochKey(k string) (abi.ChainEpoch, error) {
+	e, err := abi.ParseUIntKey(k)
+	return abi.ChainEpoch(e), err
+}
+
+// Keys a map by a sector number, as an unsigned varint.
+// Used for the miner actor's pre-committed sectors.
+func SectorNumberKey(n abi.SectorNumber) abi.Keyer {
+	return abi.UIntKey(uint64(n))
+}
+
+func ParseSectorNumberKey(k string) (abi.SectorNumber, error) {
+	n, err := abi.ParseUIntKey(k)
+	return abi.SectorNumber(n), err
+}
+
+// Keys a map or set by a deal ID, as an unsigned varint.
+// Used for the members of the market actor's deal operation sets.
+func DealIDKey(id abi.DealID) abi.Keyer {
+	return abi.UIntKey(uint64(id))
+}
+
+func ParseDealIDKey(k string) (abi.DealID, error) {
+	id, err := abi.ParseUIntKey(k)
+	return abi.DealID(id), err
+}
+
+// Keys a map by an actor ID, as an unsigned varint.
+// Used for the init actor's map of addresses by actor.
+func ActorIDKey(id abi.ActorID) abi.Keyer {
+	return abi.UIntKey(uint64(id))
+}
+
+func ParseActorIDKey(k string) (abi.ActorID, error) {
+	id, err := abi.ParseUIntKey(k)
+	return abi.ActorID(id), err
+}
+
+// Keys a map or set by the binary encoding of a CID.
+// Used for the market actor's pending deal proposals.
+func CidKey(c cid.Cid) abi.Keyer {
+	return abi.CidKey(c)
+}
+
+func ParseCidKey(k string) (cid.Cid, error) {
+	return cid.Cast([]byte(k))
+}
+
+func init() {
+	// Check that the integer types have the signedness assumed by their key encodings.
+	for _, check := range []struct {
+		v    interface{}
+		kind reflect.Kind
+	}{
+		{abi.ChainEpoch(0), reflect.Int64},
+		{abi.SectorNumber(0), reflect.Uint64},
+		{abi.DealID(0), reflect.Uint64},
+		{abi.ActorID(0), reflect.Uint64},
+	} {
+		if reflect.TypeOf(check.v).Kind() != check.kind {
+			panic(fmt.Sprintf("incorrect key encoding for %T", check.v))
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestKeys(t *testing.T) {
+	t.Run("address", func(t *testing.T) {
+		a := tutil.NewBLSAddr(t, 1)
+		parsed, err := builtin.ParseAddressKey(builtin.AddressKey(a).Key())
+		require.NoError(t, err)
+		assert.Equal(t, a, parsed)
+	})
+
+	t.Run("epochs", func(t *testing.T) {
+		for _, e := range []abi.ChainEpoch{0, 1, 1000, -1} {
+			parsed, err := builtin.ParseEpochKey(builtin.EpochKey(e).Key())
+			require.NoError(t, err)
+			assert.Equal(t, e, parsed)
+		}
+		// Signed and unsigned epoch keys are distinct encodings of the same epoch.
+		assert.NotEqual(t, builtin.EpochKey(1).Key(), builtin.UnsignedEpochKey(1).Key())
+		parsed, err := builtin.ParseUnsignedEpochKey(builtin.UnsignedEpochKey(1000).Key())
+		require.NoError(t, err)
+		assert.Equal(t, abi.ChainEpoch(1000), parsed)
+	})
+
+	t.Run("integer identifiers", func(t *testing.T) {
+		sector, err := builtin.ParseSectorNumberKey(builtin.SectorNumberKey(123).Key())
+		require.NoError(t, err)
+		assert.Equal(t, abi.SectorNumber(123), sector)
+
+		deal, err := builtin.ParseDealIDKey(builtin.DealIDKey(456).Key())
+		require.NoError(t, err)
+		assert.Equal(t, abi.DealID(456), deal)
+
+		actor, err := builtin.ParseActorIDKey(builtin.ActorIDKey(789).Key())
+		require.NoError(t, err)
+		assert.Equal(t, abi.ActorID(789), actor)
+
+		_, err = builtin.ParseDealIDKey(builtin.DealIDKey(456).Key() + "x")
+		require.Error(t, err)
+	})
+
+	t.Run("cid", func(t *testing.T) {
+		c := tutil.MakeCID("key", nil)
+		parsed, err := builtin.ParseCidKey(builtin.CidKey(c).Key())
+		require.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	})
+}
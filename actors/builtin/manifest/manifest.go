@@ -0,0 +1,296 @@
+// Package manifest maps the names of built-in actors to their code CIDs, for every supported actors version.
+//
+// A registry is populated with the code CIDs of all actors versions defined by this repo, and networks
+// which deploy actors under different code CIDs may register their own in place of them.
+package manifest
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	builtin0 "github.com/filecoin-project/specs-actors/actors/builtin"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	builtin4 "github.com/filecoin-project/specs-actors/v4/actors/builtin"
+	builtin5 "github.com/filecoin-project/specs-actors/v5/actors/builtin"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// Version identifies a version of the built-in actors.
+type Version int
+
+const (
+	Version0 = Version(0)
+	Version2 = Version(2)
+	Version3 = Version(3)
+	Version4 = Version(4)
+	Version5 = Version(5)
+	Version6 = Version(6)
+	Version7 = Version(7)
+)
+
+// Names of the built-in actors, which are the same in every version.
+const (
+	AccountKey  = "account"
+	CronKey     = "cron"
+	InitKey     = "init"
+	MarketKey   = "storagemarket"
+	MinerKey    = "storageminer"
+	MultisigKey = "multisig"
+	PaychKey    = "paymentchannel"
+	PowerKey    = "storagepower"
+	RewardKey   = "reward"
+	SystemKey   = "system"
+	VerifregKey = "verifiedregistry"
+)
+
+// Names returns the names of all built-in actors, in lexicographic order.
+func Names() []string {
+	return []string{
+		AccountKey,
+		CronKey,
+		InitKey,
+		MultisigKey,
+		PaychKey,
+		RewardKey,
+		MarketKey,
+		MinerKey,
+		PowerKey,
+		SystemKey,
+		VerifregKey,
+	}
+}
+
+func isName(name string) bool {
+	for _, n := range Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Entry identifies the actor to which a code CID belongs.
+type Entry struct {
+	Version Version
+	Name    string
+}
+
+// Registry maps actor names to code CIDs, and code CIDs back to actor names, for a set of actors versions.
+// A registry is safe for concurrent use.
+type Registry struct {
+	lk    sync.RWMutex
+	codes map[Version]map[string]cid.Cid
+	names map[cid.Cid]Entry
+}
+
+// NewRegistry returns a registry with no versions registered.
+func NewRegistry() *Registry {
+	return &Registry{
+		codes: make(map[Version]map[string]cid.Cid),
+		names: make(map[cid.Cid]Entry),
+	}
+}
+
+// NewBuiltinRegistry returns a registry holding the code CIDs of all actors versions defined by this repo.
+func NewBuiltinRegistry() *Registry {
+	r := NewRegistry()
+	for v, codes := range builtinManifests() { //nolint:nomaprange
+		if err := r.Register(v, codes); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register sets the code CIDs of the actors in a version, replacing any previously registered for that version.
+// Every name must be that of a built-in actor, and no code CID may already be registered to a different
+// version or actor.
+func (r *Registry) Register(v Version, codes map[string]cid.Cid) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	seen := make(map[cid.Cid]string, len(codes))
+	for name, code := range codes { //nolint:nomaprange
+		if !isName(name) {
+			return xerrors.Errorf("unknown actor name %q in version %d", name, v)
+		}
+		if !code.Defined() {
+			return xerrors.Errorf("undefined code CID for actor %s in version %d", name, v)
+		}
+		if other, ok := seen[code]; ok {
+			return xerrors.Errorf("code CID %v registered for both %s and %s in version %d", code, other, name, v)
+		}
+		seen[code] = name
+		if existing, ok := r.names[code]; ok && existing.Version != v {
+			return xerrors.Errorf("code CID %v for actor %s in version %d already registered for actor %s in version %d",
+				code, name, v, existing.Name, existing.Version)
+		}
+	}
+
+	for _, code := range r.codes[v] { //nolint:nomaprange
+		delete(r.names, code)
+	}
+	registered := make(map[string]cid.Cid, len(codes))
+	for name, code := range codes { //nolint:nomaprange
+		registered[name] = code
+		r.names[code] = Entry{Version: v, Name: name}
+	}
+	r.codes[v] = registered
+	return nil
+}
+
+// GetActorCodeID returns the code CID of the named actor in a version, and whether it is registered.
+func (r *Registry) GetActorCodeID(v Version, name string) (cid.Cid, bool) {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+	code, ok := r.codes[v][name]
+	return code, ok
+}
+
+// GetActorNameByCode returns the version and name of the actor with a code CID, and whether it is registered.
+func (r *Registry) GetActorNameByCode(code cid.Cid) (Entry, bool) {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+	entry, ok := r.names[code]
+	return entry, ok
+}
+
+// Versions returns the registered versions, in ascending order.
+func (r *Registry) Versions() []Version {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+	versions := make([]Version, 0, len(r.codes))
+	for v := range r.codes { //nolint:nomaprange
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
+// The registry consulted by the package-level functions.
+var defaultRegistry = NewBuiltinRegistry()
+
+// RegisterManifest sets the code CIDs of the actors in a version in the default registry.
+// See Registry.Register.
+func RegisterManifest(v Version, codes map[string]cid.Cid) error {
+	return defaultRegistry.Register(v, codes)
+}
+
+// GetActorCodeID returns the code CID of the named actor in a version from the default registry.
+func GetActorCodeID(v Version, name string) (cid.Cid, bool) {
+	return defaultRegistry.GetActorCodeID(v, name)
+}
+
+// GetActorNameByCode returns the version and name of the actor with a code CID from the default registry.
+func GetActorNameByCode(code cid.Cid) (Entry, bool) {
+	return defaultRegistry.GetActorNameByCode(code)
+}
+
+// IsBuiltinActor returns whether a code CID is registered in the default registry.
+func IsBuiltinActor(code cid.Cid) bool {
+	_, ok := defaultRegistry.GetActorNameByCode(code)
+	return ok
+}
+
+func builtinManifests() map[Version]map[string]cid.Cid {
+	return map[Version]map[string]cid.Cid{
+		Version0: {
+			AccountKey:  builtin0.AccountActorCodeID,
+			CronKey:     builtin0.CronActorCodeID,
+			InitKey:     builtin0.InitActorCodeID,
+			MarketKey:   builtin0.StorageMarketActorCodeID,
+			MinerKey:    builtin0.StorageMinerActorCodeID,
+			MultisigKey: builtin0.MultisigActorCodeID,
+			PaychKey:    builtin0.PaymentChannelActorCodeID,
+			PowerKey:    builtin0.StoragePowerActorCodeID,
+			RewardKey:   builtin0.RewardActorCodeID,
+			SystemKey:   builtin0.SystemActorCodeID,
+			VerifregKey: builtin0.VerifiedRegistryActorCodeID,
+		},
+		Version2: {
+			AccountKey:  builtin2.AccountActorCodeID,
+			CronKey:     builtin2.CronActorCodeID,
+			InitKey:     builtin2.InitActorCodeID,
+			MarketKey:   builtin2.StorageMarketActorCodeID,
+			MinerKey:    builtin2.StorageMinerActorCodeID,
+			MultisigKey: builtin2.MultisigActorCodeID,
+			PaychKey:    builtin2.PaymentChannelActorCodeID,
+			PowerKey:    builtin2.StoragePowerActorCodeID,
+			RewardKey:   builtin2.RewardActorCodeID,
+			SystemKey:   builtin2.SystemActorCodeID,
+			VerifregKey: builtin2.VerifiedRegistryActorCodeID,
+		},
+		Version3: {
+			AccountKey:  builtin3.AccountActorCodeID,
+			CronKey:     builtin3.CronActorCodeID,
+			InitKey:     builtin3.InitActorCodeID,
+			MarketKey:   builtin3.StorageMarketActorCodeID,
+			MinerKey:    builtin3.StorageMinerActorCodeID,
+			MultisigKey: builtin3.MultisigActorCodeID,
+			PaychKey:    builtin3.PaymentChannelActorCodeID,
+			PowerKey:    builtin3.StoragePowerActorCodeID,
+			RewardKey:   builtin3.RewardActorCodeID,
+			SystemKey:   builtin3.SystemActorCodeID,
+			VerifregKey: builtin3.VerifiedRegistryActorCodeID,
+		},
+		Version4: {
+			AccountKey:  builtin4.AccountActorCodeID,
+			CronKey:     builtin4.CronActorCodeID,
+			InitKey:     builtin4.InitActorCodeID,
+			MarketKey:   builtin4.StorageMarketActorCodeID,
+			MinerKey:    builtin4.StorageMinerActorCodeID,
+			MultisigKey: builtin4.MultisigActorCodeID,
+			PaychKey:    builtin4.PaymentChannelActorCodeID,
+			PowerKey:    builtin4.StoragePowerActorCodeID,
+			RewardKey:   builtin4.RewardActorCodeID,
+			SystemKey:   builtin4.SystemActorCodeID,
+			VerifregKey: builtin4.VerifiedRegistryActorCodeID,
+		},
+		Version5: {
+			AccountKey:  builtin5.AccountActorCodeID,
+			CronKey:     builtin5.CronActorCodeID,
+			InitKey:     builtin5.InitActorCodeID,
+			MarketKey:   builtin5.StorageMarketActorCodeID,
+			MinerKey:    builtin5.StorageMinerActorCodeID,
+			MultisigKey: builtin5.MultisigActorCodeID,
+			PaychKey:    builtin5.PaymentChannelActorCodeID,
+			PowerKey:    builtin5.StoragePowerActorCodeID,
+			RewardKey:   builtin5.RewardActorCodeID,
+			SystemKey:   builtin5.SystemActorCodeID,
+			VerifregKey: builtin5.VerifiedRegistryActorCodeID,
+		},
+		Version6: {
+			AccountKey:  builtin6.AccountActorCodeID,
+			CronKey:     builtin6.CronActorCodeID,
+			InitKey:     builtin6.InitActorCodeID,
+			MarketKey:   builtin6.StorageMarketActorCodeID,
+			MinerKey:    builtin6.StorageMinerActorCodeID,
+			MultisigKey: builtin6.MultisigActorCodeID,
+			PaychKey:    builtin6.PaymentChannelActorCodeID,
+			PowerKey:    builtin6.StoragePowerActorCodeID,
+			RewardKey:   builtin6.RewardActorCodeID,
+			SystemKey:   builtin6.SystemActorCodeID,
+			VerifregKey: builtin6.VerifiedRegistryActorCodeID,
+		},
+		Version7: {
+			AccountKey:  builtin7.AccountActorCodeID,
+			CronKey:     builtin7.CronActorCodeID,
+			InitKey:     builtin7.InitActorCodeID,
+			MarketKey:   builtin7.StorageMarketActorCodeID,
+			MinerKey:    builtin7.StorageMinerActorCodeID,
+			MultisigKey: builtin7.MultisigActorCodeID,
+			PaychKey:    builtin7.PaymentChannelActorCodeID,
+			PowerKey:    builtin7.StoragePowerActorCodeID,
+			RewardKey:   builtin7.RewardActorCodeID,
+			SystemKey:   builtin7.SystemActorCodeID,
+			VerifregKey: builtin7.VerifiedRegistryActorCodeID,
+		},
+	}
+}
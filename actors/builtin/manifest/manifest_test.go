@@ -0,0 +1,115 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin0 "github.com/filecoin-project/specs-actors/actors/builtin"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/manifest"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestBuiltinRegistry(t *testing.T) {
+	r := manifest.NewBuiltinRegistry()
+	assert.Equal(t, []manifest.Version{manifest.Version0, manifest.Version2, manifest.Version3, manifest.Version4,
+		manifest.Version5, manifest.Version6, manifest.Version7}, r.Versions())
+
+	t.Run("every actor in every version maps both ways", func(t *testing.T) {
+		for _, v := range r.Versions() {
+			for _, name := range manifest.Names() {
+				code, ok := r.GetActorCodeID(v, name)
+				require.True(t, ok, "no code for %s in version %d", name, v)
+				entry, ok := r.GetActorNameByCode(code)
+				require.True(t, ok)
+				assert.Equal(t, manifest.Entry{Version: v, Name: name}, entry)
+			}
+		}
+	})
+
+	t.Run("codes match the actors packages", func(t *testing.T) {
+		code, ok := r.GetActorCodeID(manifest.Version0, manifest.MinerKey)
+		require.True(t, ok)
+		assert.Equal(t, builtin0.StorageMinerActorCodeID, code)
+
+		code, ok = r.GetActorCodeID(manifest.Version6, manifest.MarketKey)
+		require.True(t, ok)
+		assert.Equal(t, builtin6.StorageMarketActorCodeID, code)
+
+		code, ok = r.GetActorCodeID(manifest.Version7, manifest.AccountKey)
+		require.True(t, ok)
+		assert.Equal(t, builtin.AccountActorCodeID, code)
+		assert.True(t, manifest.IsBuiltinActor(code))
+	})
+
+	t.Run("unknown lookups", func(t *testing.T) {
+		_, ok := r.GetActorCodeID(manifest.Version(1), manifest.AccountKey)
+		assert.False(t, ok)
+		_, ok = r.GetActorCodeID(manifest.Version7, "evm")
+		assert.False(t, ok)
+		_, ok = r.GetActorNameByCode(tutil.MakeCID("unknown", nil))
+		assert.False(t, ok)
+	})
+}
+
+func TestRegisterManifest(t *testing.T) {
+	custom := manifest.Version(8)
+	account := tutil.MakeCID("custom/account", nil)
+	miner := tutil.MakeCID("custom/miner", nil)
+
+	t.Run("registers a custom version", func(t *testing.T) {
+		r := manifest.NewBuiltinRegistry()
+		require.NoError(t, r.Register(custom, map[string]cid.Cid{
+			manifest.AccountKey: account,
+			manifest.MinerKey:   miner,
+		}))
+		code, ok := r.GetActorCodeID(custom, manifest.MinerKey)
+		require.True(t, ok)
+		assert.Equal(t, miner, code)
+		entry, ok := r.GetActorNameByCode(account)
+		require.True(t, ok)
+		assert.Equal(t, manifest.Entry{Version: custom, Name: manifest.AccountKey}, entry)
+	})
+
+	t.Run("replaces the codes of a registered version", func(t *testing.T) {
+		r := manifest.NewBuiltinRegistry()
+		require.NoError(t, r.Register(manifest.Version7, map[string]cid.Cid{
+			manifest.AccountKey: account,
+		}))
+		code, ok := r.GetActorCodeID(manifest.Version7, manifest.AccountKey)
+		require.True(t, ok)
+		assert.Equal(t, account, code)
+		_, ok = r.GetActorCodeID(manifest.Version7, manifest.MinerKey)
+		assert.False(t, ok)
+		_, ok = r.GetActorNameByCode(builtin.AccountActorCodeID)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		r := manifest.NewBuiltinRegistry()
+		err := r.Register(custom, map[string]cid.Cid{"evm": account})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown actor name")
+
+		err = r.Register(custom, map[string]cid.Cid{manifest.AccountKey: cid.Undef})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undefined code CID")
+
+		err = r.Register(custom, map[string]cid.Cid{manifest.AccountKey: account, manifest.MinerKey: account})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registered for both")
+
+		err = r.Register(custom, map[string]cid.Cid{manifest.AccountKey: builtin.AccountActorCodeID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+
+		// Failed registrations leave the registry unchanged.
+		_, ok := r.GetActorCodeID(custom, manifest.AccountKey)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,49 @@
+package market
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Bitwidths of the market state AMTs whose branching factor may be tuned by policy.
+type AmtBitwidths struct {
+	Proposals int // The deal proposals AMT.
+	States    int // The deal states AMT.
+}
+
+// Returns the bitwidths currently configured by policy.
+func CurrentAmtBitwidths() AmtBitwidths {
+	return AmtBitwidths{
+		Proposals: ProposalsAmtBitwidth,
+		States:    StatesAmtBitwidth,
+	}
+}
+
+// Checks that every bitwidth is acceptable for an AMT.
+func (b AmtBitwidths) Check() error {
+	if err := adt.CheckAmtBitwidth(b.Proposals); err != nil {
+		return xerrors.Errorf("invalid proposals bitwidth: %w", err)
+	}
+	if err := adt.CheckAmtBitwidth(b.States); err != nil {
+		return xerrors.Errorf("invalid states bitwidth: %w", err)
+	}
+	return nil
+}
+
+// Re-encodes the deal proposal and state AMTs, which were written with bitwidths `from`,
+// to use the bitwidths currently configured. This is a migration helper for use after changing the policy.
+func (st *State) ReencodeAmts(store adt.Store, from AmtBitwidths) error {
+	to := CurrentAmtBitwidths()
+	if err := to.Check(); err != nil {
+		return err
+	}
+	var err error
+	if st.Proposals, err = adt.ReencodeArray(store, st.Proposals, from.Proposals, to.Proposals); err != nil {
+		return xerrors.Errorf("failed to re-encode deal proposals: %w", err)
+	}
+	if st.States, err = adt.ReencodeArray(store, st.States, from.States, to.States); err != nil {
+		return xerrors.Errorf("failed to re-encode deal states: %w", err)
+	}
+	return nil
+}
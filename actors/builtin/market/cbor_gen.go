@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{139}
+var lengthBufState = []byte{140}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -93,6 +93,13 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 	if err := t.TotalClientStorageFee.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.DealPriceBuckets (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.DealPriceBuckets); err != nil {
+		return xerrors.Errorf("failed to write cid field t.DealPriceBuckets: %w", err)
+	}
+
 	return nil
 }
 
@@ -110,7 +117,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 11 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -251,6 +258,18 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.TotalClientStorageFee: %w", err)
 		}
 
+	}
+	// t.DealPriceBuckets (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.DealPriceBuckets: %w", err)
+		}
+
+		t.DealPriceBuckets = c
+
 	}
 	return nil
 }
@@ -398,3 +417,542 @@ func (t *DealState) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufDealPriceBucket = []byte{130}
+
+func (t *DealPriceBucket) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDealPriceBucket); err != nil {
+		return err
+	}
+
+	// t.Verified (market.DealPriceHistogram) (struct)
+	if err := t.Verified.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Unverified (market.DealPriceHistogram) (struct)
+	if err := t.Unverified.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DealPriceBucket) UnmarshalCBOR(r io.Reader) error {
+	*t = DealPriceBucket{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Verified (market.DealPriceHistogram) (struct)
+
+	{
+
+		if err := t.Verified.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Verified: %w", err)
+		}
+
+	}
+	// t.Unverified (market.DealPriceHistogram) (struct)
+
+	{
+
+		if err := t.Unverified.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Unverified: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufDealPriceHistogram = []byte{129}
+
+func (t *DealPriceHistogram) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDealPriceHistogram); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Counts ([]uint64) (slice)
+	if len(t.Counts) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Counts was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Counts))); err != nil {
+		return err
+	}
+	for _, v := range t.Counts {
+		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *DealPriceHistogram) UnmarshalCBOR(r io.Reader) error {
+	*t = DealPriceHistogram{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Counts ([]uint64) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Counts: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Counts = make([]uint64, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return xerrors.Errorf("failed to read uint64 for t.Counts slice: %w", err)
+		}
+
+		if maj != cbg.MajUnsignedInt {
+			return xerrors.Errorf("value read for array t.Counts was not a uint, instead got %d", maj)
+		}
+
+		t.Counts[i] = uint64(val)
+	}
+
+	return nil
+}
+
+var lengthBufDealPriceSummary = []byte{132}
+
+func (t *DealPriceSummary) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDealPriceSummary); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.DealCount (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.DealCount)); err != nil {
+		return err
+	}
+
+	// t.P10 (big.Int) (struct)
+	if err := t.P10.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.P50 (big.Int) (struct)
+	if err := t.P50.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.P90 (big.Int) (struct)
+	if err := t.P90.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DealPriceSummary) UnmarshalCBOR(r io.Reader) error {
+	*t = DealPriceSummary{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.DealCount (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.DealCount = uint64(extra)
+
+	}
+	// t.P10 (big.Int) (struct)
+
+	{
+
+		if err := t.P10.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.P10: %w", err)
+		}
+
+	}
+	// t.P50 (big.Int) (struct)
+
+	{
+
+		if err := t.P50.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.P50: %w", err)
+		}
+
+	}
+	// t.P90 (big.Int) (struct)
+
+	{
+
+		if err := t.P90.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.P90: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufGetDealPriceStatsReturn = []byte{130}
+
+func (t *GetDealPriceStatsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetDealPriceStatsReturn); err != nil {
+		return err
+	}
+
+	// t.Verified (market.DealPriceSummary) (struct)
+	if err := t.Verified.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Unverified (market.DealPriceSummary) (struct)
+	if err := t.Unverified.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetDealPriceStatsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetDealPriceStatsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Verified (market.DealPriceSummary) (struct)
+
+	{
+
+		if err := t.Verified.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Verified: %w", err)
+		}
+
+	}
+	// t.Unverified (market.DealPriceSummary) (struct)
+
+	{
+
+		if err := t.Unverified.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Unverified: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufGetDealsParams = []byte{129}
+
+func (t *GetDealsParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetDealsParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.DealIDs ([]abi.DealID) (slice)
+	if len(t.DealIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.DealIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.DealIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.DealIDs {
+		if err := cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetDealsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetDealsParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.DealIDs ([]abi.DealID) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.DealIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.DealIDs = make([]abi.DealID, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		maj, val, err := cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return xerrors.Errorf("failed to read uint64 for t.DealIDs slice: %w", err)
+		}
+
+		if maj != cbg.MajUnsignedInt {
+			return xerrors.Errorf("value read for array t.DealIDs was not a uint, instead got %d", maj)
+		}
+
+		t.DealIDs[i] = abi.DealID(val)
+	}
+
+	return nil
+}
+
+var lengthBufDealProposalAndState = []byte{130}
+
+func (t *DealProposalAndState) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDealProposalAndState); err != nil {
+		return err
+	}
+
+	// t.Proposal (market.DealProposal) (struct)
+	if err := t.Proposal.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.State (market.DealState) (struct)
+	if err := t.State.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DealProposalAndState) UnmarshalCBOR(r io.Reader) error {
+	*t = DealProposalAndState{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Proposal (market.DealProposal) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Proposal = new(DealProposal)
+			if err := t.Proposal.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Proposal pointer: %w", err)
+			}
+		}
+
+	}
+	// t.State (market.DealState) (struct)
+
+	{
+
+		if err := t.State.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.State: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufGetDealsReturn = []byte{129}
+
+func (t *GetDealsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetDealsReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Deals ([]market.DealProposalAndState) (slice)
+	if len(t.Deals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Deals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Deals))); err != nil {
+		return err
+	}
+	for _, v := range t.Deals {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetDealsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetDealsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Deals ([]market.DealProposalAndState) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Deals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Deals = make([]DealProposalAndState, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v DealProposalAndState
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Deals[i] = v
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package market
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	xerrors "golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Bitwidth of the AMT of deal price buckets, which holds at most DealPriceWindowBuckets entries.
+const DealPriceBucketsAmtBitwidth = 3
+
+// Number of bins in a deal price histogram.
+// Bin 0 counts deals with a zero price. Bin i > 0 counts deals priced in [2^(i-1), 2^i) attoFIL per GiB per epoch,
+// except that the last bin also counts all higher prices.
+const DealPriceHistogramBins = 96
+
+// Prices of the deals published in one bucket of DealPriceBucketEpochs epochs.
+type DealPriceBucket struct {
+	Verified   DealPriceHistogram
+	Unverified DealPriceHistogram
+}
+
+// Counts of deals by price bin.
+type DealPriceHistogram struct {
+	// Number of deals in each price bin, omitting trailing empty bins.
+	Counts []uint64
+}
+
+// Summary of the prices of the deals published within the deal price window.
+// Prices are in attoFIL per GiB per epoch, and each percentile is reported as the lower bound
+// of the histogram bin in which it falls, so is within a factor of two of the exact value.
+type DealPriceSummary struct {
+	DealCount uint64
+	P10       abi.TokenAmount
+	P50       abi.TokenAmount
+	P90       abi.TokenAmount
+}
+
+// Returns the index of the bucket into which deals published at an epoch are aggregated.
+func dealPriceBucketIndex(epoch abi.ChainEpoch) uint64 {
+	return uint64(epoch / DealPriceBucketEpochs)
+}
+
+// Returns the histogram bin for the price of a deal, normalized by its piece size.
+func dealPriceBin(proposal *DealProposal) int {
+	pricePerGiB := big.Div(big.Mul(proposal.StoragePricePerEpoch, big.NewInt(1<<30)), big.NewIntUnsigned(uint64(proposal.PieceSize)))
+	bin := int(big.BitLen(pricePerGiB))
+	if bin >= DealPriceHistogramBins {
+		bin = DealPriceHistogramBins - 1
+	}
+	return bin
+}
+
+// Returns the lower bound of the prices counted in a histogram bin.
+func dealPriceBinFloor(bin int) abi.TokenAmount {
+	if bin == 0 {
+		return big.Zero()
+	}
+	return big.Lsh(big.NewInt(1), uint(bin-1))
+}
+
+func (h *DealPriceHistogram) add(bin int) {
+	for len(h.Counts) <= bin {
+		h.Counts = append(h.Counts, 0)
+	}
+	h.Counts[bin]++
+}
+
+func (h *DealPriceHistogram) merge(other *DealPriceHistogram) {
+	for bin, count := range other.Counts {
+		if count == 0 {
+			continue
+		}
+		for len(h.Counts) <= bin {
+			h.Counts = append(h.Counts, 0)
+		}
+		h.Counts[bin] += count
+	}
+}
+
+func (h *DealPriceHistogram) summarize() DealPriceSummary {
+	total := uint64(0)
+	for _, count := range h.Counts {
+		total += count
+	}
+	return DealPriceSummary{
+		DealCount: total,
+		P10:       h.percentile(total, 10),
+		P50:       h.percentile(total, 50),
+		P90:       h.percentile(total, 90),
+	}
+}
+
+// Returns the floor of the bin holding the deal at the given percentile rank, or zero if there are no deals.
+func (h *DealPriceHistogram) percentile(total uint64, pct uint64) abi.TokenAmount {
+	rank := (total*pct + 99) / 100
+	seen := uint64(0)
+	for bin, count := range h.Counts {
+		seen += count
+		if count > 0 && seen >= rank {
+			return dealPriceBinFloor(bin)
+		}
+	}
+	return big.Zero()
+}
+
+// Records the price of a deal published at an epoch in that epoch's bucket.
+func (m *marketStateMutation) recordDealPrice(proposal *DealProposal, epoch abi.ChainEpoch) error {
+	idx := dealPriceBucketIndex(epoch)
+	var bucket DealPriceBucket
+	if _, err := m.dealPriceBuckets.Get(idx, &bucket); err != nil {
+		return xerrors.Errorf("failed to load deal price bucket %d: %w", idx, err)
+	}
+	if proposal.VerifiedDeal {
+		bucket.Verified.add(dealPriceBin(proposal))
+	} else {
+		bucket.Unverified.add(dealPriceBin(proposal))
+	}
+	if err := m.dealPriceBuckets.Set(idx, &bucket); err != nil {
+		return xerrors.Errorf("failed to store deal price bucket %d: %w", idx, err)
+	}
+	return nil
+}
+
+// Deletes the buckets that have fallen out of the deal price window as of an epoch.
+func (m *marketStateMutation) pruneDealPrices(epoch abi.ChainEpoch) error {
+	first := dealPriceWindowStart(epoch)
+	var stale []uint64
+	var bucket DealPriceBucket
+	if err := m.dealPriceBuckets.ForEach(&bucket, func(idx int64) error {
+		if uint64(idx) < first {
+			stale = append(stale, uint64(idx))
+		}
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("failed to iterate deal price buckets: %w", err)
+	}
+	if err := m.dealPriceBuckets.BatchDelete(stale, true); err != nil {
+		return xerrors.Errorf("failed to delete stale deal price buckets: %w", err)
+	}
+	return nil
+}
+
+// Returns the index of the oldest bucket within the deal price window as of an epoch.
+func dealPriceWindowStart(epoch abi.ChainEpoch) uint64 {
+	idx := dealPriceBucketIndex(epoch)
+	if idx+1 < DealPriceWindowBuckets {
+		return 0
+	}
+	return idx + 1 - DealPriceWindowBuckets
+}
+
+// Summarizes the prices of verified and unverified deals published within the deal price window as of an epoch.
+func (st *State) DealPriceStats(store adt.Store, epoch abi.ChainEpoch) (verified, unverified DealPriceSummary, err error) {
+	buckets, err := adt.AsArray(store, st.DealPriceBuckets, DealPriceBucketsAmtBitwidth)
+	if err != nil {
+		return DealPriceSummary{}, DealPriceSummary{}, xerrors.Errorf("failed to load deal price buckets: %w", err)
+	}
+	first, last := dealPriceWindowStart(epoch), dealPriceBucketIndex(epoch)
+	var verifiedPrices, unverifiedPrices DealPriceHistogram
+	var bucket DealPriceBucket
+	if err = buckets.ForEach(&bucket, func(idx int64) error {
+		if uint64(idx) < first || uint64(idx) > last {
+			return nil
+		}
+		verifiedPrices.merge(&bucket.Verified)
+		unverifiedPrices.merge(&bucket.Unverified)
+		return nil
+	}); err != nil {
+		return DealPriceSummary{}, DealPriceSummary{}, xerrors.Errorf("failed to iterate deal price buckets: %w", err)
+	}
+	return verifiedPrices.summarize(), unverifiedPrices.summarize(), nil
+}
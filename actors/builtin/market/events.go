@@ -0,0 +1,39 @@
+package market
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// Types of the events emitted by the market actor over the lifecycle of a deal.
+// Each event carries the deal ID, client and provider under the keys EventKeyDeal, EventKeyClient and EventKeyProvider.
+const (
+	EventDealPublished  = "deal-published"
+	EventDealActivated  = "deal-activated"
+	EventDealTerminated = "deal-terminated"
+	EventDealCompleted  = "deal-completed"
+)
+
+const (
+	EventKeyDeal     = "id"
+	EventKeyClient   = "client"
+	EventKeyProvider = "provider"
+)
+
+// A deal lifecycle event, collected within a state transaction to be emitted after it completes.
+type dealEvent struct {
+	eventType string
+	dealID    abi.DealID
+	proposal  *DealProposal
+}
+
+func emitDealEvents(rt Runtime, events []dealEvent) {
+	for _, e := range events {
+		builtin.NewEventBuilder(e.eventType).
+			WithDeal(EventKeyDeal, e.dealID).
+			WithAddress(EventKeyClient, e.proposal.Client).
+			WithAddress(EventKeyProvider, e.proposal.Provider).
+			Emit(rt)
+	}
+}
@@ -32,15 +32,18 @@ type Runtime = runtime.Runtime
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.AddBalance,
-		3:                         a.WithdrawBalance,
-		4:                         a.PublishStorageDeals,
-		5:                         a.VerifyDealsForActivation,
-		6:                         a.ActivateDeals,
-		7:                         a.OnMinerSectorsTerminate,
-		8:                         a.ComputeDataCommitment,
-		9:                         a.CronTick,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.AddBalance,
+		3:                          a.WithdrawBalance,
+		4:                          a.PublishStorageDeals,
+		5:                          a.VerifyDealsForActivation,
+		6:                          a.ActivateDeals,
+		7:                          a.OnMinerSectorsTerminate,
+		8:                          a.ComputeDataCommitment,
+		9:                          a.CronTick,
+		10:                         a.GetDealPriceStats,
+		11:                         a.GetDeals,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -58,6 +61,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Actor methods
 ////////////////////////////////////////////////////////////////////////////////
@@ -202,11 +210,13 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 	msm, err := st.mutator(adt.AsStore(rt)).withPendingProposals(ReadOnlyPermission).
 		withEscrowTable(ReadOnlyPermission).withLockedTable(ReadOnlyPermission).build()
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
+	clientSigErrs := verifyClientSignatures(rt, params.Deals)
 	for di, deal := range params.Deals {
 		/*
 			drop malformed deals
 		*/
-		if err := validateDeal(rt, deal, networkRawPower, networkQAPower, baselinePower); err != nil {
+		delegated, err := validateDeal(rt, deal, caller, clientSigErrs[di], networkRawPower, networkQAPower, baselinePower)
+		if err != nil {
 			rt.Log(rtt.INFO, "invalid deal %d: %s", di, err)
 			continue
 		}
@@ -268,16 +278,32 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 			drop deals with a DealSize that cannot be fully covered by VerifiedClient's available DataCap
 		*/
 		if deal.Proposal.VerifiedDeal {
-			code := rt.Send(
-				builtin.VerifiedRegistryActorAddr,
-				builtin.MethodsVerifiedRegistry.UseBytes,
-				&verifreg.UseBytesParams{
-					Address:  client,
-					DealSize: big.NewIntUnsigned(uint64(deal.Proposal.PieceSize)),
-				},
-				abi.NewTokenAmount(0),
-				&builtin.Discard{},
-			)
+			var code exitcode.ExitCode
+			if delegated {
+				// The publisher signed on the client's behalf, so must hold an allowance of the client's DataCap.
+				code = rt.Send(
+					builtin.VerifiedRegistryActorAddr,
+					builtin.MethodsVerifiedRegistry.UseBytesDelegated,
+					&verifreg.UseBytesDelegatedParams{
+						Client:   client,
+						Delegate: caller,
+						DealSize: big.NewIntUnsigned(uint64(deal.Proposal.PieceSize)),
+					},
+					abi.NewTokenAmount(0),
+					&builtin.Discard{},
+				)
+			} else {
+				code = rt.Send(
+					builtin.VerifiedRegistryActorAddr,
+					builtin.MethodsVerifiedRegistry.UseBytes,
+					&verifreg.UseBytesParams{
+						Address:  client,
+						DealSize: big.NewIntUnsigned(uint64(deal.Proposal.PieceSize)),
+					},
+					abi.NewTokenAmount(0),
+					&builtin.Discard{},
+				)
+			}
 			if code.IsError() {
 				rt.Log(rtt.INFO, "invalid deal %d: failed to acquire datacap exitcode: %d", di, code)
 				continue
@@ -300,10 +326,11 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 	builtin.RequireParam(rt, validDealCount > 0, "All deal proposals invalid")
 
 	var newDealIds []abi.DealID
+	var events []dealEvent
 	rt.StateTransaction(&st, func() {
 		msm, err := st.mutator(adt.AsStore(rt)).withPendingProposals(WritePermission).
 			withDealProposals(WritePermission).withDealsByEpoch(WritePermission).withEscrowTable(WritePermission).
-			withLockedTable(WritePermission).build()
+			withLockedTable(WritePermission).withDealPriceBuckets(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
 
 		// All storage dealProposals will be added in an atomic transaction; this operation will be unrolled if any of them fails.
@@ -328,11 +355,19 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 			err = msm.dealsByEpoch.Put(processEpoch, id)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal ops by epoch")
 
+			err = msm.recordDealPrice(&validDeal.Proposal, rt.CurrEpoch())
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record deal price")
+
 			newDealIds = append(newDealIds, id)
+			events = append(events, dealEvent{EventDealPublished, id, &validDeals[vdi].Proposal})
 		}
+		err = msm.pruneDealPrices(rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to prune deal prices")
+
 		err = msm.commitState()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush state")
 	})
+	emitDealEvents(rt, events)
 
 	return &PublishStorageDealsReturn{
 		IDs:        newDealIds,
@@ -421,6 +456,7 @@ func (a Actor) ActivateDeals(rt Runtime, params *ActivateDealsParams) *abi.Empty
 
 	var st State
 	store := adt.AsStore(rt)
+	var events []dealEvent
 
 	// Update deal dealStates.
 	rt.StateTransaction(&st, func() {
@@ -459,11 +495,13 @@ func (a Actor) ActivateDeals(rt Runtime, params *ActivateDealsParams) *abi.Empty
 				SlashEpoch:       epochUndefined,
 			})
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal state %d", dealID)
+			events = append(events, dealEvent{EventDealActivated, dealID, proposal})
 		}
 
 		err = msm.commitState()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush state")
 	})
+	emitDealEvents(rt, events)
 
 	return nil
 }
@@ -582,6 +620,7 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	amountSlashed := big.Zero()
 
 	var timedOutVerifiedDeals []*DealProposal
+	var events []dealEvent
 
 	var st State
 	rt.StateTransaction(&st, func() {
@@ -644,6 +683,12 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal state %d", dealID)
 					err = msm.dealProposals.Delete(dealID)
 					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal proposal %d", dealID)
+
+					if state.SlashEpoch != epochUndefined {
+						events = append(events, dealEvent{EventDealTerminated, dealID, deal})
+					} else {
+						events = append(events, dealEvent{EventDealCompleted, dealID, deal})
+					}
 				} else {
 					builtin.RequireState(rt, nextEpoch > rt.CurrEpoch(), "continuing deal %d next epoch %d should be in future", dealID, nextEpoch)
 					builtin.RequireState(rt, slashAmount.IsZero(), "continuing deal %d should not be slashed", dealID)
@@ -684,6 +729,7 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 		err = msm.commitState()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush state")
 	})
+	emitDealEvents(rt, events)
 
 	for _, d := range timedOutVerifiedDeals {
 		code := rt.Send(
@@ -711,6 +757,71 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	return nil
 }
 
+type GetDealPriceStatsReturn struct {
+	Verified   DealPriceSummary
+	Unverified DealPriceSummary
+}
+
+// GetDealPriceStats summarizes the prices of the verified and unverified deals published in the
+// most recent DealPriceWindowBuckets buckets of DealPriceBucketEpochs epochs.
+func (a Actor) GetDealPriceStats(rt Runtime, _ *abi.EmptyValue) *GetDealPriceStatsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+	verified, unverified, err := st.DealPriceStats(adt.AsStore(rt), rt.CurrEpoch())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to summarize deal prices")
+	return &GetDealPriceStatsReturn{
+		Verified:   verified,
+		Unverified: unverified,
+	}
+}
+
+type GetDealsParams struct {
+	DealIDs []abi.DealID
+}
+
+// A deal proposal joined with the deal's state.
+type DealProposalAndState struct {
+	Proposal *DealProposal // Nil if the deal does not exist or has been removed from state
+	State    DealState     // Epochs are all -1 if the deal has not been activated
+}
+
+type GetDealsReturn struct {
+	Deals []DealProposalAndState // In the order of the requested deal IDs
+}
+
+// GetDeals retrieves the proposal and state of each of a batch of deals.
+// This method is for use by other actors and to abstract the state representation for clients.
+func (a Actor) GetDeals(rt Runtime, params *GetDealsParams) *GetDealsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	if len(params.DealIDs) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch empty")
+	} else if len(params.DealIDs) > GetDealsBatchMaxSize {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch of %d too large, max %d", len(params.DealIDs), GetDealsBatchMaxSize)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	store := adt.AsStore(rt)
+	proposals, err := AsDealProposalArray(store, st.Proposals)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal proposals")
+	states, err := AsDealStateArray(store, st.States)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal states")
+
+	deals := make([]DealProposalAndState, len(params.DealIDs))
+	for i, dealID := range params.DealIDs {
+		proposal, found, err := proposals.Get(dealID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal proposal %d", dealID)
+		if found {
+			deals[i].Proposal = proposal
+		}
+		state, _, err := states.Get(dealID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal state %d", dealID)
+		deals[i].State = *state
+	}
+	return &GetDealsReturn{Deals: deals}
+}
+
 func GenRandNextEpoch(startEpoch abi.ChainEpoch, dealID abi.DealID) abi.ChainEpoch {
 	offset := abi.ChainEpoch(uint64(dealID) % uint64(DealUpdatesInterval))
 	q := builtin.NewQuantSpec(DealUpdatesInterval, 0)
@@ -793,58 +904,60 @@ func validateDealCanActivate(proposal *DealProposal, minerAddr addr.Address, sec
 	return nil
 }
 
-func validateDeal(rt Runtime, deal ClientDealProposal, networkRawPower, networkQAPower, baselinePower abi.StoragePower) error {
-	if err := dealProposalIsInternallyValid(rt, deal); err != nil {
-		return xerrors.Errorf("Invalid deal proposal %w", err)
+// Returns whether the deal was signed by the publisher as a delegate of the client.
+func validateDeal(rt Runtime, deal ClientDealProposal, publisher addr.Address, clientSigErr error, networkRawPower, networkQAPower, baselinePower abi.StoragePower) (bool, error) {
+	delegated, err := dealProposalIsInternallyValid(rt, deal, publisher, clientSigErr)
+	if err != nil {
+		return false, xerrors.Errorf("Invalid deal proposal %w", err)
 	}
 
 	proposal := deal.Proposal
 
 	if len(proposal.Label) > DealMaxLabelSize {
-		return xerrors.Errorf("deal label can be at most %d bytes, is %d", DealMaxLabelSize, len(proposal.Label))
+		return false, xerrors.Errorf("deal label can be at most %d bytes, is %d", DealMaxLabelSize, len(proposal.Label))
 	}
 
 	if err := proposal.PieceSize.Validate(); err != nil {
-		return xerrors.Errorf("proposal piece size is invalid: %w", err)
+		return false, xerrors.Errorf("proposal piece size is invalid: %w", err)
 	}
 
 	if !proposal.PieceCID.Defined() {
-		return xerrors.Errorf("proposal PieceCid undefined")
+		return false, xerrors.Errorf("proposal PieceCid undefined")
 	}
 
 	if proposal.PieceCID.Prefix() != PieceCIDPrefix {
-		return xerrors.Errorf("proposal PieceCID had wrong prefix")
+		return false, xerrors.Errorf("proposal PieceCID had wrong prefix")
 	}
 
 	if proposal.EndEpoch <= proposal.StartEpoch {
-		return xerrors.Errorf("proposal end before proposal start")
+		return false, xerrors.Errorf("proposal end before proposal start")
 	}
 
 	if rt.CurrEpoch() > proposal.StartEpoch {
-		return xerrors.Errorf("Deal start epoch has already elapsed")
+		return false, xerrors.Errorf("Deal start epoch has already elapsed")
 	}
 
 	minDuration, maxDuration := DealDurationBounds(proposal.PieceSize)
 	if proposal.Duration() < minDuration || proposal.Duration() > maxDuration {
-		return xerrors.Errorf("Deal duration out of bounds")
+		return false, xerrors.Errorf("Deal duration out of bounds")
 	}
 
 	minPrice, maxPrice := DealPricePerEpochBounds(proposal.PieceSize, proposal.Duration())
 	if proposal.StoragePricePerEpoch.LessThan(minPrice) || proposal.StoragePricePerEpoch.GreaterThan(maxPrice) {
-		return xerrors.Errorf("Storage price out of bounds")
+		return false, xerrors.Errorf("Storage price out of bounds")
 	}
 
 	minProviderCollateral, maxProviderCollateral := DealProviderCollateralBounds(proposal.PieceSize, proposal.VerifiedDeal,
 		networkRawPower, networkQAPower, baselinePower, rt.TotalFilCircSupply())
 	if proposal.ProviderCollateral.LessThan(minProviderCollateral) || proposal.ProviderCollateral.GreaterThan(maxProviderCollateral) {
-		return xerrors.Errorf("Provider collateral out of bounds")
+		return false, xerrors.Errorf("Provider collateral out of bounds")
 	}
 
 	minClientCollateral, maxClientCollateral := DealClientCollateralBounds(proposal.PieceSize, proposal.Duration())
 	if proposal.ClientCollateral.LessThan(minClientCollateral) || proposal.ClientCollateral.GreaterThan(maxClientCollateral) {
-		return xerrors.Errorf("Client collateral out of bounds")
+		return false, xerrors.Errorf("Client collateral out of bounds")
 	}
-	return nil
+	return delegated, nil
 }
 
 //
@@ -888,16 +1001,14 @@ func getDealProposal(proposals *DealArray, dealID abi.DealID) (*DealProposal, er
 
 // Requests the current epoch target block reward from the reward actor.
 func requestCurrentBaselinePower(rt Runtime) abi.StoragePower {
-	var ret reward.ThisEpochRewardReturn
-	code := rt.Send(builtin.RewardActorAddr, builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), &ret)
-	builtin.RequireSuccess(rt, code, "failed to check epoch baseline power")
+	ret := builtin.RequireSendAndDecode[reward.ThisEpochRewardReturn](rt, builtin.RewardActorAddr,
+		builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), "failed to check epoch baseline power")
 	return ret.ThisEpochBaselinePower
 }
 
 // Requests the current network total power and pledge from the power actor.
 func requestCurrentNetworkPower(rt Runtime) (rawPower, qaPower abi.StoragePower) {
-	var pwr power.CurrentTotalPowerReturn
-	code := rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), &pwr)
-	builtin.RequireSuccess(rt, code, "failed to check current power")
+	pwr := builtin.RequireSendAndDecode[power.CurrentTotalPowerReturn](rt, builtin.StoragePowerActorAddr,
+		builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), "failed to check current power")
 	return pwr.RawBytePower, pwr.QualityAdjPower
 }
@@ -3,6 +3,7 @@ package market
 import (
 	"bytes"
 
+	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
@@ -25,8 +26,9 @@ const (
 )
 
 // Bitwidth of AMTs determined empirically from mutation patterns and projections of mainnet data.
-const ProposalsAmtBitwidth = 5
-const StatesAmtBitwidth = 6
+// Configurable by policy (see AmtBitwidths); state written with different values must be re-encoded.
+var ProposalsAmtBitwidth = 5
+var StatesAmtBitwidth = 6
 
 type State struct {
 	// Proposals are deals that have been proposed and not yet cleaned up after expiry or termination.
@@ -41,12 +43,12 @@ type State struct {
 	PendingProposals cid.Cid // Set[DealCid]
 
 	// Total amount held in escrow, indexed by actor address (including both locked and unlocked amounts).
-	EscrowTable cid.Cid // BalanceTable
+	EscrowTable cid.Cid // ShardedBalanceTable
 
 	// Amount locked, indexed by actor address.
 	// Note: the amounts in this table do not affect the overall amount in escrow:
 	// only the _portion_ of the total escrow amount that is locked.
-	LockedTable cid.Cid // BalanceTable
+	LockedTable cid.Cid // ShardedBalanceTable
 
 	NextID abi.DealID
 
@@ -60,9 +62,15 @@ type State struct {
 	TotalProviderLockedCollateral abi.TokenAmount
 	// Total storage fee that is locked in escrow -> unlocked when payments are made
 	TotalClientStorageFee abi.TokenAmount
+
+	// Prices of deals published in recent epochs, for the deal price window.
+	DealPriceBuckets cid.Cid // AMT[ChainEpoch/DealPriceBucketEpochs]DealPriceBucket
 }
 
 func ConstructState(store adt.Store) (*State, error) {
+	if err := CurrentAmtBitwidths().Check(); err != nil {
+		return nil, err
+	}
 	emptyProposalsArrayCid, err := adt.StoreEmptyArray(store, ProposalsAmtBitwidth)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty array: %w", err)
@@ -80,10 +88,14 @@ func ConstructState(store adt.Store) (*State, error) {
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty multiset: %w", err)
 	}
-	emptyBalanceTableCid, err := adt.StoreEmptyMap(store, adt.BalanceTableBitwidth)
+	emptyBalanceTableCid, err := adt.StoreEmptyShardedBalanceTable(store)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty balance table: %w", err)
 	}
+	emptyDealPriceBucketsCid, err := adt.StoreEmptyArray(store, DealPriceBucketsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty deal price buckets array: %w", err)
+	}
 
 	return &State{
 		Proposals:        emptyProposalsArrayCid,
@@ -98,6 +110,8 @@ func ConstructState(store adt.Store) (*State, error) {
 		TotalClientLockedCollateral:   abi.NewTokenAmount(0),
 		TotalProviderLockedCollateral: abi.NewTokenAmount(0),
 		TotalClientStorageFee:         abi.NewTokenAmount(0),
+
+		DealPriceBuckets: emptyDealPriceBucketsCid,
 	}, nil
 }
 
@@ -226,19 +240,50 @@ func (m *marketStateMutation) generateStorageDealID() abi.DealID {
 // State utility functions
 ////////////////////////////////////////////////////////////////////////////////
 
-func dealProposalIsInternallyValid(rt Runtime, proposal ClientDealProposal) error {
+// Verifies the client signatures on a number of deal proposals as a batch.
+// Returns one result per proposal, which is nil if the client's signature is valid.
+func verifyClientSignatures(rt Runtime, proposals []ClientDealProposal) []error {
+	results := make([]error, len(proposals))
+	indices := make([]int, 0, len(proposals))
+	var batch builtin.SignatureBatch
+	for i := range proposals {
+		buf := bytes.Buffer{}
+		if err := proposals[i].Proposal.MarshalCBOR(&buf); err != nil {
+			results[i] = xerrors.Errorf("proposal signature verification failed to marshal proposal: %w", err)
+			continue
+		}
+		batch.Add(proposals[i].ClientSignature, proposals[i].Proposal.Client, buf.Bytes())
+		indices = append(indices, i)
+	}
+	for j, err := range batch.Verify(rt) {
+		results[indices[j]] = err
+	}
+	return results
+}
+
+// Checks the client's signature on a deal proposal, given the result of its verification.
+// A verified deal which costs the client nothing may instead be signed by the publisher on the client's behalf,
+// in which case the verified registry charges its DataCap against an allowance granted by the client to the publisher.
+// Returns whether the proposal was signed by the publisher as the client's delegate.
+func dealProposalIsInternallyValid(rt Runtime, proposal ClientDealProposal, publisher addr.Address, clientSigErr error) (bool, error) {
 	// Note: we do not verify the provider signature here, since this is implicit in the
 	// authenticity of the on-chain message publishing the deal.
+	if clientSigErr == nil {
+		return false, nil
+	}
+	balanceRequirement := proposal.Proposal.ClientBalanceRequirement()
+	if !proposal.Proposal.VerifiedDeal || !balanceRequirement.IsZero() {
+		return false, xerrors.Errorf("signature proposal invalid: %w", clientSigErr)
+	}
 	buf := bytes.Buffer{}
 	err := proposal.Proposal.MarshalCBOR(&buf)
 	if err != nil {
-		return xerrors.Errorf("proposal signature verification failed to marshal proposal: %w", err)
+		return false, xerrors.Errorf("proposal signature verification failed to marshal proposal: %w", err)
 	}
-	err = rt.VerifySignature(proposal.ClientSignature, proposal.Proposal.Client, buf.Bytes())
-	if err != nil {
-		return xerrors.Errorf("signature proposal invalid: %w", err)
+	if delegateErr := rt.VerifySignature(proposal.ClientSignature, publisher, buf.Bytes()); delegateErr != nil {
+		return false, xerrors.Errorf("signature proposal invalid for client (%s) and delegate (%s)", clientSigErr, delegateErr)
 	}
-	return nil
+	return true, nil
 }
 
 func dealGetPaymentRemaining(deal *DealProposal, slashEpoch abi.ChainEpoch) (abi.TokenAmount, error) {
@@ -282,7 +327,7 @@ type marketStateMutation struct {
 	dealStates  *DealMetaArray
 
 	escrowPermit MarketStateMutationPermission
-	escrowTable  *adt.BalanceTable
+	escrowTable  *adt.ShardedBalanceTable
 
 	pendingPermit MarketStateMutationPermission
 	pendingDeals  *adt.Set
@@ -291,11 +336,14 @@ type marketStateMutation struct {
 	dealsByEpoch *SetMultimap
 
 	lockedPermit                  MarketStateMutationPermission
-	lockedTable                   *adt.BalanceTable
+	lockedTable                   *adt.ShardedBalanceTable
 	totalClientLockedCollateral   abi.TokenAmount
 	totalProviderLockedCollateral abi.TokenAmount
 	totalClientStorageFee         abi.TokenAmount
 
+	pricePermit      MarketStateMutationPermission
+	dealPriceBuckets *adt.Array
+
 	nextDealId abi.DealID
 }
 
@@ -321,7 +369,7 @@ func (m *marketStateMutation) build() (*marketStateMutation, error) {
 	}
 
 	if m.lockedPermit != Invalid {
-		lt, err := adt.AsBalanceTable(m.store, m.st.LockedTable)
+		lt, err := adt.AsShardedBalanceTable(m.store, m.st.LockedTable)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to load locked table: %w", err)
 		}
@@ -332,7 +380,7 @@ func (m *marketStateMutation) build() (*marketStateMutation, error) {
 	}
 
 	if m.escrowPermit != Invalid {
-		et, err := adt.AsBalanceTable(m.store, m.st.EscrowTable)
+		et, err := adt.AsShardedBalanceTable(m.store, m.st.EscrowTable)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to load escrow table: %w", err)
 		}
@@ -355,6 +403,14 @@ func (m *marketStateMutation) build() (*marketStateMutation, error) {
 		m.dealsByEpoch = dbe
 	}
 
+	if m.pricePermit != Invalid {
+		prices, err := adt.AsArray(m.store, m.st.DealPriceBuckets, DealPriceBucketsAmtBitwidth)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load deal price buckets: %w", err)
+		}
+		m.dealPriceBuckets = prices
+	}
+
 	m.nextDealId = m.st.NextID
 
 	return m, nil
@@ -390,6 +446,11 @@ func (m *marketStateMutation) withDealsByEpoch(permit MarketStateMutationPermiss
 	return m
 }
 
+func (m *marketStateMutation) withDealPriceBuckets(permit MarketStateMutationPermission) *marketStateMutation {
+	m.pricePermit = permit
+	return m
+}
+
 func (m *marketStateMutation) commitState() error {
 	var err error
 	if m.proposalPermit == WritePermission {
@@ -431,6 +492,12 @@ func (m *marketStateMutation) commitState() error {
 		}
 	}
 
+	if m.pricePermit == WritePermission {
+		if m.st.DealPriceBuckets, err = m.dealPriceBuckets.Root(); err != nil {
+			return xerrors.Errorf("failed to flush deal price buckets: %w", err)
+		}
+	}
+
 	m.st.NextID = m.nextDealId
 	return nil
 }
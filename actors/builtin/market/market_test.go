@@ -24,6 +24,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/expect"
 	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,7 +83,7 @@ func TestMarketActor(t *testing.T) {
 
 		store := adt.AsStore(rt)
 
-		emptyBalanceTable, err := adt.StoreEmptyMap(store, adt.BalanceTableBitwidth)
+		emptyBalanceTable, err := adt.StoreEmptyShardedBalanceTable(store)
 		assert.NoError(t, err)
 
 		emptyMap, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
@@ -740,6 +741,58 @@ func TestPublishStorageDeals(t *testing.T) {
 		require.EqualValues(t, totalStorageFee, st.TotalClientStorageFee)
 		actor.checkState(rt)
 	})
+	t.Run("verified deal signed by the publisher uses the client's delegated datacap", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+
+		// A deal costing the client nothing, so it need not sign for any funds.
+		deal := generateDealProposalWithCollateral(client, provider, big.NewInt(10), big.Zero(), startEpoch, endEpoch)
+		deal.StoragePricePerEpoch = big.Zero()
+		deal.VerifiedDeal = true
+		actor.addProviderFunds(rt, deal.ProviderCollateral, mAddr)
+
+		rt.SetCaller(control, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		expectGetControlAddresses(rt, provider, owner, worker, control)
+		expectQueryNetworkInfo(rt, actor)
+
+		sig := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("signed by aggregator")}
+		params := &market.PublishStorageDealsParams{Deals: []market.ClientDealProposal{{Proposal: deal, ClientSignature: sig}}}
+		rt.ExpectVerifySignature(sig, client, mustCbor(&deal), fmt.Errorf("not signed by client"))
+		rt.ExpectVerifySignature(sig, control, mustCbor(&deal), nil)
+		rt.ExpectSend(builtin.VerifiedRegistryActorAddr, builtin.MethodsVerifiedRegistry.UseBytesDelegated, &verifreg.UseBytesDelegatedParams{
+			Client:   client,
+			Delegate: control,
+			DealSize: big.NewIntUnsigned(uint64(deal.PieceSize)),
+		}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+
+		ret := rt.Call(actor.PublishStorageDeals, params)
+		rt.Verify()
+		resp, ok := ret.(*market.PublishStorageDealsReturn)
+		require.True(t, ok)
+		require.Len(t, resp.IDs, 1)
+		assert.Equal(t, client, actor.getDealProposal(rt, resp.IDs[0]).Client)
+		actor.checkState(rt)
+	})
+
+	t.Run("publisher cannot sign on the client's behalf for a deal with client payments", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+
+		deal := actor.generateDealAndAddFunds(rt, client, mAddr, startEpoch, endEpoch)
+		deal.VerifiedDeal = true
+
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		expectGetControlAddresses(rt, provider, owner, worker)
+		expectQueryNetworkInfo(rt, actor)
+
+		params := mkPublishStorageParams(deal)
+		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&deal), fmt.Errorf("not signed by client"))
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.PublishStorageDeals, params)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
 }
 
 func TestPublishStorageDealsFailures(t *testing.T) {
@@ -904,7 +957,7 @@ func TestPublishStorageDealsFailures(t *testing.T) {
 				params := mkPublishStorageParams(dealProposal)
 
 				rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-				rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+				expect.ControlAddresses(provider, owner, worker).On(rt)
 				expectQueryNetworkInfo(rt, actor)
 				rt.SetCaller(worker, builtin.AccountActorCodeID)
 				rt.ExpectVerifySignature(crypto.Signature{}, dealProposal.Client, mustCbor(&dealProposal), tc.signatureVerificationError)
@@ -931,7 +984,7 @@ func TestPublishStorageDealsFailures(t *testing.T) {
 			params := mkPublishStorageParams(deal1)
 
 			rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-			rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+			expect.ControlAddresses(provider, owner, worker).On(rt)
 			expectQueryNetworkInfo(rt, actor)
 			rt.SetCaller(worker, builtin.AccountActorCodeID)
 			rt.ExpectVerifySignature(crypto.Signature{}, deal1.Client, mustCbor(&deal1), nil)
@@ -953,7 +1006,7 @@ func TestPublishStorageDealsFailures(t *testing.T) {
 			params := mkPublishStorageParams(deal1)
 
 			rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-			rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+			expect.ControlAddresses(provider, owner, worker).On(rt)
 			expectQueryNetworkInfo(rt, actor)
 			rt.SetCaller(worker, builtin.AccountActorCodeID)
 			rt.ExpectVerifySignature(crypto.Signature{}, deal1.Client, mustCbor(&deal1), nil)
@@ -978,7 +1031,7 @@ func TestPublishStorageDealsFailures(t *testing.T) {
 			params := mkPublishStorageParams(deal1, deal2)
 
 			rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-			rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+			expect.ControlAddresses(provider, owner, worker).On(rt)
 			expectQueryNetworkInfo(rt, actor)
 			rt.SetCaller(worker, builtin.AccountActorCodeID)
 			rt.ExpectVerifySignature(crypto.Signature{}, deal1.Client, mustCbor(&deal1), nil)
@@ -1592,7 +1645,7 @@ func TestCronTick(t *testing.T) {
 
 		// cron tick will slash deal1 and make payment for deal2
 		current := rt.SetEpoch(slashEpoch + 1)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d1.ProviderCollateral, nil, exitcode.Ok)
+		expect.Burn(d1.ProviderCollateral).On(rt)
 		actor.cronTick(rt)
 
 		actor.assertDealDeleted(rt, dealId1, d1)
@@ -1610,7 +1663,7 @@ func TestCronTick(t *testing.T) {
 		d2 := actor.generateDealAndAddFunds(rt, client, mAddrs, startEpoch, endEpoch)
 		params := mkPublishStorageParams(d2)
 		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-		rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+		expect.ControlAddresses(provider, owner, worker).On(rt)
 		expectQueryNetworkInfo(rt, actor)
 		rt.SetCaller(worker, builtin.AccountActorCodeID)
 		rt.ExpectVerifySignature(crypto.Signature{}, d2.Client, mustCbor(&d2), nil)
@@ -1725,7 +1778,7 @@ func TestRandomCronEpochDuringPublish(t *testing.T) {
 
 		rt.SetEpoch(processEpoch(t, dealId, startEpoch))
 
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
+		expect.Burn(d.ProviderCollateral).On(rt)
 		actor.cronTick(rt)
 
 		actor.assertDealDeleted(rt, dealId, d)
@@ -1789,7 +1842,7 @@ func TestLockedFundTrackingStates(t *testing.T) {
 
 	// make payment for p1 and p2, p3 times out as it has not been activated
 	curr = rt.SetEpoch(processEpoch(t, dealId3, startEpoch))
-	rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d3.ProviderCollateral, nil, exitcode.Ok)
+	expect.Burn(d3.ProviderCollateral).On(rt)
 	actor.cronTick(rt)
 	payment := big.Product(big.NewInt(4), d1.StoragePricePerEpoch)
 	csf = big.Sub(big.Sub(csf, payment), d3.TotalStorageFee())
@@ -1819,7 +1872,7 @@ func TestLockedFundTrackingStates(t *testing.T) {
 	csf = big.Zero()
 	clc = big.Zero()
 	plc = big.Zero()
-	rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d1.ProviderCollateral, nil, exitcode.Ok)
+	expect.Burn(d1.ProviderCollateral).On(rt)
 	actor.cronTick(rt)
 	actor.assertLockedFundStates(rt, csf, plc, clc)
 	actor.checkState(rt)
@@ -1845,7 +1898,7 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 
 		// do a cron tick for it -> should time out and get slashed
 		rt.SetEpoch(processEpoch(t, dealId, startEpoch))
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
+		expect.Burn(d.ProviderCollateral).On(rt)
 		actor.cronTick(rt)
 
 		require.Equal(t, cEscrow, actor.getEscrowBalance(rt, client))
@@ -1866,7 +1919,7 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 		d2 := actor.generateDealAndAddFunds(rt, client, mAddrs, startEpoch, endEpoch)
 		params := mkPublishStorageParams(d2)
 		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-		rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+		expect.ControlAddresses(provider, owner, worker).On(rt)
 		expectQueryNetworkInfo(rt, actor)
 		rt.SetCaller(worker, builtin.AccountActorCodeID)
 		rt.ExpectVerifySignature(crypto.Signature{}, d2.Client, mustCbor(&d2), nil)
@@ -1877,7 +1930,7 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 
 		// do a cron tick for it -> should time out and get slashed
 		rt.SetEpoch(processEpoch(t, dealId, startEpoch))
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
+		expect.Burn(d.ProviderCollateral).On(rt)
 		actor.cronTick(rt)
 		actor.assertDealDeleted(rt, dealId, d)
 
@@ -1922,7 +1975,7 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 			abi.NewTokenAmount(0), nil, exitcode.Ok)
 
 		expectedBurn := big.Mul(big.NewInt(3), deal1.ProviderCollateral)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedBurn, nil, exitcode.Ok)
+		expect.Burn(expectedBurn).On(rt)
 		actor.cronTick(rt)
 
 		// a second cron tick for the same epoch should not change anything
@@ -2271,7 +2324,7 @@ func TestCronTickDealSlashing(t *testing.T) {
 		// process slashing of deals 200 epochs later
 		rt.SetEpoch(processEpoch(t, dealId3, startEpoch) + 300)
 		totalSlashed := big.Sum(d1.ProviderCollateral, d2.ProviderCollateral, d3.ProviderCollateral)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, totalSlashed, nil, exitcode.Ok)
+		expect.Burn(totalSlashed).On(rt)
 
 		actor.cronTick(rt)
 
@@ -2404,7 +2457,7 @@ func TestMarketActorDeals(t *testing.T) {
 	// Second attempt at publishing the same deal should fail
 	{
 		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-		rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+		expect.ControlAddresses(provider, owner, worker).On(rt)
 		expectQueryNetworkInfo(rt, actor)
 		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&params.Deals[0].Proposal), nil)
 		rt.SetCaller(worker, builtin.AccountActorCodeID)
@@ -2457,7 +2510,7 @@ func TestMaxDealLabelSize(t *testing.T) {
 	// Label greater than max size should fail.
 	{
 		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
-		rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+		expect.ControlAddresses(provider, owner, worker).On(rt)
 		expectQueryNetworkInfo(rt, actor)
 		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&params.Deals[0].Proposal), nil)
 		rt.SetCaller(worker, builtin.AccountActorCodeID)
@@ -2470,6 +2523,115 @@ func TestMaxDealLabelSize(t *testing.T) {
 	actor.checkState(rt)
 }
 
+func TestDealPriceStats(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	minerAddrs := &minerAddrs{owner, worker, provider, nil}
+
+	rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+	actor.addProviderFunds(rt, abi.NewTokenAmount(1e12), minerAddrs)
+	actor.addParticipantFunds(rt, client, abi.NewTokenAmount(1e12))
+
+	publishAtPrice := func(price int64, label string) {
+		start := rt.Epoch() + 1
+		deal := generateDealProposal(client, provider, start, start+200*builtin.EpochsInDay)
+		deal.StoragePricePerEpoch = abi.NewTokenAmount(price)
+		deal.Label = label
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		actor.publishDeals(rt, minerAddrs, publishDealReq{deal: deal})
+	}
+
+	// 2048 byte pieces priced at 10 and 1000 attoFIL per epoch fall in the bins
+	// starting at 2^22 and 2^28 attoFIL per GiB per epoch.
+	lowBin := big.Lsh(big.NewInt(1), 22)
+	highBin := big.Lsh(big.NewInt(1), 28)
+
+	publishAtPrice(10, "a")
+	publishAtPrice(10, "b")
+	publishAtPrice(1000, "c")
+
+	ret := actor.getDealPriceStats(rt)
+	assert.Equal(t, uint64(0), ret.Verified.DealCount)
+	assert.Equal(t, big.Zero(), ret.Verified.P50)
+	assert.Equal(t, uint64(3), ret.Unverified.DealCount)
+	assert.Equal(t, lowBin, ret.Unverified.P10)
+	assert.Equal(t, lowBin, ret.Unverified.P50)
+	assert.Equal(t, highBin, ret.Unverified.P90)
+
+	// Deals published more than the window ago are no longer reported.
+	rt.SetEpoch(abi.ChainEpoch(market.DealPriceWindowBuckets) * market.DealPriceBucketEpochs)
+	ret = actor.getDealPriceStats(rt)
+	assert.Equal(t, uint64(0), ret.Unverified.DealCount)
+	assert.Equal(t, big.Zero(), ret.Unverified.P50)
+
+	// Publishing prunes buckets that have fallen out of the window.
+	publishAtPrice(1000, "d")
+	ret = actor.getDealPriceStats(rt)
+	assert.Equal(t, uint64(1), ret.Unverified.DealCount)
+	assert.Equal(t, highBin, ret.Unverified.P10)
+
+	var st market.State
+	rt.GetState(&st)
+	buckets, err := adt.AsArray(adt.AsStore(rt), st.DealPriceBuckets, market.DealPriceBucketsAmtBitwidth)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), buckets.Length())
+	actor.checkState(rt)
+}
+
+func TestGetDeals(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	mAddr := &minerAddrs{owner, worker, provider, nil}
+	startEpoch := abi.ChainEpoch(1000)
+	endEpoch := startEpoch + 200*builtin.EpochsInDay
+	publishEpoch := abi.ChainEpoch(1)
+
+	t.Run("joins proposals and states", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		rt.SetEpoch(publishEpoch)
+		activeID := actor.generateAndPublishDeal(rt, client, mAddr, startEpoch, endEpoch)
+		pendingID := actor.generateAndPublishDeal(rt, client, mAddr, startEpoch+1, endEpoch)
+		actor.activateDeals(rt, endEpoch, provider, publishEpoch, activeID)
+		missingID := pendingID + 1
+
+		ret := actor.getDeals(rt, pendingID, missingID, activeID)
+		require.Len(t, ret.Deals, 3)
+
+		assert.Equal(t, actor.getDealProposal(rt, pendingID), ret.Deals[0].Proposal)
+		assert.Equal(t, abi.ChainEpoch(-1), ret.Deals[0].State.SectorStartEpoch)
+
+		assert.Nil(t, ret.Deals[1].Proposal)
+		assert.Equal(t, abi.ChainEpoch(-1), ret.Deals[1].State.SectorStartEpoch)
+
+		assert.Equal(t, actor.getDealProposal(rt, activeID), ret.Deals[2].Proposal)
+		assert.Equal(t, *actor.getDealState(rt, activeID), ret.Deals[2].State)
+		assert.Equal(t, publishEpoch, ret.Deals[2].State.SectorStartEpoch)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects empty and oversized batches", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch empty", func() {
+			rt.Call(actor.GetDeals, &market.GetDealsParams{})
+		})
+		rt.Verify()
+
+		dealIDs := make([]abi.DealID, market.GetDealsBatchMaxSize+1)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "too large", func() {
+			rt.Call(actor.GetDeals, &market.GetDealsParams{DealIDs: dealIDs})
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+}
+
 func TestComputeDataCommitment(t *testing.T) {
 	owner := tutil.NewIDAddr(t, 101)
 	provider := tutil.NewIDAddr(t, 102)
@@ -2933,7 +3095,7 @@ func (h *marketActorTestHarness) cronTickAndAssertBalances(rt *mock.Runtime, cli
 	// end epoch for payment calc
 	paymentEnd := d.EndEpoch
 	if s.SlashEpoch != -1 {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
+		expect.Burn(d.ProviderCollateral).On(rt)
 		amountSlashed = d.ProviderCollateral
 
 		if s.SlashEpoch < d.StartEpoch {
@@ -3097,18 +3259,32 @@ func (h *marketActorTestHarness) getDealProposal(rt *mock.Runtime, dealID abi.De
 	return d
 }
 
+func (h *marketActorTestHarness) getDealPriceStats(rt *mock.Runtime) *market.GetDealPriceStatsReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.GetDealPriceStats, nil).(*market.GetDealPriceStatsReturn)
+	rt.Verify()
+	return ret
+}
+
+func (h *marketActorTestHarness) getDeals(rt *mock.Runtime, dealIDs ...abi.DealID) *market.GetDealsReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.GetDeals, &market.GetDealsParams{DealIDs: dealIDs}).(*market.GetDealsReturn)
+	rt.Verify()
+	return ret
+}
+
 func (h *marketActorTestHarness) assertAccountZero(rt *mock.Runtime, addr address.Address) {
 	var st market.State
 	rt.GetState(&st)
 
-	et, err := adt.AsBalanceTable(adt.AsStore(rt), st.EscrowTable)
+	et, err := adt.AsShardedBalanceTable(adt.AsStore(rt), st.EscrowTable)
 	require.NoError(h.t, err)
 
 	b, err := et.Get(addr)
 	require.NoError(h.t, err)
 	require.Equal(h.t, big.Zero(), b)
 
-	lt, err := adt.AsBalanceTable(adt.AsStore(rt), st.LockedTable)
+	lt, err := adt.AsShardedBalanceTable(adt.AsStore(rt), st.LockedTable)
 	require.NoError(h.t, err)
 	b, err = lt.Get(addr)
 	require.NoError(h.t, err)
@@ -3119,7 +3295,7 @@ func (h *marketActorTestHarness) getEscrowBalance(rt *mock.Runtime, addr address
 	var st market.State
 	rt.GetState(&st)
 
-	et, err := adt.AsBalanceTable(adt.AsStore(rt), st.EscrowTable)
+	et, err := adt.AsShardedBalanceTable(adt.AsStore(rt), st.EscrowTable)
 	require.NoError(h.t, err)
 
 	bal, err := et.Get(addr)
@@ -3131,7 +3307,7 @@ func (h *marketActorTestHarness) getLockedBalance(rt *mock.Runtime, addr address
 	var st market.State
 	rt.GetState(&st)
 
-	lt, err := adt.AsBalanceTable(adt.AsStore(rt), st.LockedTable)
+	lt, err := adt.AsShardedBalanceTable(adt.AsStore(rt), st.LockedTable)
 	require.NoError(h.t, err)
 
 	bal, err := lt.Get(addr)
@@ -3405,3 +3581,40 @@ func expectQueryNetworkInfo(rt *mock.Runtime, h *marketActorTestHarness) {
 func processEpoch(t *testing.T, id abi.DealID, startEpoch abi.ChainEpoch) abi.ChainEpoch {
 	return market.GenRandNextEpoch(startEpoch, id)
 }
+
+func TestSetMultimapPagination(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+	mm, err := market.MakeEmptySetMultimap(store, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+
+	epoch := abi.ChainEpoch(100)
+	var ids []abi.DealID
+	for i := abi.DealID(0); i < 20; i++ {
+		ids = append(ids, i)
+	}
+	require.NoError(t, mm.PutMany(epoch, ids))
+
+	count, err := mm.Count(epoch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), count)
+	count, err = mm.Count(epoch + 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	seen := map[abi.DealID]bool{}
+	cursor := uint64(0)
+	for {
+		next, more, err := mm.ForEachPage(epoch, cursor, 6, func(id abi.DealID) error {
+			assert.False(t, seen[id])
+			seen[id] = true
+			return nil
+		})
+		require.NoError(t, err)
+		if !more {
+			break
+		}
+		cursor = next
+	}
+	assert.Len(t, seen, 20)
+}
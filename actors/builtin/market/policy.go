@@ -26,6 +26,15 @@ var DealMaxDuration = abi.ChainEpoch(540 * builtin.EpochsInDay) // PARAM_SPEC
 // DealMaxLabelSize is the maximum size of a deal label.
 const DealMaxLabelSize = 256
 
+// The number of epochs over which the prices of published deals are aggregated into a single bucket.
+const DealPriceBucketEpochs = builtin.EpochsInDay
+
+// The number of most recent buckets, including the current one, summarized by deal price statistics.
+const DealPriceWindowBuckets = uint64(7)
+
+// Maximum number of deals that may be retrieved by a single call to GetDeals.
+const GetDealsBatchMaxSize = 256
+
 // Bounds (inclusive) on deal duration
 func DealDurationBounds(_ abi.PaddedPieceSize) (min abi.ChainEpoch, max abi.ChainEpoch) {
 	return DealMinDuration, DealMaxDuration
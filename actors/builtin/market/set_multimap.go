@@ -1,13 +1,12 @@
 package market
 
 import (
-	"reflect"
-
 	"github.com/filecoin-project/go-state-types/abi"
 	cid "github.com/ipfs/go-cid"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
 
@@ -53,7 +52,7 @@ func (mm *SetMultimap) Root() (cid.Cid, error) {
 
 func (mm *SetMultimap) Put(epoch abi.ChainEpoch, v abi.DealID) error {
 	// Load the hamt under key, or initialize a new empty one if not found.
-	k := abi.UIntKey(uint64(epoch))
+	k := builtin.UnsignedEpochKey(epoch)
 	set, found, err := mm.get(k)
 	if err != nil {
 		return err
@@ -66,7 +65,7 @@ func (mm *SetMultimap) Put(epoch abi.ChainEpoch, v abi.DealID) error {
 	}
 
 	// Add to the set.
-	if err = set.Put(dealKey(v)); err != nil {
+	if err = set.Put(builtin.DealIDKey(v)); err != nil {
 		return xerrors.Errorf("failed to add key to set %v: %w", epoch, err)
 	}
 
@@ -85,7 +84,7 @@ func (mm *SetMultimap) Put(epoch abi.ChainEpoch, v abi.DealID) error {
 
 func (mm *SetMultimap) PutMany(epoch abi.ChainEpoch, vs []abi.DealID) error {
 	// Load the hamt under key, or initialize a new empty one if not found.
-	k := abi.UIntKey(uint64(epoch))
+	k := builtin.UnsignedEpochKey(epoch)
 	set, found, err := mm.get(k)
 	if err != nil {
 		return err
@@ -99,7 +98,7 @@ func (mm *SetMultimap) PutMany(epoch abi.ChainEpoch, vs []abi.DealID) error {
 
 	// Add to the set.
 	for _, v := range vs {
-		if err = set.Put(dealKey(v)); err != nil {
+		if err = set.Put(builtin.DealIDKey(v)); err != nil {
 			return xerrors.Errorf("failed to add key to set %v: %w", epoch, err)
 		}
 	}
@@ -119,7 +118,7 @@ func (mm *SetMultimap) PutMany(epoch abi.ChainEpoch, vs []abi.DealID) error {
 
 // Removes all values for a key.
 func (mm *SetMultimap) RemoveAll(key abi.ChainEpoch) error {
-	if _, err := mm.mp.TryDelete(abi.UIntKey(uint64(key))); err != nil {
+	if _, err := mm.mp.TryDelete(builtin.UnsignedEpochKey(key)); err != nil {
 		return xerrors.Errorf("failed to delete set key %v: %w", key, err)
 	}
 	return nil
@@ -127,13 +126,13 @@ func (mm *SetMultimap) RemoveAll(key abi.ChainEpoch) error {
 
 // Iterates all entries for a key, iteration halts if the function returns an error.
 func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) error) error {
-	set, found, err := mm.get(abi.UIntKey(uint64(epoch)))
+	set, found, err := mm.get(builtin.UnsignedEpochKey(epoch))
 	if err != nil {
 		return err
 	}
 	if found {
 		return set.ForEach(func(k string) error {
-			v, err := parseDealKey(k)
+			v, err := builtin.ParseDealIDKey(k)
 			if err != nil {
 				return err
 			}
@@ -143,6 +142,44 @@ func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) erro
 	return nil
 }
 
+// Iterates up to limit entries for a key, skipping the first `cursor` entries in iteration order.
+// Returns the cursor from which to continue, and whether any entries remain.
+// Entries are ordered by the hash of the deal ID, which is stable while the set is unmodified,
+// and every page traverses the set from the first entry.
+func (mm *SetMultimap) ForEachPage(epoch abi.ChainEpoch, cursor uint64, limit int, fn func(id abi.DealID) error) (uint64, bool, error) {
+	if limit <= 0 {
+		return 0, false, xerrors.Errorf("invalid page limit %d", limit)
+	}
+	errStop := xerrors.New("stop")
+	seen := uint64(0)
+	more := false
+	err := mm.ForEach(epoch, func(id abi.DealID) error {
+		if seen < cursor {
+			seen++
+			return nil
+		}
+		if seen == cursor+uint64(limit) {
+			more = true
+			return errStop
+		}
+		seen++
+		return fn(id)
+	})
+	if err != nil && err != errStop {
+		return 0, false, err
+	}
+	return seen, more, nil
+}
+
+// Returns the number of entries for a key.
+func (mm *SetMultimap) Count(epoch abi.ChainEpoch) (uint64, error) {
+	set, found, err := mm.get(builtin.UnsignedEpochKey(epoch))
+	if err != nil || !found {
+		return 0, err
+	}
+	return set.Count()
+}
+
 func (mm *SetMultimap) get(key abi.Keyer) (*adt.Set, bool, error) {
 	var setRoot cbg.CborCid
 	found, err := mm.mp.Get(key, &setRoot)
@@ -158,20 +195,3 @@ func (mm *SetMultimap) get(key abi.Keyer) (*adt.Set, bool, error) {
 	}
 	return set, found, nil
 }
-
-func dealKey(e abi.DealID) abi.Keyer {
-	return abi.UIntKey(uint64(e))
-}
-
-func parseDealKey(s string) (abi.DealID, error) {
-	key, err := abi.ParseUIntKey(s)
-	return abi.DealID(key), err
-}
-
-func init() {
-	// Check that DealID is indeed an unsigned integer to confirm that dealKey is making the right interpretation.
-	var e abi.DealID
-	if reflect.TypeOf(e).Kind() != reflect.Uint64 {
-		panic("incorrect sector number encoding")
-	}
-}
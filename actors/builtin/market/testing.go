@@ -173,18 +173,16 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	//
 
 	lockTableCount := uint64(0)
-	escrowTable, err := adt.AsBalanceTable(store, st.EscrowTable)
+	escrowTable, err := adt.AsShardedBalanceTable(store, st.EscrowTable)
 	acc.RequireNoError(err, "error loading escrow table")
-	lockTable, err := adt.AsBalanceTable(store, st.LockedTable)
+	lockTable, err := adt.AsShardedBalanceTable(store, st.LockedTable)
 	acc.RequireNoError(err, "error loading locked table")
 	if escrowTable != nil && lockTable != nil {
-		var lockedAmount abi.TokenAmount
+		checkBalanceTableShards(escrowTable, "escrow", acc)
+		checkBalanceTableShards(lockTable, "locked", acc)
+
 		lockedTotal := abi.NewTokenAmount(0)
-		err = (*adt.Map)(lockTable).ForEach(&lockedAmount, func(key string) error {
-			addr, err := address.NewFromBytes([]byte(key))
-			if err != nil {
-				return err
-			}
+		err = lockTable.ForEach(func(addr address.Address, lockedAmount abi.TokenAmount) error {
 			lockedTotal = big.Add(lockedTotal, lockedAmount)
 
 			// every entry in locked table should have a corresponding entry in escrow table that is at least as high
@@ -245,6 +243,20 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 
 	acc.Require(len(expectedDealOps) == 0, "missing deal ops for proposals: %v", expectedDealOps)
 
+	// Deal Price Buckets
+	if buckets, err := adt.AsArray(store, st.DealPriceBuckets, DealPriceBucketsAmtBitwidth); err != nil {
+		acc.Addf("error loading deal price buckets: %v", err)
+	} else {
+		var bucket DealPriceBucket
+		err = buckets.ForEach(&bucket, func(idx int64) error {
+			acc.Require(uint64(idx) <= dealPriceBucketIndex(currEpoch), "deal price bucket %d is in the future at epoch %d", idx, currEpoch)
+			acc.Require(len(bucket.Verified.Counts) <= DealPriceHistogramBins, "deal price bucket %d has %d verified bins", idx, len(bucket.Verified.Counts))
+			acc.Require(len(bucket.Unverified.Counts) <= DealPriceHistogramBins, "deal price bucket %d has %d unverified bins", idx, len(bucket.Unverified.Counts))
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating deal price buckets")
+	}
+
 	return &StateSummary{
 		Deals:                proposalStats,
 		PendingProposalCount: pendingProposalCount,
@@ -254,3 +266,20 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 		DealOpCount:          dealOpCount,
 	}, acc
 }
+
+// Checks that every balance in a sharded table is held in the shard to which its address routes.
+func checkBalanceTableShards(table *adt.ShardedBalanceTable, name string, acc *builtin.MessageAccumulator) {
+	for i := uint64(0); i < adt.BalanceTableShardCount; i++ {
+		shard, err := table.Shard(i)
+		if err != nil {
+			acc.Addf("error loading %s table shard %d: %v", name, i, err)
+			continue
+		}
+		err = shard.ForEach(func(addr address.Address, balance abi.TokenAmount) error {
+			acc.Require(adt.BalanceTableShard(addr) == i, "%s table entry for %s in shard %d, expected %d", name, addr, i, adt.BalanceTableShard(addr))
+			acc.Require(balance.GreaterThanEqual(big.Zero()), "%s table entry for %s is negative: %v", name, addr, balance)
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating %s table shard %d", name, i)
+	}
+}
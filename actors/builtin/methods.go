@@ -10,27 +10,36 @@ const (
 	MethodConstructor = builtin0.MethodConstructor
 )
 
+// Method number at which every builtin actor exports GetActorInfo.
+// This is set apart from each actor's own methods so that it is uniform across all actors.
+const MethodGetActorInfo = abi.MethodNum(64)
+
 var MethodsAccount = struct {
-	Constructor   abi.MethodNum
-	PubkeyAddress abi.MethodNum
-}{MethodConstructor, 2}
+	Constructor         abi.MethodNum
+	PubkeyAddress       abi.MethodNum
+	AuthenticateMessage abi.MethodNum
+}{MethodConstructor, 2, 3}
 
 var MethodsInit = struct {
-	Constructor abi.MethodNum
-	Exec        abi.MethodNum
-}{MethodConstructor, 2}
+	Constructor  abi.MethodNum
+	Exec         abi.MethodNum
+	ExecWithSalt abi.MethodNum
+}{MethodConstructor, 2, 3}
 
 var MethodsCron = struct {
 	Constructor abi.MethodNum
 	EpochTick   abi.MethodNum
-}{MethodConstructor, 2}
+	AddEntry    abi.MethodNum
+	RemoveEntry abi.MethodNum
+}{MethodConstructor, 2, 3, 4}
 
 var MethodsReward = struct {
 	Constructor      abi.MethodNum
 	AwardBlockReward abi.MethodNum
 	ThisEpochReward  abi.MethodNum
 	UpdateNetworkKPI abi.MethodNum
-}{MethodConstructor, 2, 3, 4}
+	RecyclePenalty   abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5}
 
 var MethodsMultisig = struct {
 	Constructor                 abi.MethodNum
@@ -42,14 +51,21 @@ var MethodsMultisig = struct {
 	SwapSigner                  abi.MethodNum
 	ChangeNumApprovalsThreshold abi.MethodNum
 	LockBalance                 abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	ApproveMany                 abi.MethodNum
+	ProposeMany                 abi.MethodNum
+	SetSpendingLimit            abi.MethodNum
+	SwapSignerAndReapprove      abi.MethodNum
+	ProposeWithMetadata         abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
 
 var MethodsPaych = struct {
-	Constructor        abi.MethodNum
-	UpdateChannelState abi.MethodNum
-	Settle             abi.MethodNum
-	Collect            abi.MethodNum
-}{MethodConstructor, 2, 3, 4}
+	Constructor            abi.MethodNum
+	UpdateChannelState     abi.MethodNum
+	Settle                 abi.MethodNum
+	Collect                abi.MethodNum
+	CompactLanes           abi.MethodNum
+	UpdateChannelStateMany abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6}
 
 var MethodsMarket = struct {
 	Constructor              abi.MethodNum
@@ -61,7 +77,9 @@ var MethodsMarket = struct {
 	OnMinerSectorsTerminate  abi.MethodNum
 	ComputeDataCommitment    abi.MethodNum
 	CronTick                 abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	GetDealPriceStats        abi.MethodNum
+	GetDeals                 abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
 
 var MethodsPower = struct {
 	Constructor              abi.MethodNum
@@ -73,7 +91,8 @@ var MethodsPower = struct {
 	Deprecated1              abi.MethodNum
 	SubmitPoRepForBulkVerify abi.MethodNum
 	CurrentTotalPower        abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	OnNetworkVersionChange   abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 var MethodsMiner = struct {
 	Constructor              abi.MethodNum
@@ -105,7 +124,12 @@ var MethodsMiner = struct {
 	ProveReplicaUpdates      abi.MethodNum
 	ChangeBeneficiary        abi.MethodNum
 	GetBeneficiary           abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29}
+	GetFeeDebtStatus         abi.MethodNum
+	PauseMiner               abi.MethodNum
+	UnpauseMiner             abi.MethodNum
+	DeclareUnsealingWindow   abi.MethodNum
+	GetUnsealingWindows      abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34}
 
 var MethodsVerifiedRegistry = struct {
 	Constructor                 abi.MethodNum
@@ -115,4 +139,6 @@ var MethodsVerifiedRegistry = struct {
 	UseBytes                    abi.MethodNum
 	RestoreBytes                abi.MethodNum
 	RemoveVerifiedClientDataCap abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7}
+	SetDataCapAllowance         abi.MethodNum
+	UseBytesDelegated           abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
@@ -0,0 +1,133 @@
+package miner
+
+import (
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Bitwidths of the miner state AMTs whose branching factor may be tuned by policy.
+type AmtBitwidths struct {
+	Sectors              int // The sectors AMT and deadline sector snapshots.
+	DeadlineExpirations  int // Deadline expiration queues.
+	PartitionExpirations int // Partition expiration queues.
+}
+
+// Returns the bitwidths currently configured by policy.
+func CurrentAmtBitwidths() AmtBitwidths {
+	return AmtBitwidths{
+		Sectors:              SectorsAmtBitwidth,
+		DeadlineExpirations:  DeadlineExpirationAmtBitwidth,
+		PartitionExpirations: PartitionExpirationAmtBitwidth,
+	}
+}
+
+// Checks that every bitwidth is acceptable for an AMT.
+func (b AmtBitwidths) Check() error {
+	if err := adt.CheckAmtBitwidth(b.Sectors); err != nil {
+		return xerrors.Errorf("invalid sectors bitwidth: %w", err)
+	}
+	if err := adt.CheckAmtBitwidth(b.DeadlineExpirations); err != nil {
+		return xerrors.Errorf("invalid deadline expirations bitwidth: %w", err)
+	}
+	if err := adt.CheckAmtBitwidth(b.PartitionExpirations); err != nil {
+		return xerrors.Errorf("invalid partition expirations bitwidth: %w", err)
+	}
+	return nil
+}
+
+// Re-encodes the policy-configurable AMTs of a miner's state, which were written with bitwidths `from`,
+// to use the bitwidths currently configured. This is a migration helper for use after changing the policy.
+func (st *State) ReencodeAmts(store adt.Store, from AmtBitwidths) error {
+	to := CurrentAmtBitwidths()
+	if err := to.Check(); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+
+	var err error
+	if st.Sectors, err = adt.ReencodeArray(store, st.Sectors, from.Sectors, to.Sectors); err != nil {
+		return xerrors.Errorf("failed to re-encode sectors: %w", err)
+	}
+
+	deadlines, err := st.LoadDeadlines(store)
+	if err != nil {
+		return err
+	}
+	for dlIdx := range deadlines.Due {
+		dl, err := deadlines.LoadDeadline(store, uint64(dlIdx))
+		if err != nil {
+			return err
+		}
+		if err := dl.reencodeAmts(store, from, to); err != nil {
+			return xerrors.Errorf("failed to re-encode deadline %d: %w", dlIdx, err)
+		}
+		if err := deadlines.UpdateDeadline(store, uint64(dlIdx), dl); err != nil {
+			return err
+		}
+	}
+	return st.SaveDeadlines(store, deadlines)
+}
+
+// Re-encodes the policy-configurable AMTs of a deadline, which were written with bitwidths `from`,
+// to use the bitwidths currently configured. This is a migration helper for deadlines copied from a prior version.
+func (dl *Deadline) ReencodeAmts(store adt.Store, from AmtBitwidths) error {
+	to := CurrentAmtBitwidths()
+	if err := to.Check(); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	return dl.reencodeAmts(store, from, to)
+}
+
+func (dl *Deadline) reencodeAmts(store adt.Store, from, to AmtBitwidths) error {
+	var err error
+	if dl.SectorsSnapshot, err = adt.ReencodeArray(store, dl.SectorsSnapshot, from.Sectors, to.Sectors); err != nil {
+		return xerrors.Errorf("failed to re-encode sectors snapshot: %w", err)
+	}
+	if dl.ExpirationsEpochs, err = adt.ReencodeArray(store, dl.ExpirationsEpochs, from.DeadlineExpirations, to.DeadlineExpirations); err != nil {
+		return xerrors.Errorf("failed to re-encode expirations: %w", err)
+	}
+	if dl.Partitions, err = reencodePartitions(store, dl.Partitions, from.PartitionExpirations, to.PartitionExpirations); err != nil {
+		return xerrors.Errorf("failed to re-encode partitions: %w", err)
+	}
+	if dl.PartitionsSnapshot, err = reencodePartitions(store, dl.PartitionsSnapshot, from.PartitionExpirations, to.PartitionExpirations); err != nil {
+		return xerrors.Errorf("failed to re-encode partitions snapshot: %w", err)
+	}
+	return nil
+}
+
+// Re-encodes the expiration queue of every partition in a partitions AMT, returning the AMT's new root.
+func reencodePartitions(store adt.Store, root cid.Cid, from, to int) (cid.Cid, error) {
+	partitions, err := adt.AsArray(store, root, DeadlinePartitionsAmtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+	// Partitions are collected and stored after iteration, rather than modifying the AMT while iterating it.
+	var partition Partition
+	var indices []uint64
+	var reencoded []Partition
+	if err := partitions.ForEach(&partition, func(partIdx int64) error {
+		expirations, err := adt.ReencodeArray(store, partition.ExpirationsEpochs, from, to)
+		if err != nil {
+			return xerrors.Errorf("failed to re-encode partition %d expirations: %w", partIdx, err)
+		}
+		partition.ExpirationsEpochs = expirations
+		indices = append(indices, uint64(partIdx))
+		reencoded = append(reencoded, partition)
+		return nil
+	}); err != nil {
+		return cid.Undef, err
+	}
+	for i, partIdx := range indices {
+		if err := partitions.Set(partIdx, &reencoded[i]); err != nil {
+			return cid.Undef, xerrors.Errorf("failed to store partition %d: %w", partIdx, err)
+		}
+	}
+	return partitions.Root()
+}
@@ -13,4 +13,19 @@ const (
 	BurnMethodRepayDebt                BurnMethod = "RepayDebt"
 	BurnMethodProcessEarlyTerminations BurnMethod = "ProcessEarlyTerminations"
 	BurnMethodHandleProvingDeadline    BurnMethod = "HandleProvingDeadline "
+	BurnMethodDeclareUnsealingWindow   BurnMethod = "DeclareUnsealingWindow"
 )
+
+// Whether funds burnt by a method are penalties, a fraction of which may be recycled to the reward actor.
+func (bm BurnMethod) IsPenalty() bool {
+	switch bm {
+	case BurnMethodDisputeWindowedPoSt,
+		BurnMethodApplyRewards,
+		BurnMethodReportConsensusFault,
+		BurnMethodProcessEarlyTerminations,
+		BurnMethodHandleProvingDeadline:
+		return true
+	default:
+		return false
+	}
+}
@@ -16,7 +16,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{143}
+var lengthBufState = []byte{148}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -117,6 +117,32 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 	if err := cbg.WriteBool(w, t.DeadlineCronActive); err != nil {
 		return err
 	}
+
+	// t.FeeDebtLog (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.FeeDebtLog); err != nil {
+		return xerrors.Errorf("failed to write cid field t.FeeDebtLog: %w", err)
+	}
+
+	// t.Rebalance (miner.RebalanceCursor) (struct)
+	if err := t.Rebalance.MarshalCBOR(w); err != nil {
+		return err
+	}
+	// t.Paused (bool) (bool)
+	if err := cbg.WriteBool(w, t.Paused); err != nil {
+		return err
+	}
+
+	// t.PendingPauseChange (miner.PendingPauseChange) (struct)
+	if err := t.PendingPauseChange.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.UnsealingWindows (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.UnsealingWindows); err != nil {
+		return xerrors.Errorf("failed to write cid field t.UnsealingWindows: %w", err)
+	}
 	return nil
 }
 
@@ -134,7 +160,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 15 {
+	if extra != 20 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -323,6 +349,75 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 	default:
 		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
 	}
+	// t.FeeDebtLog (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.FeeDebtLog: %w", err)
+		}
+
+		t.FeeDebtLog = c
+
+	}
+	// t.Rebalance (miner.RebalanceCursor) (struct)
+
+	{
+
+		if err := t.Rebalance.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Rebalance: %w", err)
+		}
+
+	}
+	// t.Paused (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Paused = false
+	case 21:
+		t.Paused = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.PendingPauseChange (miner.PendingPauseChange) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.PendingPauseChange = new(PendingPauseChange)
+			if err := t.PendingPauseChange.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.PendingPauseChange pointer: %w", err)
+			}
+		}
+
+	}
+	// t.UnsealingWindows (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.UnsealingWindows: %w", err)
+		}
+
+		t.UnsealingWindows = c
+
+	}
 	return nil
 }
 
@@ -828,7 +923,7 @@ func (t *Deadlines) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufDeadline = []byte{139}
+var lengthBufDeadline = []byte{140}
 
 func (t *Deadline) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -904,6 +999,10 @@ func (t *Deadline) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.OptimisticPoStSubmissionsSnapshot: %w", err)
 	}
 
+	// t.SectorExits (miner.SectorExitCounts) (struct)
+	if err := t.SectorExits.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -921,7 +1020,7 @@ func (t *Deadline) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 11 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -1051,6 +1150,112 @@ func (t *Deadline) UnmarshalCBOR(r io.Reader) error {
 
 		t.OptimisticPoStSubmissionsSnapshot = c
 
+	}
+	// t.SectorExits (miner.SectorExitCounts) (struct)
+
+	{
+
+		if err := t.SectorExits.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.SectorExits: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSectorExitCounts = []byte{131}
+
+func (t *SectorExitCounts) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSectorExitCounts); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.OnTime (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.OnTime)); err != nil {
+		return err
+	}
+
+	// t.Terminated (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Terminated)); err != nil {
+		return err
+	}
+
+	// t.FaultExpired (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.FaultExpired)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *SectorExitCounts) UnmarshalCBOR(r io.Reader) error {
+	*t = SectorExitCounts{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.OnTime (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.OnTime = uint64(extra)
+
+	}
+	// t.Terminated (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Terminated = uint64(extra)
+
+	}
+	// t.FaultExpired (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.FaultExpired = uint64(extra)
+
 	}
 	return nil
 }
@@ -3374,3 +3579,1052 @@ func (t *ReplicaUpdate) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufFeeDebtLog = []byte{130}
+
+func (t *FeeDebtLog) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufFeeDebtLog); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Accruals ([]miner.FeeDebtAccrual) (slice)
+	if len(t.Accruals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Accruals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Accruals))); err != nil {
+		return err
+	}
+	for _, v := range t.Accruals {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Repayments ([]miner.FeeDebtRepayment) (slice)
+	if len(t.Repayments) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Repayments was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Repayments))); err != nil {
+		return err
+	}
+	for _, v := range t.Repayments {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *FeeDebtLog) UnmarshalCBOR(r io.Reader) error {
+	*t = FeeDebtLog{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Accruals ([]miner.FeeDebtAccrual) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Accruals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Accruals = make([]FeeDebtAccrual, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v FeeDebtAccrual
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Accruals[i] = v
+	}
+
+	// t.Repayments ([]miner.FeeDebtRepayment) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Repayments: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Repayments = make([]FeeDebtRepayment, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v FeeDebtRepayment
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Repayments[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufFeeDebtAccrual = []byte{131}
+
+func (t *FeeDebtAccrual) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufFeeDebtAccrual); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Reason (miner.FeeDebtReason) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Reason)); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *FeeDebtAccrual) UnmarshalCBOR(r io.Reader) error {
+	*t = FeeDebtAccrual{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.Reason (miner.FeeDebtReason) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Reason = FeeDebtReason(extra)
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufFeeDebtRepayment = []byte{130}
+
+func (t *FeeDebtRepayment) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufFeeDebtRepayment); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.PeriodStart (abi.ChainEpoch) (int64)
+	if t.PeriodStart >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.PeriodStart)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.PeriodStart-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *FeeDebtRepayment) UnmarshalCBOR(r io.Reader) error {
+	*t = FeeDebtRepayment{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.PeriodStart (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.PeriodStart = abi.ChainEpoch(extraI)
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufGetFeeDebtStatusReturn = []byte{131}
+
+func (t *GetFeeDebtStatusReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetFeeDebtStatusReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.FeeDebt (big.Int) (struct)
+	if err := t.FeeDebt.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.LastAccrual (miner.FeeDebtAccrual) (struct)
+	if err := t.LastAccrual.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RecentRepayments ([]miner.FeeDebtRepayment) (slice)
+	if len(t.RecentRepayments) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.RecentRepayments was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.RecentRepayments))); err != nil {
+		return err
+	}
+	for _, v := range t.RecentRepayments {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetFeeDebtStatusReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetFeeDebtStatusReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.FeeDebt (big.Int) (struct)
+
+	{
+
+		if err := t.FeeDebt.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.FeeDebt: %w", err)
+		}
+
+	}
+	// t.LastAccrual (miner.FeeDebtAccrual) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.LastAccrual = new(FeeDebtAccrual)
+			if err := t.LastAccrual.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.LastAccrual pointer: %w", err)
+			}
+		}
+
+	}
+	// t.RecentRepayments ([]miner.FeeDebtRepayment) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.RecentRepayments: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.RecentRepayments = make([]FeeDebtRepayment, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v FeeDebtRepayment
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.RecentRepayments[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufRebalanceCursor = []byte{131}
+
+func (t *RebalanceCursor) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRebalanceCursor); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NextPeriodStart (abi.ChainEpoch) (int64)
+	if t.NextPeriodStart >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.NextPeriodStart)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.NextPeriodStart-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Deadline (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Deadline)); err != nil {
+		return err
+	}
+
+	// t.Imbalanced (bool) (bool)
+	if err := cbg.WriteBool(w, t.Imbalanced); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RebalanceCursor) UnmarshalCBOR(r io.Reader) error {
+	*t = RebalanceCursor{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NextPeriodStart (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.NextPeriodStart = abi.ChainEpoch(extraI)
+	}
+	// t.Deadline (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Deadline = uint64(extra)
+
+	}
+	// t.Imbalanced (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Imbalanced = false
+	case 21:
+		t.Imbalanced = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufPendingPauseChange = []byte{131}
+
+func (t *PendingPauseChange) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufPendingPauseChange); err != nil {
+		return err
+	}
+
+	// t.Paused (bool) (bool)
+	if err := cbg.WriteBool(w, t.Paused); err != nil {
+		return err
+	}
+
+	// t.ApprovedByOwner (bool) (bool)
+	if err := cbg.WriteBool(w, t.ApprovedByOwner); err != nil {
+		return err
+	}
+
+	// t.ApprovedByGovernance (bool) (bool)
+	if err := cbg.WriteBool(w, t.ApprovedByGovernance); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *PendingPauseChange) UnmarshalCBOR(r io.Reader) error {
+	*t = PendingPauseChange{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Paused (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Paused = false
+	case 21:
+		t.Paused = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.ApprovedByOwner (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.ApprovedByOwner = false
+	case 21:
+		t.ApprovedByOwner = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.ApprovedByGovernance (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.ApprovedByGovernance = false
+	case 21:
+		t.ApprovedByGovernance = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufUnsealingWindows = []byte{129}
+
+func (t *UnsealingWindows) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUnsealingWindows); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Windows ([]miner.UnsealingWindow) (slice)
+	if len(t.Windows) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Windows was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Windows))); err != nil {
+		return err
+	}
+	for _, v := range t.Windows {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *UnsealingWindows) UnmarshalCBOR(r io.Reader) error {
+	*t = UnsealingWindows{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Windows ([]miner.UnsealingWindow) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Windows: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Windows = make([]UnsealingWindow, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v UnsealingWindow
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Windows[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufUnsealingWindow = []byte{131}
+
+func (t *UnsealingWindow) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUnsealingWindow); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Sectors (bitfield.BitField) (struct)
+	if err := t.Sectors.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Start (abi.ChainEpoch) (int64)
+	if t.Start >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Start)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Start-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.End (abi.ChainEpoch) (int64)
+	if t.End >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.End)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.End-1)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *UnsealingWindow) UnmarshalCBOR(r io.Reader) error {
+	*t = UnsealingWindow{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Sectors (bitfield.BitField) (struct)
+
+	{
+
+		if err := t.Sectors.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Sectors: %w", err)
+		}
+
+	}
+	// t.Start (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Start = abi.ChainEpoch(extraI)
+	}
+	// t.End (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.End = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufDeclareUnsealingWindowParams = []byte{131}
+
+func (t *DeclareUnsealingWindowParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDeclareUnsealingWindowParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Sectors (bitfield.BitField) (struct)
+	if err := t.Sectors.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Start (abi.ChainEpoch) (int64)
+	if t.Start >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Start)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Start-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.End (abi.ChainEpoch) (int64)
+	if t.End >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.End)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.End-1)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *DeclareUnsealingWindowParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DeclareUnsealingWindowParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Sectors (bitfield.BitField) (struct)
+
+	{
+
+		if err := t.Sectors.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Sectors: %w", err)
+		}
+
+	}
+	// t.Start (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Start = abi.ChainEpoch(extraI)
+	}
+	// t.End (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.End = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufGetUnsealingWindowsReturn = []byte{129}
+
+func (t *GetUnsealingWindowsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetUnsealingWindowsReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Windows ([]miner.UnsealingWindow) (slice)
+	if len(t.Windows) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Windows was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Windows))); err != nil {
+		return err
+	}
+	for _, v := range t.Windows {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetUnsealingWindowsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetUnsealingWindowsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Windows ([]miner.UnsealingWindow) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Windows: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Windows = make([]UnsealingWindow, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v UnsealingWindow
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Windows[i] = v
+	}
+
+	return nil
+}
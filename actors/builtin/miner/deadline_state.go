@@ -82,6 +82,20 @@ type Deadline struct {
 	// These proofs may be disputed via DisputeWindowedPoSt. Successfully
 	// disputed window PoSts are removed from the snapshot.
 	OptimisticPoStSubmissionsSnapshot cid.Cid
+
+	// Counts of sectors that have left this deadline, by the cause of their leaving.
+	// Unlike the partitions' terminated sectors, these are not reduced when dead sectors are compacted away.
+	SectorExits SectorExitCounts
+}
+
+// Counts of sectors that are no longer live, by the cause of their leaving.
+type SectorExitCounts struct {
+	// Sectors that expired at their committed expiration epoch.
+	OnTime uint64
+	// Sectors terminated before their expiration.
+	Terminated uint64
+	// Sectors that expired before their expiration, having been faulty for too long.
+	FaultExpired uint64
 }
 
 type WindowedPoSt struct {
@@ -96,7 +110,10 @@ type WindowedPoSt struct {
 
 // Bitwidth of AMTs determined empirically from mutation patterns and projections of mainnet data.
 const DeadlinePartitionsAmtBitwidth = 3 // Usually a small array
-const DeadlineExpirationAmtBitwidth = 5
+
+// Bitwidth of the deadline expiration queue.
+// Configurable by policy (see AmtBitwidths); state written with a different value must be re-encoded.
+var DeadlineExpirationAmtBitwidth = 5
 
 // Given that 4 partitions can be proven in one post, this AMT's height will
 // only exceed the partition AMT's height at ~0.75EiB of storage.
@@ -370,6 +387,8 @@ func (dl *Deadline) PopExpiredSectors(store adt.Store, until abi.ChainEpoch, qua
 		return nil, xerrors.Errorf("failed to count early expired sectors: %w", err)
 	}
 	dl.LiveSectors -= onTimeCount + earlyCount
+	dl.SectorExits.OnTime += onTimeCount
+	dl.SectorExits.FaultExpired += earlyCount
 
 	dl.FaultyPower = dl.FaultyPower.Sub(allFaultyPower)
 
@@ -623,6 +642,7 @@ func (dl *Deadline) TerminateSectors(
 			dl.EarlyTerminations.Set(partIdx)
 			// Record change to sectors and power
 			dl.LiveSectors -= count
+			dl.SectorExits.Terminated += count
 		} // note: we should _always_ have early terminations, unless the early termination bitfield is empty.
 
 		dl.FaultyPower = dl.FaultyPower.Sub(removed.FaultyPower)
@@ -793,14 +813,55 @@ func (dl *Deadline) RemovePartitions(store adt.Store, toRemove bitfield.BitField
 	return live, dead, removedPower, nil
 }
 
+// Returns the index of the deadline's last partition, and whether that partition may be moved to
+// another deadline. It may be moved only if it has no faulty or unproven sectors, and the deadline
+// has no pending early terminations.
+func (dl *Deadline) lastPartitionMovable(store adt.Store) (uint64, bool, error) {
+	noEarlyTerminations, err := dl.EarlyTerminations.IsEmpty()
+	if err != nil {
+		return 0, false, xerrors.Errorf("failed to check for early terminations: %w", err)
+	}
+	if !noEarlyTerminations {
+		return 0, false, nil
+	}
+
+	partitions, err := dl.PartitionsArray(store)
+	if err != nil {
+		return 0, false, err
+	}
+	if partitions.Length() == 0 {
+		return 0, false, nil
+	}
+	partIdx := partitions.Length() - 1
+	var partition Partition
+	found, err := partitions.Get(partIdx, &partition)
+	if err != nil {
+		return 0, false, xerrors.Errorf("failed to load partition %d: %w", partIdx, err)
+	}
+	if !found {
+		return 0, false, xerrors.Errorf("no partition %d", partIdx)
+	}
+
+	noFaults, err := partition.Faults.IsEmpty()
+	if err != nil {
+		return 0, false, xerrors.Errorf("failed to decode faults for partition %d: %w", partIdx, err)
+	}
+	allProven, err := partition.Unproven.IsEmpty()
+	if err != nil {
+		return 0, false, xerrors.Errorf("failed to decode unproven for partition %d: %w", partIdx, err)
+	}
+	return partIdx, noFaults && allProven, nil
+}
+
 func (dl *Deadline) RecordFaults(
 	store adt.Store, sectors Sectors, ssize abi.SectorSize, quant builtin.QuantSpec,
 	faultExpirationEpoch abi.ChainEpoch, partitionSectors PartitionSectorMap,
 ) (powerDelta PowerPair, err error) {
-	partitions, err := dl.PartitionsArray(store)
+	partitionsArr, err := dl.PartitionsArray(store)
 	if err != nil {
 		return NewPowerPairZero(), err
 	}
+	partitions := adt.NewArrayBatch(partitionsArr)
 
 	// Record partitions with some fault, for subsequently indexing in the deadline.
 	// Duplicate entries don't matter, they'll be stored in a bitfield (a set).
@@ -838,7 +899,7 @@ func (dl *Deadline) RecordFaults(
 		return NewPowerPairZero(), err
 	}
 
-	dl.Partitions, err = partitions.Root()
+	dl.Partitions, err = partitions.Commit()
 	if err != nil {
 		return NewPowerPairZero(), xc.ErrIllegalState.Wrapf("failed to store partitions root: %w", err)
 	}
@@ -855,10 +916,11 @@ func (dl *Deadline) DeclareFaultsRecovered(
 	store adt.Store, sectors Sectors, ssize abi.SectorSize,
 	partitionSectors PartitionSectorMap,
 ) (err error) {
-	partitions, err := dl.PartitionsArray(store)
+	partitionsArr, err := dl.PartitionsArray(store)
 	if err != nil {
 		return err
 	}
+	partitions := adt.NewArrayBatch(partitionsArr)
 
 	if err := partitionSectors.ForEach(func(partIdx uint64, sectorNos bitfield.BitField) error {
 		var partition Partition
@@ -883,7 +945,7 @@ func (dl *Deadline) DeclareFaultsRecovered(
 
 	// Power is not regained until the deadline end, when the recovery is confirmed.
 
-	dl.Partitions, err = partitions.Root()
+	dl.Partitions, err = partitions.Commit()
 	if err != nil {
 		return xc.ErrIllegalState.Wrapf("failed to store partitions root: %w", err)
 	}
@@ -899,14 +961,15 @@ func (dl *Deadline) ProcessDeadlineEnd(store adt.Store, quant builtin.QuantSpec,
 	powerDelta = NewPowerPairZero()
 	penalizedPower = NewPowerPairZero()
 
-	partitions, err := dl.PartitionsArray(store)
+	partitionsArr, err := dl.PartitionsArray(store)
 	if err != nil {
 		return powerDelta, penalizedPower, xerrors.Errorf("failed to load partitions: %w", err)
 	}
+	partitions := adt.NewArrayBatch(partitionsArr)
 
 	detectedAny := false
 	var rescheduledPartitions []uint64
-	for partIdx := uint64(0); partIdx < partitions.Length(); partIdx++ {
+	for partIdx := uint64(0); partIdx < partitionsArr.Length(); partIdx++ {
 		proven, err := dl.PartitionsPoSted.IsSet(partIdx)
 		if err != nil {
 			return powerDelta, penalizedPower, xerrors.Errorf("failed to check submission for partition %d: %w", partIdx, err)
@@ -959,7 +1022,7 @@ func (dl *Deadline) ProcessDeadlineEnd(store adt.Store, quant builtin.QuantSpec,
 
 	// Save modified deadline state.
 	if detectedAny {
-		dl.Partitions, err = partitions.Root()
+		dl.Partitions, err = partitions.Commit()
 		if err != nil {
 			return powerDelta, penalizedPower, xc.ErrIllegalState.Wrapf("failed to store partitions: %w", err)
 		}
@@ -1040,10 +1103,12 @@ func (dl *Deadline) RecordProvenSectors(
 		return nil, xc.ErrIllegalArgument.Wrapf("partition already proven: %v", alreadyProven)
 	}
 
-	partitions, err := dl.PartitionsArray(store)
+	partitionsArr, err := dl.PartitionsArray(store)
 	if err != nil {
 		return nil, err
 	}
+	// Partitions are written back together once all posts have been processed.
+	partitions := adt.NewArrayBatch(partitionsArr)
 
 	allSectors := make([]bitfield.BitField, 0, len(postPartitions))
 	allIgnored := make([]bitfield.BitField, 0, len(postPartitions))
@@ -1115,7 +1180,7 @@ func (dl *Deadline) RecordProvenSectors(
 	// Save everything back.
 	dl.FaultyPower = dl.FaultyPower.Sub(recoveredPowerTotal).Add(newFaultyPowerTotal)
 
-	dl.Partitions, err = partitions.Root()
+	dl.Partitions, err = partitions.Commit()
 	if err != nil {
 		return nil, xc.ErrIllegalState.Wrapf("failed to persist partitions: %w", err)
 	}
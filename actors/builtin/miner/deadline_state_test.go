@@ -118,6 +118,7 @@ func TestDeadlines(t *testing.T) {
 			expectedPower = sectorPower(t, 1, 3, 6)
 		}
 		require.True(t, expectedPower.Equals(removedPower), "dlState to remove power for terminated sectors")
+		assert.Equal(t, miner.SectorExitCounts{Terminated: 3}, dl.SectorExits)
 
 		dlState.withTerminations(1, 3, 6).
 			withUnproven(unproven...).
@@ -160,6 +161,8 @@ func TestDeadlines(t *testing.T) {
 		assertBitfieldEquals(t, dead, 1, 3)
 		livePower := miner.PowerForSectors(sectorSize, selectSectors(t, sectors, live))
 		require.True(t, livePower.Equals(removedPower))
+		// Removing dead sectors doesn't forget how they left.
+		assert.Equal(t, miner.SectorExitCounts{Terminated: 3}, dl.SectorExits)
 
 		dlState.withTerminations(6).
 			withPartitions(
@@ -460,6 +463,7 @@ func TestDeadlines(t *testing.T) {
 
 		assertBitfieldsEqual(t, onTimeExpected, exp.OnTimeSectors)
 		assertBitfieldsEqual(t, earlyExpected, exp.EarlySectors)
+		assert.Equal(t, miner.SectorExitCounts{OnTime: 7, FaultExpired: 1}, dl.SectorExits)
 
 		dlState.withTerminations(1, 2, 3, 4, 5, 6, 8, 9).
 			withPartitions(
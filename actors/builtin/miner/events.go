@@ -0,0 +1,32 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// Types of the events emitted by the miner actor over the lifecycle of a sector.
+// Each event carries the number of the sector under the key EventKeySector.
+const (
+	EventSectorPrecommitted = "sector-precommitted"
+	EventSectorActivated    = "sector-activated"
+	EventSectorUpdated      = "sector-updated"
+	EventSectorTerminated   = "sector-terminated"
+)
+
+const EventKeySector = "sector"
+
+func emitSectorEvent(rt Runtime, eventType string, sectorNo abi.SectorNumber) {
+	builtin.NewEventBuilder(eventType).WithSector(EventKeySector, sectorNo).Emit(rt)
+}
+
+func emitSectorEvents(rt Runtime, eventType string, sectorNos bitfield.BitField) {
+	err := sectorNos.ForEach(func(sectorNo uint64) error {
+		emitSectorEvent(rt, eventType, abi.SectorNumber(sectorNo))
+		return nil
+	})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to emit %s events", eventType)
+}
@@ -0,0 +1,105 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// FeeDebtReason identifies the penalty that caused fee debt to accrue.
+type FeeDebtReason uint64
+
+const (
+	FeeDebtReasonUnknown FeeDebtReason = iota
+	FeeDebtReasonContinuedFault
+	FeeDebtReasonDisputedWindowPoSt
+	FeeDebtReasonConsensusFault
+	FeeDebtReasonEarlyTermination
+	FeeDebtReasonExpiredPreCommit
+	FeeDebtReasonRewardPenalty
+	FeeDebtReasonAggregateFee
+)
+
+func (r FeeDebtReason) String() string {
+	switch r {
+	case FeeDebtReasonContinuedFault:
+		return "ContinuedFault"
+	case FeeDebtReasonDisputedWindowPoSt:
+		return "DisputedWindowPoSt"
+	case FeeDebtReasonConsensusFault:
+		return "ConsensusFault"
+	case FeeDebtReasonEarlyTermination:
+		return "EarlyTermination"
+	case FeeDebtReasonExpiredPreCommit:
+		return "ExpiredPreCommit"
+	case FeeDebtReasonRewardPenalty:
+		return "RewardPenalty"
+	case FeeDebtReasonAggregateFee:
+		return "AggregateFee"
+	default:
+		return "Unknown"
+	}
+}
+
+// FeeDebtLog records recent fee debt accruals and repayments for the miner.
+// Both slices are sorted oldest first and bounded in length, with the oldest
+// entries dropped as new ones are recorded.
+type FeeDebtLog struct {
+	Accruals   []FeeDebtAccrual
+	Repayments []FeeDebtRepayment
+}
+
+// FeeDebtAccrual records a penalty added to fee debt.
+type FeeDebtAccrual struct {
+	Epoch  abi.ChainEpoch
+	Reason FeeDebtReason
+	Amount abi.TokenAmount
+}
+
+// FeeDebtRepayment records the total fee debt repaid during a proving period.
+type FeeDebtRepayment struct {
+	PeriodStart abi.ChainEpoch
+	Amount      abi.TokenAmount
+}
+
+// ConstructFeeDebtLog constructs an empty FeeDebtLog.
+func ConstructFeeDebtLog() *FeeDebtLog {
+	return &FeeDebtLog{
+		Accruals:   []FeeDebtAccrual{},
+		Repayments: []FeeDebtRepayment{},
+	}
+}
+
+// LastAccrual returns the most recently recorded accrual, or nil if none has been recorded.
+func (l *FeeDebtLog) LastAccrual() *FeeDebtAccrual {
+	if len(l.Accruals) == 0 {
+		return nil
+	}
+	last := l.Accruals[len(l.Accruals)-1]
+	return &last
+}
+
+func (l *FeeDebtLog) addAccrual(epoch abi.ChainEpoch, reason FeeDebtReason, amount abi.TokenAmount) {
+	l.Accruals = append(l.Accruals, FeeDebtAccrual{
+		Epoch:  epoch,
+		Reason: reason,
+		Amount: amount,
+	})
+	if len(l.Accruals) > FeeDebtLogAccrualsMax {
+		l.Accruals = l.Accruals[len(l.Accruals)-FeeDebtLogAccrualsMax:]
+	}
+}
+
+// Repayments within the same proving period are merged into a single entry.
+func (l *FeeDebtLog) addRepayment(periodStart abi.ChainEpoch, amount abi.TokenAmount) {
+	if n := len(l.Repayments); n > 0 && l.Repayments[n-1].PeriodStart == periodStart {
+		l.Repayments[n-1].Amount = big.Add(l.Repayments[n-1].Amount, amount)
+		return
+	}
+	l.Repayments = append(l.Repayments, FeeDebtRepayment{
+		PeriodStart: periodStart,
+		Amount:      amount,
+	})
+	if len(l.Repayments) > FeeDebtLogRepaymentPeriodsMax {
+		l.Repayments = l.Repayments[len(l.Repayments)-FeeDebtLogRepaymentPeriodsMax:]
+	}
+}
@@ -0,0 +1,574 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package miner
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Info", Value: &t.Info},
+		{Name: "PreCommitDeposits", Value: (*jsonenc.BigInt)(&t.PreCommitDeposits)},
+		{Name: "LockedFunds", Value: (*jsonenc.BigInt)(&t.LockedFunds)},
+		{Name: "VestingFunds", Value: &t.VestingFunds},
+		{Name: "FeeDebt", Value: (*jsonenc.BigInt)(&t.FeeDebt)},
+		{Name: "InitialPledge", Value: (*jsonenc.BigInt)(&t.InitialPledge)},
+		{Name: "PreCommittedSectors", Value: &t.PreCommittedSectors},
+		{Name: "PreCommittedSectorsCleanUp", Value: &t.PreCommittedSectorsCleanUp},
+		{Name: "AllocatedSectors", Value: &t.AllocatedSectors},
+		{Name: "Sectors", Value: &t.Sectors},
+		{Name: "ProvingPeriodStart", Value: &t.ProvingPeriodStart},
+		{Name: "CurrentDeadline", Value: &t.CurrentDeadline},
+		{Name: "Deadlines", Value: &t.Deadlines},
+		{Name: "EarlyTerminations", Value: (*jsonenc.BitField)(&t.EarlyTerminations)},
+		{Name: "DeadlineCronActive", Value: &t.DeadlineCronActive},
+		{Name: "FeeDebtLog", Value: &t.FeeDebtLog},
+		{Name: "Rebalance", Value: &t.Rebalance},
+		{Name: "Paused", Value: &t.Paused},
+		{Name: "PendingPauseChange", Value: &t.PendingPauseChange},
+		{Name: "UnsealingWindows", Value: &t.UnsealingWindows},
+	}
+}
+
+func (t MinerInfo) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *MinerInfo) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *MinerInfo) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Owner", Value: &t.Owner},
+		{Name: "Worker", Value: &t.Worker},
+		{Name: "ControlAddresses", Value: &t.ControlAddresses},
+		{Name: "PendingWorkerKey", Value: &t.PendingWorkerKey},
+		{Name: "Beneficiary", Value: &t.Beneficiary},
+		{Name: "BeneficiaryTerm", Value: &t.BeneficiaryTerm},
+		{Name: "PendingBeneficiaryTerm", Value: &t.PendingBeneficiaryTerm},
+		{Name: "PeerId", Value: &t.PeerId},
+		{Name: "Multiaddrs", Value: &t.Multiaddrs},
+		{Name: "WindowPoStProofType", Value: &t.WindowPoStProofType},
+		{Name: "SectorSize", Value: &t.SectorSize},
+		{Name: "WindowPoStPartitionSectors", Value: &t.WindowPoStPartitionSectors},
+		{Name: "ConsensusFaultElapsed", Value: &t.ConsensusFaultElapsed},
+		{Name: "PendingOwnerAddress", Value: &t.PendingOwnerAddress},
+	}
+}
+
+func (t Deadlines) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *Deadlines) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *Deadlines) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Due", Value: &t.Due},
+	}
+}
+
+func (t Deadline) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *Deadline) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *Deadline) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Partitions", Value: &t.Partitions},
+		{Name: "ExpirationsEpochs", Value: &t.ExpirationsEpochs},
+		{Name: "PartitionsPoSted", Value: (*jsonenc.BitField)(&t.PartitionsPoSted)},
+		{Name: "EarlyTerminations", Value: (*jsonenc.BitField)(&t.EarlyTerminations)},
+		{Name: "LiveSectors", Value: &t.LiveSectors},
+		{Name: "TotalSectors", Value: &t.TotalSectors},
+		{Name: "FaultyPower", Value: &t.FaultyPower},
+		{Name: "OptimisticPoStSubmissions", Value: &t.OptimisticPoStSubmissions},
+		{Name: "SectorsSnapshot", Value: &t.SectorsSnapshot},
+		{Name: "PartitionsSnapshot", Value: &t.PartitionsSnapshot},
+		{Name: "OptimisticPoStSubmissionsSnapshot", Value: &t.OptimisticPoStSubmissionsSnapshot},
+		{Name: "SectorExits", Value: &t.SectorExits},
+	}
+}
+
+func (t SectorExitCounts) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *SectorExitCounts) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *SectorExitCounts) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "OnTime", Value: &t.OnTime},
+		{Name: "Terminated", Value: &t.Terminated},
+		{Name: "FaultExpired", Value: &t.FaultExpired},
+	}
+}
+
+func (t Partition) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *Partition) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *Partition) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Sectors", Value: (*jsonenc.BitField)(&t.Sectors)},
+		{Name: "Unproven", Value: (*jsonenc.BitField)(&t.Unproven)},
+		{Name: "Faults", Value: (*jsonenc.BitField)(&t.Faults)},
+		{Name: "Recoveries", Value: (*jsonenc.BitField)(&t.Recoveries)},
+		{Name: "Terminated", Value: (*jsonenc.BitField)(&t.Terminated)},
+		{Name: "ExpirationsEpochs", Value: &t.ExpirationsEpochs},
+		{Name: "EarlyTerminated", Value: &t.EarlyTerminated},
+		{Name: "LivePower", Value: &t.LivePower},
+		{Name: "UnprovenPower", Value: &t.UnprovenPower},
+		{Name: "FaultyPower", Value: &t.FaultyPower},
+		{Name: "RecoveringPower", Value: &t.RecoveringPower},
+	}
+}
+
+func (t ExpirationSet) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ExpirationSet) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ExpirationSet) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "OnTimeSectors", Value: (*jsonenc.BitField)(&t.OnTimeSectors)},
+		{Name: "EarlySectors", Value: (*jsonenc.BitField)(&t.EarlySectors)},
+		{Name: "OnTimePledge", Value: (*jsonenc.BigInt)(&t.OnTimePledge)},
+		{Name: "ActivePower", Value: &t.ActivePower},
+		{Name: "FaultyPower", Value: &t.FaultyPower},
+	}
+}
+
+func (t PowerPair) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *PowerPair) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *PowerPair) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Raw", Value: (*jsonenc.BigInt)(&t.Raw)},
+		{Name: "QA", Value: (*jsonenc.BigInt)(&t.QA)},
+	}
+}
+
+func (t SectorPreCommitOnChainInfo) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *SectorPreCommitOnChainInfo) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *SectorPreCommitOnChainInfo) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Info", Value: &t.Info},
+		{Name: "PreCommitDeposit", Value: (*jsonenc.BigInt)(&t.PreCommitDeposit)},
+		{Name: "PreCommitEpoch", Value: &t.PreCommitEpoch},
+		{Name: "DealWeight", Value: (*jsonenc.BigInt)(&t.DealWeight)},
+		{Name: "VerifiedDealWeight", Value: (*jsonenc.BigInt)(&t.VerifiedDealWeight)},
+	}
+}
+
+func (t SectorPreCommitInfo) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *SectorPreCommitInfo) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *SectorPreCommitInfo) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "SealProof", Value: &t.SealProof},
+		{Name: "SectorNumber", Value: &t.SectorNumber},
+		{Name: "SealedCID", Value: &t.SealedCID},
+		{Name: "SealRandEpoch", Value: &t.SealRandEpoch},
+		{Name: "DealIDs", Value: &t.DealIDs},
+		{Name: "Expiration", Value: &t.Expiration},
+		{Name: "ReplaceCapacity", Value: &t.ReplaceCapacity},
+		{Name: "ReplaceSectorDeadline", Value: &t.ReplaceSectorDeadline},
+		{Name: "ReplaceSectorPartition", Value: &t.ReplaceSectorPartition},
+		{Name: "ReplaceSectorNumber", Value: &t.ReplaceSectorNumber},
+	}
+}
+
+func (t SectorOnChainInfo) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *SectorOnChainInfo) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *SectorOnChainInfo) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "SectorNumber", Value: &t.SectorNumber},
+		{Name: "SealProof", Value: &t.SealProof},
+		{Name: "SealedCID", Value: &t.SealedCID},
+		{Name: "DealIDs", Value: &t.DealIDs},
+		{Name: "Activation", Value: &t.Activation},
+		{Name: "Expiration", Value: &t.Expiration},
+		{Name: "DealWeight", Value: (*jsonenc.BigInt)(&t.DealWeight)},
+		{Name: "VerifiedDealWeight", Value: (*jsonenc.BigInt)(&t.VerifiedDealWeight)},
+		{Name: "InitialPledge", Value: (*jsonenc.BigInt)(&t.InitialPledge)},
+		{Name: "ExpectedDayReward", Value: (*jsonenc.BigInt)(&t.ExpectedDayReward)},
+		{Name: "ExpectedStoragePledge", Value: (*jsonenc.BigInt)(&t.ExpectedStoragePledge)},
+		{Name: "ReplacedSectorAge", Value: &t.ReplacedSectorAge},
+		{Name: "ReplacedDayReward", Value: (*jsonenc.BigInt)(&t.ReplacedDayReward)},
+		{Name: "SectorKeyCID", Value: &t.SectorKeyCID},
+	}
+}
+
+func (t WorkerKeyChange) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *WorkerKeyChange) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *WorkerKeyChange) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "NewWorker", Value: &t.NewWorker},
+		{Name: "EffectiveAt", Value: &t.EffectiveAt},
+	}
+}
+
+func (t ChangeBeneficiaryParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ChangeBeneficiaryParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ChangeBeneficiaryParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "NewBeneficiary", Value: &t.NewBeneficiary},
+		{Name: "NewQuota", Value: (*jsonenc.BigInt)(&t.NewQuota)},
+		{Name: "NewExpiration", Value: &t.NewExpiration},
+	}
+}
+
+func (t BeneficiaryTerm) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *BeneficiaryTerm) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *BeneficiaryTerm) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Quota", Value: (*jsonenc.BigInt)(&t.Quota)},
+		{Name: "Expiration", Value: &t.Expiration},
+		{Name: "UsedQuota", Value: (*jsonenc.BigInt)(&t.UsedQuota)},
+	}
+}
+
+func (t GetBeneficiaryReturn) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *GetBeneficiaryReturn) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *GetBeneficiaryReturn) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Active", Value: &t.Active},
+		{Name: "Proposed", Value: &t.Proposed},
+	}
+}
+
+func (t GetFeeDebtStatusReturn) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *GetFeeDebtStatusReturn) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *GetFeeDebtStatusReturn) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "FeeDebt", Value: (*jsonenc.BigInt)(&t.FeeDebt)},
+		{Name: "LastAccrual", Value: &t.LastAccrual},
+		{Name: "RecentRepayments", Value: &t.RecentRepayments},
+	}
+}
+
+func (t ActiveBeneficiary) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ActiveBeneficiary) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ActiveBeneficiary) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Beneficiary", Value: &t.Beneficiary},
+		{Name: "Term", Value: &t.Term},
+	}
+}
+
+func (t PendingBeneficiaryChange) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *PendingBeneficiaryChange) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *PendingBeneficiaryChange) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "NewBeneficiary", Value: &t.NewBeneficiary},
+		{Name: "NewQuota", Value: (*jsonenc.BigInt)(&t.NewQuota)},
+		{Name: "NewExpiration", Value: &t.NewExpiration},
+		{Name: "ApprovedByBeneficiary", Value: &t.ApprovedByBeneficiary},
+		{Name: "ApprovedByNominee", Value: &t.ApprovedByNominee},
+	}
+}
+
+func (t VestingFunds) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *VestingFunds) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *VestingFunds) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Funds", Value: &t.Funds},
+	}
+}
+
+func (t VestingFund) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *VestingFund) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *VestingFund) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Epoch", Value: &t.Epoch},
+		{Name: "Amount", Value: (*jsonenc.BigInt)(&t.Amount)},
+	}
+}
+
+func (t FeeDebtLog) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *FeeDebtLog) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *FeeDebtLog) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Accruals", Value: &t.Accruals},
+		{Name: "Repayments", Value: &t.Repayments},
+	}
+}
+
+func (t FeeDebtAccrual) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *FeeDebtAccrual) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *FeeDebtAccrual) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Epoch", Value: &t.Epoch},
+		{Name: "Reason", Value: &t.Reason},
+		{Name: "Amount", Value: (*jsonenc.BigInt)(&t.Amount)},
+	}
+}
+
+func (t FeeDebtRepayment) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *FeeDebtRepayment) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *FeeDebtRepayment) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "PeriodStart", Value: &t.PeriodStart},
+		{Name: "Amount", Value: (*jsonenc.BigInt)(&t.Amount)},
+	}
+}
+
+func (t WindowedPoSt) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *WindowedPoSt) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *WindowedPoSt) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Partitions", Value: (*jsonenc.BitField)(&t.Partitions)},
+		{Name: "Proofs", Value: &t.Proofs},
+	}
+}
+
+func (t RebalanceCursor) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *RebalanceCursor) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *RebalanceCursor) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "NextPeriodStart", Value: &t.NextPeriodStart},
+		{Name: "Deadline", Value: &t.Deadline},
+		{Name: "Imbalanced", Value: &t.Imbalanced},
+	}
+}
+
+func (t PendingPauseChange) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *PendingPauseChange) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *PendingPauseChange) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Paused", Value: &t.Paused},
+		{Name: "ApprovedByOwner", Value: &t.ApprovedByOwner},
+		{Name: "ApprovedByGovernance", Value: &t.ApprovedByGovernance},
+	}
+}
+
+func (t UnsealingWindows) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *UnsealingWindows) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *UnsealingWindows) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Windows", Value: &t.Windows},
+	}
+}
+
+func (t UnsealingWindow) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *UnsealingWindow) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *UnsealingWindow) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Sectors", Value: (*jsonenc.BitField)(&t.Sectors)},
+		{Name: "Start", Value: &t.Start},
+		{Name: "End", Value: &t.End},
+	}
+}
+
+func (t DeclareUnsealingWindowParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *DeclareUnsealingWindowParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *DeclareUnsealingWindowParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Sectors", Value: (*jsonenc.BitField)(&t.Sectors)},
+		{Name: "Start", Value: &t.Start},
+		{Name: "End", Value: &t.End},
+	}
+}
+
+func (t GetUnsealingWindowsReturn) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *GetUnsealingWindowsReturn) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *GetUnsealingWindowsReturn) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Windows", Value: &t.Windows},
+	}
+}
+
+func (t ProveReplicaUpdatesParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ProveReplicaUpdatesParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ProveReplicaUpdatesParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "Updates", Value: &t.Updates},
+	}
+}
+
+func (t ReplicaUpdate) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ReplicaUpdate) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ReplicaUpdate) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "SectorID", Value: &t.SectorID},
+		{Name: "Deadline", Value: &t.Deadline},
+		{Name: "Partition", Value: &t.Partition},
+		{Name: "NewSealedSectorCID", Value: &t.NewSealedSectorCID},
+		{Name: "Deals", Value: &t.Deals},
+		{Name: "UpdateProofType", Value: &t.UpdateProofType},
+		{Name: "ReplicaProof", Value: &t.ReplicaProof},
+	}
+}
@@ -41,6 +41,9 @@ const (
 	// actually happen without programming error in the actor code.
 	//ErrToBeDetermined = exitcode.FirstActorSpecificExitCode + iota
 
+	// The miner has been paused by its owner and the governance address.
+	ErrMinerPaused = exitcode.FirstActorSpecificExitCode
+
 	// The following errors are particular cases of illegal state.
 	// They're not expected to ever happen, but if they do, distinguished codes can help us
 	// diagnose the problem.
@@ -51,35 +54,41 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.ControlAddresses,
-		3:                         a.ChangeWorkerAddress,
-		4:                         a.ChangePeerID,
-		5:                         a.SubmitWindowedPoSt,
-		6:                         a.PreCommitSector,
-		7:                         a.ProveCommitSector,
-		8:                         a.ExtendSectorExpiration,
-		9:                         a.TerminateSectors,
-		10:                        a.DeclareFaults,
-		11:                        a.DeclareFaultsRecovered,
-		12:                        a.OnDeferredCronEvent,
-		13:                        a.CheckSectorProven,
-		14:                        a.ApplyRewards,
-		15:                        a.ReportConsensusFault,
-		16:                        a.WithdrawBalance,
-		17:                        a.ConfirmSectorProofsValid,
-		18:                        a.ChangeMultiaddrs,
-		19:                        a.CompactPartitions,
-		20:                        a.CompactSectorNumbers,
-		21:                        a.ConfirmUpdateWorkerKey,
-		22:                        a.RepayDebt,
-		23:                        a.ChangeOwnerAddress,
-		24:                        a.DisputeWindowedPoSt,
-		25:                        a.PreCommitSectorBatch,
-		26:                        a.ProveCommitAggregate,
-		27:                        a.ProveReplicaUpdates,
-		28:                        a.ChangeBeneficiary,
-		29:                        a.GetBeneficiary,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.ControlAddresses,
+		3:                          a.ChangeWorkerAddress,
+		4:                          a.ChangePeerID,
+		5:                          a.SubmitWindowedPoSt,
+		6:                          a.PreCommitSector,
+		7:                          a.ProveCommitSector,
+		8:                          a.ExtendSectorExpiration,
+		9:                          a.TerminateSectors,
+		10:                         a.DeclareFaults,
+		11:                         a.DeclareFaultsRecovered,
+		12:                         a.OnDeferredCronEvent,
+		13:                         a.CheckSectorProven,
+		14:                         a.ApplyRewards,
+		15:                         a.ReportConsensusFault,
+		16:                         a.WithdrawBalance,
+		17:                         a.ConfirmSectorProofsValid,
+		18:                         a.ChangeMultiaddrs,
+		19:                         a.CompactPartitions,
+		20:                         a.CompactSectorNumbers,
+		21:                         a.ConfirmUpdateWorkerKey,
+		22:                         a.RepayDebt,
+		23:                         a.ChangeOwnerAddress,
+		24:                         a.DisputeWindowedPoSt,
+		25:                         a.PreCommitSectorBatch,
+		26:                         a.ProveCommitAggregate,
+		27:                         a.ProveReplicaUpdates,
+		28:                         a.ChangeBeneficiary,
+		29:                         a.GetBeneficiary,
+		30:                         a.GetFeeDebtStatus,
+		31:                         a.PauseMiner,
+		32:                         a.UnpauseMiner,
+		33:                         a.DeclareUnsealingWindow,
+		34:                         a.GetUnsealingWindows,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -93,6 +102,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 /////////////////
 // Constructor //
 /////////////////
@@ -256,6 +270,10 @@ func (a Actor) ChangeOwnerAddress(rt Runtime, newAddress *addr.Address) *abi.Emp
 			}
 			// Cancel pending beneficiary term change when the owner changes
 			info.PendingBeneficiaryTerm = nil
+			// The new owner must approve any pending pause change afresh
+			if st.PendingPauseChange != nil {
+				st.PendingPauseChange.ApprovedByOwner = false
+			}
 
 			// Set the new owner address
 			info.Owner = *info.PendingOwnerAddress
@@ -285,6 +303,7 @@ func (a Actor) ChangePeerID(rt Runtime, params *ChangePeerIDParams) *abi.EmptyVa
 		info := getMinerInfo(rt, &st)
 
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		info.PeerId = params.NewID
 		err := st.SaveInfo(adt.AsStore(rt), info)
@@ -306,6 +325,7 @@ func (a Actor) ChangeMultiaddrs(rt Runtime, params *ChangeMultiaddrsParams) *abi
 		info := getMinerInfo(rt, &st)
 
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		info.Multiaddrs = params.NewMultiaddrs
 		err := st.SaveInfo(adt.AsStore(rt), info)
@@ -611,7 +631,7 @@ func (a Actor) DisputeWindowedPoSt(rt Runtime, params *DisputeWindowedPoStParams
 			// portion of their fee back as a reward.
 			penaltyTarget := big.Add(penaltyBase, rewardTarget)
 
-			err := st.ApplyPenalty(penaltyTarget)
+			err := st.ApplyPenalty(store, currEpoch, FeeDebtReasonDisputedWindowPoSt, penaltyTarget)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 			penaltyFromVesting, penaltyFromBalance, err := st.RepayPartialDebtInPriorityOrder(store, currEpoch, rt.CurrentBalance())
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to pay debt")
@@ -761,7 +781,7 @@ func (a Actor) PreCommitSectorBatch(rt Runtime, params *PreCommitSectorBatchPara
 		if len(params.Sectors) > 1 {
 			aggregateFee := AggregatePreCommitNetworkFee(len(params.Sectors), rt.BaseFee())
 			// AggregateFee applied to fee debt to consolidate burn with outstanding debts
-			err := st.ApplyPenalty(aggregateFee)
+			err := st.ApplyPenalty(store, rt.CurrEpoch(), FeeDebtReasonAggregateFee, aggregateFee)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 		}
 
@@ -774,6 +794,7 @@ func (a Actor) PreCommitSectorBatch(rt Runtime, params *PreCommitSectorBatchPara
 
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		if ConsensusFaultActive(info, currEpoch) {
 			rt.Abortf(exitcode.ErrForbidden, "pre-commit not allowed during active consensus fault")
@@ -853,6 +874,9 @@ func (a Actor) PreCommitSectorBatch(rt Runtime, params *PreCommitSectorBatchPara
 	})
 
 	burnFunds(rt, feeToBurn, BurnMethodPreCommitSectorBatch)
+	for _, precommit := range params.Sectors {
+		emitSectorEvent(rt, EventSectorPrecommitted, precommit.SectorNumber)
+	}
 	rt.StateReadonly(&st)
 	err = st.CheckBalanceInvariants(rt.CurrentBalance())
 	builtin.RequireNoErr(rt, err, ErrBalanceInvariantBroken, "balance invariants broken")
@@ -895,6 +919,7 @@ func (a Actor) ProveCommitAggregate(rt Runtime, params *ProveCommitAggregatePara
 
 	info := getMinerInfo(rt, &st)
 	rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+	requireNotPaused(rt, &st)
 
 	precommits, err := st.GetAllPrecommittedSectors(store, params.SectorNumbers)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get precommits")
@@ -931,19 +956,18 @@ func (a Actor) ProveCommitAggregate(rt Runtime, params *ProveCommitAggregatePara
 	receiver := rt.Receiver()
 	minerActorID, err := addr.IDFromAddress(receiver)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "runtime provided non-ID receiver address %s", receiver)
-	buf := new(bytes.Buffer)
-	err = receiver.MarshalCBOR(buf)
-	receiverBytes := buf.Bytes()
-	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to marshal address for seal verification challenge")
+	receiverBytes := builtin.AddressEntropy(rt, receiver)
 
+	// Sectors pre-committed together share randomness epochs, so draw each distinct randomness only once.
+	randomness := builtin.NewRandomnessCache(rt)
 	for i, precommit := range precommits {
 		interactiveEpoch := precommit.PreCommitEpoch + PreCommitChallengeDelay
 		if rt.CurrEpoch() <= interactiveEpoch {
 			rt.Abortf(exitcode.ErrForbidden, "too early to prove sector %d", precommit.Info.SectorNumber)
 		}
 
-		svInfoRandomness := rt.GetRandomnessFromTickets(crypto.DomainSeparationTag_SealRandomness, precommit.Info.SealRandEpoch, receiverBytes)
-		svInfoInteractiveRandomness := rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, interactiveEpoch, receiverBytes)
+		svInfoRandomness := randomness.FromTickets(crypto.DomainSeparationTag_SealRandomness, precommit.Info.SealRandEpoch, receiverBytes)
+		svInfoInteractiveRandomness := randomness.FromBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, interactiveEpoch, receiverBytes)
 		svi := proof.AggregateSealVerifyInfo{
 			Number:                precommit.Info.SectorNumber,
 			InteractiveRandomness: abi.InteractiveSealRandomness(svInfoInteractiveRandomness),
@@ -956,6 +980,7 @@ func (a Actor) ProveCommitAggregate(rt Runtime, params *ProveCommitAggregatePara
 
 	builtin.RequireState(rt, len(precommits) > 0, "bitfield non-empty but zero precommits read from state")
 	sealProof := precommits[0].Info.SealProof
+	endTrace := builtin.TraceGas(rt, runtime.GasOpVerifyProof, "VerifyAggregateSeals")
 	err = rt.VerifyAggregateSeals(
 		proof.AggregateSealVerifyProofAndInfos{
 			Infos:          svis,
@@ -964,6 +989,7 @@ func (a Actor) ProveCommitAggregate(rt Runtime, params *ProveCommitAggregatePara
 			SealProof:      sealProof,
 			AggregateProof: abi.RegisteredAggregationProof_SnarkPackV1,
 		})
+	endTrace()
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "aggregate seal verify failed")
 
 	rew := requestCurrentEpochBlockReward(rt)
@@ -1012,6 +1038,7 @@ func (a Actor) ProveCommitSector(rt Runtime, params *ProveCommitSectorParams) *a
 
 	var st State
 	rt.StateReadonly(&st)
+	requireNotPaused(rt, &st)
 
 	precommit, found, err := st.GetPrecommittedSector(store, sectorNo)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pre-committed sector %v", sectorNo)
@@ -1147,11 +1174,11 @@ func confirmSectorProofsValid(rt Runtime, preCommits []*SectorPreCommitOnChainIn
 			}
 			pwr := QAPowerForWeight(info.SectorSize, duration, precommit.DealWeight, precommit.VerifiedDealWeight)
 
-			dayReward := ExpectedRewardForPower(thisEpochRewardSmoothed, qualityAdjPowerSmoothed, pwr, builtin.EpochsInDay)
 			// The storage pledge is recorded for use in computing the penalty if this sector is terminated
 			// before its declared expiration.
 			// It's not capped to 1 FIL, so can exceed the actual initial pledge requirement.
-			storagePledge := ExpectedRewardForPower(thisEpochRewardSmoothed, qualityAdjPowerSmoothed, pwr, InitialPledgeProjectionPeriod)
+			rewards := ExpectedRewardsForPower(thisEpochRewardSmoothed, qualityAdjPowerSmoothed, pwr, builtin.EpochsInDay, InitialPledgeProjectionPeriod)
+			dayReward, storagePledge := rewards[0], rewards[1]
 			initialPledge := InitialPledgeForPower(pwr, thisEpochBaselinePower, thisEpochRewardSmoothed,
 				qualityAdjPowerSmoothed, circulatingSupply)
 
@@ -1202,6 +1229,10 @@ func confirmSectorProofsValid(rt Runtime, preCommits []*SectorPreCommitOnChainIn
 		builtin.RequireNoErr(rt, err, ErrBalanceInvariantBroken, "balance invariants broken")
 	})
 
+	for _, sector := range newSectors {
+		emitSectorEvent(rt, EventSectorActivated, sector.SectorNumber)
+	}
+
 	// Request pledge update for activated sector.
 	notifyPledgeChanged(rt, big.Sub(totalPledge, newlyVested))
 }
@@ -1290,6 +1321,7 @@ func (a Actor) ExtendSectorExpiration(rt Runtime, params *ExtendSectorExpiration
 		info := getMinerInfo(rt, &st)
 
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		deadlines, err := st.LoadDeadlines(adt.AsStore(rt))
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
@@ -1496,6 +1528,7 @@ func (a Actor) TerminateSectors(rt Runtime, params *TerminateSectorsParams) *Ter
 
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		deadlines, err := st.LoadDeadlines(adt.AsStore(rt))
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deadlines")
@@ -1758,6 +1791,7 @@ func (a Actor) CompactPartitions(rt Runtime, params *CompactPartitionsParams) *a
 	rt.StateTransaction(&st, func() {
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		if !deadlineAvailableForCompaction(st.CurrentProvingPeriodStart(rt.CurrEpoch()), params.Deadline, rt.CurrEpoch()) {
 			rt.Abortf(exitcode.ErrForbidden,
@@ -1798,6 +1832,7 @@ func (a Actor) CompactPartitions(rt Runtime, params *CompactPartitionsParams) *a
 
 		err = st.SaveDeadlines(store, deadlines)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deadlines")
+		st.Rebalance.Imbalanced = true
 	})
 	return nil
 }
@@ -1828,6 +1863,7 @@ func (a Actor) CompactSectorNumbers(rt Runtime, params *CompactSectorNumbersPara
 	rt.StateTransaction(&st, func() {
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		err := st.AllocateSectorNumbers(store, params.MaskSectorNumbers, AllowCollisions)
 
@@ -1873,7 +1909,7 @@ func (a Actor) ApplyRewards(rt Runtime, params *builtin.ApplyRewardParams) *abi.
 		pledgeDeltaTotal = big.Add(pledgeDeltaTotal, rewardToLock)
 
 		// If the miner incurred block mining penalties charge these to miner's fee debt
-		err = st.ApplyPenalty(params.Penalty)
+		err = st.ApplyPenalty(store, rt.CurrEpoch(), FeeDebtReasonRewardPenalty, params.Penalty)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 		// Attempt to repay all fee debt in this call. In most cases the miner will have enough
 		// funds in the *reward alone* to cover the penalty. In the rare case a miner incurs more
@@ -1944,7 +1980,7 @@ func (a Actor) ReportConsensusFault(rt Runtime, params *ReportConsensusFaultPara
 			rt.Abortf(exitcode.ErrForbidden, "fault epoch %d is too old, last exclusion period ended at %d", fault.Epoch, info.ConsensusFaultElapsed)
 		}
 
-		err := st.ApplyPenalty(faultPenalty)
+		err := st.ApplyPenalty(adt.AsStore(rt), currEpoch, FeeDebtReasonConsensusFault, faultPenalty)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 
 		// Pay penalty
@@ -2001,6 +2037,7 @@ func (a Actor) WithdrawBalance(rt Runtime, params *WithdrawBalanceParams) *abi.T
 		info = getMinerInfo(rt, &st)
 		// Only the beneficiary and owner are allowed to withdraw the balance
 		rt.ValidateImmediateCallerIs(info.Owner, info.Beneficiary)
+		requireNotPaused(rt, &st)
 		// Ensure we don't have any pending terminations.
 		if count, err := st.EarlyTerminations.Count(); err != nil {
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to count early terminations")
@@ -2065,6 +2102,7 @@ func (a Actor) RepayDebt(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 		var err error
 		info := getMinerInfo(rt, &st)
 		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
 
 		// Repay as much fee debt as possible.
 		fromVesting, fromBalance, err = st.RepayPartialDebtInPriorityOrder(adt.AsStore(rt), rt.CurrEpoch(), rt.CurrentBalance())
@@ -2106,6 +2144,7 @@ func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams
 	info := getMinerInfo(rt, &stReadOnly)
 
 	rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+	requireNotPaused(rt, &stReadOnly)
 
 	sectors, err := LoadSectors(store, stReadOnly.Sectors)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load sectors array")
@@ -2277,6 +2316,7 @@ func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "couldn't load update proof type")
 				builtin.RequirePredicate(rt, updateWithDetails.update.UpdateProofType == updateProofType, exitcode.ErrIllegalArgument, "unsupported update proof type %d", updateWithDetails.update.UpdateProofType)
 
+				endTrace := builtin.TraceGas(rt, runtime.GasOpVerifyProof, "VerifyReplicaUpdate")
 				err = rt.VerifyReplicaUpdate(
 					proof.ReplicaUpdateInfo{
 						UpdateProofType:      updateProofType,
@@ -2285,6 +2325,7 @@ func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams
 						NewUnsealedSectorCID: updateWithDetails.unsealedSectorCID,
 						Proof:                updateWithDetails.update.ReplicaProof,
 					})
+				endTrace()
 
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to verify replica proof for sector %d", updateWithDetails.sectorInfo.SectorNumber)
 
@@ -2307,8 +2348,9 @@ func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams
 				pwr := QAPowerForWeight(info.SectorSize, duration, newSectorInfo.DealWeight, newSectorInfo.VerifiedDealWeight)
 
 				newSectorInfo.ReplacedDayReward = updateWithDetails.sectorInfo.ExpectedDayReward
-				newSectorInfo.ExpectedDayReward = ExpectedRewardForPower(rewRet.ThisEpochRewardSmoothed, powRet.QualityAdjPowerSmoothed, pwr, builtin.EpochsInDay)
-				newSectorInfo.ExpectedStoragePledge = ExpectedRewardForPower(rewRet.ThisEpochRewardSmoothed, powRet.QualityAdjPowerSmoothed, pwr, InitialPledgeProjectionPeriod)
+				rewards := ExpectedRewardsForPower(rewRet.ThisEpochRewardSmoothed, powRet.QualityAdjPowerSmoothed, pwr, builtin.EpochsInDay, InitialPledgeProjectionPeriod)
+				newSectorInfo.ExpectedDayReward = rewards[0]
+				newSectorInfo.ExpectedStoragePledge = rewards[1]
 				newSectorInfo.ReplacedSectorAge = maxEpoch(0, rt.CurrEpoch()-updateWithDetails.sectorInfo.Activation)
 
 				initialPledgeAtUpgrade := InitialPledgeForPower(pwr, rewRet.ThisEpochBaselinePower, rewRet.ThisEpochRewardSmoothed,
@@ -2381,6 +2423,7 @@ func (a Actor) ProveReplicaUpdates(rt Runtime, params *ProveReplicaUpdatesParams
 
 	})
 
+	emitSectorEvents(rt, EventSectorUpdated, succeededSectors)
 	notifyPledgeChanged(rt, pledgeDelta)
 	requestUpdatePower(rt, powerDelta)
 
@@ -2440,6 +2483,8 @@ func (a Actor) ChangeBeneficiary(rt Runtime, params *ChangeBeneficiaryParams) *a
 			builtin.RequireParam(rt, info.PendingBeneficiaryTerm.NewExpiration == params.NewExpiration, "new beneficiary expiredate must be equal expect %s, but got %s", info.PendingBeneficiaryTerm.NewExpiration, params.NewExpiration)
 		}
 
+		requireNotPaused(rt, &st)
+
 		if rt.Caller() == info.Beneficiary {
 			info.PendingBeneficiaryTerm.ApprovedByBeneficiary = true
 		}
@@ -2492,6 +2537,137 @@ func (a Actor) GetBeneficiary(rt Runtime, _ *abi.EmptyValue) *GetBeneficiaryRetu
 	}
 }
 
+type GetFeeDebtStatusReturn struct {
+	FeeDebt          abi.TokenAmount
+	LastAccrual      *FeeDebtAccrual    // Nil if no fee debt has been recorded
+	RecentRepayments []FeeDebtRepayment // Amounts repaid in recent proving periods, oldest first
+}
+
+// GetFeeDebtStatus retrieves the miner's current fee debt together with the most recent
+// accrual and the amounts repaid in recent proving periods.
+// This method is for use by other actors and to abstract the state representation for clients.
+func (a Actor) GetFeeDebtStatus(rt Runtime, _ *abi.EmptyValue) *GetFeeDebtStatusReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+	log, err := st.LoadFeeDebtLog(adt.AsStore(rt))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load fee debt log")
+	return &GetFeeDebtStatusReturn{
+		FeeDebt:          st.FeeDebt,
+		LastAccrual:      log.LastAccrual(),
+		RecentRepayments: log.Repayments,
+	}
+}
+
+type DeclareUnsealingWindowParams struct {
+	Sectors bitfield.BitField
+	Start   abi.ChainEpoch
+	End     abi.ChainEpoch
+}
+
+// Declares a window during which the miner will keep some of its sectors unsealed for fast retrieval.
+// The declaration is recorded in state, where clients may query it, and may serve as evidence in disputes
+// about retrieval agreements, but is not enforced by the actor.
+// Each declaration burns UnsealingWindowFee, and at most UnsealingWindowsMax windows may be declared and not yet ended.
+func (a Actor) DeclareUnsealingWindow(rt Runtime, params *DeclareUnsealingWindowParams) *abi.EmptyValue {
+	currEpoch := rt.CurrEpoch()
+	if params.Start < currEpoch {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unsealing window start %d before current epoch %d", params.Start, currEpoch)
+	}
+	if params.End <= params.Start {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unsealing window end %d not after start %d", params.End, params.Start)
+	}
+	if params.End-params.Start > UnsealingWindowMaxDuration {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unsealing window duration %d exceeds max %d", params.End-params.Start, UnsealingWindowMaxDuration)
+	}
+	sectorCount, err := params.Sectors.Count()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to count sectors")
+	if sectorCount == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "no sectors in unsealing window")
+	}
+	if sectorCount > AddressedSectorsMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "too many sectors in unsealing window %d, max %d", sectorCount, AddressedSectorsMax)
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+		requireNotPaused(rt, &st)
+
+		sectors, err := st.LoadSectorInfos(store, params.Sectors)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to load sectors")
+		for _, sector := range sectors {
+			if sector.Expiration < params.End {
+				rt.Abortf(exitcode.ErrIllegalArgument, "sector %d expires at %d before unsealing window end %d",
+					sector.SectorNumber, sector.Expiration, params.End)
+			}
+		}
+
+		windows, err := st.LoadUnsealingWindows(store)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load unsealing windows")
+		windows.pruneEnded(currEpoch)
+		if len(windows.Windows) >= UnsealingWindowsMax {
+			rt.Abortf(exitcode.ErrForbidden, "too many unsealing windows %d, max %d", len(windows.Windows), UnsealingWindowsMax)
+		}
+		windows.Windows = append(windows.Windows, UnsealingWindow{
+			Sectors: params.Sectors,
+			Start:   params.Start,
+			End:     params.End,
+		})
+		err = st.SaveUnsealingWindows(store, windows)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save unsealing windows")
+
+		unlockedBalance, err := st.GetUnlockedBalance(rt.CurrentBalance())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to determine unlocked balance")
+		if unlockedBalance.LessThan(UnsealingWindowFee) {
+			rt.Abortf(exitcode.ErrInsufficientFunds, "unlocked balance %v insufficient to pay unsealing window fee %v",
+				unlockedBalance, UnsealingWindowFee)
+		}
+	})
+
+	burnFunds(rt, UnsealingWindowFee, BurnMethodDeclareUnsealingWindow)
+	rt.StateReadonly(&st)
+	err = st.CheckBalanceInvariants(rt.CurrentBalance())
+	builtin.RequireNoErr(rt, err, ErrBalanceInvariantBroken, "balance invariants broken")
+	return nil
+}
+
+type GetUnsealingWindowsReturn struct {
+	Windows []UnsealingWindow // Windows which have not yet ended, in order of declaration
+}
+
+// GetUnsealingWindows retrieves the miner's declared unsealing windows which have not yet ended.
+// This method is for use by other actors and to abstract the state representation for clients.
+func (a Actor) GetUnsealingWindows(rt Runtime, _ *abi.EmptyValue) *GetUnsealingWindowsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+	windows, err := st.LoadUnsealingWindows(adt.AsStore(rt))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load unsealing windows")
+	return &GetUnsealingWindowsReturn{
+		Windows: windows.Active(rt.CurrEpoch()),
+	}
+}
+
+// Proposes or approves pausing the miner.
+// The miner is paused once both the owner and PauseGovernanceAddr have approved, after which methods
+// invoked by its control addresses abort with ErrMinerPaused. Window PoSt submission, fault declarations and
+// changes to the owner, worker and control addresses remain available, so that a miner whose keys are compromised
+// may be paused while they are replaced. Methods invoked by the system and other actors are also unaffected.
+func (a Actor) PauseMiner(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	changePaused(rt, true)
+	return nil
+}
+
+// Proposes or approves unpausing the miner.
+// The miner is unpaused once both the owner and PauseGovernanceAddr have approved.
+func (a Actor) UnpauseMiner(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	changePaused(rt, false)
+	return nil
+}
+
 //////////
 // Cron //
 //////////
@@ -2591,7 +2767,7 @@ func processEarlyTerminations(rt Runtime, rewardSmoothed smoothing.FilterEstimat
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to process terminations")
 
 		// Pay penalty
-		err = st.ApplyPenalty(penalty)
+		err = st.ApplyPenalty(store, rt.CurrEpoch(), FeeDebtReasonEarlyTermination, penalty)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 
 		// Remove pledge requirement.
@@ -2612,6 +2788,12 @@ func processEarlyTerminations(rt Runtime, rewardSmoothed smoothing.FilterEstimat
 		return more
 	}
 
+	err := result.ForEach(func(_ abi.ChainEpoch, sectorNos bitfield.BitField) error {
+		emitSectorEvents(rt, EventSectorTerminated, sectorNos)
+		return nil
+	})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to emit termination events")
+
 	// Burn penalty.
 	rt.Log(rtt.DEBUG, "storage provider %s penalized %s for sector termination", rt.Receiver(), penalty)
 	burnFunds(rt, penalty, BurnMethodProcessEarlyTerminations)
@@ -2663,7 +2845,7 @@ func handleProvingDeadline(rt Runtime,
 			depositToBurn, err := st.CleanUpExpiredPreCommits(store, currEpoch)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to expire pre-committed sectors")
 
-			err = st.ApplyPenalty(depositToBurn)
+			err = st.ApplyPenalty(store, currEpoch, FeeDebtReasonExpiredPreCommit, depositToBurn)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 			rt.Log(rtt.DEBUG, "storage provider %s penalized %s for expired pre commits", rt.Receiver(), depositToBurn)
 		}
@@ -2687,7 +2869,7 @@ func handleProvingDeadline(rt Runtime,
 			powerDeltaTotal = powerDeltaTotal.Add(result.PowerDelta)
 			pledgeDeltaTotal = big.Add(pledgeDeltaTotal, result.PledgeDelta)
 
-			err = st.ApplyPenalty(penaltyTarget)
+			err = st.ApplyPenalty(store, currEpoch, FeeDebtReasonContinuedFault, penaltyTarget)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to apply penalty")
 			rt.Log(rtt.DEBUG, "storage provider %s penalized %s for continued fault", rt.Receiver(), penaltyTarget)
 
@@ -2697,6 +2879,16 @@ func handleProvingDeadline(rt Runtime,
 			pledgeDeltaTotal = big.Sub(pledgeDeltaTotal, penaltyFromVesting)
 		}
 
+		{
+			// Even out Window PoSt load by gradually moving partitions between deadlines.
+			info := getMinerInfo(rt, &st)
+			moved, err := st.RebalancePartitions(store, info.WindowPoStPartitionSectors, info.SectorSize, currEpoch)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to rebalance partitions")
+			if moved > 0 {
+				rt.Log(rtt.DEBUG, "storage provider %s moved %d partitions between deadlines", rt.Receiver(), moved)
+			}
+		}
+
 		continueCron = st.ContinueDeadlineCron()
 		if !continueCron {
 			st.DeadlineCronActive = false
@@ -2835,11 +3027,8 @@ func verifyWindowedPost(rt Runtime, challengeEpoch abi.ChainEpoch, sectors []*Se
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "runtime provided bad receiver address %v", rt.Receiver())
 
 	// Regenerate challenge randomness, which must match that generated for the proof.
-	var addrBuf bytes.Buffer
-	receiver := rt.Receiver()
-	err = receiver.MarshalCBOR(&addrBuf)
-	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to marshal address for window post challenge")
-	postRandomness := rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_WindowedPoStChallengeSeed, challengeEpoch, addrBuf.Bytes())
+	entropy := builtin.AddressEntropy(rt, rt.Receiver())
+	postRandomness := rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_WindowedPoStChallengeSeed, challengeEpoch, entropy)
 
 	sectorProofInfo := make([]proof.SectorInfo, len(sectors))
 	for i, s := range sectors {
@@ -2859,7 +3048,9 @@ func verifyWindowedPost(rt Runtime, challengeEpoch abi.ChainEpoch, sectors []*Se
 	}
 
 	// Verify the PoSt ReplicaProof
+	endTrace := builtin.TraceGas(rt, runtime.GasOpVerifyProof, "VerifyPoSt")
 	err = rt.VerifyPoSt(pvInfo)
+	endTrace()
 	if err != nil {
 		return fmt.Errorf("invalid PoSt %+v: %w", pvInfo, err)
 	}
@@ -2893,13 +3084,9 @@ func getVerifyInfo(rt Runtime, params *SealVerifyStuff) *proof.SealVerifyInfo {
 	minerActorID, err := addr.IDFromAddress(rt.Receiver())
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "runtime provided non-ID receiver address %v", rt.Receiver())
 
-	buf := new(bytes.Buffer)
-	receiver := rt.Receiver()
-	err = receiver.MarshalCBOR(buf)
-	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to marshal address for seal verification challenge")
-
-	svInfoRandomness := rt.GetRandomnessFromTickets(crypto.DomainSeparationTag_SealRandomness, params.SealRandEpoch, buf.Bytes())
-	svInfoInteractiveRandomness := rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, params.InteractiveEpoch, buf.Bytes())
+	entropy := builtin.AddressEntropy(rt, rt.Receiver())
+	svInfoRandomness := rt.GetRandomnessFromTickets(crypto.DomainSeparationTag_SealRandomness, params.SealRandEpoch, entropy)
+	svInfoInteractiveRandomness := rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, params.InteractiveEpoch, entropy)
 
 	return &proof.SealVerifyInfo{
 		SealProof: params.RegisteredSealProof,
@@ -2921,17 +3108,15 @@ func requestUnsealedSectorCIDs(rt Runtime, dataCommitmentInputs ...*market.Secto
 	if len(dataCommitmentInputs) == 0 {
 		return nil
 	}
-	var ret market.ComputeDataCommitmentReturn
-	code := rt.Send(
+	ret := builtin.RequireSendAndDecode[market.ComputeDataCommitmentReturn](rt,
 		builtin.StorageMarketActorAddr,
 		builtin.MethodsMarket.ComputeDataCommitment,
 		&market.ComputeDataCommitmentParams{
 			Inputs: dataCommitmentInputs,
 		},
 		abi.NewTokenAmount(0),
-		&ret,
+		"failed request for unsealed sector CIDs",
 	)
-	builtin.RequireSuccess(rt, code, "failed request for unsealed sector CIDs")
 	builtin.RequireState(rt, len(dataCommitmentInputs) == len(ret.CommDs), "number of data commitments computed %d does not match number of data commitment inputs %d", len(ret.CommDs), len(dataCommitmentInputs))
 	unsealedCIDs := make([]cid.Cid, len(ret.CommDs))
 	for i, cbgCid := range ret.CommDs {
@@ -2960,34 +3145,29 @@ func requestDealWeights(rt Runtime, sectors []market.SectorDeals) *market.Verify
 		return emptyResult
 	}
 
-	var dealWeights market.VerifyDealsForActivationReturn
-	code := rt.Send(
+	dealWeights := builtin.RequireSendAndDecode[market.VerifyDealsForActivationReturn](rt,
 		builtin.StorageMarketActorAddr,
 		builtin.MethodsMarket.VerifyDealsForActivation,
 		&market.VerifyDealsForActivationParams{
 			Sectors: sectors,
 		},
 		abi.NewTokenAmount(0),
-		&dealWeights,
+		"failed to verify deals and get deal weight",
 	)
-	builtin.RequireSuccess(rt, code, "failed to verify deals and get deal weight")
 	return &dealWeights
 }
 
 // Requests the current epoch target block reward from the reward actor.
 // return value includes reward, smoothed estimate of reward, and baseline power
 func requestCurrentEpochBlockReward(rt Runtime) reward.ThisEpochRewardReturn {
-	var ret reward.ThisEpochRewardReturn
-	code := rt.Send(builtin.RewardActorAddr, builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), &ret)
-	builtin.RequireSuccess(rt, code, "failed to check epoch baseline power")
-	return ret
+	return builtin.RequireSendAndDecode[reward.ThisEpochRewardReturn](rt, builtin.RewardActorAddr,
+		builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), "failed to check epoch baseline power")
 }
 
 // Requests the current network total power and pledge from the power actor.
 func requestCurrentTotalPower(rt Runtime) *power.CurrentTotalPowerReturn {
-	var pwr power.CurrentTotalPowerReturn
-	code := rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), &pwr)
-	builtin.RequireSuccess(rt, code, "failed to check current power")
+	pwr := builtin.RequireSendAndDecode[power.CurrentTotalPowerReturn](rt, builtin.StoragePowerActorAddr,
+		builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), "failed to check current power")
 	return &pwr
 }
 
@@ -3033,7 +3213,20 @@ func resolveWorkerAddress(rt Runtime, raw addr.Address) addr.Address {
 	return resolved
 }
 
+// Burns funds, except that the PenaltyRecycleFraction of a penalty is returned to the reward actor.
 func burnFunds(rt Runtime, amt abi.TokenAmount, bt BurnMethod) {
+	if amt.LessThanEqual(big.Zero()) {
+		return
+	}
+	if bt.IsPenalty() {
+		toRecycle := big.Div(big.Mul(amt, PenaltyRecycleFraction.Numerator), PenaltyRecycleFraction.Denominator)
+		if toRecycle.GreaterThan(big.Zero()) {
+			rt.Log(rtt.DEBUG, "storage provder %s burn type %s recycling %s", rt.Receiver(), bt, toRecycle)
+			code := rt.Send(builtin.RewardActorAddr, builtin.MethodsReward.RecyclePenalty, nil, toRecycle, &builtin.Discard{})
+			builtin.RequireSuccess(rt, code, "failed to recycle penalty")
+			amt = big.Sub(amt, toRecycle)
+		}
+	}
 	if amt.GreaterThan(big.Zero()) {
 		rt.Log(rtt.DEBUG, "storage provder %s burn type %s burning %s", rt.Receiver(), bt, amt)
 		code := rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, amt, &builtin.Discard{})
@@ -3179,6 +3372,42 @@ func ConsensusFaultActive(info *MinerInfo, currEpoch abi.ChainEpoch) bool {
 	return currEpoch <= info.ConsensusFaultElapsed
 }
 
+// Records the caller's approval of a change to whether the miner is paused, applying the change
+// once both the owner and PauseGovernanceAddr have approved it.
+func changePaused(rt Runtime, paused bool) {
+	var st State
+	rt.StateTransaction(&st, func() {
+		info := getMinerInfo(rt, &st)
+		rt.ValidateImmediateCallerIs(info.Owner, PauseGovernanceAddr)
+
+		if st.Paused == paused {
+			rt.Abortf(exitcode.ErrIllegalArgument, "miner paused state is already %t", paused)
+		}
+		if st.PendingPauseChange == nil {
+			st.PendingPauseChange = &PendingPauseChange{Paused: paused}
+		}
+		if rt.Caller() == info.Owner {
+			st.PendingPauseChange.ApprovedByOwner = true
+		}
+		if rt.Caller() == PauseGovernanceAddr {
+			st.PendingPauseChange.ApprovedByGovernance = true
+		}
+
+		if st.PendingPauseChange.ApprovedByOwner && st.PendingPauseChange.ApprovedByGovernance {
+			st.Paused = paused
+			st.PendingPauseChange = nil
+		}
+	})
+}
+
+// Aborts with ErrMinerPaused if the miner is paused.
+// Called after validating the caller, so that callers with no authority over the miner are rejected as such.
+func requireNotPaused(rt Runtime, st *State) {
+	if st.Paused {
+		rt.Abortf(ErrMinerPaused, "miner is paused")
+	}
+}
+
 func getMinerInfo(rt Runtime, st *State) *MinerInfo {
 	info, err := st.GetInfo(adt.AsStore(rt))
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "could not read miner info")
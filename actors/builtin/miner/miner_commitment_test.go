@@ -802,6 +802,7 @@ func TestProveCommit(t *testing.T) {
 		}
 
 		precommits := actor.preCommitSectorBatch(rt, &miner.PreCommitSectorBatchParams{Sectors: sectors}, conf, big.Zero())
+		assert.Equal(t, []abi.SectorNumber{100, 101, 102}, sectorEvents(t, rt, miner.EventSectorPrecommitted))
 
 		rt.SetEpoch(proveCommitEpoch)
 		noDealPower := miner.QAPowerForWeight(actor.sectorSize, sectorExpiration-proveCommitEpoch, big.Zero(), big.Zero())
@@ -848,6 +849,7 @@ func TestProveCommit(t *testing.T) {
 			assert.Equal(t, fullDealPledge, sector.InitialPledge)
 			assert.Equal(t, big.Sum(noDealPledge, fullDealPledge, fullDealPledge), st.InitialPledge)
 		}
+		assert.Equal(t, []abi.SectorNumber{100, 101, 102}, sectorEvents(t, rt, miner.EventSectorActivated))
 	})
 
 	t.Run("invalid proof rejected", func(t *testing.T) {
@@ -2,7 +2,6 @@ package miner
 
 import (
 	"fmt"
-	"reflect"
 	"sort"
 
 	addr "github.com/filecoin-project/go-address"
@@ -79,11 +78,48 @@ type State struct {
 
 	// True when miner cron is active, false otherwise
 	DeadlineCronActive bool
+
+	// Recent fee debt accruals and repayments.
+	FeeDebtLog cid.Cid // FeeDebtLog
+
+	// Progress of background rebalancing of partitions between deadlines.
+	Rebalance RebalanceCursor
+
+	// Whether the miner is paused, during which methods invoked by its control addresses abort.
+	// Changes must be approved by both the owner and PauseGovernanceAddr.
+	Paused bool
+
+	// A proposed change to Paused, awaiting approval by both parties.
+	PendingPauseChange *PendingPauseChange // Nil if no change is pending
+
+	// Declared windows during which sectors are kept unsealed for retrieval.
+	UnsealingWindows cid.Cid // UnsealingWindows
+}
+
+// A proposal to pause or unpause a miner, and the parties that have approved it.
+type PendingPauseChange struct {
+	Paused               bool
+	ApprovedByOwner      bool
+	ApprovedByGovernance bool
+}
+
+// Tracks background movement of partitions from over-full deadlines to under-full ones.
+type RebalanceCursor struct {
+	// Start of the earliest proving period in which deadlines may next be scanned for rebalancing.
+	NextPeriodStart abi.ChainEpoch
+	// Index of the deadline at which the next scan begins looking for a partition to move.
+	Deadline uint64
+	// Whether partitions may be unevenly spread between deadlines. Set when partitions are added or
+	// removed, and cleared by a scan which finds the deadlines balanced. Deadlines are only scanned when set.
+	Imbalanced bool
 }
 
 // Bitwidth of AMTs determined empirically from mutation patterns and projections of mainnet data.
 const PrecommitCleanUpAmtBitwidth = 6
-const SectorsAmtBitwidth = 5
+
+// Bitwidth of the sectors AMT and the deadline sector snapshots.
+// Configurable by policy (see AmtBitwidths); state written with a different value must be re-encoded.
+var SectorsAmtBitwidth = 5
 
 type BeneficiaryTerm struct {
 	// Quota: The total amount the current beneficiary can withdraw. Monotonic, but reset when beneficiary changes.
@@ -218,6 +254,9 @@ type SectorOnChainInfo struct {
 }
 
 func ConstructState(store adt.Store, infoCid cid.Cid, periodStart abi.ChainEpoch, deadlineIndex uint64) (*State, error) {
+	if err := CurrentAmtBitwidths().Check(); err != nil {
+		return nil, err
+	}
 	emptyPrecommitMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to construct empty map: %w", err)
@@ -253,6 +292,14 @@ func ConstructState(store adt.Store, infoCid cid.Cid, periodStart abi.ChainEpoch
 	if err != nil {
 		return nil, xerrors.Errorf("failed to construct empty vesting funds: %w", err)
 	}
+	emptyFeeDebtLogCid, err := store.Put(store.Context(), ConstructFeeDebtLog())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty fee debt log: %w", err)
+	}
+	emptyUnsealingWindowsCid, err := store.Put(store.Context(), ConstructUnsealingWindows())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty unsealing windows: %w", err)
+	}
 
 	return &State{
 		Info: infoCid,
@@ -274,6 +321,8 @@ func ConstructState(store adt.Store, infoCid cid.Cid, periodStart abi.ChainEpoch
 		Deadlines:                  emptyDeadlinesCid,
 		EarlyTerminations:          bitfield.New(),
 		DeadlineCronActive:         false,
+		FeeDebtLog:                 emptyFeeDebtLogCid,
+		UnsealingWindows:           emptyUnsealingWindowsCid,
 	}, nil
 }
 
@@ -630,6 +679,7 @@ func (st *State) AssignSectorsToDeadlines(
 	if err := st.SaveDeadlines(store, deadlines); err != nil {
 		return err
 	}
+	st.Rebalance.Imbalanced = true
 	return nil
 }
 
@@ -752,6 +802,22 @@ func (st *State) CheckSectorActive(store adt.Store, dlIdx, pIdx uint64, sector a
 	return true, nil
 }
 
+// Returns the counts of sectors that have left each deadline, by the cause of their leaving, indexed by deadline.
+func (st *State) DeadlineSectorExits(store adt.Store) ([]SectorExitCounts, error) {
+	deadlines, err := st.LoadDeadlines(store)
+	if err != nil {
+		return nil, err
+	}
+	exits := make([]SectorExitCounts, WPoStPeriodDeadlines)
+	if err = deadlines.ForEach(store, func(dlIdx uint64, dl *Deadline) error {
+		exits[dlIdx] = dl.SectorExits
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return exits, nil
+}
+
 // Loads sector info for a sequence of sectors.
 func (st *State) LoadSectorInfos(store adt.Store, sectors bitfield.BitField) ([]*SectorOnChainInfo, error) {
 	sectorsArr, err := LoadSectors(store, st.Sectors)
@@ -799,6 +865,46 @@ func (st *State) SaveVestingFunds(store adt.Store, funds *VestingFunds) error {
 	return nil
 }
 
+// LoadFeeDebtLog loads the fee debt log from the store
+func (st *State) LoadFeeDebtLog(store adt.Store) (*FeeDebtLog, error) {
+	var log FeeDebtLog
+	if err := store.Get(store.Context(), st.FeeDebtLog, &log); err != nil {
+		return nil, xerrors.Errorf("failed to load fee debt log (%s): %w", st.FeeDebtLog, err)
+	}
+
+	return &log, nil
+}
+
+// SaveFeeDebtLog saves the fee debt log to the store
+func (st *State) SaveFeeDebtLog(store adt.Store, log *FeeDebtLog) error {
+	c, err := store.Put(store.Context(), log)
+	if err != nil {
+		return err
+	}
+	st.FeeDebtLog = c
+	return nil
+}
+
+// LoadUnsealingWindows loads the declared unsealing windows from the store
+func (st *State) LoadUnsealingWindows(store adt.Store) (*UnsealingWindows, error) {
+	var windows UnsealingWindows
+	if err := store.Get(store.Context(), st.UnsealingWindows, &windows); err != nil {
+		return nil, xerrors.Errorf("failed to load unsealing windows (%s): %w", st.UnsealingWindows, err)
+	}
+
+	return &windows, nil
+}
+
+// SaveUnsealingWindows saves the declared unsealing windows to the store
+func (st *State) SaveUnsealingWindows(store adt.Store, windows *UnsealingWindows) error {
+	c, err := store.Put(store.Context(), windows)
+	if err != nil {
+		return err
+	}
+	st.UnsealingWindows = c
+	return nil
+}
+
 // Return true when the miner actor needs to continue scheduling deadline crons
 func (st *State) ContinueDeadlineCron() bool {
 	return !st.PreCommitDeposits.IsZero() ||
@@ -858,12 +964,40 @@ func (st *State) AddLockedFunds(store adt.Store, currEpoch abi.ChainEpoch, vesti
 	return amountUnlocked, nil
 }
 
-// ApplyPenalty adds the provided penalty to fee debt.
-func (st *State) ApplyPenalty(penalty abi.TokenAmount) error {
+// ApplyPenalty adds the provided penalty to fee debt, recording the accrual in the fee debt log.
+func (st *State) ApplyPenalty(store adt.Store, currEpoch abi.ChainEpoch, reason FeeDebtReason, penalty abi.TokenAmount) error {
 	if penalty.LessThan(big.Zero()) {
 		return xerrors.Errorf("applying negative penalty %v not allowed", penalty)
 	}
 	st.FeeDebt = big.Add(st.FeeDebt, penalty)
+	if penalty.IsZero() {
+		return nil
+	}
+
+	log, err := st.LoadFeeDebtLog(store)
+	if err != nil {
+		return err
+	}
+	log.addAccrual(currEpoch, reason, penalty)
+	if err := st.SaveFeeDebtLog(store, log); err != nil {
+		return xerrors.Errorf("failed to save fee debt log: %w", err)
+	}
+	return nil
+}
+
+// Records an amount of fee debt repaid at the current epoch in the fee debt log.
+func (st *State) recordFeeDebtRepayment(store adt.Store, currEpoch abi.ChainEpoch, amount abi.TokenAmount) error {
+	if amount.IsZero() {
+		return nil
+	}
+	log, err := st.LoadFeeDebtLog(store)
+	if err != nil {
+		return err
+	}
+	log.addRepayment(st.CurrentProvingPeriodStart(currEpoch), amount)
+	if err := st.SaveFeeDebtLog(store, log); err != nil {
+		return xerrors.Errorf("failed to save fee debt log: %w", err)
+	}
 	return nil
 }
 
@@ -892,6 +1026,9 @@ func (st *State) RepayPartialDebtInPriorityOrder(store adt.Store, currEpoch abi.
 	fromBalance = big.Min(unlockedBalance, st.FeeDebt)
 	st.FeeDebt = big.Sub(st.FeeDebt, fromBalance)
 
+	if err := st.recordFeeDebtRepayment(store, currEpoch, big.Add(fromVesting, fromBalance)); err != nil {
+		return big.Zero(), big.Zero(), err
+	}
 	return fromVesting, fromBalance, nil
 
 }
@@ -900,7 +1037,7 @@ func (st *State) RepayPartialDebtInPriorityOrder(store adt.Store, currEpoch abi.
 // burnt and an error if there are not sufficient funds to cover repayment.
 // Miner state repays from unlocked funds and fails if unlocked funds are insufficient to cover fee debt.
 // FeeDebt will be zero after a successful call.
-func (st *State) repayDebts(currBalance abi.TokenAmount) (abi.TokenAmount, error) {
+func (st *State) repayDebts(store adt.Store, currEpoch abi.ChainEpoch, currBalance abi.TokenAmount) (abi.TokenAmount, error) {
 	unlockedBalance, err := st.GetUnlockedBalance(currBalance)
 	if err != nil {
 		return big.Zero(), err
@@ -910,6 +1047,9 @@ func (st *State) repayDebts(currBalance abi.TokenAmount) (abi.TokenAmount, error
 	}
 	debtToRepay := st.FeeDebt
 	st.FeeDebt = big.Zero()
+	if err := st.recordFeeDebtRepayment(store, currEpoch, debtToRepay); err != nil {
+		return big.Zero(), err
+	}
 	return debtToRepay, nil
 }
 
@@ -1276,18 +1416,150 @@ func (st *State) AdvanceDeadline(store adt.Store, currEpoch abi.ChainEpoch) (*Ad
 	}, nil
 }
 
+// RebalancePartitions moves partitions from over-full deadlines to under-full ones.
+// Deadlines are scanned only while the rebalance cursor is marked imbalanced, and at most once per proving period,
+// moving at most PartitionRebalanceMovesMax partitions. The mark is cleared once no deadline holds
+// PartitionRebalanceImbalanceMin partitions more than another.
+// Only the last partition of a deadline that is available for compaction is moved, and only if it has
+// no faulty or unproven sectors. It is moved to the mutable deadline with the fewest partitions among those
+// whose challenge window next opens before the source's, so the moved sectors are proven again no later
+// than they would otherwise have been.
+// Dead sectors in a moved partition are removed from state, as for compaction.
+// Returns the number of partitions moved.
+func (st *State) RebalancePartitions(store adt.Store, partitionSize uint64, ssize abi.SectorSize, currEpoch abi.ChainEpoch) (uint64, error) {
+	periodStart := st.CurrentProvingPeriodStart(currEpoch)
+	if !st.Rebalance.Imbalanced || periodStart < st.Rebalance.NextPeriodStart {
+		return 0, nil
+	}
+	st.Rebalance.NextPeriodStart = periodStart + WPoStProvingPeriod
+
+	deadlines, err := st.LoadDeadlines(store)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load deadlines: %w", err)
+	}
+	partitionCounts := make([]uint64, WPoStPeriodDeadlines)
+	if err = deadlines.ForEach(store, func(dlIdx uint64, dl *Deadline) error {
+		partitions, err := dl.PartitionsArray(store)
+		if err != nil {
+			return err
+		}
+		partitionCounts[dlIdx] = partitions.Length()
+		return nil
+	}); err != nil {
+		return 0, xerrors.Errorf("failed to count partitions: %w", err)
+	}
+
+	moved := uint64(0)
+	for i := uint64(0); i < WPoStPeriodDeadlines && moved < PartitionRebalanceMovesMax; i++ {
+		srcIdx := (st.Rebalance.Deadline + i) % WPoStPeriodDeadlines
+		if !deadlineAvailableForCompaction(periodStart, srcIdx, currEpoch) {
+			continue
+		}
+		destIdx, found := rebalanceDestination(periodStart, srcIdx, currEpoch, partitionCounts)
+		if !found || partitionCounts[srcIdx] < partitionCounts[destIdx]+PartitionRebalanceImbalanceMin {
+			continue
+		}
+
+		src, err := deadlines.LoadDeadline(store, srcIdx)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to load deadline %d: %w", srcIdx, err)
+		}
+		partIdx, movable, err := src.lastPartitionMovable(store)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to check last partition of deadline %d: %w", srcIdx, err)
+		}
+		if !movable {
+			continue
+		}
+		dest, err := deadlines.LoadDeadline(store, destIdx)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to load deadline %d: %w", destIdx, err)
+		}
+
+		live, dead, removedPower, err := src.RemovePartitions(store, bitfield.NewFromSet([]uint64{partIdx}), st.QuantSpecForDeadline(srcIdx))
+		if err != nil {
+			return 0, xerrors.Errorf("failed to remove partition %d from deadline %d: %w", partIdx, srcIdx, err)
+		}
+		if err = st.DeleteSectors(store, dead); err != nil {
+			return 0, xerrors.Errorf("failed to delete dead sectors: %w", err)
+		}
+		sectors, err := st.LoadSectorInfos(store, live)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to load moved sectors: %w", err)
+		}
+		proven := true
+		addedPower, err := dest.AddSectors(store, partitionSize, proven, sectors, ssize, st.QuantSpecForDeadline(destIdx))
+		if err != nil {
+			return 0, xerrors.Errorf("failed to add moved sectors to deadline %d: %w", destIdx, err)
+		}
+		if !removedPower.Equals(addedPower) {
+			return 0, xerrors.Errorf("power changed when moving partition from deadline %d to %d: was %v, is now %v",
+				srcIdx, destIdx, removedPower, addedPower)
+		}
+
+		if err = deadlines.UpdateDeadline(store, srcIdx, src); err != nil {
+			return 0, xerrors.Errorf("failed to update deadline %d: %w", srcIdx, err)
+		}
+		if err = deadlines.UpdateDeadline(store, destIdx, dest); err != nil {
+			return 0, xerrors.Errorf("failed to update deadline %d: %w", destIdx, err)
+		}
+		destPartitions, err := dest.PartitionsArray(store)
+		if err != nil {
+			return 0, err
+		}
+		partitionCounts[srcIdx]--
+		partitionCounts[destIdx] = destPartitions.Length()
+
+		st.Rebalance.Deadline = (srcIdx + 1) % WPoStPeriodDeadlines
+		moved++
+	}
+
+	if moved > 0 {
+		if err = st.SaveDeadlines(store, deadlines); err != nil {
+			return 0, xerrors.Errorf("failed to save deadlines: %w", err)
+		}
+	}
+	st.Rebalance.Imbalanced = partitionsImbalanced(partitionCounts)
+	return moved, nil
+}
+
+// Checks whether any deadline holds at least PartitionRebalanceImbalanceMin partitions more than another.
+func partitionsImbalanced(partitionCounts []uint64) bool {
+	least, most := partitionCounts[0], partitionCounts[0]
+	for _, count := range partitionCounts[1:] {
+		if count < least {
+			least = count
+		}
+		if count > most {
+			most = count
+		}
+	}
+	return most >= least+PartitionRebalanceImbalanceMin
+}
+
+// Selects the mutable deadline with the fewest partitions from among those whose challenge window
+// next opens before that of the source deadline.
+func rebalanceDestination(periodStart abi.ChainEpoch, srcIdx uint64, currEpoch abi.ChainEpoch, partitionCounts []uint64) (uint64, bool) {
+	srcOpen := NewDeadlineInfo(periodStart, srcIdx, currEpoch).NextNotElapsed().Open
+	destIdx, found := uint64(0), false
+	for dlIdx := uint64(0); dlIdx < WPoStPeriodDeadlines; dlIdx++ {
+		if !deadlineIsMutable(periodStart, dlIdx, currEpoch) {
+			continue
+		}
+		if NewDeadlineInfo(periodStart, dlIdx, currEpoch).NextNotElapsed().Open >= srcOpen {
+			continue
+		}
+		if !found || partitionCounts[dlIdx] < partitionCounts[destIdx] {
+			destIdx, found = dlIdx, true
+		}
+	}
+	return destIdx, found
+}
+
 //
 // Misc helpers
 //
 
 func SectorKey(e abi.SectorNumber) abi.Keyer {
-	return abi.UIntKey(uint64(e))
-}
-
-func init() {
-	// Check that ChainEpoch is indeed an unsigned integer to confirm that SectorKey is making the right interpretation.
-	var e abi.SectorNumber
-	if reflect.TypeOf(e).Kind() != reflect.Uint64 {
-		panic("incorrect sector number encoding")
-	}
+	return builtin.SectorNumberKey(e)
 }
@@ -745,6 +745,122 @@ func TestSectorAssignment(t *testing.T) {
 	})
 }
 
+func TestRebalancePartitions(t *testing.T) {
+	sectorSize, err := abi.RegisteredSealProof_StackedDrg32GiBV1_1.SectorSize()
+	require.NoError(t, err)
+	partitionSize := uint64(2)
+	srcIdx := uint64(10)
+	// The last epoch of deadline 0 in the first proving period.
+	currEpoch := miner.WPoStChallengeWindow - 1
+
+	setupHarness := func(t *testing.T, sectorCount uint64) *stateHarness {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		sectors := make([]*miner.SectorOnChainInfo, sectorCount)
+		for i := range sectors {
+			sectors[i] = newSectorOnChainInfo(abi.SectorNumber(i), tutils.MakeCID(fmt.Sprintf("%d", i), &miner.SealedCIDPrefix), big.NewInt(1), abi.ChainEpoch(0))
+			sectors[i].Expiration = 100 * miner.WPoStProvingPeriod
+			harness.putSector(sectors[i])
+		}
+
+		dls, err := harness.s.LoadDeadlines(harness.store)
+		require.NoError(t, err)
+		dl, err := dls.LoadDeadline(harness.store, srcIdx)
+		require.NoError(t, err)
+		_, err = dl.AddSectors(harness.store, partitionSize, true, sectors, sectorSize, harness.s.QuantSpecForDeadline(srcIdx))
+		require.NoError(t, err)
+		require.NoError(t, dls.UpdateDeadline(harness.store, srcIdx, dl))
+		require.NoError(t, harness.s.SaveDeadlines(harness.store, dls))
+		// Marked as it would be by assigning the sectors to deadlines.
+		harness.s.Rebalance.Imbalanced = true
+		return harness
+	}
+
+	partitionCount := func(t *testing.T, harness *stateHarness, dlIdx uint64) uint64 {
+		dls, err := harness.s.LoadDeadlines(harness.store)
+		require.NoError(t, err)
+		dl, err := dls.LoadDeadline(harness.store, dlIdx)
+		require.NoError(t, err)
+		partitions, err := dl.PartitionsArray(harness.store)
+		require.NoError(t, err)
+		return partitions.Length()
+	}
+
+	t.Run("moves last partition to an earlier under-full deadline", func(t *testing.T) {
+		harness := setupHarness(t, 6)
+
+		moved, err := harness.s.RebalancePartitions(harness.store, partitionSize, sectorSize, currEpoch)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), moved)
+		// Deadline 10 still holds two partitions more than most others.
+		assert.Equal(t, miner.RebalanceCursor{NextPeriodStart: miner.WPoStProvingPeriod, Deadline: srcIdx + 1, Imbalanced: true}, harness.s.Rebalance)
+
+		// Deadlines 0 and 1 are immutable, so the partition moves to deadline 2.
+		assert.Equal(t, uint64(2), partitionCount(t, harness, srcIdx))
+		assert.Equal(t, uint64(1), partitionCount(t, harness, 2))
+
+		dls, err := harness.s.LoadDeadlines(harness.store)
+		require.NoError(t, err)
+		dl, err := dls.LoadDeadline(harness.store, 2)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), dl.LiveSectors)
+		partition, err := dl.LoadPartition(harness.store, 0)
+		require.NoError(t, err)
+		assertBitfieldEquals(t, partition.Sectors, 4, 5)
+		assertBitfieldEmpty(t, partition.Unproven)
+
+		dl, err = dls.LoadDeadline(harness.store, srcIdx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), dl.LiveSectors)
+		assert.Equal(t, uint64(4), dl.TotalSectors)
+
+		// No further moves are made until the next proving period.
+		moved, err = harness.s.RebalancePartitions(harness.store, partitionSize, sectorSize, currEpoch+miner.WPoStChallengeWindow)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), moved)
+		assert.Equal(t, uint64(2), partitionCount(t, harness, srcIdx))
+	})
+
+	t.Run("does not move partitions when deadlines are balanced", func(t *testing.T) {
+		harness := setupHarness(t, 2)
+
+		moved, err := harness.s.RebalancePartitions(harness.store, partitionSize, sectorSize, currEpoch)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), moved)
+		assert.Equal(t, miner.RebalanceCursor{NextPeriodStart: miner.WPoStProvingPeriod, Deadline: 0, Imbalanced: false}, harness.s.Rebalance)
+		assert.Equal(t, uint64(1), partitionCount(t, harness, srcIdx))
+	})
+
+	t.Run("does not scan deadlines unless marked imbalanced", func(t *testing.T) {
+		harness := setupHarness(t, 6)
+		harness.s.Rebalance.Imbalanced = false
+
+		moved, err := harness.s.RebalancePartitions(harness.store, partitionSize, sectorSize, currEpoch)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), moved)
+		assert.Equal(t, miner.RebalanceCursor{}, harness.s.Rebalance)
+		assert.Equal(t, uint64(3), partitionCount(t, harness, srcIdx))
+	})
+
+	t.Run("assigning sectors to deadlines marks them imbalanced", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		sector := newSectorOnChainInfo(1, tutils.MakeCID("1", &miner.SealedCIDPrefix), big.NewInt(1), abi.ChainEpoch(0))
+		harness.putSector(sector)
+
+		require.NoError(t, harness.s.AssignSectorsToDeadlines(harness.store, currEpoch, []*miner.SectorOnChainInfo{sector}, partitionSize, sectorSize))
+		assert.True(t, harness.s.Rebalance.Imbalanced)
+	})
+
+	t.Run("does not move partitions from a deadline that is due", func(t *testing.T) {
+		harness := setupHarness(t, 6)
+
+		// Deadline 10 is next to be challenged.
+		moved, err := harness.s.RebalancePartitions(harness.store, partitionSize, sectorSize, abi.ChainEpoch(srcIdx)*miner.WPoStChallengeWindow-1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), moved)
+		assert.Equal(t, uint64(3), partitionCount(t, harness, srcIdx))
+	})
+}
+
 func TestSectorNumberAllocation(t *testing.T) {
 	allocate := func(h *stateHarness, numbers ...uint64) error {
 		return h.s.AllocateSectorNumbers(h.store, bitfield.NewFromSet(numbers), miner.DenyCollisions)
@@ -842,7 +958,7 @@ func TestRepayDebtInPriorityOrder(t *testing.T) {
 
 	currentBalance := abi.NewTokenAmount(300)
 	fee := abi.NewTokenAmount(1000)
-	err := harness.s.ApplyPenalty(fee)
+	err := harness.s.ApplyPenalty(harness.store, abi.ChainEpoch(0), miner.FeeDebtReasonContinuedFault, fee)
 	require.NoError(t, err)
 
 	assert.Equal(t, harness.s.FeeDebt, fee)
@@ -857,7 +973,7 @@ func TestRepayDebtInPriorityOrder(t *testing.T) {
 
 	currentBalance = abi.NewTokenAmount(0)
 	fee = abi.NewTokenAmount(2050)
-	err = harness.s.ApplyPenalty(fee)
+	err = harness.s.ApplyPenalty(harness.store, abi.ChainEpoch(33), miner.FeeDebtReasonConsensusFault, fee)
 	require.NoError(t, err)
 
 	_, _, err = harness.s.RepayPartialDebtInPriorityOrder(harness.store, abi.ChainEpoch(33), currentBalance)
@@ -865,6 +981,131 @@ func TestRepayDebtInPriorityOrder(t *testing.T) {
 
 	expectedDebt = big.Add(expectedDebt, fee)
 	assert.Equal(t, expectedDebt, harness.s.FeeDebt)
+
+	log, err := harness.s.LoadFeeDebtLog(harness.store)
+	require.NoError(t, err)
+	require.Len(t, log.Accruals, 2)
+	assert.Equal(t, miner.FeeDebtAccrual{Epoch: 33, Reason: miner.FeeDebtReasonConsensusFault, Amount: fee}, *log.LastAccrual())
+	require.Len(t, log.Repayments, 1)
+	assert.Equal(t, miner.FeeDebtRepayment{PeriodStart: 0, Amount: abi.NewTokenAmount(300)}, log.Repayments[0])
+}
+
+func TestFeeDebtLog(t *testing.T) {
+	t.Run("zero penalty is not recorded", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		require.NoError(t, harness.s.ApplyPenalty(harness.store, abi.ChainEpoch(1), miner.FeeDebtReasonRewardPenalty, big.Zero()))
+
+		log, err := harness.s.LoadFeeDebtLog(harness.store)
+		require.NoError(t, err)
+		assert.Empty(t, log.Accruals)
+		assert.Nil(t, log.LastAccrual())
+	})
+
+	t.Run("accruals are bounded", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		for i := 0; i < miner.FeeDebtLogAccrualsMax+3; i++ {
+			err := harness.s.ApplyPenalty(harness.store, abi.ChainEpoch(i), miner.FeeDebtReasonContinuedFault, abi.NewTokenAmount(int64(i+1)))
+			require.NoError(t, err)
+		}
+
+		log, err := harness.s.LoadFeeDebtLog(harness.store)
+		require.NoError(t, err)
+		require.Len(t, log.Accruals, miner.FeeDebtLogAccrualsMax)
+		assert.Equal(t, abi.ChainEpoch(3), log.Accruals[0].Epoch)
+		assert.Equal(t, abi.ChainEpoch(miner.FeeDebtLogAccrualsMax+2), log.LastAccrual().Epoch)
+	})
+
+	t.Run("repayments are merged per proving period and bounded", func(t *testing.T) {
+		harness := constructStateHarness(t, abi.ChainEpoch(0))
+		periods := miner.FeeDebtLogRepaymentPeriodsMax + 2
+		require.NoError(t, harness.s.ApplyPenalty(harness.store, abi.ChainEpoch(0), miner.FeeDebtReasonContinuedFault, abi.NewTokenAmount(int64(2*periods))))
+
+		for i := 0; i < periods; i++ {
+			// Advance the proving period so repayments fall in distinct periods.
+			harness.s.ProvingPeriodStart = abi.ChainEpoch(i) * miner.WPoStProvingPeriod
+			for j := 0; j < 2; j++ {
+				epoch := harness.s.ProvingPeriodStart + abi.ChainEpoch(j)
+				_, fromBalance, err := harness.s.RepayPartialDebtInPriorityOrder(harness.store, epoch, abi.NewTokenAmount(1))
+				require.NoError(t, err)
+				assert.Equal(t, abi.NewTokenAmount(1), fromBalance)
+			}
+		}
+		assert.True(t, harness.s.FeeDebt.IsZero())
+
+		log, err := harness.s.LoadFeeDebtLog(harness.store)
+		require.NoError(t, err)
+		require.Len(t, log.Repayments, miner.FeeDebtLogRepaymentPeriodsMax)
+		for i, entry := range log.Repayments {
+			assert.Equal(t, abi.ChainEpoch(i+2)*miner.WPoStProvingPeriod, entry.PeriodStart)
+			assert.Equal(t, abi.NewTokenAmount(2), entry.Amount)
+		}
+	})
+}
+
+func TestReencodeAmts(t *testing.T) {
+	partitionSectors, err := builtin.SealProofWindowPoStPartitionSectors(abi.RegisteredSealProof_StackedDrg32GiBV1_1)
+	require.NoError(t, err)
+	sectorSize, err := abi.RegisteredSealProof_StackedDrg32GiBV1_1.SectorSize()
+	require.NoError(t, err)
+
+	harness := constructStateHarness(t, abi.ChainEpoch(0))
+	sectorInfos := make([]*miner.SectorOnChainInfo, 100)
+	for i := range sectorInfos {
+		sectorInfos[i] = newSectorOnChainInfo(
+			abi.SectorNumber(i), tutils.MakeCID(fmt.Sprintf("%d", i), &miner.SealedCIDPrefix), big.NewInt(1), abi.ChainEpoch(0),
+		)
+		harness.putSector(sectorInfos[i])
+	}
+	require.NoError(t, harness.s.AssignSectorsToDeadlines(harness.store, 0, sectorInfos, partitionSectors, sectorSize))
+
+	from := miner.CurrentAmtBitwidths()
+	defer func() {
+		miner.SectorsAmtBitwidth = from.Sectors
+		miner.DeadlineExpirationAmtBitwidth = from.DeadlineExpirations
+		miner.PartitionExpirationAmtBitwidth = from.PartitionExpirations
+	}()
+	miner.SectorsAmtBitwidth = 2
+	miner.DeadlineExpirationAmtBitwidth = 3
+	miner.PartitionExpirationAmtBitwidth = 2
+
+	// State written with the old bitwidths cannot be read with the new ones until re-encoded.
+	_, err = miner.LoadSectors(harness.store, harness.s.Sectors)
+	require.Error(t, err)
+	require.NoError(t, harness.s.ReencodeAmts(harness.store, from))
+
+	for _, info := range sectorInfos {
+		assert.Equal(t, info, harness.getSector(info.SectorNumber))
+	}
+	dls, err := harness.s.LoadDeadlines(harness.store)
+	require.NoError(t, err)
+	expiring := uint64(0)
+	require.NoError(t, dls.ForEach(harness.store, func(dlIdx uint64, dl *miner.Deadline) error {
+		quant := harness.s.QuantSpecForDeadline(dlIdx)
+		_, err := miner.LoadBitfieldQueue(harness.store, dl.ExpirationsEpochs, quant, miner.DeadlineExpirationAmtBitwidth)
+		require.NoError(t, err)
+		partitions, err := dl.PartitionsArray(harness.store)
+		require.NoError(t, err)
+		var partition miner.Partition
+		return partitions.ForEach(&partition, func(int64) error {
+			queue, err := miner.LoadExpirationQueue(harness.store, partition.ExpirationsEpochs, quant, miner.PartitionExpirationAmtBitwidth)
+			require.NoError(t, err)
+			var es miner.ExpirationSet
+			return queue.ForEach(&es, func(int64) error {
+				count, err := es.OnTimeSectors.Count()
+				require.NoError(t, err)
+				expiring += count
+				return nil
+			})
+		})
+	}))
+	assert.Equal(t, uint64(len(sectorInfos)), expiring)
+
+	t.Run("rejects invalid bitwidth", func(t *testing.T) {
+		miner.SectorsAmtBitwidth = adt.MaxAmtBitwidth + 1
+		require.Error(t, harness.s.ReencodeAmts(harness.store, miner.CurrentAmtBitwidths()))
+		_, err := miner.ConstructState(harness.store, harness.s.Info, 0, 0)
+		require.Error(t, err)
+	})
 }
 
 type stateHarness struct {
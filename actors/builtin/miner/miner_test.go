@@ -31,6 +31,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
 	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/expect"
 	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
 )
 
@@ -461,6 +462,47 @@ func TestGetBeneficiary(t *testing.T) {
 	})
 }
 
+func TestGetFeeDebtStatus(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("reports accrual and repayments", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		status := actor.getFeeDebtStatus(rt)
+		assert.Equal(t, big.Zero(), status.FeeDebt)
+		assert.Nil(t, status.LastAccrual)
+		assert.Empty(t, status.RecentRepayments)
+
+		// penalty exceeds balance so the miner enters fee debt
+		amt := rt.Balance()
+		penalty := big.Mul(big.NewInt(3), amt)
+		rt.SetCaller(builtin.RewardActorAddr, builtin.RewardActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.RewardActorAddr)
+		expect.Burn(amt).On(rt)
+		rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: big.Zero(), Penalty: penalty})
+		rt.Verify()
+
+		periodStart := getState(rt).CurrentProvingPeriodStart(rt.Epoch())
+		status = actor.getFeeDebtStatus(rt)
+		assert.Equal(t, big.Mul(big.NewInt(2), amt), status.FeeDebt)
+		require.NotNil(t, status.LastAccrual)
+		assert.Equal(t, miner.FeeDebtAccrual{Epoch: rt.Epoch(), Reason: miner.FeeDebtReasonRewardPenalty, Amount: penalty}, *status.LastAccrual)
+		assert.Equal(t, []miner.FeeDebtRepayment{{PeriodStart: periodStart, Amount: amt}}, status.RecentRepayments)
+
+		// repayments in the same proving period are accumulated
+		actor.repayDebt(rt, status.FeeDebt, big.Zero(), status.FeeDebt)
+		status = actor.getFeeDebtStatus(rt)
+		assert.Equal(t, big.Zero(), status.FeeDebt)
+		assert.Equal(t, miner.FeeDebtReasonRewardPenalty, status.LastAccrual.Reason)
+		assert.Equal(t, []miner.FeeDebtRepayment{{PeriodStart: periodStart, Amount: penalty}}, status.RecentRepayments)
+		actor.checkState(rt)
+	})
+}
+
 func TestWindowPost(t *testing.T) {
 	// Remove this nasty static/global access when policy is encapsulated in a structure.
 	// See https://github.com/filecoin-project/specs-actors/issues/353.
@@ -1596,10 +1638,9 @@ func newCronControl(rt *mock.Runtime, actor *actorHarness) *cronControl {
 // Returns epoch at which precommit is scheduled for clean up and removed from state by cron.
 func (h *cronControl) preCommitToStartCron(t *testing.T, preCommitEpoch abi.ChainEpoch) abi.ChainEpoch {
 	h.rt.SetEpoch(preCommitEpoch)
-	st := getState(h.rt)
 	h.requireCronInactive(t)
 
-	dlinfo := miner.NewDeadlineInfoFromOffsetAndEpoch(st.ProvingPeriodStart, preCommitEpoch) // actor.deadline might be out of date
+	dlinfo := h.rt.DeadlineInfo() // actor.deadline might be out of date
 	sectorNo := abi.SectorNumber(h.preCommitNum)
 	h.preCommitNum++
 	expiration := dlinfo.PeriodEnd() + defaultSectorExpiration*miner.WPoStProvingPeriod // something on deadline boundary but > 180 days
@@ -2785,6 +2826,285 @@ func TestChangeMultiAddrs(t *testing.T) {
 	})
 }
 
+func TestPauseMiner(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+	governance := miner.PauseGovernanceAddr
+
+	t.Run("pause and unpause require owner and governance approval", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		actor.pauseMiner(rt, actor.owner)
+		st := getState(rt)
+		assert.False(t, st.Paused)
+		assert.Equal(t, &miner.PendingPauseChange{Paused: true, ApprovedByOwner: true}, st.PendingPauseChange)
+
+		actor.pauseMiner(rt, governance)
+		st = getState(rt)
+		assert.True(t, st.Paused)
+		assert.Nil(t, st.PendingPauseChange)
+
+		// Control address methods are rejected while paused.
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbort(miner.ErrMinerPaused, func() {
+			rt.Call(actor.a.ChangePeerID, &miner.ChangePeerIDParams{NewID: tutil.MakePID("paused")})
+		})
+		rt.Verify()
+
+		actor.unpauseMiner(rt, governance)
+		st = getState(rt)
+		assert.True(t, st.Paused)
+		assert.Equal(t, &miner.PendingPauseChange{Paused: false, ApprovedByGovernance: true}, st.PendingPauseChange)
+
+		actor.unpauseMiner(rt, actor.owner)
+		st = getState(rt)
+		assert.False(t, st.Paused)
+		assert.Nil(t, st.PendingPauseChange)
+
+		actor.changePeerID(rt, tutil.MakePID("unpaused"))
+		actor.checkState(rt)
+	})
+
+	t.Run("window post and fault declaration are permitted while paused", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 1, defaultSectorExpiration, nil, true)
+
+		actor.pauseMiner(rt, actor.owner)
+		actor.pauseMiner(rt, governance)
+
+		advanceAndSubmitPoSts(rt, actor, sectors...)
+		actor.declareFaults(rt, sectors...)
+		actor.checkState(rt)
+	})
+
+	t.Run("owner, worker and control address changes are permitted while paused", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		newWorker := tutil.NewIDAddr(t, 999)
+		newControl := tutil.NewIDAddr(t, 998)
+		newOwner := tutil.NewIDAddr(t, 1001)
+		rt.SetAddressActorType(newControl, builtin.AccountActorCodeID)
+
+		actor.pauseMiner(rt, actor.owner)
+		actor.pauseMiner(rt, governance)
+
+		effectiveEpoch := rt.Epoch() + miner.WorkerKeyChangeDelay
+		actor.changeWorkerAddress(rt, newWorker, effectiveEpoch, []addr.Address{newControl})
+		rt.SetEpoch(effectiveEpoch)
+		actor.confirmUpdateWorkerKey(rt)
+		assert.Equal(t, newWorker, actor.getInfo(rt).Worker)
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		actor.changeOwnerAddress(rt, newOwner)
+		rt.SetCaller(newOwner, builtin.AccountActorCodeID)
+		actor.changeOwnerAddress(rt, newOwner)
+		assert.Equal(t, newOwner, actor.getInfo(rt).Owner)
+		assert.True(t, getState(rt).Paused)
+	})
+
+	t.Run("callers without authority are rejected before the pause is checked", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.pauseMiner(rt, actor.owner)
+		actor.pauseMiner(rt, governance)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 1234), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.a.ChangePeerID, &miner.ChangePeerIDParams{NewID: tutil.MakePID("paused")})
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects other callers", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner, governance)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.a.PauseMiner, nil)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects a change to the current state", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner, governance)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "already false", func() {
+			rt.Call(actor.a.UnpauseMiner, nil)
+		})
+		rt.Verify()
+
+		actor.pauseMiner(rt, actor.owner)
+		actor.pauseMiner(rt, governance)
+
+		rt.SetCaller(governance, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.owner, governance)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "already true", func() {
+			rt.Call(actor.a.PauseMiner, nil)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("owner change revokes owner approval", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		newOwner := tutil.NewIDAddr(t, 1001)
+
+		actor.pauseMiner(rt, actor.owner)
+
+		rt.SetCaller(actor.owner, builtin.MultisigActorCodeID)
+		actor.changeOwnerAddress(rt, newOwner)
+		rt.SetCaller(newOwner, builtin.MultisigActorCodeID)
+		actor.changeOwnerAddress(rt, newOwner)
+
+		st := getState(rt)
+		assert.Equal(t, &miner.PendingPauseChange{Paused: true}, st.PendingPauseChange)
+
+		actor.pauseMiner(rt, governance)
+		assert.False(t, getState(rt).Paused)
+		actor.pauseMiner(rt, newOwner)
+		assert.True(t, getState(rt).Paused)
+		actor.checkState(rt)
+	})
+}
+
+func TestDeclareUnsealingWindow(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("declares a window and burns the fee", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 2, defaultSectorExpiration, nil, true)
+
+		start := rt.Epoch() + 10
+		end := start + 100
+		sectorNo := sectors[0].SectorNumber
+		actor.declareUnsealingWindow(rt, bf(uint64(sectorNo)), start, end)
+
+		ret := actor.getUnsealingWindows(rt)
+		require.Len(t, ret.Windows, 1)
+		assert.Equal(t, start, ret.Windows[0].Start)
+		assert.Equal(t, end, ret.Windows[0].End)
+
+		st := getState(rt)
+		windows, err := st.LoadUnsealingWindows(rt.AdtStore())
+		require.NoError(t, err)
+		for _, tc := range []struct {
+			sectorNo abi.SectorNumber
+			epoch    abi.ChainEpoch
+			covered  bool
+		}{
+			{sectorNo, start - 1, false},
+			{sectorNo, start, true},
+			{sectorNo, end - 1, true},
+			{sectorNo, end, false},
+			{sectors[1].SectorNumber, start, false},
+		} {
+			covered, err := windows.Covers(tc.sectorNo, tc.epoch)
+			require.NoError(t, err)
+			assert.Equal(t, tc.covered, covered, "sector %d at epoch %d", tc.sectorNo, tc.epoch)
+		}
+
+		// The window is no longer reported once it has ended.
+		rt.SetEpoch(end)
+		ret = actor.getUnsealingWindows(rt)
+		assert.Empty(t, ret.Windows)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects invalid windows", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 1, defaultSectorExpiration, nil, true)
+		sectors0 := bf(uint64(sectors[0].SectorNumber))
+		now := rt.Epoch()
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "before current epoch", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: sectors0, Start: now - 1, End: now + 10})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "not after start", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: sectors0, Start: now, End: now})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "exceeds max", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: sectors0, Start: now, End: now + miner.UnsealingWindowMaxDuration + 1})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "no sectors", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: bf(), Start: now, End: now + 10})
+		})
+		rt.Verify()
+
+		// A sector must not expire before the window ends.
+		expiration := sectors[0].Expiration
+		rt.SetEpoch(expiration - 10)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "before unsealing window end", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: sectors0, Start: expiration - 10, End: expiration + 1})
+		})
+		rt.Verify()
+	})
+
+	t.Run("rejects a caller which is not a control address", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 1, defaultSectorExpiration, nil, true)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 1234), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{
+				Sectors: bf(uint64(sectors[0].SectorNumber)),
+				Start:   rt.Epoch(),
+				End:     rt.Epoch() + 10,
+			})
+		})
+		rt.Verify()
+	})
+
+	t.Run("limits the number of windows which have not ended", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		sectors := actor.commitAndProveSectors(rt, 1, defaultSectorExpiration, nil, true)
+		sectors0 := bf(uint64(sectors[0].SectorNumber))
+
+		now := rt.Epoch()
+		for i := 0; i < miner.UnsealingWindowsMax-1; i++ {
+			actor.declareUnsealingWindow(rt, sectors0, now, now+1000)
+		}
+		// The last window ends earliest.
+		actor.declareUnsealingWindow(rt, sectors0, now, now+10)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "too many unsealing windows", func() {
+			rt.Call(actor.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{Sectors: sectors0, Start: now, End: now + 10})
+		})
+		rt.Verify()
+
+		// Once a window has ended, it's pruned to make room for another.
+		rt.SetEpoch(now + 10)
+		actor.declareUnsealingWindow(rt, sectors0, now+10, now+20)
+		ret := actor.getUnsealingWindows(rt)
+		assert.Len(t, ret.Windows, miner.UnsealingWindowsMax)
+		actor.checkState(rt)
+	})
+}
+
 func TestChangeBeneficiary(t *testing.T) {
 	periodOffset := abi.ChainEpoch(100)
 	firstBeneficiaryId := tutil.NewIDAddr(t, 999)
@@ -3772,6 +4092,31 @@ func TestApplyRewards(t *testing.T) {
 		assert.Contains(t, msgs.Messages()[0], "DeadlineCronActive == false")
 	})
 
+	t.Run("fraction of penalty is recycled to reward actor", func(t *testing.T) {
+		defaultFraction := miner.PenaltyRecycleFraction
+		miner.PenaltyRecycleFraction = builtin.BigFrac{Numerator: big.NewInt(1), Denominator: big.NewInt(3)}
+		defer func() { miner.PenaltyRecycleFraction = defaultFraction }()
+
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rwd := abi.NewTokenAmount(600_000)
+		penalty := abi.NewTokenAmount(300_000)
+		rt.SetBalance(big.Add(rt.Balance(), rwd))
+
+		lockAmt, _ := miner.LockedRewardFromReward(rwd)
+		pledgeDelta := big.Sub(lockAmt, penalty)
+		rt.SetCaller(builtin.RewardActorAddr, builtin.RewardActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.RewardActorAddr)
+		expect.PledgeUpdate(pledgeDelta).On(rt)
+		rt.ExpectSend(builtin.RewardActorAddr, builtin.MethodsReward.RecyclePenalty, nil, abi.NewTokenAmount(100_000), nil, exitcode.Ok)
+		expect.Burn(abi.NewTokenAmount(200_000)).On(rt)
+		rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: rwd, Penalty: penalty})
+		rt.Verify()
+
+		assert.Equal(t, pledgeDelta, actor.getLockedFunds(rt))
+	})
+
 	t.Run("penalty is partially burnt and stored as fee debt", func(t *testing.T) {
 		rt := builder.Build(t)
 		actor.constructAndVerify(rt)
@@ -3795,7 +4140,7 @@ func TestApplyRewards(t *testing.T) {
 
 		// burn initial balance + reward = 2*amt
 		expectBurnt := big.Mul(big.NewInt(2), amt)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectBurnt, nil, exitcode.Ok)
+		expect.Burn(expectBurnt).On(rt)
 
 		rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: reward, Penalty: penalty})
 		rt.Verify()
@@ -3852,7 +4197,7 @@ func TestApplyRewards(t *testing.T) {
 		)
 
 		expectBurnt := st.FeeDebt
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectBurnt, nil, exitcode.Ok)
+		expect.Burn(expectBurnt).On(rt)
 
 		rt.Call(actor.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: reward, Penalty: penalty})
 		rt.Verify()
@@ -4100,6 +4445,7 @@ func (h *actorHarness) constructAndVerify(rt *mock.Runtime) {
 	ret := rt.Call(h.a.Constructor, &params)
 	assert.Nil(h.t, ret)
 	rt.Verify()
+	rt.SetProvingPeriodOffset(getState(rt).ProvingPeriodStart)
 }
 
 //
@@ -4372,6 +4718,42 @@ func (h *actorHarness) changePeerID(rt *mock.Runtime, newPID abi.PeerID) {
 	require.EqualValues(h.t, newPID, info.PeerId)
 }
 
+func (h *actorHarness) pauseMiner(rt *mock.Runtime, caller addr.Address) {
+	h.changePaused(rt, caller, h.a.PauseMiner)
+}
+
+func (h *actorHarness) unpauseMiner(rt *mock.Runtime, caller addr.Address) {
+	h.changePaused(rt, caller, h.a.UnpauseMiner)
+}
+
+func (h *actorHarness) changePaused(rt *mock.Runtime, caller addr.Address, method interface{}) {
+	info := h.getInfo(rt)
+	rt.SetCaller(caller, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(info.Owner, miner.PauseGovernanceAddr)
+	rt.Call(method, nil)
+	rt.Verify()
+}
+
+func (h *actorHarness) declareUnsealingWindow(rt *mock.Runtime, sectors bitfield.BitField, start, end abi.ChainEpoch) {
+	rt.SetCaller(h.worker, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(append(h.controlAddrs, h.owner, h.worker)...)
+	expect.Burn(miner.UnsealingWindowFee).On(rt)
+	rt.Call(h.a.DeclareUnsealingWindow, &miner.DeclareUnsealingWindowParams{
+		Sectors: sectors,
+		Start:   start,
+		End:     end,
+	})
+	rt.Verify()
+}
+
+func (h *actorHarness) getUnsealingWindows(rt *mock.Runtime) *miner.GetUnsealingWindowsReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.a.GetUnsealingWindows, nil).(*miner.GetUnsealingWindowsReturn)
+	require.NotNil(h.t, ret)
+	rt.Verify()
+	return ret
+}
+
 func (h *actorHarness) controlAddresses(rt *mock.Runtime) (owner, worker addr.Address, control []addr.Address) {
 	rt.ExpectValidateCallerAny()
 	ret := rt.Call(h.a.ControlAddresses, nil).(*miner.GetControlAddressesReturn)
@@ -4388,6 +4770,14 @@ func (h *actorHarness) getBeneficiary(rt *mock.Runtime) *miner.GetBeneficiaryRet
 	return ret
 }
 
+func (h *actorHarness) getFeeDebtStatus(rt *mock.Runtime) *miner.GetFeeDebtStatusReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.a.GetFeeDebtStatus, nil).(*miner.GetFeeDebtStatusReturn)
+	require.NotNil(h.t, ret)
+	rt.Verify()
+	return ret
+}
+
 // Options for preCommitSector behaviour.
 // Default zero values should let everything be ok.
 type preCommitConf struct {
@@ -4433,12 +4823,11 @@ func (h *actorHarness) preCommitSector(rt *mock.Runtime, params *miner.PreCommit
 	}
 	st := getState(rt)
 	if st.FeeDebt.GreaterThan(big.Zero()) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, st.FeeDebt, nil, exitcode.Ok)
+		expect.Burn(st.FeeDebt).On(rt)
 	}
 
 	if first {
-		dlInfo := miner.NewDeadlineInfoFromOffsetAndEpoch(st.ProvingPeriodStart, rt.Epoch())
-		cronParams := makeDeadlineCronEventParams(h.t, dlInfo.Last())
+		cronParams := makeDeadlineCronEventParams(h.t, rt.DeadlineInfo().Last())
 		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent, cronParams, big.Zero(), nil, exitcode.Ok)
 	}
 
@@ -4500,12 +4889,11 @@ func (h *actorHarness) preCommitSectorBatch(rt *mock.Runtime, params *miner.PreC
 	if st.FeeDebt.GreaterThan(big.Zero()) || len(params.Sectors) > 1 {
 		expectedNetworkFee := miner.AggregatePreCommitNetworkFee(len(params.Sectors), baseFee)
 		expectedBurn := big.Add(expectedNetworkFee, st.FeeDebt)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedBurn, nil, exitcode.Ok)
+		expect.Burn(expectedBurn).On(rt)
 	}
 
 	if conf.firstForMiner {
-		dlInfo := miner.NewDeadlineInfoFromOffsetAndEpoch(st.ProvingPeriodStart, rt.Epoch())
-		cronParams := makeDeadlineCronEventParams(h.t, dlInfo.Last())
+		cronParams := makeDeadlineCronEventParams(h.t, rt.DeadlineInfo().Last())
 		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent, cronParams, big.Zero(), nil, exitcode.Ok)
 	}
 
@@ -4597,23 +4985,30 @@ func (h *actorHarness) proveCommitAggregateSector(rt *mock.Runtime, conf proveCo
 	}
 	expectQueryNetworkInfo(rt, h)
 
-	// Expect randomness queries for provided precommits
+	// Expect randomness queries for provided precommits, once for each distinct epoch
 	var sealRands []abi.SealRandomness
 	var sealIntRands []abi.InteractiveSealRandomness
 	{
-
+		var buf bytes.Buffer
+		receiver := rt.Receiver()
+		err := receiver.MarshalCBOR(&buf)
+		require.NoError(h.t, err)
+		sealRandEpochs := map[abi.ChainEpoch]bool{}
+		interactiveEpochs := map[abi.ChainEpoch]bool{}
 		for _, precommit := range precommits {
 			sealRand := abi.SealRandomness([]byte{1, 2, 3, 4})
 			sealRands = append(sealRands, sealRand)
 			sealIntRand := abi.InteractiveSealRandomness([]byte{5, 6, 7, 8})
 			sealIntRands = append(sealIntRands, sealIntRand)
 			interactiveEpoch := precommit.PreCommitEpoch + miner.PreCommitChallengeDelay
-			var buf bytes.Buffer
-			receiver := rt.Receiver()
-			err := receiver.MarshalCBOR(&buf)
-			require.NoError(h.t, err)
-			rt.ExpectGetRandomnessTickets(crypto.DomainSeparationTag_SealRandomness, precommit.Info.SealRandEpoch, buf.Bytes(), abi.Randomness(sealRand))
-			rt.ExpectGetRandomnessBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, interactiveEpoch, buf.Bytes(), abi.Randomness(sealIntRand))
+			if !sealRandEpochs[precommit.Info.SealRandEpoch] {
+				sealRandEpochs[precommit.Info.SealRandEpoch] = true
+				rt.ExpectGetRandomnessTickets(crypto.DomainSeparationTag_SealRandomness, precommit.Info.SealRandEpoch, buf.Bytes(), abi.Randomness(sealRand))
+			}
+			if !interactiveEpochs[interactiveEpoch] {
+				interactiveEpochs[interactiveEpoch] = true
+				rt.ExpectGetRandomnessBeacon(crypto.DomainSeparationTag_InteractiveSealChallengeSeed, interactiveEpoch, buf.Bytes(), abi.Randomness(sealIntRand))
+			}
 		}
 	}
 	// Verify syscall
@@ -4647,7 +5042,7 @@ func (h *actorHarness) proveCommitAggregateSector(rt *mock.Runtime, conf proveCo
 	// burn networkFee
 	{
 		expectedFee := miner.AggregateProveCommitNetworkFee(len(precommits), baseFee)
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedFee, nil, exitcode.Ok)
+		expect.Burn(expectedFee).On(rt)
 	}
 
 	rt.SetCaller(h.worker, builtin.AccountActorCodeID)
@@ -4704,7 +5099,7 @@ func (h *actorHarness) confirmSectorProofsValidInternal(rt *mock.Runtime, conf p
 		}
 
 		if !expectPledge.IsZero() {
-			rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &expectPledge, big.Zero(), nil, exitcode.Ok)
+			expect.PledgeUpdate(expectPledge).On(rt)
 		}
 	}
 }
@@ -4903,12 +5298,7 @@ func (h *actorHarness) disputeWindowPoSt(rt *mock.Runtime, deadline *dline.Info,
 	if expectSuccess != nil {
 		// expect power update
 		if !expectSuccess.expectedPowerDelta.IsZero() {
-			claim := &power.UpdateClaimedPowerParams{
-				RawByteDelta:         expectSuccess.expectedPowerDelta.Raw,
-				QualityAdjustedDelta: expectSuccess.expectedPowerDelta.QA,
-			}
-			rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, claim, abi.NewTokenAmount(0),
-				nil, exitcode.Ok)
+			expect.PowerUpdate(expectSuccess.expectedPowerDelta.Raw, expectSuccess.expectedPowerDelta.QA).On(rt)
 		}
 		// expect reward
 		if !expectSuccess.expectedReward.IsZero() {
@@ -4916,12 +5306,11 @@ func (h *actorHarness) disputeWindowPoSt(rt *mock.Runtime, deadline *dline.Info,
 		}
 		// expect penalty
 		if !expectSuccess.expectedPenalty.IsZero() {
-			rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectSuccess.expectedPenalty, nil, exitcode.Ok)
+			expect.Burn(expectSuccess.expectedPenalty).On(rt)
 		}
 		// expect pledge update
 		if !expectSuccess.expectedPledgeDelta.IsZero() {
-			rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal,
-				&expectSuccess.expectedPledgeDelta, abi.NewTokenAmount(0), nil, exitcode.Ok)
+			expect.PledgeUpdate(expectSuccess.expectedPledgeDelta).On(rt)
 		}
 	}
 
@@ -5043,12 +5432,7 @@ func (h *actorHarness) submitWindowPoStRaw(rt *mock.Runtime, deadline *dline.Inf
 	if poStCfg != nil {
 		// expect power update
 		if !poStCfg.expectedPowerDelta.Raw.NilOrZero() || !poStCfg.expectedPowerDelta.QA.NilOrZero() {
-			claim := &power.UpdateClaimedPowerParams{
-				RawByteDelta:         poStCfg.expectedPowerDelta.Raw,
-				QualityAdjustedDelta: poStCfg.expectedPowerDelta.QA,
-			}
-			rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, claim, abi.NewTokenAmount(0),
-				nil, exitcode.Ok)
+			expect.PowerUpdate(poStCfg.expectedPowerDelta.Raw, poStCfg.expectedPowerDelta.QA).On(rt)
 		}
 	}
 
@@ -5094,7 +5478,7 @@ func (h *actorHarness) declareRecoveries(rt *mock.Runtime, deadlineIdx uint64, p
 	rt.ExpectValidateCallerAddr(append(h.controlAddrs, h.owner, h.worker)...)
 
 	if expectedDebtRepaid.GreaterThan(big.Zero()) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedDebtRepaid, nil, exitcode.Ok)
+		expect.Burn(expectedDebtRepaid).On(rt)
 	}
 
 	// Calculate params from faulted sector infos
@@ -5162,7 +5546,7 @@ func (h *actorHarness) terminateSectors(rt *mock.Runtime, sectors bitfield.BitFi
 	pledgeDelta := big.Zero()
 	var sectorPower miner.PowerPair
 	if big.Zero().LessThan(expectedFee) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedFee, nil, exitcode.Ok)
+		expect.Burn(expectedFee).On(rt)
 		pledgeDelta = big.Sum(pledgeDelta, expectedFee.Neg())
 	}
 	// notify change to initial pledge
@@ -5172,7 +5556,7 @@ func (h *actorHarness) terminateSectors(rt *mock.Runtime, sectors bitfield.BitFi
 		}
 	}
 	if !pledgeDelta.Equals(big.Zero()) {
-		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+		expect.PledgeUpdate(pledgeDelta).On(rt)
 	}
 	if len(dealIDs) > 0 {
 		size := len(dealIDs)
@@ -5187,10 +5571,7 @@ func (h *actorHarness) terminateSectors(rt *mock.Runtime, sectors bitfield.BitFi
 	}
 	{
 		sectorPower = miner.PowerForSectors(h.sectorSize, sectorInfos)
-		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, &power.UpdateClaimedPowerParams{
-			RawByteDelta:         sectorPower.Raw.Neg(),
-			QualityAdjustedDelta: sectorPower.QA.Neg(),
-		}, abi.NewTokenAmount(0), nil, exitcode.Ok)
+		expect.PowerUpdate(sectorPower.Raw.Neg(), sectorPower.QA.Neg()).On(rt)
 	}
 
 	// create declarations
@@ -5247,7 +5628,7 @@ func (h *actorHarness) reportConsensusFault(rt *mock.Runtime, from addr.Address,
 
 	// pay fault fee
 	toBurn := big.Sub(penaltyTotal, rewardTotal)
-	rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, toBurn, nil, exitcode.Ok)
+	expect.Burn(toBurn).On(rt)
 
 	rt.Call(h.a.ReportConsensusFault, params)
 	rt.Verify()
@@ -5277,7 +5658,7 @@ func (h *actorHarness) applyRewards(rt *mock.Runtime, amt, penalty abi.TokenAmou
 	)
 
 	if penalty.GreaterThan(big.Zero()) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, penalty, nil, exitcode.Ok)
+		expect.Burn(penalty).On(rt)
 	}
 
 	rt.Call(h.a.ApplyRewards, &builtin.ApplyRewardParams{Reward: amt, Penalty: penalty})
@@ -5330,7 +5711,7 @@ func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
 		penaltyTotal = big.Add(penaltyTotal, config.expiredPrecommitPenalty)
 	}
 	if !penaltyTotal.IsZero() {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, penaltyTotal, nil, exitcode.Ok)
+		expect.Burn(penaltyTotal).On(rt)
 		penaltyFromVesting := penaltyTotal
 		// Outstanding fee debt is only repaid from unlocked balance, not vesting funds.
 		if !config.repaidFeeDebt.NilOrZero() {
@@ -5354,7 +5735,7 @@ func (h *actorHarness) onDeadlineCron(rt *mock.Runtime, config *cronConfig) {
 	pledgeDelta = big.Sub(pledgeDelta, immediatelyVestingFunds(rt, &st))
 
 	if !pledgeDelta.IsZero() {
-		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+		expect.PledgeUpdate(pledgeDelta).On(rt)
 	}
 
 	// Re-enrollment for next period.
@@ -5381,7 +5762,7 @@ func (h *actorHarness) withdrawFunds(rt *mock.Runtime, amountRequested, expected
 
 	rt.ExpectSend(h.beneficiary, builtin.MethodSend, nil, expectedWithdrawn, nil, exitcode.Ok)
 	if expectedDebtRepaid.GreaterThan(big.Zero()) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedDebtRepaid, nil, exitcode.Ok)
+		expect.Burn(expectedDebtRepaid).On(rt)
 	}
 
 	rt.ExpectValidateCallerAddr(h.owner, h.beneficiary)
@@ -5405,12 +5786,12 @@ func (h *actorHarness) repayDebt(rt *mock.Runtime, value, expectedRepayedFromVes
 	rt.SetReceived(value)
 	if expectedRepayedFromVest.GreaterThan(big.Zero()) {
 		pledgeDelta := expectedRepayedFromVest.Neg()
-		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &pledgeDelta, big.Zero(), nil, exitcode.Ok)
+		expect.PledgeUpdate(pledgeDelta).On(rt)
 	}
 
 	totalRepaid := big.Sum(expectedRepayedFromVest, expectedRepaidFromBalance)
 	if totalRepaid.GreaterThan((big.Zero())) {
-		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, totalRepaid, nil, exitcode.Ok)
+		expect.Burn(totalRepaid).On(rt)
 	}
 	rt.Call(h.a.RepayDebt, nil)
 
@@ -5494,15 +5875,13 @@ func (h *actorHarness) setMultiaddrs(rt *mock.Runtime, newMultiaddrs ...abi.Mult
 // If cron is run asserts that the deadline schedules a new cron on the next deadline
 func advanceDeadline(rt *mock.Runtime, h *actorHarness, config *cronConfig) *dline.Info {
 	st := getState(rt)
-	deadline := miner.NewDeadlineInfoFromOffsetAndEpoch(st.ProvingPeriodStart, rt.Epoch())
-
 	if st.DeadlineCronActive {
-		rt.SetEpoch(deadline.Last())
+		deadline := rt.AdvanceToDeadlineLast()
 
 		config.expectedEnrollment = deadline.Last() + miner.WPoStChallengeWindow
 		h.onDeadlineCron(rt, config)
 	}
-	rt.SetEpoch(deadline.NextOpen())
+	rt.AdvanceToNextDeadline()
 	st = getState(rt)
 
 	return st.DeadlineInfo(rt.Epoch())
@@ -5655,6 +6034,24 @@ func getState(rt *mock.Runtime) *miner.State {
 	return &st
 }
 
+// Returns the numbers of the sectors named by the events of a type emitted so far, in order of emission.
+func sectorEvents(t testing.TB, rt *mock.Runtime, eventType string) []abi.SectorNumber {
+	var sectorNos []abi.SectorNumber
+	for _, event := range rt.Events() {
+		require.Len(t, event.Entries, 2)
+		actualType, err := cbg.ReadString(bytes.NewReader(event.Entries[0].Value))
+		require.NoError(t, err)
+		if actualType != eventType {
+			continue
+		}
+		require.Equal(t, miner.EventKeySector, event.Entries[1].Key)
+		var sectorNo cbg.CborInt
+		require.NoError(t, sectorNo.UnmarshalCBOR(bytes.NewReader(event.Entries[1].Value)))
+		sectorNos = append(sectorNos, abi.SectorNumber(sectorNo))
+	}
+	return sectorNos
+}
+
 func makeDeadlineCronEventParams(t testing.TB, epoch abi.ChainEpoch) *power.EnrollCronEventParams {
 	eventPayload := miner.CronEventPayload{EventType: miner.CronEventProvingDeadline}
 	buf := bytes.Buffer{}
@@ -7,7 +7,8 @@ import (
 	rtt "github.com/filecoin-project/go-state-types/rt"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
-	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/fixed"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
 )
 
@@ -63,6 +64,13 @@ const ConsensusFaultFactor = 5
 var LockedRewardFactorNum = big.NewInt(75)
 var LockedRewardFactorDenom = big.NewInt(100)
 
+// Fraction of fault, termination, consensus fault and disputed PoSt penalties returned to the reward actor's
+// storage mining allocation rather than burnt. Fees, and repayments of fee debt, are always burnt in full.
+var PenaltyRecycleFraction = builtin.BigFrac{ // PARAM_SPEC
+	Numerator:   big.Zero(),
+	Denominator: big.NewInt(1),
+}
+
 // Base reward for successfully disputing a window posts proofs.
 var BaseRewardForDisputedWindowPoSt = big.Mul(big.NewInt(4), builtin.TokenPrecision) // PARAM_SPEC
 // Base penalty for a successful disputed window post proof.
@@ -78,13 +86,31 @@ func ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate smoothing.Fil
 	if networkQAPowerSmoothed.IsZero() {
 		return smoothing.Estimate(&rewardEstimate)
 	}
-	expectedRewardForProvingPeriod := smoothing.ExtrapolatedCumSumOfRatio(projectionDuration, 0, rewardEstimate, networkQAPowerEstimate)
-	br128 := big.Mul(qaSectorPower, expectedRewardForProvingPeriod) // Q.0 * Q.128 => Q.128
-	br := big.Rsh(br128, math.Precision128)
+	expectedRewardForProvingPeriod := fixed.FromRaw(smoothing.ExtrapolatedCumSumOfRatio(projectionDuration, 0, rewardEstimate, networkQAPowerEstimate))
+	br := expectedRewardForProvingPeriod.MulInt(qaSectorPower).Floor()
 
 	return big.Max(br, big.Zero())
 }
 
+// BR for each of several projection periods, sharing the filter extrapolation work between them.
+// Each result is identical to that of ExpectedRewardForPower for the same projection duration.
+func ExpectedRewardsForPower(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower, projectionDurations ...abi.ChainEpoch) []abi.TokenAmount {
+	out := make([]abi.TokenAmount, len(projectionDurations))
+	networkQAPowerSmoothed := smoothing.Estimate(&networkQAPowerEstimate)
+	if networkQAPowerSmoothed.IsZero() {
+		for i := range out {
+			out[i] = smoothing.Estimate(&rewardEstimate)
+		}
+		return out
+	}
+	expectedRewards := smoothing.ExtrapolatedCumSumsOfRatio(projectionDurations, 0, rewardEstimate, networkQAPowerEstimate)
+	for i, expectedRewardForPeriod := range expectedRewards {
+		br := fixed.FromRaw(expectedRewardForPeriod).MulInt(qaSectorPower).Floor()
+		out[i] = big.Max(br, big.Zero())
+	}
+	return out
+}
+
 // BR but zero values are clamped at 1 attofil
 // Some uses of BR (PCD, IP) require a strictly positive value for BR derived values so
 // accounting variables can be used as succinct indicators of miner activity.
@@ -190,7 +216,7 @@ func InitialPledgeForPower(qaPower, baselinePower abi.StoragePower, rewardEstima
 // will be at most one proving period old if computed in the cron callback.
 func RepayDebtsOrAbort(rt Runtime, st *State) abi.TokenAmount {
 	currBalance := rt.CurrentBalance()
-	toBurn, err := st.repayDebts(currBalance)
+	toBurn, err := st.repayDebts(adt.AsStore(rt), rt.CurrEpoch(), currBalance)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "unlocked balance can not repay fee debt")
 	rt.Log(rtt.DEBUG, "RepayDebtsOrAbort was called and succeeded")
 	return toBurn
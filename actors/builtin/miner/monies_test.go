@@ -6,6 +6,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
@@ -155,6 +156,34 @@ func TestNegativeBRClamp(t *testing.T) {
 	assert.Equal(t, big.Zero(), fourBR)
 }
 
+func TestExpectedRewardsForPower(t *testing.T) {
+	durations := []abi.ChainEpoch{builtin.EpochsInDay, miner.InitialPledgeProjectionPeriod, 180 * builtin.EpochsInDay}
+	qaSectorPower := abi.NewStoragePower(1 << 36)
+	rewardEstimate := smoothing.NewEstimate(abi.NewTokenAmount(1<<50), big.NewInt(-1<<20))
+
+	t.Run("matches individual projections", func(t *testing.T) {
+		for _, powerEstimate := range []smoothing.FilterEstimate{
+			smoothing.NewEstimate(abi.NewStoragePower(1<<60), big.Zero()),
+			smoothing.NewEstimate(abi.NewStoragePower(1<<60), abi.NewStoragePower(1<<40)),
+			smoothing.NewEstimate(abi.NewStoragePower(1<<10), abi.NewStoragePower(1<<10).Neg()),
+		} {
+			brs := miner.ExpectedRewardsForPower(rewardEstimate, powerEstimate, qaSectorPower, durations...)
+			require.Len(t, brs, len(durations))
+			for i, duration := range durations {
+				assert.Equal(t, miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, qaSectorPower, duration), brs[i])
+			}
+		}
+	})
+
+	t.Run("zero network power", func(t *testing.T) {
+		powerEstimate := smoothing.NewEstimate(big.Zero(), big.Zero())
+		brs := miner.ExpectedRewardsForPower(rewardEstimate, powerEstimate, qaSectorPower, durations...)
+		for _, br := range brs {
+			assert.Equal(t, smoothing.Estimate(&rewardEstimate), br)
+		}
+	})
+}
+
 func TestContinuedFault(t *testing.T) {
 	t.Run("zero power means zero fault penalty", func(t *testing.T) {
 		epochTargetReward := abi.NewTokenAmount(1 << 50)
@@ -0,0 +1,247 @@
+// Package onboarding models the rules and timing by which the miner actor takes a sector from pre-commitment
+// to activation, so that sealing schedulers may plan from the same policy the actor enforces.
+//
+// A sector sealed with randomness from the chain is pre-committed, then waits for the epoch of its interactive
+// seal challenge, whose randomness (the seed) its proof of replication commits to. It may then be proven, either
+// alone with ProveCommitSector, its proof being verified by cron at the end of the epoch, or together with other
+// sectors with ProveCommitAggregate, which activates them at once. A pre-commitment not proven by its due epoch
+// expires, and is later cleaned up, burning its deposit.
+//
+// The timing is read from the miner actor's policy when computed, and so follows any changes made to the policy
+// for testing and development networks.
+package onboarding
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+)
+
+// The stage of a sector's onboarding at some epoch.
+type Stage int
+
+const (
+	// Sealed, but not yet pre-committed.
+	Sealed Stage = iota
+	// Pre-committed, waiting for the epoch of the interactive seal challenge.
+	WaitSeed
+	// The seed is available, and the sector may be proven until its pre-commitment's due epoch.
+	Provable
+	// A proof was submitted with ProveCommitSector, to be verified by cron at the end of the epoch.
+	ProofSubmitted
+	// Proven and activated.
+	Active
+	// The pre-commitment expired without being proven. It is cleaned up, and its deposit burnt, at its clean-up epoch.
+	Expired
+)
+
+func (s Stage) String() string {
+	switch s {
+	case Sealed:
+		return "sealed"
+	case WaitSeed:
+		return "wait-seed"
+	case Provable:
+		return "provable"
+	case ProofSubmitted:
+		return "proof-submitted"
+	case Active:
+		return "active"
+	case Expired:
+		return "expired"
+	default:
+		return fmt.Sprintf("Stage(%d)", int(s))
+	}
+}
+
+// The epochs at which a pre-committed sector moves between stages, as enforced by the miner actor.
+type Timeline struct {
+	SealProof      abi.RegisteredSealProof
+	PreCommitEpoch abi.ChainEpoch
+	// Epoch of the interactive seal challenge, from whose beacon randomness the seed is drawn.
+	SeedEpoch abi.ChainEpoch
+	// First epoch at which a proof is accepted, following the seed epoch.
+	ProveCommitOpen abi.ChainEpoch
+	// Last epoch at which a proof is accepted.
+	ProveCommitDue abi.ChainEpoch
+	// Epoch at which an expired pre-commitment is removed and its deposit burnt.
+	CleanUp abi.ChainEpoch
+}
+
+// Computes the timeline of a sector pre-committed at an epoch.
+func NewTimeline(sealProof abi.RegisteredSealProof, preCommitEpoch abi.ChainEpoch) (Timeline, error) {
+	msd, ok := miner.MaxProveCommitDuration[sealProof]
+	if !ok {
+		return Timeline{}, xerrors.Errorf("no max prove-commit duration for seal proof %d", sealProof)
+	}
+	seed := preCommitEpoch + miner.PreCommitChallengeDelay
+	return Timeline{
+		SealProof:       sealProof,
+		PreCommitEpoch:  preCommitEpoch,
+		SeedEpoch:       seed,
+		ProveCommitOpen: seed + 1,
+		ProveCommitDue:  preCommitEpoch + msd,
+		CleanUp:         preCommitEpoch + msd + miner.ExpiredPreCommitCleanUpDelay,
+	}, nil
+}
+
+// Returns the stage at an epoch of a sector pre-committed according to the timeline and not yet proven.
+func (tl Timeline) StageAt(epoch abi.ChainEpoch) Stage {
+	switch {
+	case epoch < tl.PreCommitEpoch:
+		return Sealed
+	case epoch < tl.ProveCommitOpen:
+		return WaitSeed
+	case epoch <= tl.ProveCommitDue:
+		return Provable
+	default:
+		return Expired
+	}
+}
+
+// Returns the range of epochs, inclusive, at which a sector sealed with randomness from an epoch may be
+// pre-committed.
+func PreCommitWindow(sealRandEpoch abi.ChainEpoch) (first, last abi.ChainEpoch) {
+	return sealRandEpoch + 1, sealRandEpoch + miner.MaxPreCommitRandomnessLookback
+}
+
+// Returns the range of expirations, inclusive, permitted to a sector pre-committed at an epoch.
+// The minimum lifetime is reckoned from the latest epoch at which the sector may be activated, its prove-commit
+// due epoch.
+func ExpirationBounds(sealProof abi.RegisteredSealProof, preCommitEpoch abi.ChainEpoch) (min, max abi.ChainEpoch, err error) {
+	tl, err := NewTimeline(sealProof, preCommitEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxLifetime, err := builtin.SealProofSectorMaximumLifetime(sealProof)
+	if err != nil {
+		return 0, 0, err
+	}
+	min = tl.ProveCommitDue + miner.MinSectorExpiration
+	max = preCommitEpoch + miner.MaxSectorExpirationExtension
+	if lifetimeMax := tl.ProveCommitDue + maxLifetime; lifetimeMax < max {
+		max = lifetimeMax
+	}
+	return min, max, nil
+}
+
+// A sector's progress through onboarding, checking each transition as the miner actor would.
+type Sector struct {
+	SealProof     abi.RegisteredSealProof
+	SealRandEpoch abi.ChainEpoch
+	Expiration    abi.ChainEpoch
+	// The sector's timeline, once pre-committed.
+	Timeline *Timeline
+	// The epoch at which the sector was proven, or at which its proof was submitted to be verified by cron,
+	// once proven.
+	ProofEpoch abi.ChainEpoch
+	proven     bool
+	aggregated bool
+}
+
+// Makes a sector sealed with randomness from an epoch, to be pre-committed.
+func NewSector(sealProof abi.RegisteredSealProof, sealRandEpoch abi.ChainEpoch) (*Sector, error) {
+	if !miner.CanPreCommitSealProof(sealProof) {
+		return nil, xerrors.Errorf("unsupported seal proof type %d", sealProof)
+	}
+	return &Sector{SealProof: sealProof, SealRandEpoch: sealRandEpoch}, nil
+}
+
+// Returns the sector's stage at an epoch, which should be no earlier than the sector's last transition.
+// A sector whose proof was submitted alone is taken to be activated at the end of the epoch of submission.
+func (s *Sector) StageAt(epoch abi.ChainEpoch) Stage {
+	if s.proven {
+		if epoch == s.ProofEpoch && !s.aggregated {
+			return ProofSubmitted
+		}
+		return Active
+	}
+	if s.Timeline == nil {
+		return Sealed
+	}
+	return s.Timeline.StageAt(epoch)
+}
+
+// Pre-commits the sector at an epoch with an expiration.
+func (s *Sector) PreCommit(epoch, expiration abi.ChainEpoch) error {
+	if stage := s.StageAt(epoch); stage != Sealed {
+		return xerrors.Errorf("cannot pre-commit sector in stage %s", stage)
+	}
+	first, last := PreCommitWindow(s.SealRandEpoch)
+	if epoch < first {
+		return xerrors.Errorf("seal challenge epoch %d must be before pre-commit epoch %d", s.SealRandEpoch, epoch)
+	}
+	if epoch > last {
+		return xerrors.Errorf("seal challenge epoch %d too old to pre-commit at %d, last epoch %d", s.SealRandEpoch, epoch, last)
+	}
+	min, max, err := ExpirationBounds(s.SealProof, epoch)
+	if err != nil {
+		return err
+	}
+	if expiration < min || expiration > max {
+		return xerrors.Errorf("expiration %d outside permitted range [%d, %d]", expiration, min, max)
+	}
+	tl, err := NewTimeline(s.SealProof, epoch)
+	if err != nil {
+		return err
+	}
+	s.Timeline = &tl
+	s.Expiration = expiration
+	return nil
+}
+
+// Submits a proof of the sector alone at an epoch, as with ProveCommitSector.
+// The sector is activated when the proof is verified by cron at the end of the epoch.
+func (s *Sector) ProveCommit(epoch abi.ChainEpoch) error {
+	if err := s.checkProvable(epoch); err != nil {
+		return err
+	}
+	s.proven, s.ProofEpoch = true, epoch
+	return nil
+}
+
+func (s *Sector) checkProvable(epoch abi.ChainEpoch) error {
+	switch stage := s.StageAt(epoch); stage {
+	case Provable:
+		return nil
+	case WaitSeed:
+		return xerrors.Errorf("too early to prove sector at %d, first epoch %d", epoch, s.Timeline.ProveCommitOpen)
+	case Expired:
+		return xerrors.Errorf("too late to prove sector at %d, due %d", epoch, s.Timeline.ProveCommitDue)
+	default:
+		return xerrors.Errorf("cannot prove sector in stage %s", stage)
+	}
+}
+
+// Proves sectors together at an epoch, as with ProveCommitAggregate, activating them at once.
+// As by the actor, sectors whose pre-commitments have expired are skipped and returned, while any sector not yet
+// provable fails the whole aggregate.
+func ProveCommitAggregate(epoch abi.ChainEpoch, sectors ...*Sector) (skipped []*Sector, err error) {
+	if len(sectors) > miner.MaxAggregatedSectors {
+		return nil, xerrors.Errorf("too many sectors addressed, addressed %d want <= %d", len(sectors), miner.MaxAggregatedSectors)
+	} else if len(sectors) < miner.MinAggregatedSectors {
+		return nil, xerrors.Errorf("too few sectors addressed, addressed %d want >= %d", len(sectors), miner.MinAggregatedSectors)
+	}
+	var provable []*Sector
+	for _, s := range sectors {
+		if s.SealProof != sectors[0].SealProof {
+			return nil, xerrors.Errorf("aggregate contains mismatched seal proofs %d and %d", sectors[0].SealProof, s.SealProof)
+		}
+		if s.StageAt(epoch) == Expired {
+			skipped = append(skipped, s)
+			continue
+		}
+		if err := s.checkProvable(epoch); err != nil {
+			return nil, err
+		}
+		provable = append(provable, s)
+	}
+	for _, s := range provable {
+		s.proven, s.aggregated, s.ProofEpoch = true, true, epoch
+	}
+	return skipped, nil
+}
@@ -0,0 +1,168 @@
+package onboarding_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner/onboarding"
+)
+
+const sealProof = abi.RegisteredSealProof_StackedDrg32GiBV1_1
+
+func TestTimeline(t *testing.T) {
+	preCommitEpoch := abi.ChainEpoch(1000)
+	tl, err := onboarding.NewTimeline(sealProof, preCommitEpoch)
+	require.NoError(t, err)
+
+	assert.Equal(t, preCommitEpoch+miner.PreCommitChallengeDelay, tl.SeedEpoch)
+	assert.Equal(t, tl.SeedEpoch+1, tl.ProveCommitOpen)
+	assert.Equal(t, preCommitEpoch+miner.MaxProveCommitDuration[sealProof], tl.ProveCommitDue)
+	assert.Equal(t, tl.ProveCommitDue+miner.ExpiredPreCommitCleanUpDelay, tl.CleanUp)
+
+	assert.Equal(t, onboarding.Sealed, tl.StageAt(preCommitEpoch-1))
+	assert.Equal(t, onboarding.WaitSeed, tl.StageAt(preCommitEpoch))
+	assert.Equal(t, onboarding.WaitSeed, tl.StageAt(tl.SeedEpoch))
+	assert.Equal(t, onboarding.Provable, tl.StageAt(tl.ProveCommitOpen))
+	assert.Equal(t, onboarding.Provable, tl.StageAt(tl.ProveCommitDue))
+	assert.Equal(t, onboarding.Expired, tl.StageAt(tl.ProveCommitDue+1))
+
+	t.Run("unknown seal proof", func(t *testing.T) {
+		_, err := onboarding.NewTimeline(abi.RegisteredSealProof(-1), preCommitEpoch)
+		assert.Error(t, err)
+	})
+}
+
+func TestExpirationBounds(t *testing.T) {
+	preCommitEpoch := abi.ChainEpoch(1000)
+	min, max, err := onboarding.ExpirationBounds(sealProof, preCommitEpoch)
+	require.NoError(t, err)
+	assert.Equal(t, preCommitEpoch+miner.MaxProveCommitDuration[sealProof]+miner.MinSectorExpiration, min)
+	assert.Equal(t, preCommitEpoch+miner.MaxSectorExpirationExtension, max)
+}
+
+func TestSector(t *testing.T) {
+	sealRandEpoch := abi.ChainEpoch(1000)
+	preCommitEpoch := sealRandEpoch + 10
+
+	newPreCommitted := func(t *testing.T) *onboarding.Sector {
+		s, err := onboarding.NewSector(sealProof, sealRandEpoch)
+		require.NoError(t, err)
+		min, _, err := onboarding.ExpirationBounds(sealProof, preCommitEpoch)
+		require.NoError(t, err)
+		require.NoError(t, s.PreCommit(preCommitEpoch, min))
+		return s
+	}
+
+	t.Run("prove commit", func(t *testing.T) {
+		s := newPreCommitted(t)
+		assert.Error(t, s.ProveCommit(s.Timeline.SeedEpoch))
+		assert.Equal(t, onboarding.WaitSeed, s.StageAt(s.Timeline.SeedEpoch))
+
+		proveEpoch := s.Timeline.ProveCommitOpen
+		require.NoError(t, s.ProveCommit(proveEpoch))
+		assert.Equal(t, onboarding.ProofSubmitted, s.StageAt(proveEpoch))
+		assert.Equal(t, onboarding.Active, s.StageAt(proveEpoch+1))
+		assert.Error(t, s.ProveCommit(proveEpoch+1))
+	})
+
+	t.Run("prove commit too late", func(t *testing.T) {
+		s := newPreCommitted(t)
+		require.NoError(t, s.ProveCommit(s.Timeline.ProveCommitDue))
+
+		s = newPreCommitted(t)
+		assert.Error(t, s.ProveCommit(s.Timeline.ProveCommitDue+1))
+		assert.Equal(t, onboarding.Expired, s.StageAt(s.Timeline.ProveCommitDue+1))
+	})
+
+	t.Run("pre-commit window", func(t *testing.T) {
+		first, last := onboarding.PreCommitWindow(sealRandEpoch)
+		min, _, err := onboarding.ExpirationBounds(sealProof, last)
+		require.NoError(t, err)
+
+		s, err := onboarding.NewSector(sealProof, sealRandEpoch)
+		require.NoError(t, err)
+		assert.Error(t, s.PreCommit(first-1, min))
+		assert.Error(t, s.PreCommit(last+1, min))
+		require.NoError(t, s.PreCommit(last, min))
+		assert.Error(t, s.PreCommit(last, min), "pre-committed twice")
+	})
+
+	t.Run("expiration out of bounds", func(t *testing.T) {
+		min, max, err := onboarding.ExpirationBounds(sealProof, preCommitEpoch)
+		require.NoError(t, err)
+		s, err := onboarding.NewSector(sealProof, sealRandEpoch)
+		require.NoError(t, err)
+		assert.Error(t, s.PreCommit(preCommitEpoch, min-1))
+		assert.Error(t, s.PreCommit(preCommitEpoch, max+1))
+		require.NoError(t, s.PreCommit(preCommitEpoch, max))
+	})
+
+	t.Run("unsupported seal proof", func(t *testing.T) {
+		_, err := onboarding.NewSector(abi.RegisteredSealProof_StackedDrg32GiBV1, sealRandEpoch)
+		assert.Error(t, err)
+	})
+}
+
+func TestProveCommitAggregate(t *testing.T) {
+	newPreCommitted := func(t *testing.T, count int, preCommitEpoch abi.ChainEpoch) []*onboarding.Sector {
+		min, _, err := onboarding.ExpirationBounds(sealProof, preCommitEpoch)
+		require.NoError(t, err)
+		sectors := make([]*onboarding.Sector, count)
+		for i := range sectors {
+			s, err := onboarding.NewSector(sealProof, preCommitEpoch-1)
+			require.NoError(t, err)
+			require.NoError(t, s.PreCommit(preCommitEpoch, min))
+			sectors[i] = s
+		}
+		return sectors
+	}
+
+	t.Run("activates at once", func(t *testing.T) {
+		sectors := newPreCommitted(t, miner.MinAggregatedSectors, 1000)
+		proveEpoch := sectors[0].Timeline.ProveCommitOpen
+		skipped, err := onboarding.ProveCommitAggregate(proveEpoch, sectors...)
+		require.NoError(t, err)
+		assert.Empty(t, skipped)
+		for _, s := range sectors {
+			assert.Equal(t, onboarding.Active, s.StageAt(proveEpoch))
+		}
+	})
+
+	t.Run("size limits", func(t *testing.T) {
+		sectors := newPreCommitted(t, miner.MinAggregatedSectors-1, 1000)
+		_, err := onboarding.ProveCommitAggregate(sectors[0].Timeline.ProveCommitOpen, sectors...)
+		assert.Error(t, err)
+
+		sectors = newPreCommitted(t, miner.MaxAggregatedSectors+1, 1000)
+		_, err = onboarding.ProveCommitAggregate(sectors[0].Timeline.ProveCommitOpen, sectors...)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired sectors skipped", func(t *testing.T) {
+		early := newPreCommitted(t, 1, 1000)
+		proveEpoch := early[0].Timeline.ProveCommitDue + 1
+		later := newPreCommitted(t, miner.MinAggregatedSectors-1, proveEpoch-miner.PreCommitChallengeDelay-1)
+
+		skipped, err := onboarding.ProveCommitAggregate(proveEpoch, append(early, later...)...)
+		require.NoError(t, err)
+		assert.Equal(t, early, skipped)
+		assert.Equal(t, onboarding.Expired, early[0].StageAt(proveEpoch))
+		for _, s := range later {
+			assert.Equal(t, onboarding.Active, s.StageAt(proveEpoch))
+		}
+	})
+
+	t.Run("early sector fails aggregate", func(t *testing.T) {
+		sectors := newPreCommitted(t, miner.MinAggregatedSectors-1, 1000)
+		sectors = append(sectors, newPreCommitted(t, 1, 1001)...)
+		_, err := onboarding.ProveCommitAggregate(sectors[0].Timeline.ProveCommitOpen, sectors...)
+		assert.Error(t, err)
+		for _, s := range sectors {
+			assert.NotEqual(t, onboarding.Active, s.StageAt(sectors[0].Timeline.ProveCommitOpen))
+		}
+	})
+}
@@ -52,9 +52,12 @@ type Partition struct {
 }
 
 // Bitwidth of AMTs determined empirically from mutation patterns and projections of mainnet data.
-const PartitionExpirationAmtBitwidth = 4
 const PartitionEarlyTerminationArrayAmtBitwidth = 3
 
+// Bitwidth of the partition expiration queue.
+// Configurable by policy (see AmtBitwidths); state written with a different value must be re-encoded.
+var PartitionExpirationAmtBitwidth = 4
+
 // Value type for a pair of raw and QA power.
 type PowerPair struct {
 	Raw abi.StoragePower
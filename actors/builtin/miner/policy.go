@@ -96,6 +96,12 @@ const (
 // Maximum number of control addresses a miner may register.
 const MaxControlAddresses = 10
 
+// Maximum number of partitions moved between deadlines by background rebalancing in each proving period.
+const PartitionRebalanceMovesMax = uint64(1)
+
+// Minimum number of partitions by which a deadline must exceed another for a partition to be moved between them.
+const PartitionRebalanceImbalanceMin = uint64(2)
+
 // The maximum number of partitions that may be required to be loaded in a single invocation,
 // when all the sector infos for the partitions will be loaded.
 func loadPartitionsSectorsMax(partitionSectorCount uint64) uint64 {
@@ -308,3 +314,22 @@ const MaxAggregateProofSize = 81960
 // stay in state for a period of time creating a grace period during which a late-running aggregated prove-commit
 // can still prove its non-expired precommits without resubmitting a message
 const ExpiredPreCommitCleanUpDelay = 8 * builtin.EpochsInHour
+
+// Maximum number of fee debt accruals retained in the miner's fee debt log.
+const FeeDebtLogAccrualsMax = 16
+
+// Maximum number of proving periods for which fee debt repayments are retained in the miner's fee debt log.
+const FeeDebtLogRepaymentPeriodsMax = 8
+
+// Maximum number of unsealing windows which a miner may have declared and not yet ended.
+const UnsealingWindowsMax = 32 // PARAM_SPEC
+
+// Maximum duration of an unsealing window.
+const UnsealingWindowMaxDuration = 30 * builtin.EpochsInDay // PARAM_SPEC
+
+// Fee burnt for each declaration of an unsealing window, deterring miners from filling their declarations
+// with short windows.
+var UnsealingWindowFee = big.Div(builtin.TokenPrecision, big.NewInt(1000)) // PARAM_SPEC
+
+// Address which, together with a miner's owner, must approve pausing or unpausing that miner.
+var PauseGovernanceAddr = builtin.GovernanceActorAddr // PARAM_SPEC
@@ -92,6 +92,22 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 	// Check deadlines
 	acc.Require(st.CurrentDeadline < WPoStPeriodDeadlines,
 		"current deadline index is greater than deadlines per period(%d): %d", WPoStPeriodDeadlines, st.CurrentDeadline)
+	acc.Require(st.Rebalance.Deadline < WPoStPeriodDeadlines,
+		"rebalance cursor deadline index is greater than deadlines per period(%d): %d", WPoStPeriodDeadlines, st.Rebalance.Deadline)
+	if st.PendingPauseChange != nil {
+		acc.Require(st.PendingPauseChange.Paused != st.Paused, "pending pause change to %t is a no-op", st.PendingPauseChange.Paused)
+		acc.Require(!st.PendingPauseChange.ApprovedByOwner || !st.PendingPauseChange.ApprovedByGovernance,
+			"pending pause change approved by both parties but not applied")
+	}
+	if windows, err := st.LoadUnsealingWindows(store); err != nil {
+		acc.Addf("error loading unsealing windows: %v", err)
+	} else {
+		acc.Require(len(windows.Windows) <= UnsealingWindowsMax, "miner has %d unsealing windows, max %d", len(windows.Windows), UnsealingWindowsMax)
+		for _, window := range windows.Windows {
+			acc.Require(window.Start < window.End, "unsealing window end %d not after start %d", window.End, window.Start)
+			acc.Require(window.End-window.Start <= UnsealingWindowMaxDuration, "unsealing window from %d to %d exceeds max duration", window.Start, window.End)
+		}
+	}
 
 	deadlines, err := st.LoadDeadlines(store)
 	if err != nil {
@@ -748,6 +764,26 @@ func CheckMinerBalances(st *State, store adt.Store, balance abi.TokenAmount, acc
 	acc.Require(st.LockedFunds.Equals(vestingSum),
 		"locked funds %d is not sum of vesting table entries %d", st.LockedFunds, vestingSum)
 
+	// fee debt log is bounded and records only positive amounts in epoch order
+	if log, err := st.LoadFeeDebtLog(store); err != nil {
+		acc.Addf("error loading fee debt log: %v", err)
+	} else {
+		acc.Require(len(log.Accruals) <= FeeDebtLogAccrualsMax, "fee debt log has %d accruals, max %d", len(log.Accruals), FeeDebtLogAccrualsMax)
+		acc.Require(len(log.Repayments) <= FeeDebtLogRepaymentPeriodsMax, "fee debt log has %d repayment periods, max %d", len(log.Repayments), FeeDebtLogRepaymentPeriodsMax)
+		for i, entry := range log.Accruals {
+			acc.Require(entry.Amount.GreaterThan(big.Zero()), "non-positive amount in fee debt accrual %v", entry)
+			if i > 0 {
+				acc.Require(entry.Epoch >= log.Accruals[i-1].Epoch, "fee debt accruals out of order at %d", entry.Epoch)
+			}
+		}
+		for i, entry := range log.Repayments {
+			acc.Require(entry.Amount.GreaterThan(big.Zero()), "non-positive amount in fee debt repayment %v", entry)
+			if i > 0 {
+				acc.Require(entry.PeriodStart > log.Repayments[i-1].PeriodStart, "fee debt repayments out of order at %d", entry.PeriodStart)
+			}
+		}
+	}
+
 	// Non zero funds implies that DeadlineCronActive is true.
 	if st.ContinueDeadlineCron() {
 		acc.Require(st.DeadlineCronActive, "DeadlineCronActive == false when IP+PCD+LF > 0")
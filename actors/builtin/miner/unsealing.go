@@ -0,0 +1,60 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// UnsealingWindows records the windows during which the miner has declared it will keep sectors unsealed
+// for fast retrieval. Windows are sorted by declaration, oldest first, and bounded in number.
+// Declarations are not enforced by the actor, but are evidence for retrieval agreements made off-chain.
+type UnsealingWindows struct {
+	Windows []UnsealingWindow
+}
+
+// UnsealingWindow declares that sectors will be kept unsealed from Start until End (exclusive).
+type UnsealingWindow struct {
+	Sectors bitfield.BitField
+	Start   abi.ChainEpoch
+	End     abi.ChainEpoch
+}
+
+// ConstructUnsealingWindows constructs an empty UnsealingWindows.
+func ConstructUnsealingWindows() *UnsealingWindows {
+	return &UnsealingWindows{
+		Windows: []UnsealingWindow{},
+	}
+}
+
+// Returns the windows which have not ended as of an epoch.
+func (w *UnsealingWindows) Active(epoch abi.ChainEpoch) []UnsealingWindow {
+	active := []UnsealingWindow{}
+	for _, window := range w.Windows {
+		if window.End > epoch {
+			active = append(active, window)
+		}
+	}
+	return active
+}
+
+// Returns whether the miner declared that a sector would be unsealed at an epoch.
+func (w *UnsealingWindows) Covers(sectorNo abi.SectorNumber, epoch abi.ChainEpoch) (bool, error) {
+	for _, window := range w.Windows {
+		if epoch < window.Start || epoch >= window.End {
+			continue
+		}
+		covered, err := window.Sectors.IsSet(uint64(sectorNo))
+		if err != nil {
+			return false, err
+		}
+		if covered {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Removes the windows which have ended as of an epoch.
+func (w *UnsealingWindows) pruneEnded(epoch abi.ChainEpoch) {
+	w.Windows = w.Active(epoch)
+}
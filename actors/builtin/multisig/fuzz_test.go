@@ -0,0 +1,135 @@
+package multisig_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/harness"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Fuzzes the methods by which a multisig administers itself, called by the multisig with parameters decoded
+// from the input. Each call must either abort or leave the state consistent.
+func FuzzSelfAdministration(f *testing.F) {
+	anne, bob, chuck := tutil.NewIDAddr(f, 101), tutil.NewIDAddr(f, 102), tutil.NewIDAddr(f, 103)
+	methods := []abi.MethodNum{
+		builtin.MethodsMultisig.AddSigner,
+		builtin.MethodsMultisig.RemoveSigner,
+		builtin.MethodsMultisig.SwapSigner,
+		builtin.MethodsMultisig.ChangeNumApprovalsThreshold,
+		builtin.MethodsMultisig.LockBalance,
+		builtin.MethodsMultisig.SwapSignerAndReapprove,
+		builtin.MethodsMultisig.SetSpendingLimit,
+	}
+	seeds := []cbor.Marshaler{
+		&multisig.AddSignerParams{Signer: chuck, Increase: true},
+		&multisig.RemoveSignerParams{Signer: bob, Decrease: true},
+		&multisig.SwapSignerParams{From: anne, To: chuck},
+		&multisig.ChangeNumApprovalsThresholdParams{NewThreshold: 1},
+		&multisig.LockBalanceParams{StartEpoch: 10, UnlockDuration: 100, Amount: abi.NewTokenAmount(50)},
+		&multisig.SwapSignerAndReapproveParams{From: anne, To: chuck, ApprovalPolicy: multisig.SwapApprovalsTransfer},
+		&multisig.SetSpendingLimitParams{Amount: abi.NewTokenAmount(10), Window: 100, Destinations: []addr.Address{chuck}},
+	}
+	for i, seed := range seeds {
+		f.Add(uint8(i), marshal(f, seed))
+	}
+
+	f.Fuzz(func(t *testing.T, index uint8, data []byte) {
+		method := methods[int(index)%len(methods)]
+		params, ok := unmarshalParams(t, method, data)
+		if !ok {
+			return
+		}
+		h := newFuzzHarness(t, anne, bob, chuck)
+
+		h.SetCaller(h.Receiver(), builtin.MultisigActorCodeID)
+		h.ExpectValidateCallerAddr(h.Receiver())
+		if _, code := h.CallMayAbort(method, params); code == exitcode.Ok {
+			checkHarnessState(t, h)
+		}
+	})
+}
+
+// Fuzzes proposals by a signer, with parameters decoded from the input, and their cancellation.
+// Proposals are not approved by enough signers to be executed.
+func FuzzProposeAndCancel(f *testing.F) {
+	anne, bob, chuck := tutil.NewIDAddr(f, 101), tutil.NewIDAddr(f, 102), tutil.NewIDAddr(f, 103)
+	f.Add(marshal(f, &multisig.ProposeParams{To: chuck, Value: abi.NewTokenAmount(10), Method: builtin.MethodSend}))
+	f.Add(marshal(f, &multisig.ProposeParams{To: chuck, Value: big.Zero(), Method: 2, Params: []byte{1, 2, 3}}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		params, ok := unmarshalParams(t, builtin.MethodsMultisig.Propose, data)
+		if !ok {
+			return
+		}
+		h := newFuzzHarness(t, anne, bob, chuck)
+
+		h.SetCaller(anne, builtin.AccountActorCodeID)
+		h.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		ret, code := h.CallMayAbort(builtin.MethodsMultisig.Propose, params)
+		if code != exitcode.Ok {
+			return
+		}
+		proposed := ret.(*multisig.ProposeReturn)
+		assert.False(t, proposed.Applied)
+		checkHarnessState(t, h)
+
+		h.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		h.Call(builtin.MethodsMultisig.Cancel, &multisig.TxnIDParams{ID: proposed.TxnID})
+		checkHarnessState(t, h)
+	})
+}
+
+// Constructs a multisig requiring two approvals of its signers, anne and bob, with a transaction to chuck
+// proposed by anne.
+func newFuzzHarness(t *testing.T, anne, bob, chuck addr.Address) *harness.Harness {
+	h := harness.New(t).
+		WithActor(multisig.Actor{}).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithActorType(anne, builtin.AccountActorCodeID).
+		WithActorType(bob, builtin.AccountActorCodeID).
+		WithActorType(chuck, builtin.AccountActorCodeID).
+		WithBalance(abi.NewTokenAmount(1000)).
+		Build()
+	h.ExpectValidateCallerAddr(builtin.InitActorAddr)
+	h.Call(builtin.MethodsMultisig.Constructor, &multisig.ConstructorParams{
+		Signers:               []addr.Address{anne, bob},
+		NumApprovalsThreshold: 2,
+	})
+
+	h.SetCaller(anne, builtin.AccountActorCodeID)
+	h.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	h.Call(builtin.MethodsMultisig.Propose, &multisig.ProposeParams{To: chuck, Value: abi.NewTokenAmount(10), Method: builtin.MethodSend})
+	return h
+}
+
+// Decodes the parameters of a multisig method, reporting whether the data is a valid encoding of them.
+func unmarshalParams(t *testing.T, method abi.MethodNum, data []byte) (cbor.Unmarshaler, bool) {
+	m, ok := exported.LookupMethod(builtin.MultisigActorCodeID, method)
+	require.True(t, ok)
+	params := m.NewParams()
+	return params, params.UnmarshalCBOR(bytes.NewReader(data)) == nil
+}
+
+func marshal(t testing.TB, v cbor.Marshaler) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, v.MarshalCBOR(&buf))
+	return buf.Bytes()
+}
+
+func checkHarnessState(t testing.TB, h *harness.Harness) {
+	var st multisig.State
+	h.GetState(&st)
+	assertStateInvariants(t, h.Runtime, &st)
+}
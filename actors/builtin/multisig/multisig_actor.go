@@ -51,15 +51,21 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.Propose,
-		3:                         a.Approve,
-		4:                         a.Cancel,
-		5:                         a.AddSigner,
-		6:                         a.RemoveSigner,
-		7:                         a.SwapSigner,
-		8:                         a.ChangeNumApprovalsThreshold,
-		9:                         a.LockBalance,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.Propose,
+		3:                          a.Approve,
+		4:                          a.Cancel,
+		5:                          a.AddSigner,
+		6:                          a.RemoveSigner,
+		7:                          a.SwapSigner,
+		8:                          a.ChangeNumApprovalsThreshold,
+		9:                          a.LockBalance,
+		10:                         a.ApproveMany,
+		11:                         a.ProposeMany,
+		12:                         a.SetSpendingLimit,
+		13:                         a.SwapSignerAndReapprove,
+		14:                         a.ProposeWithMetadata,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -73,6 +79,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 // type ConstructorParams struct {
 // 	Signers               []addr.Address
 // 	NumApprovalsThreshold uint64
@@ -123,11 +134,16 @@ func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.E
 	if err != nil {
 		rt.Abortf(exitcode.ErrIllegalState, "failed to create empty map: %v", err)
 	}
+	pendingMetadata, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
+	if err != nil {
+		rt.Abortf(exitcode.ErrIllegalState, "failed to create empty map: %v", err)
+	}
 
 	var st State
 	st.Signers = resolvedSigners
 	st.NumApprovalsThreshold = params.NumApprovalsThreshold
 	st.PendingTxns = pending
+	st.PendingTxnMetadata = pendingMetadata
 	st.InitialBalance = abi.NewTokenAmount(0)
 	if params.UnlockDuration != 0 {
 		st.SetLocked(params.StartEpoch, params.UnlockDuration, rt.ValueReceived())
@@ -159,6 +175,58 @@ type ProposeReturn = multisig0.ProposeReturn
 
 func (a Actor) Propose(rt runtime.Runtime, params *ProposeParams) *ProposeReturn {
 	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	return a.propose(rt, params, nil)
+}
+
+type ProposeWithMetadataParams struct {
+	Proposal ProposeParams
+	// Opaque data describing the transaction, such as its purpose or an invoice reference.
+	Metadata []byte
+}
+
+// Proposes a transaction exactly as Propose, additionally recording metadata with the pending transaction
+// for the information of other signers. The metadata is available from the pending transactions
+// accessor until the transaction is executed or cancelled.
+func (a Actor) ProposeWithMetadata(rt runtime.Runtime, params *ProposeWithMetadataParams) *ProposeReturn {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+
+	if len(params.Metadata) > TxnMetadataSizeMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "metadata of %d bytes too large, max %d", len(params.Metadata), TxnMetadataSizeMax)
+	}
+	return a.propose(rt, &params.Proposal, params.Metadata)
+}
+
+type ProposeManyParams struct {
+	Proposals []ProposeParams
+}
+
+type ProposeManyReturn struct {
+	// Results has one entry per proposal, in the order the proposals were given.
+	Results []ProposeReturn
+}
+
+// Proposes a batch of transactions in a single message.
+// Each proposal is processed in order exactly as if it had been submitted to Propose, so a proposal
+// that meets the approval threshold is executed before the next is proposed.
+// Any proposal that would cause Propose to abort aborts the whole batch.
+func (a Actor) ProposeMany(rt runtime.Runtime, params *ProposeManyParams) *ProposeManyReturn {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+
+	if len(params.Proposals) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch empty")
+	}
+	if len(params.Proposals) > BatchSizeMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch of %d too large, max %d", len(params.Proposals), BatchSizeMax)
+	}
+
+	results := make([]ProposeReturn, 0, len(params.Proposals))
+	for i := range params.Proposals {
+		results = append(results, *a.propose(rt, &params.Proposals[i], nil))
+	}
+	return &ProposeManyReturn{Results: results}
+}
+
+func (a Actor) propose(rt runtime.Runtime, params *ProposeParams, metadata []byte) *ProposeReturn {
 	proposer := rt.Caller()
 
 	if params.Value.Sign() < 0 {
@@ -192,6 +260,17 @@ func (a Actor) Propose(rt runtime.Runtime, params *ProposeParams) *ProposeReturn
 
 		st.PendingTxns, err = ptx.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pending transactions")
+
+		if len(metadata) > 0 {
+			pmd, err := adt.AsMap(adt.AsStore(rt), st.PendingTxnMetadata, builtin.DefaultHamtBitwidth)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pending transaction metadata")
+
+			err = pmd.Put(txnID, &TxnMetadata{Data: metadata})
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put metadata for transaction %v", txnID)
+
+			st.PendingTxnMetadata, err = pmd.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pending transaction metadata")
+		}
 	})
 
 	applied, ret, code := a.approveTransaction(rt, txnID, txn)
@@ -226,6 +305,47 @@ type ApproveReturn = multisig0.ApproveReturn
 
 func (a Actor) Approve(rt runtime.Runtime, params *TxnIDParams) *ApproveReturn {
 	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	return a.approve(rt, params)
+}
+
+type ApproveManyParams struct {
+	Approvals []TxnIDParams
+}
+
+type ApproveManyReturn struct {
+	// Results has one entry per approval, in the order the approvals were given.
+	Results []ApproveReturn
+}
+
+// Approves a batch of pending transactions in a single message.
+// Each approval is processed in order exactly as if it had been submitted to Approve.
+// Any approval that would cause Approve to abort (e.g. an unknown transaction, a mismatched
+// proposal hash or a duplicate approval) aborts the whole batch.
+func (a Actor) ApproveMany(rt runtime.Runtime, params *ApproveManyParams) *ApproveManyReturn {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+
+	if len(params.Approvals) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch empty")
+	}
+	if len(params.Approvals) > BatchSizeMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch of %d too large, max %d", len(params.Approvals), BatchSizeMax)
+	}
+	seen := make(map[TxnID]struct{}, len(params.Approvals))
+	for _, approval := range params.Approvals {
+		if _, ok := seen[approval.ID]; ok {
+			rt.Abortf(exitcode.ErrIllegalArgument, "duplicate transaction %d in batch", approval.ID)
+		}
+		seen[approval.ID] = struct{}{}
+	}
+
+	results := make([]ApproveReturn, 0, len(params.Approvals))
+	for i := range params.Approvals {
+		results = append(results, *a.approve(rt, &params.Approvals[i]))
+	}
+	return &ApproveManyReturn{Results: results}
+}
+
+func (a Actor) approve(rt runtime.Runtime, params *TxnIDParams) *ApproveReturn {
 	approver := rt.Caller()
 
 	var st State
@@ -291,6 +411,9 @@ func (a Actor) Cancel(rt runtime.Runtime, params *TxnIDParams) *abi.EmptyValue {
 
 		st.PendingTxns, err = ptx.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pending transactions")
+
+		err = st.DeleteTxnMetadata(adt.AsStore(rt), params.ID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete metadata for transaction %v", params.ID)
 	})
 	return nil
 }
@@ -406,14 +529,7 @@ func (a Actor) SwapSigner(rt runtime.Runtime, params *SwapSignerParams) *abi.Emp
 			rt.Abortf(exitcode.ErrIllegalArgument, "%s already a signer", toResolved)
 		}
 
-		newSigners := make([]addr.Address, 0, len(st.Signers))
-		for _, s := range st.Signers {
-			if s != fromResolved {
-				newSigners = append(newSigners, s)
-			}
-		}
-		newSigners = append(newSigners, toResolved)
-		st.Signers = newSigners
+		st.Signers = replaceSigner(st.Signers, fromResolved, toResolved)
 
 		err := st.PurgeApprovals(store, fromResolved)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to purge approvals of removed signer")
@@ -422,6 +538,66 @@ func (a Actor) SwapSigner(rt runtime.Runtime, params *SwapSignerParams) *abi.Emp
 	return nil
 }
 
+// SwapApprovalPolicy determines the fate of the approvals of a signer removed by SwapSignerAndReapprove.
+type SwapApprovalPolicy uint64
+
+const (
+	// The removed signer's approvals are purged, as by SwapSigner.
+	SwapApprovalsPurge SwapApprovalPolicy = iota
+	// The removed signer's approvals are re-attributed to the new signer.
+	SwapApprovalsTransfer
+)
+
+type SwapSignerAndReapproveParams struct {
+	From           addr.Address
+	To             addr.Address
+	ApprovalPolicy SwapApprovalPolicy
+}
+
+// Replaces one signer with another in a single step, and either purges or transfers the approvals of the
+// removed signer on pending transactions according to an explicit policy.
+// Transferring approvals preserves the approval count of every pending transaction, so a rotation
+// never leaves a transaction short of a quorum it had already reached among the remaining signers.
+func (a Actor) SwapSignerAndReapprove(rt runtime.Runtime, params *SwapSignerAndReapproveParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	if params.ApprovalPolicy != SwapApprovalsPurge && params.ApprovalPolicy != SwapApprovalsTransfer {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid approval policy %d", params.ApprovalPolicy)
+	}
+
+	fromResolved, err := builtin.ResolveToIDAddr(rt, params.From)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve from address %v", params.From)
+
+	toResolved, err := builtin.ResolveToIDAddr(rt, params.To)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve to address %v", params.To)
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		if !st.IsSigner(fromResolved) {
+			rt.Abortf(exitcode.ErrForbidden, "from addr %s is not a signer", fromResolved)
+		}
+
+		if st.IsSigner(toResolved) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "%s already a signer", toResolved)
+		}
+
+		st.Signers = replaceSigner(st.Signers, fromResolved, toResolved)
+
+		switch params.ApprovalPolicy {
+		case SwapApprovalsPurge:
+			err := st.PurgeApprovals(store, fromResolved)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to purge approvals of removed signer")
+		case SwapApprovalsTransfer:
+			err := st.TransferApprovals(store, fromResolved, toResolved)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to transfer approvals of removed signer")
+		}
+	})
+
+	return nil
+}
+
 //type ChangeNumApprovalsThresholdParams struct {
 //	NewThreshold uint64
 //}
@@ -472,6 +648,69 @@ func (a Actor) LockBalance(rt runtime.Runtime, params *LockBalanceParams) *abi.E
 	return nil
 }
 
+type SetSpendingLimitParams struct {
+	// Maximum total value that may be sent under the limit in each window. Zero removes the limit.
+	Amount abi.TokenAmount
+	// Length of each window in epochs.
+	Window abi.ChainEpoch
+	// Destinations to which value may be sent under the limit. Empty permits any destination.
+	Destinations []addr.Address
+}
+
+func (a Actor) SetSpendingLimit(rt runtime.Runtime, params *SetSpendingLimitParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	if params.Amount.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "spending limit must be non-negative, was %v", params.Amount)
+	}
+	if params.Amount.IsZero() {
+		var st State
+		rt.StateTransaction(&st, func() {
+			st.SpendingLimit = nil
+		})
+		return nil
+	}
+
+	if params.Window <= 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "spending limit window must be positive, was %d", params.Window)
+	}
+	if len(params.Destinations) > SpendingLimitDestinationsMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot permit more than %d destinations", SpendingLimitDestinationsMax)
+	}
+
+	// resolve destination addresses and do not allow duplicates
+	destinations := make([]addr.Address, 0, len(params.Destinations))
+	deDupDestinations := make(map[addr.Address]struct{}, len(params.Destinations))
+	for _, d := range params.Destinations {
+		resolved, err := builtin.ResolveToIDAddr(rt, d)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve addr %v to ID addr", d)
+
+		if _, ok := deDupDestinations[resolved]; ok {
+			rt.Abortf(exitcode.ErrIllegalArgument, "duplicate destination not allowed: %s", d)
+		}
+		if resolved == rt.Receiver() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "multisig cannot be a spending limit destination")
+		}
+
+		destinations = append(destinations, resolved)
+		deDupDestinations[resolved] = struct{}{}
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		// Replacing the limit starts a fresh window.
+		st.SpendingLimit = &SpendingLimit{
+			Amount:       params.Amount,
+			Window:       params.Window,
+			Destinations: destinations,
+			WindowStart:  rt.CurrEpoch() - rt.CurrEpoch()%params.Window,
+			Spent:        big.Zero(),
+		}
+	})
+	return nil
+}
+
 func (a Actor) approveTransaction(rt runtime.Runtime, txnID TxnID, txn *Transaction) (bool, []byte, exitcode.ExitCode) {
 	caller := rt.Caller()
 
@@ -527,11 +766,19 @@ func executeTransactionIfApproved(rt runtime.Runtime, st State, txnID TxnID, txn
 	applied := false
 
 	thresholdMet := uint64(len(txn.Approved)) >= st.NumApprovalsThreshold
-	if thresholdMet {
+	withinLimit := !thresholdMet && withinSpendingLimit(rt, rt.Receiver(), &st, txn)
+	if thresholdMet || withinLimit {
 		if err := st.assertAvailable(rt.CurrentBalance(), txn.Value, rt.CurrEpoch()); err != nil {
 			rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds unlocked: %v", err)
 		}
 
+		if withinLimit {
+			// Charge the spending limit before sending so that a re-entrant call observes the spend.
+			rt.StateTransaction(&st, func() {
+				st.SpendingLimit.recordSpend(rt.CurrEpoch(), txn.Value)
+			})
+		}
+
 		// A sufficient number of approvals have arrived and sufficient funds have been unlocked: relay the message and delete from pending queue.
 		code = rt.Send(
 			txn.To,
@@ -555,6 +802,9 @@ func executeTransactionIfApproved(rt runtime.Runtime, st State, txnID TxnID, txn
 
 			st.PendingTxns, err = ptx.Root()
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pending transactions")
+
+			err = st.DeleteTxnMetadata(adt.AsStore(rt), txnID)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete metadata for transaction %v", txnID)
 		})
 	}
 
@@ -564,6 +814,23 @@ func executeTransactionIfApproved(rt runtime.Runtime, st State, txnID TxnID, txn
 	return applied, out, code
 }
 
+// Tests whether a transaction may execute with fewer than the threshold number of approvals
+// under the multisig's spending limit, if any.
+func withinSpendingLimit(sr runtime.StateReader, receiver addr.Address, st *State, txn *Transaction) bool {
+	limit := st.SpendingLimit
+	if limit == nil || txn.Method != builtin.MethodSend {
+		return false
+	}
+	to := txn.To
+	if resolved, ok := sr.ResolveAddress(txn.To); ok {
+		to = resolved
+	}
+	if to == receiver || !limit.PermitsDestination(to) {
+		return false
+	}
+	return txn.Value.LessThanEqual(limit.Available(sr.CurrEpoch()))
+}
+
 // Computes a digest of a proposed transaction. This digest is used to confirm identity of the transaction
 // associated with an ID, which might change under chain re-orgs.
 func ComputeProposalHash(txn *Transaction, hash func([]byte) [32]byte) ([]byte, error) {
@@ -583,3 +850,14 @@ func ComputeProposalHash(txn *Transaction, hash func([]byte) [32]byte) ([]byte,
 	hashResult := hash(data)
 	return hashResult[:], nil
 }
+
+// Returns a new signer set with one signer removed and another appended.
+func replaceSigner(signers []addr.Address, from, to addr.Address) []addr.Address {
+	newSigners := make([]addr.Address, 0, len(signers))
+	for _, s := range signers {
+		if s != from {
+			newSigners = append(newSigners, s)
+		}
+	}
+	return append(newSigners, to)
+}
@@ -1,6 +1,8 @@
 package multisig
 
 import (
+	"sort"
+
 	address "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -22,6 +24,34 @@ type State struct {
 	UnlockDuration abi.ChainEpoch
 
 	PendingTxns cid.Cid // HAMT[TxnID]Transaction
+
+	// Optional policy under which a single signer may send value without reaching the approval threshold.
+	SpendingLimit *SpendingLimit
+
+	PendingTxnMetadata cid.Cid // HAMT[TxnID]TxnMetadata
+}
+
+// Metadata attached to a transaction by its proposer, for the information of other signers.
+// Metadata is not interpreted by the actor and does not contribute to the proposal hash.
+type TxnMetadata struct {
+	Data []byte
+}
+
+// A policy permitting plain value transfers to execute with a single approval, up to a total
+// amount sent in each window of epochs.
+// Transactions that invoke a method, or that are sent to the multisig itself, always require
+// the full approval threshold.
+type SpendingLimit struct {
+	// Maximum total value that may be sent under the limit in each window.
+	Amount abi.TokenAmount
+	// Length of each window. Windows are aligned to multiples of this length from epoch zero.
+	Window abi.ChainEpoch
+	// ID-addresses to which value may be sent under the limit. Empty permits any destination.
+	Destinations []address.Address
+	// First epoch of the window in which Spent was accumulated.
+	WindowStart abi.ChainEpoch
+	// Total value sent under the limit in the window beginning at WindowStart.
+	Spent abi.TokenAmount
 }
 
 // Tests whether an address is in the list of signers.
@@ -64,6 +94,40 @@ func (st *State) AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
 	return locked
 }
 
+// Returns the value that may still be sent under the limit in the window containing an epoch.
+func (l *SpendingLimit) Available(currEpoch abi.ChainEpoch) abi.TokenAmount {
+	if l.windowStart(currEpoch) != l.WindowStart {
+		return l.Amount
+	}
+	return big.Max(big.Sub(l.Amount, l.Spent), big.Zero())
+}
+
+// Tests whether a destination may be sent to under the limit.
+func (l *SpendingLimit) PermitsDestination(to address.Address) bool {
+	if len(l.Destinations) == 0 {
+		return true
+	}
+	for _, d := range l.Destinations {
+		if d == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Accounts for value sent under the limit, starting a new window if the current one has elapsed.
+func (l *SpendingLimit) recordSpend(currEpoch abi.ChainEpoch, value abi.TokenAmount) {
+	if start := l.windowStart(currEpoch); start != l.WindowStart {
+		l.WindowStart = start
+		l.Spent = big.Zero()
+	}
+	l.Spent = big.Add(l.Spent, value)
+}
+
+func (l *SpendingLimit) windowStart(currEpoch abi.ChainEpoch) abi.ChainEpoch {
+	return currEpoch - currEpoch%l.Window
+}
+
 // Iterates all pending transactions and removes an address from each list of approvals, if present.
 // If an approval list becomes empty, the pending transaction is deleted.
 func (st *State) PurgeApprovals(store adt.Store, addr address.Address) error {
@@ -109,6 +173,13 @@ func (st *State) PurgeApprovals(store adt.Store, addr address.Address) error {
 			if err := txns.Delete(StringKey(txid)); err != nil {
 				return xerrors.Errorf("failed to delete transaction with no approvers: %w", err)
 			}
+			txnID, err := ParseTxnIDKey(txid)
+			if err != nil {
+				return xerrors.Errorf("failed to parse transaction key %v: %w", txid, err)
+			}
+			if err := st.DeleteTxnMetadata(store, txnID); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -120,6 +191,154 @@ func (st *State) PurgeApprovals(store adt.Store, addr address.Address) error {
 	return nil
 }
 
+// Re-attributes the approvals of one address to another in every pending transaction.
+// Where both addresses have approved a transaction, the approval of the first is dropped.
+// A transaction proposed by the first address is thereafter treated as proposed by the second,
+// which changes its proposal hash.
+func (st *State) TransferApprovals(store adt.Store, from, to address.Address) error {
+	txns, err := adt.AsMap(store, st.PendingTxns, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load transactions: %w", err)
+	}
+
+	// Identify the transactions that need updating.
+	var txnIdsToUpdate []string              // For stable iteration
+	txnsToUpdate := map[string]Transaction{} // Values are not pointers, we need copies
+	var txn Transaction
+	if err = txns.ForEach(&txn, func(txid string) error {
+		for _, approver := range txn.Approved {
+			if approver == from {
+				txnIdsToUpdate = append(txnIdsToUpdate, txid)
+				txnsToUpdate[txid] = txn
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("failed to traverse transactions: %w", err)
+	}
+
+	for _, txid := range txnIdsToUpdate {
+		txn := txnsToUpdate[txid]
+		alreadyApproved := false
+		for _, approver := range txn.Approved {
+			if approver == to {
+				alreadyApproved = true
+				break
+			}
+		}
+
+		newApprovers := make([]address.Address, 0, len(txn.Approved))
+		for _, approver := range txn.Approved {
+			if approver != from {
+				newApprovers = append(newApprovers, approver)
+			} else if !alreadyApproved {
+				newApprovers = append(newApprovers, to)
+			}
+		}
+
+		txn.Approved = newApprovers
+		if err := txns.Put(StringKey(txid), &txn); err != nil {
+			return xerrors.Errorf("failed to update transaction approvers: %w", err)
+		}
+	}
+
+	if newTxns, err := txns.Root(); err != nil {
+		return xerrors.Errorf("failed to persist transactions: %w", err)
+	} else {
+		st.PendingTxns = newTxns
+	}
+	return nil
+}
+
+// Removes the metadata of a transaction that is no longer pending, if it has any.
+func (st *State) DeleteTxnMetadata(store adt.Store, txnID TxnID) error {
+	metadata, err := adt.AsMap(store, st.PendingTxnMetadata, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load transaction metadata: %w", err)
+	}
+	if found, err := metadata.TryDelete(txnID); err != nil {
+		return xerrors.Errorf("failed to delete metadata for transaction %v: %w", txnID, err)
+	} else if !found {
+		return nil
+	}
+	if st.PendingTxnMetadata, err = metadata.Root(); err != nil {
+		return xerrors.Errorf("failed to persist transaction metadata: %w", err)
+	}
+	return nil
+}
+
+// A pending transaction together with details derived from it, for consumption by clients.
+type PendingTransaction struct {
+	ID          TxnID
+	Transaction Transaction
+	// Metadata attached by the proposer, or nil if none.
+	Metadata []byte
+	// Hash of the transaction's ProposalHashData, as expected by Approve and Cancel.
+	ProposalHash []byte
+	// Number of further approvals required before the transaction can execute.
+	ApprovalsRemaining uint64
+}
+
+// Returns up to limit pending transactions with IDs no less than start, in ascending ID order.
+// The returned flag is true if further transactions remain beyond the page, in which case
+// the next page starts at the ID following that of the last transaction returned.
+// The hash function must be BLAKE2b-256 for proposal hashes to match those checked by the actor.
+func (st *State) PendingTransactions(store adt.Store, hash func([]byte) [32]byte, start TxnID, limit uint64) ([]PendingTransaction, bool, error) {
+	txns, err := adt.AsMap(store, st.PendingTxns, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load transactions: %w", err)
+	}
+
+	var pending []PendingTransaction
+	var txn Transaction
+	if err = txns.ForEach(&txn, func(key string) error {
+		txnID, err := ParseTxnIDKey(key)
+		if err != nil {
+			return xerrors.Errorf("failed to parse transaction key %v: %w", key, err)
+		}
+		if txnID < start {
+			return nil
+		}
+		pending = append(pending, PendingTransaction{ID: txnID, Transaction: txn})
+		return nil
+	}); err != nil {
+		return nil, false, xerrors.Errorf("failed to traverse transactions: %w", err)
+	}
+
+	// HAMT iteration order is by key hash, so order by ID before paginating.
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ID < pending[j].ID
+	})
+	more := uint64(len(pending)) > limit
+	if more {
+		pending = pending[:limit]
+	}
+
+	metadata, err := adt.AsMap(store, st.PendingTxnMetadata, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load transaction metadata: %w", err)
+	}
+
+	for i := range pending {
+		p := &pending[i]
+		var md TxnMetadata
+		if found, err := metadata.Get(p.ID, &md); err != nil {
+			return nil, false, xerrors.Errorf("failed to load metadata for %v: %w", p.ID, err)
+		} else if found {
+			p.Metadata = md.Data
+		}
+		p.ProposalHash, err = ComputeProposalHash(&p.Transaction, hash)
+		if err != nil {
+			return nil, false, xerrors.Errorf("failed to compute proposal hash for %v: %w", p.ID, err)
+		}
+		if approved := uint64(len(p.Transaction.Approved)); approved < st.NumApprovalsThreshold {
+			p.ApprovalsRemaining = st.NumApprovalsThreshold - approved
+		}
+	}
+	return pending, more, nil
+}
+
 // return nil if MultiSig maintains required locked balance after spending the amount, else return an error.
 func (st *State) assertAvailable(currBalance abi.TokenAmount, amountToSpend abi.TokenAmount, currEpoch abi.ChainEpoch) error {
 	if amountToSpend.LessThan(big.Zero()) {
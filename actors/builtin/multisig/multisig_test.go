@@ -893,6 +893,400 @@ func TestApprove(t *testing.T) {
 	})
 }
 
+func TestProposeMany(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+	startEpoch := abi.ChainEpoch(0)
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob}
+
+	builder := mock.NewBuilder(receiver).WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID)
+
+	t.Run("propose many awaiting approval", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		ret := actor.proposeMany(rt, []multisig.ProposeParams{
+			{To: chuck, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams},
+			{To: darlene, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams},
+		})
+		require.Len(t, ret.Results, 2)
+		for i, r := range ret.Results {
+			assert.Equal(t, multisig.TxnID(i), r.TxnID)
+			assert.False(t, r.Applied)
+		}
+
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    sendValue,
+			Method:   builtin.MethodSend,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne},
+		}, multisig.Transaction{
+			To:       darlene,
+			Value:    sendValue,
+			Method:   builtin.MethodSend,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne},
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("propose many with threshold met executes each in order", func(t *testing.T) {
+		rt := builder.WithBalance(abi.NewTokenAmount(20), abi.NewTokenAmount(0)).Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, startEpoch, signers...)
+
+		rt.ExpectSend(chuck, builtin.MethodSend, fakeParams, sendValue, nil, exitcode.Ok)
+		rt.ExpectSend(darlene, builtin.MethodSend, fakeParams, sendValue, nil, exitcode.ErrIllegalState)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		ret := actor.proposeMany(rt, []multisig.ProposeParams{
+			{To: chuck, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams},
+			{To: darlene, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams},
+		})
+		require.Len(t, ret.Results, 2)
+		assert.True(t, ret.Results[0].Applied)
+		assert.Equal(t, exitcode.Ok, ret.Results[0].Code)
+		assert.True(t, ret.Results[1].Applied)
+		assert.Equal(t, exitcode.ErrIllegalState, ret.Results[1].Code)
+
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("fail propose many with empty batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch empty", func() {
+			actor.proposeMany(rt, nil)
+		})
+		rt.Reset()
+		actor.checkState(rt)
+	})
+
+	t.Run("fail propose many with batch too large", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, signers...)
+
+		proposals := make([]multisig.ProposeParams, multisig.BatchSizeMax+1)
+		for i := range proposals {
+			proposals[i] = multisig.ProposeParams{To: chuck, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams}
+		}
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "too large", func() {
+			actor.proposeMany(rt, proposals)
+		})
+		rt.Reset()
+		actor.assertTransactions(rt)
+	})
+
+	t.Run("fail propose many from non-signer", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(chuck, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.proposeMany(rt, []multisig.ProposeParams{
+				{To: darlene, Value: sendValue, Method: builtin.MethodSend, Params: fakeParams},
+			})
+		})
+		rt.Reset()
+		actor.assertTransactions(rt)
+	})
+}
+
+func TestApproveMany(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+	startEpoch := abi.ChainEpoch(0)
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const fakeMethod = abi.MethodNum(42)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob, chuck}
+
+	builder := mock.NewBuilder(receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256)
+
+	t.Run("approve many executes every transaction meeting threshold", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		hash0 := actor.proposeOK(rt, darlene, sendValue, fakeMethod, fakeParams, nil)
+		hash1 := actor.proposeOK(rt, chuck, sendValue, fakeMethod, fakeParams, nil)
+
+		rt.SetBalance(big.Mul(big.NewInt(2), sendValue))
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(darlene, fakeMethod, fakeParams, sendValue, nil, exitcode.Ok)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, exitcode.Ok)
+		ret := actor.approveMany(rt, []multisig.TxnIDParams{
+			{ID: 0, ProposalHash: hash0},
+			{ID: 1, ProposalHash: hash1},
+		})
+		require.Len(t, ret.Results, 2)
+		for _, r := range ret.Results {
+			assert.True(t, r.Applied)
+			assert.Equal(t, exitcode.Ok, r.Code)
+		}
+
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("approve many records approvals below threshold", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, darlene, sendValue, fakeMethod, fakeParams, nil)
+		actor.proposeOK(rt, darlene, sendValue, fakeMethod, nil, nil)
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		ret := actor.approveMany(rt, []multisig.TxnIDParams{{ID: 1}, {ID: 0}})
+		require.Len(t, ret.Results, 2)
+		assert.False(t, ret.Results[0].Applied)
+		assert.False(t, ret.Results[1].Applied)
+
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       darlene,
+			Value:    sendValue,
+			Method:   fakeMethod,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne, bob},
+		}, multisig.Transaction{
+			To:       darlene,
+			Value:    sendValue,
+			Method:   fakeMethod,
+			Params:   nil,
+			Approved: []addr.Address{anne, bob},
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fail approve many with duplicate transaction", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, darlene, sendValue, fakeMethod, fakeParams, nil)
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "duplicate transaction", func() {
+			actor.approveMany(rt, []multisig.TxnIDParams{{ID: 0}, {ID: 0}})
+		})
+		rt.Reset()
+		actor.checkState(rt)
+	})
+
+	t.Run("fail approve many when any transaction is missing", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, darlene, sendValue, fakeMethod, fakeParams, nil)
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			actor.approveMany(rt, []multisig.TxnIDParams{{ID: 0}, {ID: 7}})
+		})
+		rt.Reset()
+		actor.checkState(rt)
+	})
+
+	t.Run("fail approve many with empty batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, signers...)
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch empty", func() {
+			actor.approveMany(rt, nil)
+		})
+		rt.Reset()
+	})
+}
+
+func TestPendingTransactions(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+
+	const numApprovals = uint64(3)
+	const fakeMethod = abi.MethodNum(42)
+	var signers = []addr.Address{anne, bob, chuck}
+
+	builder := mock.NewBuilder(receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256)
+
+	t.Run("lists pending transactions in pages", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		var hashes [][]byte
+		for i := 0; i < 5; i++ {
+			hashes = append(hashes, actor.proposeOK(rt, chuck, abi.NewTokenAmount(int64(i+1)), fakeMethod, nil, nil))
+		}
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, 1, hashes[1], nil)
+
+		var st multisig.State
+		rt.GetState(&st)
+
+		page, more, err := st.PendingTransactions(rt.AdtStore(), blake2b.Sum256, 0, 2)
+		require.NoError(t, err)
+		assert.True(t, more)
+		require.Len(t, page, 2)
+		assert.Equal(t, multisig.TxnID(0), page[0].ID)
+		assert.Equal(t, hashes[0], page[0].ProposalHash)
+		assert.Equal(t, uint64(2), page[0].ApprovalsRemaining)
+		assert.Equal(t, multisig.TxnID(1), page[1].ID)
+		assert.Equal(t, hashes[1], page[1].ProposalHash)
+		assert.Equal(t, []addr.Address{anne, bob}, page[1].Transaction.Approved)
+		assert.Equal(t, uint64(1), page[1].ApprovalsRemaining)
+
+		page, more, err = st.PendingTransactions(rt.AdtStore(), blake2b.Sum256, page[1].ID+1, 10)
+		require.NoError(t, err)
+		assert.False(t, more)
+		require.Len(t, page, 3)
+		for i, p := range page {
+			assert.Equal(t, multisig.TxnID(i+2), p.ID)
+			assert.Equal(t, hashes[i+2], p.ProposalHash)
+			assert.Equal(t, abi.NewTokenAmount(int64(i+3)), p.Transaction.Value)
+		}
+
+		page, more, err = st.PendingTransactions(rt.AdtStore(), blake2b.Sum256, 5, 10)
+		require.NoError(t, err)
+		assert.False(t, more)
+		assert.Empty(t, page)
+		actor.checkState(rt)
+	})
+}
+
+func TestProposeWithMetadata(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+
+	const numApprovals = uint64(2)
+	const fakeMethod = abi.MethodNum(42)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob}
+	var memo = []byte("invoice 1234")
+
+	builder := mock.NewBuilder(receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256)
+
+	pendingMetadata := func(rt *mock.Runtime) [][]byte {
+		var st multisig.State
+		rt.GetState(&st)
+		page, _, err := st.PendingTransactions(rt.AdtStore(), blake2b.Sum256, 0, 10)
+		require.NoError(t, err)
+		var metadata [][]byte
+		for _, p := range page {
+			metadata = append(metadata, p.Metadata)
+		}
+		return metadata
+	}
+
+	t.Run("metadata is surfaced with the pending transaction", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		ret := actor.proposeWithMetadata(rt, chuck, sendValue, fakeMethod, fakeParams, memo)
+		assert.Equal(t, multisig.TxnID(0), ret.TxnID)
+		assert.False(t, ret.Applied)
+		actor.proposeOK(rt, chuck, sendValue, fakeMethod, fakeParams, nil)
+
+		// Metadata does not form part of the transaction.
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    sendValue,
+			Method:   fakeMethod,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne},
+		}, multisig.Transaction{
+			To:       chuck,
+			Value:    sendValue,
+			Method:   fakeMethod,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne},
+		})
+		assert.Equal(t, [][]byte{memo, nil}, pendingMetadata(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("metadata is removed when the transaction executes", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithMetadata(rt, chuck, sendValue, fakeMethod, fakeParams, memo)
+
+		rt.SetBalance(sendValue)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, exitcode.Ok)
+		actor.approveOK(rt, 0, nil, nil)
+
+		actor.assertTransactions(rt)
+		assert.Empty(t, pendingMetadata(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("metadata is removed when the transaction is cancelled", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithMetadata(rt, chuck, sendValue, fakeMethod, fakeParams, memo)
+		actor.cancel(rt, 0, nil)
+
+		actor.assertTransactions(rt)
+		assert.Empty(t, pendingMetadata(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("fail if metadata is too large", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.proposeWithMetadata(rt, chuck, sendValue, fakeMethod, fakeParams, make([]byte, multisig.TxnMetadataSizeMax+1))
+		})
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+}
+
 func TestCancel(t *testing.T) {
 	actor := msActorHarness{multisig.Actor{}, t}
 	startEpoch := abi.ChainEpoch(0)
@@ -1638,6 +2032,140 @@ func TestSwapSigners(t *testing.T) {
 	})
 }
 
+func TestSwapSignerAndReapprove(t *testing.T) {
+	startEpoch := abi.ChainEpoch(0)
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+
+	actor := msActorHarness{multisig.Actor{}, t}
+	builder := mock.NewBuilder(receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256)
+
+	t.Run("transfer policy re-attributes approvals to the new signer", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, anne, bob, chuck)
+
+		// Anne proposes and Bob approves.
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, big.Zero(), builtin.MethodSend, nil, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, 0, nil, nil)
+
+		// Bob is rotated out for Darlene, who inherits Bob's approval.
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.swapSignerAndReapprove(rt, bob, darlene, multisig.SwapApprovalsTransfer)
+
+		var st multisig.State
+		rt.GetState(&st)
+		assert.Equal(t, []addr.Address{anne, chuck, darlene}, st.Signers)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    big.Zero(),
+			Method:   builtin.MethodSend,
+			Params:   nil,
+			Approved: []addr.Address{anne, darlene},
+		})
+
+		// Darlene may not approve again.
+		rt.SetCaller(darlene, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.approve(rt, 0, nil, nil)
+		})
+		rt.Reset()
+
+		// Chuck's approval reaches the threshold without any re-approval.
+		rt.SetCaller(chuck, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, big.Zero(), nil, exitcode.Ok)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("transfer policy makes the new signer the proposer", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, startEpoch, anne, bob)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, big.Zero(), builtin.MethodSend, nil, nil)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.swapSignerAndReapprove(rt, anne, darlene, multisig.SwapApprovalsTransfer)
+
+		// The solo proposal survives and its new proposer may cancel it.
+		rt.SetCaller(darlene, builtin.AccountActorCodeID)
+		actor.cancel(rt, 0, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("purge policy removes approvals", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, startEpoch, anne, bob, chuck)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, big.Zero(), builtin.MethodSend, nil, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.proposeOK(rt, chuck, big.Zero(), builtin.MethodSend, nil, nil)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.swapSignerAndReapprove(rt, bob, darlene, multisig.SwapApprovalsPurge)
+
+		// Bob's solo proposal is deleted and his approval of Anne's proposal is removed.
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    big.Zero(),
+			Method:   builtin.MethodSend,
+			Params:   nil,
+			Approved: []addr.Address{anne},
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails with invalid policy", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, startEpoch, anne, bob)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.swapSignerAndReapprove(rt, bob, darlene, multisig.SwapApprovalPolicy(2))
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when from is not a signer or to is already a signer", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, startEpoch, anne, bob)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.swapSignerAndReapprove(rt, chuck, darlene, multisig.SwapApprovalsTransfer)
+		})
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.swapSignerAndReapprove(rt, anne, bob, multisig.SwapApprovalsTransfer)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when not called by the multisig", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, startEpoch, anne, bob)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.swapSignerAndReapprove(rt, bob, darlene, multisig.SwapApprovalsTransfer)
+		})
+		actor.checkState(rt)
+	})
+}
+
 type thresholdTestCase struct {
 	desc             string
 	initialThreshold uint64
@@ -1921,6 +2449,160 @@ func TestLockBalance(t *testing.T) {
 // Helper methods for calling multisig actor methods
 //
 
+func TestSpendingLimit(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const numApprovals = uint64(2)
+	const window = abi.ChainEpoch(100)
+	const fakeMethod = abi.MethodNum(42)
+	limit := abi.NewTokenAmount(100)
+
+	builder := mock.NewBuilder(receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithEpoch(0).
+		WithBalance(abi.NewTokenAmount(1000), big.Zero()).
+		WithHasher(blake2b.Sum256)
+
+	t.Run("single signer sends up to limit in each window", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetEpoch(150)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSpendingLimit(rt, limit, window, nil)
+
+		// Executes immediately under the limit.
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(60), nil, exitcode.Ok)
+		actor.proposeOK(rt, chuck, abi.NewTokenAmount(60), builtin.MethodSend, nil, nil)
+		actor.assertTransactions(rt)
+
+		// Exceeds the remainder of the window so requires full approval.
+		actor.proposeOK(rt, chuck, abi.NewTokenAmount(50), builtin.MethodSend, nil, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    abi.NewTokenAmount(50),
+			Method:   builtin.MethodSend,
+			Approved: []addr.Address{anne},
+		})
+
+		// In the next window the pending transaction fits the limit and a single approval executes it.
+		rt.SetEpoch(200)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(50), nil, exitcode.Ok)
+		actor.approveOK(rt, 1, nil, nil)
+		actor.assertTransactions(rt)
+
+		var st multisig.State
+		rt.GetState(&st)
+		require.NotNil(t, st.SpendingLimit)
+		assert.Equal(t, abi.ChainEpoch(200), st.SpendingLimit.WindowStart)
+		assert.Equal(t, abi.NewTokenAmount(50), st.SpendingLimit.Spent)
+		assert.Equal(t, abi.NewTokenAmount(50), st.SpendingLimit.Available(rt.Epoch()))
+		actor.checkState(rt)
+	})
+
+	t.Run("limit does not apply to method calls or the multisig itself", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSpendingLimit(rt, limit, window, nil)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, abi.NewTokenAmount(1), fakeMethod, nil, nil)
+		actor.proposeOK(rt, receiver, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+
+		// Neither transaction executed.
+		var st multisig.State
+		rt.GetState(&st)
+		pending, _, err := st.PendingTransactions(rt.AdtStore(), blake2b.Sum256, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, chuck, pending[0].Transaction.To)
+		assert.Equal(t, receiver, pending[1].Transaction.To)
+		assert.True(t, st.SpendingLimit.Spent.IsZero())
+		actor.checkState(rt)
+	})
+
+	t.Run("limit restricted to destinations", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSpendingLimit(rt, limit, window, []addr.Address{chuck})
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(10), nil, exitcode.Ok)
+		actor.proposeOK(rt, chuck, abi.NewTokenAmount(10), builtin.MethodSend, nil, nil)
+		actor.proposeOK(rt, darlene, abi.NewTokenAmount(10), builtin.MethodSend, nil, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       darlene,
+			Value:    abi.NewTokenAmount(10),
+			Method:   builtin.MethodSend,
+			Approved: []addr.Address{anne},
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("zero amount removes limit", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSpendingLimit(rt, limit, window, nil)
+		actor.setSpendingLimit(rt, big.Zero(), 0, nil)
+
+		var st multisig.State
+		rt.GetState(&st)
+		assert.Nil(t, st.SpendingLimit)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       chuck,
+			Value:    abi.NewTokenAmount(1),
+			Method:   builtin.MethodSend,
+			Approved: []addr.Address{anne},
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fail to set limit from signer", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.setSpendingLimit(rt, limit, window, nil)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fail to set invalid limit", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, 0, 0, anne, bob)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be non-negative", func() {
+			actor.setSpendingLimit(rt, big.NewInt(-1), window, nil)
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "window must be positive", func() {
+			actor.setSpendingLimit(rt, limit, 0, nil)
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "duplicate destination", func() {
+			actor.setSpendingLimit(rt, limit, window, []addr.Address{chuck, chuck})
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "cannot be a spending limit destination", func() {
+			actor.setSpendingLimit(rt, limit, window, []addr.Address{receiver})
+		})
+		actor.checkState(rt)
+	})
+}
+
 type msActorHarness struct {
 	a multisig.Actor
 	t testing.TB
@@ -2010,6 +2692,50 @@ func (h *msActorHarness) approveOK(rt *mock.Runtime, txnID int64, proposalParams
 	}
 }
 
+func (h *msActorHarness) proposeMany(rt *mock.Runtime, proposals []multisig.ProposeParams) *multisig.ProposeManyReturn {
+	rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+	ret := rt.Call(h.a.ProposeMany, &multisig.ProposeManyParams{Proposals: proposals})
+	rt.Verify()
+
+	proposeManyReturn, ok := ret.(*multisig.ProposeManyReturn)
+	if !ok {
+		h.t.Fatalf("unexpected type returned from call to ProposeMany")
+	}
+	return proposeManyReturn
+}
+
+func (h *msActorHarness) proposeWithMetadata(rt *mock.Runtime, to addr.Address, value abi.TokenAmount, method abi.MethodNum, params []byte, metadata []byte) *multisig.ProposeReturn {
+	rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+	ret := rt.Call(h.a.ProposeWithMetadata, &multisig.ProposeWithMetadataParams{
+		Proposal: multisig.ProposeParams{
+			To:     to,
+			Value:  value,
+			Method: method,
+			Params: params,
+		},
+		Metadata: metadata,
+	})
+	rt.Verify()
+
+	proposeReturn, ok := ret.(*multisig.ProposeReturn)
+	if !ok {
+		h.t.Fatalf("unexpected type returned from call to ProposeWithMetadata")
+	}
+	return proposeReturn
+}
+
+func (h *msActorHarness) approveMany(rt *mock.Runtime, approvals []multisig.TxnIDParams) *multisig.ApproveManyReturn {
+	rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+	ret := rt.Call(h.a.ApproveMany, &multisig.ApproveManyParams{Approvals: approvals})
+	rt.Verify()
+
+	approveManyReturn, ok := ret.(*multisig.ApproveManyReturn)
+	if !ok {
+		h.t.Fatalf("unexpected type returned from call to ApproveMany")
+	}
+	return approveManyReturn
+}
+
 func (h *msActorHarness) cancel(rt *mock.Runtime, txnID int64, proposalParams []byte) {
 	rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
 	rt.Call(h.a.Cancel, &multisig.TxnIDParams{
@@ -2046,6 +2772,16 @@ func (h *msActorHarness) swapSigners(rt *mock.Runtime, oldSigner, newSigner addr
 	rt.Verify()
 }
 
+func (h *msActorHarness) swapSignerAndReapprove(rt *mock.Runtime, oldSigner, newSigner addr.Address, policy multisig.SwapApprovalPolicy) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(h.a.SwapSignerAndReapprove, &multisig.SwapSignerAndReapproveParams{
+		From:           oldSigner,
+		To:             newSigner,
+		ApprovalPolicy: policy,
+	})
+	rt.Verify()
+}
+
 func (h *msActorHarness) changeNumApprovalsThreshold(rt *mock.Runtime, newThreshold uint64) {
 	rt.ExpectValidateCallerAddr(rt.Receiver())
 	rt.Call(h.a.ChangeNumApprovalsThreshold, &multisig.ChangeNumApprovalsThresholdParams{
@@ -2064,6 +2800,17 @@ func (h *msActorHarness) lockBalance(rt *mock.Runtime, start, duration abi.Chain
 	rt.Verify()
 }
 
+func (h *msActorHarness) setSpendingLimit(rt *mock.Runtime, amount abi.TokenAmount, window abi.ChainEpoch, destinations []addr.Address) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	ret := rt.Call(h.a.SetSpendingLimit, &multisig.SetSpendingLimitParams{
+		Amount:       amount,
+		Window:       window,
+		Destinations: destinations,
+	})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
 func (h *msActorHarness) assertTransactions(rt *mock.Runtime, expected ...multisig.Transaction) {
 	var st multisig.State
 	rt.GetState(&st)
@@ -3,3 +3,15 @@ package multisig
 // SignersMax is the maximum number of signers allowed in a multisig. If more
 // are required, please use a combining tree of multisigs.
 const SignersMax = 256
+
+// BatchSizeMax is the maximum number of transactions that may be proposed or
+// approved in a single ProposeMany or ApproveMany message.
+const BatchSizeMax = 256
+
+// SpendingLimitDestinationsMax is the maximum number of destinations that may
+// be permitted by a multisig's spending limit.
+const SpendingLimitDestinationsMax = 32
+
+// TxnMetadataSizeMax is the maximum size in bytes of the metadata that may be
+// attached to a proposed transaction.
+const TxnMetadataSizeMax = 256
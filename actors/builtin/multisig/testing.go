@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
@@ -28,6 +29,20 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 		acc.Require(st.InitialBalance.IsZero(), "non-zero locked balance %v with zero unlock duration", st.InitialBalance)
 	}
 
+	if limit := st.SpendingLimit; limit != nil {
+		acc.Require(limit.Amount.GreaterThan(big.Zero()), "non-positive spending limit %v", limit.Amount)
+		acc.Require(limit.Window > 0, "non-positive spending limit window %d", limit.Window)
+		acc.Require(len(limit.Destinations) <= SpendingLimitDestinationsMax, "spending limit has too many destinations: %d", len(limit.Destinations))
+		acc.Require(limit.Spent.GreaterThanEqual(big.Zero()), "negative spending limit spend %v", limit.Spent)
+		acc.Require(limit.Spent.LessThanEqual(limit.Amount), "spending limit spend %v exceeds limit %v", limit.Spent, limit.Amount)
+		if limit.Window > 0 {
+			acc.Require(limit.WindowStart%limit.Window == 0, "spending limit window start %d not aligned to window %d", limit.WindowStart, limit.Window)
+		}
+		for _, d := range limit.Destinations {
+			acc.Require(d.Protocol() == address.ID, "spending limit destination %v is not an ID address", d)
+		}
+	}
+
 	// create lookup to test transaction approvals are multisig signers.
 	signers := make(map[address.Address]struct{})
 	for _, a := range st.Signers {
@@ -37,6 +52,7 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	// test pending transactions
 	maxTxnID := TxnID(-1)
 	numPending := uint64(0)
+	pendingIDs := make(map[TxnID]struct{})
 	if transactions, err := adt.AsMap(store, st.PendingTxns, builtin.DefaultHamtBitwidth); err != nil {
 		acc.Addf("error loading transactions: %v", err)
 	} else {
@@ -61,6 +77,7 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 				seenApprovals[approval] = struct{}{}
 			}
 
+			pendingIDs[txnID] = struct{}{}
 			numPending++
 			return nil
 		})
@@ -68,6 +85,25 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	}
 
 	acc.Require(st.NextTxnID > maxTxnID, "next transaction id %d is not greater than pending ids", st.NextTxnID)
+
+	// test pending transaction metadata
+	if metadata, err := adt.AsMap(store, st.PendingTxnMetadata, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading transaction metadata: %v", err)
+	} else {
+		var md TxnMetadata
+		err = metadata.ForEach(&md, func(txnIDStr string) error {
+			txnID, err := ParseTxnIDKey(txnIDStr)
+			if err != nil {
+				return err
+			}
+			_, pending := pendingIDs[txnID]
+			acc.Require(pending, "metadata for transaction %d that is not pending", txnID)
+			acc.Require(len(md.Data) > 0, "empty metadata for transaction %d", txnID)
+			acc.Require(len(md.Data) <= TxnMetadataSizeMax, "metadata for transaction %d exceeds max size: %d", txnID, len(md.Data))
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating transaction metadata")
+	}
 	return &StateSummary{
 		PendingTxnCount:       numPending,
 		NumApprovalsThreshold: st.NumApprovalsThreshold,
@@ -7,15 +7,14 @@ import (
 	"io"
 
 	abi "github.com/filecoin-project/go-state-types/abi"
-	crypto "github.com/filecoin-project/go-state-types/crypto"
-	paych "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	exitcode "github.com/filecoin-project/go-state-types/exitcode"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
 )
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{134}
+var lengthBufState = []byte{137}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -71,6 +70,36 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.LaneStates: %w", err)
 	}
 
+	// t.RetiredLanes (bitfield.BitField) (struct)
+	if err := t.RetiredLanes.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.SettleDelay (abi.ChainEpoch) (int64)
+	if t.SettleDelay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SettleDelay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.SettleDelay-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.AdditionalPayees ([]paych.PayeeState) (slice)
+	if len(t.AdditionalPayees) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.AdditionalPayees was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AdditionalPayees))); err != nil {
+		return err
+	}
+	for _, v := range t.AdditionalPayees {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -88,7 +117,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 6 {
+	if extra != 9 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -181,6 +210,69 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		t.LaneStates = c
 
 	}
+	// t.RetiredLanes (bitfield.BitField) (struct)
+
+	{
+
+		if err := t.RetiredLanes.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RetiredLanes: %w", err)
+		}
+
+	}
+	// t.SettleDelay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.SettleDelay = abi.ChainEpoch(extraI)
+	}
+	// t.AdditionalPayees ([]paych.PayeeState) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.AdditionalPayees: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.AdditionalPayees = make([]PayeeState, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v PayeeState
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.AdditionalPayees[i] = v
+	}
+
 	return nil
 }
 
@@ -339,115 +431,108 @@ func (t *UpdateChannelStateParams) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufSignedVoucher = []byte{139}
+var lengthBufCompactLanesParams = []byte{130}
 
-func (t *SignedVoucher) MarshalCBOR(w io.Writer) error {
+func (t *CompactLanesParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufSignedVoucher); err != nil {
+	if _, err := w.Write(lengthBufCompactLanesParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.ChannelAddr (address.Address) (struct)
-	if err := t.ChannelAddr.MarshalCBOR(w); err != nil {
+	// t.Lanes (bitfield.BitField) (struct)
+	if err := t.Lanes.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.TimeLockMin (abi.ChainEpoch) (int64)
-	if t.TimeLockMin >= 0 {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.TimeLockMin)); err != nil {
-			return err
-		}
-	} else {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.TimeLockMin-1)); err != nil {
-			return err
-		}
-	}
+	// t.Into (uint64) (uint64)
 
-	// t.TimeLockMax (abi.ChainEpoch) (int64)
-	if t.TimeLockMax >= 0 {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.TimeLockMax)); err != nil {
-			return err
-		}
-	} else {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.TimeLockMax-1)); err != nil {
-			return err
-		}
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Into)); err != nil {
+		return err
 	}
 
-	// t.SecretHash ([]uint8) (slice)
-	if len(t.SecretHash) > cbg.ByteArrayMaxLen {
-		return xerrors.Errorf("Byte array in field t.SecretHash was too long")
-	}
+	return nil
+}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.SecretHash))); err != nil {
-		return err
-	}
+func (t *CompactLanesParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CompactLanesParams{}
 
-	if _, err := w.Write(t.SecretHash[:]); err != nil {
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
 		return err
 	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
 
-	// t.Extra (paych.ModVerifyParams) (struct)
-	if err := t.Extra.MarshalCBOR(w); err != nil {
-		return err
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Lane (uint64) (uint64)
+	// t.Lanes (bitfield.BitField) (struct)
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Lane)); err != nil {
-		return err
-	}
+	{
 
-	// t.Nonce (uint64) (uint64)
+		if err := t.Lanes.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Lanes: %w", err)
+		}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Nonce)); err != nil {
-		return err
 	}
+	// t.Into (uint64) (uint64)
 
-	// t.Amount (big.Int) (struct)
-	if err := t.Amount.MarshalCBOR(w); err != nil {
-		return err
-	}
+	{
 
-	// t.MinSettleHeight (abi.ChainEpoch) (int64)
-	if t.MinSettleHeight >= 0 {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.MinSettleHeight)); err != nil {
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
 			return err
 		}
-	} else {
-		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.MinSettleHeight-1)); err != nil {
-			return err
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
 		}
+		t.Into = uint64(extra)
+
 	}
+	return nil
+}
 
-	// t.Merges ([]paych.Merge) (slice)
-	if len(t.Merges) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Merges was too long")
+var lengthBufUpdateChannelStateManyParams = []byte{129}
+
+func (t *UpdateChannelStateManyParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
 	}
+	if _, err := w.Write(lengthBufUpdateChannelStateManyParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Merges))); err != nil {
+	// t.Updates ([]paych.UpdateChannelStateParams) (slice)
+	if len(t.Updates) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Updates was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Updates))); err != nil {
 		return err
 	}
-	for _, v := range t.Merges {
+	for _, v := range t.Updates {
 		if err := v.MarshalCBOR(w); err != nil {
 			return err
 		}
 	}
-
-	// t.Signature (crypto.Signature) (struct)
-	if err := t.Signature.MarshalCBOR(w); err != nil {
-		return err
-	}
 	return nil
 }
 
-func (t *SignedVoucher) UnmarshalCBOR(r io.Reader) error {
-	*t = SignedVoucher{}
+func (t *UpdateChannelStateManyParams) UnmarshalCBOR(r io.Reader) error {
+	*t = UpdateChannelStateManyParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -460,147 +545,167 @@ func (t *SignedVoucher) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 11 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.ChannelAddr (address.Address) (struct)
+	// t.Updates ([]paych.UpdateChannelStateParams) (slice)
 
-	{
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
 
-		if err := t.ChannelAddr.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.ChannelAddr: %w", err)
-		}
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Updates: array too large (%d)", extra)
+	}
 
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
 	}
-	// t.TimeLockMin (abi.ChainEpoch) (int64)
-	{
-		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
-		var extraI int64
-		if err != nil {
+
+	if extra > 0 {
+		t.Updates = make([]UpdateChannelStateParams, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v UpdateChannelStateParams
+		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
-		switch maj {
-		case cbg.MajUnsignedInt:
-			extraI = int64(extra)
-			if extraI < 0 {
-				return fmt.Errorf("int64 positive overflow")
-			}
-		case cbg.MajNegativeInt:
-			extraI = int64(extra)
-			if extraI < 0 {
-				return fmt.Errorf("int64 negative oveflow")
-			}
-			extraI = -1 - extraI
-		default:
-			return fmt.Errorf("wrong type for int64 field: %d", maj)
-		}
 
-		t.TimeLockMin = abi.ChainEpoch(extraI)
+		t.Updates[i] = v
 	}
-	// t.TimeLockMax (abi.ChainEpoch) (int64)
-	{
-		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
-		var extraI int64
-		if err != nil {
+
+	return nil
+}
+
+var lengthBufUpdateChannelStateManyReturn = []byte{129}
+
+func (t *UpdateChannelStateManyReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUpdateChannelStateManyReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Results ([]paych.UpdateChannelStateResult) (slice)
+	if len(t.Results) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Results was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Results))); err != nil {
+		return err
+	}
+	for _, v := range t.Results {
+		if err := v.MarshalCBOR(w); err != nil {
 			return err
 		}
-		switch maj {
-		case cbg.MajUnsignedInt:
-			extraI = int64(extra)
-			if extraI < 0 {
-				return fmt.Errorf("int64 positive overflow")
-			}
-		case cbg.MajNegativeInt:
-			extraI = int64(extra)
-			if extraI < 0 {
-				return fmt.Errorf("int64 negative oveflow")
-			}
-			extraI = -1 - extraI
-		default:
-			return fmt.Errorf("wrong type for int64 field: %d", maj)
-		}
+	}
+	return nil
+}
 
-		t.TimeLockMax = abi.ChainEpoch(extraI)
+func (t *UpdateChannelStateManyReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = UpdateChannelStateManyReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
 	}
-	// t.SecretHash ([]uint8) (slice)
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Results ([]paych.UpdateChannelStateResult) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
 
-	if extra > cbg.ByteArrayMaxLen {
-		return fmt.Errorf("t.SecretHash: byte array too large (%d)", extra)
-	}
-	if maj != cbg.MajByteString {
-		return fmt.Errorf("expected byte array")
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Results: array too large (%d)", extra)
 	}
 
-	if extra > 0 {
-		t.SecretHash = make([]uint8, extra)
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
 	}
 
-	if _, err := io.ReadFull(br, t.SecretHash[:]); err != nil {
-		return err
+	if extra > 0 {
+		t.Results = make([]UpdateChannelStateResult, extra)
 	}
-	// t.Extra (paych.ModVerifyParams) (struct)
 
-	{
+	for i := 0; i < int(extra); i++ {
 
-		b, err := br.ReadByte()
-		if err != nil {
+		var v UpdateChannelStateResult
+		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
-		if b != cbg.CborNull[0] {
-			if err := br.UnreadByte(); err != nil {
-				return err
-			}
-			t.Extra = new(paych.ModVerifyParams)
-			if err := t.Extra.UnmarshalCBOR(br); err != nil {
-				return xerrors.Errorf("unmarshaling t.Extra pointer: %w", err)
-			}
-		}
 
+		t.Results[i] = v
 	}
-	// t.Lane (uint64) (uint64)
 
-	{
+	return nil
+}
 
-		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-		if err != nil {
-			return err
-		}
-		if maj != cbg.MajUnsignedInt {
-			return fmt.Errorf("wrong type for uint64 field")
-		}
-		t.Lane = uint64(extra)
+var lengthBufUpdateChannelStateResult = []byte{129}
 
+func (t *UpdateChannelStateResult) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUpdateChannelStateResult); err != nil {
+		return err
 	}
-	// t.Nonce (uint64) (uint64)
 
-	{
+	scratch := make([]byte, 9)
 
-		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-		if err != nil {
+	// t.Code (exitcode.ExitCode) (int64)
+	if t.Code >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Code)); err != nil {
 			return err
 		}
-		if maj != cbg.MajUnsignedInt {
-			return fmt.Errorf("wrong type for uint64 field")
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Code-1)); err != nil {
+			return err
 		}
-		t.Nonce = uint64(extra)
-
 	}
-	// t.Amount (big.Int) (struct)
 
-	{
+	return nil
+}
 
-		if err := t.Amount.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
-		}
+func (t *UpdateChannelStateResult) UnmarshalCBOR(r io.Reader) error {
+	*t = UpdateChannelStateResult{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
 
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
 	}
-	// t.MinSettleHeight (abi.ChainEpoch) (int64)
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Code (exitcode.ExitCode) (int64)
 	{
 		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
 		var extraI int64
@@ -623,53 +728,81 @@ func (t *SignedVoucher) UnmarshalCBOR(r io.Reader) error {
 			return fmt.Errorf("wrong type for int64 field: %d", maj)
 		}
 
-		t.MinSettleHeight = abi.ChainEpoch(extraI)
+		t.Code = exitcode.ExitCode(extraI)
 	}
-	// t.Merges ([]paych.Merge) (slice)
+	return nil
+}
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
+var lengthBufPayeeState = []byte{131}
+
+func (t *PayeeState) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufPayeeState); err != nil {
 		return err
 	}
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Merges: array too large (%d)", extra)
+	// t.Payee (address.Address) (struct)
+	if err := t.Payee.MarshalCBOR(w); err != nil {
+		return err
 	}
 
+	// t.ToSend (big.Int) (struct)
+	if err := t.ToSend.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Lanes (bitfield.BitField) (struct)
+	if err := t.Lanes.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *PayeeState) UnmarshalCBOR(r io.Reader) error {
+	*t = PayeeState{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
 	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
+		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra > 0 {
-		t.Merges = make([]paych.Merge, extra)
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	for i := 0; i < int(extra); i++ {
+	// t.Payee (address.Address) (struct)
 
-		var v paych.Merge
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
+	{
+
+		if err := t.Payee.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Payee: %w", err)
 		}
 
-		t.Merges[i] = v
 	}
-
-	// t.Signature (crypto.Signature) (struct)
+	// t.ToSend (big.Int) (struct)
 
 	{
 
-		b, err := br.ReadByte()
-		if err != nil {
-			return err
+		if err := t.ToSend.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ToSend: %w", err)
 		}
-		if b != cbg.CborNull[0] {
-			if err := br.UnreadByte(); err != nil {
-				return err
-			}
-			t.Signature = new(crypto.Signature)
-			if err := t.Signature.UnmarshalCBOR(br); err != nil {
-				return xerrors.Errorf("unmarshaling t.Signature pointer: %w", err)
-			}
+
+	}
+	// t.Lanes (bitfield.BitField) (struct)
+
+	{
+
+		if err := t.Lanes.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Lanes: %w", err)
 		}
 
 	}
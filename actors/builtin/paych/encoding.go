@@ -0,0 +1,579 @@
+package paych
+
+import (
+	"fmt"
+	"io"
+
+	address "github.com/filecoin-project/go-address"
+	abi "github.com/filecoin-project/go-state-types/abi"
+	crypto "github.com/filecoin-project/go-state-types/crypto"
+	paych "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	xerrors "golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+// The encodings of the types in this file are maintained by hand, rather than generated, so that they remain
+// compatible with encodings produced by earlier versions of the actors.
+
+// ConstructorParams encoded by v6 and earlier lack SettleDelay and AdditionalPayees, so have only two fields.
+// Such parameters decode with both fields absent, selecting the default settle delay and no additional payees.
+// Parameters are always encoded with all four fields.
+var lengthBufConstructorParams = []byte{132}
+
+func (t *ConstructorParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufConstructorParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.From (address.Address) (struct)
+	if err := t.From.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.To (address.Address) (struct)
+	if err := t.To.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.SettleDelay (abi.ChainEpoch) (int64)
+	if t.SettleDelay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SettleDelay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.SettleDelay-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.AdditionalPayees ([]address.Address) (slice)
+	if len(t.AdditionalPayees) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.AdditionalPayees was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AdditionalPayees))); err != nil {
+		return err
+	}
+	for _, v := range t.AdditionalPayees {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *ConstructorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ConstructorParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	// Parameters encoded by v6 and earlier have only the From and To fields.
+	if extra != 4 && extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.From (address.Address) (struct)
+
+	{
+
+		if err := t.From.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.From: %w", err)
+		}
+
+	}
+	// t.To (address.Address) (struct)
+
+	{
+
+		if err := t.To.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.To: %w", err)
+		}
+
+	}
+	if extra == 2 {
+		return nil
+	}
+	// t.SettleDelay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.SettleDelay = abi.ChainEpoch(extraI)
+	}
+	// t.AdditionalPayees ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.AdditionalPayees: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.AdditionalPayees = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.AdditionalPayees[i] = v
+	}
+
+	return nil
+}
+
+func (t ConstructorParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *ConstructorParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *ConstructorParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "From", Value: &t.From},
+		{Name: "To", Value: &t.To},
+		{Name: "SettleDelay", Value: &t.SettleDelay},
+		{Name: "AdditionalPayees", Value: &t.AdditionalPayees},
+	}
+}
+
+// A SignedVoucher without a payee has the 11 fields of the original v7 encoding, so that vouchers (and their
+// signing bytes) are unchanged by the addition of Payee. A voucher with a payee has Payee appended as a 12th field,
+// binding the voucher's signature to the payee.
+
+var lengthBufSignedVoucher = []byte{139}
+var lengthBufSignedVoucherWithPayee = []byte{140}
+
+func (t *SignedVoucher) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	lengthBuf := lengthBufSignedVoucher
+	if t.Payee != nil {
+		lengthBuf = lengthBufSignedVoucherWithPayee
+	}
+	if _, err := w.Write(lengthBuf); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ChannelAddr (address.Address) (struct)
+	if err := t.ChannelAddr.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TimeLockMin (abi.ChainEpoch) (int64)
+	if t.TimeLockMin >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.TimeLockMin)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.TimeLockMin-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.TimeLockMax (abi.ChainEpoch) (int64)
+	if t.TimeLockMax >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.TimeLockMax)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.TimeLockMax-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.SecretHash ([]uint8) (slice)
+	if len(t.SecretHash) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.SecretHash was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.SecretHash))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.SecretHash[:]); err != nil {
+		return err
+	}
+
+	// t.Extra (paych.ModVerifyParams) (struct)
+	if err := t.Extra.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Lane (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Lane)); err != nil {
+		return err
+	}
+
+	// t.Nonce (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Nonce)); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.MinSettleHeight (abi.ChainEpoch) (int64)
+	if t.MinSettleHeight >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.MinSettleHeight)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.MinSettleHeight-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Merges ([]paych.Merge) (slice)
+	if len(t.Merges) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Merges was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Merges))); err != nil {
+		return err
+	}
+	for _, v := range t.Merges {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Signature (crypto.Signature) (struct)
+	if err := t.Signature.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Payee (address.Address) (struct)
+	if t.Payee != nil {
+		if err := t.Payee.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SignedVoucher) UnmarshalCBOR(r io.Reader) error {
+	*t = SignedVoucher{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	// Vouchers without a payee have the 11 fields of the original v7 encoding.
+	if extra != 11 && extra != 12 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+	fields := extra
+
+	// t.ChannelAddr (address.Address) (struct)
+
+	{
+
+		if err := t.ChannelAddr.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ChannelAddr: %w", err)
+		}
+
+	}
+	// t.TimeLockMin (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.TimeLockMin = abi.ChainEpoch(extraI)
+	}
+	// t.TimeLockMax (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.TimeLockMax = abi.ChainEpoch(extraI)
+	}
+	// t.SecretHash ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.SecretHash: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.SecretHash = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.SecretHash[:]); err != nil {
+		return err
+	}
+	// t.Extra (paych.ModVerifyParams) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Extra = new(paych.ModVerifyParams)
+			if err := t.Extra.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Extra pointer: %w", err)
+			}
+		}
+
+	}
+	// t.Lane (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Lane = uint64(extra)
+
+	}
+	// t.Nonce (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Nonce = uint64(extra)
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.MinSettleHeight (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.MinSettleHeight = abi.ChainEpoch(extraI)
+	}
+	// t.Merges ([]paych.Merge) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Merges: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Merges = make([]paych.Merge, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v paych.Merge
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Merges[i] = v
+	}
+
+	// t.Signature (crypto.Signature) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Signature = new(crypto.Signature)
+			if err := t.Signature.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Signature pointer: %w", err)
+			}
+		}
+
+	}
+	if fields == 11 {
+		return nil
+	}
+	// t.Payee (address.Address) (struct)
+
+	{
+
+		t.Payee = new(address.Address)
+		if err := t.Payee.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Payee: %w", err)
+		}
+
+	}
+	return nil
+}
+
+func (t SignedVoucher) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *SignedVoucher) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *SignedVoucher) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "ChannelAddr", Value: &t.ChannelAddr},
+		{Name: "TimeLockMin", Value: &t.TimeLockMin},
+		{Name: "TimeLockMax", Value: &t.TimeLockMax},
+		{Name: "SecretHash", Value: &t.SecretHash},
+		{Name: "Extra", Value: &t.Extra},
+		{Name: "Lane", Value: &t.Lane},
+		{Name: "Nonce", Value: &t.Nonce},
+		{Name: "Amount", Value: (*jsonenc.BigInt)(&t.Amount)},
+		{Name: "MinSettleHeight", Value: &t.MinSettleHeight},
+		{Name: "Merges", Value: &t.Merges},
+		{Name: "Signature", Value: &t.Signature},
+		{Name: "Payee", Value: &t.Payee},
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 
 	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	rtt "github.com/filecoin-project/go-state-types/rt"
 	paych0 "github.com/filecoin-project/specs-actors/actors/builtin/paych"
 
 	"github.com/ipfs/go-cid"
@@ -26,10 +28,13 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.UpdateChannelState,
-		3:                         a.Settle,
-		4:                         a.Collect,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.UpdateChannelState,
+		3:                          a.Settle,
+		4:                          a.Collect,
+		5:                          a.CompactLanes,
+		6:                          a.UpdateChannelStateMany,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -43,11 +48,24 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
-//type ConstructorParams struct {
-//	From addr.Address // Payer
-//	To   addr.Address // Payee
-//}
-type ConstructorParams = paych0.ConstructorParams
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
+// Changed in v7:
+// - Added SettleDelay
+// - Added AdditionalPayees
+// Parameters encoded by v6 and earlier, without these fields, are still accepted.
+type ConstructorParams struct {
+	From addr.Address // Payer
+	To   addr.Address // Payee
+	// (optional) Delay between settling and collecting the channel.
+	// Zero selects the default SettleDelay, and any other value must be no shorter than that.
+	SettleDelay abi.ChainEpoch
+	// (optional) Payees besides `To`, each of which is paid on its own lanes.
+	AdditionalPayees []addr.Address
+}
 
 // Constructor creates a payment channel actor. See State for meaning of params.
 func (pca *Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.EmptyValue {
@@ -61,12 +79,35 @@ func (pca *Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *ab
 	from, err := pca.resolveAccount(rt, params.From)
 	builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to resolve from address: %s", params.From)
 
+	if len(params.AdditionalPayees)+1 > MaxPayees {
+		rt.Abortf(exitcode.ErrIllegalArgument, "too many payees %d, max %d", len(params.AdditionalPayees)+1, MaxPayees)
+	}
+	payees := []addr.Address{to}
+	for _, raw := range params.AdditionalPayees {
+		payee, err := pca.resolveAccount(rt, raw)
+		builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to resolve payee address: %s", raw)
+		for _, p := range payees {
+			if p == payee {
+				rt.Abortf(exitcode.ErrIllegalArgument, "duplicate payee %v", raw)
+			}
+		}
+		payees = append(payees, payee)
+	}
+
 	emptyArr, err := adt.MakeEmptyArray(adt.AsStore(rt), LaneStatesAmtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create empty array")
 	emptyArrCid, err := emptyArr.Root()
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to persist empty array")
 
-	st := ConstructState(from, to, emptyArrCid)
+	settleDelay := params.SettleDelay
+	if settleDelay == 0 {
+		settleDelay = SettleDelay
+	}
+	if settleDelay < SettleDelay {
+		rt.Abortf(exitcode.ErrIllegalArgument, "settle delay %d shorter than minimum %d", settleDelay, SettleDelay)
+	}
+
+	st := ConstructState(from, to, payees[1:], emptyArrCid, settleDelay)
 	rt.StateCreate(st)
 
 	return nil
@@ -101,10 +142,11 @@ type UpdateChannelStateParams struct {
 	Secret []byte
 }
 
-// A voucher is sent by `From` to `To` off-chain in order to enable
-// `To` to redeem payments on-chain in the future
+// A voucher is sent by `From` to a payee off-chain in order to enable
+// the payee to redeem payments on-chain in the future
 // Changed in v7:
 // - Renamed SecretPreImage to SecretHash
+// - Added Payee
 type SignedVoucher struct {
 	// ChannelAddr is the address of the payment channel this signed voucher is valid for
 	ChannelAddr addr.Address
@@ -131,6 +173,11 @@ type SignedVoucher struct {
 
 	// Sender's signature over the voucher
 	Signature *crypto.Signature
+
+	// (optional) Payee to which the voucher pays out, if other than `To`.
+	// The voucher's lane, and any merged lanes, must be owned by the payee.
+	// Encoded only if present, so vouchers without a payee keep their original encoding.
+	Payee *addr.Address
 }
 
 func VoucherSigningBytes(t *SignedVoucher) ([]byte, error) {
@@ -167,17 +214,10 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 	var st State
 	rt.StateReadonly(&st)
 
-	// both parties must sign voucher: one who submits it, the other explicitly signs it
-	rt.ValidateImmediateCallerIs(st.From, st.To)
-	var signer addr.Address
-	if rt.Caller() == st.From {
-		signer = st.To
-	} else {
-		signer = st.From
-	}
-	sv := params.Sv
+	// both the payer and payee must sign voucher: one who submits it, the other explicitly signs it
+	rt.ValidateImmediateCallerIs(st.Parties()...)
 
-	if sv.Signature == nil {
+	if params.Sv.Signature == nil {
 		rt.Abortf(exitcode.ErrIllegalArgument, "voucher has no signature")
 	}
 
@@ -185,154 +225,161 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 		rt.Abortf(ErrChannelStateUpdateAfterSettled, "no vouchers can be processed after SettlingAt epoch")
 	}
 
-	if len(params.Secret) > MaxSecretSize {
-		rt.Abortf(exitcode.ErrIllegalArgument, "secret must be at most 256 bytes long")
-	}
+	err := validateVoucher(rt, runtimeVoucherEnv(rt), &st, params)
+	builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalArgument), "invalid voucher")
 
-	vb, err := VoucherSigningBytes(&sv)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to serialize signedvoucher")
+	rt.StateTransaction(&st, func() {
+		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
 
-	err = rt.VerifySignature(*sv.Signature, signer, vb)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "voucher signature invalid")
+		err = redeemVoucher(runtimeVoucherEnv(rt), &st, lstates, &params.Sv)
+		builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalArgument), "cannot redeem voucher")
 
-	pchAddr := rt.Receiver()
-	svpchIDAddr, found := rt.ResolveAddress(sv.ChannelAddr)
-	if !found {
-		rt.Abortf(exitcode.ErrIllegalArgument, "voucher payment channel address %s does not resolve to an ID address", sv.ChannelAddr)
-	}
-	if pchAddr != svpchIDAddr {
-		rt.Abortf(exitcode.ErrIllegalArgument, "voucher payment channel address %s does not match receiver %s", svpchIDAddr, pchAddr)
-	}
+		st.LaneStates, err = lstates.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
+	})
+	return nil
+}
 
-	if rt.CurrEpoch() < sv.TimeLockMin {
-		rt.Abortf(exitcode.ErrIllegalArgument, "cannot use this voucher yet!")
-	}
+type UpdateChannelStateManyParams struct {
+	Updates []UpdateChannelStateParams
+}
 
-	if sv.TimeLockMax != 0 && rt.CurrEpoch() > sv.TimeLockMax {
-		rt.Abortf(exitcode.ErrIllegalArgument, "this voucher has expired!")
-	}
+type UpdateChannelStateManyReturn struct {
+	// Results has one entry per update, in the order the updates were given.
+	Results []UpdateChannelStateResult
+}
 
-	if sv.Amount.Sign() < 0 {
-		rt.Abortf(exitcode.ErrIllegalArgument, "voucher amount must be non-negative, was %v", sv.Amount)
-	}
+type UpdateChannelStateResult struct {
+	// Code is Ok if the voucher was redeemed, else the exit code with which UpdateChannelState
+	// would have aborted given the same voucher.
+	Code exitcode.ExitCode
+}
 
-	if len(sv.SecretHash) > 0 {
-		hashedSecret := rt.HashBlake2b(params.Secret)
-		if !bytes.Equal(hashedSecret[:], sv.SecretHash) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "incorrect secret!")
-		}
-	}
+// Redeems a batch of vouchers, possibly for different lanes, in a single message.
+// Each voucher is validated and redeemed in order exactly as if it had been submitted to UpdateChannelState,
+// so a voucher may depend on the lane states left by those preceding it.
+// A voucher that cannot be redeemed leaves the channel state unchanged and is reported in the results
+// without aborting the batch.
+func (pca Actor) UpdateChannelStateMany(rt runtime.Runtime, params *UpdateChannelStateManyParams) *UpdateChannelStateManyReturn {
+	var st State
+	rt.StateReadonly(&st)
 
-	if sv.Extra != nil {
+	rt.ValidateImmediateCallerIs(st.Parties()...)
 
-		code := rt.Send(
-			sv.Extra.Actor,
-			sv.Extra.Method,
-			builtin.CBORBytes(sv.Extra.Data),
-			abi.NewTokenAmount(0),
-			&builtin.Discard{},
-		)
-		builtin.RequireSuccess(rt, code, "spend voucher verification failed")
+	if len(params.Updates) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch empty")
 	}
-
-	rt.StateTransaction(&st, func() {
-		laneFound := true
-
-		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
-
-		// Find the voucher lane, creating if necessary.
-		laneId := sv.Lane
-		laneState := findLane(rt, lstates, sv.Lane)
-
-		if laneState == nil {
-			laneState = &LaneState{
-				Redeemed: big.Zero(),
-				Nonce:    0,
-			}
-			laneFound = false
+	if len(params.Updates) > MaxVoucherBatchSize {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch of %d too large, max %d", len(params.Updates), MaxVoucherBatchSize)
+	}
+	for i := range params.Updates {
+		if params.Updates[i].Sv.Signature == nil {
+			rt.Abortf(exitcode.ErrIllegalArgument, "voucher %d has no signature", i)
 		}
+	}
 
-		if laneFound {
-			if laneState.Nonce >= sv.Nonce {
-				rt.Abortf(exitcode.ErrIllegalArgument, "voucher has an outdated nonce, existing nonce: %d, voucher nonce: %d, cannot redeem",
-					laneState.Nonce, sv.Nonce)
-			}
-		}
+	if st.SettlingAt != 0 && rt.CurrEpoch() >= st.SettlingAt {
+		rt.Abortf(ErrChannelStateUpdateAfterSettled, "no vouchers can be processed after SettlingAt epoch")
+	}
 
-		// The next section actually calculates the payment amounts to update the payment channel state
-		// 1. (optional) sum already redeemed value of all merging lanes
-		redeemedFromOthers := big.Zero()
-		for _, merge := range sv.Merges {
-			if merge.Lane == sv.Lane {
-				rt.Abortf(exitcode.ErrIllegalArgument, "voucher cannot merge lanes into its own lane")
-			}
+	// Validation may invoke other actors, so must complete before the state transaction.
+	envs := batchVerifyVoucherSignatures(rt, &st, params.Updates)
+	results := make([]UpdateChannelStateResult, len(params.Updates))
+	for i := range params.Updates {
+		if err := validateVoucher(rt, envs[i], &st, &params.Updates[i]); err != nil {
+			rt.Log(rtt.INFO, "voucher %d invalid: %s", i, err)
+			results[i].Code = exitcode.Unwrap(err, exitcode.ErrIllegalArgument)
+		}
+	}
 
-			otherls := findLane(rt, lstates, merge.Lane)
-			if otherls == nil {
-				rt.Abortf(exitcode.ErrIllegalArgument, "voucher specifies invalid merge lane %v", merge.Lane)
-				return // makes linters happy
+	rt.StateTransaction(&st, func() {
+		store := adt.AsStore(rt)
+		for i := range params.Updates {
+			if results[i].Code != exitcode.Ok {
+				continue
 			}
 
-			if otherls.Nonce >= merge.Nonce {
-				rt.Abortf(exitcode.ErrIllegalArgument, "merged lane in voucher has outdated nonce, cannot redeem")
+			// Redeem each voucher against a snapshot of the lanes, so a failed voucher leaves no trace.
+			lstates, err := adt.AsArray(store, st.LaneStates, LaneStatesAmtBitwidth)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
+
+			if err := redeemVoucher(runtimeVoucherEnv(rt), &st, lstates, &params.Updates[i].Sv); err != nil {
+				// A failure to load or store state aborts the whole batch.
+				code := exitcode.Unwrap(err, exitcode.ErrIllegalArgument)
+				if code == exitcode.ErrIllegalState {
+					rt.Abortf(code, "failed to redeem voucher %d: %s", i, err)
+				}
+				rt.Log(rtt.INFO, "voucher %d not redeemed: %s", i, err)
+				results[i].Code = code
+				continue
 			}
 
-			redeemedFromOthers = big.Add(redeemedFromOthers, otherls.Redeemed)
-			otherls.Nonce = merge.Nonce
-			err = lstates.Set(merge.Lane, otherls)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", merge.Lane)
+			st.LaneStates, err = lstates.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
 		}
+	})
+	return &UpdateChannelStateManyReturn{Results: results}
+}
 
-		// 2. To prevent double counting, remove already redeemed amounts (from
-		// voucher or other lanes) from the voucher amount
-		laneState.Nonce = sv.Nonce
-		balanceDelta := big.Sub(sv.Amount, big.Add(redeemedFromOthers, laneState.Redeemed))
-		// 3. set new redeemed value for merged-into lane
-		laneState.Redeemed = sv.Amount
-
-		newSendBalance := big.Add(st.ToSend, balanceDelta)
-
-		// 4. check operation validity
-		if newSendBalance.LessThan(big.Zero()) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "voucher would leave channel balance negative")
+// Verifies the signatures of a number of vouchers as a batch, returning for each voucher a validation environment
+// in which checking its signature yields the result of the batch.
+// A voucher which would be rejected before its signature is checked is left out of the batch.
+func batchVerifyVoucherSignatures(rt runtime.Runtime, st *State, updates []UpdateChannelStateParams) []*VoucherValidationEnv {
+	envs := make([]*VoucherValidationEnv, len(updates))
+	indices := make([]int, 0, len(updates))
+	var batch builtin.SignatureBatch
+	for i := range updates {
+		envs[i] = runtimeVoucherEnv(rt)
+		signer, plaintext, err := voucherSignatureRequest(envs[i], st, rt.Caller(), &updates[i])
+		if err != nil {
+			continue
 		}
-		if newSendBalance.GreaterThan(rt.CurrentBalance()) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "not enough funds in channel to cover voucher")
+		batch.Add(*updates[i].Sv.Signature, signer, plaintext)
+		indices = append(indices, i)
+	}
+	for j, err := range batch.Verify(rt) {
+		sigErr := err
+		envs[indices[j]].VerifySignature = func(crypto.Signature, addr.Address, []byte) error {
+			return sigErr
 		}
+	}
+	return envs
+}
 
-		// 5. add new redemption ToSend
-		st.ToSend = newSendBalance
-
-		// update channel settlingAt and MinSettleHeight if delayed by voucher
-		if sv.MinSettleHeight != 0 {
-			if st.SettlingAt != 0 && st.SettlingAt < sv.MinSettleHeight {
-				st.SettlingAt = sv.MinSettleHeight
-			}
-			if st.MinSettleHeight < sv.MinSettleHeight {
-				st.MinSettleHeight = sv.MinSettleHeight
-			}
-		}
+// Checks a voucher's payee, signature, secret, time locks and extra verification, independent of lane state.
+// The returned error carries the exit code with which to reject the voucher.
+func validateVoucher(rt runtime.Runtime, env *VoucherValidationEnv, st *State, params *UpdateChannelStateParams) error {
+	if err := checkVoucher(env, st, rt.Caller(), params); err != nil {
+		return err
+	}
 
-		err = lstates.Set(laneId, laneState)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane", laneId)
+	if sv := &params.Sv; sv.Extra != nil {
 
-		st.LaneStates, err = lstates.Root()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
-	})
+		code := rt.Send(
+			sv.Extra.Actor,
+			sv.Extra.Method,
+			builtin.CBORBytes(sv.Extra.Data),
+			abi.NewTokenAmount(0),
+			&builtin.Discard{},
+		)
+		if !code.IsSuccess() {
+			return code.Wrapf("spend voucher verification failed")
+		}
+	}
 	return nil
 }
 
 func (pca Actor) Settle(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	var st State
 	rt.StateTransaction(&st, func() {
-		rt.ValidateImmediateCallerIs(st.From, st.To)
+		rt.ValidateImmediateCallerIs(st.Parties()...)
 
 		if st.SettlingAt != 0 {
 			rt.Abortf(exitcode.ErrIllegalState, "channel already settling")
 		}
 
-		st.SettlingAt = rt.CurrEpoch() + SettleDelay
+		st.SettlingAt = rt.CurrEpoch() + st.SettleDelay
 		if st.SettlingAt < st.MinSettleHeight {
 			st.SettlingAt = st.MinSettleHeight
 		}
@@ -343,7 +390,7 @@ func (pca Actor) Settle(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 func (pca Actor) Collect(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	var st State
 	rt.StateReadonly(&st)
-	rt.ValidateImmediateCallerIs(st.From, st.To)
+	rt.ValidateImmediateCallerIs(st.Parties()...)
 
 	if st.SettlingAt == 0 || rt.CurrEpoch() < st.SettlingAt {
 		rt.Abortf(exitcode.ErrForbidden, "payment channel not settling or settled")
@@ -359,25 +406,140 @@ func (pca Actor) Collect(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue
 	)
 	builtin.RequireSuccess(rt, codeTo, "Failed to send funds to `To`")
 
+	// send each additional payee its own ToSend
+	for _, p := range st.AdditionalPayees {
+		code := rt.Send(
+			p.Payee,
+			builtin.MethodSend,
+			nil,
+			p.ToSend,
+			&builtin.Discard{},
+		)
+		builtin.RequireSuccess(rt, code, "failed to send funds to payee %v", p.Payee)
+	}
+
 	// the remaining balance will be returned to "From" upon deletion.
 	rt.DeleteActor(st.From)
 
 	return nil
 }
 
-// Returns the insertion index for a lane ID, with the matching lane state if found, or nil.
-func findLane(rt runtime.Runtime, ls *adt.Array, id uint64) *LaneState {
+type CompactLanesParams struct {
+	// Lanes to be merged into `Into` and removed from the channel state.
+	Lanes bitfield.BitField
+	// Lane which receives the redeemed amounts of the merged lanes (will be created if does not exist).
+	Into uint64
+}
+
+// CompactLanes merges the redeemed amounts of a set of lanes into a single lane, removing the merged
+// lanes from the channel state so that long-lived channels don't accumulate state for every lane ever used.
+// The merged lanes are retired: vouchers for them, or merging them, are subsequently rejected, so
+// any unredeemed vouchers for them are forfeited. A voucher for the `Into` lane must subsequently account
+// for the amounts redeemed by the merged lanes, as with a voucher that merges lanes explicitly.
+// Since compaction can only reduce the amount the recipient is able to redeem, only the payee owning the
+// lanes may compact them.
+func (pca Actor) CompactLanes(rt runtime.Runtime, params *CompactLanesParams) *abi.EmptyValue {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.Payees()...)
+	payee, _ := st.payeeIndex(rt.Caller())
+
+	if st.SettlingAt != 0 && rt.CurrEpoch() >= st.SettlingAt {
+		rt.Abortf(ErrChannelStateUpdateAfterSettled, "no lanes can be compacted after SettlingAt epoch")
+	}
+
+	count, err := params.Lanes.Count()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to count lanes")
+	if count == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "no lanes to compact")
+	}
+	if count > MaxCompactLanes {
+		rt.Abortf(exitcode.ErrIllegalArgument, "too many lanes to compact %d, max %d", count, MaxCompactLanes)
+	}
+	isMerged, err := params.Lanes.IsSet(params.Into)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to check lanes")
+	if isMerged {
+		rt.Abortf(exitcode.ErrIllegalArgument, "cannot compact lane %d into itself", params.Into)
+	}
+
+	rt.StateTransaction(&st, func() {
+		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
+
+		err = checkLaneNotRetired(&st, params.Into)
+		builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "cannot compact into lane %d", params.Into)
+		intoState, err := findLane(lstates, params.Into)
+		builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to load lane %d", params.Into)
+		if intoState == nil {
+			intoState = &LaneState{
+				Redeemed: big.Zero(),
+				Nonce:    0,
+			}
+			err = st.updatePayee(payee, st.payeeToSend(payee), params.Into)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to assign lane %d", params.Into)
+		} else {
+			err = checkLaneOwner(&st, params.Into, payee)
+			builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "cannot compact into lane %d", params.Into)
+		}
+
+		var merged []uint64
+		err = params.Lanes.ForEach(func(laneId uint64) error {
+			ls, err := findLane(lstates, laneId)
+			if err != nil {
+				return err
+			}
+			if ls == nil {
+				rt.Abortf(exitcode.ErrIllegalArgument, "cannot compact unknown lane %d", laneId)
+			}
+			if err := checkLaneOwner(&st, laneId, payee); err != nil {
+				return err
+			}
+			intoState.Redeemed = big.Add(intoState.Redeemed, ls.Redeemed)
+			merged = append(merged, laneId)
+			return nil
+		})
+		builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalArgument), "failed to iterate lanes")
+
+		err = lstates.BatchDelete(merged, true)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete compacted lanes")
+		err = lstates.Set(params.Into, intoState)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", params.Into)
+
+		st.LaneStates, err = lstates.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
+		st.RetiredLanes, err = bitfield.MergeBitFields(st.RetiredLanes, params.Lanes)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record retired lanes")
+	})
+	return nil
+}
+
+// Returns an error if a lane has been retired by compaction.
+func checkLaneNotRetired(st *State, id uint64) error {
+	retired, err := st.RetiredLanes.IsSet(id)
+	if err != nil {
+		return exitcode.ErrIllegalState.Wrapf("failed to check retired lanes: %w", err)
+	}
+	if retired {
+		return exitcode.ErrIllegalArgument.Wrapf("lane %d has been retired by compaction", id)
+	}
+	return nil
+}
+
+// Returns the lane state for a lane ID if found, or nil.
+func findLane(ls *adt.Array, id uint64) (*LaneState, error) {
 	if id > MaxLane {
-		rt.Abortf(exitcode.ErrIllegalArgument, "maximum lane ID is 2^63-1")
+		return nil, exitcode.ErrIllegalArgument.Wrapf("maximum lane ID is 2^63-1")
 	}
 
 	var out LaneState
 	found, err := ls.Get(id, &out)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lane %d", id)
+	if err != nil {
+		return nil, exitcode.ErrIllegalState.Wrapf("failed to load lane %d: %w", id, err)
+	}
 
 	if !found {
-		return nil
+		return nil, nil
 	}
 
-	return &out
+	return &out, nil
 }
@@ -2,18 +2,19 @@ package paych
 
 import (
 	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/ipfs/go-cid"
 )
 
 // A given payment channel actor is established by From
-// to enable off-chain microtransactions to To to be reconciled
+// to enable off-chain microtransactions to To, and any additional payees, to be reconciled
 // and tallied on chain.
 type State struct {
 	// Channel owner, who has funded the actor
 	From addr.Address
-	// Recipient of payouts from channel
+	// Recipient of payouts from channel, owning every lane not owned by an additional payee
 	To addr.Address
 
 	// Amount successfully redeemed through the payment channel, paid out on `Collect()`
@@ -26,6 +27,27 @@ type State struct {
 
 	// Collections of lane states for the channel, maintained in ID order.
 	LaneStates cid.Cid // AMT<LaneState>
+
+	// Lanes that have been merged into another lane by `CompactLanes`.
+	// A retired lane is removed from LaneStates and can never be redeemed or merged again.
+	RetiredLanes bitfield.BitField
+
+	// Delay between `Settle()` and the epoch at which the channel can be `Collected`,
+	// no shorter than the SettleDelay policy.
+	SettleDelay abi.ChainEpoch
+
+	// Recipients of payouts from channel besides `To`, in the order given at construction.
+	AdditionalPayees []PayeeState
+}
+
+// The state of a payee of a channel other than `To`.
+type PayeeState struct {
+	// Recipient of payouts, which may redeem vouchers only on the lanes it owns.
+	Payee addr.Address
+	// Amount successfully redeemed by the payee, paid out on `Collect()`
+	ToSend abi.TokenAmount
+	// Lanes owned by the payee, assigned when a voucher for the payee first redeems the lane.
+	Lanes bitfield.BitField
 }
 
 // The Lane state tracks the latest (highest) voucher nonce used to merge the lane
@@ -37,13 +59,100 @@ type LaneState struct {
 
 const LaneStatesAmtBitwidth = 3
 
-func ConstructState(from addr.Address, to addr.Address, emptyArrCid cid.Cid) *State {
+func ConstructState(from addr.Address, to addr.Address, additionalPayees []addr.Address, emptyArrCid cid.Cid, settleDelay abi.ChainEpoch) *State {
+	var payees []PayeeState
+	for _, payee := range additionalPayees {
+		payees = append(payees, PayeeState{
+			Payee:  payee,
+			ToSend: big.Zero(),
+			Lanes:  bitfield.New(),
+		})
+	}
 	return &State{
-		From:            from,
-		To:              to,
-		ToSend:          big.Zero(),
-		SettlingAt:      0,
-		MinSettleHeight: 0,
-		LaneStates:      emptyArrCid,
+		From:             from,
+		To:               to,
+		ToSend:           big.Zero(),
+		SettlingAt:       0,
+		MinSettleHeight:  0,
+		LaneStates:       emptyArrCid,
+		RetiredLanes:     bitfield.New(),
+		SettleDelay:      settleDelay,
+		AdditionalPayees: payees,
+	}
+}
+
+// Returns the addresses of the channel's payees, `To` followed by any additional payees.
+// A payee is identified by its index in this list.
+func (st *State) Payees() []addr.Address {
+	payees := []addr.Address{st.To}
+	for _, p := range st.AdditionalPayees {
+		payees = append(payees, p.Payee)
+	}
+	return payees
+}
+
+// Returns the addresses of all parties to the channel, `From` followed by the payees.
+func (st *State) Parties() []addr.Address {
+	return append([]addr.Address{st.From}, st.Payees()...)
+}
+
+// Returns the total amount redeemed by all payees.
+func (st *State) TotalToSend() abi.TokenAmount {
+	total := st.ToSend
+	for _, p := range st.AdditionalPayees {
+		total = big.Add(total, p.ToSend)
+	}
+	return total
+}
+
+// Returns the index of a payee, or false if the address is not a payee of the channel.
+func (st *State) payeeIndex(a addr.Address) (int, bool) {
+	for i, payee := range st.Payees() {
+		if payee == a {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Returns the index of the payee owning a lane.
+func (st *State) laneOwner(lane uint64) (int, error) {
+	for i := range st.AdditionalPayees {
+		owned, err := st.AdditionalPayees[i].Lanes.IsSet(lane)
+		if err != nil {
+			return 0, err
+		}
+		if owned {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// Returns the amount redeemed by a payee.
+func (st *State) payeeToSend(payee int) abi.TokenAmount {
+	if payee == 0 {
+		return st.ToSend
+	}
+	return st.AdditionalPayees[payee-1].ToSend
+}
+
+// Sets the amount redeemed by a payee, and assigns it any given lanes.
+// The additional payees are copied rather than modified in place, so copies of the state are unaffected.
+func (st *State) updatePayee(payee int, toSend abi.TokenAmount, lanes ...uint64) error {
+	if payee == 0 {
+		st.ToSend = toSend
+		return nil
+	}
+	payees := append([]PayeeState(nil), st.AdditionalPayees...)
+	payees[payee-1].ToSend = toSend
+	if len(lanes) > 0 {
+		merged, err := bitfield.MergeBitFields(payees[payee-1].Lanes, bitfield.NewFromSet(lanes))
+		if err != nil {
+			return err
+		}
+		payees[payee-1].Lanes = merged
 	}
+	st.AdditionalPayees = payees
+	return nil
 }
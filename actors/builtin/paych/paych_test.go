@@ -1,6 +1,7 @@
 package paych_test
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"reflect"
@@ -8,10 +9,13 @@ import (
 	"testing"
 
 	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -145,6 +149,82 @@ func TestPaymentChannelActor_Constructor(t *testing.T) {
 			rt.Call(actor.Constructor, &ConstructorParams{To: paychAddr})
 		})
 	})
+
+	t.Run("can create a payment channel with a longer settle delay", func(t *testing.T) {
+		rt := mock.NewBuilder(paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			Build(t)
+		settleDelay := abi.ChainEpoch(SettleDelay * 2)
+
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: payeeAddr, SettleDelay: settleDelay})
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, settleDelay, st.SettleDelay)
+		actor.checkState(rt)
+
+		// Settling honours the channel's delay.
+		rt.SetEpoch(10)
+		rt.SetCaller(payerAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.Call(actor.Settle, nil)
+		rt.Verify()
+
+		rt.GetState(&st)
+		assert.Equal(t, 10+settleDelay, st.SettlingAt)
+		actor.checkState(rt)
+	})
+
+	t.Run("accepts parameters encoded by v6", func(t *testing.T) {
+		rt := mock.NewBuilder(paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			Build(t)
+
+		var encoded bytes.Buffer
+		require.NoError(t, (&paych6.ConstructorParams{From: payerAddr, To: payeeAddr}).MarshalCBOR(&encoded))
+		var params ConstructorParams
+		require.NoError(t, params.UnmarshalCBOR(&encoded))
+		assert.Equal(t, ConstructorParams{From: payerAddr, To: payeeAddr}, params)
+
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &params)
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, abi.ChainEpoch(SettleDelay), st.SettleDelay)
+		assert.Empty(t, st.AdditionalPayees)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails if settle delay is shorter than the default", func(t *testing.T) {
+		rt := mock.NewBuilder(paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			Build(t)
+
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: payeeAddr, SettleDelay: SettleDelay - 1})
+		})
+		rt.Verify()
+	})
+}
+
+// ConstructorParams and SignedVoucher are encoded by hand, so have no generated fuzz targets.
+func FuzzCBORConstructorParams(f *testing.F) {
+	tutil.FuzzCBORRoundTrip(f, func() cbor.Er { return new(ConstructorParams) })
+}
+
+func FuzzCBORSignedVoucher(f *testing.F) {
+	tutil.FuzzCBORRoundTrip(f, func() cbor.Er { return new(SignedVoucher) })
 }
 
 func TestPaymentChannelActor_CreateLane(t *testing.T) {
@@ -357,6 +437,7 @@ func TestActor_UpdateChannelStateRedeem(t *testing.T) {
 			SettlingAt:      st1.SettlingAt,
 			MinSettleHeight: st1.MinSettleHeight,
 			LaneStates:      constructLaneStateAMT(t, rt, []*LaneState{&expLs}),
+			SettleDelay:     st1.SettleDelay,
 		}
 		verifyState(t, rt, 1, expState)
 		actor.checkState(rt)
@@ -704,6 +785,545 @@ func TestActor_UpdateChannelStateSecretHash(t *testing.T) {
 	})
 }
 
+func TestActor_UpdateChannelStateMany(t *testing.T) {
+	t.Run("redeems valid vouchers and reports failures", func(t *testing.T) {
+		rt, actor, sv := requireCreateChannelWithLanes(t, 2)
+		var st1 State
+		rt.GetState(&st1)
+
+		voucher := func(lane, nonce uint64, amount int64, merges ...Merge) UpdateChannelStateParams {
+			v := *sv
+			v.Lane = lane
+			v.Nonce = nonce
+			v.Amount = big.NewInt(amount)
+			v.Merges = merges
+			return UpdateChannelStateParams{Sv: v}
+		}
+		updates := []UpdateChannelStateParams{
+			voucher(0, 5, 10),
+			// outdated nonce
+			voucher(1, 1, 10),
+			// bad signature
+			voucher(2, 1, 10),
+			// insufficient funds after merging lane 1, which must not update lane 1's nonce
+			voucher(4, 1, 1000000, Merge{Lane: 1, Nonce: 10}),
+			voucher(3, 1, 5, Merge{Lane: 1, Nonce: 3}),
+		}
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st1.From, st1.To)
+		for i := range updates {
+			var sigErr error
+			if i == 2 {
+				sigErr = fmt.Errorf("bad signature")
+			}
+			rt.ExpectVerifySignature(*updates[i].Sv.Signature, actor.payee, voucherBytes(t, &updates[i].Sv), sigErr)
+		}
+		ret := rt.Call(actor.UpdateChannelStateMany, &UpdateChannelStateManyParams{Updates: updates}).(*UpdateChannelStateManyReturn)
+		rt.Verify()
+
+		require.Len(t, ret.Results, len(updates))
+		assert.Equal(t, exitcode.Ok, ret.Results[0].Code)
+		assert.Equal(t, exitcode.ErrIllegalArgument, ret.Results[1].Code)
+		assert.Equal(t, exitcode.ErrIllegalArgument, ret.Results[2].Code)
+		assert.Equal(t, exitcode.ErrIllegalArgument, ret.Results[3].Code)
+		assert.Equal(t, exitcode.Ok, ret.Results[4].Code)
+
+		var st2 State
+		rt.GetState(&st2)
+		// Lane 0 redeems 9 more, and lane 3 redeems 5 less the 2 already redeemed by lane 1.
+		assert.Equal(t, big.Add(st1.ToSend, big.NewInt(12)), st2.ToSend)
+		assertLaneStatesLength(t, rt, st2.LaneStates, 3)
+		assert.Equal(t, LaneState{Redeemed: big.NewInt(10), Nonce: 5}, *getLaneState(t, rt, st2.LaneStates, 0))
+		assert.Equal(t, LaneState{Redeemed: big.NewInt(2), Nonce: 3}, *getLaneState(t, rt, st2.LaneStates, 1))
+		assert.Equal(t, LaneState{Redeemed: big.NewInt(5), Nonce: 1}, *getLaneState(t, rt, st2.LaneStates, 3))
+		actor.checkState(rt)
+	})
+
+	t.Run("fails with empty batch", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, 1)
+		var st State
+		rt.GetState(&st)
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.From, st.To)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.UpdateChannelStateMany, &UpdateChannelStateManyParams{})
+		})
+		rt.Verify()
+	})
+
+	t.Run("fails after settling", func(t *testing.T) {
+		rt, actor, sv := requireCreateChannelWithLanes(t, 1)
+		var st State
+		rt.GetState(&st)
+		st.SettlingAt = rt.Epoch()
+		rt.ReplaceState(&st)
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.From, st.To)
+		rt.ExpectAbort(ErrChannelStateUpdateAfterSettled, func() {
+			rt.Call(actor.UpdateChannelStateMany, &UpdateChannelStateManyParams{Updates: []UpdateChannelStateParams{{Sv: *sv}}})
+		})
+		rt.Verify()
+	})
+}
+
+func TestActor_CompactLanes(t *testing.T) {
+	t.Run("merges lanes into an existing lane and retires them", func(t *testing.T) {
+		rt, actor, sv := requireCreateChannelWithLanes(t, 3)
+		var st1 State
+		rt.GetState(&st1)
+
+		actor.compactLanes(rt, bitfield.NewFromSet([]uint64{0, 1}), 2)
+
+		var st2 State
+		rt.GetState(&st2)
+		assertLaneStatesLength(t, rt, st2.LaneStates, 1)
+		merged := getLaneState(t, rt, st2.LaneStates, 2)
+		assert.Equal(t, big.NewInt(6), merged.Redeemed)
+		assert.Equal(t, uint64(3), merged.Nonce)
+		assert.Equal(t, st1.ToSend, st2.ToSend)
+		retired, err := st2.RetiredLanes.All(MaxCompactLanes)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0, 1}, retired)
+		actor.checkState(rt)
+
+		// A voucher on the merged lane accounts for the amounts redeemed by the retired lanes.
+		sv.Amount = big.NewInt(10)
+		ucp := &UpdateChannelStateParams{Sv: *sv}
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st2.From, st2.To)
+		rt.ExpectVerifySignature(*sv.Signature, actor.payee, voucherBytes(t, &ucp.Sv), nil)
+		rt.Call(actor.UpdateChannelState, ucp)
+		rt.Verify()
+
+		var st3 State
+		rt.GetState(&st3)
+		assert.Equal(t, big.Add(st2.ToSend, big.NewInt(4)), st3.ToSend)
+		actor.checkState(rt)
+	})
+
+	t.Run("merges lanes into a new lane", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, 2)
+
+		actor.compactLanes(rt, bitfield.NewFromSet([]uint64{0, 1}), 5)
+
+		var st State
+		rt.GetState(&st)
+		assertLaneStatesLength(t, rt, st.LaneStates, 1)
+		merged := getLaneState(t, rt, st.LaneStates, 5)
+		assert.Equal(t, big.NewInt(3), merged.Redeemed)
+		assert.Equal(t, uint64(0), merged.Nonce)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects vouchers for retired lanes", func(t *testing.T) {
+		rt, actor, sv := requireCreateChannelWithLanes(t, 3)
+		actor.compactLanes(rt, bitfield.NewFromSet([]uint64{0, 1}), 2)
+
+		var st State
+		rt.GetState(&st)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+
+		// Redeeming a retired lane.
+		retiredSv := *sv
+		retiredSv.Lane = 0
+		retiredSv.Nonce = 10
+		ucp := &UpdateChannelStateParams{Sv: retiredSv}
+		rt.ExpectValidateCallerAddr(st.From, st.To)
+		rt.ExpectVerifySignature(*retiredSv.Signature, actor.payee, voucherBytes(t, &ucp.Sv), nil)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.UpdateChannelState, ucp)
+		})
+		rt.Verify()
+
+		// Merging a retired lane.
+		mergeSv := *sv
+		mergeSv.Merges = []Merge{{Lane: 1, Nonce: 10}}
+		ucp = &UpdateChannelStateParams{Sv: mergeSv}
+		rt.ExpectValidateCallerAddr(st.From, st.To)
+		rt.ExpectVerifySignature(*mergeSv.Signature, actor.payee, voucherBytes(t, &ucp.Sv), nil)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.UpdateChannelState, ucp)
+		})
+		rt.Verify()
+
+		// Compacting into a retired lane.
+		rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.To)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet([]uint64{2}), Into: 0})
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when called by payer", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, 2)
+		var st State
+		rt.GetState(&st)
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.To)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet([]uint64{0}), Into: 1})
+		})
+		rt.Verify()
+	})
+
+	t.Run("fails with invalid lanes", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			lanes []uint64
+			into  uint64
+		}{
+			{"no lanes", []uint64{}, 1},
+			{"compact into merged lane", []uint64{0, 1}, 1},
+			{"unknown lane", []uint64{0, 7}, 1},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rt, actor, _ := requireCreateChannelWithLanes(t, 2)
+				var st State
+				rt.GetState(&st)
+
+				rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+				rt.ExpectValidateCallerAddr(st.To)
+				rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+					rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet(tc.lanes), Into: tc.into})
+				})
+				rt.Verify()
+			})
+		}
+	})
+
+	t.Run("fails after settling", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, 2)
+		var st State
+		rt.GetState(&st)
+		st.SettlingAt = rt.Epoch()
+		rt.ReplaceState(&st)
+
+		rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.To)
+		rt.ExpectAbort(ErrChannelStateUpdateAfterSettled, func() {
+			rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet([]uint64{0}), Into: 1})
+		})
+		rt.Verify()
+	})
+}
+
+func TestActor_MultiplePayees(t *testing.T) {
+	paychAddr := tutil.NewIDAddr(t, 100)
+	payerAddr := tutil.NewIDAddr(t, 101)
+	toAddr := tutil.NewIDAddr(t, 102)
+	payee1 := tutil.NewIDAddr(t, 103)
+	payee2 := tutil.NewIDAddr(t, 104)
+	parties := []addr.Address{payerAddr, toAddr, payee1, payee2}
+	sig := &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{0, 1, 2, 3, 4, 5, 6, 7}}
+	actor := pcActorHarness{Actor{}, t, paychAddr, payerAddr, toAddr}
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(paychAddr).
+			WithBalance(abi.NewTokenAmount(100), big.Zero()).
+			WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(toAddr, builtin.AccountActorCodeID).
+			WithActorType(payee1, builtin.AccountActorCodeID).
+			WithActorType(payee2, builtin.AccountActorCodeID).
+			Build(t)
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: toAddr, AdditionalPayees: []addr.Address{payee1, payee2}})
+		rt.Verify()
+		return rt
+	}
+	voucher := func(lane, nonce uint64, amount int64, payee *addr.Address) *UpdateChannelStateParams {
+		return &UpdateChannelStateParams{Sv: SignedVoucher{
+			ChannelAddr: paychAddr,
+			Lane:        lane,
+			Nonce:       nonce,
+			Amount:      abi.NewTokenAmount(amount),
+			Payee:       payee,
+			Signature:   sig,
+		}}
+	}
+	redeem := func(rt *mock.Runtime, submitter, signer addr.Address, params *UpdateChannelStateParams) {
+		rt.SetCaller(submitter, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectVerifySignature(*sig, signer, voucherBytes(t, &params.Sv), nil)
+		rt.Call(actor.UpdateChannelState, params)
+		rt.Verify()
+	}
+
+	t.Run("payees redeem their own lanes and collect separately", func(t *testing.T) {
+		rt := setup(t)
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, []addr.Address{toAddr, payee1, payee2}, st.Payees())
+
+		// Each payee redeems vouchers signed by the payer, and the payer submits vouchers signed by a payee.
+		redeem(rt, payee1, payerAddr, voucher(0, 1, 10, &payee1))
+		redeem(rt, payerAddr, payee2, voucher(1, 1, 5, &payee2))
+		redeem(rt, toAddr, payerAddr, voucher(2, 1, 7, nil))
+
+		rt.GetState(&st)
+		assert.Equal(t, abi.NewTokenAmount(7), st.ToSend)
+		assert.Equal(t, abi.NewTokenAmount(10), st.AdditionalPayees[0].ToSend)
+		assert.Equal(t, abi.NewTokenAmount(5), st.AdditionalPayees[1].ToSend)
+		assert.Equal(t, abi.NewTokenAmount(22), st.TotalToSend())
+		owned, err := st.AdditionalPayees[0].Lanes.IsSet(0)
+		require.NoError(t, err)
+		assert.True(t, owned)
+
+		rt.SetCaller(payee2, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.Call(actor.Settle, nil)
+		rt.Verify()
+
+		rt.GetState(&st)
+		rt.SetEpoch(st.SettlingAt)
+		rt.SetCaller(payerAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectSend(toAddr, builtin.MethodSend, nil, abi.NewTokenAmount(7), nil, exitcode.Ok)
+		rt.ExpectSend(payee1, builtin.MethodSend, nil, abi.NewTokenAmount(10), nil, exitcode.Ok)
+		rt.ExpectSend(payee2, builtin.MethodSend, nil, abi.NewTokenAmount(5), nil, exitcode.Ok)
+		rt.ExpectDeleteActor(payerAddr)
+		rt.Call(actor.Collect, nil)
+		rt.Verify()
+	})
+
+	t.Run("payee cannot redeem another payee's lane", func(t *testing.T) {
+		rt := setup(t)
+		redeem(rt, payee1, payerAddr, voucher(0, 1, 10, &payee1))
+
+		params := voucher(0, 2, 20, nil)
+		rt.SetCaller(toAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectVerifySignature(*sig, payerAddr, voucherBytes(t, &params.Sv), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "owned by another payee", func() {
+			rt.Call(actor.UpdateChannelState, params)
+		})
+		rt.Verify()
+
+		// Nor merge it into its own lane.
+		params = voucher(1, 1, 20, nil)
+		params.Sv.Merges = []Merge{{Lane: 0, Nonce: 2}}
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectVerifySignature(*sig, payerAddr, voucherBytes(t, &params.Sv), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "owned by another payee", func() {
+			rt.Call(actor.UpdateChannelState, params)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("payee cannot submit a voucher for another payee", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(payee2, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.UpdateChannelState, voucher(0, 1, 10, &payee1))
+		})
+		rt.Verify()
+	})
+
+	t.Run("voucher payee must be a payee of the channel", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(payerAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(parties...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "not a payee", func() {
+			rt.Call(actor.UpdateChannelState, voucher(0, 1, 10, &payerAddr))
+		})
+		rt.Verify()
+	})
+
+	t.Run("payee compacts only its own lanes", func(t *testing.T) {
+		rt := setup(t)
+		redeem(rt, payee1, payerAddr, voucher(0, 1, 10, &payee1))
+		redeem(rt, payee1, payerAddr, voucher(1, 1, 3, &payee1))
+		redeem(rt, toAddr, payerAddr, voucher(2, 1, 7, nil))
+
+		rt.SetCaller(payee1, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(toAddr, payee1, payee2)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "owned by another payee", func() {
+			rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet([]uint64{1, 2}), Into: 0})
+		})
+		rt.Verify()
+
+		rt.ExpectValidateCallerAddr(toAddr, payee1, payee2)
+		rt.Call(actor.CompactLanes, &CompactLanesParams{Lanes: bitfield.NewFromSet([]uint64{1}), Into: 0})
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, abi.NewTokenAmount(13), getLaneState(t, rt, st.LaneStates, 0).Redeemed)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects duplicate and excess payees", func(t *testing.T) {
+		rt := mock.NewBuilder(paychAddr).
+			WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(toAddr, builtin.AccountActorCodeID).
+			WithActorType(payee1, builtin.AccountActorCodeID).
+			Build(t)
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "duplicate payee", func() {
+			rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: toAddr, AdditionalPayees: []addr.Address{payee1, toAddr}})
+		})
+		rt.Verify()
+
+		payees := make([]addr.Address, MaxPayees)
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "too many payees", func() {
+			rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: toAddr, AdditionalPayees: payees})
+		})
+		rt.Verify()
+	})
+}
+
+func TestSignedVoucherEncoding(t *testing.T) {
+	channel := tutil.NewIDAddr(t, 100)
+	payee := tutil.NewIDAddr(t, 103)
+	sig := &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{0, 1, 2, 3}}
+	v6Voucher := paych6.SignedVoucher{
+		ChannelAddr:     channel,
+		TimeLockMin:     1,
+		TimeLockMax:     2,
+		SecretPreimage:  []byte("secret"),
+		Lane:            3,
+		Nonce:           4,
+		Amount:          abi.NewTokenAmount(5),
+		MinSettleHeight: 6,
+		Merges:          []Merge{{Lane: 7, Nonce: 8}},
+		Signature:       sig,
+	}
+	var v6Encoded bytes.Buffer
+	require.NoError(t, v6Voucher.MarshalCBOR(&v6Encoded))
+
+	t.Run("voucher without a payee keeps the prior encoding", func(t *testing.T) {
+		var sv SignedVoucher
+		require.NoError(t, sv.UnmarshalCBOR(bytes.NewReader(v6Encoded.Bytes())))
+		assert.Nil(t, sv.Payee)
+		assert.Equal(t, v6Voucher.SecretPreimage, sv.SecretHash)
+
+		var encoded bytes.Buffer
+		require.NoError(t, sv.MarshalCBOR(&encoded))
+		assert.Equal(t, v6Encoded.Bytes(), encoded.Bytes())
+
+		v6SigningBytes, err := v6Voucher.SigningBytes()
+		require.NoError(t, err)
+		assert.Equal(t, v6SigningBytes, voucherBytes(t, &sv))
+	})
+
+	t.Run("voucher with a payee appends it", func(t *testing.T) {
+		var sv SignedVoucher
+		require.NoError(t, sv.UnmarshalCBOR(bytes.NewReader(v6Encoded.Bytes())))
+		sv.Payee = &payee
+
+		var encoded bytes.Buffer
+		require.NoError(t, sv.MarshalCBOR(&encoded))
+		var decoded SignedVoucher
+		require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(encoded.Bytes())))
+		assert.Equal(t, sv, decoded)
+
+		// the signature is bound to the payee
+		assert.NotEqual(t, voucherBytes(t, &SignedVoucher{ChannelAddr: channel, Amount: big.Zero()}),
+			voucherBytes(t, &SignedVoucher{ChannelAddr: channel, Amount: big.Zero(), Payee: &payee}))
+	})
+}
+
+func TestValidateVoucher(t *testing.T) {
+	setup := func(t *testing.T) (*mock.Runtime, *pcActorHarness, *SignedVoucher, *State, *VoucherValidationEnv) {
+		rt, actor, sv := requireCreateChannelWithLanes(t, 2)
+		var st State
+		rt.GetState(&st)
+		env := &VoucherValidationEnv{
+			Channel: actor.addr,
+			Epoch:   rt.Epoch(),
+			Balance: rt.Balance(),
+			ResolveAddress: func(a addr.Address) (addr.Address, bool) {
+				return rt.GetIdAddr(a)
+			},
+			VerifySignature: func(signature crypto.Signature, signer addr.Address, plaintext []byte) error {
+				if signer != actor.payee {
+					return fmt.Errorf("wrong signer %v", signer)
+				}
+				return nil
+			},
+			HashBlake2b: func(data []byte) [32]byte { return [32]byte{} },
+		}
+		return rt, actor, sv, &st, env
+	}
+
+	t.Run("accepts voucher that would be redeemed", func(t *testing.T) {
+		rt, actor, sv, st, env := setup(t)
+		sv.Amount = big.NewInt(10)
+		ucp := &UpdateChannelStateParams{Sv: *sv}
+
+		require.NoError(t, ValidateVoucher(rt.AdtStore(), st, env, actor.payer, ucp))
+
+		// validation doesn't modify the state
+		var stAfter State
+		rt.GetState(&stAfter)
+		assert.Equal(t, st.LaneStates, stAfter.LaneStates)
+		assert.Equal(t, st.ToSend, stAfter.ToSend)
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(st.From, st.To)
+		rt.ExpectVerifySignature(*sv.Signature, actor.payee, voucherBytes(t, &ucp.Sv), nil)
+		rt.Call(actor.UpdateChannelState, ucp)
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects voucher that would not be redeemed", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			modify    func(sv *SignedVoucher, env *VoucherValidationEnv)
+			submitter func(actor *pcActorHarness) addr.Address
+			code      exitcode.ExitCode
+		}{
+			{"outdated nonce", func(sv *SignedVoucher, env *VoucherValidationEnv) { sv.Nonce = 1 }, nil, exitcode.ErrIllegalArgument},
+			{"merge into own lane", func(sv *SignedVoucher, env *VoucherValidationEnv) {
+				sv.Merges = []Merge{{Lane: sv.Lane, Nonce: 10}}
+			}, nil, exitcode.ErrIllegalArgument},
+			{"unknown merge lane", func(sv *SignedVoucher, env *VoucherValidationEnv) {
+				sv.Merges = []Merge{{Lane: 7, Nonce: 10}}
+			}, nil, exitcode.ErrIllegalArgument},
+			{"insufficient balance", func(sv *SignedVoucher, env *VoucherValidationEnv) { env.Balance = big.NewInt(2) }, nil, exitcode.ErrIllegalArgument},
+			{"before time lock", func(sv *SignedVoucher, env *VoucherValidationEnv) { sv.TimeLockMin = env.Epoch + 1 }, nil, exitcode.ErrIllegalArgument},
+			{"after time lock", func(sv *SignedVoucher, env *VoucherValidationEnv) { sv.TimeLockMax = env.Epoch - 1 }, nil, exitcode.ErrIllegalArgument},
+			{"no signature", func(sv *SignedVoucher, env *VoucherValidationEnv) { sv.Signature = nil }, nil, exitcode.ErrIllegalArgument},
+			{"wrong signer", nil, func(actor *pcActorHarness) addr.Address { return actor.payee }, exitcode.ErrIllegalArgument},
+			{"not a party", nil, func(actor *pcActorHarness) addr.Address { return actor.addr }, exitcode.ErrForbidden},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rt, actor, sv, st, env := setup(t)
+				if tc.modify != nil {
+					tc.modify(sv, env)
+				}
+				submitter := actor.payer
+				if tc.submitter != nil {
+					submitter = tc.submitter(actor)
+				}
+				err := ValidateVoucher(rt.AdtStore(), st, env, submitter, &UpdateChannelStateParams{Sv: *sv})
+				require.Error(t, err)
+				assert.Equal(t, tc.code, exitcode.Unwrap(err, exitcode.Ok))
+			})
+		}
+	})
+
+	t.Run("rejects voucher after settling", func(t *testing.T) {
+		rt, actor, sv, st, env := setup(t)
+		st.SettlingAt = env.Epoch
+		err := ValidateVoucher(rt.AdtStore(), st, env, actor.payer, &UpdateChannelStateParams{Sv: *sv})
+		assert.Equal(t, ErrChannelStateUpdateAfterSettled, exitcode.Unwrap(err, exitcode.Ok))
+	})
+}
+
 func TestActor_Settle(t *testing.T) {
 	ep := abi.ChainEpoch(10)
 
@@ -951,6 +1571,14 @@ func (h *pcActorHarness) constructAndVerify(t *testing.T, rt *mock.Runtime, send
 	verifyInitialState(t, rt, senderId, receiverId)
 }
 
+func (h *pcActorHarness) compactLanes(rt *mock.Runtime, lanes bitfield.BitField, into uint64) {
+	rt.SetCaller(h.payee, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.payee)
+	ret := rt.Call(h.CompactLanes, &CompactLanesParams{Lanes: lanes, Into: into})
+	require.Nil(h.t, ret)
+	rt.Verify()
+}
+
 func (h *pcActorHarness) checkState(rt *mock.Runtime) {
 	var st State
 	rt.GetState(&st)
@@ -963,7 +1591,7 @@ func verifyInitialState(t *testing.T, rt *mock.Runtime, sender, receiver addr.Ad
 	rt.GetState(&st)
 	emptyArray, err := adt.StoreEmptyArray(adt.AsStore(rt), LaneStatesAmtBitwidth)
 	require.NoError(t, err)
-	expectedState := State{From: sender, To: receiver, ToSend: abi.NewTokenAmount(0), LaneStates: emptyArray}
+	expectedState := State{From: sender, To: receiver, ToSend: abi.NewTokenAmount(0), LaneStates: emptyArray, SettleDelay: SettleDelay}
 	verifyState(t, rt, -1, expectedState)
 }
 
@@ -975,6 +1603,7 @@ func verifyState(t *testing.T, rt *mock.Runtime, expLanes int, expectedState Sta
 	assert.Equal(t, expectedState.MinSettleHeight, st.MinSettleHeight)
 	assert.Equal(t, expectedState.SettlingAt, st.SettlingAt)
 	assert.Equal(t, expectedState.ToSend, st.ToSend)
+	assert.Equal(t, expectedState.SettleDelay, st.SettleDelay)
 	if expLanes >= 0 {
 		assertLaneStatesLength(t, rt, st.LaneStates, expLanes)
 		assert.True(t, reflect.DeepEqual(expectedState.LaneStates, st.LaneStates))
@@ -13,3 +13,12 @@ const SettleDelay = builtin.EpochsInHour * 12
 
 // Maximum size of a secret that can be submitted with a payment channel update (in bytes).
 const MaxSecretSize = 256
+
+// Maximum number of lanes that may be merged by a single CompactLanes message.
+const MaxCompactLanes = 1024
+
+// Maximum number of vouchers that may be redeemed by a single UpdateChannelStateMany message.
+const MaxVoucherBatchSize = 256
+
+// Maximum number of payees of a channel, including `To`.
+const MaxPayees = 16
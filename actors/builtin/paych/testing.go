@@ -2,6 +2,7 @@ package paych
 
 import (
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
@@ -23,6 +24,33 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 	acc.Require(st.To.Protocol() == address.ID, "to address is not ID address %v", st.To)
 	acc.Require(st.SettlingAt >= st.MinSettleHeight,
 		"channel is setting at epoch %d before min settle height %d", st.SettlingAt, st.MinSettleHeight)
+	acc.Require(st.SettleDelay >= SettleDelay, "channel settle delay %d shorter than minimum %d", st.SettleDelay, SettleDelay)
+	acc.Require(len(st.AdditionalPayees)+1 <= MaxPayees, "channel has %d payees, max %d", len(st.AdditionalPayees)+1, MaxPayees)
+
+	payees := map[address.Address]bool{st.To: true}
+	var ownedLanes []bitfield.BitField
+	for _, p := range st.AdditionalPayees {
+		acc.Require(p.Payee.Protocol() == address.ID, "payee address is not ID address %v", p.Payee)
+		acc.Require(!payees[p.Payee], "duplicate payee %v", p.Payee)
+		payees[p.Payee] = true
+		acc.Require(!p.ToSend.LessThan(big.Zero()), "payee %v to send is negative %v", p.Payee, p.ToSend)
+		ownedLanes = append(ownedLanes, p.Lanes)
+	}
+	if len(ownedLanes) > 0 {
+		union, err := bitfield.MultiMerge(ownedLanes...)
+		acc.RequireNoError(err, "error merging owned lanes")
+		if err == nil {
+			total, err := union.Count()
+			acc.RequireNoError(err, "error counting owned lanes")
+			sum := uint64(0)
+			for _, lanes := range ownedLanes {
+				count, err := lanes.Count()
+				acc.RequireNoError(err, "error counting owned lanes")
+				sum += count
+			}
+			acc.Require(total == sum, "lanes are owned by more than one payee")
+		}
+	}
 
 	if lanes, err := adt.AsArray(store, st.LaneStates, LaneStatesAmtBitwidth); err != nil {
 		acc.Addf("error loading lanes: %v", err)
@@ -30,14 +58,17 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 		var lane LaneState
 		err = lanes.ForEach(&lane, func(i int64) error {
 			acc.Require(lane.Redeemed.GreaterThan(big.Zero()), "land %d redeemed is not greater than zero %v", i, lane.Redeemed)
+			retired, err := st.RetiredLanes.IsSet(uint64(i))
+			acc.RequireNoError(err, "error checking retired lanes")
+			acc.Require(!retired, "lane %d is retired but has state", i)
 			paychSummary.Redeemed = big.Add(paychSummary.Redeemed, lane.Redeemed)
 			return nil
 		})
 		acc.RequireNoError(err, "error iterating lanes")
 	}
 
-	acc.Require(balance.GreaterThanEqual(st.ToSend),
-		"channel has insufficient funds to send (%v < %v)", balance, st.ToSend)
+	acc.Require(balance.GreaterThanEqual(st.TotalToSend()),
+		"channel has insufficient funds to send (%v < %v)", balance, st.TotalToSend())
 
 	return paychSummary, acc
 }
@@ -0,0 +1,300 @@
+package paych
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// The environment in which a voucher is validated.
+// On chain this is provided by the runtime; off chain a client provides values for the epoch
+// and channel balance at which it expects the voucher to be redeemed.
+type VoucherValidationEnv struct {
+	// ID address of the payment channel actor.
+	Channel addr.Address
+	// Epoch at which the voucher is redeemed.
+	Epoch abi.ChainEpoch
+	// Balance of the payment channel actor.
+	Balance abi.TokenAmount
+	// Resolves an address to an ID address.
+	ResolveAddress func(addr.Address) (addr.Address, bool)
+	// Verifies a signature over plaintext by a signer.
+	VerifySignature func(signature crypto.Signature, signer addr.Address, plaintext []byte) error
+	// Computes the blake2b hash of a secret.
+	HashBlake2b func(data []byte) [32]byte
+}
+
+func runtimeVoucherEnv(rt runtime.Runtime) *VoucherValidationEnv {
+	return &VoucherValidationEnv{
+		Channel:         rt.Receiver(),
+		Epoch:           rt.CurrEpoch(),
+		Balance:         rt.CurrentBalance(),
+		ResolveAddress:  rt.ResolveAddress,
+		VerifySignature: rt.VerifySignature,
+		HashBlake2b:     rt.HashBlake2b,
+	}
+}
+
+// Checks whether UpdateChannelState would accept a voucher submitted by a party to the channel,
+// given the channel state. The state is not modified.
+// This performs exactly the checks performed on chain, with the exception of a voucher's extra
+// verification method (if any), which invokes another actor and must be evaluated separately.
+// The returned error carries the exit code with which UpdateChannelState would abort.
+func ValidateVoucher(store adt.Store, st *State, env *VoucherValidationEnv, submitter addr.Address, params *UpdateChannelStateParams) error {
+	if !isParty(st, submitter) {
+		return exitcode.ErrForbidden.Wrapf("submitter %v is not a party to the channel", submitter)
+	}
+	if params.Sv.Signature == nil {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher has no signature")
+	}
+	if st.SettlingAt != 0 && env.Epoch >= st.SettlingAt {
+		return ErrChannelStateUpdateAfterSettled.Wrapf("no vouchers can be processed after SettlingAt epoch")
+	}
+	if err := checkVoucher(env, st, submitter, params); err != nil {
+		return err
+	}
+
+	lstates, err := adt.AsArray(store, st.LaneStates, LaneStatesAmtBitwidth)
+	if err != nil {
+		return exitcode.ErrIllegalState.Wrapf("failed to load lanes: %w", err)
+	}
+	// Redeem against a copy of the state, discarding the (unflushed) lane modifications.
+	stCopy := *st
+	return redeemVoucher(env, &stCopy, lstates, &params.Sv)
+}
+
+func isParty(st *State, a addr.Address) bool {
+	for _, party := range st.Parties() {
+		if party == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the index of the payee to which a voucher pays out, which is `To` unless the voucher names another payee.
+func voucherPayee(env *VoucherValidationEnv, st *State, sv *SignedVoucher) (int, error) {
+	if sv.Payee == nil {
+		return 0, nil
+	}
+	resolved, found := env.ResolveAddress(*sv.Payee)
+	if !found {
+		return 0, exitcode.ErrIllegalArgument.Wrapf("voucher payee %s does not resolve to an ID address", *sv.Payee)
+	}
+	payee, ok := st.payeeIndex(resolved)
+	if !ok {
+		return 0, exitcode.ErrIllegalArgument.Wrapf("voucher payee %s is not a payee of the channel", resolved)
+	}
+	return payee, nil
+}
+
+// Returns the party whose signature is required on a voucher for a payee: the payee if the voucher is
+// submitted by `From`, otherwise `From`, in which case the submitter must be the payee.
+func voucherSigner(st *State, submitter addr.Address, payee int) (addr.Address, error) {
+	payeeAddr := st.Payees()[payee]
+	if submitter == st.From {
+		return payeeAddr, nil
+	}
+	if submitter != payeeAddr {
+		return addr.Undef, exitcode.ErrForbidden.Wrapf("submitter %v is neither the payer nor the voucher payee %v", submitter, payeeAddr)
+	}
+	return st.From, nil
+}
+
+// Returns an error if a lane is owned by a payee other than the given one.
+func checkLaneOwner(st *State, lane uint64, payee int) error {
+	owner, err := st.laneOwner(lane)
+	if err != nil {
+		return exitcode.ErrIllegalState.Wrapf("failed to check owner of lane %d: %w", lane, err)
+	}
+	if owner != payee {
+		return exitcode.ErrIllegalArgument.Wrapf("lane %d is owned by another payee", lane)
+	}
+	return nil
+}
+
+// Returns the address which must have signed a voucher, and the bytes it must have signed.
+// The returned error carries the exit code with which to reject the voucher.
+func voucherSignatureRequest(env *VoucherValidationEnv, st *State, submitter addr.Address, params *UpdateChannelStateParams) (addr.Address, []byte, error) {
+	if len(params.Secret) > MaxSecretSize {
+		return addr.Undef, nil, exitcode.ErrIllegalArgument.Wrapf("secret must be at most 256 bytes long")
+	}
+
+	payee, err := voucherPayee(env, st, &params.Sv)
+	if err != nil {
+		return addr.Undef, nil, err
+	}
+	signer, err := voucherSigner(st, submitter, payee)
+	if err != nil {
+		return addr.Undef, nil, err
+	}
+
+	vb, err := VoucherSigningBytes(&params.Sv)
+	if err != nil {
+		return addr.Undef, nil, exitcode.ErrIllegalArgument.Wrapf("failed to serialize signedvoucher: %w", err)
+	}
+	return signer, vb, nil
+}
+
+// Checks a voucher's payee, signature, secret and time locks, independent of lane state.
+// The returned error carries the exit code with which to reject the voucher.
+func checkVoucher(env *VoucherValidationEnv, st *State, submitter addr.Address, params *UpdateChannelStateParams) error {
+	sv := &params.Sv
+
+	signer, vb, err := voucherSignatureRequest(env, st, submitter, params)
+	if err != nil {
+		return err
+	}
+
+	if err := env.VerifySignature(*sv.Signature, signer, vb); err != nil {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher signature invalid: %w", err)
+	}
+
+	svpchIDAddr, found := env.ResolveAddress(sv.ChannelAddr)
+	if !found {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher payment channel address %s does not resolve to an ID address", sv.ChannelAddr)
+	}
+	if env.Channel != svpchIDAddr {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher payment channel address %s does not match receiver %s", svpchIDAddr, env.Channel)
+	}
+
+	if env.Epoch < sv.TimeLockMin {
+		return exitcode.ErrIllegalArgument.Wrapf("cannot use this voucher yet!")
+	}
+
+	if sv.TimeLockMax != 0 && env.Epoch > sv.TimeLockMax {
+		return exitcode.ErrIllegalArgument.Wrapf("this voucher has expired!")
+	}
+
+	if sv.Amount.Sign() < 0 {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher amount must be non-negative, was %v", sv.Amount)
+	}
+
+	if len(sv.SecretHash) > 0 {
+		hashedSecret := env.HashBlake2b(params.Secret)
+		if !bytes.Equal(hashedSecret[:], sv.SecretHash) {
+			return exitcode.ErrIllegalArgument.Wrapf("incorrect secret!")
+		}
+	}
+	return nil
+}
+
+// Updates the channel state and lane states to redeem a validated voucher.
+// The returned error carries the exit code with which to reject the voucher.
+// If an error is returned the channel state is unchanged, but lane states may have been modified.
+func redeemVoucher(env *VoucherValidationEnv, st *State, lstates *adt.Array, sv *SignedVoucher) error {
+	laneFound := true
+
+	// Find the voucher lane, creating if necessary.
+	laneId := sv.Lane
+	if err := checkLaneNotRetired(st, laneId); err != nil {
+		return err
+	}
+	laneState, err := findLane(lstates, sv.Lane)
+	if err != nil {
+		return err
+	}
+	payee, err := voucherPayee(env, st, sv)
+	if err != nil {
+		return err
+	}
+
+	if laneState == nil {
+		laneState = &LaneState{
+			Redeemed: big.Zero(),
+			Nonce:    0,
+		}
+		laneFound = false
+	}
+
+	if laneFound {
+		if err := checkLaneOwner(st, laneId, payee); err != nil {
+			return err
+		}
+		if laneState.Nonce >= sv.Nonce {
+			return exitcode.ErrIllegalArgument.Wrapf("voucher has an outdated nonce, existing nonce: %d, voucher nonce: %d, cannot redeem",
+				laneState.Nonce, sv.Nonce)
+		}
+	}
+
+	// The next section actually calculates the payment amounts to update the payment channel state
+	// 1. (optional) sum already redeemed value of all merging lanes
+	redeemedFromOthers := big.Zero()
+	for _, merge := range sv.Merges {
+		if merge.Lane == sv.Lane {
+			return exitcode.ErrIllegalArgument.Wrapf("voucher cannot merge lanes into its own lane")
+		}
+		if err := checkLaneNotRetired(st, merge.Lane); err != nil {
+			return err
+		}
+
+		otherls, err := findLane(lstates, merge.Lane)
+		if err != nil {
+			return err
+		}
+		if otherls == nil {
+			return exitcode.ErrIllegalArgument.Wrapf("voucher specifies invalid merge lane %v", merge.Lane)
+		}
+		if err := checkLaneOwner(st, merge.Lane, payee); err != nil {
+			return err
+		}
+
+		if otherls.Nonce >= merge.Nonce {
+			return exitcode.ErrIllegalArgument.Wrapf("merged lane in voucher has outdated nonce, cannot redeem")
+		}
+
+		redeemedFromOthers = big.Add(redeemedFromOthers, otherls.Redeemed)
+		otherls.Nonce = merge.Nonce
+		if err := lstates.Set(merge.Lane, otherls); err != nil {
+			return exitcode.ErrIllegalState.Wrapf("failed to store lane %d: %w", merge.Lane, err)
+		}
+	}
+
+	// 2. To prevent double counting, remove already redeemed amounts (from
+	// voucher or other lanes) from the voucher amount
+	laneState.Nonce = sv.Nonce
+	balanceDelta := big.Sub(sv.Amount, big.Add(redeemedFromOthers, laneState.Redeemed))
+	// 3. set new redeemed value for merged-into lane
+	laneState.Redeemed = sv.Amount
+
+	newSendBalance := big.Add(st.payeeToSend(payee), balanceDelta)
+
+	// 4. check operation validity
+	if newSendBalance.LessThan(big.Zero()) {
+		return exitcode.ErrIllegalArgument.Wrapf("voucher would leave channel balance negative")
+	}
+	if big.Add(st.TotalToSend(), balanceDelta).GreaterThan(env.Balance) {
+		return exitcode.ErrIllegalArgument.Wrapf("not enough funds in channel to cover voucher")
+	}
+
+	// 5. add new redemption ToSend, assigning a new lane to the payee
+	var assigned []uint64
+	if !laneFound {
+		assigned = append(assigned, laneId)
+	}
+	if err := st.updatePayee(payee, newSendBalance, assigned...); err != nil {
+		return exitcode.ErrIllegalState.Wrapf("failed to assign lane %d: %w", laneId, err)
+	}
+
+	// update channel settlingAt and MinSettleHeight if delayed by voucher
+	if sv.MinSettleHeight != 0 {
+		if st.SettlingAt != 0 && st.SettlingAt < sv.MinSettleHeight {
+			st.SettlingAt = sv.MinSettleHeight
+		}
+		if st.MinSettleHeight < sv.MinSettleHeight {
+			st.MinSettleHeight = sv.MinSettleHeight
+		}
+	}
+
+	if err := lstates.Set(laneId, laneState); err != nil {
+		return exitcode.ErrIllegalState.Wrapf("failed to store lane %d: %w", laneId, err)
+	}
+	return nil
+}
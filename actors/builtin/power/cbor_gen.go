@@ -7,6 +7,7 @@ import (
 	"io"
 
 	abi "github.com/filecoin-project/go-state-types/abi"
+	network "github.com/filecoin-project/go-state-types/network"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
 )
@@ -537,3 +538,60 @@ func (t *CronEvent) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufOnNetworkVersionChangeParams = []byte{129}
+
+func (t *OnNetworkVersionChangeParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufOnNetworkVersionChangeParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewVersion (network.Version) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.NewVersion)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *OnNetworkVersionChangeParams) UnmarshalCBOR(r io.Reader) error {
+	*t = OnNetworkVersionChangeParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewVersion (network.Version) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.NewVersion = network.Version(extra)
+
+	}
+	return nil
+}
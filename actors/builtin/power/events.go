@@ -0,0 +1,17 @@
+package power
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// Type of the event emitted by the power actor when it creates a miner.
+// The event carries the ID address of the new miner under the key EventKeyMiner.
+const EventMinerCreated = "miner-created"
+
+const EventKeyMiner = "miner"
+
+func emitMinerEvent(rt Runtime, eventType string, miner addr.Address) {
+	builtin.NewEventBuilder(eventType).WithAddress(EventKeyMiner, miner).Emit(rt)
+}
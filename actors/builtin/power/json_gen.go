@@ -0,0 +1,80 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package power
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "TotalRawBytePower", Value: (*jsonenc.BigInt)(&t.TotalRawBytePower)},
+		{Name: "TotalBytesCommitted", Value: (*jsonenc.BigInt)(&t.TotalBytesCommitted)},
+		{Name: "TotalQualityAdjPower", Value: (*jsonenc.BigInt)(&t.TotalQualityAdjPower)},
+		{Name: "TotalQABytesCommitted", Value: (*jsonenc.BigInt)(&t.TotalQABytesCommitted)},
+		{Name: "TotalPledgeCollateral", Value: (*jsonenc.BigInt)(&t.TotalPledgeCollateral)},
+		{Name: "ThisEpochRawBytePower", Value: (*jsonenc.BigInt)(&t.ThisEpochRawBytePower)},
+		{Name: "ThisEpochQualityAdjPower", Value: (*jsonenc.BigInt)(&t.ThisEpochQualityAdjPower)},
+		{Name: "ThisEpochPledgeCollateral", Value: (*jsonenc.BigInt)(&t.ThisEpochPledgeCollateral)},
+		{Name: "ThisEpochQAPowerSmoothed", Value: (*jsonenc.FilterEstimate)(&t.ThisEpochQAPowerSmoothed)},
+		{Name: "MinerCount", Value: &t.MinerCount},
+		{Name: "MinerAboveMinPowerCount", Value: &t.MinerAboveMinPowerCount},
+		{Name: "CronEventQueue", Value: &t.CronEventQueue},
+		{Name: "FirstCronEpoch", Value: &t.FirstCronEpoch},
+		{Name: "Claims", Value: &t.Claims},
+		{Name: "ProofValidationBatch", Value: &t.ProofValidationBatch},
+	}
+}
+
+func (t Claim) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *Claim) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *Claim) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "WindowPoStProofType", Value: &t.WindowPoStProofType},
+		{Name: "RawBytePower", Value: (*jsonenc.BigInt)(&t.RawBytePower)},
+		{Name: "QualityAdjPower", Value: (*jsonenc.BigInt)(&t.QualityAdjPower)},
+	}
+}
+
+func (t CronEvent) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *CronEvent) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *CronEvent) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "MinerAddr", Value: &t.MinerAddr},
+		{Name: "CallbackPayload", Value: &t.CallbackPayload},
+	}
+}
+
+func (t OnNetworkVersionChangeParams) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *OnNetworkVersionChangeParams) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *OnNetworkVersionChangeParams) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "NewVersion", Value: &t.NewVersion},
+	}
+}
@@ -1,5 +1,7 @@
 package power
 
+import "github.com/filecoin-project/go-state-types/network"
+
 // The number of miners that must meet the consensus minimum miner power before that minimum power is enforced
 // as a condition of leader election.
 // This ensures a network still functions before any miners reach that threshold.
@@ -10,3 +12,8 @@ const ConsensusMinerMinMiners = 4 // PARAM_SPEC
 // This limits the number of proof partitions we may need to load in the cron call path.
 // Onboarding 1EiB/year requires at least 32 prove-commits per epoch.
 const MaxMinerProveCommitsPerEpoch = 200 // PARAM_SPEC
+
+// Network versions upon whose activation OnNetworkVersionChange re-seeds the smoothed quality-adjusted power
+// estimate from the current network power, discarding the velocity the estimate has accumulated.
+// An upgrade that changes how power is accounted can list its version here in place of bespoke migration code.
+var QAPowerEstimateReseedVersions = map[network.Version]struct{}{}
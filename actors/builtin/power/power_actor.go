@@ -7,6 +7,7 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 	rtt "github.com/filecoin-project/go-state-types/rt"
 	xerrors "golang.org/x/xerrors"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
 )
 
 type Runtime = runtime.Runtime
@@ -35,15 +37,17 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.CreateMiner,
-		3:                         a.UpdateClaimedPower,
-		4:                         a.EnrollCronEvent,
-		5:                         a.CronTick,
-		6:                         a.UpdatePledgeTotal,
-		7:                         nil, // deprecated
-		8:                         a.SubmitPoRepForBulkVerify,
-		9:                         a.CurrentTotalPower,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.CreateMiner,
+		3:                          a.UpdateClaimedPower,
+		4:                          a.EnrollCronEvent,
+		5:                          a.CronTick,
+		6:                          a.UpdatePledgeTotal,
+		7:                          nil, // deprecated
+		8:                          a.SubmitPoRepForBulkVerify,
+		9:                          a.CurrentTotalPower,
+		10:                         a.OnNetworkVersionChange,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -61,6 +65,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 // Storage miner actor constructor params are defined here so the power actor can send them to the init actor
 // to instantiate miners.
 // Changed in v3:
@@ -149,6 +158,7 @@ func (a Actor) CreateMiner(rt Runtime, params *CreateMinerParams) *CreateMinerRe
 		st.Claims, err = claims.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush claims")
 	})
+	emitMinerEvent(rt, EventMinerCreated, addresses.IDAddress)
 	return &CreateMinerReturn{
 		IDAddress:     addresses.IDAddress,
 		RobustAddress: addresses.RobustAddress,
@@ -217,9 +227,8 @@ func (a Actor) EnrollCronEvent(rt Runtime, params *EnrollCronEventParams) *abi.E
 func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.CronActorAddr)
 
-	var rewret reward.ThisEpochRewardReturn
-	rewretcode := rt.Send(builtin.RewardActorAddr, builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), &rewret)
-	builtin.RequireSuccess(rt, rewretcode, "failed to check epoch baseline power")
+	rewret := builtin.RequireSendAndDecode[reward.ThisEpochRewardReturn](rt, builtin.RewardActorAddr,
+		builtin.MethodsReward.ThisEpochReward, nil, big.Zero(), "failed to check epoch baseline power")
 
 	if err := a.processBatchProofVerifies(rt, rewret); err != nil {
 		rt.Log(rtt.ERROR, "unexpected error processing batch proof verifies: %s. Skipping all verification for epoch %d", err, rt.CurrEpoch())
@@ -334,6 +343,36 @@ func (a Actor) CurrentTotalPower(rt Runtime, _ *abi.EmptyValue) *CurrentTotalPow
 	}
 }
 
+type OnNetworkVersionChangeParams struct {
+	NewVersion network.Version
+}
+
+// Invoked by the system actor during a network upgrade, once the runtime reports the new network version.
+// Recomputes the power totals that depend on version-dependent consensus minimum power thresholds,
+// and re-seeds the smoothed power estimate if the new version is listed in QAPowerEstimateReseedVersions.
+// Repeating the call for the same version within an epoch has no further effect.
+func (a Actor) OnNetworkVersionChange(rt Runtime, params *OnNetworkVersionChangeParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
+	if params.NewVersion != rt.NetworkVersion() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "new network version %d does not match current network version %d",
+			params.NewVersion, rt.NetworkVersion())
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		claims, err := adt.AsMap(adt.AsStore(rt), st.Claims, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load claims")
+
+		err = st.recomputeClaimTotals(claims)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to recompute claim totals")
+
+		if _, ok := QAPowerEstimateReseedVersions[params.NewVersion]; ok {
+			st.ThisEpochQAPowerSmoothed = smoothing.NewEstimate(st.ThisEpochQualityAdjPower, big.Zero())
+		}
+	})
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Method utility functions
 ////////////////////////////////////////////////////////////////////////////////
@@ -417,7 +456,9 @@ func (a Actor) processBatchProofVerifies(rt Runtime, rewret reward.ThisEpochRewa
 		return stErr
 	}
 
+	endTrace := builtin.TraceGas(rt, runtime.GasOpVerifyProof, "BatchVerifySeals")
 	res, err := rt.BatchVerifySeals(verifies)
+	endTrace()
 	if err != nil {
 		return xerrors.Errorf("failed to batch verify: %w", err)
 	}
@@ -499,7 +540,7 @@ func (a Actor) processDeferredCronEvents(rt Runtime, rewret reward.ThisEpochRewa
 			}
 
 			if len(epochEvents) > 0 {
-				err = events.RemoveAll(epochKey(epoch))
+				err = events.RemoveAll(builtin.EpochKey(epoch))
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to clear cron events at %v", epoch)
 			} else {
 				rt.Log(rtt.DEBUG, "no epoch events were loaded")
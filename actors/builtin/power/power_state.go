@@ -2,7 +2,6 @@ package power
 
 import (
 	"fmt"
-	"reflect"
 
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
@@ -231,6 +230,42 @@ func (st *State) addToClaim(claims *adt.Map, miner addr.Address, power abi.Stora
 	return setClaim(claims, miner, &newClaim)
 }
 
+// Recomputes the power totals and the count of miners above the consensus minimum power from the claims,
+// under the current consensus minimum power thresholds.
+func (st *State) recomputeClaimTotals(claims *adt.Map) error {
+	committedRawPower := big.Zero()
+	committedQAPower := big.Zero()
+	rawPower := big.Zero()
+	qaPower := big.Zero()
+	aboveMinPowerCount := int64(0)
+
+	var claim Claim
+	if err := claims.ForEach(&claim, func(key string) error {
+		committedRawPower = big.Add(committedRawPower, claim.RawBytePower)
+		committedQAPower = big.Add(committedQAPower, claim.QualityAdjPower)
+
+		minPower, err := builtin.ConsensusMinerMinPower(claim.WindowPoStProofType)
+		if err != nil {
+			return xerrors.Errorf("could not get consensus miner min power: %w", err)
+		}
+		if claim.RawBytePower.GreaterThanEqual(minPower) {
+			aboveMinPowerCount++
+			rawPower = big.Add(rawPower, claim.RawBytePower)
+			qaPower = big.Add(qaPower, claim.QualityAdjPower)
+		}
+		return nil
+	}); err != nil {
+		return xerrors.Errorf("failed to iterate claims: %w", err)
+	}
+
+	st.TotalBytesCommitted = committedRawPower
+	st.TotalQABytesCommitted = committedQAPower
+	st.TotalRawBytePower = rawPower
+	st.TotalQualityAdjPower = qaPower
+	st.MinerAboveMinPowerCount = aboveMinPowerCount
+	return nil
+}
+
 func (st *State) updateStatsForNewMiner(windowPoStProof abi.RegisteredPoStProof) error {
 	minPower, err := builtin.ConsensusMinerMinPower(windowPoStProof)
 	if err != nil {
@@ -286,7 +321,7 @@ func (st *State) appendCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, eve
 		st.FirstCronEpoch = epoch
 	}
 
-	if err := events.Add(epochKey(epoch), event); err != nil {
+	if err := events.Add(builtin.EpochKey(epoch), event); err != nil {
 		return xerrors.Errorf("failed to store cron event at epoch %v for miner %v: %w", epoch, event, err)
 	}
 
@@ -294,14 +329,14 @@ func (st *State) appendCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, eve
 }
 
 func (st *State) updateSmoothedEstimate(delta abi.ChainEpoch) {
-	filterQAPower := smoothing.LoadFilter(st.ThisEpochQAPowerSmoothed, smoothing.DefaultAlpha, smoothing.DefaultBeta)
+	filterQAPower := smoothing.LoadEstimator(st.ThisEpochQAPowerSmoothed)
 	st.ThisEpochQAPowerSmoothed = filterQAPower.NextEstimate(st.ThisEpochQualityAdjPower, delta)
 }
 
 func loadCronEvents(mmap *adt.Multimap, epoch abi.ChainEpoch) ([]CronEvent, error) {
 	var events []CronEvent
 	var ev CronEvent
-	err := mmap.ForEach(epochKey(epoch), &ev, func(i int64) error {
+	err := mmap.ForEach(builtin.EpochKey(epoch), &ev, func(i int64) error {
 		events = append(events, ev)
 		return nil
 	})
@@ -329,16 +364,3 @@ func CurrentTotalPower(st *State) (abi.StoragePower, abi.StoragePower) {
 	}
 	return st.TotalRawBytePower, st.TotalQualityAdjPower
 }
-
-func epochKey(e abi.ChainEpoch) abi.Keyer {
-	return abi.IntKey(int64(e))
-}
-
-func init() {
-	// Check that ChainEpoch is indeed a signed integer to confirm that epochKey is making the right interpretation.
-	var e abi.ChainEpoch
-	if reflect.TypeOf(e).Kind() != reflect.Int64 {
-		panic("incorrect chain epoch encoding")
-	}
-
-}
@@ -11,6 +11,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 	cid "github.com/ipfs/go-cid"
 	assert "github.com/stretchr/testify/assert"
 	require "github.com/stretchr/testify/require"
@@ -538,6 +539,84 @@ func TestUpdatePledgeTotal(t *testing.T) {
 	})
 }
 
+func TestOnNetworkVersionChange(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+
+	t.Run("recomputes power totals from claims", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.createMinerBasic(rt, owner, owner, miner2)
+
+		minPower, err := builtin.ConsensusMinerMinPower(actor.windowPoStProof)
+		require.NoError(t, err)
+		actor.updateClaimedPower(rt, miner1, minPower, big.Mul(minPower, big.NewInt(2)))
+		actor.updateClaimedPower(rt, miner2, big.Div(minPower, big.NewInt(2)), big.Div(minPower, big.NewInt(2)))
+		expected := getState(rt)
+
+		// Corrupt the totals, as if derived under a different threshold.
+		st := getState(rt)
+		st.TotalRawBytePower = big.Zero()
+		st.TotalQualityAdjPower = big.Zero()
+		st.TotalBytesCommitted = big.Zero()
+		st.TotalQABytesCommitted = big.Zero()
+		st.MinerAboveMinPowerCount = 0
+		rt.ReplaceState(st)
+
+		rt.SetNetworkVersion(network.Version15)
+		actor.onNetworkVersionChange(rt, network.Version15)
+
+		st = getState(rt)
+		assert.Equal(t, expected.TotalRawBytePower, st.TotalRawBytePower)
+		assert.Equal(t, expected.TotalQualityAdjPower, st.TotalQualityAdjPower)
+		assert.Equal(t, expected.TotalBytesCommitted, st.TotalBytesCommitted)
+		assert.Equal(t, expected.TotalQABytesCommitted, st.TotalQABytesCommitted)
+		assert.Equal(t, int64(1), st.MinerAboveMinPowerCount)
+		// The smoothed estimate is untouched for versions not listed for re-seeding.
+		assert.Equal(t, expected.ThisEpochQAPowerSmoothed, st.ThisEpochQAPowerSmoothed)
+		actor.checkState(rt)
+	})
+
+	t.Run("re-seeds power estimate for listed versions", func(t *testing.T) {
+		power.QAPowerEstimateReseedVersions[network.Version15] = struct{}{}
+		defer delete(power.QAPowerEstimateReseedVersions, network.Version15)
+
+		rt, actor := basicPowerSetup(t)
+		qaPower := abi.NewStoragePower(1 << 40)
+		st := getState(rt)
+		st.ThisEpochQualityAdjPower = qaPower
+		rt.ReplaceState(st)
+
+		rt.SetNetworkVersion(network.Version15)
+		actor.onNetworkVersionChange(rt, network.Version15)
+
+		st = getState(rt)
+		assert.Equal(t, smoothing.NewEstimate(qaPower, big.Zero()), st.ThisEpochQAPowerSmoothed)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails if version is not the current network version", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		rt.SetNetworkVersion(network.Version15)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.onNetworkVersionChange(rt, network.Version14)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails if caller is not the system actor", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		rt.SetNetworkVersion(network.Version15)
+		rt.SetCaller(owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.OnNetworkVersionChange, &power.OnNetworkVersionChangeParams{NewVersion: network.Version15})
+		})
+		actor.checkState(rt)
+	})
+}
+
 func TestCron(t *testing.T) {
 	actor := newHarness(t)
 	miner1 := tutil.NewIDAddr(t, 101)
@@ -1369,6 +1448,14 @@ func (h *spActorHarness) updatePledgeTotal(rt *mock.Runtime, miner addr.Address,
 	require.EqualValues(h.t, big.Add(prev, delta), new)
 }
 
+func (h *spActorHarness) onNetworkVersionChange(rt *mock.Runtime, version network.Version) {
+	rt.SetCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	ret := rt.Call(h.OnNetworkVersionChange, &power.OnNetworkVersionChangeParams{NewVersion: version})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
 func (h *spActorHarness) currentPowerTotal(rt *mock.Runtime) *power.CurrentTotalPowerReturn {
 	rt.ExpectValidateCallerAny()
 	ret := rt.Call(h.CurrentTotalPower, nil).(*power.CurrentTotalPowerReturn)
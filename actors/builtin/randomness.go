@@ -0,0 +1,66 @@
+package builtin
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// Draws randomness from the runtime, remembering each value drawn so that repeated draws with the same
+// domain separation tag, epoch and entropy are served without another syscall.
+// A cache should be constructed for a single method invocation, and not retained beyond it.
+type RandomnessCache struct {
+	rt      runtime.Runtime
+	beacon  map[randomnessKey]abi.Randomness
+	tickets map[randomnessKey]abi.Randomness
+}
+
+type randomnessKey struct {
+	tag     crypto.DomainSeparationTag
+	epoch   abi.ChainEpoch
+	entropy string
+}
+
+func NewRandomnessCache(rt runtime.Runtime) *RandomnessCache {
+	return &RandomnessCache{
+		rt:      rt,
+		beacon:  make(map[randomnessKey]abi.Randomness),
+		tickets: make(map[randomnessKey]abi.Randomness),
+	}
+}
+
+// Returns randomness from the beacon, as for runtime.GetRandomnessFromBeacon.
+func (c *RandomnessCache) FromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	key := randomnessKey{tag, epoch, string(entropy)}
+	if r, ok := c.beacon[key]; ok {
+		return r
+	}
+	r := c.rt.GetRandomnessFromBeacon(tag, epoch, entropy)
+	c.beacon[key] = r
+	return r
+}
+
+// Returns randomness from the ticket chain, as for runtime.GetRandomnessFromTickets.
+func (c *RandomnessCache) FromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	key := randomnessKey{tag, epoch, string(entropy)}
+	if r, ok := c.tickets[key]; ok {
+		return r
+	}
+	r := c.rt.GetRandomnessFromTickets(tag, epoch, entropy)
+	c.tickets[key] = r
+	return r
+}
+
+// Returns the entropy identifying an actor in a randomness draw: the CBOR encoding of its address.
+// Aborts if the address cannot be encoded.
+func AddressEntropy(rt runtime.Runtime, a addr.Address) []byte {
+	var buf bytes.Buffer
+	err := a.MarshalCBOR(&buf)
+	RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to marshal address %v for randomness entropy", a)
+	return buf.Bytes()
+}
@@ -0,0 +1,71 @@
+package builtin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestRandomnessCache(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	other := tutil.NewIDAddr(t, 101)
+	tag := crypto.DomainSeparationTag_SealRandomness
+
+	var buf bytes.Buffer
+	require.NoError(t, receiver.MarshalCBOR(&buf))
+	entropy := buf.Bytes()
+
+	t.Run("address entropy is the address encoding", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			assert.Equal(t, entropy, builtin.AddressEntropy(rt, receiver))
+			assert.NotEqual(t, entropy, builtin.AddressEntropy(rt, other))
+			return nil
+		}, nil)
+	})
+
+	t.Run("repeated draws are served from the cache", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).WithEpoch(10).Build(t)
+		rt.ExpectGetRandomnessTickets(tag, 5, entropy, abi.Randomness("t5"))
+		rt.ExpectGetRandomnessBeacon(tag, 5, entropy, abi.Randomness("b5"))
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			cache := builtin.NewRandomnessCache(rt)
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, abi.Randomness("t5"), cache.FromTickets(tag, 5, entropy))
+				assert.Equal(t, abi.Randomness("b5"), cache.FromBeacon(tag, 5, entropy))
+			}
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("distinct draws query the runtime", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).WithEpoch(10).Build(t)
+		otherEntropy := []byte("other")
+		rt.ExpectGetRandomnessTickets(tag, 5, entropy, abi.Randomness("a"))
+		rt.ExpectGetRandomnessTickets(tag, 6, entropy, abi.Randomness("b"))
+		rt.ExpectGetRandomnessTickets(crypto.DomainSeparationTag_PoStChainCommit, 5, entropy, abi.Randomness("c"))
+		rt.ExpectGetRandomnessTickets(tag, 5, otherEntropy, abi.Randomness("d"))
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			cache := builtin.NewRandomnessCache(rt)
+			assert.Equal(t, abi.Randomness("a"), cache.FromTickets(tag, 5, entropy))
+			assert.Equal(t, abi.Randomness("b"), cache.FromTickets(tag, 6, entropy))
+			assert.Equal(t, abi.Randomness("c"), cache.FromTickets(crypto.DomainSeparationTag_PoStChainCommit, 5, entropy))
+			assert.Equal(t, abi.Randomness("d"), cache.FromTickets(tag, 5, otherEntropy))
+			assert.Equal(t, abi.Randomness("a"), cache.FromTickets(tag, 5, entropy))
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+}
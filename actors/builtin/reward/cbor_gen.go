@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{139}
+var lengthBufState = []byte{140}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -92,6 +92,11 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 	if err := t.BaselineTotal.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.TotalRecycledPenalties (big.Int) (struct)
+	if err := t.TotalRecycledPenalties.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -109,7 +114,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 11 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -243,6 +248,15 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.BaselineTotal: %w", err)
 		}
 
+	}
+	// t.TotalRecycledPenalties (big.Int) (struct)
+
+	{
+
+		if err := t.TotalRecycledPenalties.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalRecycledPenalties: %w", err)
+		}
+
 	}
 	return nil
 }
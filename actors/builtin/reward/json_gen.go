@@ -0,0 +1,32 @@
+// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.
+
+package reward
+
+import (
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+func (t State) MarshalJSON() ([]byte, error) {
+	return t.jsonFields().MarshalJSON()
+}
+
+func (t *State) UnmarshalJSON(b []byte) error {
+	return t.jsonFields().UnmarshalJSON(b)
+}
+
+func (t *State) jsonFields() jsonenc.Object {
+	return jsonenc.Object{
+		{Name: "CumsumBaseline", Value: (*jsonenc.BigInt)(&t.CumsumBaseline)},
+		{Name: "CumsumRealized", Value: (*jsonenc.BigInt)(&t.CumsumRealized)},
+		{Name: "EffectiveNetworkTime", Value: &t.EffectiveNetworkTime},
+		{Name: "EffectiveBaselinePower", Value: (*jsonenc.BigInt)(&t.EffectiveBaselinePower)},
+		{Name: "ThisEpochReward", Value: (*jsonenc.BigInt)(&t.ThisEpochReward)},
+		{Name: "ThisEpochRewardSmoothed", Value: (*jsonenc.FilterEstimate)(&t.ThisEpochRewardSmoothed)},
+		{Name: "ThisEpochBaselinePower", Value: (*jsonenc.BigInt)(&t.ThisEpochBaselinePower)},
+		{Name: "Epoch", Value: &t.Epoch},
+		{Name: "TotalStoragePowerReward", Value: (*jsonenc.BigInt)(&t.TotalStoragePowerReward)},
+		{Name: "SimpleTotal", Value: (*jsonenc.BigInt)(&t.SimpleTotal)},
+		{Name: "BaselineTotal", Value: (*jsonenc.BigInt)(&t.BaselineTotal)},
+		{Name: "TotalRecycledPenalties", Value: (*jsonenc.BigInt)(&t.TotalRecycledPenalties)},
+	}
+}
@@ -21,10 +21,12 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.AwardBlockReward,
-		3:                         a.ThisEpochReward,
-		4:                         a.UpdateNetworkKPI,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.AwardBlockReward,
+		3:                          a.ThisEpochReward,
+		4:                          a.UpdateNetworkKPI,
+		5:                          a.RecyclePenalty,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -42,6 +44,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 func (a Actor) Constructor(rt runtime.Runtime, currRealizedPower *abi.StoragePower) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 
@@ -179,3 +186,20 @@ func (a Actor) UpdateNetworkKPI(rt runtime.Runtime, currRealizedPower *abi.Stora
 	})
 	return nil
 }
+
+// Returns the funds sent with this message, which are a portion of a miner's penalty, to the storage
+// mining allocation from which block rewards are paid, rather than burning them.
+// This method is called only by miner actors, which determine the portion of each penalty to recycle.
+func (a Actor) RecyclePenalty(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.StorageMinerActorCodeID)
+	amount := rt.ValueReceived()
+	if amount.LessThanEqual(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "non-positive penalty %v to recycle", amount)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		st.TotalRecycledPenalties = big.Add(st.TotalRecycledPenalties, amount)
+	})
+	return nil
+}
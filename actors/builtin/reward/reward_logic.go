@@ -4,6 +4,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 
+	"github.com/filecoin-project/specs-actors/v7/actors/util/fixed"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
 )
 
@@ -31,8 +32,7 @@ func InitBaselinePower() abi.StoragePower {
 // Compute BaselinePower(t) from BaselinePower(t-1) with an additional multiplication
 // of the base exponent.
 func BaselinePowerFromPrev(prevEpochBaselinePower abi.StoragePower) abi.StoragePower {
-	thisEpochBaselinePower := big.Mul(prevEpochBaselinePower, BaselineExponent) // Q.0 * Q.128 => Q.128
-	return big.Rsh(thisEpochBaselinePower, math.Precision128)                   // Q.128 => Q.0
+	return fixed.FromRaw(BaselineExponent).MulInt(prevEpochBaselinePower).Floor()
 }
 
 // These numbers are estimates of the onchain constants.  They are good for initializing state in
@@ -93,16 +93,11 @@ func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta, simpleTotal, base
 // Computes baseline supply based on theta in Q.128 format.
 // Return is in Q.128 format
 func computeBaselineSupply(theta, baselineTotal big.Int) big.Int {
-	thetaLam := big.Mul(theta, Lambda)              // Q.128 * Q.128 => Q.256
-	thetaLam = big.Rsh(thetaLam, math.Precision128) // Q.256 >> 128 => Q.128
+	thetaLam := fixed.FromRaw(theta).Mul(fixed.FromRaw(Lambda))
 
-	eTL := big.NewFromGo(math.ExpNeg(thetaLam.Int)) // Q.128
+	eTL := fixed.FromRaw(big.NewFromGo(math.ExpNeg(thetaLam.Raw().Int)))
 
-	one := big.NewInt(1)
-	one = big.Lsh(one, math.Precision128) // Q.0 => Q.128
-	oneSub := big.Sub(one, eTL)           // Q.128
-
-	return big.Mul(baselineTotal, oneSub) // Q.0 * Q.128 => Q.128
+	return fixed.One().Sub(eTL).MulInt(baselineTotal).Raw()
 }
 
 // SlowConvenientBaselineForEpoch computes baseline power for use in epoch t
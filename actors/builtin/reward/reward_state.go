@@ -62,6 +62,10 @@ type State struct {
 	// into a code constant in a subsequent upgrade.
 	SimpleTotal   abi.TokenAmount
 	BaselineTotal abi.TokenAmount
+
+	// TotalRecycledPenalties tracks the total FIL of miner penalties returned to the
+	// storage mining allocation rather than burnt
+	TotalRecycledPenalties abi.TokenAmount
 }
 
 func ConstructState(currRealizedPower abi.StoragePower) *State {
@@ -80,6 +84,8 @@ func ConstructState(currRealizedPower abi.StoragePower) *State {
 
 		SimpleTotal:   DefaultSimpleTotal,
 		BaselineTotal: DefaultBaselineTotal,
+
+		TotalRecycledPenalties: big.Zero(),
 	}
 
 	st.updateToNextEpochWithReward(currRealizedPower)
@@ -113,6 +119,6 @@ func (st *State) updateToNextEpochWithReward(currRealizedPower abi.StoragePower)
 }
 
 func (st *State) updateSmoothedEstimates(delta abi.ChainEpoch) {
-	filterReward := smoothing.LoadFilter(st.ThisEpochRewardSmoothed, smoothing.DefaultAlpha, smoothing.DefaultBeta)
+	filterReward := smoothing.LoadEstimator(st.ThisEpochRewardSmoothed)
 	st.ThisEpochRewardSmoothed = filterReward.NextEstimate(st.ThisEpochReward, delta)
 }
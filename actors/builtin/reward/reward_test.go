@@ -252,6 +252,48 @@ func TestSuccessiveKPIUpdates(t *testing.T) {
 
 }
 
+func TestRecyclePenalty(t *testing.T) {
+	actor := rewardHarness{reward.Actor{}, t}
+	builder := mock.NewBuilder(builtin.RewardActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	miner := tutil.NewIDAddr(t, 1000)
+	power := abi.NewStoragePower(1 << 50)
+
+	t.Run("tracks recycled penalties", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, &power)
+		assert.Equal(t, big.Zero(), getState(rt).TotalRecycledPenalties)
+
+		actor.recyclePenalty(rt, miner, abi.NewTokenAmount(100))
+		actor.recyclePenalty(rt, miner, abi.NewTokenAmount(20))
+		assert.Equal(t, abi.NewTokenAmount(120), getState(rt).TotalRecycledPenalties)
+	})
+
+	t.Run("rejects non-miner caller", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, &power)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 1001), builtin.AccountActorCodeID)
+		rt.SetReceived(abi.NewTokenAmount(100))
+		rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.RecyclePenalty, nil)
+		})
+	})
+
+	t.Run("rejects zero penalty", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, &power)
+
+		rt.SetCaller(miner, builtin.StorageMinerActorCodeID)
+		rt.SetReceived(big.Zero())
+		rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "non-positive penalty", func() {
+			rt.Call(actor.RecyclePenalty, nil)
+		})
+	})
+}
+
 type rewardHarness struct {
 	reward.Actor
 	t testing.TB
@@ -289,6 +331,16 @@ func (h *rewardHarness) awardBlockReward(rt *mock.Runtime, miner address.Address
 	rt.Verify()
 }
 
+func (h *rewardHarness) recyclePenalty(rt *mock.Runtime, miner address.Address, amount abi.TokenAmount) {
+	rt.SetCaller(miner, builtin.StorageMinerActorCodeID)
+	rt.SetReceived(amount)
+	rt.SetBalance(big.Add(rt.Balance(), amount))
+	rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
+	ret := rt.Call(h.RecyclePenalty, nil)
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
 func (h *rewardHarness) thisEpochReward(rt *mock.Runtime) *reward.ThisEpochRewardReturn {
 	rt.ExpectValidateCallerAny()
 
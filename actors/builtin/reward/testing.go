@@ -15,8 +15,12 @@ var StorageMiningAllocationCheck = big.Mul(big.NewInt(1_100_000_000), FIL)
 func CheckStateInvariants(st *State, store adt.Store, priorEpoch abi.ChainEpoch, balance abi.TokenAmount) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
 
+	acc.Require(st.TotalRecycledPenalties.GreaterThanEqual(big.Zero()), "negative recycled penalties %v", st.TotalRecycledPenalties)
+
+	// Recycled penalties are added to the storage mining allocation.
 	// Can't assert equality because anyone can send funds to reward actor (and already have on mainnet)
-	acc.Require(big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(StorageMiningAllocationCheck), "reward given %v + reward left %v < storage mining allocation %v", st.TotalStoragePowerReward, balance, StorageMiningAllocationCheck)
+	allocation := big.Add(StorageMiningAllocationCheck, st.TotalRecycledPenalties)
+	acc.Require(big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(allocation), "reward given %v + reward left %v < storage mining allocation %v + recycled penalties %v", st.TotalStoragePowerReward, balance, StorageMiningAllocationCheck, st.TotalRecycledPenalties)
 
 	acc.Require(st.Epoch == priorEpoch+1, "reward state epoch %d does not match priorEpoch+1 %d", st.Epoch, priorEpoch+1)
 	acc.Require(st.EffectiveNetworkTime <= st.Epoch, "effective network time greater than state epoch")
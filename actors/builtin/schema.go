@@ -0,0 +1,153 @@
+package builtin
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+)
+
+// Types with their own CBOR encoding, declared as named bytes in the schema.
+var schemaByteTypes = map[reflect.Type]string{
+	reflect.TypeOf(big.Int{}):           "BigInt",
+	reflect.TypeOf(addr.Address{}):      "Address",
+	reflect.TypeOf(bitfield.BitField{}): "BitField",
+	reflect.TypeOf(crypto.Signature{}):  "Signature",
+}
+
+var cidType = reflect.TypeOf(cid.Cid{})
+
+// Matches the major version of a specs-actors package path, absent for v0.
+var actorsVersionPattern = regexp.MustCompile(`^github\.com/filecoin-project/specs-actors(/v(\d+))?/`)
+
+// Accumulates IPLD schema declarations of Go types encoded by cbor-gen.
+type SchemaBuilder struct {
+	names map[reflect.Type]string // schema names of declared struct types
+	decls map[string]string       // declarations of struct types by name
+}
+
+func NewSchemaBuilder() *SchemaBuilder {
+	return &SchemaBuilder{
+		names: make(map[reflect.Type]string),
+		decls: make(map[string]string),
+	}
+}
+
+// Returns the schema type name of a parameter or return type, declaring it and the types it references.
+func (b *SchemaBuilder) TypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name, ok := schemaByteTypes[t]; ok {
+		return name
+	}
+	if t.Kind() == reflect.Struct && t != cidType {
+		return b.declareStruct(t)
+	}
+	// Scalars and lists are not named in the schema, so are referenced by their representation.
+	return b.ref(t)
+}
+
+// Returns the schema type expression for a field or element of a type.
+func (b *SchemaBuilder) ref(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "nullable " + b.ref(t.Elem())
+	}
+	if name, ok := schemaByteTypes[t]; ok {
+		return name
+	}
+	if t == cidType {
+		return "&Any"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "Bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int"
+	case reflect.String:
+		return "String"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "Bytes"
+		}
+		return "[" + b.ref(t.Elem()) + "]"
+	case reflect.Map:
+		return "{" + b.ref(t.Key()) + ":" + b.ref(t.Elem()) + "}"
+	case reflect.Struct:
+		return b.declareStruct(t)
+	}
+	return "Any"
+}
+
+func (b *SchemaBuilder) declareStruct(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := schemaStructName(t)
+	b.names[t] = name
+
+	var decl strings.Builder
+	fmt.Fprintf(&decl, "type %s struct {\n", name)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported fields are not encoded
+		}
+		fmt.Fprintf(&decl, "\t%s %s\n", f.Name, b.ref(f.Type))
+	}
+	decl.WriteString("} representation tuple")
+	b.decls[name] = decl.String()
+	return name
+}
+
+// Returns the schema document, with declarations ordered by name.
+func (b *SchemaBuilder) String() string {
+	names := make([]string, 0, len(b.decls))
+	for name := range b.decls { //nolint:nomaprange
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	byteNames := make([]string, 0, len(schemaByteTypes))
+	for _, name := range schemaByteTypes { //nolint:nomaprange
+		byteNames = append(byteNames, name)
+	}
+	sort.Strings(byteNames)
+
+	var out strings.Builder
+	for _, name := range byteNames {
+		fmt.Fprintf(&out, "type %s bytes\n\n", name)
+	}
+	for i, name := range names {
+		if i > 0 {
+			out.WriteString("\n\n")
+		}
+		out.WriteString(b.decls[name])
+	}
+	out.WriteString("\n")
+	return out.String()
+}
+
+// Names a struct type by its package and type name, e.g. MinerPowerPair, with the actors version appended for
+// types defined by prior versions of specs-actors, e.g. MinerTerminateSectorsParamsV0, so names are unique.
+func schemaStructName(t reflect.Type) string {
+	pkg := t.String()[:strings.LastIndexByte(t.String(), '.')]
+	pkg = strings.TrimSuffix(pkg, "_")
+	name := strings.ToUpper(pkg[:1]) + pkg[1:] + t.Name()
+	if m := actorsVersionPattern.FindStringSubmatch(t.PkgPath()); m != nil && m[2] != "7" {
+		version := m[2]
+		if version == "" {
+			version = "0"
+		}
+		name += "V" + version
+	}
+	return name
+}
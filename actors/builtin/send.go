@@ -0,0 +1,39 @@
+package builtin
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// Sends a message and decodes its return value as a T.
+// If the send fails, returns the zero value of T along with the exit code.
+// The type parameter PT is inferred, so callers name only the return type, e.g.
+//
+//	ret, code := SendAndDecode[reward.ThisEpochRewardReturn](rt, RewardActorAddr, MethodsReward.ThisEpochReward, nil, big.Zero())
+func SendAndDecode[T any, PT interface {
+	*T
+	cbor.Er
+}](rt runtime.Runtime, to addr.Address, method abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount) (T, exitcode.ExitCode) {
+	var ret T
+	code := rt.Send(to, method, params, value, PT(&ret))
+	if !code.IsSuccess() {
+		var zero T
+		return zero, code
+	}
+	return ret, code
+}
+
+// Sends a message and decodes its return value as a T, aborting with the exit code and a formatted message
+// if the send fails.
+func RequireSendAndDecode[T any, PT interface {
+	*T
+	cbor.Er
+}](rt runtime.Runtime, to addr.Address, method abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, msg string, args ...interface{}) T {
+	ret, code := SendAndDecode[T, PT](rt, to, method, params, value)
+	RequireSuccess(rt, code, msg, args...)
+	return ret
+}
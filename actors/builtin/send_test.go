@@ -0,0 +1,64 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestSendAndDecode(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	params := &market.ComputeDataCommitmentParams{}
+	expected := market.ComputeDataCommitmentReturn{
+		CommDs: []cbg.CborCid{cbg.CborCid(tutil.MakeCID("commd", &market.PieceCIDPrefix))},
+	}
+
+	t.Run("decodes the return value", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero(), &expected, exitcode.Ok)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			ret, code := builtin.SendAndDecode[market.ComputeDataCommitmentReturn](rt, builtin.StorageMarketActorAddr,
+				builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero())
+			assert.Equal(t, exitcode.Ok, code)
+			assert.Equal(t, expected, ret)
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("returns the zero value on failure", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero(), &expected, exitcode.ErrForbidden)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			ret, code := builtin.SendAndDecode[market.ComputeDataCommitmentReturn](rt, builtin.StorageMarketActorAddr,
+				builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero())
+			assert.Equal(t, exitcode.ErrForbidden, code)
+			assert.Equal(t, market.ComputeDataCommitmentReturn{}, ret)
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("aborts with the exit code on failure", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero(), &expected, exitcode.ErrForbidden)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "failed to compute data commitment", func() {
+			rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+				builtin.RequireSendAndDecode[market.ComputeDataCommitmentReturn](rt, builtin.StorageMarketActorAddr,
+					builtin.MethodsMarket.ComputeDataCommitment, params, big.Zero(), "failed to compute data commitment")
+				return nil
+			}, nil)
+		})
+		rt.Verify()
+	})
+}
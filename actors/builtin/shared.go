@@ -156,3 +156,12 @@ func (d *Discard) UnmarshalCBOR(_ io.Reader) error {
 	// deserialization is a noop
 	return nil
 }
+
+// Reports the start of a significant operation to the runtime, if it is a runtime.GasTracer, returning a
+// function to be called when the operation completes.
+func TraceGas(rt runtime.Runtime, op runtime.GasOperation, name string) (end func()) {
+	if tracer, ok := rt.(runtime.GasTracer); ok {
+		return tracer.TraceGas(op, name)
+	}
+	return func() {}
+}
@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// Collects signature verifications to be submitted to the runtime together.
+// The batch is verified in one call if the runtime is a runtime.BatchSignatureVerifier,
+// and otherwise each signature is verified in turn.
+type SignatureBatch struct {
+	verifications []runtime.SignatureVerification
+}
+
+// Adds a verification to the batch, returning the index of its result from Verify.
+func (b *SignatureBatch) Add(signature crypto.Signature, signer addr.Address, plaintext []byte) int {
+	b.verifications = append(b.verifications, runtime.SignatureVerification{
+		Signature: signature,
+		Signer:    signer,
+		Plaintext: plaintext,
+	})
+	return len(b.verifications) - 1
+}
+
+// The number of verifications in the batch.
+func (b *SignatureBatch) Len() int {
+	return len(b.verifications)
+}
+
+// Verifies all signatures in the batch, returning one result per verification in the order they were added.
+// Each result is nil if the signature is valid.
+func (b *SignatureBatch) Verify(rt runtime.Runtime) []error {
+	if len(b.verifications) == 0 {
+		return nil
+	}
+	if verifier, ok := rt.(runtime.BatchSignatureVerifier); ok {
+		results := verifier.BatchVerifySignatures(b.verifications)
+		RequireState(rt, len(results) == len(b.verifications), "batch signature verification returned %d results for %d signatures",
+			len(results), len(b.verifications))
+		return results
+	}
+	results := make([]error, len(b.verifications))
+	for i, v := range b.verifications {
+		results[i] = rt.VerifySignature(v.Signature, v.Signer, v.Plaintext)
+	}
+	return results
+}
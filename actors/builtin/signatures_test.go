@@ -0,0 +1,86 @@
+package builtin_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// A runtime which verifies signatures in batches, accepting only those with non-empty data.
+type batchVerifyingRuntime struct {
+	runtime.Runtime
+	batches [][]runtime.SignatureVerification
+}
+
+func (rt *batchVerifyingRuntime) BatchVerifySignatures(verifications []runtime.SignatureVerification) []error {
+	rt.batches = append(rt.batches, verifications)
+	results := make([]error, len(verifications))
+	for i, v := range verifications {
+		if len(v.Signature.Data) == 0 {
+			results[i] = fmt.Errorf("bad signature")
+		}
+	}
+	return results
+}
+
+func TestSignatureBatch(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	signer1 := tutil.NewIDAddr(t, 101)
+	signer2 := tutil.NewIDAddr(t, 102)
+	good := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("good")}
+	bad := crypto.Signature{Type: crypto.SigTypeBLS}
+
+	t.Run("verifies each signature if the runtime cannot batch", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.ExpectVerifySignature(good, signer1, []byte("one"), nil)
+		rt.ExpectVerifySignature(bad, signer2, []byte("two"), fmt.Errorf("bad signature"))
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			var batch builtin.SignatureBatch
+			assert.Equal(t, 0, batch.Add(good, signer1, []byte("one")))
+			assert.Equal(t, 1, batch.Add(bad, signer2, []byte("two")))
+			results := batch.Verify(rt)
+			require.Len(t, results, 2)
+			assert.NoError(t, results[0])
+			assert.Error(t, results[1])
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("verifies signatures together if the runtime can batch", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			brt := &batchVerifyingRuntime{Runtime: rt}
+			var batch builtin.SignatureBatch
+			batch.Add(bad, signer1, []byte("one"))
+			batch.Add(good, signer2, []byte("two"))
+			results := batch.Verify(brt)
+			require.Len(t, results, 2)
+			assert.Error(t, results[0])
+			assert.NoError(t, results[1])
+			require.Len(t, brt.batches, 1)
+			assert.Len(t, brt.batches[0], 2)
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		rt := mock.NewBuilder(receiver).Build(t)
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			var batch builtin.SignatureBatch
+			assert.Empty(t, batch.Verify(rt))
+			return nil
+		}, nil)
+	})
+}
@@ -14,6 +14,11 @@ var (
 	StoragePowerActorAddr     = mustMakeAddress(4)
 	StorageMarketActorAddr    = mustMakeAddress(5)
 	VerifiedRegistryActorAddr = mustMakeAddress(6)
+	// Actor of the network governance, which manages cron entries and approves pausing miners.
+	// It is created at genesis (on mainnet, as the multisig holding the verified registry root key), or by the
+	// nv15 migration as a multisig of configured signers for a network whose genesis did not create it.
+	// Its ID is reserved below FirstNonSingletonActorId so that it is never allocated by the init actor.
+	GovernanceActorAddr = mustMakeAddress(80)
 	// Distinguished AccountActor that is the destination of all burnt funds.
 	BurntFundsActorAddr = mustMakeAddress(99)
 )
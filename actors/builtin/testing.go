@@ -1,17 +1,74 @@
 package builtin
 
 import (
+	"encoding/json"
 	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
 )
 
+// Severity of an accumulated message.
+type Severity int
+
+const (
+	// A violated invariant.
+	SeverityError Severity = iota
+	// A condition that is suspicious but does not by itself indicate a violation.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "error":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	default:
+		return xerrors.Errorf("unknown severity %q", string(text))
+	}
+	return nil
+}
+
+// A single accumulated message along with its structured context.
+type Record struct {
+	// The actor to which the message pertains, if known.
+	Actor *addr.Address `json:",omitempty"`
+	// Identifier of the check that produced the message, formed by joining the names given to
+	// WithCheck() (and Report()) with "/", e.g. "miner/deadline". Empty if no check was named.
+	Check    string
+	Severity Severity
+	// The full message text, including any prefixes.
+	Message string
+	// Optional values relevant to the message, for consumers that don't want to parse the text.
+	Details map[string]interface{} `json:",omitempty"`
+}
+
 // Accumulates a sequence of messages (e.g. validation failures).
 type MessageAccumulator struct {
 	// Accumulated messages.
 	// This is a pointer to support accumulators derived from `WithPrefix()` accumulating to
 	// the same underlying collection.
-	msgs *[]string
+	records *[]Record
 	// Optional prefix to all new messages, e.g. describing higher level context.
 	prefix string
+	// Optional actor and check to which all new messages pertain.
+	actor *addr.Address
+	check string
 }
 
 // Returns a new accumulator backed by the same collection, that will prefix each new message with
@@ -19,26 +76,64 @@ type MessageAccumulator struct {
 func (ma *MessageAccumulator) WithPrefix(format string, args ...interface{}) *MessageAccumulator {
 	ma.initialize()
 	return &MessageAccumulator{
-		msgs:   ma.msgs,
-		prefix: ma.prefix + fmt.Sprintf(format, args...),
+		records: ma.records,
+		prefix:  ma.prefix + fmt.Sprintf(format, args...),
+		actor:   ma.actor,
+		check:   ma.check,
 	}
 }
 
+// Returns a new accumulator backed by the same collection, that will attribute each new message to
+// an actor and prefix it with the actor's address.
+func (ma *MessageAccumulator) WithActor(a addr.Address) *MessageAccumulator {
+	acc := ma.WithPrefix("%v ", a)
+	acc.actor = &a
+	return acc
+}
+
+// Returns a new accumulator backed by the same collection, that will attribute each new message to
+// a named check (nested within any check of this accumulator) and prefix it with the name.
+func (ma *MessageAccumulator) WithCheck(name string) *MessageAccumulator {
+	acc := ma.WithPrefix("%s: ", name)
+	acc.check = joinCheck(ma.check, name)
+	return acc
+}
+
 func (ma *MessageAccumulator) IsEmpty() bool {
-	return ma.msgs == nil || len(*ma.msgs) == 0
+	return ma.records == nil || len(*ma.records) == 0
 }
 
 func (ma *MessageAccumulator) Messages() []string {
-	if ma.msgs == nil {
+	if ma.records == nil {
 		return nil
 	}
-	return (*ma.msgs)[:]
+	msgs := make([]string, len(*ma.records))
+	for i, r := range *ma.records {
+		msgs[i] = r.Message
+	}
+	return msgs
+}
+
+// Returns the accumulated messages with their structured context.
+func (ma *MessageAccumulator) Records() []Record {
+	if ma.records == nil {
+		return []Record{}
+	}
+	return append([]Record{}, *ma.records...)
+}
+
+// Encodes the accumulated records as a JSON array.
+func (ma *MessageAccumulator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ma.Records())
 }
 
 // Adds messages to the accumulator.
 func (ma *MessageAccumulator) Add(msg string) {
-	ma.initialize()
-	*ma.msgs = append(*ma.msgs, ma.prefix+msg)
+	ma.append(Record{
+		Check:    ma.check,
+		Severity: SeverityError,
+		Message:  msg,
+	})
 }
 
 // Adds a message to the accumulator
@@ -46,13 +141,25 @@ func (ma *MessageAccumulator) Addf(format string, args ...interface{}) {
 	ma.Add(fmt.Sprintf(format, args...))
 }
 
+// Adds a message with an explicit severity, check name (nested within any check of this accumulator)
+// and details to the accumulator.
+func (ma *MessageAccumulator) Report(severity Severity, check string, details map[string]interface{}, format string, args ...interface{}) {
+	ma.append(Record{
+		Check:    joinCheck(ma.check, check),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Details:  details,
+	})
+}
+
 // Adds messages from another accumulator to this one.
 func (ma *MessageAccumulator) AddAll(other *MessageAccumulator) {
-	if other.msgs == nil {
+	if other.records == nil {
 		return
 	}
-	for _, msg := range *other.msgs {
-		ma.Add(msg)
+	for _, r := range *other.records {
+		r.Check = joinCheck(ma.check, r.Check)
+		ma.append(r)
 	}
 }
 
@@ -71,8 +178,29 @@ func (ma *MessageAccumulator) RequireNoError(err error, msg string, args ...inte
 	}
 }
 
+// Appends a record, applying this accumulator's prefix and actor.
+// The record's check is expected to already be qualified.
+func (ma *MessageAccumulator) append(r Record) {
+	ma.initialize()
+	r.Message = ma.prefix + r.Message
+	if r.Actor == nil {
+		r.Actor = ma.actor
+	}
+	*ma.records = append(*ma.records, r)
+}
+
 func (ma *MessageAccumulator) initialize() {
-	if ma.msgs == nil {
-		ma.msgs = &[]string{}
+	if ma.records == nil {
+		ma.records = &[]Record{}
+	}
+}
+
+func joinCheck(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
 	}
+	return parent + "/" + name
 }
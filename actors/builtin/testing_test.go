@@ -1,11 +1,14 @@
 package builtin_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
 )
 
 func TestMessageAccumulator(t *testing.T) {
@@ -56,4 +59,59 @@ func TestMessageAccumulator(t *testing.T) {
 
 		assert.Equal(t, []string{"Aa1", "Aa2", "BAa1", "BAa2"}, acc.Messages())
 	})
+	t.Run("records", func(t *testing.T) {
+		actor := tutil.NewIDAddr(t, 100)
+		acc := &builtin.MessageAccumulator{}
+		assert.Equal(t, []builtin.Record{}, acc.Records())
+
+		acc.Add("top")
+		minerAcc := acc.WithActor(actor).WithCheck("miner")
+		minerAcc.WithPrefix("deadline 1: ").Add("bad")
+		minerAcc.Report(builtin.SeverityWarning, "fees", map[string]interface{}{"debt": 5}, "fee debt %d", 5)
+
+		other := &builtin.MessageAccumulator{}
+		other.WithCheck("power").Add("claim")
+		acc.WithCheck("cross").AddAll(other)
+
+		assert.Equal(t, []string{
+			"top",
+			actor.String() + " miner: deadline 1: bad",
+			actor.String() + " miner: fee debt 5",
+			"cross: power: claim",
+		}, acc.Messages())
+
+		records := acc.Records()
+		require.Len(t, records, 4)
+		assert.Nil(t, records[0].Actor)
+		assert.Equal(t, "", records[0].Check)
+		assert.Equal(t, actor, *records[1].Actor)
+		assert.Equal(t, "miner", records[1].Check)
+		assert.Equal(t, builtin.SeverityError, records[1].Severity)
+		assert.Equal(t, "miner/fees", records[2].Check)
+		assert.Equal(t, builtin.SeverityWarning, records[2].Severity)
+		assert.Equal(t, map[string]interface{}{"debt": 5}, records[2].Details)
+		assert.Nil(t, records[3].Actor)
+		assert.Equal(t, "cross/power", records[3].Check)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		acc := &builtin.MessageAccumulator{}
+		out, err := json.Marshal(acc)
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(out))
+
+		actor := tutil.NewIDAddr(t, 100)
+		acc.WithActor(actor).WithCheck("miner").Report(builtin.SeverityWarning, "", map[string]interface{}{"n": 1}, "oops")
+		out, err = json.Marshal(acc)
+		require.NoError(t, err)
+
+		var decoded []builtin.Record
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		require.Len(t, decoded, 1)
+		assert.Equal(t, actor, *decoded[0].Actor)
+		assert.Equal(t, "miner", decoded[0].Check)
+		assert.Equal(t, builtin.SeverityWarning, decoded[0].Severity)
+		assert.Equal(t, actor.String()+" miner: oops", decoded[0].Message)
+		assert.Equal(t, map[string]interface{}{"n": 1.0}, decoded[0].Details)
+	})
 }
@@ -0,0 +1,26 @@
+package verifreg
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// Types of the events emitted by the verified registry actor when verifiers and clients change.
+// Verifier events carry the ID address of the verifier under the key EventKeyVerifier, and
+// client events carry the ID address of the client under the key EventKeyClient.
+const (
+	EventVerifierAdded   = "verifier-added"
+	EventVerifierRemoved = "verifier-removed"
+	EventClientAdded     = "verified-client-added"
+)
+
+const (
+	EventKeyVerifier = "verifier"
+	EventKeyClient   = "client"
+)
+
+func emitAddressEvent(rt runtime.Runtime, eventType string, key string, address addr.Address) {
+	builtin.NewEventBuilder(eventType).WithAddress(key, address).Emit(rt)
+}
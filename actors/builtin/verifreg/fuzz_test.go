@@ -0,0 +1,129 @@
+package verifreg_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/harness"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Fuzzes the methods that move DataCap between verifiers, clients and delegates, each called by a caller it
+// permits with parameters decoded from the input. Each call must either abort or leave the state consistent.
+func FuzzDataCapAccounting(f *testing.F) {
+	root, verifier := tutil.NewIDAddr(f, 101), tutil.NewIDAddr(f, 102)
+	client, delegate := tutil.NewIDAddr(f, 103), tutil.NewIDAddr(f, 104)
+	type call struct {
+		method     abi.MethodNum
+		caller     addr.Address
+		callerCode cid.Cid
+		expect     func(h *harness.Harness)
+	}
+	byRoot := func(h *harness.Harness) { h.ExpectValidateCallerAddr(root) }
+	byMarket := func(h *harness.Harness) { h.ExpectValidateCallerAddr(builtin.StorageMarketActorAddr) }
+	calls := []call{
+		{builtin.MethodsVerifiedRegistry.AddVerifier, root, builtin.AccountActorCodeID, byRoot},
+		{builtin.MethodsVerifiedRegistry.RemoveVerifier, root, builtin.AccountActorCodeID, byRoot},
+		{builtin.MethodsVerifiedRegistry.AddVerifiedClient, verifier, builtin.AccountActorCodeID, func(h *harness.Harness) {
+			h.ExpectValidateCallerAny()
+		}},
+		{builtin.MethodsVerifiedRegistry.UseBytes, builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID, byMarket},
+		{builtin.MethodsVerifiedRegistry.RestoreBytes, builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID, byMarket},
+		{builtin.MethodsVerifiedRegistry.SetDataCapAllowance, client, builtin.AccountActorCodeID, func(h *harness.Harness) {
+			h.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		}},
+		{builtin.MethodsVerifiedRegistry.UseBytesDelegated, builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID, byMarket},
+	}
+	size := verifreg.MinVerifiedDealSize
+	seeds := []cbor.Marshaler{
+		&verifreg.AddVerifierParams{Address: delegate, Allowance: size},
+		&verifier,
+		&verifreg.AddVerifiedClientParams{Address: client, Allowance: size},
+		&verifreg.UseBytesParams{Address: client, DealSize: size},
+		&verifreg.RestoreBytesParams{Address: client, DealSize: size},
+		&verifreg.SetDataCapAllowanceParams{Delegate: delegate, Amount: big.Zero()},
+		&verifreg.UseBytesDelegatedParams{Client: client, Delegate: delegate, DealSize: size},
+	}
+	for i, seed := range seeds {
+		f.Add(uint8(i), marshal(f, seed))
+	}
+
+	f.Fuzz(func(t *testing.T, index uint8, data []byte) {
+		c := calls[int(index)%len(calls)]
+		params, ok := unmarshalParams(t, c.method, data)
+		if !ok {
+			return
+		}
+		h := newFuzzHarness(t, root, verifier, client, delegate)
+
+		h.SetCaller(c.caller, c.callerCode)
+		c.expect(h)
+		if _, code := h.CallMayAbort(c.method, params); code == exitcode.Ok {
+			checkHarnessState(t, h)
+		}
+	})
+}
+
+// Constructs a registry in which the verifier has granted DataCap to the client, which has delegated some of it
+// to the delegate.
+func newFuzzHarness(t *testing.T, root, verifier, client, delegate addr.Address) *harness.Harness {
+	size := verifreg.MinVerifiedDealSize
+	h := harness.New(t).
+		WithActor(verifreg.Actor{}).
+		WithReceiver(builtin.VerifiedRegistryActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		WithActorType(root, builtin.AccountActorCodeID).
+		WithActorType(verifier, builtin.AccountActorCodeID).
+		WithActorType(client, builtin.AccountActorCodeID).
+		WithActorType(delegate, builtin.AccountActorCodeID).
+		Build()
+	h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	h.Call(builtin.MethodsVerifiedRegistry.Constructor, &root)
+
+	h.ExpectValidateCallerAddr(root)
+	h.CallAs(root, builtin.AccountActorCodeID, builtin.MethodsVerifiedRegistry.AddVerifier,
+		&verifreg.AddVerifierParams{Address: verifier, Allowance: big.Mul(size, big.NewInt(10))})
+
+	h.ExpectValidateCallerAny()
+	h.CallAs(verifier, builtin.AccountActorCodeID, builtin.MethodsVerifiedRegistry.AddVerifiedClient,
+		&verifreg.AddVerifiedClientParams{Address: client, Allowance: big.Mul(size, big.NewInt(4))})
+
+	h.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	h.CallAs(client, builtin.AccountActorCodeID, builtin.MethodsVerifiedRegistry.SetDataCapAllowance,
+		&verifreg.SetDataCapAllowanceParams{Delegate: delegate, Amount: big.Mul(size, big.NewInt(2)), Expiration: h.Epoch() + 100})
+	return h
+}
+
+// Decodes the parameters of a registry method, reporting whether the data is a valid encoding of them.
+func unmarshalParams(t *testing.T, method abi.MethodNum, data []byte) (cbor.Unmarshaler, bool) {
+	m, ok := exported.LookupMethod(builtin.VerifiedRegistryActorCodeID, method)
+	require.True(t, ok)
+	params := m.NewParams()
+	return params, params.UnmarshalCBOR(bytes.NewReader(data)) == nil
+}
+
+func marshal(t testing.TB, v cbor.Marshaler) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, v.MarshalCBOR(&buf))
+	return buf.Bytes()
+}
+
+func checkHarnessState(t testing.TB, h *harness.Harness) {
+	var st verifreg.State
+	h.GetState(&st)
+	_, msgs := verifreg.CheckStateInvariants(&st, h.AdtStore())
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+}
@@ -21,18 +21,17 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 
 	// Check verifiers
 	allVerifiers := map[addr.Address]DataCap{}
-	if verifiers, err := adt.AsMap(store, st.Verifiers, builtin.DefaultHamtBitwidth); err != nil {
+	if verifiers, err := adt.AsTypedMap[abi.AddrKey, DataCap](store, st.Verifiers, builtin.DefaultHamtBitwidth); err != nil {
 		acc.Addf("error loading verifiers: %v", err)
 	} else {
-		var vcap abi.StoragePower
-		err = verifiers.ForEach(&vcap, func(key string) error {
+		err = verifiers.ForEach(func(key string, vcap DataCap) error {
 			verifier, err := addr.NewFromBytes([]byte(key))
 			if err != nil {
 				return err
 			}
 			acc.Require(verifier.Protocol() == addr.ID, "verifier %v should have ID protocol", verifier)
 			acc.Require(vcap.GreaterThanEqual(big.Zero()), "verifier %v cap %v is negative", verifier, vcap)
-			allVerifiers[verifier] = vcap.Copy()
+			allVerifiers[verifier] = vcap
 			return nil
 		})
 		acc.RequireNoError(err, "error iterating verifiers")
@@ -40,18 +39,17 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 
 	// Check clients
 	allClients := map[addr.Address]DataCap{}
-	if clients, err := adt.AsMap(store, st.VerifiedClients, builtin.DefaultHamtBitwidth); err != nil {
+	if clients, err := adt.AsTypedMap[abi.AddrKey, DataCap](store, st.VerifiedClients, builtin.DefaultHamtBitwidth); err != nil {
 		acc.Addf("error loading clients: %v", err)
 	} else {
-		var ccap abi.StoragePower
-		err = clients.ForEach(&ccap, func(key string) error {
+		err = clients.ForEach(func(key string, ccap DataCap) error {
 			client, err := addr.NewFromBytes([]byte(key))
 			if err != nil {
 				return err
 			}
 			acc.Require(client.Protocol() == addr.ID, "client %v should have ID protocol", client)
 			acc.Require(ccap.GreaterThanEqual(big.Zero()), "client %v cap %v is negative", client, ccap)
-			allClients[client] = ccap.Copy()
+			allClients[client] = ccap
 			return nil
 		})
 		acc.RequireNoError(err, "error iterating clients")
@@ -64,6 +62,18 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	}
 	// No need to iterate all clients; any overlap must have been one of all verifiers.
 
+	// Check datacap allowances
+	if allowances, err := adt.AsMap(store, st.DataCapAllowances, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading datacap allowances: %v", err)
+	} else {
+		var allowance DataCapAllowance
+		err = allowances.ForEach(&allowance, func(key string) error {
+			acc.Require(allowance.Amount.GreaterThanEqual(MinVerifiedDealSize), "datacap allowance %v below minimum verified deal size", allowance.Amount)
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating datacap allowances")
+	}
+
 	return &StateSummary{
 		Verifiers: allVerifiers,
 		Clients:   allClients,
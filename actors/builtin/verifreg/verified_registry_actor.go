@@ -18,13 +18,16 @@ type Actor struct{}
 
 func (a Actor) Exports() []interface{} {
 	return []interface{}{
-		builtin.MethodConstructor: a.Constructor,
-		2:                         a.AddVerifier,
-		3:                         a.RemoveVerifier,
-		4:                         a.AddVerifiedClient,
-		5:                         a.UseBytes,
-		6:                         a.RestoreBytes,
-		7:                         a.RemoveVerifiedClientDataCap,
+		builtin.MethodConstructor:  a.Constructor,
+		2:                          a.AddVerifier,
+		3:                          a.RemoveVerifier,
+		4:                          a.AddVerifiedClient,
+		5:                          a.UseBytes,
+		6:                          a.RestoreBytes,
+		7:                          a.RemoveVerifiedClientDataCap,
+		8:                          a.SetDataCapAllowance,
+		9:                          a.UseBytesDelegated,
+		builtin.MethodGetActorInfo: a.GetActorInfo,
 	}
 }
 
@@ -42,6 +45,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
+// Returns metadata describing this actor.
+func (a Actor) GetActorInfo(rt runtime.Runtime, _ *abi.EmptyValue) *builtin.GetActorInfoReturn {
+	return builtin.GetActorInfo(rt, a.Code(), a.State())
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Actor methods
 ////////////////////////////////////////////////////////////////////////////////
@@ -100,6 +108,7 @@ func (a Actor) AddVerifier(rt runtime.Runtime, params *AddVerifierParams) *abi.E
 		st.Verifiers, err = verifiers.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifiers")
 	})
+	emitAddressEvent(rt, EventVerifierAdded, EventKeyVerifier, verifier)
 
 	return nil
 }
@@ -123,6 +132,7 @@ func (a Actor) RemoveVerifier(rt runtime.Runtime, verifierAddr *addr.Address) *a
 		st.Verifiers, err = verifiers.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifiers")
 	})
+	emitAddressEvent(rt, EventVerifierRemoved, EventKeyVerifier, verifier)
 
 	return nil
 }
@@ -151,23 +161,22 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 	}
 
 	rt.StateTransaction(&st, func() {
-		verifiers, err := adt.AsMap(adt.AsStore(rt), st.Verifiers, builtin.DefaultHamtBitwidth)
+		verifiers, err := adt.AsTypedMap[abi.AddrKey, DataCap](adt.AsStore(rt), st.Verifiers, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifiers")
 
-		verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
+		verifiedClients, err := adt.AsTypedMap[abi.AddrKey, DataCap](adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
 
 		// Validate caller is one of the verifiers.
 		verifier := rt.Caller()
-		var verifierCap DataCap
-		found, err := verifiers.Get(abi.AddrKey(verifier), &verifierCap)
+		verifierCap, found, err := verifiers.Get(abi.AddrKey(verifier))
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verifier %v", verifier)
 		if !found {
 			rt.Abortf(exitcode.ErrNotFound, "no such verifier %v", verifier)
 		}
 
 		// Validate client to be added isn't a verifier
-		found, err = verifiers.Get(abi.AddrKey(client), nil)
+		found, err = verifiers.Has(abi.AddrKey(client))
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verifier")
 		if found {
 			rt.Abortf(exitcode.ErrIllegalArgument, "verifier %v cannot be added as a verified client", client)
@@ -179,11 +188,10 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 		}
 		newVerifierCap := big.Sub(verifierCap, params.Allowance)
 
-		err = verifiers.Put(abi.AddrKey(verifier), &newVerifierCap)
+		err = verifiers.Put(abi.AddrKey(verifier), newVerifierCap)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update new verifier cap (%d) for %v", newVerifierCap, verifier)
 
-		var clientCap DataCap
-		found, err = verifiedClients.Get(abi.AddrKey(client), &clientCap)
+		clientCap, found, err := verifiedClients.Get(abi.AddrKey(client))
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
 
 		// if verified client exists, add allowance to existing cap
@@ -193,7 +201,7 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 		} else {
 			clientCap = params.Allowance
 		}
-		err = verifiedClients.Put(abi.AddrKey(client), &clientCap)
+		err = verifiedClients.Put(abi.AddrKey(client), clientCap)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add verified client %v with cap %d", client, clientCap)
 
 		st.Verifiers, err = verifiers.Root()
@@ -202,6 +210,7 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 		st.VerifiedClients, err = verifiedClients.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
 	})
+	emitAddressEvent(rt, EventClientAdded, EventKeyClient, client)
 
 	return nil
 }
@@ -230,31 +239,7 @@ func (a Actor) UseBytes(rt runtime.Runtime, params *UseBytesParams) *abi.EmptyVa
 		verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
 
-		var vcCap DataCap
-		found, err := verifiedClients.Get(abi.AddrKey(client), &vcCap)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
-		if !found {
-			rt.Abortf(exitcode.ErrNotFound, "no such verified client %v", client)
-		}
-		builtin.RequireState(rt, vcCap.GreaterThanEqual(big.Zero()), "negative cap for client %v: %v", client, vcCap)
-
-		if params.DealSize.GreaterThan(vcCap) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "DealSize %d exceeds allowable cap: %d for VerifiedClient %v", params.DealSize, vcCap, client)
-		}
-
-		newVcCap := big.Sub(vcCap, params.DealSize)
-		if newVcCap.LessThan(MinVerifiedDealSize) {
-			// Delete entry if remaining DataCap is less than MinVerifiedDealSize.
-			// Will be restored later if the deal did not get activated with a ProvenSector.
-			//
-			// NOTE: Technically, client could lose up to MinVerifiedDealSize worth of DataCap.
-			// See: https://github.com/filecoin-project/specs-actors/issues/727
-			err = verifiedClients.Delete(abi.AddrKey(client))
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete verified client %v", client)
-		} else {
-			err = verifiedClients.Put(abi.AddrKey(client), &newVcCap)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update verified client %v with %v", client, newVcCap)
-		}
+		useClientDataCap(rt, verifiedClients, client, params.DealSize)
 
 		st.VerifiedClients, err = verifiedClients.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
@@ -362,12 +347,12 @@ func (a Actor) RemoveVerifiedClientDataCap(rt runtime.Runtime, params *RemoveDat
 			rt.Abortf(exitcode.ErrNotFound, "%s is not a verified client", params.VerifiedClientToRemove)
 		}
 
-		if !isVerifier(rt, st, verifier1) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "%s is not a verifier", params.VerifierRequest1)
-		}
-
-		if !isVerifier(rt, st, verifier2) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "%s is not a verifier", params.VerifierRequest2)
+		for _, verifier := range []addr.Address{verifier1, verifier2} {
+			found, err := isVerifier(rt, st, verifier)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check verifier %v", verifier)
+			if !found {
+				rt.Abortf(exitcode.ErrIllegalArgument, "%s is not a verifier", verifier)
+			}
 		}
 
 		// validate signatures
@@ -404,3 +389,162 @@ func (a Actor) RemoveVerifiedClientDataCap(rt runtime.Runtime, params *RemoveDat
 		DataCapRemoved: removedDataCapAmount,
 	}
 }
+
+type SetDataCapAllowanceParams struct {
+	Delegate   addr.Address   // Address authorized to use the caller's DataCap.
+	Amount     DataCap        // DataCap the delegate may use. Zero revokes the allowance.
+	Expiration abi.ChainEpoch // Epoch from which the allowance may no longer be used.
+}
+
+// Authorizes a delegate, such as a storage provider or deal aggregator, to use up to some amount of the calling
+// verified client's DataCap for deals it signs on the client's behalf, until an expiration epoch.
+// Replaces any existing allowance from the caller to the delegate.
+func (a Actor) SetDataCapAllowance(rt runtime.Runtime, params *SetDataCapAllowanceParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	client := rt.Caller()
+
+	revoke := params.Amount.IsZero()
+	if !revoke {
+		if params.Amount.LessThan(MinVerifiedDealSize) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "allowance %d below MinVerifiedDealSize", params.Amount)
+		}
+		if params.Expiration <= rt.CurrEpoch() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "allowance expiration %d must be after current epoch %d", params.Expiration, rt.CurrEpoch())
+		}
+	}
+
+	delegate, err := builtin.ResolveToIDAddr(rt, params.Delegate)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to resolve delegate address %v to ID address", params.Delegate)
+	if delegate == client {
+		rt.Abortf(exitcode.ErrIllegalArgument, "client %v cannot delegate DataCap to itself", client)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if !revoke {
+			verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
+			found, err := verifiedClients.Has(abi.AddrKey(client))
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
+			if !found {
+				rt.Abortf(exitcode.ErrForbidden, "caller %v is not a verified client", client)
+			}
+		}
+
+		allowances, err := adt.AsMap(adt.AsStore(rt), st.DataCapAllowances, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load datacap allowances")
+
+		key := abi.NewAddrPairKey(client, delegate)
+		if revoke {
+			_, err = allowances.TryDelete(key)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete allowance for client,delegate %v,%v", client, delegate)
+		} else {
+			allowance := DataCapAllowance{
+				Amount:     params.Amount,
+				Expiration: params.Expiration,
+			}
+			err = allowances.Put(key, &allowance)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put allowance for client,delegate %v,%v", client, delegate)
+		}
+
+		st.DataCapAllowances, err = allowances.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush datacap allowances")
+	})
+
+	return nil
+}
+
+type UseBytesDelegatedParams struct {
+	Client   addr.Address     // Address of verified client.
+	Delegate addr.Address     // Address of the delegate that signed the deal on the client's behalf.
+	DealSize abi.StoragePower // Number of bytes to use.
+}
+
+// Called by StorageMarketActor during PublishStorageDeals for a verified deal signed by a delegate of the client.
+// Deducts DealSize from both the delegate's allowance and the client's DataCap.
+// DataCap later restored for a deal that fails to activate is returned to the client, not to the allowance.
+func (a Actor) UseBytesDelegated(rt runtime.Runtime, params *UseBytesDelegatedParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(builtin.StorageMarketActorAddr)
+
+	client, err := builtin.ResolveToIDAddr(rt, params.Client)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve verified client address %v", params.Client)
+	delegate, err := builtin.ResolveToIDAddr(rt, params.Delegate)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve delegate address %v", params.Delegate)
+
+	if params.DealSize.LessThan(MinVerifiedDealSize) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "VerifiedDealSize: %d below minimum in UseBytesDelegated", params.DealSize)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		allowances, err := adt.AsMap(adt.AsStore(rt), st.DataCapAllowances, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load datacap allowances")
+
+		key := abi.NewAddrPairKey(client, delegate)
+		var allowance DataCapAllowance
+		found, err := allowances.Get(key, &allowance)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get allowance for client,delegate %v,%v", client, delegate)
+		if !found {
+			rt.Abortf(exitcode.ErrForbidden, "client %v has no allowance for delegate %v", client, delegate)
+		}
+		if rt.CurrEpoch() >= allowance.Expiration {
+			rt.Abortf(exitcode.ErrForbidden, "allowance from client %v to delegate %v expired at %d", client, delegate, allowance.Expiration)
+		}
+		if params.DealSize.GreaterThan(allowance.Amount) {
+			rt.Abortf(exitcode.ErrForbidden, "DealSize %d exceeds allowance %d from client %v to delegate %v",
+				params.DealSize, allowance.Amount, client, delegate)
+		}
+
+		allowance.Amount = big.Sub(allowance.Amount, params.DealSize)
+		if allowance.Amount.LessThan(MinVerifiedDealSize) {
+			// The remainder could never cover a verified deal.
+			err = allowances.Delete(key)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete allowance for client,delegate %v,%v", client, delegate)
+		} else {
+			err = allowances.Put(key, &allowance)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allowance for client,delegate %v,%v", client, delegate)
+		}
+
+		verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
+
+		useClientDataCap(rt, verifiedClients, client, params.DealSize)
+
+		st.DataCapAllowances, err = allowances.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush datacap allowances")
+		st.VerifiedClients, err = verifiedClients.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
+	})
+
+	return nil
+}
+
+// Deducts a deal's size from a verified client's DataCap, aborting if the client's DataCap cannot cover it.
+// Deletes the client entry if the remaining DataCap is smaller than the minimum verified deal size.
+func useClientDataCap(rt runtime.Runtime, verifiedClients *adt.Map, client addr.Address, dealSize abi.StoragePower) {
+	var vcCap DataCap
+	found, err := verifiedClients.Get(abi.AddrKey(client), &vcCap)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
+	if !found {
+		rt.Abortf(exitcode.ErrNotFound, "no such verified client %v", client)
+	}
+	builtin.RequireState(rt, vcCap.GreaterThanEqual(big.Zero()), "negative cap for client %v: %v", client, vcCap)
+
+	if dealSize.GreaterThan(vcCap) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "DealSize %d exceeds allowable cap: %d for VerifiedClient %v", dealSize, vcCap, client)
+	}
+
+	newVcCap := big.Sub(vcCap, dealSize)
+	if newVcCap.LessThan(MinVerifiedDealSize) {
+		// Delete entry if remaining DataCap is less than MinVerifiedDealSize.
+		// Will be restored later if the deal did not get activated with a ProvenSector.
+		//
+		// NOTE: Technically, client could lose up to MinVerifiedDealSize worth of DataCap.
+		// See: https://github.com/filecoin-project/specs-actors/issues/727
+		err = verifiedClients.Delete(abi.AddrKey(client))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete verified client %v", client)
+	} else {
+		err = verifiedClients.Put(abi.AddrKey(client), &newVcCap)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update verified client %v with %v", client, newVcCap)
+	}
+}
@@ -42,6 +42,18 @@ type State struct {
 	//specific client. Unique proposal ids ensure that removal proposals cannot be replayed.√
 	// AddrPairKey is constructed as <verifier address, client address>, both using ID addresses.
 	RemoveDataCapProposalIDs cid.Cid // HAMT[AddrPairKey]RmDcProposalID
+
+	// DataCapAllowances records the DataCap a verified client has authorized a delegate to spend on its behalf.
+	// AddrPairKey is constructed as <client address, delegate address>, both using ID addresses.
+	DataCapAllowances cid.Cid // HAMT[AddrPairKey]DataCapAllowance
+}
+
+// A DataCapAllowance caps the DataCap a delegate may use on behalf of a verified client.
+type DataCapAllowance struct {
+	// Amount of the client's DataCap the delegate may still use.
+	Amount DataCap
+	// Epoch from which the allowance may no longer be used.
+	Expiration abi.ChainEpoch
 }
 
 var MinVerifiedDealSize = abi.NewStoragePower(1 << 20)
@@ -58,6 +70,7 @@ func ConstructState(store adt.Store, rootKeyAddress addr.Address) (*State, error
 		Verifiers:                emptyMapCid,
 		VerifiedClients:          emptyMapCid,
 		RemoveDataCapProposalIDs: emptyMapCid,
+		DataCapAllowances:        emptyMapCid,
 	}, nil
 }
 
@@ -81,14 +94,17 @@ type RemoveDataCapRequest struct {
 	VerifierSignature crypto.Signature
 }
 
-func isVerifier(rt runtime.Runtime, st State, address addr.Address) bool {
-	verifiers, err := adt.AsMap(adt.AsStore(rt), st.Verifiers, builtin.DefaultHamtBitwidth)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifiers")
+func isVerifier(sr runtime.StateReader, st State, address addr.Address) (bool, error) {
+	verifiers, err := adt.AsMap(adt.AsStore(sr), st.Verifiers, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load verifiers: %w", err)
+	}
 
 	ok, err := verifiers.Get(abi.AddrKey(address), nil)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifier")
-
-	return ok
+	if err != nil {
+		return false, xerrors.Errorf("failed to load verifier %v: %w", address, err)
+	}
+	return ok, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
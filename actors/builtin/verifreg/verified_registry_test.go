@@ -1,6 +1,7 @@
 package verifreg_test
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
@@ -749,6 +751,128 @@ func TestRestoreBytes(t *testing.T) {
 	})
 }
 
+func TestDataCapAllowance(t *testing.T) {
+	root := tutil.NewIDAddr(t, 101)
+	clientAddr := tutil.NewIDAddr(t, 201)
+	delegateAddr := tutil.NewIDAddr(t, 401)
+	verifierAddr := tutil.NewIDAddr(t, 301)
+	vallow := big.Add(verifreg.MinVerifiedDealSize, big.NewInt(100))
+	clientCap := big.Mul(verifreg.MinVerifiedDealSize, big.NewInt(4))
+	expiration := abi.ChainEpoch(1000)
+
+	t.Run("delegate uses allowance until exhausted", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, clientCap)
+
+		allowance := big.Mul(verifreg.MinVerifiedDealSize, big.NewInt(2))
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, allowance, expiration)
+
+		dSize := verifreg.MinVerifiedDealSize
+		ac.useBytesDelegated(rt, clientAddr, delegateAddr, dSize)
+		assert.EqualValues(t, big.Sub(clientCap, dSize), ac.getClientCap(rt, clientAddr))
+		remaining, found := ac.getDataCapAllowance(rt, clientAddr, delegateAddr)
+		require.True(t, found)
+		assert.EqualValues(t, big.Sub(allowance, dSize), remaining.Amount)
+		assert.Equal(t, expiration, remaining.Expiration)
+
+		// Using the rest of the allowance removes it.
+		ac.useBytesDelegated(rt, clientAddr, delegateAddr, dSize)
+		_, found = ac.getDataCapAllowance(rt, clientAddr, delegateAddr)
+		assert.False(t, found)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			ac.useBytesDelegated(rt, clientAddr, delegateAddr, dSize)
+		})
+		// The client keeps the DataCap it did not delegate.
+		assert.EqualValues(t, big.Sub(clientCap, big.Mul(dSize, big.NewInt(2))), ac.getClientCap(rt, clientAddr))
+		ac.checkState(rt)
+	})
+
+	t.Run("fails when deal exceeds allowance", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, clientCap)
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, verifreg.MinVerifiedDealSize, expiration)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			ac.useBytesDelegated(rt, clientAddr, delegateAddr, big.Add(verifreg.MinVerifiedDealSize, big.NewInt(1)))
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("fails when allowance has expired", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, clientCap)
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, clientCap, expiration)
+
+		rt.SetEpoch(expiration)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			ac.useBytesDelegated(rt, clientAddr, delegateAddr, verifreg.MinVerifiedDealSize)
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("fails when client cap is smaller than allowance", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, verifreg.MinVerifiedDealSize)
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, clientCap, expiration)
+
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			ac.useBytesDelegated(rt, clientAddr, delegateAddr, big.Add(verifreg.MinVerifiedDealSize, big.NewInt(1)))
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("zero amount revokes allowance", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, clientCap)
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, clientCap, expiration)
+		ac.setDataCapAllowance(rt, clientAddr, delegateAddr, big.Zero(), 0)
+
+		_, found := ac.getDataCapAllowance(rt, clientAddr, delegateAddr)
+		assert.False(t, found)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			ac.useBytesDelegated(rt, clientAddr, delegateAddr, verifreg.MinVerifiedDealSize)
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("fails when caller is not a verified client", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			ac.setDataCapAllowance(rt, clientAddr, delegateAddr, clientCap, expiration)
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("fails with invalid allowance", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, vallow, clientCap)
+
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			ac.setDataCapAllowance(rt, clientAddr, delegateAddr, big.Sub(verifreg.MinVerifiedDealSize, big.NewInt(1)), expiration)
+		})
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			ac.setDataCapAllowance(rt, clientAddr, delegateAddr, clientCap, rt.Epoch())
+		})
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			ac.setDataCapAllowance(rt, clientAddr, clientAddr, clientCap, expiration)
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("fails if caller of delegated use is not storage market actor", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		rt.ExpectValidateCallerAddr(builtin.StorageMarketActorAddr)
+		rt.SetCaller(builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID)
+		param := &verifreg.UseBytesDelegatedParams{Client: clientAddr, Delegate: delegateAddr, DealSize: verifreg.MinVerifiedDealSize}
+
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(ac.UseBytesDelegated, param)
+		})
+		ac.checkState(rt)
+	})
+}
+
 type verifRegActorTestHarness struct {
 	rootkey address.Address
 	verifreg.Actor
@@ -818,6 +942,7 @@ func (h *verifRegActorTestHarness) addVerifiedClient(rt *mock.Runtime, verifier,
 	clientIdAddr, found := rt.GetIdAddr(client)
 	require.True(h.t, found)
 	assert.EqualValues(h.t, totalAllowance, h.getClientCap(rt, clientIdAddr))
+	h.assertLastEvent(rt, verifreg.EventClientAdded, verifreg.EventKeyClient, clientIdAddr)
 }
 
 func (h *verifRegActorTestHarness) addVerifier(rt *mock.Runtime, verifier address.Address, datacap verifreg.DataCap) {
@@ -833,6 +958,7 @@ func (h *verifRegActorTestHarness) addVerifier(rt *mock.Runtime, verifier addres
 	require.True(h.t, found)
 	assert.Nil(h.t, ret)
 	assert.EqualValues(h.t, datacap, h.getVerifierCap(rt, verifierIdAddr))
+	h.assertLastEvent(rt, verifreg.EventVerifierAdded, verifreg.EventKeyVerifier, verifierIdAddr)
 }
 
 func (h *verifRegActorTestHarness) removeVerifier(rt *mock.Runtime, verifier address.Address) {
@@ -844,6 +970,26 @@ func (h *verifRegActorTestHarness) removeVerifier(rt *mock.Runtime, verifier add
 
 	require.Nil(h.t, ret)
 	h.assertVerifierRemoved(rt, verifier)
+	verifierIdAddr, found := rt.GetIdAddr(verifier)
+	require.True(h.t, found)
+	h.assertLastEvent(rt, verifreg.EventVerifierRemoved, verifreg.EventKeyVerifier, verifierIdAddr)
+}
+
+// Checks that the last event emitted names its type and carries an address field.
+func (h *verifRegActorTestHarness) assertLastEvent(rt *mock.Runtime, eventType, key string, expected address.Address) {
+	events := rt.Events()
+	require.NotEmpty(h.t, events)
+	entries := events[len(events)-1].Entries
+	require.Len(h.t, entries, 2)
+
+	actualType, err := cbg.ReadString(bytes.NewReader(entries[0].Value))
+	require.NoError(h.t, err)
+	assert.Equal(h.t, eventType, actualType)
+
+	assert.Equal(h.t, key, entries[1].Key)
+	var actual address.Address
+	require.NoError(h.t, actual.UnmarshalCBOR(bytes.NewReader(entries[1].Value)))
+	assert.Equal(h.t, expected, actual)
 }
 
 type capExpectation struct {
@@ -889,6 +1035,39 @@ func (h *verifRegActorTestHarness) restoreBytes(rt *mock.Runtime, a address.Addr
 	assert.EqualValues(h.t, expectedCap.expectedCap, h.getClientCap(rt, clientIdAddr))
 }
 
+func (h *verifRegActorTestHarness) setDataCapAllowance(rt *mock.Runtime, client, delegate address.Address, amount verifreg.DataCap, expiration abi.ChainEpoch) {
+	rt.SetCaller(client, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+
+	param := &verifreg.SetDataCapAllowanceParams{Delegate: delegate, Amount: amount, Expiration: expiration}
+	ret := rt.Call(h.SetDataCapAllowance, param)
+	rt.Verify()
+	assert.Nil(h.t, ret)
+}
+
+func (h *verifRegActorTestHarness) useBytesDelegated(rt *mock.Runtime, client, delegate address.Address, dealSize verifreg.DataCap) {
+	rt.ExpectValidateCallerAddr(builtin.StorageMarketActorAddr)
+	rt.SetCaller(builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID)
+
+	param := &verifreg.UseBytesDelegatedParams{Client: client, Delegate: delegate, DealSize: dealSize}
+	ret := rt.Call(h.UseBytesDelegated, param)
+	rt.Verify()
+	assert.Nil(h.t, ret)
+}
+
+func (h *verifRegActorTestHarness) getDataCapAllowance(rt *mock.Runtime, client, delegate address.Address) (*verifreg.DataCapAllowance, bool) {
+	var st verifreg.State
+	rt.GetState(&st)
+
+	allowances, err := adt.AsMap(adt.AsStore(rt), st.DataCapAllowances, builtin.DefaultHamtBitwidth)
+	require.NoError(h.t, err)
+
+	var allowance verifreg.DataCapAllowance
+	found, err := allowances.Get(abi.NewAddrPairKey(client, delegate), &allowance)
+	require.NoError(h.t, err)
+	return &allowance, found
+}
+
 func (h *verifRegActorTestHarness) getVerifierCap(rt *mock.Runtime, a address.Address) verifreg.DataCap {
 	var st verifreg.State
 	rt.GetState(&st)
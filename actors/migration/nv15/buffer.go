@@ -0,0 +1,172 @@
+package nv15
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// BufferedBlockstore holds the blocks written by a migration until they are flushed to another store, and reads
+// blocks from its buffer or else from an underlying store, which it never writes to.
+// At most a limited total size of blocks is held in memory. Once the limit is reached, further blocks are
+// spilled to a temporary file on disk, so that migrating large states (such as the mainnet market actor)
+// doesn't require holding many gigabytes of pending blocks in RAM.
+// It is threadsafe.
+type BufferedBlockstore struct {
+	under          cbor.IpldStore
+	maxMemoryBytes uint64
+	spillDir       string
+
+	lk          sync.RWMutex
+	memory      map[cid.Cid]block.Block
+	memoryBytes uint64
+	spilled     map[cid.Cid]spilledBlock
+	spillFile   *os.File
+	spillBytes  int64
+}
+
+var _ cbor.IpldBlockstore = &BufferedBlockstore{}
+
+// Location of a block's data in the spill file.
+type spilledBlock struct {
+	offset int64
+	size   int
+}
+
+// Creates a buffer over an underlying store, which may be nil if nothing need be read from elsewhere.
+// A maxMemoryBytes of zero places no limit on the blocks held in memory. Spill files are created in
+// spillDir, or the system temporary directory if empty.
+func NewBufferedBlockstore(under cbor.IpldStore, maxMemoryBytes uint64, spillDir string) *BufferedBlockstore {
+	return &BufferedBlockstore{
+		under:          under,
+		maxMemoryBytes: maxMemoryBytes,
+		spillDir:       spillDir,
+		memory:         make(map[cid.Cid]block.Block),
+		spilled:        make(map[cid.Cid]spilledBlock),
+	}
+}
+
+func (bs *BufferedBlockstore) Get(c cid.Cid) (block.Block, error) {
+	bs.lk.RLock()
+	b, inMemory := bs.memory[c]
+	s, isSpilled := bs.spilled[c]
+	spillFile := bs.spillFile
+	bs.lk.RUnlock()
+	if inMemory {
+		return b, nil
+	}
+	if isSpilled {
+		data := make([]byte, s.size)
+		if _, err := spillFile.ReadAt(data, s.offset); err != nil {
+			return nil, xerrors.Errorf("failed to read spilled block %s: %w", c, err)
+		}
+		return block.NewBlockWithCid(data, c)
+	}
+	if bs.under == nil {
+		return nil, xerrors.Errorf("block %s not found", c)
+	}
+	var raw cbg.Deferred
+	if err := bs.under.Get(context.Background(), c, &raw); err != nil {
+		return nil, err
+	}
+	return block.NewBlockWithCid(raw.Raw, c)
+}
+
+func (bs *BufferedBlockstore) Put(b block.Block) error {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	if _, ok := bs.memory[b.Cid()]; ok {
+		return nil
+	}
+	if _, ok := bs.spilled[b.Cid()]; ok {
+		return nil
+	}
+	size := uint64(len(b.RawData()))
+	if bs.maxMemoryBytes == 0 || bs.memoryBytes+size <= bs.maxMemoryBytes {
+		bs.memory[b.Cid()] = b
+		bs.memoryBytes += size
+		return nil
+	}
+
+	if bs.spillFile == nil {
+		f, err := os.CreateTemp(bs.spillDir, "migration-spill-*")
+		if err != nil {
+			return xerrors.Errorf("failed to create spill file: %w", err)
+		}
+		bs.spillFile = f
+	}
+	if _, err := bs.spillFile.WriteAt(b.RawData(), bs.spillBytes); err != nil {
+		return xerrors.Errorf("failed to spill block %s: %w", b.Cid(), err)
+	}
+	bs.spilled[b.Cid()] = spilledBlock{offset: bs.spillBytes, size: len(b.RawData())}
+	bs.spillBytes += int64(size)
+	return nil
+}
+
+// Returns the number and total size of blocks buffered.
+func (bs *BufferedBlockstore) Written() (blocks, bytes uint64) {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	return uint64(len(bs.memory) + len(bs.spilled)), bs.memoryBytes + uint64(bs.spillBytes)
+}
+
+// Returns the total size of the blocks buffered in memory, and of those spilled to disk.
+func (bs *BufferedBlockstore) BufferedBytes() (memory, spilled uint64) {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	return bs.memoryBytes, uint64(bs.spillBytes)
+}
+
+// Writes every buffered block to a store, then empties the buffer and removes any spill file.
+func (bs *BufferedBlockstore) Flush(to cbor.IpldBlockstore) error {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	for _, b := range bs.memory { // nolint:nomaprange // order of writes doesn't matter
+		if err := to.Put(b); err != nil {
+			return xerrors.Errorf("failed to flush block %s: %w", b.Cid(), err)
+		}
+	}
+	for c, s := range bs.spilled { // nolint:nomaprange
+		data := make([]byte, s.size)
+		if _, err := bs.spillFile.ReadAt(data, s.offset); err != nil {
+			return xerrors.Errorf("failed to read spilled block %s: %w", c, err)
+		}
+		b, err := block.NewBlockWithCid(data, c)
+		if err != nil {
+			return err
+		}
+		if err := to.Put(b); err != nil {
+			return xerrors.Errorf("failed to flush block %s: %w", c, err)
+		}
+	}
+	return bs.reset()
+}
+
+// Discards the buffered blocks and removes any spill file.
+func (bs *BufferedBlockstore) Close() error {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	return bs.reset()
+}
+
+func (bs *BufferedBlockstore) reset() error {
+	bs.memory = make(map[cid.Cid]block.Block)
+	bs.memoryBytes = 0
+	bs.spilled = make(map[cid.Cid]spilledBlock)
+	bs.spillBytes = 0
+	if bs.spillFile == nil {
+		return nil
+	}
+	f := bs.spillFile
+	bs.spillFile = nil
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
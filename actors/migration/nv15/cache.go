@@ -0,0 +1,97 @@
+package nv15
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+)
+
+// FileMigrationCache is a MigrationCache which persists its entries to a file, so that the results of a
+// pre-migration survive a restart of the process and can be reused by later pre-migrations and the
+// migration itself.
+// Entries are held in memory and appended to the file as they are written, one "key cid" line per entry.
+// A later entry for a key supersedes earlier ones.
+type FileMigrationCache struct {
+	MemMigrationCache
+
+	lk   sync.Mutex
+	file *os.File
+}
+
+var _ MigrationCache = &FileMigrationCache{}
+
+// Opens a file-backed cache, loading any entries already in the file, or creating the file if it doesn't exist.
+func OpenFileMigrationCache(path string) (*FileMigrationCache, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	c := &FileMigrationCache{file: f}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			_ = f.Close()
+			return nil, xerrors.Errorf("malformed migration cache entry at %s:%d", path, line)
+		}
+		value, err := cid.Decode(fields[1])
+		if err != nil {
+			_ = f.Close()
+			return nil, xerrors.Errorf("malformed migration cache entry at %s:%d: %w", path, line, err)
+		}
+		c.MigrationMap.Store(fields[0], value)
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *FileMigrationCache) Write(key string, value cid.Cid) error {
+	if strings.ContainsAny(key, " \t\n") {
+		return xerrors.Errorf("invalid migration cache key %q", key)
+	}
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if _, err := c.file.WriteString(key + " " + value.String() + "\n"); err != nil {
+		return xerrors.Errorf("failed to persist migration cache entry: %w", err)
+	}
+	return c.MemMigrationCache.Write(key, value)
+}
+
+func (c *FileMigrationCache) Load(key string, loadFunc func() (cid.Cid, error)) (cid.Cid, error) {
+	found, value, err := c.Read(key)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if found {
+		return value, nil
+	}
+	value, err = loadFunc()
+	if err != nil {
+		return cid.Undef, err
+	}
+	if err := c.Write(key, value); err != nil {
+		return cid.Undef, err
+	}
+	return value, nil
+}
+
+// Flushes the cache's file to stable storage.
+func (c *FileMigrationCache) Sync() error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.file.Sync()
+}
+
+// Closes the cache's file. The cache must not be written to afterwards.
+func (c *FileMigrationCache) Close() error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.file.Close()
+}
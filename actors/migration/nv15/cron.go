@@ -0,0 +1,47 @@
+package nv15
+
+import (
+	"context"
+
+	cron6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/cron"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+type cronMigrator struct{}
+
+func (m cronMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState cron6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// Existing entries have no gas budget.
+	entries := make([]cron7.Entry, len(inState.Entries))
+	for i, e := range inState.Entries {
+		entries[i] = cron7.Entry{Receiver: e.Receiver, MethodNum: e.MethodNum}
+	}
+	outState, err := cron7.ConstructState(adt.WrapStore(ctx, store), entries)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct outState: %w", err)
+	}
+
+	newHead, err := store.Put(ctx, outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m cronMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.CronActorCodeID
+}
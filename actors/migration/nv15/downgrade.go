@@ -0,0 +1,496 @@
+package nv15
+
+import (
+	"context"
+	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	cron6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/cron"
+	init6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/init"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	multisig6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/multisig"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
+	reward6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/reward"
+	verifreg6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/verifreg"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	init7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	paych7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	reward7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	verifreg7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Returned, wrapped, when v7 state cannot be represented in v6 without changing the behaviour of the actor.
+var ErrIrreversible = xerrors.New("state cannot be downgraded")
+
+// Migrates the state tree from v7 back to v6, reversing MigrateStateTree.
+// This is intended only for emergency rollback of test networks.
+//
+// State added in v7 is dropped where it is at its initial value or is informational only (e.g. the init actor's
+// reverse address index, cron tick reports, market deal price buckets, miner fee debt logs, multisig transaction
+// metadata and the reward actor's recycled penalty total).
+// State that v6 actors cannot represent, such as a multisig spending limit, a paused miner, a replica-updated sector,
+// or a payment channel with additional payees, fails the downgrade with an error wrapping ErrIrreversible,
+// as do miner or market AMT bitwidths configured by policy to differ from those of v6.
+func DowngradeStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg Config, log Logger) (cid.Cid, error) {
+	if miner7.CurrentAmtBitwidths() != minerAmtBitwidthsV6 || market7.CurrentAmtBitwidths() != marketAmtBitwidthsV6 {
+		return cid.Undef, xerrors.Errorf("AMT bitwidths configured by policy differ from v6: %w", ErrIrreversible)
+	}
+	registry, err := DowngradeMigrations(ctx, store)
+	if err != nil {
+		return cid.Undef, err
+	}
+	// Downgrades are one-off, so nothing is gained by caching their results.
+	root, _, err := MigrateStateTreeWithRegistry(ctx, store, actorsRootIn, priorEpoch, cfg, log, NewMemMigrationCache(), registry)
+	return root, err
+}
+
+// Returns a registry of the migrations of the builtin actors from v7 back to v6.
+func DowngradeMigrations(ctx context.Context, store cbor.IpldStore) (*MigrationRegistry, error) {
+	mm, err := newMinerMigrator(ctx, store)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create miner migrator: %w", err)
+	}
+
+	// Maps v7 code CIDs to downgrade functions.
+	var migrations = map[cid.Cid]actorMigration{
+		builtin7.AccountActorCodeID:          nilMigrator{builtin6.AccountActorCodeID},
+		builtin7.CronActorCodeID:             cronDowngrader{},
+		builtin7.InitActorCodeID:             initDowngrader{},
+		builtin7.MultisigActorCodeID:         multisigDowngrader{},
+		builtin7.PaymentChannelActorCodeID:   paychDowngrader{},
+		builtin7.RewardActorCodeID:           rewardDowngrader{},
+		builtin7.StorageMarketActorCodeID:    marketDowngrader{},
+		builtin7.StorageMinerActorCodeID:     minerDowngrader{mm},
+		builtin7.StoragePowerActorCodeID:     nilMigrator{builtin6.StoragePowerActorCodeID},
+		builtin7.SystemActorCodeID:           nilMigrator{builtin6.SystemActorCodeID},
+		builtin7.VerifiedRegistryActorCodeID: verifregDowngrader{},
+	}
+	if len(migrations) != 11 {
+		panic(fmt.Sprintf("incomplete downgrade specification with %d code CIDs", len(migrations)))
+	}
+	return &MigrationRegistry{migrations: migrations}, nil
+}
+
+type cronDowngrader struct{}
+
+func (m cronDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState cron7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// Tick reports are dropped.
+	outState := cron6.State{Entries: make([]cron6.Entry, len(inState.Entries))}
+	for i, e := range inState.Entries {
+		if e.GasBudget != 0 || e.Flagged {
+			return nil, xerrors.Errorf("cron entry %d for %v method %d has gas budget %d, flagged %t: %w",
+				i, e.Receiver, e.MethodNum, e.GasBudget, e.Flagged, ErrIrreversible)
+		}
+		outState.Entries[i] = cron6.Entry{Receiver: e.Receiver, MethodNum: e.MethodNum}
+	}
+
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m cronDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.CronActorCodeID
+}
+
+type initDowngrader struct{}
+
+func (m initDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState init7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// The reverse address index is derived from the address map, so is dropped.
+	outState := init6.State{
+		AddressMap:  inState.AddressMap,
+		NextID:      inState.NextID,
+		NetworkName: inState.NetworkName,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m initDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.InitActorCodeID
+}
+
+type multisigDowngrader struct{}
+
+func (m multisigDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState multisig7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+	if inState.SpendingLimit != nil {
+		return nil, xerrors.Errorf("multisig has a spending limit: %w", ErrIrreversible)
+	}
+
+	// Transaction metadata is not interpreted by the actor, so is dropped.
+	outState := multisig6.State{
+		Signers:               inState.Signers,
+		NumApprovalsThreshold: inState.NumApprovalsThreshold,
+		NextTxnID:             inState.NextTxnID,
+		InitialBalance:        inState.InitialBalance,
+		StartEpoch:            inState.StartEpoch,
+		UnlockDuration:        inState.UnlockDuration,
+		PendingTxns:           inState.PendingTxns,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m multisigDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.MultisigActorCodeID
+}
+
+type paychDowngrader struct{}
+
+func (m paychDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState paych7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+	if len(inState.AdditionalPayees) > 0 {
+		return nil, xerrors.Errorf("channel has %d additional payees: %w", len(inState.AdditionalPayees), ErrIrreversible)
+	}
+	if inState.SettleDelay != paych6.SettleDelay {
+		return nil, xerrors.Errorf("channel has settle delay %d, not %d: %w", inState.SettleDelay, paych6.SettleDelay, ErrIrreversible)
+	}
+	noRetired, err := inState.RetiredLanes.IsEmpty()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read retired lanes: %w", err)
+	}
+	if !noRetired {
+		return nil, xerrors.Errorf("channel has retired lanes: %w", ErrIrreversible)
+	}
+
+	outState := paych6.State{
+		From:            inState.From,
+		To:              inState.To,
+		ToSend:          inState.ToSend,
+		SettlingAt:      inState.SettlingAt,
+		MinSettleHeight: inState.MinSettleHeight,
+		LaneStates:      inState.LaneStates,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m paychDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.PaymentChannelActorCodeID
+}
+
+type rewardDowngrader struct{}
+
+func (m rewardDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState reward7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// The total of recycled penalties is informational only, so is dropped.
+	outState := reward6.State{
+		CumsumBaseline:          inState.CumsumBaseline,
+		CumsumRealized:          inState.CumsumRealized,
+		EffectiveNetworkTime:    inState.EffectiveNetworkTime,
+		EffectiveBaselinePower:  inState.EffectiveBaselinePower,
+		ThisEpochReward:         inState.ThisEpochReward,
+		ThisEpochRewardSmoothed: inState.ThisEpochRewardSmoothed,
+		ThisEpochBaselinePower:  inState.ThisEpochBaselinePower,
+		Epoch:                   inState.Epoch,
+		TotalStoragePowerReward: inState.TotalStoragePowerReward,
+		SimpleTotal:             inState.SimpleTotal,
+		BaselineTotal:           inState.BaselineTotal,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m rewardDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.RewardActorCodeID
+}
+
+type marketDowngrader struct{}
+
+func (m marketDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState market7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+	ctxStore := adt.WrapStore(ctx, store)
+
+	escrowTableOut, err := downgradeBalanceTable(ctxStore, inState.EscrowTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to downgrade escrow table: %w", err)
+	}
+	lockedTableOut, err := downgradeBalanceTable(ctxStore, inState.LockedTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to downgrade locked table: %w", err)
+	}
+
+	// Deal price buckets are informational only, so are dropped.
+	outState := market6.State{
+		Proposals:                     inState.Proposals,
+		States:                        inState.States,
+		PendingProposals:              inState.PendingProposals,
+		EscrowTable:                   escrowTableOut,
+		LockedTable:                   lockedTableOut,
+		NextID:                        inState.NextID,
+		DealOpsByEpoch:                inState.DealOpsByEpoch,
+		LastCron:                      inState.LastCron,
+		TotalClientLockedCollateral:   inState.TotalClientLockedCollateral,
+		TotalProviderLockedCollateral: inState.TotalProviderLockedCollateral,
+		TotalClientStorageFee:         inState.TotalClientStorageFee,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m marketDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.StorageMarketActorCodeID
+}
+
+// Copies every balance from a sharded balance table into a new single-HAMT balance table.
+func downgradeBalanceTable(store adt.Store, root cid.Cid) (cid.Cid, error) {
+	inTable, err := adt.AsShardedBalanceTable(store, root)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load sharded balance table: %w", err)
+	}
+	outTable, err := adt.MakeEmptyMap(store, adt.BalanceTableBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to construct balance table: %w", err)
+	}
+	if err := inTable.ForEach(func(key addr.Address, balance abi.TokenAmount) error {
+		return outTable.Put(abi.AddrKey(key), &balance)
+	}); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to downgrade balances: %w", err)
+	}
+	return outTable.Root()
+}
+
+type verifregDowngrader struct{}
+
+func (m verifregDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState verifreg7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// Proposal IDs protect against replay of data cap removals, and allowances grant data cap,
+	// so neither may be dropped unless empty.
+	ctxStore := adt.WrapStore(ctx, store)
+	emptyMap, err := adt.StoreEmptyMap(ctxStore, builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty map: %w", err)
+	}
+	if inState.RemoveDataCapProposalIDs != emptyMap {
+		return nil, xerrors.Errorf("registry has data cap removal proposal IDs: %w", ErrIrreversible)
+	}
+	if inState.DataCapAllowances != emptyMap {
+		return nil, xerrors.Errorf("registry has data cap allowances: %w", ErrIrreversible)
+	}
+
+	outState := verifreg6.State{
+		RootKey:         inState.RootKey,
+		Verifiers:       inState.Verifiers,
+		VerifiedClients: inState.VerifiedClients,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m verifregDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.VerifiedRegistryActorCodeID
+}
+
+// Shares the empty deadline CIDs computed for the forward migration.
+type minerDowngrader struct {
+	*minerMigrator
+}
+
+func (m minerDowngrader) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState miner7.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+	if inState.Paused || inState.PendingPauseChange != nil {
+		return nil, xerrors.Errorf("miner is paused or has a pending pause change: %w", ErrIrreversible)
+	}
+	if inState.UnsealingWindows != m.emptyUnsealing {
+		return nil, xerrors.Errorf("miner has declared unsealing windows: %w", ErrIrreversible)
+	}
+	ctxStore := adt.WrapStore(ctx, store)
+
+	infoOut, err := downgradeInfo(ctx, store, inState.Info)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to downgrade miner info: %w", err)
+	}
+	sectorsOut, err := downgradeSectors(ctxStore, inState.Sectors)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to downgrade sectors: %w", err)
+	}
+	deadlinesOut, err := m.downgradeDeadlines(ctx, ctxStore, inState.Deadlines)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to downgrade deadlines: %w", err)
+	}
+
+	// The fee debt log is informational only and the rebalance cursor only records the progress of
+	// scans that v6 does not perform, so both are dropped.
+	outState := miner6.State{
+		Info:                       infoOut,
+		PreCommitDeposits:          inState.PreCommitDeposits,
+		LockedFunds:                inState.LockedFunds,
+		VestingFunds:               inState.VestingFunds,
+		FeeDebt:                    inState.FeeDebt,
+		InitialPledge:              inState.InitialPledge,
+		PreCommittedSectors:        inState.PreCommittedSectors,
+		PreCommittedSectorsCleanUp: inState.PreCommittedSectorsCleanUp,
+		AllocatedSectors:           inState.AllocatedSectors,
+		Sectors:                    sectorsOut,
+		ProvingPeriodStart:         inState.ProvingPeriodStart,
+		CurrentDeadline:            inState.CurrentDeadline,
+		Deadlines:                  deadlinesOut,
+		EarlyTerminations:          inState.EarlyTerminations,
+		DeadlineCronActive:         inState.DeadlineCronActive,
+	}
+	return putDowngradedState(ctx, store, &outState, m.migratedCodeCID())
+}
+
+func (m minerDowngrader) migratedCodeCID() cid.Cid {
+	return builtin6.StorageMinerActorCodeID
+}
+
+// Drops the beneficiary, which must be the owner without any term, as migrated from v6.
+func downgradeInfo(ctx context.Context, store cbor.IpldStore, infoCid cid.Cid) (cid.Cid, error) {
+	var inInfo miner7.MinerInfo
+	if err := store.Get(ctx, infoCid, &inInfo); err != nil {
+		return cid.Undef, err
+	}
+	term := inInfo.BeneficiaryTerm
+	if inInfo.Beneficiary != inInfo.Owner || !term.Quota.IsZero() || !term.UsedQuota.IsZero() || term.Expiration != 0 ||
+		inInfo.PendingBeneficiaryTerm != nil {
+		return cid.Undef, xerrors.Errorf("miner has a beneficiary other than its owner: %w", ErrIrreversible)
+	}
+	var pendingWorkerKey *miner6.WorkerKeyChange
+	if inInfo.PendingWorkerKey != nil {
+		pendingWorkerKey = &miner6.WorkerKeyChange{
+			NewWorker:   inInfo.PendingWorkerKey.NewWorker,
+			EffectiveAt: inInfo.PendingWorkerKey.EffectiveAt,
+		}
+	}
+	return store.Put(ctx, &miner6.MinerInfo{
+		Owner:                      inInfo.Owner,
+		Worker:                     inInfo.Worker,
+		ControlAddresses:           inInfo.ControlAddresses,
+		PendingWorkerKey:           pendingWorkerKey,
+		PeerId:                     inInfo.PeerId,
+		Multiaddrs:                 inInfo.Multiaddrs,
+		WindowPoStProofType:        inInfo.WindowPoStProofType,
+		SectorSize:                 inInfo.SectorSize,
+		WindowPoStPartitionSectors: inInfo.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      inInfo.ConsensusFaultElapsed,
+		PendingOwnerAddress:        inInfo.PendingOwnerAddress,
+	})
+}
+
+func downgradeSectors(store adt.Store, inRoot cid.Cid) (cid.Cid, error) {
+	inArray, err := adt.AsArray(store, inRoot, miner7.SectorsAmtBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to read sectors array: %w", err)
+	}
+	outArray, err := adt.MakeEmptyArray(store, miner6.SectorsAmtBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to construct new sectors array: %w", err)
+	}
+
+	var info miner7.SectorOnChainInfo
+	if err = inArray.ForEach(&info, func(k int64) error {
+		if info.SectorKeyCID != nil {
+			return xerrors.Errorf("sector %d has been updated with a new replica: %w", info.SectorNumber, ErrIrreversible)
+		}
+		return outArray.Set(uint64(k), &miner6.SectorOnChainInfo{
+			SectorNumber:          info.SectorNumber,
+			SealProof:             info.SealProof,
+			SealedCID:             info.SealedCID,
+			DealIDs:               info.DealIDs,
+			Activation:            info.Activation,
+			Expiration:            info.Expiration,
+			DealWeight:            info.DealWeight,
+			VerifiedDealWeight:    info.VerifiedDealWeight,
+			InitialPledge:         info.InitialPledge,
+			ExpectedDayReward:     info.ExpectedDayReward,
+			ExpectedStoragePledge: info.ExpectedStoragePledge,
+			ReplacedSectorAge:     info.ReplacedSectorAge,
+			ReplacedDayReward:     info.ReplacedDayReward,
+		})
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return outArray.Root()
+}
+
+func (m minerDowngrader) downgradeDeadlines(ctx context.Context, store adt.Store, deadlines cid.Cid) (cid.Cid, error) {
+	if deadlines == m.emptyDeadlinesV7 {
+		return m.emptyDeadlinesV6, nil
+	}
+
+	var inDeadlines miner7.Deadlines
+	if err := store.Get(ctx, deadlines, &inDeadlines); err != nil {
+		return cid.Undef, err
+	}
+
+	var outDeadlines miner6.Deadlines
+	for i, c := range inDeadlines.Due {
+		if c == m.emptyDeadlineV7 {
+			outDeadlines.Due[i] = m.emptyDeadlineV6
+			continue
+		}
+		var inDeadline miner7.Deadline
+		if err := store.Get(ctx, c, &inDeadline); err != nil {
+			return cid.Undef, err
+		}
+
+		// The sectors snapshot is dropped. Optimistic PoSts it would have been used to dispute
+		// may instead be disputed against the current sectors, as in v6.
+		outDeadline := miner6.Deadline{
+			Partitions:                        inDeadline.Partitions,
+			ExpirationsEpochs:                 inDeadline.ExpirationsEpochs,
+			PartitionsPoSted:                  inDeadline.PartitionsPoSted,
+			EarlyTerminations:                 inDeadline.EarlyTerminations,
+			LiveSectors:                       inDeadline.LiveSectors,
+			TotalSectors:                      inDeadline.TotalSectors,
+			FaultyPower:                       miner6.PowerPair(inDeadline.FaultyPower),
+			OptimisticPoStSubmissions:         inDeadline.OptimisticPoStSubmissions,
+			PartitionsSnapshot:                inDeadline.PartitionsSnapshot,
+			OptimisticPoStSubmissionsSnapshot: inDeadline.OptimisticPoStSubmissionsSnapshot,
+		}
+		outDlCid, err := store.Put(ctx, &outDeadline)
+		if err != nil {
+			return cid.Undef, err
+		}
+		outDeadlines.Due[i] = outDlCid
+	}
+
+	return store.Put(ctx, &outDeadlines)
+}
+
+func putDowngradedState(ctx context.Context, store cbor.IpldStore, outState interface{}, code cid.Cid) (*actorMigrationResult, error) {
+	newHead, err := store.Put(ctx, outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+	return &actorMigrationResult{
+		newCodeCID: code,
+		newHead:    newHead,
+	}, nil
+}
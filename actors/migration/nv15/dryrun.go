@@ -0,0 +1,97 @@
+package nv15
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/rt"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+)
+
+// The outcome of a dry run of the migration.
+type DryRunReport struct {
+	InputRoot  cid.Cid
+	OutputRoot cid.Cid
+	// Time taken by the migration itself, excluding the checks.
+	MigrationDuration time.Duration
+	// Number and size of the blocks the migration would write to the store.
+	BlocksWritten uint64
+	BytesWritten  uint64
+	// Sum of the balances of all actors in the input state tree.
+	InputBalanceTotal abi.TokenAmount
+	// Violations of the v7 state invariants in the output state tree, expecting the input balance total.
+	Invariants []builtin7.Record
+	// Supply of tokens in the output state tree, and any inconsistencies found in it.
+	Supply           *states7.SupplyAudit
+	SupplyViolations []builtin7.Record
+}
+
+// Whether the migrated state satisfied all invariant and supply checks.
+func (r *DryRunReport) OK() bool {
+	return len(r.Invariants) == 0 && len(r.SupplyViolations) == 0
+}
+
+// Runs the migration as MigrateStateTree would, but writes the migrated state only to a throwaway
+// BufferedBlockstore layered over the given store, which is never written to.
+// The migrated state is then checked against the v7 state invariants and audited for token supply.
+// The migration cache is not used, so that the dry run neither depends on nor pollutes a cache
+// used for the real migration.
+// An error is returned only if the migration or checks could not be run; failed checks are reported.
+func DryRunMigration(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg Config, log Logger) (*DryRunReport, error) {
+	buffer := NewBufferedBlockstore(store, cfg.BufferMemoryBytes, cfg.BufferSpillDir)
+	defer func() { _ = buffer.Close() }()
+	overlay := cbor.NewCborStore(buffer)
+	startTime := time.Now()
+	actorsRootOut, err := MigrateStateTree(ctx, overlay, actorsRootIn, priorEpoch, cfg, log, NewMemMigrationCache())
+	if err != nil {
+		return nil, xerrors.Errorf("migration failed: %w", err)
+	}
+	report := &DryRunReport{
+		InputRoot:         actorsRootIn,
+		OutputRoot:        actorsRootOut,
+		MigrationDuration: time.Since(startTime),
+	}
+	report.BlocksWritten, report.BytesWritten = buffer.Written()
+
+	adtStore := adt7.WrapStore(ctx, overlay)
+	actorsIn, err := states6.LoadTree(adtStore, actorsRootIn)
+	if err != nil {
+		return nil, err
+	}
+	report.InputBalanceTotal = big.Zero()
+	if err := actorsIn.ForEach(func(_ address.Address, actor *states6.Actor) error {
+		report.InputBalanceTotal = big.Add(report.InputBalanceTotal, actor.Balance)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	actorsOut, err := states7.LoadTree(adtStore, actorsRootOut)
+	if err != nil {
+		return nil, err
+	}
+	log.Log(rt.INFO, "Checking state invariants of migrated tree %s", actorsRootOut)
+	msgs, err := states7.CheckStateInvariants(actorsOut, report.InputBalanceTotal, priorEpoch)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to check state invariants: %w", err)
+	}
+	report.Invariants = msgs.Records()
+
+	report.Supply, err = states7.AuditSupply(actorsOut, priorEpoch)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to audit supply: %w", err)
+	}
+	report.SupplyViolations = report.Supply.Check(report.InputBalanceTotal).Records()
+	log.Log(rt.INFO, "Dry run found %d invariant violations and %d supply violations after %v",
+		len(report.Invariants), len(report.SupplyViolations), time.Since(startTime))
+	return report, nil
+}
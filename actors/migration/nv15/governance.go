@@ -0,0 +1,62 @@
+package nv15
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Ensures the migrated tree has an actor at the governance address, which manages cron entries and approves
+// pausing miners. A network whose genesis created an actor at that address (as mainnet did, for the verified
+// registry root key) keeps it. Otherwise a multisig of the configured governance signers is created there.
+func ensureGovernanceActor(store adt.Store, actorsOut *states6.Tree, cfg Config) error {
+	_, found, err := actorsOut.GetActor(builtin7.GovernanceActorAddr)
+	if err != nil {
+		return xerrors.Errorf("failed to load governance actor: %w", err)
+	}
+	if found {
+		return nil
+	}
+
+	if len(cfg.GovernanceSigners) == 0 {
+		return xerrors.Errorf("no actor at governance address %v and no governance signers configured", builtin7.GovernanceActorAddr)
+	}
+	if cfg.GovernanceThreshold < 1 || cfg.GovernanceThreshold > uint64(len(cfg.GovernanceSigners)) {
+		return xerrors.Errorf("invalid governance threshold %d for %d signers", cfg.GovernanceThreshold, len(cfg.GovernanceSigners))
+	}
+	for _, signer := range cfg.GovernanceSigners {
+		if signer.Protocol() != address.ID {
+			return xerrors.Errorf("governance signer %v is not an ID address", signer)
+		}
+	}
+
+	emptyMap, err := adt.StoreEmptyMap(store, builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to create empty map: %w", err)
+	}
+	st := multisig7.State{
+		Signers:               cfg.GovernanceSigners,
+		NumApprovalsThreshold: cfg.GovernanceThreshold,
+		NextTxnID:             0,
+		InitialBalance:        big.Zero(),
+		StartEpoch:            0,
+		UnlockDuration:        0,
+		PendingTxns:           emptyMap,
+		PendingTxnMetadata:    emptyMap,
+	}
+	head, err := store.Put(store.Context(), &st)
+	if err != nil {
+		return xerrors.Errorf("failed to flush governance state: %w", err)
+	}
+	return actorsOut.SetActor(builtin7.GovernanceActorAddr, &states6.Actor{
+		Code:       builtin7.MultisigActorCodeID,
+		Head:       head,
+		CallSeqNum: 0,
+		Balance:    big.Zero(),
+	})
+}
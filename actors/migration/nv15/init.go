@@ -0,0 +1,84 @@
+package nv15
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	init6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/init"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	init7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+type initMigrator struct{}
+
+func (m initMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState init6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// Build the reverse index of the address map.
+	adtStore := adt.WrapStore(ctx, store)
+	addressMap, err := adt.AsMap(adtStore, inState.AddressMap, builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load address map: %w", err)
+	}
+	actorAddresses := make(map[abi.ActorID]*init7.ActorAddresses)
+	var actorID cbg.CborInt
+	if err := addressMap.ForEach(&actorID, func(key string) error {
+		a, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		id := abi.ActorID(actorID)
+		if _, ok := actorAddresses[id]; !ok {
+			actorAddresses[id] = &init7.ActorAddresses{}
+		}
+		actorAddresses[id].Addresses = append(actorAddresses[id].Addresses, a)
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate address map: %w", err)
+	}
+
+	reverse, err := adt.MakeEmptyMap(adtStore, builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty actor addresses map: %w", err)
+	}
+	for id, addrs := range actorAddresses { // nolint:nomaprange // HAMT root is independent of insertion order
+		if err := reverse.Put(abi.UIntKey(uint64(id)), addrs); err != nil {
+			return nil, xerrors.Errorf("failed to put addresses for actor %d: %w", id, err)
+		}
+	}
+	reverseRoot, err := reverse.Root()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush actor addresses: %w", err)
+	}
+
+	outState := init7.State{
+		AddressMap:     inState.AddressMap,
+		NextID:         inState.NextID,
+		NetworkName:    inState.NetworkName,
+		ActorAddresses: reverseRoot,
+	}
+
+	newHead, err := store.Put(ctx, &outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m initMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.InitActorCodeID
+}
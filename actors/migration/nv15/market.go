@@ -0,0 +1,108 @@
+package nv15
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Bitwidths with which v6 wrote the market AMTs whose bitwidths v7 takes from policy.
+var marketAmtBitwidthsV6 = market7.AmtBitwidths{
+	Proposals: market6.ProposalsAmtBitwidth,
+	States:    market6.StatesAmtBitwidth,
+}
+
+type marketMigrator struct{}
+
+func (m marketMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState market6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+	ctxStore := adt.WrapStore(ctx, store)
+
+	escrowTableOut, err := migrateBalanceTable(ctxStore, inState.EscrowTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to migrate escrow table: %w", err)
+	}
+	lockedTableOut, err := migrateBalanceTable(ctxStore, inState.LockedTable)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to migrate locked table: %w", err)
+	}
+	dealPriceBuckets, err := adt.StoreEmptyArray(ctxStore, market7.DealPriceBucketsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty deal price buckets: %w", err)
+	}
+
+	outState := market7.State{
+		Proposals:                     inState.Proposals,
+		States:                        inState.States,
+		PendingProposals:              inState.PendingProposals,
+		EscrowTable:                   escrowTableOut,
+		LockedTable:                   lockedTableOut,
+		NextID:                        inState.NextID,
+		DealOpsByEpoch:                inState.DealOpsByEpoch,
+		LastCron:                      inState.LastCron,
+		TotalClientLockedCollateral:   inState.TotalClientLockedCollateral,
+		TotalProviderLockedCollateral: inState.TotalProviderLockedCollateral,
+		TotalClientStorageFee:         inState.TotalClientStorageFee,
+		DealPriceBuckets:              dealPriceBuckets,
+	}
+	// Deals are carried over as v6 wrote them, so are re-encoded if policy configures other bitwidths.
+	if err := outState.ReencodeAmts(ctxStore, marketAmtBitwidthsV6); err != nil {
+		return nil, xerrors.Errorf("failed to re-encode deals: %w", err)
+	}
+
+	newHead, err := store.Put(ctx, &outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m marketMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.StorageMarketActorCodeID
+}
+
+// Copies every balance from a single-HAMT balance table into a new sharded balance table.
+func migrateBalanceTable(store adt.Store, root cid.Cid) (cid.Cid, error) {
+	inTable, err := adt.AsMap(store, root, adt.BalanceTableBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load balance table: %w", err)
+	}
+	outTable, err := adt.MakeEmptyShardedBalanceTable(store)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to construct sharded balance table: %w", err)
+	}
+
+	var balances []adt.BalanceDelta
+	var balance abi.TokenAmount
+	if err := inTable.ForEach(&balance, func(key string) error {
+		a, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return xerrors.Errorf("failed to parse balance table key: %w", err)
+		}
+		balances = append(balances, adt.BalanceDelta{Address: a, Amount: balance})
+		return nil
+	}); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to read balances: %w", err)
+	}
+	if err := outTable.AddMany(balances); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to migrate balances: %w", err)
+	}
+
+	return outTable.Root()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-amt-ipld/v3"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
 	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
@@ -21,6 +22,8 @@ type minerMigrator struct {
 	emptyDeadlineV7  cid.Cid
 	emptyDeadlinesV7 cid.Cid
 	emptySectorsV7   cid.Cid
+	emptyFeeDebtLog  cid.Cid
+	emptyUnsealing   cid.Cid
 }
 
 func newMinerMigrator(ctx context.Context, store cbor.IpldStore) (*minerMigrator, error) {
@@ -68,15 +71,34 @@ func newMinerMigrator(ctx context.Context, store cbor.IpldStore) (*minerMigrator
 		return nil, xerrors.Errorf("failed to construct empty sectors snapshot array: %w", err)
 	}
 
+	efdlCid, err := store.Put(ctx, miner7.ConstructFeeDebtLog())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty fee debt log: %w", err)
+	}
+
+	euwCid, err := store.Put(ctx, miner7.ConstructUnsealingWindows())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct empty unsealing windows: %w", err)
+	}
+
 	return &minerMigrator{
 		emptyDeadlineV6:  edv6cid,
 		emptyDeadlinesV6: edsv6cid,
 		emptyDeadlineV7:  edv7cid,
 		emptyDeadlinesV7: edsv7cid,
 		emptySectorsV7:   essCid,
+		emptyFeeDebtLog:  efdlCid,
+		emptyUnsealing:   euwCid,
 	}, nil
 }
 
+// Bitwidths with which v6 wrote the miner AMTs whose bitwidths v7 takes from policy.
+var minerAmtBitwidthsV6 = miner7.AmtBitwidths{
+	Sectors:              miner6.SectorsAmtBitwidth,
+	DeadlineExpirations:  miner6.DeadlineExpirationAmtBitwidth,
+	PartitionExpirations: miner6.PartitionExpirationAmtBitwidth,
+}
+
 func (m minerMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
 	var inState miner6.State
 	if err := store.Get(ctx, in.head, &inState); err != nil {
@@ -86,6 +108,12 @@ func (m minerMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 	outState := fromv6State(inState)
 	ctxStore := adt.WrapStore(ctx, store)
 
+	infoOut, err := migrateInfo(ctx, store, inState.Info)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to migrate miner info: %w", err)
+	}
+	outState.Info = infoOut
+
 	sectorsOut, err := migrateSectors(ctx, ctxStore, in.cache, in.address, inState.Sectors)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to migrate sectors: %w", err)
@@ -99,6 +127,8 @@ func (m minerMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 	}
 
 	outState.Deadlines = deadlinesOut
+	outState.FeeDebtLog = m.emptyFeeDebtLog
+	outState.UnsealingWindows = m.emptyUnsealing
 
 	newHead, err := store.Put(ctx, &outState)
 	if err != nil {
@@ -135,7 +165,7 @@ func migrateSectors(ctx context.Context, store adt.Store, cache MigrationCache,
 		var outArray *adt.Array
 		if okIn && okOut {
 			// we have previous work, but the AMT has changed -- diff them
-			diffs, err := amt.Diff(ctx, store, store, prevInRoot, inRoot, amt.UseTreeBitWidth(miner7.SectorsAmtBitwidth))
+			diffs, err := amt.Diff(ctx, store, store, prevInRoot, inRoot, amt.UseTreeBitWidth(miner6.SectorsAmtBitwidth))
 			if err != nil {
 				return cid.Undef, xerrors.Errorf("failed to diff old and new Sector AMTs: %w", err)
 			}
@@ -232,6 +262,13 @@ func (m minerMigrator) migrateDeadlines(ctx context.Context, store adt.Store, de
 			} else {
 				outDeadline.SectorsSnapshot = m.emptySectorsV7
 			}
+			// The expiration queues are carried over as v6 wrote them, so are re-encoded if policy configures
+			// other bitwidths. The sectors snapshot was written with the configured bitwidth.
+			from := minerAmtBitwidthsV6
+			from.Sectors = miner7.SectorsAmtBitwidth
+			if err := outDeadline.ReencodeAmts(store, from); err != nil {
+				return cid.Undef, xerrors.Errorf("failed to re-encode deadline %d: %w", i, err)
+			}
 
 			outDlCid, err := store.Put(ctx, &outDeadline)
 			if err != nil {
@@ -264,7 +301,43 @@ func migrateSectorInfo(sectorInfo miner6.SectorOnChainInfo) *miner7.SectorOnChai
 	}
 }
 
-// copies over all fields except Sectors and Deadlines
+// The owner becomes the beneficiary of a miner's funds, without any term, as for a new miner.
+func migrateInfo(ctx context.Context, store cbor.IpldStore, infoCid cid.Cid) (cid.Cid, error) {
+	var inInfo miner6.MinerInfo
+	if err := store.Get(ctx, infoCid, &inInfo); err != nil {
+		return cid.Undef, err
+	}
+	var pendingWorkerKey *miner7.WorkerKeyChange
+	if inInfo.PendingWorkerKey != nil {
+		pendingWorkerKey = &miner7.WorkerKeyChange{
+			NewWorker:   inInfo.PendingWorkerKey.NewWorker,
+			EffectiveAt: inInfo.PendingWorkerKey.EffectiveAt,
+		}
+	}
+	outInfo := miner7.MinerInfo{
+		Owner:            inInfo.Owner,
+		Worker:           inInfo.Worker,
+		ControlAddresses: inInfo.ControlAddresses,
+		PendingWorkerKey: pendingWorkerKey,
+		Beneficiary:      inInfo.Owner,
+		BeneficiaryTerm: miner7.BeneficiaryTerm{
+			Quota:      big.Zero(),
+			Expiration: 0,
+			UsedQuota:  big.Zero(),
+		},
+		PendingBeneficiaryTerm:     nil,
+		PeerId:                     inInfo.PeerId,
+		Multiaddrs:                 inInfo.Multiaddrs,
+		WindowPoStProofType:        inInfo.WindowPoStProofType,
+		SectorSize:                 inInfo.SectorSize,
+		WindowPoStPartitionSectors: inInfo.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      inInfo.ConsensusFaultElapsed,
+		PendingOwnerAddress:        inInfo.PendingOwnerAddress,
+	}
+	return store.Put(ctx, &outInfo)
+}
+
+// copies over all fields except Info, Sectors, Deadlines, FeeDebtLog and UnsealingWindows
 func fromv6State(inState miner6.State) miner7.State {
 	return miner7.State{
 		Info:                       inState.Info,
@@ -280,10 +353,12 @@ func fromv6State(inState miner6.State) miner7.State {
 		CurrentDeadline:            inState.CurrentDeadline,
 		EarlyTerminations:          inState.EarlyTerminations,
 		DeadlineCronActive:         inState.DeadlineCronActive,
+		// Deadlines of existing miners have never been scanned, so are assumed to be imbalanced.
+		Rebalance: miner7.RebalanceCursor{Imbalanced: true},
 	}
 }
 
-// copies over all fields except SectorsSnapshot
+// copies over all fields except SectorsSnapshot; sector exit counts begin at zero
 func fromv6Deadline(inDeadline miner6.Deadline) miner7.Deadline {
 	return miner7.Deadline{
 		Partitions:                        inDeadline.Partitions,
@@ -0,0 +1,55 @@
+package nv15
+
+import (
+	"context"
+
+	multisig6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/multisig"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+type multisigMigrator struct{}
+
+func (m multisigMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState multisig6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	pendingMetadata, err := adt.StoreEmptyMap(adt.WrapStore(ctx, store), builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty transaction metadata map: %w", err)
+	}
+
+	// Migrated multisigs have no spending limit, and no metadata for pending transactions.
+	outState := multisig7.State{
+		Signers:               inState.Signers,
+		NumApprovalsThreshold: inState.NumApprovalsThreshold,
+		NextTxnID:             inState.NextTxnID,
+		InitialBalance:        inState.InitialBalance,
+		StartEpoch:            inState.StartEpoch,
+		UnlockDuration:        inState.UnlockDuration,
+		PendingTxns:           inState.PendingTxns,
+		SpendingLimit:         nil,
+		PendingTxnMetadata:    pendingMetadata,
+	}
+
+	newHead, err := store.Put(ctx, &outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m multisigMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.MultisigActorCodeID
+}
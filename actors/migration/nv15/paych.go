@@ -0,0 +1,49 @@
+package nv15
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-bitfield"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	paych7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+)
+
+type paychMigrator struct{}
+
+func (m paychMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState paych6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// Migrated channels have a single payee, have never compacted lanes, and settle after the default delay.
+	outState := paych7.State{
+		From:            inState.From,
+		To:              inState.To,
+		ToSend:          inState.ToSend,
+		SettlingAt:      inState.SettlingAt,
+		MinSettleHeight: inState.MinSettleHeight,
+		LaneStates:      inState.LaneStates,
+		RetiredLanes:    bitfield.New(),
+		SettleDelay:     paych7.SettleDelay,
+	}
+
+	newHead, err := store.Put(ctx, &outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m paychMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.PaymentChannelActorCodeID
+}
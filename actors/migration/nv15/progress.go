@@ -0,0 +1,169 @@
+package nv15
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+)
+
+// ProgressReporter receives periodic snapshots of a migration's progress.
+// Its implementation must be threadsafe.
+type ProgressReporter interface {
+	ReportProgress(p Progress)
+}
+
+// A snapshot of a migration's progress.
+type Progress struct {
+	// Time since the migration started.
+	Elapsed time.Duration
+	// Number of actor migration jobs created so far.
+	JobsCreated uint32
+	// Number of actor migration jobs completed so far.
+	JobsDone uint32
+	// Whether all jobs have been created, i.e. JobsCreated is the total number of actors to migrate.
+	AllJobsCreated bool
+	// Number and size of the state objects written to the store so far.
+	ObjectsWritten uint64
+	BytesWritten   uint64
+	// Completed jobs and the total time spent running them, by the name of the prior actor code.
+	Kinds map[string]KindProgress
+	// Estimated time until all jobs are done, assuming the rate of completion so far is maintained.
+	// Zero until all jobs have been created and at least one is done.
+	Remaining time.Duration
+}
+
+// Progress of the migrations of actors of one kind.
+type KindProgress struct {
+	JobsDone uint64
+	// Total time spent by workers running the jobs. With multiple workers this may exceed the elapsed time.
+	Duration time.Duration
+	// Number of state objects read and written by the jobs, including those served by a store cache.
+	NodesRead    uint64
+	NodesWritten uint64
+}
+
+// Collects the progress of a migration from its workers.
+type progressTracker struct {
+	start          time.Time
+	jobsCreated    *uint32
+	jobsDone       *uint32
+	allJobsCreated uint32 // Set to one once all jobs are created.
+	objectsWritten uint64
+	bytesWritten   uint64
+
+	lk    sync.Mutex
+	kinds map[string]KindProgress
+}
+
+func newProgressTracker(start time.Time, jobsCreated, jobsDone *uint32) *progressTracker {
+	return &progressTracker{
+		start:       start,
+		jobsCreated: jobsCreated,
+		jobsDone:    jobsDone,
+		kinds:       make(map[string]KindProgress),
+	}
+}
+
+func (t *progressTracker) finishedCreating() {
+	atomic.StoreUint32(&t.allJobsCreated, 1)
+}
+
+func (t *progressTracker) recordJob(code cid.Cid, duration time.Duration, store *countingStore) {
+	name := actorNameByCode(code)
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	kind := t.kinds[name]
+	kind.JobsDone++
+	kind.Duration += duration
+	kind.NodesRead += atomic.LoadUint64(&store.reads)
+	kind.NodesWritten += atomic.LoadUint64(&store.writes)
+	t.kinds[name] = kind
+}
+
+func (t *progressTracker) recordWrite(bytes int) {
+	atomic.AddUint64(&t.objectsWritten, 1)
+	atomic.AddUint64(&t.bytesWritten, uint64(bytes))
+}
+
+func (t *progressTracker) snapshot() Progress {
+	p := Progress{
+		Elapsed:        time.Since(t.start),
+		JobsCreated:    atomic.LoadUint32(t.jobsCreated),
+		JobsDone:       atomic.LoadUint32(t.jobsDone),
+		AllJobsCreated: atomic.LoadUint32(&t.allJobsCreated) == 1,
+		ObjectsWritten: atomic.LoadUint64(&t.objectsWritten),
+		BytesWritten:   atomic.LoadUint64(&t.bytesWritten),
+		Kinds:          make(map[string]KindProgress),
+	}
+	t.lk.Lock()
+	for name, kind := range t.kinds { // nolint:nomaprange
+		p.Kinds[name] = kind
+	}
+	t.lk.Unlock()
+
+	if p.AllJobsCreated && p.JobsDone > 0 {
+		remaining := p.JobsCreated - p.JobsDone
+		p.Remaining = time.Duration(int64(p.Elapsed) / int64(p.JobsDone) * int64(remaining))
+	}
+	return p
+}
+
+// Decorates a store to count the objects and bytes written through it.
+// Objects are encoded once and written through in encoded form.
+type meteredStore struct {
+	cbor.IpldStore
+	tracker *progressTracker
+}
+
+func (s *meteredStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	m, ok := v.(cbg.CBORMarshaler)
+	if !ok {
+		s.tracker.recordWrite(0)
+		return s.IpldStore.Put(ctx, v)
+	}
+	var buf bytes.Buffer
+	if err := m.MarshalCBOR(&buf); err != nil {
+		return cid.Undef, err
+	}
+	raw := buf.Bytes()
+	c, err := s.IpldStore.Put(ctx, &cbg.Deferred{Raw: raw})
+	if err != nil {
+		return cid.Undef, err
+	}
+	s.tracker.recordWrite(len(raw))
+	return c, nil
+}
+
+// Decorates a store to count the objects read and written through it.
+type countingStore struct {
+	cbor.IpldStore
+	reads  uint64
+	writes uint64
+}
+
+func (s *countingStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	atomic.AddUint64(&s.reads, 1)
+	return s.IpldStore.Get(ctx, c, out)
+}
+
+func (s *countingStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	atomic.AddUint64(&s.writes, 1)
+	return s.IpldStore.Put(ctx, v)
+}
+
+// Returns the name of an actor's prior version code, which is a v7 code when downgrading.
+func actorNameByCode(code cid.Cid) string {
+	if name := builtin6.ActorNameByCode(code); name != "<unknown>" {
+		return name
+	}
+	return builtin7.ActorNameByCode(code)
+}
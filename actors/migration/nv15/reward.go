@@ -0,0 +1,53 @@
+package nv15
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-state-types/big"
+	reward6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/reward"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	reward7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+)
+
+type rewardMigrator struct{}
+
+func (m rewardMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState reward6.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, xerrors.Errorf("getting inState: %w", err)
+	}
+
+	// No penalties were recycled before the migration.
+	outState := reward7.State{
+		CumsumBaseline:          inState.CumsumBaseline,
+		CumsumRealized:          inState.CumsumRealized,
+		EffectiveNetworkTime:    inState.EffectiveNetworkTime,
+		EffectiveBaselinePower:  inState.EffectiveBaselinePower,
+		ThisEpochReward:         inState.ThisEpochReward,
+		ThisEpochRewardSmoothed: inState.ThisEpochRewardSmoothed,
+		ThisEpochBaselinePower:  inState.ThisEpochBaselinePower,
+		Epoch:                   inState.Epoch,
+		TotalStoragePowerReward: inState.TotalStoragePowerReward,
+		SimpleTotal:             inState.SimpleTotal,
+		BaselineTotal:           inState.BaselineTotal,
+		TotalRecycledPenalties:  big.Zero(),
+	}
+
+	newHead, err := store.Put(ctx, &outState)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to flush outState: %w", err)
+	}
+
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, nil
+}
+
+func (m rewardMigrator) migratedCodeCID() cid.Cid {
+	return builtin7.RewardActorCodeID
+}
@@ -0,0 +1,120 @@
+package test_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestMigrationToPolicyBitwidths(t *testing.T) {
+	prior := newPriorTree(t)
+	minerAddr := tutil.NewIDAddr(t, 5000)
+	owner := tutil.NewIDAddr(t, 5001)
+
+	info, err := miner6.ConstructMinerInfo(owner, owner, nil, nil, nil, abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	infoCid, err := prior.store.Put(prior.store.Context(), info)
+	require.NoError(t, err)
+	inState, err := miner6.ConstructState(prior.store, infoCid, 0, 0)
+	require.NoError(t, err)
+	sectors := make([]*miner6.SectorOnChainInfo, 10)
+	for i := range sectors {
+		sectors[i] = &miner6.SectorOnChainInfo{
+			SectorNumber:          abi.SectorNumber(i),
+			SealProof:             abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			SealedCID:             tutil.MakeCID("sealed", &miner6.SealedCIDPrefix),
+			Activation:            0,
+			Expiration:            1_000_000 + abi.ChainEpoch(i),
+			DealWeight:            big.Zero(),
+			VerifiedDealWeight:    big.Zero(),
+			InitialPledge:         big.Zero(),
+			ExpectedDayReward:     big.Zero(),
+			ExpectedStoragePledge: big.Zero(),
+			ReplacedDayReward:     big.Zero(),
+		}
+	}
+	require.NoError(t, inState.PutSectors(prior.store, sectors...))
+	require.NoError(t, inState.AssignSectorsToDeadlines(prior.store, 0, sectors, 2349, 32<<30))
+	prior.setState(minerAddr, builtin6.StorageMinerActorCodeID, inState)
+
+	// Configure bitwidths other than those v6 wrote with.
+	minerDefaults, marketDefaults := miner7.CurrentAmtBitwidths(), market7.CurrentAmtBitwidths()
+	defer func() {
+		miner7.SectorsAmtBitwidth = minerDefaults.Sectors
+		miner7.DeadlineExpirationAmtBitwidth = minerDefaults.DeadlineExpirations
+		miner7.PartitionExpirationAmtBitwidth = minerDefaults.PartitionExpirations
+		market7.ProposalsAmtBitwidth = marketDefaults.Proposals
+		market7.StatesAmtBitwidth = marketDefaults.States
+	}()
+	miner7.SectorsAmtBitwidth = 3
+	miner7.DeadlineExpirationAmtBitwidth = 2
+	miner7.PartitionExpirationAmtBitwidth = 2
+	market7.ProposalsAmtBitwidth = 3
+	market7.StatesAmtBitwidth = 4
+
+	migrated := prior.migrate()
+
+	t.Run("miner state is readable with the configured bitwidths", func(t *testing.T) {
+		var st miner7.State
+		migrated.getState(minerAddr, builtin7.StorageMinerActorCodeID, &st)
+		outSectors, err := miner7.LoadSectors(migrated.store, st.Sectors)
+		require.NoError(t, err)
+		for _, s := range sectors {
+			_, found, err := outSectors.Get(s.SectorNumber)
+			require.NoError(t, err)
+			assert.True(t, found, "sector %d", s.SectorNumber)
+		}
+
+		deadlines, err := st.LoadDeadlines(migrated.store)
+		require.NoError(t, err)
+		assigned := uint64(0)
+		require.NoError(t, deadlines.ForEach(migrated.store, func(dlIdx uint64, dl *miner7.Deadline) error {
+			quant := st.QuantSpecForDeadline(dlIdx)
+			_, err := miner7.LoadBitfieldQueue(migrated.store, dl.ExpirationsEpochs, quant, miner7.DeadlineExpirationAmtBitwidth)
+			require.NoError(t, err)
+			partitions, err := dl.PartitionsArray(migrated.store)
+			require.NoError(t, err)
+			var partition miner7.Partition
+			return partitions.ForEach(&partition, func(int64) error {
+				queue, err := miner7.LoadExpirationQueue(migrated.store, partition.ExpirationsEpochs, quant, miner7.PartitionExpirationAmtBitwidth)
+				require.NoError(t, err)
+				var es miner7.ExpirationSet
+				return queue.ForEach(&es, func(int64) error {
+					n, err := es.OnTimeSectors.Count()
+					assigned += n
+					return err
+				})
+			})
+		}))
+		assert.Equal(t, uint64(len(sectors)), assigned)
+	})
+
+	t.Run("market state is readable with the configured bitwidths", func(t *testing.T) {
+		var st market7.State
+		migrated.getState(builtin7.StorageMarketActorAddr, builtin7.StorageMarketActorCodeID, &st)
+		_, err := market7.AsDealProposalArray(migrated.store, st.Proposals)
+		require.NoError(t, err)
+		_, err = market7.AsDealStateArray(migrated.store, st.States)
+		require.NoError(t, err)
+	})
+
+	t.Run("downgrade is refused", func(t *testing.T) {
+		root, err := migrated.tree.Flush()
+		require.NoError(t, err)
+		_, err = nv15.DowngradeStateTree(migrated.store.Context(), migrated.store, root, 0, nv15.Config{MaxWorkers: 2}, nv15.TestLogger{TB: t})
+		require.Error(t, err)
+		assert.True(t, xerrors.Is(err, nv15.ErrIrreversible), err.Error())
+	})
+}
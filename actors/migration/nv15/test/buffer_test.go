@@ -0,0 +1,87 @@
+package test_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	block "github.com/ipfs/go-block-format"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+)
+
+func TestBufferedBlockstore(t *testing.T) {
+	spillDir := t.TempDir()
+	buffer := nv15.NewBufferedBlockstore(nil, 100, spillDir)
+
+	var blocks []block.Block
+	for i := 0; i < 10; i++ {
+		b := block.NewBlock([]byte(fmt.Sprintf("block %d with some padding to fill the buffer", i)))
+		blocks = append(blocks, b)
+		require.NoError(t, buffer.Put(b))
+		require.NoError(t, buffer.Put(b)) // Idempotent.
+	}
+
+	count, total := buffer.Written()
+	assert.Equal(t, uint64(10), count)
+	memory, spilled := buffer.BufferedBytes()
+	assert.True(t, memory <= 100)
+	assert.True(t, spilled > 0)
+	assert.Equal(t, total, memory+spilled)
+	files, err := os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	for _, b := range blocks {
+		got, err := buffer.Get(b.Cid())
+		require.NoError(t, err)
+		assert.Equal(t, b.RawData(), got.RawData())
+	}
+
+	// Flushing writes every block, and removes the spill file.
+	dest := ipld2.NewSyncBlockStoreInMemory()
+	require.NoError(t, buffer.Flush(dest))
+	for _, b := range blocks {
+		got, err := dest.Get(b.Cid())
+		require.NoError(t, err)
+		assert.Equal(t, b.RawData(), got.RawData())
+	}
+	count, _ = buffer.Written()
+	assert.Zero(t, count)
+	files, err = os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDryRunSpillsToDisk(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := newSingletonsVM(ctx, t, bs)
+	store := cbor.NewCborStore(bs)
+
+	unbounded, err := nv15.DryRunMigration(ctx, store, vm.StateRoot(), abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+	require.NoError(t, err)
+
+	spillDir := t.TempDir()
+	bounded, err := nv15.DryRunMigration(ctx, store, vm.StateRoot(), abi.ChainEpoch(0), nv15.Config{
+		MaxWorkers:        2,
+		BufferMemoryBytes: 512,
+		BufferSpillDir:    spillDir,
+	}, log)
+	require.NoError(t, err)
+	assert.Equal(t, unbounded.OutputRoot, bounded.OutputRoot)
+	assert.Equal(t, unbounded.BlocksWritten, bounded.BlocksWritten)
+	assert.True(t, bounded.OK())
+
+	// The spill file is removed when the dry run completes.
+	files, err := os.ReadDir(spillDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,83 @@
+package test_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+func TestFileMigrationCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+
+	cache, err := nv15.OpenFileMigrationCache(path)
+	require.NoError(t, err)
+	key := nv15.ActorCodeHeadKey(builtin6.StorageMinerActorCodeID, builtin6.MultisigActorCodeID)
+	require.NoError(t, cache.Write(key, builtin6.AccountActorCodeID))
+	loaded, err := cache.Load("other", func() (cid.Cid, error) { return builtin6.CronActorCodeID, nil })
+	require.NoError(t, err)
+	assert.Equal(t, builtin6.CronActorCodeID, loaded)
+	require.Error(t, cache.Write("bad key", builtin6.CronActorCodeID))
+	require.NoError(t, cache.Close())
+
+	reopened, err := nv15.OpenFileMigrationCache(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, reopened.Close()) }()
+	found, value, err := reopened.Read(key)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, builtin6.AccountActorCodeID, value)
+	found, value, err = reopened.Read("other")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, builtin6.CronActorCodeID, value)
+
+	// Keys depend on the code as well as the head.
+	assert.NotEqual(t, key, nv15.ActorCodeHeadKey(builtin6.MultisigActorCodeID, builtin6.MultisigActorCodeID))
+}
+
+func TestMigrationCacheReusedAcrossRuns(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	adtStore := adt7.WrapStore(ctx, cbor.NewCborStore(bs))
+	startRoot := vm.StateRoot()
+	path := filepath.Join(t.TempDir(), "cache")
+
+	cache, err := nv15.OpenFileMigrationCache(path)
+	require.NoError(t, err)
+	preRoot, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, cache)
+	require.NoError(t, err)
+	require.NoError(t, cache.Close())
+
+	// A later migration from the persisted cache finds every migrated actor cached and produces the same result.
+	cache, err = nv15.OpenFileMigrationCache(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, cache.Close()) }()
+	root, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(100), nv15.Config{MaxWorkers: 2}, log, cache)
+	require.NoError(t, err)
+	assert.Equal(t, preRoot, root)
+
+	found, _, err := cache.Read(nv15.ActorCodeHeadKey(builtin6.RewardActorCodeID, rewardHead(t, vm)))
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func rewardHead(t *testing.T, vm *vm6.VM) cid.Cid {
+	actor, found, err := vm.GetActor(builtin6.RewardActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	return actor.Head
+}
@@ -0,0 +1,45 @@
+package test_test
+
+import (
+	"testing"
+
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	cron6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/cron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestCronMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	var inState cron6.State
+	prior.getState(builtin6.CronActorAddr, &inState)
+	inState.Entries = append(inState.Entries, cron6.Entry{Receiver: tutil.NewIDAddr(t, 4000), MethodNum: 7})
+	require.Len(t, inState.Entries, 3) // with the power and market entries from genesis
+	prior.setState(builtin6.CronActorAddr, builtin6.CronActorCodeID, &inState)
+
+	migrated := prior.migrate()
+	var outState cron7.State
+	migrated.getState(builtin7.CronActorAddr, builtin7.CronActorCodeID, &outState)
+
+	t.Run("entries are kept in order without budgets or flags", func(t *testing.T) {
+		require.Len(t, outState.Entries, len(inState.Entries))
+		for i, in := range inState.Entries {
+			out := outState.Entries[i]
+			assert.Equal(t, in.Receiver, out.Receiver, "entry %d", i)
+			assert.Equal(t, in.MethodNum, out.MethodNum, "entry %d", i)
+			assert.Zero(t, out.GasBudget, "entry %d", i)
+			assert.False(t, out.Flagged, "entry %d", i)
+		}
+	})
+
+	t.Run("there are no tick reports", func(t *testing.T) {
+		reports, err := adt7.AsArray(migrated.store, outState.TickReports, cron7.TickReportsAmtBitwidth)
+		require.NoError(t, err)
+		assert.Zero(t, reports.Length())
+	})
+}
@@ -0,0 +1,62 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+func TestDowngradeStateTree(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	store := cbor.NewCborStore(bs)
+	adtStore := adt7.WrapStore(ctx, store)
+	startRoot := vm.StateRoot()
+
+	migratedRoot, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		// Nothing has happened since the migration, so downgrading restores the prior state exactly.
+		root, err := nv15.DowngradeStateTree(ctx, store, migratedRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+		require.NoError(t, err)
+		assert.Equal(t, startRoot, root)
+	})
+
+	t.Run("irreversible", func(t *testing.T) {
+		// Give a cron entry a gas budget, which v6 can't represent.
+		tree, err := states7.LoadTree(adtStore, migratedRoot)
+		require.NoError(t, err)
+		cronActor, found, err := tree.GetActor(builtin7.CronActorAddr)
+		require.NoError(t, err)
+		require.True(t, found)
+		var cronState cron7.State
+		require.NoError(t, store.Get(ctx, cronActor.Head, &cronState))
+		require.NotEmpty(t, cronState.Entries)
+		cronState.Entries[0].GasBudget = 1_000_000
+		cronActor.Head, err = store.Put(ctx, &cronState)
+		require.NoError(t, err)
+		require.NoError(t, tree.SetActor(builtin7.CronActorAddr, cronActor))
+		root, err := tree.Flush()
+		require.NoError(t, err)
+
+		_, err = nv15.DowngradeStateTree(ctx, store, root, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+		require.Error(t, err)
+		assert.True(t, xerrors.Is(err, nv15.ErrIrreversible), err.Error())
+	})
+}
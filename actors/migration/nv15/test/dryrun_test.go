@@ -0,0 +1,54 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+func TestDryRunMigration(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := newSingletonsVM(ctx, t, bs)
+	store := cbor.NewCborStore(bs)
+	startRoot := vm.StateRoot()
+
+	report, err := nv15.DryRunMigration(ctx, store, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+	require.NoError(t, err)
+	assert.Equal(t, startRoot, report.InputRoot)
+	assert.True(t, report.BlocksWritten > 0)
+	assert.True(t, report.BytesWritten > 0)
+	assert.True(t, report.OK(), "invariants: %v, supply: %v", report.Invariants, report.SupplyViolations)
+	assert.Equal(t, report.InputBalanceTotal, report.Supply.Total)
+
+	// The dry run wrote nothing to the node's store.
+	_, err = bs.Get(report.OutputRoot)
+	require.Error(t, err)
+
+	// The real migration produces the same state.
+	root, err := nv15.MigrateStateTree(ctx, adt7.WrapStore(ctx, store), startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 1}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+	assert.Equal(t, root, report.OutputRoot)
+	_, err = bs.Get(report.OutputRoot)
+	require.NoError(t, err)
+}
+
+// Creates a v6 VM with the singleton actors, after the cron tick of epoch zero. The reward actor's state satisfies
+// the invariants checked after migration only once it has been updated by a cron tick.
+func newSingletonsVM(ctx context.Context, t *testing.T, bs cbor.IpldBlockstore) *vm6.VM {
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	vm6.RequireApplyMessage(t, vm, builtin6.SystemActorAddr, builtin6.CronActorAddr, big.Zero(), builtin6.MethodsCron.EpochTick, nil, t.Name())
+	return vm
+}
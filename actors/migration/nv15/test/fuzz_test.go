@@ -0,0 +1,559 @@
+package test_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	rlepluslazy "github.com/filecoin-project/go-bitfield/rle"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	cron6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/cron"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	multisig6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/multisig"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	paych7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Migrates state trees holding actors with randomly generated, schema-valid v6 state, and checks that
+// the migrated state is structurally sound and equivalent to the input, field by field.
+// Run with -fuzz=FuzzMigration to explore beyond the seed corpus.
+func FuzzMigration(f *testing.F) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		ctx := context.Background()
+		bs := ipld2.NewSyncBlockStoreInMemory()
+		vm := vm6.NewVMWithSingletons(ctx, t, bs)
+		store := adt7.WrapStore(ctx, ipldcbor.NewCborStore(bs))
+		tree, err := states6.LoadTree(store, vm.StateRoot())
+		require.NoError(t, err)
+
+		g := &stateGen{t: t, rnd: rand.New(rand.NewSource(seed)), store: store, nextID: 1000}
+		var actors []fuzzActor
+		for i := 0; i < 1+g.rnd.Intn(12); i++ {
+			var a fuzzActor
+			switch g.rnd.Intn(5) {
+			case 0:
+				a = g.multisig()
+			case 1:
+				a = g.paych()
+			case 2:
+				a = g.miner()
+			case 3:
+				a = g.market()
+			case 4:
+				a = g.cron()
+			}
+			a.addr = g.idAddr()
+			head, err := store.Put(ctx, a.state)
+			require.NoError(t, err)
+			require.NoError(t, tree.SetActor(a.addr, &states6.Actor{Code: a.code, Head: head, Balance: g.tokens()}))
+			actors = append(actors, a)
+		}
+		startRoot, err := tree.Flush()
+		require.NoError(t, err)
+
+		root, err := nv15.MigrateStateTree(ctx, store, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, nv15.TestLogger{TB: t}, nv15.NewMemMigrationCache())
+		require.NoError(t, err)
+		treeOut, err := states7.LoadTree(store, root)
+		require.NoError(t, err)
+
+		for _, a := range actors {
+			actorOut, found, err := treeOut.GetActor(a.addr)
+			require.NoError(t, err)
+			require.True(t, found, a.addr)
+			switch in := a.state.(type) {
+			case *multisig6.State:
+				checkMultisig(t, store, in, actorOut)
+			case *paych6.State:
+				checkPaych(t, store, in, actorOut)
+			case *miner6.State:
+				checkMiner(t, store, in, actorOut)
+			case *market6.State:
+				checkMarket(t, store, in, actorOut)
+			case *cron6.State:
+				checkCron(t, store, in, actorOut)
+			}
+		}
+	})
+}
+
+// A generated actor, with its prior version code and state.
+type fuzzActor struct {
+	addr  addr.Address
+	code  cid.Cid
+	state cbor.Marshaler
+}
+
+// Generates random state values, favouring the edges of each value's range.
+type stateGen struct {
+	t      *testing.T
+	rnd    *rand.Rand
+	store  adt7.Store
+	nextID uint64
+}
+
+func (g *stateGen) idAddr() addr.Address {
+	g.nextID++
+	return tutil.NewIDAddr(g.t, g.nextID)
+}
+
+func (g *stateGen) idAddrs(max int) []addr.Address {
+	out := make([]addr.Address, g.rnd.Intn(max+1))
+	for i := range out {
+		out[i] = g.idAddr()
+	}
+	return out
+}
+
+func (g *stateGen) tokens() abi.TokenAmount {
+	switch g.rnd.Intn(4) {
+	case 0:
+		return big.Zero()
+	case 1:
+		return big.NewInt(g.rnd.Int63n(1000))
+	case 2:
+		// Larger than the total supply.
+		return big.Lsh(big.NewInt(g.rnd.Int63()), 64)
+	}
+	return big.NewInt(g.rnd.Int63())
+}
+
+func (g *stateGen) epoch() abi.ChainEpoch {
+	switch g.rnd.Intn(3) {
+	case 0:
+		return 0
+	case 1:
+		return abi.ChainEpoch(g.rnd.Int63n(1 << 20))
+	}
+	return abi.ChainEpoch(g.rnd.Int63())
+}
+
+func (g *stateGen) bytes(max int) []byte {
+	out := make([]byte, g.rnd.Intn(max+1))
+	g.rnd.Read(out)
+	return out
+}
+
+// Returns an empty bitfield, a long run, or sparse bits up to very large values.
+func (g *stateGen) bitfield() bitfield.BitField {
+	switch g.rnd.Intn(3) {
+	case 0:
+		return bitfield.New()
+	case 1:
+		runs := []rlepluslazy.Run{
+			{Val: false, Len: g.rnd.Uint64() >> 2},
+			{Val: true, Len: 1 + g.rnd.Uint64()>>40},
+		}
+		bf, err := bitfield.NewFromIter(&rlepluslazy.RunSliceIterator{Runs: runs})
+		require.NoError(g.t, err)
+		return bf
+	}
+	bits := make([]uint64, 1+g.rnd.Intn(100))
+	for i := range bits {
+		bits[i] = g.rnd.Uint64() >> 2
+	}
+	return bitfield.NewFromSet(bits)
+}
+
+// Returns a printable string of exactly n bytes.
+func (g *stateGen) label(n int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = chars[g.rnd.Intn(len(chars))]
+	}
+	return string(out)
+}
+
+func (g *stateGen) cid() cid.Cid {
+	return tutil.MakeCID(fmt.Sprintf("%d", g.rnd.Int63()), &miner7.SealedCIDPrefix)
+}
+
+func (g *stateGen) multisig() fuzzActor {
+	signers := g.idAddrs(5)
+	txns, err := adt7.MakeEmptyMap(g.store, builtin6.DefaultHamtBitwidth)
+	require.NoError(g.t, err)
+	nextTxnID := multisig6.TxnID(0)
+	for i := 0; i < g.rnd.Intn(10); i++ {
+		approved := signers
+		if len(approved) > 0 {
+			approved = approved[:g.rnd.Intn(len(approved))]
+		}
+		require.NoError(g.t, txns.Put(nextTxnID, &multisig6.Transaction{
+			To:       g.idAddr(),
+			Value:    g.tokens(),
+			Method:   abi.MethodNum(g.rnd.Intn(10)),
+			Params:   g.bytes(1 << 10),
+			Approved: approved,
+		}))
+		nextTxnID++
+	}
+	return fuzzActor{code: builtin6.MultisigActorCodeID, state: &multisig6.State{
+		Signers:               signers,
+		NumApprovalsThreshold: uint64(g.rnd.Intn(len(signers) + 1)),
+		NextTxnID:             nextTxnID,
+		InitialBalance:        g.tokens(),
+		StartEpoch:            g.epoch(),
+		UnlockDuration:        g.epoch(),
+		PendingTxns:           tutil.MustRoot(g.t, txns),
+	}}
+}
+
+func (g *stateGen) paych() fuzzActor {
+	lanes, err := adt7.MakeEmptyArray(g.store, paych6.LaneStatesAmtBitwidth)
+	require.NoError(g.t, err)
+	for i := 0; i < g.rnd.Intn(20); i++ {
+		require.NoError(g.t, lanes.Set(g.rnd.Uint64()>>1, &paych6.LaneState{Redeemed: g.tokens(), Nonce: g.rnd.Uint64()}))
+	}
+	return fuzzActor{code: builtin6.PaymentChannelActorCodeID, state: &paych6.State{
+		From:            g.idAddr(),
+		To:              g.idAddr(),
+		ToSend:          g.tokens(),
+		SettlingAt:      g.epoch(),
+		MinSettleHeight: g.epoch(),
+		LaneStates:      tutil.MustRoot(g.t, lanes),
+	}}
+}
+
+func (g *stateGen) miner() fuzzActor {
+	owner := g.idAddr()
+	info, err := miner6.ConstructMinerInfo(owner, owner, g.idAddrs(3), g.bytes(64), nil, abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(g.t, err)
+	infoCid, err := g.store.Put(g.store.Context(), info)
+	require.NoError(g.t, err)
+	st, err := miner6.ConstructState(g.store, infoCid, g.epoch()%miner6.WPoStProvingPeriod, uint64(g.rnd.Intn(int(miner6.WPoStPeriodDeadlines))))
+	require.NoError(g.t, err)
+
+	sectors, err := adt7.MakeEmptyArray(g.store, miner6.SectorsAmtBitwidth)
+	require.NoError(g.t, err)
+	for i := 0; i < g.rnd.Intn(50); i++ {
+		number := abi.SectorNumber(g.rnd.Int63n(abi.MaxSectorNumber))
+		dealIDs := make([]abi.DealID, g.rnd.Intn(300))
+		for j := range dealIDs {
+			dealIDs[j] = abi.DealID(g.rnd.Uint64() >> 1)
+		}
+		require.NoError(g.t, sectors.Set(uint64(number), &miner6.SectorOnChainInfo{
+			SectorNumber:          number,
+			SealProof:             abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			SealedCID:             g.cid(),
+			DealIDs:               dealIDs,
+			Activation:            g.epoch(),
+			Expiration:            g.epoch(),
+			DealWeight:            g.tokens(),
+			VerifiedDealWeight:    g.tokens(),
+			InitialPledge:         g.tokens(),
+			ExpectedDayReward:     g.tokens(),
+			ExpectedStoragePledge: g.tokens(),
+			ReplacedSectorAge:     g.epoch(),
+			ReplacedDayReward:     g.tokens(),
+		}))
+	}
+	st.Sectors = tutil.MustRoot(g.t, sectors)
+	st.EarlyTerminations = g.bitfield()
+	st.FeeDebt = g.tokens()
+	st.LockedFunds = g.tokens()
+
+	// Populate some deadlines, leaving others empty.
+	if g.rnd.Intn(2) == 0 {
+		var deadlines miner6.Deadlines
+		require.NoError(g.t, g.store.Get(g.store.Context(), st.Deadlines, &deadlines))
+		for i := 0; i < 1+g.rnd.Intn(5); i++ {
+			dl, err := miner6.ConstructDeadline(g.store)
+			require.NoError(g.t, err)
+			dl.PartitionsPoSted = g.bitfield()
+			dl.EarlyTerminations = g.bitfield()
+			dl.LiveSectors = g.rnd.Uint64()
+			dl.TotalSectors = g.rnd.Uint64()
+			dl.FaultyPower = miner6.NewPowerPair(g.tokens(), g.tokens())
+			deadlines.Due[g.rnd.Intn(len(deadlines.Due))], err = g.store.Put(g.store.Context(), dl)
+			require.NoError(g.t, err)
+		}
+		st.Deadlines, err = g.store.Put(g.store.Context(), &deadlines)
+		require.NoError(g.t, err)
+	}
+	return fuzzActor{code: builtin6.StorageMinerActorCodeID, state: st}
+}
+
+func (g *stateGen) market() fuzzActor {
+	st, err := market6.ConstructState(g.store)
+	require.NoError(g.t, err)
+
+	// Balance tables don't store zero balances.
+	for _, table := range []*cid.Cid{&st.EscrowTable, &st.LockedTable} {
+		balances, err := adt7.MakeEmptyMap(g.store, adt7.BalanceTableBitwidth)
+		require.NoError(g.t, err)
+		for i := 0; i < g.rnd.Intn(100); i++ {
+			amount := big.Add(g.tokens(), big.NewInt(1))
+			require.NoError(g.t, balances.Put(abi.AddrKey(g.idAddr()), &amount))
+		}
+		*table = tutil.MustRoot(g.t, balances)
+	}
+
+	proposals, err := adt7.MakeEmptyArray(g.store, market6.ProposalsAmtBitwidth)
+	require.NoError(g.t, err)
+	for i := 0; i < g.rnd.Intn(20); i++ {
+		labelSize := g.rnd.Intn(market7.DealMaxLabelSize + 1)
+		if g.rnd.Intn(2) == 0 {
+			labelSize = market7.DealMaxLabelSize
+		}
+		require.NoError(g.t, proposals.Set(uint64(st.NextID), &market7.DealProposal{
+			PieceCID:             tutil.MakeCID(fmt.Sprintf("%d", g.rnd.Int63()), &market7.PieceCIDPrefix),
+			PieceSize:            abi.PaddedPieceSize(1 << (7 + g.rnd.Intn(30))),
+			VerifiedDeal:         g.rnd.Intn(2) == 0,
+			Client:               g.idAddr(),
+			Provider:             g.idAddr(),
+			Label:                g.label(labelSize),
+			StartEpoch:           g.epoch(),
+			EndEpoch:             g.epoch(),
+			StoragePricePerEpoch: g.tokens(),
+			ProviderCollateral:   g.tokens(),
+			ClientCollateral:     g.tokens(),
+		}))
+		st.NextID++
+	}
+	st.Proposals = tutil.MustRoot(g.t, proposals)
+	st.TotalClientLockedCollateral = g.tokens()
+	st.TotalProviderLockedCollateral = g.tokens()
+	st.TotalClientStorageFee = g.tokens()
+	return fuzzActor{code: builtin6.StorageMarketActorCodeID, state: st}
+}
+
+func (g *stateGen) cron() fuzzActor {
+	entries := make([]cron6.Entry, g.rnd.Intn(20))
+	for i := range entries {
+		entries[i] = cron6.Entry{Receiver: g.idAddr(), MethodNum: abi.MethodNum(g.rnd.Uint64())}
+	}
+	return fuzzActor{code: builtin6.CronActorCodeID, state: &cron6.State{Entries: entries}}
+}
+
+func checkMultisig(t *testing.T, store adt7.Store, in *multisig6.State, actor *states7.Actor) {
+	require.Equal(t, builtin7.MultisigActorCodeID, actor.Code)
+	var out multisig7.State
+	require.NoError(t, store.Get(store.Context(), actor.Head, &out))
+	if len(in.Signers) == 0 {
+		assert.Empty(t, out.Signers) // an empty list decodes as nil
+	} else {
+		assert.Equal(t, in.Signers, out.Signers)
+	}
+	assert.Equal(t, in.NumApprovalsThreshold, out.NumApprovalsThreshold)
+	assert.Equal(t, int64(in.NextTxnID), int64(out.NextTxnID))
+	assert.Equal(t, in.InitialBalance, out.InitialBalance)
+	assert.Equal(t, in.StartEpoch, out.StartEpoch)
+	assert.Equal(t, in.UnlockDuration, out.UnlockDuration)
+	assert.Equal(t, in.PendingTxns, out.PendingTxns)
+	assert.Nil(t, out.SpendingLimit)
+	emptyMap, err := adt7.StoreEmptyMap(store, builtin7.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	assert.Equal(t, emptyMap, out.PendingTxnMetadata)
+
+	// Pending transactions decode as v7.
+	txns, err := adt7.AsMap(store, out.PendingTxns, builtin7.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	var txn multisig7.Transaction
+	require.NoError(t, txns.ForEach(&txn, func(string) error { return nil }))
+}
+
+func checkPaych(t *testing.T, store adt7.Store, in *paych6.State, actor *states7.Actor) {
+	require.Equal(t, builtin7.PaymentChannelActorCodeID, actor.Code)
+	var out paych7.State
+	require.NoError(t, store.Get(store.Context(), actor.Head, &out))
+	assert.Equal(t, in.From, out.From)
+	assert.Equal(t, in.To, out.To)
+	assert.Equal(t, in.ToSend, out.ToSend)
+	assert.Equal(t, in.SettlingAt, out.SettlingAt)
+	assert.Equal(t, in.MinSettleHeight, out.MinSettleHeight)
+	assert.Equal(t, in.LaneStates, out.LaneStates)
+	assert.EqualValues(t, paych7.SettleDelay, out.SettleDelay)
+	assert.Empty(t, out.AdditionalPayees)
+	retired, err := out.RetiredLanes.Count()
+	require.NoError(t, err)
+	assert.Zero(t, retired)
+
+	lanes, err := adt7.AsArray(store, out.LaneStates, paych7.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	var lane paych7.LaneState
+	require.NoError(t, lanes.ForEach(&lane, func(int64) error { return nil }))
+}
+
+func checkMiner(t *testing.T, store adt7.Store, in *miner6.State, actor *states7.Actor) {
+	require.Equal(t, builtin7.StorageMinerActorCodeID, actor.Code)
+	var out miner7.State
+	require.NoError(t, store.Get(store.Context(), actor.Head, &out))
+	var minerInfo miner6.MinerInfo
+	require.NoError(t, store.Get(store.Context(), in.Info, &minerInfo))
+	outInfo, err := out.GetInfo(store)
+	require.NoError(t, err)
+	assert.Equal(t, minerInfo.Owner, outInfo.Owner)
+	assert.Equal(t, minerInfo.Worker, outInfo.Worker)
+	assert.Equal(t, minerInfo.ControlAddresses, outInfo.ControlAddresses)
+	assert.Equal(t, minerInfo.PeerId, outInfo.PeerId)
+	assert.Equal(t, minerInfo.WindowPoStProofType, outInfo.WindowPoStProofType)
+	assert.Equal(t, minerInfo.Owner, outInfo.Beneficiary)
+	assert.True(t, outInfo.BeneficiaryTerm.Quota.IsZero())
+	assert.Nil(t, outInfo.PendingBeneficiaryTerm)
+	assert.Equal(t, in.PreCommitDeposits, out.PreCommitDeposits)
+	assert.Equal(t, in.LockedFunds, out.LockedFunds)
+	assert.Equal(t, in.VestingFunds, out.VestingFunds)
+	assert.Equal(t, in.FeeDebt, out.FeeDebt)
+	assert.Equal(t, in.InitialPledge, out.InitialPledge)
+	assert.Equal(t, in.PreCommittedSectors, out.PreCommittedSectors)
+	assert.Equal(t, in.PreCommittedSectorsCleanUp, out.PreCommittedSectorsCleanUp)
+	assert.Equal(t, in.AllocatedSectors, out.AllocatedSectors)
+	assert.Equal(t, in.ProvingPeriodStart, out.ProvingPeriodStart)
+	assert.Equal(t, in.CurrentDeadline, out.CurrentDeadline)
+	assert.Equal(t, in.DeadlineCronActive, out.DeadlineCronActive)
+	assertBitfieldsEqual(t, in.EarlyTerminations, out.EarlyTerminations)
+	assert.False(t, out.Paused)
+	assert.Nil(t, out.PendingPauseChange)
+
+	// Every sector is migrated, with no sector key.
+	inSectors, err := miner6.LoadSectors(store, in.Sectors)
+	require.NoError(t, err)
+	outSectors, err := miner7.LoadSectors(store, out.Sectors)
+	require.NoError(t, err)
+	var inInfo miner6.SectorOnChainInfo
+	count := 0
+	require.NoError(t, inSectors.ForEach(&inInfo, func(i int64) error {
+		count++
+		outInfo, found, err := outSectors.Get(abi.SectorNumber(i))
+		require.NoError(t, err)
+		require.True(t, found, "sector %d", i)
+		assert.Equal(t, inInfo.SectorNumber, outInfo.SectorNumber)
+		assert.Equal(t, inInfo.SealProof, outInfo.SealProof)
+		assert.Equal(t, inInfo.SealedCID, outInfo.SealedCID)
+		assert.Equal(t, inInfo.DealIDs, outInfo.DealIDs)
+		assert.Equal(t, inInfo.Activation, outInfo.Activation)
+		assert.Equal(t, inInfo.Expiration, outInfo.Expiration)
+		assert.Equal(t, inInfo.DealWeight, outInfo.DealWeight)
+		assert.Equal(t, inInfo.VerifiedDealWeight, outInfo.VerifiedDealWeight)
+		assert.Equal(t, inInfo.InitialPledge, outInfo.InitialPledge)
+		assert.Equal(t, inInfo.ExpectedDayReward, outInfo.ExpectedDayReward)
+		assert.Equal(t, inInfo.ExpectedStoragePledge, outInfo.ExpectedStoragePledge)
+		assert.Equal(t, inInfo.ReplacedSectorAge, outInfo.ReplacedSectorAge)
+		assert.Equal(t, inInfo.ReplacedDayReward, outInfo.ReplacedDayReward)
+		assert.Nil(t, outInfo.SectorKeyCID)
+		return nil
+	}))
+	assert.Equal(t, uint64(count), outSectors.Length())
+
+	// Deadlines are migrated in place, each with an empty sectors snapshot as no PoSts can be disputed.
+	var inDeadlines miner6.Deadlines
+	require.NoError(t, store.Get(store.Context(), in.Deadlines, &inDeadlines))
+	outDeadlines, err := out.LoadDeadlines(store)
+	require.NoError(t, err)
+	emptySectors, err := adt7.StoreEmptyArray(store, miner7.SectorsAmtBitwidth)
+	require.NoError(t, err)
+	for i, c := range inDeadlines.Due {
+		var inDl miner6.Deadline
+		require.NoError(t, store.Get(store.Context(), c, &inDl))
+		outDl, err := outDeadlines.LoadDeadline(store, uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, inDl.Partitions, outDl.Partitions)
+		assert.Equal(t, inDl.ExpirationsEpochs, outDl.ExpirationsEpochs)
+		assertBitfieldsEqual(t, inDl.PartitionsPoSted, outDl.PartitionsPoSted)
+		assertBitfieldsEqual(t, inDl.EarlyTerminations, outDl.EarlyTerminations)
+		assert.Equal(t, inDl.LiveSectors, outDl.LiveSectors)
+		assert.Equal(t, inDl.TotalSectors, outDl.TotalSectors)
+		assert.Equal(t, inDl.FaultyPower.Raw, outDl.FaultyPower.Raw)
+		assert.Equal(t, inDl.FaultyPower.QA, outDl.FaultyPower.QA)
+		assert.Equal(t, inDl.OptimisticPoStSubmissions, outDl.OptimisticPoStSubmissions)
+		assert.Equal(t, emptySectors, outDl.SectorsSnapshot)
+	}
+}
+
+func checkMarket(t *testing.T, store adt7.Store, in *market6.State, actor *states7.Actor) {
+	require.Equal(t, builtin7.StorageMarketActorCodeID, actor.Code)
+	var out market7.State
+	require.NoError(t, store.Get(store.Context(), actor.Head, &out))
+	assert.Equal(t, in.Proposals, out.Proposals)
+	assert.Equal(t, in.States, out.States)
+	assert.Equal(t, in.PendingProposals, out.PendingProposals)
+	assert.Equal(t, in.NextID, out.NextID)
+	assert.Equal(t, in.DealOpsByEpoch, out.DealOpsByEpoch)
+	assert.Equal(t, in.LastCron, out.LastCron)
+	assert.Equal(t, in.TotalClientLockedCollateral, out.TotalClientLockedCollateral)
+	assert.Equal(t, in.TotalProviderLockedCollateral, out.TotalProviderLockedCollateral)
+	assert.Equal(t, in.TotalClientStorageFee, out.TotalClientStorageFee)
+
+	// Every balance is present in the sharded table, and no others.
+	for _, tables := range [][2]cid.Cid{{in.EscrowTable, out.EscrowTable}, {in.LockedTable, out.LockedTable}} {
+		inTable, err := adt7.AsMap(store, tables[0], adt7.BalanceTableBitwidth)
+		require.NoError(t, err)
+		outTable, err := adt7.AsShardedBalanceTable(store, tables[1])
+		require.NoError(t, err)
+		var balance abi.TokenAmount
+		inTotal := big.Zero()
+		require.NoError(t, inTable.ForEach(&balance, func(key string) error {
+			a, err := addr.NewFromBytes([]byte(key))
+			require.NoError(t, err)
+			got, err := outTable.Get(a)
+			require.NoError(t, err)
+			assert.True(t, balance.Equals(got), a)
+			inTotal = big.Add(inTotal, balance)
+			return nil
+		}))
+		outTotal, err := outTable.Total()
+		require.NoError(t, err)
+		assert.True(t, inTotal.Equals(outTotal))
+	}
+
+	// Proposals, including those with maximum-size labels, decode as v7.
+	proposals, err := market7.AsDealProposalArray(store, out.Proposals)
+	require.NoError(t, err)
+	var proposal market7.DealProposal
+	require.NoError(t, proposals.ForEach(&proposal, func(int64) error {
+		assert.True(t, len(proposal.Label) <= market7.DealMaxLabelSize)
+		return nil
+	}))
+}
+
+func checkCron(t *testing.T, store adt7.Store, in *cron6.State, actor *states7.Actor) {
+	require.Equal(t, builtin7.CronActorCodeID, actor.Code)
+	var out cron7.State
+	require.NoError(t, store.Get(store.Context(), actor.Head, &out))
+	require.Len(t, out.Entries, len(in.Entries))
+	for i, e := range in.Entries {
+		assert.Equal(t, e.Receiver, out.Entries[i].Receiver)
+		assert.Equal(t, e.MethodNum, out.Entries[i].MethodNum)
+		assert.Zero(t, out.Entries[i].GasBudget)
+		assert.False(t, out.Entries[i].Flagged)
+	}
+}
+
+func assertBitfieldsEqual(t *testing.T, expected, actual bitfield.BitField) {
+	x, err := bitfield.SubtractBitField(expected, actual)
+	require.NoError(t, err)
+	y, err := bitfield.SubtractBitField(actual, expected)
+	require.NoError(t, err)
+	xEmpty, err := x.IsEmpty()
+	require.NoError(t, err)
+	yEmpty, err := y.IsEmpty()
+	require.NoError(t, err)
+	assert.True(t, xEmpty && yEmpty, "bitfields differ")
+}
@@ -0,0 +1,61 @@
+package test_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	account7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestGovernanceMigration(t *testing.T) {
+	signers := []addr.Address{tutil.NewIDAddr(t, 101), tutil.NewIDAddr(t, 102), tutil.NewIDAddr(t, 103)}
+
+	t.Run("an existing governance actor is kept", func(t *testing.T) {
+		// The genesis of the prior tree created an account at the governance address.
+		prior := newPriorTree(t)
+		migrated, err := prior.migrateWithConfig(nv15.Config{MaxWorkers: 2, GovernanceSigners: signers, GovernanceThreshold: 2})
+		require.NoError(t, err)
+		var st account7.State
+		migrated.getState(builtin.GovernanceActorAddr, builtin.AccountActorCodeID, &st)
+		assert.Equal(t, builtin.GovernanceActorAddr, st.Address)
+	})
+
+	t.Run("a missing governance actor is created as a multisig of the configured signers", func(t *testing.T) {
+		prior := newPriorTree(t)
+		prior.deleteActor(builtin.GovernanceActorAddr)
+		migrated, err := prior.migrateWithConfig(nv15.Config{MaxWorkers: 2, GovernanceSigners: signers, GovernanceThreshold: 2})
+		require.NoError(t, err)
+		var st multisig7.State
+		migrated.getState(builtin.GovernanceActorAddr, builtin.MultisigActorCodeID, &st)
+		assert.Equal(t, signers, st.Signers)
+		assert.Equal(t, uint64(2), st.NumApprovalsThreshold)
+		assert.True(t, st.InitialBalance.IsZero())
+		assert.Nil(t, st.SpendingLimit)
+		emptyMap, err := adt7.StoreEmptyMap(migrated.store, builtin.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		assert.Equal(t, emptyMap, st.PendingTxns)
+		assert.Equal(t, emptyMap, st.PendingTxnMetadata)
+	})
+
+	t.Run("a missing governance actor must be configured", func(t *testing.T) {
+		for _, cfg := range []nv15.Config{
+			{MaxWorkers: 2},
+			{MaxWorkers: 2, GovernanceSigners: signers, GovernanceThreshold: 0},
+			{MaxWorkers: 2, GovernanceSigners: signers, GovernanceThreshold: 4},
+			{MaxWorkers: 2, GovernanceSigners: []addr.Address{tutil.NewBLSAddr(t, 1)}, GovernanceThreshold: 1},
+		} {
+			prior := newPriorTree(t)
+			prior.deleteActor(builtin.GovernanceActorAddr)
+			_, err := prior.migrateWithConfig(cfg)
+			assert.Error(t, err)
+		}
+	})
+}
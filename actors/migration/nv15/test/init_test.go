@@ -0,0 +1,86 @@
+package test_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	init6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/init"
+	adt6 "github.com/filecoin-project/specs-actors/v6/actors/util/adt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	init7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestInitMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	var inState init6.State
+	prior.getState(builtin6.InitActorAddr, &inState)
+
+	for i := 0; i < 20; i++ {
+		_, err := inState.MapAddressToNewID(prior.store, tutil.NewBLSAddr(t, int64(i)))
+		require.NoError(t, err)
+		_, err = inState.MapAddressToNewID(prior.store, tutil.NewActorAddr(t, string(rune('a'+i))))
+		require.NoError(t, err)
+	}
+	// A second address mapped to an existing actor.
+	addressMap, err := adt6.AsMap(prior.store, inState.AddressMap, builtin6.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	shared := cbg.CborInt(inState.NextID - 1)
+	require.NoError(t, addressMap.Put(abi.AddrKey(tutil.NewSECP256K1Addr(t, "shared")), &shared))
+	inState.AddressMap, err = addressMap.Root()
+	require.NoError(t, err)
+	prior.setState(builtin6.InitActorAddr, builtin6.InitActorCodeID, &inState)
+
+	// Every address in the map, grouped by the actor to which it is mapped.
+	expected := map[abi.ActorID][]addr.Address{}
+	var id cbg.CborInt
+	require.NoError(t, addressMap.ForEach(&id, func(key string) error {
+		a, err := addr.NewFromBytes([]byte(key))
+		require.NoError(t, err)
+		expected[abi.ActorID(id)] = append(expected[abi.ActorID(id)], a)
+		return nil
+	}))
+	require.Len(t, expected[abi.ActorID(shared)], 2)
+
+	migrated := prior.migrate()
+	var outState init7.State
+	migrated.getState(builtin7.InitActorAddr, builtin7.InitActorCodeID, &outState)
+
+	t.Run("fields are kept", func(t *testing.T) {
+		assert.Equal(t, inState.AddressMap, outState.AddressMap)
+		assert.Equal(t, inState.NextID, outState.NextID)
+		assert.Equal(t, inState.NetworkName, outState.NetworkName)
+	})
+
+	t.Run("every address is in the reverse index", func(t *testing.T) {
+		for id, addrs := range expected {
+			found, err := outState.LookupAddresses(migrated.store, id)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, addrs, found, "addresses of actor %d", id)
+		}
+	})
+
+	t.Run("reverse index has no other entries", func(t *testing.T) {
+		reverse, err := adt7.AsMap(migrated.store, outState.ActorAddresses, builtin7.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		count := 0
+		var entry init7.ActorAddresses
+		require.NoError(t, reverse.ForEach(&entry, func(key string) error {
+			count++
+			id, err := abi.ParseUIntKey(key)
+			require.NoError(t, err)
+			addrs, ok := expected[abi.ActorID(id)]
+			require.True(t, ok, "unexpected entry for actor %d", id)
+			assert.ElementsMatch(t, addrs, entry.Addresses)
+			return nil
+		}))
+		assert.Equal(t, len(expected), count)
+	})
+}
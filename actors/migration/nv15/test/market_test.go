@@ -0,0 +1,124 @@
+package test_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	adt6 "github.com/filecoin-project/specs-actors/v6/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestMarketMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	var inState market6.State
+	prior.getState(builtin6.StorageMarketActorAddr, &inState)
+
+	// Enough parties to populate every shard of the migrated tables.
+	escrow := map[addr.Address]abi.TokenAmount{}
+	locked := map[addr.Address]abi.TokenAmount{}
+	escrowTable, err := adt6.AsBalanceTable(prior.store, inState.EscrowTable)
+	require.NoError(t, err)
+	lockedTable, err := adt6.AsBalanceTable(prior.store, inState.LockedTable)
+	require.NoError(t, err)
+	for i := 0; i < 4*adt7.BalanceTableShardCount; i++ {
+		party := tutil.NewIDAddr(t, uint64(1000+i))
+		escrow[party] = abi.NewTokenAmount(int64(1_000_000 + i))
+		require.NoError(t, escrowTable.Add(party, escrow[party]))
+		if i%3 == 0 {
+			locked[party] = abi.NewTokenAmount(int64(1_000 + i))
+			require.NoError(t, lockedTable.Add(party, locked[party]))
+		}
+	}
+	// A key address, as may be recorded for a party added before its ID address was resolved.
+	keyParty := tutil.NewBLSAddr(t, 1)
+	escrow[keyParty] = abi.NewTokenAmount(42)
+	require.NoError(t, escrowTable.Add(keyParty, escrow[keyParty]))
+	inState.EscrowTable, err = escrowTable.Root()
+	require.NoError(t, err)
+	inState.LockedTable, err = lockedTable.Root()
+	require.NoError(t, err)
+	inState.NextID = 17
+	inState.LastCron = 1234
+	inState.TotalClientLockedCollateral = abi.NewTokenAmount(11)
+	inState.TotalProviderLockedCollateral = abi.NewTokenAmount(22)
+	inState.TotalClientStorageFee = abi.NewTokenAmount(33)
+	prior.setState(builtin6.StorageMarketActorAddr, builtin6.StorageMarketActorCodeID, &inState)
+
+	migrated := prior.migrate()
+	var outState market7.State
+	migrated.getState(builtin7.StorageMarketActorAddr, builtin7.StorageMarketActorCodeID, &outState)
+
+	t.Run("balances are kept after sharding", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			root     cid.Cid
+			balances map[addr.Address]abi.TokenAmount
+		}{
+			{"escrow", outState.EscrowTable, escrow},
+			{"locked", outState.LockedTable, locked},
+		} {
+			table, err := adt7.AsShardedBalanceTable(migrated.store, tc.root)
+			require.NoError(t, err)
+			expectedTotal := big.Zero()
+			for party, balance := range tc.balances {
+				got, err := table.Get(party)
+				require.NoError(t, err)
+				assert.Equal(t, balance, got, "%s balance of %v", tc.name, party)
+				expectedTotal = big.Add(expectedTotal, balance)
+			}
+			count := 0
+			require.NoError(t, table.ForEach(func(party addr.Address, balance abi.TokenAmount) error {
+				count++
+				expected, ok := tc.balances[party]
+				assert.True(t, ok, "unexpected %s balance for %v", tc.name, party)
+				assert.Equal(t, expected, balance)
+				return nil
+			}))
+			assert.Equal(t, len(tc.balances), count, tc.name)
+			total, err := table.Total()
+			require.NoError(t, err)
+			assert.Equal(t, expectedTotal, total, tc.name)
+		}
+	})
+
+	t.Run("every shard is populated", func(t *testing.T) {
+		table, err := adt7.AsShardedBalanceTable(migrated.store, outState.EscrowTable)
+		require.NoError(t, err)
+		for i := uint64(0); i < adt7.BalanceTableShardCount; i++ {
+			shard, err := table.Shard(i)
+			require.NoError(t, err)
+			total, err := shard.Total()
+			require.NoError(t, err)
+			assert.True(t, total.GreaterThan(big.Zero()), "shard %d", i)
+		}
+	})
+
+	t.Run("other fields are kept", func(t *testing.T) {
+		assert.Equal(t, inState.Proposals, outState.Proposals)
+		assert.Equal(t, inState.States, outState.States)
+		assert.Equal(t, inState.PendingProposals, outState.PendingProposals)
+		assert.Equal(t, inState.NextID, outState.NextID)
+		assert.Equal(t, inState.DealOpsByEpoch, outState.DealOpsByEpoch)
+		assert.Equal(t, inState.LastCron, outState.LastCron)
+		assert.Equal(t, inState.TotalClientLockedCollateral, outState.TotalClientLockedCollateral)
+		assert.Equal(t, inState.TotalProviderLockedCollateral, outState.TotalProviderLockedCollateral)
+		assert.Equal(t, inState.TotalClientStorageFee, outState.TotalClientStorageFee)
+	})
+
+	t.Run("deal price buckets start empty", func(t *testing.T) {
+		buckets, err := adt7.AsArray(migrated.store, outState.DealPriceBuckets, market7.DealPriceBucketsAmtBitwidth)
+		require.NoError(t, err)
+		assert.Zero(t, buckets.Length())
+	})
+}
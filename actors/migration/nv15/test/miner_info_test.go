@@ -0,0 +1,114 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestMinerInfoMigration(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	store := cbor.NewCborStore(bs)
+	adtStore := adt7.WrapStore(ctx, store)
+
+	owner, worker := tutil.NewIDAddr(t, 1001), tutil.NewIDAddr(t, 1002)
+	control, newWorker, pendingOwner := tutil.NewIDAddr(t, 1003), tutil.NewIDAddr(t, 1004), tutil.NewIDAddr(t, 1005)
+	minerAddr := tutil.NewIDAddr(t, 1010)
+
+	inInfo, err := miner6.ConstructMinerInfo(owner, worker, []addr.Address{control}, []byte("peer"), [][]byte{[]byte("addr")},
+		abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	inInfo.PendingWorkerKey = &miner6.WorkerKeyChange{NewWorker: newWorker, EffectiveAt: 1234}
+	inInfo.PendingOwnerAddress = &pendingOwner
+	inInfo.ConsensusFaultElapsed = 5678
+	infoCid, err := store.Put(ctx, inInfo)
+	require.NoError(t, err)
+	inState, err := miner6.ConstructState(adtStore, infoCid, 0, 0)
+	require.NoError(t, err)
+	head, err := store.Put(ctx, inState)
+	require.NoError(t, err)
+
+	tree, err := states6.LoadTree(adtStore, vm.StateRoot())
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActor(minerAddr, &states6.Actor{Code: builtin6.StorageMinerActorCodeID, Head: head, Balance: big.Zero()}))
+	startRoot, err := tree.Flush()
+	require.NoError(t, err)
+
+	migratedRoot, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+	outTree, err := states7.LoadTree(adtStore, migratedRoot)
+	require.NoError(t, err)
+	minerActor, found, err := outTree.GetActor(minerAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var outState miner7.State
+	require.NoError(t, store.Get(ctx, minerActor.Head, &outState))
+	outInfo, err := outState.GetInfo(adtStore)
+	require.NoError(t, err)
+
+	t.Run("owner becomes beneficiary without a term", func(t *testing.T) {
+		assert.Equal(t, owner, outInfo.Beneficiary)
+		assert.Equal(t, big.Zero(), outInfo.BeneficiaryTerm.Quota)
+		assert.Equal(t, big.Zero(), outInfo.BeneficiaryTerm.UsedQuota)
+		assert.Equal(t, abi.ChainEpoch(0), outInfo.BeneficiaryTerm.Expiration)
+		assert.Nil(t, outInfo.PendingBeneficiaryTerm)
+	})
+
+	t.Run("other fields are kept", func(t *testing.T) {
+		assert.Equal(t, owner, outInfo.Owner)
+		assert.Equal(t, worker, outInfo.Worker)
+		assert.Equal(t, inInfo.ControlAddresses, outInfo.ControlAddresses)
+		require.NotNil(t, outInfo.PendingWorkerKey)
+		assert.Equal(t, newWorker, outInfo.PendingWorkerKey.NewWorker)
+		assert.Equal(t, abi.ChainEpoch(1234), outInfo.PendingWorkerKey.EffectiveAt)
+		assert.Equal(t, inInfo.PeerId, outInfo.PeerId)
+		assert.Equal(t, inInfo.Multiaddrs, outInfo.Multiaddrs)
+		assert.Equal(t, inInfo.WindowPoStProofType, outInfo.WindowPoStProofType)
+		assert.Equal(t, inInfo.SectorSize, outInfo.SectorSize)
+		assert.Equal(t, inInfo.WindowPoStPartitionSectors, outInfo.WindowPoStPartitionSectors)
+		assert.Equal(t, abi.ChainEpoch(5678), outInfo.ConsensusFaultElapsed)
+		assert.Equal(t, &pendingOwner, outInfo.PendingOwnerAddress)
+	})
+
+	t.Run("downgrade restores the v6 info", func(t *testing.T) {
+		root, err := nv15.DowngradeStateTree(ctx, store, migratedRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+		require.NoError(t, err)
+		assert.Equal(t, startRoot, root)
+	})
+
+	t.Run("downgrade refuses a beneficiary other than the owner", func(t *testing.T) {
+		changed := *outInfo
+		changed.Beneficiary = control
+		require.NoError(t, outState.SaveInfo(adtStore, &changed))
+		minerActor.Head, err = store.Put(ctx, &outState)
+		require.NoError(t, err)
+		require.NoError(t, outTree.SetActor(minerAddr, minerActor))
+		root, err := outTree.Flush()
+		require.NoError(t, err)
+
+		_, err = nv15.DowngradeStateTree(ctx, store, root, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log)
+		require.Error(t, err)
+		assert.True(t, xerrors.Is(err, nv15.ErrIrreversible), err.Error())
+	})
+}
@@ -0,0 +1,101 @@
+package test_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	multisig6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/multisig"
+	adt6 "github.com/filecoin-project/specs-actors/v6/actors/util/adt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestMultisigMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	msigAddr := tutil.NewIDAddr(t, 2000)
+
+	pending, err := adt6.MakeEmptyMap(prior.store, builtin6.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	txns := map[multisig6.TxnID]multisig6.Transaction{
+		3: {
+			To:       tutil.NewIDAddr(t, 2001),
+			Value:    abi.NewTokenAmount(100),
+			Method:   builtin6.MethodSend,
+			Params:   nil,
+			Approved: []addr.Address{tutil.NewIDAddr(t, 101)},
+		},
+		4: {
+			To:       tutil.NewIDAddr(t, 2002),
+			Value:    abi.NewTokenAmount(0),
+			Method:   abi.MethodNum(7),
+			Params:   []byte{1, 2, 3},
+			Approved: []addr.Address{tutil.NewIDAddr(t, 101), tutil.NewIDAddr(t, 102)},
+		},
+	}
+	for id, txn := range txns {
+		txn := txn
+		require.NoError(t, pending.Put(abi.IntKey(int64(id)), &txn))
+	}
+	pendingRoot, err := pending.Root()
+	require.NoError(t, err)
+
+	inState := multisig6.State{
+		Signers:               []addr.Address{tutil.NewIDAddr(t, 101), tutil.NewIDAddr(t, 102), tutil.NewIDAddr(t, 103)},
+		NumApprovalsThreshold: 3,
+		NextTxnID:             5,
+		InitialBalance:        abi.NewTokenAmount(1_000_000),
+		StartEpoch:            100,
+		UnlockDuration:        200,
+		PendingTxns:           pendingRoot,
+	}
+	prior.setState(msigAddr, builtin6.MultisigActorCodeID, &inState)
+
+	migrated := prior.migrate()
+	var outState multisig7.State
+	migrated.getState(msigAddr, builtin7.MultisigActorCodeID, &outState)
+
+	t.Run("fields are kept", func(t *testing.T) {
+		assert.Equal(t, inState.Signers, outState.Signers)
+		assert.Equal(t, inState.NumApprovalsThreshold, outState.NumApprovalsThreshold)
+		assert.Equal(t, int64(inState.NextTxnID), int64(outState.NextTxnID))
+		assert.Equal(t, inState.InitialBalance, outState.InitialBalance)
+		assert.Equal(t, inState.StartEpoch, outState.StartEpoch)
+		assert.Equal(t, inState.UnlockDuration, outState.UnlockDuration)
+		assert.Equal(t, inState.PendingTxns, outState.PendingTxns)
+	})
+
+	t.Run("pending transactions decode as v7", func(t *testing.T) {
+		outTxns, err := adt7.AsMap(migrated.store, outState.PendingTxns, builtin7.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		count := 0
+		var txn multisig7.Transaction
+		require.NoError(t, outTxns.ForEach(&txn, func(k string) error {
+			count++
+			id, err := abi.ParseIntKey(k)
+			require.NoError(t, err)
+			expected, ok := txns[multisig6.TxnID(id)]
+			require.True(t, ok, "unexpected transaction %d", id)
+			assert.Equal(t, expected.To, txn.To)
+			assert.Equal(t, expected.Value, txn.Value)
+			assert.Equal(t, expected.Method, txn.Method)
+			assert.Equal(t, expected.Params, txn.Params)
+			assert.Equal(t, expected.Approved, txn.Approved)
+			return nil
+		}))
+		assert.Equal(t, len(txns), count)
+	})
+
+	t.Run("spending limit and metadata start empty", func(t *testing.T) {
+		assert.Nil(t, outState.SpendingLimit)
+		emptyMap, err := adt7.StoreEmptyMap(migrated.store, builtin7.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		assert.Equal(t, emptyMap, outState.PendingTxnMetadata)
+	})
+}
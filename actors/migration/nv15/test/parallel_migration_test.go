@@ -41,7 +41,9 @@ func TestParallelMigrationCalls(t *testing.T) {
 	})
 	grp.Go(func() error {
 		var err2 error
-		endRootParallel2, err2 = nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+		// Caching store objects must not change the result.
+		cfg := nv15.Config{MaxWorkers: 2, StoreCacheBytes: 1 << 20}
+		endRootParallel2, err2 = nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), cfg, log, nv15.NewMemMigrationCache())
 		return err2
 	})
 	require.NoError(t, grp.Wait())
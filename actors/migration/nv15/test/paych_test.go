@@ -0,0 +1,89 @@
+package test_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
+	adt6 "github.com/filecoin-project/specs-actors/v6/actors/util/adt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	paych7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestPaychMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	paychAddr := tutil.NewIDAddr(t, 3000)
+
+	lanes, err := adt6.MakeEmptyArray(prior.store, paych6.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	laneStates := map[uint64]paych6.LaneState{
+		0: {Redeemed: big.NewInt(10), Nonce: 1},
+		5: {Redeemed: big.NewInt(250), Nonce: 9},
+	}
+	for id, ls := range laneStates {
+		ls := ls
+		require.NoError(t, lanes.Set(id, &ls))
+	}
+	lanesRoot, err := lanes.Root()
+	require.NoError(t, err)
+
+	inState := paych6.State{
+		From:            tutil.NewIDAddr(t, 3001),
+		To:              tutil.NewIDAddr(t, 3002),
+		ToSend:          abi.NewTokenAmount(260),
+		SettlingAt:      500,
+		MinSettleHeight: 400,
+		LaneStates:      lanesRoot,
+	}
+	prior.setState(paychAddr, builtin6.PaymentChannelActorCodeID, &inState)
+
+	migrated := prior.migrate()
+	var outState paych7.State
+	migrated.getState(paychAddr, builtin7.PaymentChannelActorCodeID, &outState)
+
+	t.Run("fields are kept", func(t *testing.T) {
+		assert.Equal(t, inState.From, outState.From)
+		assert.Equal(t, inState.To, outState.To)
+		assert.Equal(t, inState.ToSend, outState.ToSend)
+		assert.Equal(t, inState.SettlingAt, outState.SettlingAt)
+		assert.Equal(t, inState.MinSettleHeight, outState.MinSettleHeight)
+		assert.Equal(t, inState.LaneStates, outState.LaneStates)
+	})
+
+	t.Run("lane states decode as v7", func(t *testing.T) {
+		outLanes, err := adt7.AsArray(migrated.store, outState.LaneStates, paych7.LaneStatesAmtBitwidth)
+		require.NoError(t, err)
+		count := 0
+		var ls paych7.LaneState
+		require.NoError(t, outLanes.ForEach(&ls, func(id int64) error {
+			count++
+			expected, ok := laneStates[uint64(id)]
+			require.True(t, ok, "unexpected lane %d", id)
+			assert.Equal(t, expected.Redeemed, ls.Redeemed)
+			assert.Equal(t, expected.Nonce, ls.Nonce)
+			return nil
+		}))
+		assert.Equal(t, len(laneStates), count)
+	})
+
+	t.Run("no lanes are retired", func(t *testing.T) {
+		empty, err := outState.RetiredLanes.IsEmpty()
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+
+	t.Run("settle delay is the default", func(t *testing.T) {
+		assert.Equal(t, abi.ChainEpoch(paych7.SettleDelay), outState.SettleDelay)
+	})
+
+	t.Run("channel has no additional payees", func(t *testing.T) {
+		assert.Empty(t, outState.AdditionalPayees)
+	})
+}
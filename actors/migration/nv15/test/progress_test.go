@@ -0,0 +1,93 @@
+package test_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+type recordingReporter struct {
+	lk      sync.Mutex
+	reports []nv15.Progress
+}
+
+func (r *recordingReporter) ReportProgress(p nv15.Progress) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.reports = append(r.reports, p)
+}
+
+func TestMigrationProgressReporting(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	adtStore := adt7.WrapStore(ctx, cbor.NewCborStore(bs))
+	startRoot := vm.StateRoot()
+
+	expectedRoot, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+
+	reporter := &recordingReporter{}
+	cfg := nv15.Config{MaxWorkers: 2, ProgressReporter: reporter}
+	root, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), cfg, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+	// Metering writes must not change the result.
+	assert.Equal(t, expectedRoot, root)
+
+	// Without a progress period, only the final report is made.
+	require.Len(t, reporter.reports, 1)
+	final := reporter.reports[0]
+	assert.True(t, final.AllJobsCreated)
+	assert.True(t, final.JobsDone > 0)
+	assert.Equal(t, final.JobsCreated, final.JobsDone)
+	assert.True(t, final.ObjectsWritten > 0)
+	assert.True(t, final.BytesWritten > 0)
+	assert.Equal(t, uint64(1), final.Kinds[builtin6.ActorNameByCode(builtin6.StoragePowerActorCodeID)].JobsDone)
+
+	var kindJobs uint64
+	for _, kind := range final.Kinds {
+		kindJobs += kind.JobsDone
+	}
+	assert.Equal(t, uint64(final.JobsDone), kindJobs)
+}
+
+func TestMigrationMetrics(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	adtStore := adt7.WrapStore(ctx, cbor.NewCborStore(bs))
+	startRoot := vm.StateRoot()
+
+	expectedRoot, err := nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+	root, metrics, err := nv15.MigrateStateTreeWithMetrics(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+
+	assert.True(t, metrics.Jobs > 0)
+	assert.True(t, metrics.AllocBytes > 0)
+	var jobs, read uint64
+	for _, kind := range metrics.Kinds {
+		jobs += kind.JobsDone
+		read += kind.NodesRead
+	}
+	assert.Equal(t, uint64(metrics.Jobs), jobs)
+	assert.True(t, read > 0)
+	// Migrating the market actor reads and rewrites its state.
+	market := metrics.Kinds[builtin6.ActorNameByCode(builtin6.StorageMarketActorCodeID)]
+	assert.True(t, market.NodesRead > 0)
+	assert.True(t, market.NodesWritten > 0)
+}
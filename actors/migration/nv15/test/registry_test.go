@@ -0,0 +1,86 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Migrates a custom actor by changing its code and preserving its state.
+type customMigrator struct {
+	code cid.Cid
+}
+
+func (m customMigrator) MigrateState(_ context.Context, _ cbor.IpldStore, in nv15.ActorMigrationInput) (*nv15.ActorMigrationResult, error) {
+	return &nv15.ActorMigrationResult{NewCodeCID: m.code, NewHead: in.Head}, nil
+}
+
+func (m customMigrator) MigratedCodeCID() cid.Cid {
+	return m.code
+}
+
+func TestCustomMigrationRegistry(t *testing.T) {
+	ctx := context.Background()
+	log := nv15.TestLogger{TB: t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	store := cbor.NewCborStore(bs)
+	adtStore := adt7.WrapStore(ctx, store)
+
+	builder := cid.V1Builder{Codec: cid.Raw, MhType: mh.IDENTITY}
+	customCode6, err := builder.Sum([]byte("fil/6/custom"))
+	require.NoError(t, err)
+	customCode7, err := builder.Sum([]byte("fil/7/custom"))
+	require.NoError(t, err)
+
+	// Add a custom actor to the prior state tree.
+	tree, err := states6.LoadTree(adtStore, vm.StateRoot())
+	require.NoError(t, err)
+	customAddr := tutil.NewIDAddr(t, 1000)
+	customState := cbg.CborInt(7)
+	customHead, err := store.Put(ctx, &customState)
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActor(customAddr, &states6.Actor{Code: customCode6, Head: customHead, Balance: big.Zero()}))
+	startRoot, err := tree.Flush()
+	require.NoError(t, err)
+
+	// The builtin migrations alone can't migrate the custom actor.
+	_, err = nv15.MigrateStateTree(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, nv15.NewMemMigrationCache())
+	require.Error(t, err)
+
+	cache := nv15.NewMemMigrationCache()
+	registry, err := nv15.BuiltinMigrations(ctx, store, cache)
+	require.NoError(t, err)
+	registry.RegisterCached(customCode6, customMigrator{customCode7}, cache)
+	root, _, err := nv15.MigrateStateTreeWithRegistry(ctx, adtStore, startRoot, abi.ChainEpoch(0), nv15.Config{MaxWorkers: 2}, log, cache, registry)
+	require.NoError(t, err)
+
+	treeOut, err := states7.LoadTree(adtStore, root)
+	require.NoError(t, err)
+	actor, found, err := treeOut.GetActor(customAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, customCode7, actor.Code)
+	assert.Equal(t, customHead, actor.Head)
+
+	found, cached, err := cache.Read(nv15.ActorCodeHeadKey(customCode6, customHead))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, customHead, cached)
+}
@@ -0,0 +1,51 @@
+package test_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	reward6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/reward"
+	"github.com/stretchr/testify/assert"
+
+	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	reward7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+)
+
+func TestRewardMigration(t *testing.T) {
+	prior := newPriorTree(t)
+	inState := reward6.ConstructState(abi.NewStoragePower(1 << 50))
+	// Distinct values in every field, so a field copied into the wrong place is noticed.
+	inState.CumsumBaseline = big.NewInt(101)
+	inState.CumsumRealized = big.NewInt(102)
+	inState.EffectiveNetworkTime = 103
+	inState.Epoch = 104
+	inState.TotalStoragePowerReward = abi.NewTokenAmount(105)
+	inState.SimpleTotal = abi.NewTokenAmount(106)
+	inState.BaselineTotal = abi.NewTokenAmount(107)
+	prior.setState(builtin6.RewardActorAddr, builtin6.RewardActorCodeID, inState)
+
+	migrated := prior.migrate()
+	var outState reward7.State
+	migrated.getState(builtin7.RewardActorAddr, builtin7.RewardActorCodeID, &outState)
+
+	t.Run("fields are kept", func(t *testing.T) {
+		assert.Equal(t, inState.CumsumBaseline, outState.CumsumBaseline)
+		assert.Equal(t, inState.CumsumRealized, outState.CumsumRealized)
+		assert.Equal(t, inState.EffectiveNetworkTime, outState.EffectiveNetworkTime)
+		assert.Equal(t, inState.EffectiveBaselinePower, outState.EffectiveBaselinePower)
+		assert.Equal(t, inState.ThisEpochReward, outState.ThisEpochReward)
+		assert.Equal(t, inState.ThisEpochRewardSmoothed.PositionEstimate, outState.ThisEpochRewardSmoothed.PositionEstimate)
+		assert.Equal(t, inState.ThisEpochRewardSmoothed.VelocityEstimate, outState.ThisEpochRewardSmoothed.VelocityEstimate)
+		assert.Equal(t, inState.ThisEpochBaselinePower, outState.ThisEpochBaselinePower)
+		assert.Equal(t, inState.Epoch, outState.Epoch)
+		assert.Equal(t, inState.TotalStoragePowerReward, outState.TotalStoragePowerReward)
+		assert.Equal(t, inState.SimpleTotal, outState.SimpleTotal)
+		assert.Equal(t, inState.BaselineTotal, outState.BaselineTotal)
+	})
+
+	t.Run("no penalties have been recycled", func(t *testing.T) {
+		assert.Equal(t, big.Zero(), outState.TotalRecycledPenalties)
+	})
+}
@@ -0,0 +1,100 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	states6 "github.com/filecoin-project/specs-actors/v6/actors/states"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	states7 "github.com/filecoin-project/specs-actors/v7/actors/states"
+	adt7 "github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// A v6 state tree of the singleton actors, to which a test adds or replaces actors before migrating it.
+type priorTree struct {
+	t     *testing.T
+	store adt7.Store
+	tree  *states6.Tree
+}
+
+func newPriorTree(t *testing.T) *priorTree {
+	ctx := context.Background()
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	vm := vm6.NewVMWithSingletons(ctx, t, bs)
+	store := adt7.WrapStore(ctx, ipldcbor.NewCborStore(bs))
+	tree, err := states6.LoadTree(store, vm.StateRoot())
+	require.NoError(t, err)
+	return &priorTree{t: t, store: store, tree: tree}
+}
+
+// Sets the state of an actor, creating it with a zero balance if it doesn't exist.
+func (p *priorTree) setState(a addr.Address, code cid.Cid, state cbor.Marshaler) {
+	head, err := p.store.Put(p.store.Context(), state)
+	require.NoError(p.t, err)
+	actor, found, err := p.tree.GetActor(a)
+	require.NoError(p.t, err)
+	if !found {
+		actor = &states6.Actor{Code: code, Balance: big.Zero()}
+	}
+	actor.Head = head
+	require.NoError(p.t, p.tree.SetActor(a, actor))
+}
+
+// Loads the state of an actor in the prior tree.
+func (p *priorTree) getState(a addr.Address, out cbor.Unmarshaler) {
+	actor, found, err := p.tree.GetActor(a)
+	require.NoError(p.t, err)
+	require.True(p.t, found, a)
+	require.NoError(p.t, p.store.Get(p.store.Context(), actor.Head, out))
+}
+
+// Removes an actor from the tree.
+func (p *priorTree) deleteActor(a addr.Address) {
+	require.NoError(p.t, p.tree.Map.Delete(abi.AddrKey(a)))
+}
+
+// Migrates the tree, returning the migrated tree.
+func (p *priorTree) migrate() *migratedTree {
+	migrated, err := p.migrateWithConfig(nv15.Config{MaxWorkers: 2})
+	require.NoError(p.t, err)
+	return migrated
+}
+
+// Migrates the tree with a migration config, returning the migrated tree or the migration's error.
+func (p *priorTree) migrateWithConfig(cfg nv15.Config) (*migratedTree, error) {
+	root, err := p.tree.Flush()
+	require.NoError(p.t, err)
+	outRoot, err := nv15.MigrateStateTree(p.store.Context(), p.store, root, abi.ChainEpoch(0), cfg,
+		nv15.TestLogger{TB: p.t}, nv15.NewMemMigrationCache())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := states7.LoadTree(p.store, outRoot)
+	require.NoError(p.t, err)
+	return &migratedTree{t: p.t, store: p.store, tree: tree}, nil
+}
+
+type migratedTree struct {
+	t     *testing.T
+	store adt7.Store
+	tree  *states7.Tree
+}
+
+// Loads the state of an actor in the migrated tree, checking its code.
+func (m *migratedTree) getState(a addr.Address, code cid.Cid, out cbor.Unmarshaler) {
+	actor, found, err := m.tree.GetActor(a)
+	require.NoError(m.t, err)
+	require.True(m.t, found, a)
+	require.Equal(m.t, code, actor.Code)
+	require.NoError(m.t, m.store.Get(m.store.Context(), actor.Head, out))
+}
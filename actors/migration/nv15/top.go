@@ -3,6 +3,7 @@ package nv15
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -38,6 +39,24 @@ type Config struct {
 	// Time between progress logs to emit.
 	// Zero (the default) results in no progress logs.
 	ProgressLogPeriod time.Duration
+	// Receives a snapshot of progress every ProgressLogPeriod, and once more when the migration completes.
+	// Nil (the default) results in no progress reports.
+	ProgressReporter ProgressReporter
+	// Total size of the encoded state objects to retain in memory, so that repeated loads
+	// of the same objects by different actor migrations are not read from the store again.
+	// Zero (the default) results in no caching.
+	StoreCacheBytes int
+	// Total size of the blocks a BufferedBlockstore created for the migration (such as that holding the output
+	// of a dry run) may hold in memory, beyond which further blocks are spilled to a temporary file on disk.
+	// Zero (the default) results in no limit.
+	BufferMemoryBytes uint64
+	// Directory in which to create spill files, or the system temporary directory if empty.
+	BufferSpillDir string
+	// ID addresses of the signers of the multisig created at builtin.GovernanceActorAddr, if the prior tree has
+	// no actor there, and the number of them required to approve its transactions.
+	// A network whose genesis created an actor at that address keeps it, and need not configure these.
+	GovernanceSigners   []address.Address
+	GovernanceThreshold uint64
 }
 
 type Logger interface {
@@ -47,14 +66,18 @@ type Logger interface {
 
 // Migrates from v14 to v15
 //
-// This migration only updates the actor code CIDs in the state tree.
-// MigrationCache stores and loads cached data. Its implementation must be threadsafe
+// This migration updates the actor code CIDs in the state tree, and migrates miner, market, multisig and
+// verified registry state.
+// MigrationCache stores and loads cached data. Its implementation must be threadsafe.
+// The migrated state of an actor is cached under ActorCodeHeadKey, so a cache populated by a
+// pre-migration at one epoch serves every actor whose state is unchanged at a later epoch.
 type MigrationCache interface {
 	Write(key string, newCid cid.Cid) error
 	Read(key string) (bool, cid.Cid, error)
 	Load(key string, loadFunc func() (cid.Cid, error)) (cid.Cid, error)
 }
 
+// Deprecated: migrated actor state is cached under ActorCodeHeadKey, which does not depend on the address.
 func ActorHeadKey(addr address.Address, head cid.Cid) string {
 	headKey, err := head.StringOfBase(multibase.Base32)
 	if err != nil {
@@ -64,6 +87,21 @@ func ActorHeadKey(addr address.Address, head cid.Cid) string {
 	return addr.String() + "-head-" + headKey
 }
 
+// Key for the migrated state of an actor with some prior version code and state head.
+// The migrated state depends only on these, so it may be shared between actors and epochs.
+func ActorCodeHeadKey(code cid.Cid, head cid.Cid) string {
+	codeKey, err := code.StringOfBase(multibase.Base32)
+	if err != nil {
+		panic(err)
+	}
+	headKey, err := head.StringOfBase(multibase.Base32)
+	if err != nil {
+		panic(err)
+	}
+
+	return "actor-" + codeKey + "-head-" + headKey
+}
+
 func SectorsAmtKey(sectorsAmt cid.Cid) string {
 	sectorsAmtKey, err := sectorsAmt.StringOfBase(multibase.Base32)
 	if err != nil {
@@ -84,49 +122,67 @@ func MinerPrevSectorsOutKey(m address.Address) string {
 // Migrates the filecoin state tree starting from the global state tree and upgrading all actor state.
 // The store must support concurrent writes (even if the configured worker count is 1).
 func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg Config, log Logger, cache MigrationCache) (cid.Cid, error) {
-	if cfg.MaxWorkers <= 0 {
-		return cid.Undef, xerrors.Errorf("invalid migration config with %d workers", cfg.MaxWorkers)
-	}
+	root, _, err := MigrateStateTreeWithMetrics(ctx, store, actorsRootIn, priorEpoch, cfg, log, cache)
+	return root, err
+}
 
-	mm, err := newMinerMigrator(ctx, store)
+// Costs of a completed migration.
+type MigrationMetrics struct {
+	Duration time.Duration
+	Jobs     uint32
+	// Completed jobs, time and objects visited, by the name of the prior actor code.
+	Kinds map[string]KindProgress
+	// Heap allocations and garbage collection by the whole process during the migration.
+	// Migrators run concurrently, so allocations cannot be attributed to them individually.
+	AllocBytes uint64
+	Allocs     uint64
+	GCCycles   uint32
+	GCPause    time.Duration
+}
+
+// Migrates the state tree as MigrateStateTree does, also returning measurements of the migration's cost.
+func MigrateStateTreeWithMetrics(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg Config, log Logger, cache MigrationCache) (cid.Cid, *MigrationMetrics, error) {
+	registry, err := BuiltinMigrations(ctx, store, cache)
 	if err != nil {
-		return cid.Undef, xerrors.Errorf("failed to create miner migrator: %w", err)
+		return cid.Undef, nil, err
 	}
+	return MigrateStateTreeWithRegistry(ctx, store, actorsRootIn, priorEpoch, cfg, log, cache, registry)
+}
 
-	// Maps prior version code CIDs to migration functions.
-	var migrations = map[cid.Cid]actorMigration{
-		builtin6.AccountActorCodeID:          nilMigrator{builtin7.AccountActorCodeID},
-		builtin6.CronActorCodeID:             nilMigrator{builtin7.CronActorCodeID},
-		builtin6.InitActorCodeID:             nilMigrator{builtin7.InitActorCodeID},
-		builtin6.MultisigActorCodeID:         nilMigrator{builtin7.MultisigActorCodeID},
-		builtin6.PaymentChannelActorCodeID:   nilMigrator{builtin7.PaymentChannelActorCodeID},
-		builtin6.RewardActorCodeID:           nilMigrator{builtin7.RewardActorCodeID},
-		builtin6.StorageMarketActorCodeID:    nilMigrator{builtin7.StorageMarketActorCodeID},
-		builtin6.StorageMinerActorCodeID:     cachedMigration(cache, *mm),
-		builtin6.StoragePowerActorCodeID:     nilMigrator{builtin7.StoragePowerActorCodeID},
-		builtin6.SystemActorCodeID:           nilMigrator{builtin7.SystemActorCodeID},
-		builtin6.VerifiedRegistryActorCodeID: verifregMigrator{},
+// Migrates the state tree by applying the migrations in a registry to each actor, according to its code CID.
+// Every actor in the tree must have a migration registered for its code.
+// This is the framework underlying MigrateStateTree, for networks with actors beyond the builtin set.
+func MigrateStateTreeWithRegistry(ctx context.Context, store cbor.IpldStore, actorsRootIn cid.Cid, priorEpoch abi.ChainEpoch, cfg Config, log Logger, cache MigrationCache, registry *MigrationRegistry) (cid.Cid, *MigrationMetrics, error) {
+	if cfg.MaxWorkers <= 0 {
+		return cid.Undef, nil, xerrors.Errorf("invalid migration config with %d workers", cfg.MaxWorkers)
 	}
+	var memStart runtime.MemStats
+	runtime.ReadMemStats(&memStart)
 
-	// Set of prior version code CIDs for actors to defer during iteration, for explicit migration afterwards.
-	var deferredCodeIDs = map[cid.Cid]struct{}{
-		// None
+	startTime := time.Now()
+	// Atomically-modified counters for logging progress
+	var jobCount uint32
+	var doneCount uint32
+	progress := newProgressTracker(startTime, &jobCount, &doneCount)
+	if cfg.ProgressReporter != nil {
+		store = &meteredStore{IpldStore: store, tracker: progress}
 	}
 
-	if len(migrations)+len(deferredCodeIDs) != 11 {
-		panic(fmt.Sprintf("incomplete migration specification with %d code CIDs", len(migrations)))
+	var cachingStore *adt7.CachingStore
+	if cfg.StoreCacheBytes > 0 {
+		cachingStore = adt7.NewCachingStore(adt7.WrapStore(ctx, store), cfg.StoreCacheBytes)
+		store = cachingStore
 	}
-	startTime := time.Now()
 
 	// Load input and output state trees
 	adtStore := adt7.WrapStore(ctx, store)
 	actorsIn, err := states6.LoadTree(adtStore, actorsRootIn)
 	if err != nil {
-		return cid.Undef, err
+		return cid.Undef, nil, err
 	}
 	actorsOut, err := states6.NewTree(adtStore)
 	if err != nil {
-		return cid.Undef, err
+		return cid.Undef, nil, err
 	}
 
 	// Setup synchronization
@@ -134,19 +190,13 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 	// Input and output queues for workers.
 	jobCh := make(chan *migrationJob, cfg.JobQueueSize)
 	jobResultCh := make(chan *migrationJobResult, cfg.ResultQueueSize)
-	// Atomically-modified counters for logging progress
-	var jobCount uint32
-	var doneCount uint32
 
 	// Iterate all actors in old state root to create migration jobs for each non-deferred actor.
 	grp.Go(func() error {
 		defer close(jobCh)
 		log.Log(rt.INFO, "Creating migration jobs for tree %s", actorsRootIn)
 		if err = actorsIn.ForEach(func(addr address.Address, actorIn *states6.Actor) error {
-			if _, ok := deferredCodeIDs[actorIn.Code]; ok {
-				return nil // Deferred for explicit migration later.
-			}
-			migration, ok := migrations[actorIn.Code]
+			migration, ok := registry.migrations[actorIn.Code]
 			if !ok {
 				return xerrors.Errorf("actor with code %s has no registered migration function", actorIn.Code)
 			}
@@ -168,6 +218,7 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 		}); err != nil {
 			return err
 		}
+		progress.finishedCreating()
 		log.Log(rt.INFO, "Done creating %d migration jobs for tree %s after %v", jobCount, actorsRootIn, time.Since(startTime))
 		return nil
 	})
@@ -180,10 +231,13 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 		grp.Go(func() error {
 			defer workerWg.Done()
 			for job := range jobCh {
-				result, err := job.run(ctx, store, priorEpoch)
+				jobStore := &countingStore{IpldStore: store}
+				jobStart := time.Now()
+				result, err := job.run(ctx, jobStore, priorEpoch)
 				if err != nil {
 					return err
 				}
+				progress.recordJob(job.Actor.Code, time.Since(jobStart), jobStore)
 				select {
 				case jobResultCh <- result:
 				case <-ctx.Done():
@@ -213,6 +267,9 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 					rate := float64(doneNow) / elapsed.Seconds()
 					log.Log(rt.INFO, "%d jobs created, %d done, %d pending after %v (%.0f/s)",
 						jobsNow, doneNow, pendingNow, elapsed, rate)
+					if cfg.ProgressReporter != nil {
+						cfg.ProgressReporter.ReportProgress(progress.snapshot())
+					}
 				case <-workersFinished:
 					return
 				case <-ctx.Done():
@@ -246,17 +303,149 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 	})
 
 	if err := grp.Wait(); err != nil {
-		return cid.Undef, err
+		return cid.Undef, nil, err
+	}
+	if registry.createGovernance {
+		if err := ensureGovernanceActor(adtStore, actorsOut, cfg); err != nil {
+			return cid.Undef, nil, err
+		}
 	}
 
 	elapsed := time.Since(startTime)
 	rate := float64(doneCount) / elapsed.Seconds()
 	log.Log(rt.INFO, "All %d done after %v (%.0f/s). Flushing state tree root.", doneCount, elapsed, rate)
-	return actorsOut.Flush()
+	if cachingStore != nil {
+		metrics := cachingStore.Metrics()
+		log.Log(rt.INFO, "Store cache served %d reads, %d read from store (%d bytes), %d writes (%d bytes)",
+			metrics.Hits, metrics.Misses, metrics.ReadBytes, metrics.Writes, metrics.WriteBytes)
+	}
+	root, err := actorsOut.Flush()
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+	final := progress.snapshot()
+	if cfg.ProgressReporter != nil {
+		cfg.ProgressReporter.ReportProgress(final)
+	}
+
+	var memEnd runtime.MemStats
+	runtime.ReadMemStats(&memEnd)
+	return root, &MigrationMetrics{
+		Duration:   time.Since(startTime),
+		Jobs:       final.JobsDone,
+		Kinds:      final.Kinds,
+		AllocBytes: memEnd.TotalAlloc - memStart.TotalAlloc,
+		Allocs:     memEnd.Mallocs - memStart.Mallocs,
+		GCCycles:   memEnd.NumGC - memStart.NumGC,
+		GCPause:    time.Duration(memEnd.PauseTotalNs - memStart.PauseTotalNs),
+	}, nil
+}
+
+// Registry of the migrations to apply to actors, by their prior version code CID.
+type MigrationRegistry struct {
+	migrations map[cid.Cid]actorMigration
+	// Whether to create the governance actor when the tree lacks it, as the upgrade to v7 does.
+	createGovernance bool
+}
+
+func NewMigrationRegistry() *MigrationRegistry {
+	return &MigrationRegistry{migrations: make(map[cid.Cid]actorMigration)}
+}
+
+// Returns a registry of the migrations of the builtin actors from v6 to v7.
+// A migration with this registry also creates the governance actor if the tree lacks it (see Config.GovernanceSigners).
+// Further migrations may be registered for other actors, and the builtin migrations replaced.
+func BuiltinMigrations(ctx context.Context, store cbor.IpldStore, cache MigrationCache) (*MigrationRegistry, error) {
+	mm, err := newMinerMigrator(ctx, store)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create miner migrator: %w", err)
+	}
+
+	// Maps prior version code CIDs to migration functions.
+	var migrations = map[cid.Cid]actorMigration{
+		builtin6.AccountActorCodeID:          nilMigrator{builtin7.AccountActorCodeID},
+		builtin6.CronActorCodeID:             cachedMigration(cache, cronMigrator{}),
+		builtin6.InitActorCodeID:             cachedMigration(cache, initMigrator{}),
+		builtin6.MultisigActorCodeID:         cachedMigration(cache, multisigMigrator{}),
+		builtin6.PaymentChannelActorCodeID:   cachedMigration(cache, paychMigrator{}),
+		builtin6.RewardActorCodeID:           cachedMigration(cache, rewardMigrator{}),
+		builtin6.StorageMarketActorCodeID:    cachedMigration(cache, marketMigrator{}),
+		builtin6.StorageMinerActorCodeID:     cachedMigration(cache, *mm),
+		builtin6.StoragePowerActorCodeID:     nilMigrator{builtin7.StoragePowerActorCodeID},
+		builtin6.SystemActorCodeID:           nilMigrator{builtin7.SystemActorCodeID},
+		builtin6.VerifiedRegistryActorCodeID: cachedMigration(cache, verifregMigrator{}),
+	}
+	if len(migrations) != 11 {
+		panic(fmt.Sprintf("incomplete migration specification with %d code CIDs", len(migrations)))
+	}
+	return &MigrationRegistry{migrations: migrations, createGovernance: true}, nil
+}
+
+// Registers a migration for actors with a code CID, replacing any migration already registered for it.
+func (r *MigrationRegistry) Register(code cid.Cid, m ActorMigration) {
+	r.migrations[code] = externalMigrator{m}
+}
+
+// Registers a migration for actors with a code CID, as Register does, caching its results by the actor's
+// code and head so that actors with the same state are migrated only once.
+// The migration's result must depend only on the actor's code and head.
+func (r *MigrationRegistry) RegisterCached(code cid.Cid, m ActorMigration, cache MigrationCache) {
+	r.migrations[code] = cachedMigration(cache, externalMigrator{m})
+}
+
+// Input to an ActorMigration.
+type ActorMigrationInput struct {
+	Address    address.Address // actor's address
+	Code       cid.Cid         // actor's prior version code
+	Head       cid.Cid         // actor's prior state
+	PriorEpoch abi.ChainEpoch  // epoch of last state transition prior to migration
+	Cache      MigrationCache  // cache available to the migration for its own intermediate results
+}
+
+// Result of an ActorMigration.
+type ActorMigrationResult struct {
+	NewCodeCID cid.Cid
+	NewHead    cid.Cid
+}
+
+// A migration of actors with some code, which may be registered in a MigrationRegistry.
+// Implementations must be threadsafe, as actors are migrated concurrently.
+type ActorMigration interface {
+	// Loads an actor's state from the store and writes its new state to the store.
+	MigrateState(ctx context.Context, store cbor.IpldStore, input ActorMigrationInput) (*ActorMigrationResult, error)
+	// The code CID of migrated actors.
+	MigratedCodeCID() cid.Cid
+}
+
+// Adapts an ActorMigration to the internal migration interface.
+type externalMigrator struct {
+	ActorMigration
+}
+
+func (e externalMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	result, err := e.MigrateState(ctx, store, ActorMigrationInput{
+		Address:    in.address,
+		Code:       in.code,
+		Head:       in.head,
+		PriorEpoch: in.priorEpoch,
+		Cache:      in.cache,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &actorMigrationResult{
+		newCodeCID: result.NewCodeCID,
+		newHead:    result.NewHead,
+	}, nil
+}
+
+func (e externalMigrator) migratedCodeCID() cid.Cid {
+	return e.MigratedCodeCID()
 }
 
 type actorMigrationInput struct {
 	address    address.Address // actor's address
+	code       cid.Cid         // actor's prior version code
 	head       cid.Cid
 	priorEpoch abi.ChainEpoch // epoch of last state transition prior to migration
 	cache      MigrationCache // cache of existing cid -> cid migrations for this actor
@@ -289,13 +478,14 @@ type migrationJobResult struct {
 func (job *migrationJob) run(ctx context.Context, store cbor.IpldStore, priorEpoch abi.ChainEpoch) (*migrationJobResult, error) {
 	result, err := job.migrateState(ctx, store, actorMigrationInput{
 		address:    job.Address,
+		code:       job.Actor.Code,
 		head:       job.Actor.Head,
 		priorEpoch: priorEpoch,
 		cache:      job.cache,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("state migration failed for %s actor, addr %s: %w",
-			builtin6.ActorNameByCode(job.Actor.Code), job.Address, err)
+			actorNameByCode(job.Actor.Code), job.Address, err)
 	}
 
 	// Set up new actor record with the migrated state.
@@ -333,7 +523,7 @@ type cachedMigrator struct {
 }
 
 func (c cachedMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
-	newHead, err := c.cache.Load(ActorHeadKey(in.address, in.head), func() (cid.Cid, error) {
+	newHead, err := c.cache.Load(ActorCodeHeadKey(in.code, in.head), func() (cid.Cid, error) {
 		result, err := c.actorMigration.migrateState(ctx, store, in)
 		if err != nil {
 			return cid.Undef, err
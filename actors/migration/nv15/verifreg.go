@@ -26,12 +26,18 @@ func (m verifregMigrator) migrateState(ctx context.Context, store cbor.IpldStore
 		return nil, err
 	}
 
+	allowances, err := adt.StoreEmptyMap(adt.WrapStore(ctx, store), builtin7.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to construct new datacap allowance map %w", err)
+	}
+
 
 	outState := verifreg7.State{
 		RootKey: inState.RootKey,
 		Verifiers: inState.Verifiers,
 		VerifiedClients: inState.VerifiedClients,
 		RemoveDataCapProposalIDs: proposalId,
+		DataCapAllowances: allowances,
 	}
 
 	newHead, err := store.Put(ctx, &outState)
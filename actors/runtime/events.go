@@ -0,0 +1,25 @@
+package runtime
+
+// An event emitted by an actor, as an ordered list of key-value entries.
+// Events are recorded by the VM with the receipt of the message which caused them, for consumption by
+// clients and indexers. Events emitted by an invocation which aborts are discarded along with its state changes.
+// Events are never observable by actors.
+type ActorEvent struct {
+	Entries []EventEntry
+}
+
+// One key-value entry of an event.
+type EventEntry struct {
+	Flags uint64 // A combination of EventFlag* values, hinting at which parts of the entry to index.
+	Key   string
+	Value []byte // The CBOR encoding of the value.
+}
+
+const (
+	// Indicates that the key of an entry should be indexed.
+	EventFlagIndexedKey uint64 = 1 << iota
+	// Indicates that the value of an entry should be indexed.
+	EventFlagIndexedValue
+
+	EventFlagIndexedAll = EventFlagIndexedKey | EventFlagIndexedValue
+)
@@ -0,0 +1,23 @@
+package runtime
+
+// Kinds of significant operation reported to a GasTracer.
+type GasOperation string
+
+const (
+	// Loading an actor's state object.
+	GasOpStateLoad GasOperation = "state-load"
+	// Verifying a seal, PoSt, replica update or aggregate proof.
+	GasOpVerifyProof GasOperation = "verify-proof"
+	// Sending a message to another actor, including the execution of the method invoked.
+	GasOpSend GasOperation = "send"
+)
+
+// GasTracer is an optional interface which a Runtime may implement to observe the gas consumed by
+// significant operations, for profiling built-in actor methods.
+// Actor code reports operations through builtin.TraceGas, which does nothing if the runtime is not a GasTracer.
+// Tracing must not affect execution: a tracer may observe gas, but not charge it.
+type GasTracer interface {
+	// Marks the start of an operation, returning a function to be called when the operation completes.
+	// The name distinguishes operations of the same kind, e.g. the proof type verified.
+	TraceGas(op GasOperation, name string) (end func())
+}
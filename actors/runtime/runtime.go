@@ -32,8 +32,8 @@ type Runtime interface {
 	// Provides a handle for the actor's state object.
 	StateHandle
 
-	// Provides IPLD storage for actor state
-	Store
+	// Provides read access to state: storage, the current epoch, and address resolution.
+	StateReader
 
 	// Provides the system call interface.
 	Syscalls
@@ -41,9 +41,6 @@ type Runtime interface {
 	// The network protocol version number at the current epoch.
 	NetworkVersion() network.Version
 
-	// The current chain epoch number. The genesis block has epoch zero.
-	CurrEpoch() abi.ChainEpoch
-
 	// Satisfies the requirement that every exported actor method must invoke at least one caller validation
 	// method before returning, without making any assertions about the caller.
 	ValidateImmediateCallerAcceptAny()
@@ -61,11 +58,6 @@ type Runtime interface {
 	// The balance of the receiver. Always >= zero.
 	CurrentBalance() abi.TokenAmount
 
-	// Resolves an address of any protocol to an ID address (via the Init actor's table).
-	// This allows resolution of externally-provided SECP, BLS, or actor addresses to the canonical form.
-	// If the argument is an ID address it is returned directly.
-	ResolveAddress(address addr.Address) (addr.Address, bool)
-
 	// Look up the code ID at an actor address.
 	// The address will be resolved as if via ResolveAddress, if necessary, so need not be an ID-address.
 	GetActorCodeCID(addr addr.Address) (ret cid.Cid, ok bool)
@@ -90,6 +82,11 @@ type Runtime interface {
 	// will be rolled back.
 	Send(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, out cbor.Er) exitcode.ExitCode
 
+	// Sends a message to another actor as for Send, but the invoked method (and any messages it sends in turn)
+	// may consume at most gasLimit gas. If the limit is exhausted, the send fails with SysErrOutOfGas and
+	// the sender continues execution with the remainder of its own gas.
+	SendWithGasLimit(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, gasLimit int64, out cbor.Er) exitcode.ExitCode
+
 	// Halts execution upon an error from which the receiver cannot recover. The caller will receive the exitcode and
 	// an empty return value. State changes made within this call will be rolled back.
 	// This method does not return.
@@ -124,11 +121,6 @@ type Runtime interface {
 	// - deal collateral locked by the storage market actor
 	TotalFilCircSupply() abi.TokenAmount
 
-	// Provides a Go context for use by HAMT, etc.
-	// The VM is intended to provide an idealised machine abstraction, with infinite storage etc, so this context
-	// should not be used by actor code directly.
-	Context() context.Context
-
 	// Starts a new tracing span. The span must be End()ed explicitly by invoking or deferring EndSpan
 	StartSpan(name string) (EndSpan func())
 
@@ -142,10 +134,34 @@ type Runtime interface {
 	// Note events that may make debugging easier
 	Log(level rt.LogLevel, msg string, args ...interface{})
 
+	// Emits an event composed of the given entries, to be recorded with the message receipt.
+	EmitEvent(entries ...EventEntry)
+
 	// BaseFee returns the basefee value in attoFIL per unit gas for the currently exectuting tipset.
 	BaseFee() abi.TokenAmount
 }
 
+// StateReader is the subset of the runtime needed to read actor state.
+// Code which only reads state accepts a StateReader rather than a Runtime, so that it may be invoked by
+// off-chain tools without an implementation of sending, aborting, or gas accounting.
+type StateReader interface {
+	// Provides IPLD storage for actor state
+	Store
+
+	// The current chain epoch number. The genesis block has epoch zero.
+	CurrEpoch() abi.ChainEpoch
+
+	// Resolves an address of any protocol to an ID address (via the Init actor's table).
+	// This allows resolution of externally-provided SECP, BLS, or actor addresses to the canonical form.
+	// If the argument is an ID address it is returned directly.
+	ResolveAddress(address addr.Address) (addr.Address, bool)
+
+	// Provides a Go context for use by HAMT, etc.
+	// The VM is intended to provide an idealised machine abstraction, with infinite storage etc, so this context
+	// should not be used by actor code directly.
+	Context() context.Context
+}
+
 // Store defines the storage module exposed to actors.
 type Store interface {
 	// Retrieves and deserializes an object from the store into `o`. Returns whether successful.
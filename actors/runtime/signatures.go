@@ -0,0 +1,23 @@
+package runtime
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// A request to verify a signature by a signer over some plaintext.
+type SignatureVerification struct {
+	Signature crypto.Signature
+	Signer    addr.Address
+	Plaintext []byte
+}
+
+// BatchSignatureVerifier is an optional interface which a Runtime may implement to verify many signatures
+// in a single call, more cheaply than verifying them one at a time.
+// Actor code verifies signatures in batches through builtin.SignatureBatch, which falls back to
+// verifying each signature with VerifySignature if the runtime is not a BatchSignatureVerifier.
+type BatchSignatureVerifier interface {
+	// Verifies a batch of signatures, returning one result per verification in the order given.
+	// Each result is nil if the signature is valid, as for VerifySignature.
+	BatchVerifySignatures(verifications []SignatureVerification) []error
+}
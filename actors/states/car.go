@@ -0,0 +1,138 @@
+package states
+
+import (
+	"bytes"
+	"io"
+
+	addr "github.com/filecoin-project/go-address"
+	blocks "github.com/ipfs/go-block-format"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	car "github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Writes a CAR file containing a state tree that holds only the given actors from the tree rooted at root,
+// along with all the state reachable from those actors. Returns the root of the new tree, which is the
+// single root of the CAR file.
+// The exported tree can be loaded with ImportCAR and LoadTree to reproduce the behaviour of those actors
+// without the rest of the (possibly very large) state.
+// Links to CIDs that are not DAG-CBOR, such as sector commitments and actor code CIDs, are not followed.
+func ExportActorsCAR(store adt.Store, root cid.Cid, actors []addr.Address, w io.Writer) (cid.Cid, error) {
+	tree, err := LoadTree(store, root)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	// The new tree's nodes are written to a scratch store, while the actors' state is read from the original.
+	scratch := adt.WrapStore(store.Context(), ipldcbor.NewCborStore(newMemBlockstore()))
+	subset, err := NewTree(scratch)
+	if err != nil {
+		return cid.Undef, err
+	}
+	for _, a := range actors {
+		actor, found, err := tree.GetActor(a)
+		if err != nil {
+			return cid.Undef, err
+		}
+		if !found {
+			return cid.Undef, xerrors.Errorf("actor %v not found in state tree %v", a, root)
+		}
+		if err := subset.SetActor(a, actor); err != nil {
+			return cid.Undef, err
+		}
+	}
+	subsetRoot, err := subset.Flush()
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{subsetRoot}, Version: 1}, w); err != nil {
+		return cid.Undef, err
+	}
+	union := &unionStore{ctx: store.Context(), stores: []adt.Store{scratch, store}}
+	if err := writeCARBlocks(union, subsetRoot, w, make(map[cid.Cid]struct{})); err != nil {
+		return cid.Undef, err
+	}
+	return subsetRoot, nil
+}
+
+// Writes a CAR file containing the whole state tree rooted at root, which is the single root of the CAR file.
+// As for ExportActorsCAR, links to CIDs that are not DAG-CBOR are not followed.
+func ExportCAR(store adt.Store, root cid.Cid, w io.Writer) error {
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, w); err != nil {
+		return err
+	}
+	return writeCARBlocks(store, root, w, make(map[cid.Cid]struct{}))
+}
+
+// Reads the blocks of a CAR file into a block store, returning the CAR file's root.
+// The CAR file must have exactly one root, as written by ExportActorsCAR or ExportCAR.
+func ImportCAR(bs ipldcbor.IpldBlockstore, r io.Reader) (cid.Cid, error) {
+	header, err := car.LoadCar(bs, r)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if len(header.Roots) != 1 {
+		return cid.Undef, xerrors.Errorf("expected one root in CAR file, found %d", len(header.Roots))
+	}
+	return header.Roots[0], nil
+}
+
+// Writes the DAG-CBOR block c and all blocks reachable from it that have not already been seen,
+// in depth-first order.
+func writeCARBlocks(store adt.Store, c cid.Cid, w io.Writer, seen map[cid.Cid]struct{}) error {
+	if c.Prefix().Codec != cid.DagCBOR {
+		return nil
+	}
+	if _, ok := seen[c]; ok {
+		return nil
+	}
+	seen[c] = struct{}{}
+
+	var raw cbg.Deferred
+	if err := store.Get(store.Context(), c, &raw); err != nil {
+		return xerrors.Errorf("failed to load block %v: %w", c, err)
+	}
+	if err := carutil.LdWrite(w, c.Bytes(), raw.Raw); err != nil {
+		return err
+	}
+	var links []cid.Cid
+	if err := cbg.ScanForLinks(bytes.NewReader(raw.Raw), func(l cid.Cid) {
+		links = append(links, l)
+	}); err != nil {
+		return xerrors.Errorf("failed to scan block %v for links: %w", c, err)
+	}
+	for _, l := range links {
+		if err := writeCARBlocks(store, l, w, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A minimal in-memory block store.
+type memBlockstore map[cid.Cid]blocks.Block
+
+var _ ipldcbor.IpldBlockstore = memBlockstore{}
+
+func newMemBlockstore() memBlockstore {
+	return make(memBlockstore)
+}
+
+func (m memBlockstore) Get(c cid.Cid) (blocks.Block, error) {
+	b, ok := m[c]
+	if !ok {
+		return nil, xerrors.Errorf("block %v not found", c)
+	}
+	return b, nil
+}
+
+func (m memBlockstore) Put(b blocks.Block) error {
+	m[b.Cid()] = b
+	return nil
+}
@@ -0,0 +1,87 @@
+package states_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	address "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestExportActorsCAR(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+	tree, err := states.NewTree(store)
+	require.NoError(t, err)
+
+	for id := uint64(100); id < 110; id++ {
+		head, err := store.Put(ctx, &account.State{Address: tutil.NewBLSAddr(t, int64(id))})
+		require.NoError(t, err)
+		require.NoError(t, tree.SetActor(tutil.NewIDAddr(t, id), &states.Actor{
+			Code:    builtin.AccountActorCodeID,
+			Head:    head,
+			Balance: big.NewInt(int64(id)),
+		}))
+	}
+
+	emptyMap, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	msigAddr := tutil.NewIDAddr(t, 200)
+	msigHead, err := store.Put(ctx, &multisig.State{
+		Signers:               []address.Address{tutil.NewIDAddr(t, 100)},
+		NumApprovalsThreshold: 1,
+		InitialBalance:        big.Zero(),
+		PendingTxns:           emptyMap,
+		PendingTxnMetadata:    emptyMap,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActor(msigAddr, &states.Actor{Code: builtin.MultisigActorCodeID, Head: msigHead, Balance: big.Zero()}))
+	root, err := tree.Flush()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	exported := []address.Address{tutil.NewIDAddr(t, 103), msigAddr}
+	exportedRoot, err := states.ExportActorsCAR(store, root, exported, &buf)
+	require.NoError(t, err)
+
+	bs := ipld.NewBlockStoreInMemory()
+	importedRoot, err := states.ImportCAR(bs, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, exportedRoot, importedRoot)
+
+	imported := adt.WrapStore(ctx, ipldcbor.NewCborStore(bs))
+	importedTree, err := states.LoadTree(imported, importedRoot)
+	require.NoError(t, err)
+
+	var keys []address.Address
+	require.NoError(t, importedTree.ForEachKey(func(a address.Address) error {
+		keys = append(keys, a)
+		return nil
+	}))
+	assert.ElementsMatch(t, exported, keys)
+
+	// The actors' state is fully available in the imported store.
+	actor, found, err := importedTree.GetActor(msigAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st multisig.State
+	require.NoError(t, imported.Get(ctx, actor.Head, &st))
+	_, err = adt.AsMap(imported, st.PendingTxns, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+
+	// Exporting a missing actor fails.
+	_, err = states.ExportActorsCAR(store, root, []address.Address{tutil.NewIDAddr(t, 999)}, &bytes.Buffer{})
+	require.Error(t, err)
+}
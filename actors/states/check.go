@@ -6,6 +6,8 @@ import (
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
@@ -20,141 +22,296 @@ import (
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 )
 
 // Within this code, Go errors are not expected, but are often converted to messages so that execution
 // can continue to find more errors rather than fail with no insight.
 // Only errors thar are particularly troublesome to recover from should propagate as Go errors.
 func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, priorEpoch abi.ChainEpoch) (*builtin.MessageAccumulator, error) {
-	acc := &builtin.MessageAccumulator{}
-	totalFIl := big.Zero()
-	var initSummary *init_.StateSummary
-	var cronSummary *cron.StateSummary
-	var verifregSummary *verifreg.StateSummary
-	var marketSummary *market.StateSummary
-	var rewardSummary *reward.StateSummary
-	var accountSummaries []*account.StateSummary
-	var powerSummary *power.StateSummary
-	var paychSummaries []*paych.StateSummary
-	var multisigSummaries []*multisig.StateSummary
-	minerSummaries := make(map[addr.Address]*miner.StateSummary)
-
-	if err := tree.ForEach(func(key addr.Address, actor *Actor) error {
-		acc := acc.WithPrefix("%v ", key) // Intentional shadow
-		if key.Protocol() != addr.ID {
-			acc.Addf("unexpected address protocol in state tree root: %v", key)
-		}
-		totalFIl = big.Add(totalFIl, actor.Balance)
-
-		switch actor.Code {
-		case builtin.SystemActorCodeID:
-
-		case builtin.InitActorCodeID:
-			var st init_.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := init_.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("init: ").AddAll(msgs)
-			initSummary = summary
-		case builtin.CronActorCodeID:
-			var st cron.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := cron.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("cron: ").AddAll(msgs)
-			cronSummary = summary
-		case builtin.AccountActorCodeID:
-			var st account.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := account.CheckStateInvariants(&st, key)
-			acc.WithPrefix("account: ").AddAll(msgs)
-			accountSummaries = append(accountSummaries, summary)
-		case builtin.StoragePowerActorCodeID:
-			var st power.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := power.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("power: ").AddAll(msgs)
-			powerSummary = summary
-		case builtin.StorageMinerActorCodeID:
-			var st miner.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := miner.CheckStateInvariants(&st, tree.Store, actor.Balance)
-			acc.WithPrefix("miner: ").AddAll(msgs)
-			minerSummaries[key] = summary
-		case builtin.StorageMarketActorCodeID:
-			var st market.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := market.CheckStateInvariants(&st, tree.Store, actor.Balance, priorEpoch)
-			acc.WithPrefix("market: ").AddAll(msgs)
-			marketSummary = summary
-		case builtin.PaymentChannelActorCodeID:
-			var st paych.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := paych.CheckStateInvariants(&st, tree.Store, actor.Balance)
-			acc.WithPrefix("paych: ").AddAll(msgs)
-			paychSummaries = append(paychSummaries, summary)
-		case builtin.MultisigActorCodeID:
-			var st multisig.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
-			}
-			summary, msgs := multisig.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("multisig: ").AddAll(msgs)
-			multisigSummaries = append(multisigSummaries, summary)
-		case builtin.RewardActorCodeID:
-			var st reward.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
+	checker := newStateChecker(tree.Store, priorEpoch)
+	if err := tree.ForEach(checker.checkActor); err != nil {
+		return nil, err
+	}
+	return checker.finish(expectedBalanceTotal), nil
+}
+
+// Options for CheckStateInvariantsWithOptions.
+type CheckOptions struct {
+	// Number of actors whose state is checked concurrently. Values less than two check actors sequentially.
+	// The tree's store must be safe for concurrent use if this is more than one.
+	Workers int
+	// If non-empty, only actors with one of these code CIDs are checked.
+	Codes []cid.Cid
+	// If non-empty, only actors with one of these addresses are checked.
+	Addresses []addr.Address
+}
+
+// Checks the same invariants as CheckStateInvariants, optionally checking actors concurrently and
+// restricting the checks to a subset of actors.
+// When checks are restricted, the cross-actor checks consider only the actors checked, and are skipped
+// if the power or market actor is not among them. The total balance is always checked over all actors.
+// Messages are reported in the same order as by CheckStateInvariants.
+func CheckStateInvariantsWithOptions(tree *Tree, expectedBalanceTotal abi.TokenAmount, priorEpoch abi.ChainEpoch,
+	opts CheckOptions) (*builtin.MessageAccumulator, error) {
+	checker := newStateChecker(tree.Store, priorEpoch)
+	checker.selected = opts.selector()
+	if opts.Workers < 2 {
+		if err := tree.ForEach(checker.checkActor); err != nil {
+			return nil, err
+		}
+		return checker.finish(expectedBalanceTotal), nil
+	}
+
+	root, err := tree.Map.Root()
+	if err != nil {
+		return nil, err
+	}
+	results, err := adt.ParForEachMap(tree.Store, root, opts.Workers, func(k string, val *cbg.Deferred) (actorCheck, error) {
+		key, err := addr.NewFromBytes([]byte(k))
+		if err != nil {
+			return actorCheck{}, err
+		}
+		var actor Actor
+		if err := actor.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return actorCheck{}, xerrors.Errorf("failed to decode actor %v: %w", key, err)
+		}
+		return checker.checkActorState(key, &actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		checker.record(res)
+	}
+	return checker.finish(expectedBalanceTotal), nil
+}
+
+// Returns a predicate selecting the actors to check, or nil if all actors are to be checked.
+func (o CheckOptions) selector() func(key addr.Address, actor *Actor) bool {
+	if len(o.Codes) == 0 && len(o.Addresses) == 0 {
+		return nil
+	}
+	codes := make(map[cid.Cid]struct{}, len(o.Codes))
+	for _, c := range o.Codes {
+		codes[c] = struct{}{}
+	}
+	addrs := make(map[addr.Address]struct{}, len(o.Addresses))
+	for _, a := range o.Addresses {
+		addrs[a] = struct{}{}
+	}
+	return func(key addr.Address, actor *Actor) bool {
+		if len(codes) > 0 {
+			if _, ok := codes[actor.Code]; !ok {
+				return false
 			}
-			summary, msgs := reward.CheckStateInvariants(&st, tree.Store, priorEpoch, actor.Balance)
-			acc.WithPrefix("reward: ").AddAll(msgs)
-			rewardSummary = summary
-		case builtin.VerifiedRegistryActorCodeID:
-			var st verifreg.State
-			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
-				return err
+		}
+		if len(addrs) > 0 {
+			if _, ok := addrs[key]; !ok {
+				return false
 			}
-			summary, msgs := verifreg.CheckStateInvariants(&st, tree.Store)
-			acc.WithPrefix("verifreg: ").AddAll(msgs)
-			verifregSummary = summary
-		default:
-			return xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
 		}
-		return nil
-	}); err != nil {
-		return nil, err
+		return true
 	}
+}
+
+// Accumulates the results of checking each actor's state, and the summaries of singleton and miner actors
+// for cross-actor checks. Summaries of the (many) account, payment channel and multisig actors are not retained.
+type stateChecker struct {
+	store      adt.Store
+	priorEpoch abi.ChainEpoch
+	acc        *builtin.MessageAccumulator
+	totalFIl   abi.TokenAmount
+	// Selects the actors whose state is checked, or nil for all actors.
+	selected func(key addr.Address, actor *Actor) bool
+
+	initSummary     *init_.StateSummary
+	cronSummary     *cron.StateSummary
+	verifregSummary *verifreg.StateSummary
+	marketSummary   *market.StateSummary
+	rewardSummary   *reward.StateSummary
+	powerSummary    *power.StateSummary
+	minerSummaries  map[addr.Address]*miner.StateSummary
+}
+
+// The result of checking a single actor's state.
+type actorCheck struct {
+	key     addr.Address
+	balance abi.TokenAmount
+	msgs    *builtin.MessageAccumulator
+	summary interface{} // Summary retained for cross-actor checks, if any.
+}
+
+func newStateChecker(store adt.Store, priorEpoch abi.ChainEpoch) *stateChecker {
+	return &stateChecker{
+		store:          store,
+		priorEpoch:     priorEpoch,
+		acc:            &builtin.MessageAccumulator{},
+		totalFIl:       big.Zero(),
+		minerSummaries: make(map[addr.Address]*miner.StateSummary),
+	}
+}
+
+func (c *stateChecker) checkActor(key addr.Address, actor *Actor) error {
+	res, err := c.checkActorState(key, actor)
+	if err != nil {
+		return err
+	}
+	c.record(res)
+	return nil
+}
+
+// Checks a single actor's state. This does not modify the checker, so may be called concurrently.
+func (c *stateChecker) checkActorState(key addr.Address, actor *Actor) (actorCheck, error) {
+	res := actorCheck{key: key, balance: actor.Balance, msgs: &builtin.MessageAccumulator{}}
+	if c.selected != nil && !c.selected(key, actor) {
+		return res, nil
+	}
+	acc := res.msgs.WithActor(key)
+	if key.Protocol() != addr.ID {
+		acc.Addf("unexpected address protocol in state tree root: %v", key)
+	}
+	store := c.store
+
+	switch actor.Code {
+	case builtin.SystemActorCodeID:
+
+	case builtin.InitActorCodeID:
+		var st init_.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := init_.CheckStateInvariants(&st, store)
+		acc.WithCheck("init").AddAll(msgs)
+		res.summary = summary
+	case builtin.CronActorCodeID:
+		var st cron.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := cron.CheckStateInvariants(&st, store)
+		acc.WithCheck("cron").AddAll(msgs)
+		res.summary = summary
+	case builtin.AccountActorCodeID:
+		var st account.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		_, msgs := account.CheckStateInvariants(&st, key)
+		acc.WithCheck("account").AddAll(msgs)
+	case builtin.StoragePowerActorCodeID:
+		var st power.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := power.CheckStateInvariants(&st, store)
+		acc.WithCheck("power").AddAll(msgs)
+		res.summary = summary
+	case builtin.StorageMinerActorCodeID:
+		var st miner.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := miner.CheckStateInvariants(&st, store, actor.Balance)
+		acc.WithCheck("miner").AddAll(msgs)
+		res.summary = summary
+	case builtin.StorageMarketActorCodeID:
+		var st market.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := market.CheckStateInvariants(&st, store, actor.Balance, c.priorEpoch)
+		acc.WithCheck("market").AddAll(msgs)
+		res.summary = summary
+	case builtin.PaymentChannelActorCodeID:
+		var st paych.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		_, msgs := paych.CheckStateInvariants(&st, store, actor.Balance)
+		acc.WithCheck("paych").AddAll(msgs)
+	case builtin.MultisigActorCodeID:
+		var st multisig.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		_, msgs := multisig.CheckStateInvariants(&st, store)
+		acc.WithCheck("multisig").AddAll(msgs)
+	case builtin.RewardActorCodeID:
+		var st reward.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := reward.CheckStateInvariants(&st, store, c.priorEpoch, actor.Balance)
+		acc.WithCheck("reward").AddAll(msgs)
+		res.summary = summary
+	case builtin.VerifiedRegistryActorCodeID:
+		var st verifreg.State
+		if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+			return actorCheck{}, err
+		}
+		summary, msgs := verifreg.CheckStateInvariants(&st, store)
+		acc.WithCheck("verifreg").AddAll(msgs)
+		res.summary = summary
+	default:
+		return actorCheck{}, xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
+	}
+	return res, nil
+}
+
+// Accumulates the result of checking an actor.
+func (c *stateChecker) record(res actorCheck) {
+	c.acc.AddAll(res.msgs)
+	c.totalFIl = big.Add(c.totalFIl, res.balance)
+	switch summary := res.summary.(type) {
+	case *init_.StateSummary:
+		c.initSummary = summary
+	case *cron.StateSummary:
+		c.cronSummary = summary
+	case *verifreg.StateSummary:
+		c.verifregSummary = summary
+	case *market.StateSummary:
+		c.marketSummary = summary
+	case *reward.StateSummary:
+		c.rewardSummary = summary
+	case *power.StateSummary:
+		c.powerSummary = summary
+	case *miner.StateSummary:
+		c.minerSummaries[res.key] = summary
+	}
+}
+
+// Performs the cross-actor checks once every actor has been checked.
+func (c *stateChecker) finish(expectedBalanceTotal abi.TokenAmount) *builtin.MessageAccumulator {
+	acc := c.acc
 
 	//
 	// Perform cross-actor checks from state summaries here.
 	//
 
-	CheckMinersAgainstPower(acc, minerSummaries, powerSummary)
-	CheckDealStatesAgainstSectors(acc, minerSummaries, marketSummary)
-
-	_ = initSummary
-	_ = verifregSummary
-	_ = cronSummary
-	_ = marketSummary
-	_ = rewardSummary
-
-	if !totalFIl.Equals(expectedBalanceTotal) {
-		acc.Addf("total token balance is %v, expected %v", totalFIl, expectedBalanceTotal)
+	if c.selected == nil {
+		CheckMinersAgainstPower(acc, c.minerSummaries, c.powerSummary)
+		CheckDealStatesAgainstSectors(acc, c.minerSummaries, c.marketSummary)
+	} else {
+		// Only the checked actors can be cross-checked.
+		if c.powerSummary != nil {
+			CheckMinersAgainstPower(acc, c.minerSummaries, c.powerSummary)
+		}
+		if c.marketSummary != nil {
+			marketSummary := *c.marketSummary
+			marketSummary.Deals = make(map[abi.DealID]*market.DealSummary)
+			for dealID, deal := range c.marketSummary.Deals { // nolint:nomaprange
+				if _, ok := c.minerSummaries[deal.Provider]; ok {
+					marketSummary.Deals[dealID] = deal
+				}
+			}
+			CheckDealStatesAgainstSectors(acc, c.minerSummaries, &marketSummary)
+		}
 	}
 
-	return acc, nil
+	checkTotalBalance(acc, c.totalFIl, expectedBalanceTotal)
+
+	return acc
 }
 
 func CheckMinersAgainstPower(acc *builtin.MessageAccumulator, minerSummaries map[addr.Address]*miner.StateSummary, powerSummary *power.StateSummary) {
@@ -0,0 +1,229 @@
+package states
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Kind of difference in an actor between two state trees.
+type ActorChange int
+
+const (
+	ActorAdded ActorChange = iota
+	ActorRemoved
+	ActorModified
+)
+
+func (c ActorChange) String() string {
+	switch c {
+	case ActorAdded:
+		return "added"
+	case ActorRemoved:
+		return "removed"
+	case ActorModified:
+		return "modified"
+	}
+	return "unknown"
+}
+
+// A field whose value differs between two versions of a structure.
+type FieldDiff struct {
+	Name string
+	From interface{}
+	To   interface{}
+}
+
+// The difference in a single actor between two state trees.
+type ActorDiff struct {
+	Address addr.Address
+	Change  ActorChange
+	// The actor in the first tree, nil if added.
+	From *Actor
+	// The actor in the second tree, nil if removed.
+	To *Actor
+	// Differences in the actor's code, head, call sequence number and balance, for a modified actor.
+	ActorFields []FieldDiff
+	// Differences in the top-level fields of the actor's state, for a modified builtin actor whose code
+	// is unchanged. Fields that are the roots of collections are reported by CID.
+	StateFields []FieldDiff
+}
+
+// The differences between two state trees, ordered by actor address.
+type TreeDiff struct {
+	Actors []ActorDiff
+}
+
+// Returns the differences between the state tree rooted at rootA in storeA and that rooted at rootB in storeB.
+// The stores may be the same.
+// Subtrees shared by both trees are skipped without being loaded, so the cost is proportional to the
+// size of the difference.
+func Diff(storeA adt.Store, rootA cid.Cid, storeB adt.Store, rootB cid.Cid) (*TreeDiff, error) {
+	differ := &treeDiffer{
+		storeA: storeA,
+		storeB: storeB,
+		states: builtinStateTypes(),
+	}
+	union := &unionStore{ctx: storeB.Context(), stores: []adt.Store{storeB, storeA}}
+	if err := adt.DiffMap(union, rootA, rootB, differ); err != nil {
+		return nil, err
+	}
+	sort.Slice(differ.diffs, func(i, j int) bool {
+		return bytes.Compare(differ.diffs[i].Address.Bytes(), differ.diffs[j].Address.Bytes()) < 0
+	})
+	return &TreeDiff{Actors: differ.diffs}, nil
+}
+
+type treeDiffer struct {
+	storeA, storeB adt.Store
+	states         map[cid.Cid]reflect.Type
+	diffs          []ActorDiff
+}
+
+var _ adt.MapDiffer = &treeDiffer{}
+
+func (d *treeDiffer) Add(key string, val *cbg.Deferred) error {
+	a, actor, err := decodeActorEntry(key, val)
+	if err != nil {
+		return err
+	}
+	d.diffs = append(d.diffs, ActorDiff{Address: a, Change: ActorAdded, To: actor})
+	return nil
+}
+
+func (d *treeDiffer) Remove(key string, val *cbg.Deferred) error {
+	a, actor, err := decodeActorEntry(key, val)
+	if err != nil {
+		return err
+	}
+	d.diffs = append(d.diffs, ActorDiff{Address: a, Change: ActorRemoved, From: actor})
+	return nil
+}
+
+func (d *treeDiffer) Modify(key string, from, to *cbg.Deferred) error {
+	a, fromActor, err := decodeActorEntry(key, from)
+	if err != nil {
+		return err
+	}
+	_, toActor, err := decodeActorEntry(key, to)
+	if err != nil {
+		return err
+	}
+	diff := ActorDiff{
+		Address:     a,
+		Change:      ActorModified,
+		From:        fromActor,
+		To:          toActor,
+		ActorFields: diffFields(reflect.ValueOf(fromActor).Elem(), reflect.ValueOf(toActor).Elem()),
+	}
+	if fromActor.Code.Equals(toActor.Code) && !fromActor.Head.Equals(toActor.Head) {
+		if stateType, ok := d.states[fromActor.Code]; ok {
+			fromState := reflect.New(stateType)
+			toState := reflect.New(stateType)
+			if err := d.storeA.Get(d.storeA.Context(), fromActor.Head, fromState.Interface()); err != nil {
+				return xerrors.Errorf("failed to load state of %v in first tree: %w", a, err)
+			}
+			if err := d.storeB.Get(d.storeB.Context(), toActor.Head, toState.Interface()); err != nil {
+				return xerrors.Errorf("failed to load state of %v in second tree: %w", a, err)
+			}
+			diff.StateFields = diffFields(fromState.Elem(), toState.Elem())
+		}
+	}
+	d.diffs = append(d.diffs, diff)
+	return nil
+}
+
+func decodeActorEntry(key string, val *cbg.Deferred) (addr.Address, *Actor, error) {
+	a, err := addr.NewFromBytes([]byte(key))
+	if err != nil {
+		return addr.Undef, nil, err
+	}
+	var actor Actor
+	if err := actor.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+		return addr.Undef, nil, xerrors.Errorf("failed to decode actor %v: %w", a, err)
+	}
+	return a, &actor, nil
+}
+
+// Maps the code CID of each builtin actor to the type of its state.
+func builtinStateTypes() map[cid.Cid]reflect.Type {
+	out := make(map[cid.Cid]reflect.Type)
+	for _, actor := range exported.BuiltinActors() {
+		out[actor.Code()] = reflect.TypeOf(actor.State()).Elem()
+	}
+	return out
+}
+
+// Compares the exported fields of two structs of the same type.
+func diffFields(from, to reflect.Value) []FieldDiff {
+	var out []FieldDiff
+	for i := 0; i < from.NumField(); i++ {
+		field := from.Type().Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		f, t := from.Field(i), to.Field(i)
+		if !fieldsEqual(f, t) {
+			out = append(out, FieldDiff{Name: field.Name, From: f.Interface(), To: t.Interface()})
+		}
+	}
+	return out
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+func fieldsEqual(a, b reflect.Value) bool {
+	if a.Type() == bigIntType {
+		x, y := a.Interface().(big.Int), b.Interface().(big.Int)
+		if x.Int == nil || y.Int == nil {
+			return x.Int == nil && y.Int == nil
+		}
+		return x.Equals(y)
+	}
+	if m, ok := a.Interface().(cbor.Marshaler); ok {
+		if n, ok := b.Interface().(cbor.Marshaler); ok {
+			var bufA, bufB bytes.Buffer
+			if m.MarshalCBOR(&bufA) == nil && n.MarshalCBOR(&bufB) == nil {
+				return bytes.Equal(bufA.Bytes(), bufB.Bytes())
+			}
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// A read-only store which reads each block from the first of its stores that has it.
+type unionStore struct {
+	ctx    context.Context
+	stores []adt.Store
+}
+
+var _ adt.Store = &unionStore{}
+
+func (s *unionStore) Context() context.Context {
+	return s.ctx
+}
+
+func (s *unionStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	var err error
+	for _, store := range s.stores {
+		if err = store.Get(ctx, c, out); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (s *unionStore) Put(_ context.Context, _ interface{}) (cid.Cid, error) {
+	return cid.Undef, xerrors.Errorf("union store is read-only")
+}
@@ -0,0 +1,82 @@
+package states_test
+
+import (
+	"context"
+	"testing"
+
+	address "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+	storeA := ipld.NewADTStore(ctx)
+	storeB := ipld.NewADTStore(ctx)
+
+	putAccount := func(tree *states.Tree, id uint64, pubkey address.Address, balance int64) {
+		head, err := tree.Store.Put(ctx, &account.State{Address: pubkey})
+		require.NoError(t, err)
+		require.NoError(t, tree.SetActor(tutil.NewIDAddr(t, id), &states.Actor{
+			Code:    builtin.AccountActorCodeID,
+			Head:    head,
+			Balance: big.NewInt(balance),
+		}))
+	}
+
+	keyA := tutil.NewBLSAddr(t, 1)
+	keyB := tutil.NewBLSAddr(t, 2)
+
+	treeA, err := states.NewTree(storeA)
+	require.NoError(t, err)
+	putAccount(treeA, 100, keyA, 10)
+	putAccount(treeA, 101, keyA, 10)
+	putAccount(treeA, 103, keyA, 10)
+	rootA, err := treeA.Flush()
+	require.NoError(t, err)
+
+	treeB, err := states.NewTree(storeB)
+	require.NoError(t, err)
+	putAccount(treeB, 100, keyB, 20) // modified state and balance
+	putAccount(treeB, 102, keyA, 10) // added
+	putAccount(treeB, 103, keyA, 10) // unchanged
+	rootB, err := treeB.Flush()
+	require.NoError(t, err)
+
+	diff, err := states.Diff(storeA, rootA, storeB, rootB)
+	require.NoError(t, err)
+	require.Len(t, diff.Actors, 3)
+
+	modified := diff.Actors[0]
+	assert.Equal(t, tutil.NewIDAddr(t, 100), modified.Address)
+	assert.Equal(t, states.ActorModified, modified.Change)
+	require.Len(t, modified.ActorFields, 2)
+	assert.Equal(t, "Head", modified.ActorFields[0].Name)
+	assert.Equal(t, states.FieldDiff{Name: "Balance", From: big.NewInt(10), To: big.NewInt(20)}, modified.ActorFields[1])
+	assert.Equal(t, []states.FieldDiff{{Name: "Address", From: keyA, To: keyB}}, modified.StateFields)
+
+	removed := diff.Actors[1]
+	assert.Equal(t, tutil.NewIDAddr(t, 101), removed.Address)
+	assert.Equal(t, states.ActorRemoved, removed.Change)
+	assert.NotNil(t, removed.From)
+	assert.Nil(t, removed.To)
+
+	added := diff.Actors[2]
+	assert.Equal(t, tutil.NewIDAddr(t, 102), added.Address)
+	assert.Equal(t, states.ActorAdded, added.Change)
+	assert.Nil(t, added.From)
+	assert.NotNil(t, added.To)
+
+	t.Run("identical trees", func(t *testing.T) {
+		diff, err := states.Diff(storeA, rootA, storeA, rootA)
+		require.NoError(t, err)
+		assert.Empty(t, diff.Actors)
+	})
+}
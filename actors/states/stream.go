@@ -0,0 +1,147 @@
+package states
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	"github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Default budget for the raw blocks retained by the streaming state checker.
+const DefaultStreamingCacheBytes = 64 << 20
+
+// Checks the same invariants as CheckStateInvariants, but streams through the state tree rooted at a CID
+// rather than loading it, so that the memory used for blocks is bounded by cacheBytes.
+// Blocks are read from the blockstore and decoded on every access, except for those retained in a
+// least-recently-used cache of at most cacheBytes, so this is slower than CheckStateInvariants.
+// Only the per-actor summaries needed for cross-actor checks are retained for the whole traversal.
+func CheckStateInvariantsStreaming(ctx context.Context, bs ipldcbor.IpldBlockstore, root cid.Cid, expectedBalanceTotal abi.TokenAmount,
+	priorEpoch abi.ChainEpoch, cacheBytes int) (*builtin.MessageAccumulator, error) {
+	store := NewBoundedStore(ctx, bs, cacheBytes)
+	checker := newStateChecker(store, priorEpoch)
+	if err := StreamActors(store, root, checker.checkActor); err != nil {
+		return nil, err
+	}
+	return checker.finish(expectedBalanceTotal), nil
+}
+
+// Traverses all entries in the state tree rooted at a CID, in the same order as Tree.ForEach.
+// Unlike Tree.ForEach, nodes of the tree are not retained after they have been traversed,
+// so memory use is proportional to the depth of the tree rather than its size.
+// The actor passed to fn is only valid for the duration of the call.
+func StreamActors(store adt.Store, root cid.Cid, fn func(key addr.Address, actor *Actor) error) error {
+	return streamNode(store, root, fn)
+}
+
+func streamNode(store adt.Store, c cid.Cid, fn func(key addr.Address, actor *Actor) error) error {
+	var node hamt.Node
+	if err := store.Get(store.Context(), c, &node); err != nil {
+		return xerrors.Errorf("failed to load state tree node %v: %w", c, err)
+	}
+	var actor Actor
+	for _, p := range node.Pointers {
+		if len(p.KVs) == 0 {
+			if err := streamNode(store, p.Link, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, kv := range p.KVs {
+			key, err := addr.NewFromBytes(kv.Key)
+			if err != nil {
+				return err
+			}
+			if err := actor.UnmarshalCBOR(bytes.NewReader(kv.Value.Raw)); err != nil {
+				return xerrors.Errorf("failed to decode actor %v: %w", key, err)
+			}
+			if err := fn(key, &actor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Adapts a block store as a read-only ADT store which retains the most recently used raw blocks,
+// up to a total of cacheBytes, and decodes blocks afresh on every read.
+// Decoded objects are never retained by the store, so the memory it holds is bounded by the cache size.
+func NewBoundedStore(ctx context.Context, bs ipldcbor.IpldBlockstore, cacheBytes int) adt.Store {
+	return &boundedStore{
+		ctx:        ctx,
+		bs:         bs,
+		cacheBytes: cacheBytes,
+		entries:    make(map[cid.Cid]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+type boundedStore struct {
+	ctx        context.Context
+	bs         ipldcbor.IpldBlockstore
+	cacheBytes int
+	size       int
+	entries    map[cid.Cid]*list.Element
+	recent     *list.List // Most recently used at the front.
+}
+
+type boundedStoreEntry struct {
+	c   cid.Cid
+	raw []byte
+}
+
+var _ adt.Store = &boundedStore{}
+
+func (s *boundedStore) Context() context.Context {
+	return s.ctx
+}
+
+func (s *boundedStore) Get(_ context.Context, c cid.Cid, out interface{}) error {
+	um, ok := out.(cbg.CBORUnmarshaler)
+	if !ok {
+		return xerrors.Errorf("cannot decode block %v into %T", c, out)
+	}
+	raw, err := s.load(c)
+	if err != nil {
+		return err
+	}
+	return um.UnmarshalCBOR(bytes.NewReader(raw))
+}
+
+func (s *boundedStore) Put(_ context.Context, _ interface{}) (cid.Cid, error) {
+	return cid.Undef, xerrors.Errorf("bounded store is read-only")
+}
+
+func (s *boundedStore) load(c cid.Cid) ([]byte, error) {
+	if elem, ok := s.entries[c]; ok {
+		s.recent.MoveToFront(elem)
+		return elem.Value.(*boundedStoreEntry).raw, nil
+	}
+	blk, err := s.bs.Get(c)
+	if err != nil {
+		return nil, err
+	}
+	raw := blk.RawData()
+	if len(raw) > s.cacheBytes {
+		return raw, nil
+	}
+	s.entries[c] = s.recent.PushFront(&boundedStoreEntry{c: c, raw: raw})
+	s.size += len(raw)
+	for s.size > s.cacheBytes {
+		oldest := s.recent.Back()
+		entry := oldest.Value.(*boundedStoreEntry)
+		s.recent.Remove(oldest)
+		delete(s.entries, entry.c)
+		s.size -= len(entry.raw)
+	}
+	return raw, nil
+}
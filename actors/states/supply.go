@@ -0,0 +1,154 @@
+package states
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+)
+
+// The components of the token supply held in a state tree.
+// Every token is held in the balance of exactly one actor, so Total is the sum of all balances and
+// Circulating is what remains after excluding unminted, burnt and locked funds.
+type SupplyAudit struct {
+	// Epoch at which multisig vesting was evaluated.
+	Epoch abi.ChainEpoch
+	// Sum of the balances of all actors.
+	Total abi.TokenAmount
+	// Balance of the reward actor, yet to be awarded to miners.
+	Unminted abi.TokenAmount
+	// Total awarded to miners by the reward actor.
+	Mined abi.TokenAmount
+	// Balance of the burnt funds actor.
+	Burnt abi.TokenAmount
+	// Amount of multisig initial balances that has vested by Epoch.
+	Vested abi.TokenAmount
+	// Client and provider collateral and client storage fees locked in the market actor.
+	LockedMarket abi.TokenAmount
+	// Initial pledge, pre-commit deposits and vesting rewards locked in miner actors.
+	LockedMiner abi.TokenAmount
+	// Balances of payment channel actors.
+	LockedPaych abi.TokenAmount
+	// Amount of multisig initial balances yet to vest at Epoch.
+	LockedMultisig abi.TokenAmount
+	// Total less unminted, burnt and locked funds.
+	Circulating abi.TokenAmount
+	// Balance and locked funds of each actor with a non-zero balance, in state tree order.
+	Actors []ActorSupply
+}
+
+// An actor's contribution to the token supply.
+type ActorSupply struct {
+	Address addr.Address
+	Code    cid.Cid
+	Balance abi.TokenAmount
+	// Portion of the balance that is locked (or unminted or burnt), and so excluded from circulation.
+	Locked abi.TokenAmount
+}
+
+// Computes the components of the token supply in a state tree, evaluating multisig vesting at an epoch.
+func AuditSupply(tree *Tree, epoch abi.ChainEpoch) (*SupplyAudit, error) {
+	audit := &SupplyAudit{
+		Epoch:          epoch,
+		Total:          big.Zero(),
+		Unminted:       big.Zero(),
+		Mined:          big.Zero(),
+		Burnt:          big.Zero(),
+		Vested:         big.Zero(),
+		LockedMarket:   big.Zero(),
+		LockedMiner:    big.Zero(),
+		LockedPaych:    big.Zero(),
+		LockedMultisig: big.Zero(),
+	}
+	store := tree.Store
+	if err := tree.ForEach(func(key addr.Address, actor *Actor) error {
+		audit.Total = big.Add(audit.Total, actor.Balance)
+		locked := big.Zero()
+
+		switch {
+		case key == builtin.BurntFundsActorAddr:
+			audit.Burnt = big.Add(audit.Burnt, actor.Balance)
+			locked = actor.Balance
+		case actor.Code == builtin.RewardActorCodeID:
+			var st reward.State
+			if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+				return xerrors.Errorf("failed to load reward state: %w", err)
+			}
+			audit.Unminted = big.Add(audit.Unminted, actor.Balance)
+			audit.Mined = big.Add(audit.Mined, st.TotalStoragePowerReward)
+			locked = actor.Balance
+		case actor.Code == builtin.StorageMarketActorCodeID:
+			var st market.State
+			if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+				return xerrors.Errorf("failed to load market state: %w", err)
+			}
+			locked = big.Min(actor.Balance, big.Sum(st.TotalClientLockedCollateral, st.TotalProviderLockedCollateral, st.TotalClientStorageFee))
+			audit.LockedMarket = big.Add(audit.LockedMarket, locked)
+		case actor.Code == builtin.StorageMinerActorCodeID:
+			var st miner.State
+			if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+				return xerrors.Errorf("failed to load miner %v state: %w", key, err)
+			}
+			locked = big.Min(actor.Balance, big.Sum(st.InitialPledge, st.PreCommitDeposits, st.LockedFunds))
+			audit.LockedMiner = big.Add(audit.LockedMiner, locked)
+		case actor.Code == builtin.PaymentChannelActorCodeID:
+			locked = actor.Balance
+			audit.LockedPaych = big.Add(audit.LockedPaych, locked)
+		case actor.Code == builtin.MultisigActorCodeID:
+			var st multisig.State
+			if err := store.Get(store.Context(), actor.Head, &st); err != nil {
+				return xerrors.Errorf("failed to load multisig %v state: %w", key, err)
+			}
+			vesting := st.AmountLocked(epoch - st.StartEpoch)
+			audit.Vested = big.Add(audit.Vested, big.Sub(st.InitialBalance, vesting))
+			locked = big.Min(actor.Balance, vesting)
+			audit.LockedMultisig = big.Add(audit.LockedMultisig, locked)
+		}
+
+		if !actor.Balance.IsZero() {
+			audit.Actors = append(audit.Actors, ActorSupply{
+				Address: key,
+				Code:    actor.Code,
+				Balance: actor.Balance,
+				Locked:  locked,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	audit.Circulating = big.Sub(audit.Total, big.Sum(audit.Unminted, audit.Burnt, audit.LockedMarket,
+		audit.LockedMiner, audit.LockedPaych, audit.LockedMultisig))
+	return audit, nil
+}
+
+// Checks that the total supply matches an expected total and that the components are consistent.
+func (a *SupplyAudit) Check(expectedTotal abi.TokenAmount) *builtin.MessageAccumulator {
+	acc := &builtin.MessageAccumulator{}
+	checkTotalBalance(acc, a.Total, expectedTotal)
+
+	sum := big.Zero()
+	for _, actor := range a.Actors {
+		acc.Require(actor.Locked.GreaterThanEqual(big.Zero()) && actor.Locked.LessThanEqual(actor.Balance),
+			"actor %v locked %v outside balance %v", actor.Address, actor.Locked, actor.Balance)
+		sum = big.Add(sum, actor.Balance)
+	}
+	acc.Require(sum.Equals(a.Total), "sum of actor balances %v does not match total %v", sum, a.Total)
+	acc.Require(a.Circulating.GreaterThanEqual(big.Zero()), "circulating supply %v is negative", a.Circulating)
+	acc.Require(a.Vested.GreaterThanEqual(big.Zero()), "vested supply %v is negative", a.Vested)
+	return acc
+}
+
+func checkTotalBalance(acc *builtin.MessageAccumulator, total, expected abi.TokenAmount) {
+	if !total.Equals(expected) {
+		acc.Addf("total token balance is %v, expected %v", total, expected)
+	}
+}
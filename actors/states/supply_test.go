@@ -0,0 +1,79 @@
+package states_test
+
+import (
+	"context"
+	"testing"
+
+	address "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cbor "github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestAuditSupply(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+	tree, err := states.NewTree(store)
+	require.NoError(t, err)
+
+	putActor := func(a address.Address, code cid.Cid, state cbor.Marshaler, balance int64) {
+		head, err := store.Put(ctx, state)
+		require.NoError(t, err)
+		require.NoError(t, tree.SetActor(a, &states.Actor{Code: code, Head: head, Balance: big.NewInt(balance)}))
+	}
+
+	rewardState := reward.ConstructState(big.Zero())
+	rewardState.TotalStoragePowerReward = big.NewInt(300)
+	putActor(builtin.RewardActorAddr, builtin.RewardActorCodeID, rewardState, 700)
+	putActor(builtin.BurntFundsActorAddr, builtin.AccountActorCodeID, &account.State{Address: builtin.BurntFundsActorAddr}, 50)
+	putActor(tutil.NewIDAddr(t, 100), builtin.AccountActorCodeID, &account.State{Address: tutil.NewBLSAddr(t, 1)}, 150)
+
+	emptyMap, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	msig := &multisig.State{
+		Signers:               []address.Address{tutil.NewIDAddr(t, 100)},
+		NumApprovalsThreshold: 1,
+		PendingTxns:           emptyMap,
+		PendingTxnMetadata:    emptyMap,
+	}
+	msig.SetLocked(0, 100, big.NewInt(100))
+	putActor(tutil.NewIDAddr(t, 101), builtin.MultisigActorCodeID, msig, 100)
+	// An empty account is not reported in the breakdown.
+	putActor(tutil.NewIDAddr(t, 102), builtin.AccountActorCodeID, &account.State{Address: tutil.NewBLSAddr(t, 2)}, 0)
+
+	audit, err := states.AuditSupply(tree, abi.ChainEpoch(25))
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(1000), audit.Total)
+	assert.Equal(t, big.NewInt(700), audit.Unminted)
+	assert.Equal(t, big.NewInt(300), audit.Mined)
+	assert.Equal(t, big.NewInt(50), audit.Burnt)
+	assert.Equal(t, big.NewInt(25), audit.Vested)
+	assert.Equal(t, big.NewInt(75), audit.LockedMultisig)
+	assert.Equal(t, big.Zero(), audit.LockedMarket)
+	assert.Equal(t, big.Zero(), audit.LockedMiner)
+	assert.Equal(t, big.Zero(), audit.LockedPaych)
+	assert.Equal(t, big.NewInt(175), audit.Circulating) // 150 in the account and 25 vested
+
+	require.Len(t, audit.Actors, 4)
+	for _, actor := range audit.Actors {
+		if actor.Address == tutil.NewIDAddr(t, 101) {
+			assert.Equal(t, big.NewInt(75), actor.Locked)
+		}
+	}
+
+	assert.True(t, audit.Check(big.NewInt(1000)).IsEmpty())
+	assert.Equal(t, []string{"total token balance is 1000, expected 1001"}, audit.Check(big.NewInt(1001)).Messages())
+}
@@ -8,10 +8,12 @@ import (
 	address "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v7/support/ipld"
 )
 
@@ -179,3 +181,49 @@ func TestStateTreeConsistency(t *testing.T) {
 		t.Fatal("MISMATCH!")
 	}
 }
+
+func TestStreamActors(t *testing.T) {
+	ctx := context.Background()
+	bs := ipld.NewBlockStoreInMemory()
+	st, err := states.NewTree(adt.WrapBlockStore(ctx, bs))
+	require.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		a, err := address.NewIDAddress(uint64(i))
+		require.NoError(t, err)
+		err = st.SetActor(a, &states.Actor{
+			Code:       builtin.AccountActorCodeID,
+			Head:       builtin.AccountActorCodeID,
+			CallSeqNum: uint64(i),
+			Balance:    big.NewInt(int64(i)),
+		})
+		require.NoError(t, err)
+	}
+	root, err := st.Flush()
+	require.NoError(t, err)
+
+	var expected []states.Actor
+	err = st.ForEach(func(_ address.Address, actor *states.Actor) error {
+		expected = append(expected, *actor)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A cache smaller than a single node forces every block to be read again.
+	for _, cacheBytes := range []int{0, 1 << 10, states.DefaultStreamingCacheBytes} {
+		var streamed []states.Actor
+		err = states.StreamActors(states.NewBoundedStore(ctx, bs, cacheBytes), root, func(key address.Address, actor *states.Actor) error {
+			assert.Equal(t, actor.CallSeqNum, mustIDFromAddress(t, key))
+			streamed = append(streamed, *actor)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, streamed)
+	}
+}
+
+func mustIDFromAddress(t *testing.T, a address.Address) uint64 {
+	id, err := address.IDFromAddress(a)
+	require.NoError(t, err)
+	return id
+}
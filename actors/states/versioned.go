@@ -0,0 +1,434 @@
+package states
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"golang.org/x/xerrors"
+
+	account0 "github.com/filecoin-project/specs-actors/actors/builtin/account"
+	cron0 "github.com/filecoin-project/specs-actors/actors/builtin/cron"
+	init0 "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	market0 "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	multisig0 "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	paych0 "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	power0 "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	reward0 "github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	system0 "github.com/filecoin-project/specs-actors/actors/builtin/system"
+	verifreg0 "github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
+
+	account2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/account"
+	cron2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/cron"
+	init2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/init"
+	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+	multisig2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/multisig"
+	paych2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/paych"
+	power2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/power"
+	reward2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/reward"
+	system2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/system"
+	verifreg2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/verifreg"
+
+	account3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	cron3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
+	init3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
+	market3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
+	miner3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	multisig3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/multisig"
+	paych3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/paych"
+	power3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+	reward3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
+	system3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/system"
+	verifreg3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
+
+	account4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/account"
+	cron4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/cron"
+	init4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/init"
+	market4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/market"
+	miner4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/miner"
+	multisig4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/multisig"
+	paych4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/paych"
+	power4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/power"
+	reward4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/reward"
+	system4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/system"
+	verifreg4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/verifreg"
+
+	account5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/account"
+	cron5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/cron"
+	init5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/init"
+	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
+	miner5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/miner"
+	multisig5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/multisig"
+	paych5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/paych"
+	power5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/power"
+	reward5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/reward"
+	system5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/system"
+	verifreg5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/verifreg"
+
+	account6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/account"
+	cron6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/cron"
+	init6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/init"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	multisig6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/multisig"
+	paych6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/paych"
+	power6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/power"
+	reward6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/reward"
+	system6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/system"
+	verifreg6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/verifreg"
+
+	account7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	cron7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	init7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	multisig7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	paych7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	power7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	reward7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	system7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/system"
+	verifreg7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// A read-only view of the state of a builtin actor from any supported actors version (v0-v7).
+// Views of the miner, market, power, reward and multisig actors' state also implement the
+// corresponding actor-specific view interface.
+type ActorStateView interface {
+	// The actors version of the actor's code, from 0 to 7.
+	ActorsVersion() int
+	// The actor's code CID.
+	Code() cid.Cid
+	// The version-independent name of the actor type, e.g. "storageminer".
+	ActorType() string
+	// The decoded state, a pointer to the State type of the version-specific actor package.
+	Raw() interface{}
+	// Returns the value of a top-level field of the state, and whether the field exists in this version.
+	Field(name string) (interface{}, bool)
+}
+
+type MinerStateView interface {
+	ActorStateView
+	// Named InitialPledgeRequirement in v0.
+	InitialPledge() abi.TokenAmount
+	PreCommitDeposits() abi.TokenAmount
+	LockedFunds() abi.TokenAmount
+	// Always zero in v0, which predates fee debt.
+	FeeDebt() abi.TokenAmount
+}
+
+type MarketStateView interface {
+	ActorStateView
+	NextDealID() abi.DealID
+	TotalClientLockedCollateral() abi.TokenAmount
+	TotalProviderLockedCollateral() abi.TokenAmount
+	TotalClientStorageFee() abi.TokenAmount
+}
+
+type PowerStateView interface {
+	ActorStateView
+	TotalRawBytePower() abi.StoragePower
+	TotalQualityAdjPower() abi.StoragePower
+	TotalPledgeCollateral() abi.TokenAmount
+	MinerCount() int64
+}
+
+type RewardStateView interface {
+	ActorStateView
+	ThisEpochReward() abi.TokenAmount
+	ThisEpochBaselinePower() abi.StoragePower
+	// Named TotalMined in v0.
+	TotalStoragePowerReward() abi.TokenAmount
+}
+
+type MultisigStateView interface {
+	ActorStateView
+	InitialBalance() abi.TokenAmount
+	StartEpoch() abi.ChainEpoch
+	UnlockDuration() abi.ChainEpoch
+	AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount
+}
+
+// Loads the state of a builtin actor of any supported actors version, as identified by its code CID.
+func LoadActorStateAny(store adt.Store, actor *Actor) (ActorStateView, error) {
+	vs, ok := versionedStates[actor.Code]
+	if !ok {
+		return nil, xerrors.Errorf("unsupported actor code %v", actor.Code)
+	}
+	st := reflect.New(vs.stateType)
+	if err := store.Get(store.Context(), actor.Head, st.Interface()); err != nil {
+		return nil, xerrors.Errorf("failed to load v%d %s state %v: %w", vs.version, vs.name, actor.Head, err)
+	}
+	view := stateView{version: vs.version, code: actor.Code, name: vs.name, raw: st}
+	switch vs.name {
+	case "storageminer":
+		return &minerStateView{view}, nil
+	case "storagemarket":
+		return &marketStateView{view}, nil
+	case "storagepower":
+		return &powerStateView{view}, nil
+	case "reward":
+		return &rewardStateView{view}, nil
+	case "multisig":
+		return &multisigStateView{view}, nil
+	}
+	return &view, nil
+}
+
+// The actors version, type name and state type of a builtin actor code.
+type versionedState struct {
+	version   int
+	name      string
+	stateType reflect.Type
+}
+
+var versionedStates = buildVersionedStates()
+
+func buildVersionedStates() map[cid.Cid]versionedState {
+	builder := cid.V1Builder{Codec: cid.Raw, MhType: mh.IDENTITY}
+	out := make(map[cid.Cid]versionedState)
+	for _, vs := range []struct {
+		version int
+		name    string
+		state   interface{}
+	}{
+		{0, "system", system0.State{}},
+		{0, "init", init0.State{}},
+		{0, "cron", cron0.State{}},
+		{0, "account", account0.State{}},
+		{0, "storagepower", power0.State{}},
+		{0, "storageminer", miner0.State{}},
+		{0, "storagemarket", market0.State{}},
+		{0, "paymentchannel", paych0.State{}},
+		{0, "multisig", multisig0.State{}},
+		{0, "reward", reward0.State{}},
+		{0, "verifiedregistry", verifreg0.State{}},
+
+		{2, "system", system2.State{}},
+		{2, "init", init2.State{}},
+		{2, "cron", cron2.State{}},
+		{2, "account", account2.State{}},
+		{2, "storagepower", power2.State{}},
+		{2, "storageminer", miner2.State{}},
+		{2, "storagemarket", market2.State{}},
+		{2, "paymentchannel", paych2.State{}},
+		{2, "multisig", multisig2.State{}},
+		{2, "reward", reward2.State{}},
+		{2, "verifiedregistry", verifreg2.State{}},
+
+		{3, "system", system3.State{}},
+		{3, "init", init3.State{}},
+		{3, "cron", cron3.State{}},
+		{3, "account", account3.State{}},
+		{3, "storagepower", power3.State{}},
+		{3, "storageminer", miner3.State{}},
+		{3, "storagemarket", market3.State{}},
+		{3, "paymentchannel", paych3.State{}},
+		{3, "multisig", multisig3.State{}},
+		{3, "reward", reward3.State{}},
+		{3, "verifiedregistry", verifreg3.State{}},
+
+		{4, "system", system4.State{}},
+		{4, "init", init4.State{}},
+		{4, "cron", cron4.State{}},
+		{4, "account", account4.State{}},
+		{4, "storagepower", power4.State{}},
+		{4, "storageminer", miner4.State{}},
+		{4, "storagemarket", market4.State{}},
+		{4, "paymentchannel", paych4.State{}},
+		{4, "multisig", multisig4.State{}},
+		{4, "reward", reward4.State{}},
+		{4, "verifiedregistry", verifreg4.State{}},
+
+		{5, "system", system5.State{}},
+		{5, "init", init5.State{}},
+		{5, "cron", cron5.State{}},
+		{5, "account", account5.State{}},
+		{5, "storagepower", power5.State{}},
+		{5, "storageminer", miner5.State{}},
+		{5, "storagemarket", market5.State{}},
+		{5, "paymentchannel", paych5.State{}},
+		{5, "multisig", multisig5.State{}},
+		{5, "reward", reward5.State{}},
+		{5, "verifiedregistry", verifreg5.State{}},
+
+		{6, "system", system6.State{}},
+		{6, "init", init6.State{}},
+		{6, "cron", cron6.State{}},
+		{6, "account", account6.State{}},
+		{6, "storagepower", power6.State{}},
+		{6, "storageminer", miner6.State{}},
+		{6, "storagemarket", market6.State{}},
+		{6, "paymentchannel", paych6.State{}},
+		{6, "multisig", multisig6.State{}},
+		{6, "reward", reward6.State{}},
+		{6, "verifiedregistry", verifreg6.State{}},
+
+		{7, "system", system7.State{}},
+		{7, "init", init7.State{}},
+		{7, "cron", cron7.State{}},
+		{7, "account", account7.State{}},
+		{7, "storagepower", power7.State{}},
+		{7, "storageminer", miner7.State{}},
+		{7, "storagemarket", market7.State{}},
+		{7, "paymentchannel", paych7.State{}},
+		{7, "multisig", multisig7.State{}},
+		{7, "reward", reward7.State{}},
+		{7, "verifiedregistry", verifreg7.State{}},
+	} {
+		// Actors v0 codes are named for version 1.
+		codeVersion := vs.version
+		if codeVersion == 0 {
+			codeVersion = 1
+		}
+		c, err := builder.Sum([]byte(fmt.Sprintf("fil/%d/%s", codeVersion, vs.name)))
+		if err != nil {
+			panic(err)
+		}
+		out[c] = versionedState{version: vs.version, name: vs.name, stateType: reflect.TypeOf(vs.state)}
+	}
+	return out
+}
+
+type stateView struct {
+	version int
+	code    cid.Cid
+	name    string
+	raw     reflect.Value // Pointer to the state.
+}
+
+func (v *stateView) ActorsVersion() int {
+	return v.version
+}
+
+func (v *stateView) Code() cid.Cid {
+	return v.code
+}
+
+func (v *stateView) ActorType() string {
+	return v.name
+}
+
+func (v *stateView) Raw() interface{} {
+	return v.raw.Interface()
+}
+
+func (v *stateView) Field(name string) (interface{}, bool) {
+	f := v.raw.Elem().FieldByName(name)
+	if !f.IsValid() {
+		return nil, false
+	}
+	return f.Interface(), true
+}
+
+// Returns the value of the first of the named fields that exists, or zero if none does.
+func (v *stateView) tokenField(names ...string) big.Int {
+	for _, name := range names {
+		if f, ok := v.Field(name); ok {
+			if n, ok := f.(big.Int); ok && n.Int != nil {
+				return n
+			}
+			return big.Zero()
+		}
+	}
+	return big.Zero()
+}
+
+type minerStateView struct{ stateView }
+
+func (v *minerStateView) InitialPledge() abi.TokenAmount {
+	return v.tokenField("InitialPledge", "InitialPledgeRequirement")
+}
+
+func (v *minerStateView) PreCommitDeposits() abi.TokenAmount {
+	return v.tokenField("PreCommitDeposits")
+}
+
+func (v *minerStateView) LockedFunds() abi.TokenAmount {
+	return v.tokenField("LockedFunds")
+}
+
+func (v *minerStateView) FeeDebt() abi.TokenAmount {
+	return v.tokenField("FeeDebt")
+}
+
+type marketStateView struct{ stateView }
+
+func (v *marketStateView) NextDealID() abi.DealID {
+	id, _ := v.Field("NextID")
+	return id.(abi.DealID)
+}
+
+func (v *marketStateView) TotalClientLockedCollateral() abi.TokenAmount {
+	return v.tokenField("TotalClientLockedCollateral")
+}
+
+func (v *marketStateView) TotalProviderLockedCollateral() abi.TokenAmount {
+	return v.tokenField("TotalProviderLockedCollateral")
+}
+
+func (v *marketStateView) TotalClientStorageFee() abi.TokenAmount {
+	return v.tokenField("TotalClientStorageFee")
+}
+
+type powerStateView struct{ stateView }
+
+func (v *powerStateView) TotalRawBytePower() abi.StoragePower {
+	return v.tokenField("TotalRawBytePower")
+}
+
+func (v *powerStateView) TotalQualityAdjPower() abi.StoragePower {
+	return v.tokenField("TotalQualityAdjPower")
+}
+
+func (v *powerStateView) TotalPledgeCollateral() abi.TokenAmount {
+	return v.tokenField("TotalPledgeCollateral")
+}
+
+func (v *powerStateView) MinerCount() int64 {
+	count, _ := v.Field("MinerCount")
+	return count.(int64)
+}
+
+type rewardStateView struct{ stateView }
+
+func (v *rewardStateView) ThisEpochReward() abi.TokenAmount {
+	return v.tokenField("ThisEpochReward")
+}
+
+func (v *rewardStateView) ThisEpochBaselinePower() abi.StoragePower {
+	return v.tokenField("ThisEpochBaselinePower")
+}
+
+func (v *rewardStateView) TotalStoragePowerReward() abi.TokenAmount {
+	return v.tokenField("TotalStoragePowerReward", "TotalMined")
+}
+
+type multisigStateView struct{ stateView }
+
+func (v *multisigStateView) InitialBalance() abi.TokenAmount {
+	return v.tokenField("InitialBalance")
+}
+
+func (v *multisigStateView) StartEpoch() abi.ChainEpoch {
+	epoch, _ := v.Field("StartEpoch")
+	return epoch.(abi.ChainEpoch)
+}
+
+func (v *multisigStateView) UnlockDuration() abi.ChainEpoch {
+	duration, _ := v.Field("UnlockDuration")
+	return duration.(abi.ChainEpoch)
+}
+
+func (v *multisigStateView) AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
+	// The multisig state of every version implements this with the same signature.
+	return v.Raw().(interface {
+		AmountLocked(abi.ChainEpoch) abi.TokenAmount
+	}).AmountLocked(elapsedEpoch)
+}
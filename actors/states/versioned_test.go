@@ -0,0 +1,91 @@
+package states_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin0 "github.com/filecoin-project/specs-actors/actors/builtin"
+	reward0 "github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+func TestLoadActorStateAny(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+
+	putActor := func(code cid.Cid, state interface{}) *states.Actor {
+		head, err := store.Put(ctx, state)
+		require.NoError(t, err)
+		return &states.Actor{Code: code, Head: head, Balance: big.Zero()}
+	}
+
+	t.Run("current version", func(t *testing.T) {
+		st := reward.ConstructState(big.NewInt(1 << 40))
+		st.TotalStoragePowerReward = big.NewInt(1234)
+		view, err := states.LoadActorStateAny(store, putActor(builtin.RewardActorCodeID, st))
+		require.NoError(t, err)
+
+		assert.Equal(t, 7, view.ActorsVersion())
+		assert.Equal(t, "reward", view.ActorType())
+		assert.Equal(t, builtin.RewardActorCodeID, view.Code())
+		assert.IsType(t, &reward.State{}, view.Raw())
+
+		rewardView, ok := view.(states.RewardStateView)
+		require.True(t, ok)
+		assert.Equal(t, big.NewInt(1234), rewardView.TotalStoragePowerReward())
+		assert.Equal(t, st.ThisEpochReward, rewardView.ThisEpochReward())
+
+		_, ok = view.(states.MinerStateView)
+		assert.False(t, ok)
+	})
+
+	t.Run("v0 renamed field", func(t *testing.T) {
+		st := reward0.ConstructState(big.NewInt(1 << 40))
+		st.TotalMined = big.NewInt(99)
+		view, err := states.LoadActorStateAny(store, putActor(builtin0.RewardActorCodeID, st))
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, view.ActorsVersion())
+		assert.IsType(t, &reward0.State{}, view.Raw())
+		assert.Equal(t, big.NewInt(99), view.(states.RewardStateView).TotalStoragePowerReward())
+
+		_, ok := view.Field("TotalStoragePowerReward")
+		assert.False(t, ok)
+		mined, ok := view.Field("TotalMined")
+		assert.True(t, ok)
+		assert.Equal(t, big.NewInt(99), mined)
+	})
+
+	t.Run("multisig vesting", func(t *testing.T) {
+		emptyMap, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		st := &multisig.State{PendingTxns: emptyMap, PendingTxnMetadata: emptyMap}
+		st.SetLocked(10, 100, big.NewInt(1000))
+		view, err := states.LoadActorStateAny(store, putActor(builtin.MultisigActorCodeID, st))
+		require.NoError(t, err)
+
+		msigView := view.(states.MultisigStateView)
+		assert.Equal(t, abi.ChainEpoch(10), msigView.StartEpoch())
+		assert.Equal(t, abi.ChainEpoch(100), msigView.UnlockDuration())
+		assert.Equal(t, big.NewInt(1000), msigView.InitialBalance())
+		assert.Equal(t, big.NewInt(750), msigView.AmountLocked(25))
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		code, err := builtin.AccountActorCodeID.Prefix().Sum([]byte("fil/7/other"))
+		require.NoError(t, err)
+		_, err = states.LoadActorStateAny(store, &states.Actor{Code: code})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestBatchHelpers(t *testing.T) {
+	ctx := context.Background()
+	balance := big.Mul(big.NewInt(10_000), vm.FIL)
+	sealProof := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	v, actors := vm.NewVMWithGenesis(ctx, t, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: balance}, {Balance: balance}, {Balance: balance}},
+		Seed:     93837778,
+		Miners: []vm.GenesisMiner{{
+			SealProof: sealProof,
+			Balance:   big.Mul(big.NewInt(1_000), vm.FIL),
+		}},
+	})
+	worker, clients := actors.Accounts[0], actors.Accounts[1:]
+	minerAddr := actors.Miners[0].IDAddress
+	v, err := v.WithEpoch(200)
+	require.NoError(t, err)
+
+	// Publish deals across both clients.
+	for _, client := range clients {
+		vm.ApplyOk(t, v, client, builtin.StorageMarketActorAddr, big.Mul(big.NewInt(10), vm.FIL), builtin.MethodsMarket.AddBalance, &client)
+	}
+	vm.ApplyOk(t, v, worker, builtin.StorageMarketActorAddr, big.Mul(big.NewInt(100), vm.FIL), builtin.MethodsMarket.AddBalance, &minerAddr)
+	dealStart := v.GetEpoch() + miner.MaxProveCommitDuration[sealProof]
+	dealIDs := vm.PublishDeals(t, v, worker, minerAddr, clients, 4, market.DealProposal{
+		PieceSize:            32 << 30,
+		Label:                "batch",
+		StartEpoch:           dealStart,
+		EndEpoch:             dealStart + 180*builtin.EpochsInDay,
+		StoragePricePerEpoch: abi.NewTokenAmount(1 << 20),
+		ProviderCollateral:   big.Mul(big.NewInt(2), vm.FIL),
+		ClientCollateral:     big.Mul(big.NewInt(1), vm.FIL),
+	})
+	require.Len(t, dealIDs, 4)
+	var marketState market.State
+	require.NoError(t, v.GetState(builtin.StorageMarketActorAddr, &marketState))
+	proposals, err := market.AsDealProposalArray(v.Store(), marketState.Proposals)
+	require.NoError(t, err)
+	clientOf := make(map[address.Address]int)
+	for _, dealID := range dealIDs {
+		proposal, found, err := proposals.Get(dealID)
+		require.NoError(t, err)
+		require.True(t, found)
+		clientOf[proposal.Client]++
+	}
+	assert.Len(t, clientOf, 2)
+
+	// Onboard sectors holding the deals with an aggregate proof, and a few more with individual proofs.
+	expiration := v.GetEpoch() + miner.MaxSectorExpirationExtension
+	dealsPerSector := make([][]abi.DealID, len(dealIDs))
+	for i, dealID := range dealIDs {
+		dealsPerSector[i] = []abi.DealID{dealID}
+	}
+	v, aggregated := vm.OnboardSectors(t, v, worker, minerAddr, sealProof, 100, 6, expiration, dealsPerSector)
+	v, individual := vm.OnboardSectors(t, v, worker, minerAddr, sealProof, 200, 2, expiration, nil)
+	sectorNumbers := append(aggregated, individual...)
+	for _, sectorNumber := range sectorNumbers {
+		assert.Equal(t, expiration, vm.SectorInfo(t, v, minerAddr, sectorNumber).Expiration)
+	}
+	for _, dealID := range dealIDs {
+		state, found := vm.GetDealState(t, v, dealID)
+		require.True(t, found)
+		assert.NotEqual(t, abi.ChainEpoch(-1), state.SectorStartEpoch)
+	}
+	assert.Equal(t, big.Zero(), vm.MinerPower(t, v, minerAddr).Raw)
+
+	// Proving every deadline activates the power of all sectors.
+	startDeadline := vm.MinerDLInfo(t, v, minerAddr)
+	v = vm.SubmitProvingPeriodPoSts(t, v, minerAddr, worker)
+	assert.Equal(t, startDeadline.Index, vm.MinerDLInfo(t, v, minerAddr).Index)
+	assert.True(t, v.GetEpoch() >= startDeadline.Close)
+	sectorSize, err := sealProof.SectorSize()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(int64(len(sectorNumbers))*int64(sectorSize)), vm.MinerPower(t, v, minerAddr).Raw)
+
+	// Trigger cron to keep reward accounting correct
+	vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+
+	stateTree, err := v.GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := v.GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, v.GetEpoch())
+	require.NoError(t, err)
+	assert.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
@@ -547,6 +547,24 @@ func TestBatchOnboarding(t *testing.T) {
 	acc, err := states.CheckStateInvariants(stateTree, totalBalance, v.GetEpoch())
 	require.NoError(t, err)
 	assert.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+
+	// Streaming check with a cache too small to hold the state tree finds the same (lack of) errors.
+	streamAcc, err := states.CheckStateInvariantsStreaming(ctx, blkStore, v.StateRoot(), totalBalance, v.GetEpoch(), 16<<10)
+	require.NoError(t, err)
+	assert.True(t, streamAcc.IsEmpty(), strings.Join(streamAcc.Messages(), "\n"))
+
+	// Parallel check finds the same (lack of) errors.
+	parAcc, err := states.CheckStateInvariantsWithOptions(stateTree, totalBalance, v.GetEpoch(), states.CheckOptions{Workers: 4})
+	require.NoError(t, err)
+	assert.True(t, parAcc.IsEmpty(), strings.Join(parAcc.Messages(), "\n"))
+
+	// Check of just the miner and power actors cross-checks the miner's claim.
+	selectedAcc, err := states.CheckStateInvariantsWithOptions(stateTree, totalBalance, v.GetEpoch(), states.CheckOptions{
+		Workers:   2,
+		Addresses: []address.Address{minerAddrs.IDAddress, builtin.StoragePowerActorAddr},
+	})
+	require.NoError(t, err)
+	assert.True(t, selectedAcc.IsEmpty(), strings.Join(selectedAcc.Messages(), "\n"))
 }
 
 func TestAggregateOnePreCommitExpires(t *testing.T) {
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestCronEntriesManagedByGovernance(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	cheap, costly := addrs[0], addrs[1]
+
+	// The governance actor exists from genesis.
+	_, found, err := v.GetActor(builtin.GovernanceActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// Only governance may add entries.
+	vm.ApplyCode(t, v, cheap, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.AddEntry,
+		&cron.AddEntryParams{Receiver: cheap, MethodNum: builtin.MethodsAccount.PubkeyAddress}, exitcode.SysErrForbidden)
+
+	vm.ApplyOk(t, v, builtin.GovernanceActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.AddEntry,
+		&cron.AddEntryParams{Receiver: cheap, MethodNum: builtin.MethodsAccount.PubkeyAddress, GasBudget: 1_000_000})
+	vm.ApplyOk(t, v, builtin.GovernanceActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.AddEntry,
+		&cron.AddEntryParams{Receiver: costly, MethodNum: builtin.MethodsAccount.PubkeyAddress, GasBudget: 1})
+
+	vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+
+	// The VM enforces the gas budgets, flagging the entry which exhausted its budget.
+	var st cron.State
+	require.NoError(t, v.GetState(builtin.CronActorAddr, &st))
+	report, found, err := st.GetTickReport(v.Store(), v.GetEpoch())
+	require.NoError(t, err)
+	require.True(t, found)
+	codes := map[string]exitcode.ExitCode{}
+	for _, r := range report.Results {
+		codes[r.Receiver.String()] = r.ExitCode
+	}
+	cheapID, _ := v.NormalizeAddress(cheap)
+	costlyID, _ := v.NormalizeAddress(costly)
+	assert.Equal(t, exitcode.Ok, codes[cheapID.String()])
+	assert.Equal(t, exitcode.SysErrOutOfGas, codes[costlyID.String()])
+	for _, e := range st.Entries {
+		assert.Equal(t, e.Receiver == costlyID, e.Flagged, "entry for %v", e.Receiver)
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestStateExportOnEpochAdvance(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	recorder := &vm.StateRecorder{Dir: t.TempDir(), CARs: true}
+	v.SetStateExporter(recorder)
+	start := v.GetEpoch()
+
+	// Transfer in each of several epochs, so each epoch ends with a distinct state.
+	for i := 0; i < 4; i++ {
+		vm.ApplyOk(t, v, addrs[0], addrs[1], builtin.TokenPrecision, builtin.MethodSend, nil)
+		v = vm.AdvanceOneEpochWithCron(t, v)
+	}
+	// Deriving a VM at the same epoch doesn't export.
+	_, err := v.WithEpoch(v.GetEpoch())
+	require.NoError(t, err)
+
+	require.Len(t, recorder.States, 4)
+	for i, s := range recorder.States {
+		assert.Equal(t, start+abi.ChainEpoch(i), s.Epoch)
+	}
+	roots, err := os.ReadFile(filepath.Join(recorder.Dir, "roots.txt"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(roots)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, fmt.Sprintf("%d %s", start+3, recorder.States[3].Root), lines[3])
+
+	// Each exported CAR holds the complete state at the end of its epoch.
+	f, err := os.Open(recorder.CARPath(start + 2))
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+	bs := ipld.NewBlockStoreInMemory()
+	root, err := states.ImportCAR(bs, f)
+	require.NoError(t, err)
+	assert.Equal(t, recorder.States[2].Root, root)
+	recipient := vm.RequireNormalizeAddress(t, addrs[1], v)
+	imported, err := states.LoadTree(adt.WrapBlockStore(ctx, bs), root)
+	require.NoError(t, err)
+	original, err := states.LoadTree(v.Store(), root)
+	require.NoError(t, err)
+	importedActor, found, err := imported.GetActor(recipient)
+	require.NoError(t, err)
+	require.True(t, found)
+	originalActor, _, err := original.GetActor(recipient)
+	require.NoError(t, err)
+	assert.Equal(t, originalActor, importedActor)
+
+	// Bisect for the first epoch to end with the recipient holding all but the last transfer.
+	final, _, err := v.GetActor(addrs[1])
+	require.NoError(t, err)
+	threshold := big.Sub(final.Balance, builtin.TokenPrecision)
+	first, found, err := recorder.FirstWhere(func(s vm.EpochState) (bool, error) {
+		tree, err := states.LoadTree(v.Store(), s.Root)
+		if err != nil {
+			return false, err
+		}
+		actor, _, err := tree.GetActor(recipient)
+		if err != nil {
+			return false, err
+		}
+		return actor.Balance.GreaterThanEqual(threshold), nil
+	})
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, start+2, first.Epoch)
+}
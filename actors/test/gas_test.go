@@ -0,0 +1,60 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestGasMetering(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), builtin.TokenPrecision), 93837778)
+	transferCost := vm.GasScheduleV13.SendBase + vm.GasScheduleV13.SendTransferFunds + vm.GasScheduleV13.SendTransferOnlyPremium
+
+	t.Run("charges sum to message gas", func(t *testing.T) {
+		result := vm.RequireApplyMessage(t, v, addrs[0], addrs[1], big.NewInt(1), builtin.MethodSend, nil, t.Name())
+		require.Equal(t, exitcode.Ok, result.Code)
+
+		total := int64(0)
+		for _, charge := range v.GasCharges() {
+			total += charge.Total()
+		}
+		assert.Equal(t, result.GasCharged, total)
+		assert.Equal(t, "OnChainMessage", v.GasCharges()[0].Name)
+		assert.Equal(t, transferCost, v.GasChargedByName()["OnMethodInvocation"])
+		vm.ExpectGasBetween(t, v, result, transferCost, transferCost+1_000_000)
+	})
+
+	t.Run("custom schedule", func(t *testing.T) {
+		schedule := vm.GasScheduleV13
+		schedule.SendTransferOnlyPremium *= 2
+		v.SetGasSchedule(&schedule)
+		defer v.SetGasSchedule(&vm.GasScheduleV13)
+
+		result := vm.RequireApplyMessage(t, v, addrs[0], addrs[1], big.NewInt(1), builtin.MethodSend, nil, t.Name())
+		require.Equal(t, exitcode.Ok, result.Code)
+		assert.Equal(t, transferCost+vm.GasScheduleV13.SendTransferOnlyPremium, v.GasChargedByName()["OnMethodInvocation"])
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		defer v.SetGasLimit(v.GetGasLimit())
+		v.SetGasLimit(vm.GasScheduleV13.SendBase)
+
+		before, _, err := v.GetActor(addrs[1])
+		require.NoError(t, err)
+		result := vm.RequireApplyMessage(t, v, addrs[0], addrs[1], big.NewInt(1), builtin.MethodSend, nil, t.Name())
+		assert.Equal(t, exitcode.SysErrOutOfGas, result.Code)
+		after, _, err := v.GetActor(addrs[1])
+		require.NoError(t, err)
+		assert.Equal(t, before.Balance, after.Balance)
+	})
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestGenesis(t *testing.T) {
+	ctx := context.Background()
+	balance := big.Mul(big.NewInt(10_000), vm.FIL)
+	vesting := big.Mul(big.NewInt(1_000), vm.FIL)
+	sealProof := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	v, actors := vm.NewVMWithGenesis(ctx, t, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: balance}, {Balance: balance}, {Balance: balance}},
+		Seed:     93837778,
+		Multisigs: []vm.GenesisMultisig{{
+			Signers:        []int{0, 1},
+			Threshold:      2,
+			Balance:        vesting,
+			UnlockDuration: 1000,
+		}},
+		VerifregRoot: &vm.GenesisMultisig{Signers: []int{1, 2}, Threshold: 1},
+		Miners: []vm.GenesisMiner{{
+			Owner:     2,
+			SealProof: sealProof,
+			Balance:   big.Mul(big.NewInt(1_000), vm.FIL),
+			Sectors:   2,
+		}},
+	})
+	require.Len(t, actors.Accounts, 3)
+	require.Len(t, actors.Multisigs, 1)
+	require.Len(t, actors.Miners, 1)
+	// The VM starts at the epoch after genesis.
+	assert.Equal(t, abi.ChainEpoch(1), v.GetEpoch())
+
+	// The multisig vests its balance from genesis.
+	var msState multisig.State
+	require.NoError(t, v.GetState(actors.Multisigs[0], &msState))
+	assert.Equal(t, vesting, msState.InitialBalance)
+	assert.Equal(t, abi.ChainEpoch(0), msState.StartEpoch)
+	assert.Equal(t, abi.ChainEpoch(1000), msState.UnlockDuration)
+	assert.Equal(t, vesting, msState.AmountLocked(0))
+
+	var vrState verifreg.State
+	require.NoError(t, v.GetState(builtin.VerifiedRegistryActorAddr, &vrState))
+	assert.Equal(t, actors.VerifregRoot, vrState.RootKey)
+
+	// The pre-sealed sectors are committed, and gain power when first proven.
+	minerAddr := actors.Miners[0].IDAddress
+	for _, sectorNumber := range []abi.SectorNumber{0, 1} {
+		sector := vm.SectorInfo(t, v, minerAddr, sectorNumber)
+		assert.Equal(t, sealProof, sector.SealProof)
+	}
+	balances := vm.GetMinerBalances(t, v, minerAddr)
+	assert.True(t, balances.InitialPledge.GreaterThan(big.Zero()))
+	assert.Equal(t, big.Zero(), balances.PreCommitDeposit)
+
+	// The genesis state satisfies the invariants of the end of the genesis epoch.
+	stateTree, err := v.GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := v.GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, v.GetEpoch()-1)
+	require.NoError(t, err)
+	assert.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+
+	dlInfo, pIdx, v := vm.AdvanceTillProvingDeadline(t, v, minerAddr, 0)
+	vm.SubmitPoSt(t, v, minerAddr, actors.Accounts[2], dlInfo, pIdx)
+	sectorSize, err := sealProof.SectorSize()
+	require.NoError(t, err)
+	assert.True(t, vm.MinerPower(t, v, minerAddr).Raw.GreaterThanEqual(big.NewInt(int64(sectorSize))))
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestPredictExecAddresses(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+	creator := addrs[0]
+
+	multisigParams := multisig.ConstructorParams{
+		Signers:               addrs,
+		NumApprovalsThreshold: 1,
+	}
+	paramBuf := new(bytes.Buffer)
+	require.NoError(t, multisigParams.MarshalCBOR(paramBuf))
+	execParams := init_.ExecParams{
+		CodeCID:           builtin.MultisigActorCodeID,
+		ConstructorParams: paramBuf.Bytes(),
+	}
+
+	for i := 0; i < 2; i++ {
+		var st init_.State
+		require.NoError(t, v.GetState(builtin.InitActorAddr, &st))
+		creatorActor, found, err := v.GetActor(creator)
+		require.NoError(t, err)
+		require.True(t, found)
+
+		predicted, err := init_.PredictExecAddresses(&st, creator, creatorActor.CallSeqNum, 0)
+		require.NoError(t, err)
+
+		ret := vm.ApplyOk(t, v, creator, builtin.InitActorAddr, big.Zero(), builtin.MethodsInit.Exec, &execParams)
+		assert.Equal(t, predicted, ret.(*init_.ExecReturn))
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner/onboarding"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+// Checks the onboarding timeline against the epochs at which the miner actor accepts and rejects each transition.
+func TestOnboardingTimelineMatchesActor(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	sealProof := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	wPoStProof, err := sealProof.RegisteredWindowPoStProof()
+	require.NoError(t, err)
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	worker := addrs[0]
+	minerAddrs := createMiner(t, v, worker, worker, wPoStProof, big.Mul(big.NewInt(10_000), vm.FIL))
+	minerAddr := minerAddrs.IDAddress
+
+	v, err = v.WithEpoch(200)
+	require.NoError(t, err)
+	preCommitEpoch := v.GetEpoch()
+	tl, err := onboarding.NewTimeline(sealProof, preCommitEpoch)
+	require.NoError(t, err)
+	minExpiration, maxExpiration, err := onboarding.ExpirationBounds(sealProof, preCommitEpoch)
+	require.NoError(t, err)
+
+	preCommit := func(sectorNumber abi.SectorNumber, expiration abi.ChainEpoch, code exitcode.ExitCode) {
+		params := miner.PreCommitSectorBatchParams{Sectors: []miner0.SectorPreCommitInfo{{
+			SealProof:     sealProof,
+			SectorNumber:  sectorNumber,
+			SealedCID:     tutil.MakeCID("onboarding", &miner.SealedCIDPrefix),
+			SealRandEpoch: preCommitEpoch - 1,
+			Expiration:    expiration,
+		}}}
+		vm.ApplyCode(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.PreCommitSectorBatch, &params, code)
+	}
+	preCommit(100, minExpiration-1, exitcode.ErrIllegalArgument)
+	preCommit(100, maxExpiration+1, exitcode.ErrIllegalArgument)
+	preCommit(100, minExpiration, exitcode.Ok)
+	preCommit(101, maxExpiration, exitcode.Ok)
+
+	proveCommit := func(sectorNumber abi.SectorNumber, code exitcode.ExitCode) {
+		params := miner.ProveCommitSectorParams{SectorNumber: sectorNumber}
+		vm.ApplyCode(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.ProveCommitSector, &params, code)
+	}
+
+	// The actor refuses a proof until the epoch after the seed epoch.
+	v, _ = vm.AdvanceByDeadlineTillEpoch(t, v, minerAddr, tl.SeedEpoch)
+	v, err = v.WithEpoch(tl.SeedEpoch)
+	require.NoError(t, err)
+	proveCommit(100, exitcode.ErrForbidden)
+
+	v, err = v.WithEpoch(tl.ProveCommitOpen)
+	require.NoError(t, err)
+	proveCommit(100, exitcode.Ok)
+
+	// Cron at the end of the epoch verifies the proof and activates the sector.
+	v = vm.AdvanceOneEpochWithCron(t, v)
+	assert.Equal(t, minExpiration, vm.SectorInfo(t, v, minerAddr, 100).Expiration)
+
+	// The actor refuses a proof after the due epoch.
+	v, _ = vm.AdvanceByDeadlineTillEpoch(t, v, minerAddr, tl.ProveCommitDue+1)
+	v, err = v.WithEpoch(tl.ProveCommitDue + 1)
+	require.NoError(t, err)
+	require.True(t, v.GetEpoch() < tl.CleanUp)
+	proveCommit(101, exitcode.ErrIllegalArgument)
+}
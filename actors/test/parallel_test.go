@@ -0,0 +1,58 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestRunParallel(t *testing.T) {
+	ctx := context.Background()
+	initial := big.Mul(big.NewInt(10_000), vm.FIL)
+	base, actors := vm.NewVMWithGenesis(ctx, t, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: initial}, {Balance: initial}},
+		Seed:     93837778,
+	})
+	from, to := actors.Accounts[0], actors.Accounts[1]
+	base, err := base.WithEpoch(10)
+	require.NoError(t, err)
+	balance := func(v *vm.VM) abi.TokenAmount {
+		act, found, err := v.GetActor(to)
+		require.NoError(t, err)
+		require.True(t, found)
+		return act.Balance
+	}
+
+	// Each scenario sees only its own transfers, on a VM at the base epoch with statistics of its own.
+	var scenarios []vm.Scenario
+	for i := 1; i <= 4; i++ {
+		amount := big.Mul(big.NewInt(int64(i)), vm.FIL)
+		scenarios = append(scenarios, vm.Scenario{
+			Name: fmt.Sprintf("transfer %d", i),
+			Run: func(t *testing.T, v *vm.VM) {
+				assert.Equal(t, abi.ChainEpoch(10), v.GetEpoch())
+				for j := 0; j < 3; j++ {
+					vm.ApplyOk(t, v, from, to, amount, builtin.MethodSend, nil)
+					v = vm.AdvanceOneEpochWithCron(t, v)
+				}
+				assert.Equal(t, big.Add(initial, big.Mul(big.NewInt(3), amount)), balance(v))
+				assert.True(t, v.StoreWrites() > 0)
+			},
+		})
+	}
+	vm.RunParallel(t, base, scenarios...)
+
+	// The base VM is unaffected, and usable once the scenarios complete.
+	assert.Equal(t, initial, balance(base))
+	vm.ApplyOk(t, base, from, to, vm.FIL, builtin.MethodSend, nil)
+	assert.Equal(t, big.Add(initial, vm.FIL), balance(base))
+}
@@ -2,15 +2,18 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v7/support/ipld"
 	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
 	"github.com/filecoin-project/specs-actors/v7/support/vm"
@@ -59,6 +62,22 @@ func TestCreateMiner(t *testing.T) {
 			}},
 		}},
 	}.Matches(t, v.Invocations()[0])
+
+	// The VM traces the gas consumed by state loads and sends.
+	var sends, loads []string
+	for _, trace := range v.GasTraces() {
+		require.True(t, trace.Gas > 0, "no gas traced for %s %s", trace.Op, trace.Name)
+		switch trace.Op {
+		case runtime.GasOpSend:
+			sends = append(sends, trace.Name)
+		case runtime.GasOpStateLoad:
+			if trace.Actor == builtin.StoragePowerActorAddr {
+				loads = append(loads, trace.Name)
+			}
+		}
+	}
+	assert.Contains(t, sends, fmt.Sprintf("%v.%d", builtin.InitActorAddr, builtin.MethodsInit.Exec))
+	assert.Contains(t, loads, "*power.State")
 }
 
 func TestCronTick(t *testing.T) {
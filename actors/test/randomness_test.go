@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestScriptedRandomness(t *testing.T) {
+	ctx := context.Background()
+	v, actors := vm.NewVMWithGenesis(ctx, t, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: big.Mul(big.NewInt(10_000), vm.FIL)}},
+		Seed:     93837778,
+		Miners: []vm.GenesisMiner{{
+			SealProof: abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			Balance:   big.Mul(big.NewInt(1_000), vm.FIL),
+			Sectors:   1,
+		}},
+	})
+	worker, minerAddr := actors.Accounts[0], actors.Miners[0].IDAddress
+	randomness := vm.NewScriptedRandomness(vm.HashRandomness{Seed: []byte("scripted")})
+	v.SetRandomness(randomness)
+
+	dlInfo, pIdx, v := vm.AdvanceTillProvingDeadline(t, v, minerAddr, 0)
+	assert.Equal(t, randomness, v.GetRandomness())
+	scripted := abi.Randomness("scripted chain commit randomness")
+	randomness.SetTickets(crypto.DomainSeparationTag_PoStChainCommit, dlInfo.Challenge, scripted)
+	assert.Equal(t, scripted, vm.ChainCommitRand(v, dlInfo.Challenge))
+
+	// A proof committing to the default randomness is rejected.
+	params := miner.SubmitWindowedPoStParams{
+		Deadline:         dlInfo.Index,
+		Partitions:       []miner.PoStPartition{{Index: pIdx, Skipped: bitfield.New()}},
+		Proofs:           []proof.PoStProof{{PoStProof: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1}},
+		ChainCommitEpoch: dlInfo.Challenge,
+		ChainCommitRand:  []byte(vm.RandString),
+	}
+	vm.ApplyCode(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.SubmitWindowedPoSt, &params, exitcode.ErrIllegalArgument)
+
+	// A proof committing to the scripted randomness is accepted.
+	vm.SubmitPoSt(t, v, minerAddr, worker, dlInfo, pIdx)
+}
+
+func TestHashRandomness(t *testing.T) {
+	r := vm.HashRandomness{Seed: []byte("seed")}
+	tag := crypto.DomainSeparationTag_WindowedPoStChallengeSeed
+	base := r.GetRandomnessFromBeacon(tag, 10, nil)
+	assert.Len(t, base, 32)
+	assert.Equal(t, base, r.GetRandomnessFromBeacon(tag, 10, nil))
+
+	distinct := []abi.Randomness{
+		r.GetRandomnessFromTickets(tag, 10, nil),
+		r.GetRandomnessFromBeacon(crypto.DomainSeparationTag_SealRandomness, 10, nil),
+		r.GetRandomnessFromBeacon(tag, 11, nil),
+		r.GetRandomnessFromBeacon(tag, 10, []byte("entropy")),
+		vm.HashRandomness{Seed: []byte("other")}.GetRandomnessFromBeacon(tag, 10, nil),
+	}
+	for _, other := range distinct {
+		assert.NotEqual(t, base, other)
+	}
+}
@@ -2,7 +2,6 @@ package test
 
 import (
 	"context"
-	"strconv"
 	"testing"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
@@ -22,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v7/support/ipld"
 	"github.com/filecoin-project/specs-actors/v7/support/vm"
@@ -706,14 +706,16 @@ func createDeals(t *testing.T, numberOfDeals int, v *vm.VM, clientAddress addres
 	collateral = big.Mul(big.NewInt(int64(64*numberOfDeals)), vm.FIL)
 	vm.ApplyOk(t, v, workerAddress, builtin.StorageMarketActorAddr, collateral, builtin.MethodsMarket.AddBalance, &minerAddress)
 
-	var dealIDs []abi.DealID
-	for i := 0; i < numberOfDeals; i++ {
-		dealStart := v.GetEpoch() + miner.MaxProveCommitDuration[sealProof]
-		deals := publishDeal(t, v, workerAddress, workerAddress, minerAddress, "dealLabel"+strconv.Itoa(i), 32<<30, false, dealStart, 180*builtin.EpochsInDay)
-		dealIDs = append(dealIDs, deals.IDs...)
-	}
-
-	return dealIDs
+	dealStart := v.GetEpoch() + miner.MaxProveCommitDuration[sealProof]
+	return vm.PublishDeals(t, v, workerAddress, minerAddress, []address.Address{clientAddress}, numberOfDeals, market.DealProposal{
+		PieceSize:            32 << 30,
+		Label:                "dealLabel",
+		StartEpoch:           dealStart,
+		EndEpoch:             dealStart + 180*builtin.EpochsInDay,
+		StoragePricePerEpoch: abi.NewTokenAmount(1 << 20),
+		ProviderCollateral:   big.Mul(big.NewInt(2), vm.FIL),
+		ClientCollateral:     big.Mul(big.NewInt(1), vm.FIL),
+	})
 }
 
 // This method produces an active, mutable sector, by:
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestSnapshotAndRevert(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), builtin.TokenPrecision), 93837778)
+	sender, recipient := addrs[0], addrs[1]
+
+	// Common setup shared by each branch.
+	params := power.CreateMinerParams{
+		Owner:               sender,
+		Worker:              sender,
+		WindowPoStProofType: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		Peer:                abi.PeerID("not really a peer id"),
+	}
+	vm.ApplyOk(t, v, sender, builtin.StoragePowerActorAddr, big.Zero(), builtin.MethodsPower.CreateMiner, &params)
+	snap, err := v.Snapshot()
+	require.NoError(t, err)
+	setupRoot := v.StateRoot()
+	balance := func(v *vm.VM) abi.TokenAmount {
+		actor, found, err := v.GetActor(recipient)
+		require.NoError(t, err)
+		require.True(t, found)
+		return actor.Balance
+	}
+	setupBalance := balance(v)
+	setupEpoch := v.GetEpoch()
+
+	t.Run("revert in place", func(t *testing.T) {
+		vm.ApplyOk(t, v, sender, recipient, builtin.TokenPrecision, builtin.MethodSend, nil)
+		assert.Equal(t, big.Add(setupBalance, builtin.TokenPrecision), balance(v))
+
+		require.NoError(t, v.Revert(snap))
+		assert.Equal(t, setupRoot, v.StateRoot())
+		assert.Equal(t, setupBalance, balance(v))
+		assert.Empty(t, v.Invocations())
+	})
+
+	t.Run("revert derived vm", func(t *testing.T) {
+		later, err := v.WithEpoch(1000)
+		require.NoError(t, err)
+		vm.ApplyOk(t, later, sender, recipient, big.Mul(big.NewInt(2), builtin.TokenPrecision), builtin.MethodSend, nil)
+
+		require.NoError(t, later.Revert(snap))
+		assert.Equal(t, setupRoot, later.StateRoot())
+		assert.Equal(t, setupEpoch, later.GetEpoch())
+		assert.Equal(t, setupBalance, balance(later))
+	})
+
+	t.Run("revert repeatedly", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, v.Revert(snap))
+			vm.ApplyOk(t, v, sender, recipient, builtin.TokenPrecision, builtin.MethodSend, nil)
+			assert.Equal(t, big.Add(setupBalance, builtin.TokenPrecision), balance(v))
+		}
+	})
+}
@@ -176,6 +176,19 @@ func TestTerminateSectors(t *testing.T) {
 	assert.Equal(t, big.Zero(), minerBalances.InitialPledge)
 	assert.Equal(t, big.Zero(), minerBalances.PreCommitDeposit)
 
+	// expect the sector's deadline to count it as terminated
+	var minerState miner.State
+	require.NoError(t, v.GetState(minerAddrs.IDAddress, &minerState))
+	exits, err := minerState.DeadlineSectorExits(v.Store())
+	require.NoError(t, err)
+	for dlIdx, dlExits := range exits {
+		if uint64(dlIdx) == dlInfo.Index {
+			assert.Equal(t, miner.SectorExitCounts{Terminated: 1}, dlExits)
+		} else {
+			assert.Equal(t, miner.SectorExitCounts{}, dlExits)
+		}
+	}
+
 	// expect network stats to reflect power has been removed from sector
 	stats := vm.GetNetworkStats(t, v)
 	assert.Equal(t, int64(0), stats.MinerAboveMinPowerCount)
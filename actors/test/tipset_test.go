@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestApplyTipset(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 3, big.Mul(big.NewInt(10_000), builtin.TokenPrecision), 93837778)
+
+	var miners []address.Address
+	for _, owner := range addrs[:2] {
+		params := power.CreateMinerParams{
+			Owner:               owner,
+			Worker:              owner,
+			WindowPoStProofType: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+			Peer:                abi.PeerID("not really a peer id"),
+		}
+		ret := vm.ApplyOk(t, v, owner, builtin.StoragePowerActorAddr, big.Zero(), builtin.MethodsPower.CreateMiner, &params)
+		miners = append(miners, ret.(*power.CreateMinerReturn).IDAddress)
+	}
+	balance := func(v *vm.VM, a address.Address) abi.TokenAmount {
+		actor, found, err := v.GetActor(a)
+		require.NoError(t, err)
+		require.True(t, found)
+		return actor.Balance
+	}
+	minerBalances := []abi.TokenAmount{balance(v, miners[0]), balance(v, miners[1])}
+	recipientBalance := balance(v, addrs[2])
+
+	// Two null rounds precede a tipset of two blocks, the second with two winning tickets.
+	start := v.GetEpoch()
+	tipsetEpoch := start + 2
+	next, result, err := v.ApplyTipset(tipsetEpoch, []vm.Block{{
+		Miner:    miners[0],
+		WinCount: 1,
+		Messages: []vm.Message{{From: addrs[0], To: addrs[2], Value: builtin.TokenPrecision, Method: builtin.MethodSend}},
+	}, {
+		Miner:    miners[1],
+		WinCount: 2,
+		Messages: []vm.Message{{From: addrs[1], To: addrs[2], Value: builtin.TokenPrecision, Method: builtin.MethodSend}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, tipsetEpoch+1, next.GetEpoch())
+	assert.Len(t, result.NullRoundCrons, 2)
+	require.Len(t, result.Messages, 2)
+	for _, messages := range result.Messages {
+		require.Len(t, messages, 1)
+		assert.Equal(t, exitcode.Ok, messages[0].Code)
+	}
+	assert.Len(t, result.Rewards, 2)
+	assert.Equal(t, exitcode.Ok, result.Cron.Code)
+
+	assert.Equal(t, big.Add(recipientBalance, big.Mul(big.NewInt(2), builtin.TokenPrecision)), balance(next, addrs[2]))
+	reward0 := big.Sub(balance(next, miners[0]), minerBalances[0])
+	reward1 := big.Sub(balance(next, miners[1]), minerBalances[1])
+	assert.True(t, reward0.GreaterThan(big.Zero()))
+	assert.True(t, reward1.GreaterThan(reward0), "reward for two wins %v not more than one win %v", reward1, reward0)
+
+	t.Run("tipset may not precede current epoch", func(t *testing.T) {
+		_, _, err := next.ApplyTipset(start, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty tipset runs cron only", func(t *testing.T) {
+		after, result, err := next.ApplyTipset(next.GetEpoch(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, result.NullRoundCrons)
+		assert.Empty(t, result.Rewards)
+		assert.Equal(t, exitcode.Ok, result.Cron.Code)
+		assert.Equal(t, next.GetEpoch()+1, after.GetEpoch())
+	})
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+// Mirrors the JSON encoding of vm.TraceFrame, with parameters left generic.
+type traceNode struct {
+	Actor      string
+	MethodName string
+	Params     map[string]interface{}
+	ExitCode   exitcode.ExitCode
+	Status     string
+	Subcalls   []*traceNode
+}
+
+func (n *traceNode) find(actor, method string) *traceNode {
+	if n.Actor == actor && n.MethodName == method {
+		return n
+	}
+	for _, sub := range n.Subcalls {
+		if found := sub.find(actor, method); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestExecutionTrace(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	vm.LogTraceOnFailure(t, v)
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), builtin.TokenPrecision), 93837778)
+	owner := addrs[0]
+
+	params := power.CreateMinerParams{
+		Owner:               owner,
+		Worker:              owner,
+		WindowPoStProofType: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		Peer:                abi.PeerID("not really a peer id"),
+	}
+	vm.ApplyOk(t, v, owner, builtin.StoragePowerActorAddr, big.Zero(), builtin.MethodsPower.CreateMiner, &params)
+	result := vm.RequireApplyMessage(t, v, owner, builtin.StoragePowerActorAddr, big.Zero(), 1000, nil, t.Name())
+	require.Equal(t, exitcode.SysErrInvalidMethod, result.Code)
+
+	var buf bytes.Buffer
+	require.NoError(t, v.ExportTrace(&buf))
+	var trace []*traceNode
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	require.Len(t, trace, len(v.Invocations()))
+
+	powerName := builtin.ActorNameByCode(builtin.StoragePowerActorCodeID)
+	createMiner := trace[len(trace)-2]
+	assert.Equal(t, powerName, createMiner.Actor)
+	assert.Equal(t, "CreateMiner", createMiner.MethodName)
+	assert.Equal(t, exitcode.Ok, createMiner.ExitCode)
+	assert.Equal(t, string(params.Peer), decodeBase64(t, createMiner.Params["Peer"]))
+
+	// The constructor parameters are sent as raw bytes by the init actor, but traced as their decoded type.
+	constructor := createMiner.find(builtin.ActorNameByCode(builtin.StorageMinerActorCodeID), "Constructor")
+	require.NotNil(t, constructor)
+	assert.Equal(t, float64(params.WindowPoStProofType), constructor.Params["WindowPoStProofType"])
+	require.NotNil(t, createMiner.find(builtin.ActorNameByCode(builtin.InitActorCodeID), "Exec"))
+
+	failed := trace[len(trace)-1]
+	assert.Equal(t, powerName, failed.Actor)
+	assert.Empty(t, failed.MethodName)
+	assert.Equal(t, exitcode.SysErrInvalidMethod, failed.ExitCode)
+	assert.Equal(t, exitcode.SysErrInvalidMethod.String(), failed.Status)
+}
+
+func decodeBase64(t *testing.T, v interface{}) string {
+	var out []byte
+	encoded, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, &out))
+	return string(out)
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	power6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/power"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+// Commits a sector with a deal on v6 actors, upgrades to v7 between prove-commit and the deal's start,
+// and continues proving the sector until the deal is paid on v7.
+func TestSectorAndDealLifecycleAcrossUpgrade(t *testing.T) {
+	ctx := context.Background()
+	v6 := vm6.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	fil := builtin6.TokenPrecision
+	addrs := vm6.CreateAccounts(ctx, t, v6, 2, big.Mul(big.NewInt(10_000), fil), 93837778)
+	worker, client := addrs[0], addrs[1]
+
+	sealProof := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	wPoStProof, err := sealProof.RegisteredWindowPoStProof()
+	require.NoError(t, err)
+	sectorSize, err := sealProof.SectorSize()
+	require.NoError(t, err)
+
+	ret := vm6.ApplyOk(t, v6, worker, builtin6.StoragePowerActorAddr, big.Mul(big.NewInt(1_000), fil), builtin6.MethodsPower.CreateMiner, &power6.CreateMinerParams{
+		Owner:               worker,
+		Worker:              worker,
+		WindowPoStProofType: wPoStProof,
+		Peer:                abi.PeerID("not really a peer id"),
+	})
+	minerAddr := ret.(*power6.CreateMinerReturn).IDAddress
+
+	// Advance so seal randomness is in the past.
+	v6, err = v6.WithEpoch(200)
+	require.NoError(t, err)
+
+	// Publish a deal starting shortly after the sector will be proven.
+	vm6.ApplyOk(t, v6, client, builtin6.StorageMarketActorAddr, big.Mul(big.NewInt(100), fil), builtin6.MethodsMarket.AddBalance, &client)
+	vm6.ApplyOk(t, v6, worker, builtin6.StorageMarketActorAddr, big.Mul(big.NewInt(100), fil), builtin6.MethodsMarket.AddBalance, &minerAddr)
+	dealStart := v6.GetEpoch() + miner6.PreCommitChallengeDelay + 400
+	deal := market6.DealProposal{
+		PieceCID:             tutil.MakeCID("upgrade", &market6.PieceCIDPrefix),
+		PieceSize:            abi.PaddedPieceSize(1 << 30),
+		Client:               client,
+		Provider:             minerAddr,
+		Label:                "upgrade",
+		StartEpoch:           dealStart,
+		EndEpoch:             dealStart + 180*builtin6.EpochsInDay,
+		StoragePricePerEpoch: abi.NewTokenAmount(1 << 20),
+		ProviderCollateral:   big.Mul(big.NewInt(2), fil),
+		ClientCollateral:     big.Mul(big.NewInt(1), fil),
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, deal.MarshalCBOR(buf))
+	ret = vm6.ApplyOk(t, v6, worker, builtin6.StorageMarketActorAddr, big.Zero(), builtin6.MethodsMarket.PublishStorageDeals, &market6.PublishStorageDealsParams{
+		Deals: []market6.ClientDealProposal{{
+			Proposal:        deal,
+			ClientSignature: crypto.Signature{Type: crypto.SigTypeBLS, Data: buf.Bytes()},
+		}},
+	})
+	dealID := ret.(*market6.PublishStorageDealsReturn).IDs[0]
+
+	// Pre-commit and prove-commit a sector containing the deal.
+	sectorNumber := abi.SectorNumber(100)
+	vm6.ApplyOk(t, v6, worker, minerAddr, big.Zero(), builtin6.MethodsMiner.PreCommitSector, &miner6.PreCommitSectorParams{
+		SealProof:     sealProof,
+		SectorNumber:  sectorNumber,
+		SealedCID:     tutil.MakeCID("100", &miner6.SealedCIDPrefix),
+		SealRandEpoch: v6.GetEpoch() - 1,
+		DealIDs:       []abi.DealID{dealID},
+		Expiration:    v6.GetEpoch() + miner6.MinSectorExpiration + miner6.MaxProveCommitDuration[sealProof] + 100,
+	})
+	v6, err = v6.WithEpoch(v6.GetEpoch() + miner6.PreCommitChallengeDelay + 1)
+	require.NoError(t, err)
+	vm6.ApplyOk(t, v6, worker, minerAddr, big.Zero(), builtin6.MethodsMiner.ProveCommitSector, &miner6.ProveCommitSectorParams{
+		SectorNumber: sectorNumber,
+	})
+	vm6.ApplyOk(t, v6, builtin6.SystemActorAddr, builtin6.CronActorAddr, big.Zero(), builtin6.MethodsCron.EpochTick, nil)
+
+	//
+	// Upgrade
+	//
+
+	v, err := vm.UpgradeFromV6(ctx, v6, nv15.Config{MaxWorkers: 2}, nv15.TestLogger{TB: t})
+	require.NoError(t, err)
+	assert.Equal(t, v6.GetEpoch()+1, v.GetEpoch())
+
+	// The deal and sector survive the migration.
+	dealState, found := vm.GetDealState(t, v, dealID)
+	require.True(t, found)
+	assert.Equal(t, v6.GetEpoch(), dealState.SectorStartEpoch)
+	sector := vm.SectorInfo(t, v, minerAddr, sectorNumber)
+	assert.Equal(t, []abi.DealID{dealID}, sector.DealIDs)
+
+	// Proving continues on v7 actors, and the deal is paid once started.
+	dlInfo, pIdx, v := vm.AdvanceTillProvingDeadline(t, v, minerAddr, sectorNumber)
+	vm.SubmitPoSt(t, v, minerAddr, worker, dlInfo, pIdx)
+	assert.Equal(t, big.NewInt(int64(sectorSize)), vm.MinerPower(t, v, minerAddr).Raw)
+
+	// move forward one deadline so advanceWhileProving doesn't fail double submitting posts
+	v, _ = vm.AdvanceByDeadlineTillIndex(t, v, minerAddr, dlInfo.Index+2%miner.WPoStPeriodDeadlines)
+	v = vm.AdvanceByDeadlineTillEpochWhileProving(t, v, minerAddr, worker, sectorNumber, dealStart+market.DealUpdatesInterval)
+	dealState, found = vm.GetDealState(t, v, dealID)
+	require.True(t, found)
+	assert.True(t, dealState.LastUpdatedEpoch >= dealStart, "deal not processed since start, last updated %d", dealState.LastUpdatedEpoch)
+	assert.Equal(t, abi.ChainEpoch(-1), dealState.SlashEpoch)
+	assert.True(t, vm.CheckSectorActive(t, v, minerAddr, dlInfo.Index, pIdx, sectorNumber))
+
+	stateTree, err := v.GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := v.GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, v.GetEpoch())
+	require.NoError(t, err)
+	assert.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
@@ -46,6 +46,42 @@ func MakeEmptyArray(s Store, bitwidth int) (*Array, error) {
 	}, nil
 }
 
+// Largest bitwidth accepted for an AMT whose bitwidth is configurable by policy.
+// Wider nodes hold so many values that a node of large values risks exceeding the maximum block size.
+const MaxAmtBitwidth = 10
+
+// Checks that a bitwidth configured by policy is acceptable for an AMT.
+func CheckAmtBitwidth(bitwidth int) error {
+	if bitwidth < 1 || bitwidth > MaxAmtBitwidth {
+		return xerrors.Errorf("AMT bitwidth %d out of range [1, %d]", bitwidth, MaxAmtBitwidth)
+	}
+	return nil
+}
+
+// Copies every entry of the AMT with root `r` and bitwidth `from` into a new AMT with bitwidth `to`,
+// returning the new root. Returns `r` unchanged if the bitwidths are equal.
+// This supports migrating state after a change to a policy-configured bitwidth.
+func ReencodeArray(s Store, r cid.Cid, from, to int) (cid.Cid, error) {
+	if from == to {
+		return r, nil
+	}
+	in, err := AsArray(s, r, from)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load array with bitwidth %d: %w", from, err)
+	}
+	out, err := MakeEmptyArray(s, to)
+	if err != nil {
+		return cid.Undef, err
+	}
+	var value cbg.Deferred
+	if err := in.ForEach(&value, func(i int64) error {
+		return out.Set(uint64(i), &value)
+	}); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to copy array: %w", err)
+	}
+	return out.Root()
+}
+
 // Writes a new empty array to the store, returning its CID.
 func StoreEmptyArray(s Store, bitwidth int) (cid.Cid, error) {
 	arr, err := MakeEmptyArray(s, bitwidth)
@@ -107,7 +143,12 @@ func (a *Array) BatchDelete(ix []uint64, strict bool) error {
 // Iteration halts if the function returns an error.
 // If the output parameter is nil, deserialization is skipped.
 func (a *Array) ForEach(out cbor.Unmarshaler, fn func(i int64) error) error {
-	return a.root.ForEach(a.store.Context(), func(k uint64, val *cbg.Deferred) error {
+	return a.ForEachFrom(0, out, fn)
+}
+
+// Iterates the entries in the array with index at least `start`, as for ForEach.
+func (a *Array) ForEachFrom(start uint64, out cbor.Unmarshaler, fn func(i int64) error) error {
+	return a.root.ForEachAt(a.store.Context(), start, func(k uint64, val *cbg.Deferred) error {
 		if out != nil {
 			if deferred, ok := out.(*cbg.Deferred); ok {
 				// fast-path deferred -> deferred to avoid re-decoding.
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v7/support/mock"
@@ -20,3 +22,42 @@ func TestArrayNotFound(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, found)
 }
+
+func TestReencodeArray(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+	arr, err := adt.MakeEmptyArray(store, 5)
+	require.NoError(t, err)
+	for _, i := range []uint64{0, 3, 100, 1000} {
+		value := cbg.CborInt(i * 2)
+		require.NoError(t, arr.Set(i, &value))
+	}
+	root, err := arr.Root()
+	require.NoError(t, err)
+
+	same, err := adt.ReencodeArray(store, root, 5, 5)
+	require.NoError(t, err)
+	assert.Equal(t, root, same)
+
+	reencoded, err := adt.ReencodeArray(store, root, 5, 2)
+	require.NoError(t, err)
+	_, err = adt.AsArray(store, reencoded, 5)
+	require.Error(t, err)
+	out, err := adt.AsArray(store, reencoded, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), out.Length())
+	for _, i := range []uint64{0, 3, 100, 1000} {
+		var value cbg.CborInt
+		found, err := out.Get(i, &value)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, cbg.CborInt(i*2), value)
+	}
+}
+
+func TestCheckAmtBitwidth(t *testing.T) {
+	assert.NoError(t, adt.CheckAmtBitwidth(1))
+	assert.NoError(t, adt.CheckAmtBitwidth(adt.MaxAmtBitwidth))
+	assert.Error(t, adt.CheckAmtBitwidth(0))
+	assert.Error(t, adt.CheckAmtBitwidth(adt.MaxAmtBitwidth+1))
+}
@@ -14,7 +14,18 @@ const BalanceTableBitwidth = 6
 
 // A specialization of a map of addresses to (positive) token amounts.
 // Absent keys implicitly have a balance of zero.
-type BalanceTable Map
+type BalanceTable struct {
+	m *Map
+	// Sum of all balances, computed on first request and maintained by subsequent updates.
+	// Nil until computed.
+	total abi.TokenAmount
+}
+
+// A change to the balance of an address.
+type BalanceDelta struct {
+	Address addr.Address
+	Amount  abi.TokenAmount
+}
 
 // Interprets a store as balance table with root `r`.
 func AsBalanceTable(s Store, r cid.Cid) (*BalanceTable, error) {
@@ -23,21 +34,18 @@ func AsBalanceTable(s Store, r cid.Cid) (*BalanceTable, error) {
 		return nil, err
 	}
 
-	return &BalanceTable{
-		root:  m.root,
-		store: s,
-	}, nil
+	return &BalanceTable{m: m}, nil
 }
 
 // Returns the root cid of underlying HAMT.
 func (t *BalanceTable) Root() (cid.Cid, error) {
-	return (*Map)(t).Root()
+	return t.m.Root()
 }
 
 // Gets the balance for a key, which is zero if they key has never been added to.
 func (t *BalanceTable) Get(key addr.Address) (abi.TokenAmount, error) {
 	var value abi.TokenAmount
-	found, err := (*Map)(t).Get(abi.AddrKey(key), &value)
+	found, err := t.m.Get(abi.AddrKey(key), &value)
 	if !found || err != nil {
 		value = big.Zero()
 	}
@@ -56,9 +64,64 @@ func (t *BalanceTable) Add(key addr.Address, value abi.TokenAmount) error {
 	if sign < 0 {
 		return xerrors.Errorf("adding %v to balance %v would give negative: %v", value, prev, sum)
 	} else if sign == 0 && !prev.IsZero() {
-		return (*Map)(t).Delete(abi.AddrKey(key))
+		err = t.m.Delete(abi.AddrKey(key))
+	} else {
+		err = t.m.Put(abi.AddrKey(key), &sum)
 	}
-	return (*Map)(t).Put(abi.AddrKey(key), &sum)
+	if err != nil {
+		return err
+	}
+	t.addToTotal(value)
+	return nil
+}
+
+// Adds each of a sequence of amounts to the corresponding balance, requiring every resulting balance to be
+// non-negative, and flushes the table once.
+// An address may appear more than once, in which case its amounts are summed in order.
+// If any balance would become negative, returns an error and leaves the table unchanged.
+func (t *BalanceTable) AddMany(deltas []BalanceDelta) error {
+	batch := NewMapBatch(t.m)
+	added := big.Zero()
+	for _, d := range deltas {
+		var prev abi.TokenAmount
+		found, err := batch.Get(abi.AddrKey(d.Address), &prev)
+		if err != nil {
+			return err
+		}
+		if !found {
+			prev = big.Zero()
+		}
+		sum := big.Add(prev, d.Amount)
+		sign := sum.Sign()
+		if sign < 0 {
+			return xerrors.Errorf("adding %v to balance %v of %v would give negative: %v", d.Amount, prev, d.Address, sum)
+		} else if sign == 0 && !prev.IsZero() {
+			batch.Delete(abi.AddrKey(d.Address))
+		} else if err := batch.Put(abi.AddrKey(d.Address), &sum); err != nil {
+			return err
+		}
+		added = big.Add(added, d.Amount)
+	}
+	if _, err := batch.Commit(); err != nil {
+		// The table may be partially updated, so the running total can no longer be trusted.
+		t.total = abi.TokenAmount{}
+		return err
+	}
+	t.addToTotal(added)
+	return nil
+}
+
+// Subtracts each of a sequence of amounts from the corresponding balance, and flushes the table once.
+// Returns an error, leaving the table unchanged, if any amount is negative or any account has insufficient balance.
+func (t *BalanceTable) SubtractMany(deltas []BalanceDelta) error {
+	negated := make([]BalanceDelta, len(deltas))
+	for i, d := range deltas {
+		if d.Amount.Sign() < 0 {
+			return xerrors.Errorf("negative amount %v to subtract from %v", d.Amount, d.Address)
+		}
+		negated[i] = BalanceDelta{Address: d.Address, Amount: d.Amount.Neg()}
+	}
+	return t.AddMany(negated)
 }
 
 // Subtracts up to the specified amount from a balance, without reducing the balance below some minimum.
@@ -93,13 +156,101 @@ func (t *BalanceTable) MustSubtract(key addr.Address, req abi.TokenAmount) error
 	return t.Add(key, req.Neg())
 }
 
-// Returns the total balance held by this BalanceTable
+// Returns the total balance held by this BalanceTable.
+// The first call traverses the table; the total is then maintained as balances are updated.
 func (t *BalanceTable) Total() (abi.TokenAmount, error) {
+	if !t.total.Nil() {
+		return t.total, nil
+	}
 	total := big.Zero()
-	var cur abi.TokenAmount
-	err := (*Map)(t).ForEach(&cur, func(key string) error {
-		total = big.Add(total, cur)
+	if err := t.ForEach(func(_ addr.Address, balance abi.TokenAmount) error {
+		total = big.Add(total, balance)
 		return nil
-	})
-	return total, err
+	}); err != nil {
+		return big.Zero(), err
+	}
+	t.total = total
+	return total, nil
+}
+
+func (t *BalanceTable) addToTotal(value abi.TokenAmount) {
+	if !t.total.Nil() {
+		t.total = big.Add(t.total, value)
+	}
+}
+
+// Iterates all balances, calling a function with each address and balance.
+// Iteration halts if the function returns an error.
+func (t *BalanceTable) ForEach(fn func(key addr.Address, balance abi.TokenAmount) error) error {
+	return t.forEachAfter(addr.Undef, fn)
+}
+
+// Iterates up to limit balances following the balance for an address, and returns the address from which
+// to continue, or an undefined address if there are no more balances.
+// An undefined after address starts from the first balance. Balances are ordered by the hash of the address,
+// which is stable but not meaningful, and every page traverses the table from the first balance.
+func (t *BalanceTable) ForEachPage(after addr.Address, limit int, fn func(key addr.Address, balance abi.TokenAmount) error) (addr.Address, error) {
+	if limit <= 0 {
+		return addr.Undef, xerrors.Errorf("invalid page limit %d", limit)
+	}
+	p := balancePager{limit: limit, fn: fn}
+	return p.finish(t.forEachAfter(after, p.visit))
+}
+
+// Iterates the balances following the balance for an address, or all balances if the address is undefined.
+// Returns an error if the address is defined but has no balance in the table.
+func (t *BalanceTable) forEachAfter(after addr.Address, fn func(key addr.Address, balance abi.TokenAmount) error) error {
+	started := after == addr.Undef
+	afterKey := string(after.Bytes())
+	var balance abi.TokenAmount
+	if err := t.m.ForEach(&balance, func(k string) error {
+		if !started {
+			started = k == afterKey
+			return nil
+		}
+		a, err := addr.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		return fn(a, balance)
+	}); err != nil {
+		return err
+	}
+	if !started {
+		return xerrors.Errorf("address %v not found in balance table", after)
+	}
+	return nil
+}
+
+var errPageFull = xerrors.New("page full")
+
+// Limits an iteration to a page of balances, recording where the next page starts.
+type balancePager struct {
+	limit int
+	fn    func(key addr.Address, balance abi.TokenAmount) error
+	count int
+	last  addr.Address
+	next  addr.Address
+}
+
+func (p *balancePager) visit(key addr.Address, balance abi.TokenAmount) error {
+	if p.count == p.limit {
+		p.next = p.last
+		return errPageFull
+	}
+	if err := p.fn(key, balance); err != nil {
+		return err
+	}
+	p.last = key
+	p.count++
+	return nil
+}
+
+func (p *balancePager) finish(err error) (addr.Address, error) {
+	if err == errPageFull {
+		return p.next, nil
+	} else if err != nil {
+		return addr.Undef, err
+	}
+	return addr.Undef, nil
 }
@@ -54,9 +54,10 @@ func TestBalanceTable(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, abi.NewTokenAmount(0), amount)
 		// The zero entry is not stored.
-		found, err := ((*adt.Map)(bt)).Get(abi.AddrKey(addr), nil)
-		require.NoError(t, err)
-		require.False(t, found)
+		require.NoError(t, bt.ForEach(func(key address.Address, _ abi.TokenAmount) error {
+			require.NotEqual(t, addr, key)
+			return nil
+		}))
 	})
 
 	t.Run("Must subtract fails if account balance is insufficient", func(t *testing.T) {
@@ -89,9 +90,10 @@ func TestBalanceTable(t *testing.T) {
 		require.Equal(t, abi.NewTokenAmount(0), bal)
 
 		// The zero entry is not stored.
-		found, err := ((*adt.Map)(bt)).Get(abi.AddrKey(addr), nil)
-		require.NoError(t, err)
-		require.False(t, found)
+		require.NoError(t, bt.ForEach(func(key address.Address, _ abi.TokenAmount) error {
+			require.NotEqual(t, addr, key)
+			return nil
+		}))
 	})
 
 	t.Run("Total returns total amount tracked", func(t *testing.T) {
@@ -172,3 +174,104 @@ func TestSubtractWithMinimum(t *testing.T) {
 		require.EqualValues(t, abi.NewTokenAmount(2), remaining)
 	})
 }
+
+func TestBalanceTableBulk(t *testing.T) {
+	buildBalanceTable := func() *adt.BalanceTable {
+		rt := mock.NewBuilder(address.Undef).Build(t)
+		store := adt.AsStore(rt)
+		emptyMap, err := adt.MakeEmptyMap(store, adt.BalanceTableBitwidth)
+		require.NoError(t, err)
+
+		bt, err := adt.AsBalanceTable(store, tutil.MustRoot(t, emptyMap))
+		require.NoError(t, err)
+		return bt
+	}
+	addr1 := tutil.NewIDAddr(t, 100)
+	addr2 := tutil.NewIDAddr(t, 101)
+
+	t.Run("adds many and maintains total", func(t *testing.T) {
+		bt := buildBalanceTable()
+		require.NoError(t, bt.Add(addr1, abi.NewTokenAmount(5)))
+		total, err := bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(5), total)
+
+		require.NoError(t, bt.AddMany([]adt.BalanceDelta{
+			{Address: addr1, Amount: abi.NewTokenAmount(-5)},
+			{Address: addr2, Amount: abi.NewTokenAmount(3)},
+			{Address: addr2, Amount: abi.NewTokenAmount(4)},
+		}))
+		amount, err := bt.Get(addr1)
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), amount)
+		amount, err = bt.Get(addr2)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(7), amount)
+
+		total, err = bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(7), total)
+
+		require.NoError(t, bt.SubtractMany([]adt.BalanceDelta{{Address: addr2, Amount: abi.NewTokenAmount(2)}}))
+		total, err = bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(5), total)
+	})
+
+	t.Run("failed bulk update leaves table unchanged", func(t *testing.T) {
+		bt := buildBalanceTable()
+		require.NoError(t, bt.Add(addr1, abi.NewTokenAmount(5)))
+		before, err := bt.Root()
+		require.NoError(t, err)
+
+		err = bt.SubtractMany([]adt.BalanceDelta{
+			{Address: addr2, Amount: abi.NewTokenAmount(0)},
+			{Address: addr1, Amount: abi.NewTokenAmount(3)},
+			{Address: addr1, Amount: abi.NewTokenAmount(3)},
+		})
+		require.Error(t, err)
+		err = bt.SubtractMany([]adt.BalanceDelta{{Address: addr1, Amount: abi.NewTokenAmount(-1)}})
+		require.Error(t, err)
+
+		after, err := bt.Root()
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		total, err := bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(5), total)
+	})
+
+	t.Run("pages through balances", func(t *testing.T) {
+		bt := buildBalanceTable()
+		expected := map[address.Address]abi.TokenAmount{}
+		for i := uint64(0); i < 10; i++ {
+			a := tutil.NewIDAddr(t, 100+i)
+			expected[a] = abi.NewTokenAmount(int64(i + 1))
+			require.NoError(t, bt.Add(a, expected[a]))
+		}
+
+		seen := map[address.Address]abi.TokenAmount{}
+		pages := 0
+		after := address.Undef
+		for {
+			next, err := bt.ForEachPage(after, 3, func(key address.Address, balance abi.TokenAmount) error {
+				assert.NotContains(t, seen, key)
+				seen[key] = balance
+				return nil
+			})
+			require.NoError(t, err)
+			pages++
+			if next == address.Undef {
+				break
+			}
+			after = next
+		}
+		assert.Equal(t, 4, pages)
+		assert.Equal(t, expected, seen)
+
+		_, err := bt.ForEachPage(after, 0, nil)
+		require.Error(t, err)
+		_, err = bt.ForEachPage(tutil.NewIDAddr(t, 200), 3, func(address.Address, abi.TokenAmount) error { return nil })
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,152 @@
+package adt
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// MapBatch accumulates writes to a Map and applies them together on Commit, so that a key written
+// repeatedly within a method is written to the HAMT only once, and the HAMT is flushed only once.
+// Values are encoded when written, so may be mutated afterwards without affecting the batch.
+// Reads through the batch observe pending writes. The underlying map must not be used until the batch is committed.
+type MapBatch struct {
+	m       *Map
+	pending map[string]*cbg.Deferred // A nil value is a pending delete.
+}
+
+func NewMapBatch(m *Map) *MapBatch {
+	return &MapBatch{m: m, pending: make(map[string]*cbg.Deferred)}
+}
+
+// Records a pending write of value `v` at key `k`.
+func (b *MapBatch) Put(k abi.Keyer, v cbor.Marshaler) error {
+	d, err := encodeDeferred(v)
+	if err != nil {
+		return xerrors.Errorf("failed to encode value for key %v: %w", k.Key(), err)
+	}
+	b.pending[k.Key()] = d
+	return nil
+}
+
+// Records a pending delete of key `k`, which need not exist.
+func (b *MapBatch) Delete(k abi.Keyer) {
+	b.pending[k.Key()] = nil
+}
+
+// Retrieves the value at `k` into `out`, observing pending writes. Returns whether the key was found.
+func (b *MapBatch) Get(k abi.Keyer, out cbor.Unmarshaler) (bool, error) {
+	if d, ok := b.pending[k.Key()]; ok {
+		return decodeDeferred(d, out)
+	}
+	return b.m.Get(k, out)
+}
+
+// Applies pending writes to the map, in key order, and returns the map's new root.
+func (b *MapBatch) Commit() (cid.Cid, error) {
+	keys := make([]string, 0, len(b.pending))
+	for k := range b.pending {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if d := b.pending[k]; d != nil {
+			if err := b.m.Put(rawKey(k), d); err != nil {
+				return cid.Undef, err
+			}
+		} else if _, err := b.m.TryDelete(rawKey(k)); err != nil {
+			return cid.Undef, err
+		}
+	}
+	b.pending = make(map[string]*cbg.Deferred)
+	return b.m.Root()
+}
+
+// ArrayBatch accumulates writes to an Array and applies them together on Commit, so that an index written
+// repeatedly within a method is written to the AMT only once, and the AMT is flushed only once.
+// Values are encoded when written, so may be mutated afterwards without affecting the batch.
+// Reads through the batch observe pending writes. The underlying array must not be used until the batch is committed.
+type ArrayBatch struct {
+	a       *Array
+	pending map[uint64]*cbg.Deferred // A nil value is a pending delete.
+}
+
+func NewArrayBatch(a *Array) *ArrayBatch {
+	return &ArrayBatch{a: a, pending: make(map[uint64]*cbg.Deferred)}
+}
+
+// Records a pending write of value `v` at index `i`.
+func (b *ArrayBatch) Set(i uint64, v cbor.Marshaler) error {
+	d, err := encodeDeferred(v)
+	if err != nil {
+		return xerrors.Errorf("failed to encode value for index %d: %w", i, err)
+	}
+	b.pending[i] = d
+	return nil
+}
+
+// Records a pending delete of index `i`, which need not exist.
+func (b *ArrayBatch) Delete(i uint64) {
+	b.pending[i] = nil
+}
+
+// Retrieves the value at index `i` into `out`, observing pending writes. Returns whether the index was found.
+func (b *ArrayBatch) Get(i uint64, out cbor.Unmarshaler) (bool, error) {
+	if d, ok := b.pending[i]; ok {
+		return decodeDeferred(d, out)
+	}
+	return b.a.Get(i, out)
+}
+
+// Applies pending writes to the array, in index order, and returns the array's new root.
+func (b *ArrayBatch) Commit() (cid.Cid, error) {
+	indices := make([]uint64, 0, len(b.pending))
+	for i := range b.pending {
+		indices = append(indices, i)
+	}
+	sort.Slice(indices, func(x, y int) bool { return indices[x] < indices[y] })
+	for _, i := range indices {
+		if d := b.pending[i]; d != nil {
+			if err := b.a.Set(i, d); err != nil {
+				return cid.Undef, err
+			}
+		} else if _, err := b.a.TryDelete(i); err != nil {
+			return cid.Undef, err
+		}
+	}
+	b.pending = make(map[uint64]*cbg.Deferred)
+	return b.a.Root()
+}
+
+// A key which is already in its string form.
+type rawKey string
+
+func (k rawKey) Key() string {
+	return string(k)
+}
+
+func encodeDeferred(v cbor.Marshaler) (*cbg.Deferred, error) {
+	var buf bytes.Buffer
+	if err := v.MarshalCBOR(&buf); err != nil {
+		return nil, err
+	}
+	return &cbg.Deferred{Raw: buf.Bytes()}, nil
+}
+
+// Decodes a pending value into out, if non-nil. Returns false for a pending delete.
+func decodeDeferred(d *cbg.Deferred, out cbor.Unmarshaler) (bool, error) {
+	if d == nil {
+		return false, nil
+	}
+	if out != nil {
+		if err := out.UnmarshalCBOR(bytes.NewReader(d.Raw)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,101 @@
+package adt_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+)
+
+func TestMapBatch(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	m, err := adt.MakeEmptyMap(store, 5)
+	require.NoError(t, err)
+	require.NoError(t, m.Put(abi.UIntKey(1), intValue(1)))
+	require.NoError(t, m.Put(abi.UIntKey(2), intValue(2)))
+	before, err := m.Root()
+	require.NoError(t, err)
+
+	batch := adt.NewMapBatch(m)
+	v := intValue(10)
+	require.NoError(t, batch.Put(abi.UIntKey(1), v))
+	*v = 11 // Mutating after the write does not affect the batch.
+	require.NoError(t, batch.Put(abi.UIntKey(3), intValue(3)))
+	batch.Delete(abi.UIntKey(2))
+
+	// Reads observe pending writes, but the map is untouched until commit.
+	var out cbg.CborInt
+	found, err := batch.Get(abi.UIntKey(1), &out)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cbg.CborInt(10), out)
+	found, err = batch.Get(abi.UIntKey(2), nil)
+	require.NoError(t, err)
+	assert.False(t, found)
+	found, err = m.Get(abi.UIntKey(2), nil)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	root, err := batch.Commit()
+	require.NoError(t, err)
+	assert.NotEqual(t, before, root)
+
+	committed, err := adt.AsMap(store, root, 5)
+	require.NoError(t, err)
+	keys, err := committed.CollectKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+	found, err = committed.Get(abi.UIntKey(1), &out)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cbg.CborInt(10), out)
+	found, err = committed.Get(abi.UIntKey(2), nil)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestArrayBatch(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	arr, err := adt.MakeEmptyArray(store, 3)
+	require.NoError(t, err)
+	require.NoError(t, arr.Set(0, intValue(0)))
+
+	batch := adt.NewArrayBatch(arr)
+	for i := uint64(0); i < 5; i++ {
+		require.NoError(t, batch.Set(4, intValue(i)))
+	}
+	require.NoError(t, batch.Set(2, intValue(2)))
+	batch.Delete(0)
+	batch.Delete(9) // Absent indices may be deleted.
+
+	var out cbg.CborInt
+	found, err := batch.Get(4, &out)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cbg.CborInt(4), out)
+	assert.Equal(t, uint64(1), arr.Length())
+
+	root, err := batch.Commit()
+	require.NoError(t, err)
+
+	committed, err := adt.AsArray(store, root, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), committed.Length())
+	found, err = committed.Get(0, nil)
+	require.NoError(t, err)
+	assert.False(t, found)
+	found, err = committed.Get(4, &out)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cbg.CborInt(4), out)
+}
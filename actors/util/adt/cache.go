@@ -0,0 +1,177 @@
+package adt
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Counts of the operations served by a CachingStore.
+type StoreMetrics struct {
+	Hits       uint64 // Reads served from the cache.
+	Misses     uint64 // Reads passed through to the underlying store.
+	ReadBytes  uint64 // Bytes read from the underlying store.
+	Writes     uint64 // Writes passed through to the underlying store.
+	WriteBytes uint64 // Bytes written to the underlying store.
+}
+
+// CachingStore decorates a Store with a least-recently-used cache of encoded objects, up to a total of
+// cacheBytes, and counts the reads and writes it serves.
+// Objects are cached in encoded form and decoded afresh on every read, so values returned by Get may be
+// mutated freely. Objects written through the store are cached too, since they are commonly read back soon after.
+// Objects whose types do not implement CBOR (un)marshaling pass through uncached.
+// A CachingStore is safe for concurrent use if the underlying store is.
+type CachingStore struct {
+	inner      Store
+	cacheBytes int
+
+	lk      sync.Mutex
+	size    int
+	entries map[cid.Cid]*list.Element
+	recent  *list.List // Most recently used at the front.
+	metrics StoreMetrics
+}
+
+type cachingStoreEntry struct {
+	c   cid.Cid
+	raw []byte
+}
+
+var _ Store = &CachingStore{}
+
+func NewCachingStore(inner Store, cacheBytes int) *CachingStore {
+	return &CachingStore{
+		inner:      inner,
+		cacheBytes: cacheBytes,
+		entries:    make(map[cid.Cid]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+func (s *CachingStore) Context() context.Context {
+	return s.inner.Context()
+}
+
+func (s *CachingStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	um, ok := out.(cbor.Unmarshaler)
+	if !ok {
+		s.lk.Lock()
+		s.metrics.Misses++
+		s.lk.Unlock()
+		return s.inner.Get(ctx, c, out)
+	}
+
+	s.lk.Lock()
+	raw, found := s.lookup(c)
+	if found {
+		s.metrics.Hits++
+	} else {
+		s.metrics.Misses++
+	}
+	s.lk.Unlock()
+
+	if !found {
+		var d cbg.Deferred
+		if err := s.inner.Get(ctx, c, &d); err != nil {
+			return err
+		}
+		raw = d.Raw
+		s.lk.Lock()
+		s.metrics.ReadBytes += uint64(len(raw))
+		s.insert(c, raw)
+		s.lk.Unlock()
+	}
+	return um.UnmarshalCBOR(bytes.NewReader(raw))
+}
+
+func (s *CachingStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	m, ok := v.(cbor.Marshaler)
+	if !ok {
+		s.lk.Lock()
+		s.metrics.Writes++
+		s.lk.Unlock()
+		return s.inner.Put(ctx, v)
+	}
+
+	// Encode once, writing the encoding through to the underlying store and retaining it in the cache.
+	var buf bytes.Buffer
+	if err := m.MarshalCBOR(&buf); err != nil {
+		return cid.Undef, err
+	}
+	raw := buf.Bytes()
+	c, err := s.inner.Put(ctx, &cbg.Deferred{Raw: raw})
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.metrics.Writes++
+	s.metrics.WriteBytes += uint64(len(raw))
+	s.insert(c, raw)
+	return c, nil
+}
+
+// Returns a snapshot of the counts of operations served so far.
+func (s *CachingStore) Metrics() StoreMetrics {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return s.metrics
+}
+
+// The following methods expose the counts of operations reaching the underlying store in the form
+// expected by the test VM's call statistics.
+
+func (s *CachingStore) ReadCount() uint64 {
+	return s.Metrics().Misses
+}
+
+func (s *CachingStore) WriteCount() uint64 {
+	return s.Metrics().Writes
+}
+
+func (s *CachingStore) ReadSize() uint64 {
+	return s.Metrics().ReadBytes
+}
+
+func (s *CachingStore) WriteSize() uint64 {
+	return s.Metrics().WriteBytes
+}
+
+// Returns the cached encoding of an object, marking it most recently used.
+// Must be called with the lock held.
+func (s *CachingStore) lookup(c cid.Cid) ([]byte, bool) {
+	elem, ok := s.entries[c]
+	if !ok {
+		return nil, false
+	}
+	s.recent.MoveToFront(elem)
+	return elem.Value.(*cachingStoreEntry).raw, true
+}
+
+// Caches the encoding of an object, evicting the least recently used objects to stay within the cache size.
+// Objects larger than the whole cache are not cached.
+// Must be called with the lock held.
+func (s *CachingStore) insert(c cid.Cid, raw []byte) {
+	if len(raw) > s.cacheBytes {
+		return
+	}
+	if elem, ok := s.entries[c]; ok {
+		s.recent.MoveToFront(elem)
+		return
+	}
+	s.entries[c] = s.recent.PushFront(&cachingStoreEntry{c: c, raw: raw})
+	s.size += len(raw)
+	for s.size > s.cacheBytes {
+		oldest := s.recent.Back()
+		entry := oldest.Value.(*cachingStoreEntry)
+		s.recent.Remove(oldest)
+		delete(s.entries, entry.c)
+		s.size -= len(entry.raw)
+	}
+}
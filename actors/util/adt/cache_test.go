@@ -0,0 +1,104 @@
+package adt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+func TestCachingStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads written objects from cache", func(t *testing.T) {
+		bs := ipld.NewMetricsBlockStore(ipld.NewBlockStoreInMemory())
+		store := adt.NewCachingStore(adt.WrapBlockStore(ctx, bs), 1<<20)
+
+		value := big.NewInt(1234)
+		c, err := store.Put(ctx, &value)
+		require.NoError(t, err)
+
+		var out abi.TokenAmount
+		require.NoError(t, store.Get(ctx, c, &out))
+		assert.Equal(t, value, out)
+		require.NoError(t, store.Get(ctx, c, &out))
+		assert.Equal(t, value, out)
+
+		metrics := store.Metrics()
+		assert.Equal(t, uint64(2), metrics.Hits)
+		assert.Equal(t, uint64(0), metrics.Misses)
+		assert.Equal(t, uint64(1), metrics.Writes)
+		assert.Equal(t, uint64(0), bs.Reads)
+		assert.Equal(t, uint64(1), bs.Writes)
+		assert.Equal(t, bs.WriteBytes, metrics.WriteBytes)
+	})
+
+	t.Run("caches objects read from the store", func(t *testing.T) {
+		bs := ipld.NewMetricsBlockStore(ipld.NewBlockStoreInMemory())
+		m, err := adt.MakeEmptyMap(adt.WrapBlockStore(ctx, bs), 5)
+		require.NoError(t, err)
+		for i := uint64(0); i < 100; i++ {
+			value := big.NewIntUnsigned(i)
+			require.NoError(t, m.Put(abi.UIntKey(i), &value))
+		}
+		root, err := m.Root()
+		require.NoError(t, err)
+
+		store := adt.NewCachingStore(adt.WrapBlockStore(ctx, bs), 1<<20)
+		readAll := func() {
+			m, err := adt.AsMap(store, root, 5)
+			require.NoError(t, err)
+			count := 0
+			var value abi.TokenAmount
+			require.NoError(t, m.ForEach(&value, func(string) error {
+				count++
+				return nil
+			}))
+			assert.Equal(t, 100, count)
+		}
+
+		readAll()
+		first := store.Metrics()
+		assert.Equal(t, uint64(0), first.Hits)
+		assert.Equal(t, bs.Reads, first.Misses)
+		assert.Equal(t, bs.ReadBytes, first.ReadBytes)
+
+		readAll()
+		second := store.Metrics()
+		assert.Equal(t, first.Misses, second.Misses)
+		assert.Equal(t, first.Misses, second.Hits)
+		assert.Equal(t, first.Misses, bs.Reads)
+	})
+
+	t.Run("evicts least recently used objects", func(t *testing.T) {
+		bs := ipld.NewMetricsBlockStore(ipld.NewBlockStoreInMemory())
+		inner := adt.WrapBlockStore(ctx, bs)
+		var cids []cid.Cid
+		for i := int64(0); i < 3; i++ {
+			value := big.NewInt(i + 1)
+			c, err := inner.Put(ctx, &value)
+			require.NoError(t, err)
+			cids = append(cids, c)
+		}
+
+		// Each small value encodes to 3 bytes, so the cache holds two of them.
+		store := adt.NewCachingStore(inner, 6)
+		var out abi.TokenAmount
+		for _, c := range cids {
+			require.NoError(t, store.Get(ctx, c, &out))
+		}
+		require.NoError(t, store.Get(ctx, cids[2], &out))
+		assert.Equal(t, uint64(1), store.Metrics().Hits)
+		require.NoError(t, store.Get(ctx, cids[0], &out))
+		assert.Equal(t, uint64(1), store.Metrics().Hits)
+		assert.Equal(t, uint64(4), bs.Reads)
+		assert.Equal(t, big.NewInt(1), out)
+	})
+}
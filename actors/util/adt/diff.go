@@ -0,0 +1,464 @@
+package adt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Receives the differences between two maps, as found by DiffMap.
+// Values are provided undecoded. Diffing halts at the first callback to return an error.
+type MapDiffer interface {
+	Add(key string, val *cbg.Deferred) error
+	Modify(key string, from, to *cbg.Deferred) error
+	Remove(key string, val *cbg.Deferred) error
+}
+
+// Receives the differences between two arrays, as found by DiffArray.
+// Values are provided undecoded. Diffing halts at the first callback to return an error.
+type ArrayDiffer interface {
+	Add(key uint64, val *cbg.Deferred) error
+	Modify(key uint64, from, to *cbg.Deferred) error
+	Remove(key uint64, val *cbg.Deferred) error
+}
+
+// Walks two HAMT-based maps, reporting each entry added, modified or removed in `cur` relative to `prev`.
+// The maps must have the same bitwidth.
+// Subtrees shared by both maps are skipped without being loaded, so the cost is proportional to the
+// size of the difference rather than the size of the maps.
+func DiffMap(s Store, prev, cur cid.Cid, out MapDiffer) error {
+	if prev.Equals(cur) {
+		return nil
+	}
+	var prevRoot, curRoot hamt.Node
+	if err := s.Get(s.Context(), prev, &prevRoot); err != nil {
+		return xerrors.Errorf("failed to load map root %v: %w", prev, err)
+	}
+	if err := s.Get(s.Context(), cur, &curRoot); err != nil {
+		return xerrors.Errorf("failed to load map root %v: %w", cur, err)
+	}
+	return diffHamtNodes(s, &prevRoot, &curRoot, out)
+}
+
+func diffHamtNodes(s Store, prev, cur *hamt.Node, out MapDiffer) error {
+	width := prev.Bitfield.BitLen()
+	if cur.Bitfield.BitLen() > width {
+		width = cur.Bitfield.BitLen()
+	}
+	// Pointers are compacted, so track the index of the next pointer in each node.
+	pi, ci := 0, 0
+	for idx := 0; idx < width; idx++ {
+		inPrev, inCur := prev.Bitfield.Bit(idx) == 1, cur.Bitfield.Bit(idx) == 1
+		var prevKVs, curKVs []*hamt.KV
+		var err error
+		if inPrev && inCur {
+			pp, cp := prev.Pointers[pi], cur.Pointers[ci]
+			pi++
+			ci++
+			if len(pp.KVs) == 0 && len(cp.KVs) == 0 {
+				if pp.Link.Equals(cp.Link) {
+					continue
+				}
+				var prevChild, curChild hamt.Node
+				if err := s.Get(s.Context(), pp.Link, &prevChild); err != nil {
+					return xerrors.Errorf("failed to load map node %v: %w", pp.Link, err)
+				}
+				if err := s.Get(s.Context(), cp.Link, &curChild); err != nil {
+					return xerrors.Errorf("failed to load map node %v: %w", cp.Link, err)
+				}
+				if err := diffHamtNodes(s, &prevChild, &curChild, out); err != nil {
+					return err
+				}
+				continue
+			}
+			// A bucket of entries on one side and a subtree on the other, or buckets on both.
+			if prevKVs, err = collectHamtKVs(s, pp); err != nil {
+				return err
+			}
+			if curKVs, err = collectHamtKVs(s, cp); err != nil {
+				return err
+			}
+		} else if inPrev {
+			if prevKVs, err = collectHamtKVs(s, prev.Pointers[pi]); err != nil {
+				return err
+			}
+			pi++
+		} else if inCur {
+			if curKVs, err = collectHamtKVs(s, cur.Pointers[ci]); err != nil {
+				return err
+			}
+			ci++
+		}
+		if err := diffHamtKVs(prevKVs, curKVs, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collects all entries held in or beneath a pointer.
+func collectHamtKVs(s Store, p *hamt.Pointer) ([]*hamt.KV, error) {
+	if len(p.KVs) > 0 {
+		return p.KVs, nil
+	}
+	var node hamt.Node
+	if err := s.Get(s.Context(), p.Link, &node); err != nil {
+		return nil, xerrors.Errorf("failed to load map node %v: %w", p.Link, err)
+	}
+	var kvs []*hamt.KV
+	for _, child := range node.Pointers {
+		childKVs, err := collectHamtKVs(s, child)
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, childKVs...)
+	}
+	return kvs, nil
+}
+
+func diffHamtKVs(prev, cur []*hamt.KV, out MapDiffer) error {
+	prevValues := make(map[string]*cbg.Deferred, len(prev))
+	for _, kv := range prev {
+		prevValues[string(kv.Key)] = kv.Value
+	}
+	for _, kv := range cur {
+		key := string(kv.Key)
+		if before, ok := prevValues[key]; ok {
+			delete(prevValues, key)
+			if bytes.Equal(before.Raw, kv.Value.Raw) {
+				continue
+			}
+			if err := out.Modify(key, before, kv.Value); err != nil {
+				return err
+			}
+		} else if err := out.Add(key, kv.Value); err != nil {
+			return err
+		}
+	}
+	// Report removals in the order of the previous entries.
+	for _, kv := range prev {
+		if _, ok := prevValues[string(kv.Key)]; !ok {
+			continue
+		}
+		if err := out.Remove(string(kv.Key), kv.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walks two AMT-based arrays, reporting each entry added, modified or removed in `cur` relative to `prev`,
+// in index order.
+// The arrays must have the same bitwidth.
+// Subtrees shared by both arrays are skipped without being loaded, so the cost is proportional to the
+// size of the difference rather than the size of the arrays.
+func DiffArray(s Store, prev, cur cid.Cid, out ArrayDiffer) error {
+	if prev.Equals(cur) {
+		return nil
+	}
+	var prevRoot, curRoot amtRoot
+	if err := s.Get(s.Context(), prev, &prevRoot); err != nil {
+		return xerrors.Errorf("failed to load array root %v: %w", prev, err)
+	}
+	if err := s.Get(s.Context(), cur, &curRoot); err != nil {
+		return xerrors.Errorf("failed to load array root %v: %w", cur, err)
+	}
+	if prevRoot.BitWidth != curRoot.BitWidth {
+		return xerrors.Errorf("cannot diff arrays with different bitwidths %d and %d", prevRoot.BitWidth, curRoot.BitWidth)
+	}
+	d := amtDiffer{amtWalker: amtWalker{store: s, width: uint64(1) << prevRoot.BitWidth}, out: out}
+	return d.diff(&prevRoot.Node, prevRoot.Height, &curRoot.Node, curRoot.Height, 0)
+}
+
+type amtDiffer struct {
+	amtWalker
+	out ArrayDiffer
+}
+
+// Traverses the nodes of an AMT of some width.
+type amtWalker struct {
+	store Store
+	width uint64
+}
+
+// Diffs two nodes covering the same range of indices from offset, either of which may be nil.
+func (d *amtDiffer) diff(prev *amtNode, prevHeight uint64, cur *amtNode, curHeight uint64, offset uint64) error {
+	if prev == nil {
+		return d.each(cur, curHeight, offset, d.out.Add)
+	}
+	if cur == nil {
+		return d.each(prev, prevHeight, offset, d.out.Remove)
+	}
+	// The shorter array lies entirely within the first child of the taller one.
+	if prevHeight > curHeight {
+		return d.diffTaller(prev, prevHeight, offset, func(first *amtNode) error {
+			return d.diff(first, prevHeight-1, cur, curHeight, offset)
+		}, d.out.Remove)
+	}
+	if curHeight > prevHeight {
+		return d.diffTaller(cur, curHeight, offset, func(first *amtNode) error {
+			return d.diff(prev, prevHeight, first, curHeight-1, offset)
+		}, d.out.Add)
+	}
+
+	if prevHeight == 0 {
+		prevValues, curValues := prev.expandValues(d.width), cur.expandValues(d.width)
+		for i := uint64(0); i < d.width; i++ {
+			before, after := prevValues[i], curValues[i]
+			var err error
+			if before != nil && after != nil {
+				if !bytes.Equal(before.Raw, after.Raw) {
+					err = d.out.Modify(offset+i, before, after)
+				}
+			} else if before != nil {
+				err = d.out.Remove(offset+i, before)
+			} else if after != nil {
+				err = d.out.Add(offset+i, after)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	span := pow(d.width, prevHeight)
+	prevLinks, curLinks := prev.expandLinks(d.width), cur.expandLinks(d.width)
+	for i := uint64(0); i < d.width; i++ {
+		if prevLinks[i] == nil && curLinks[i] == nil {
+			continue
+		}
+		if prevLinks[i] != nil && curLinks[i] != nil && prevLinks[i].Equals(*curLinks[i]) {
+			continue
+		}
+		prevChild, err := d.load(prevLinks[i])
+		if err != nil {
+			return err
+		}
+		curChild, err := d.load(curLinks[i])
+		if err != nil {
+			return err
+		}
+		if err := d.diff(prevChild, prevHeight-1, curChild, curHeight-1, offset+i*span); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diffs the first child of a node against the other array with fn, and reports all values beneath
+// the node's other children with report.
+func (d *amtDiffer) diffTaller(node *amtNode, height, offset uint64, fn func(first *amtNode) error,
+	report func(uint64, *cbg.Deferred) error) error {
+	span := pow(d.width, height)
+	links := node.expandLinks(d.width)
+	first, err := d.load(links[0])
+	if err != nil {
+		return err
+	}
+	if err := fn(first); err != nil {
+		return err
+	}
+	for i := uint64(1); i < d.width; i++ {
+		child, err := d.load(links[i])
+		if err != nil {
+			return err
+		}
+		if err := d.each(child, height-1, offset+i*span, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reports every value in or beneath a node, which may be nil.
+func (d *amtWalker) each(node *amtNode, height, offset uint64, report func(uint64, *cbg.Deferred) error) error {
+	if node == nil {
+		return nil
+	}
+	if height == 0 {
+		for i, v := range node.expandValues(d.width) {
+			if v == nil {
+				continue
+			}
+			if err := report(offset+uint64(i), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	span := pow(d.width, height)
+	for i, c := range node.expandLinks(d.width) {
+		child, err := d.load(c)
+		if err != nil {
+			return err
+		}
+		if err := d.each(child, height-1, offset+uint64(i)*span, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Loads a node from a link, or returns nil for an absent link.
+func (d *amtWalker) load(c *cid.Cid) (*amtNode, error) {
+	if c == nil {
+		return nil, nil
+	}
+	var node amtNode
+	if err := d.store.Get(d.store.Context(), *c, &node); err != nil {
+		return nil, xerrors.Errorf("failed to load array node %v: %w", *c, err)
+	}
+	return &node, nil
+}
+
+func pow(base, exp uint64) uint64 {
+	result := uint64(1)
+	for i := uint64(0); i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+// The serialized form of an AMT root, as defined by go-amt-ipld, decoded for traversal by DiffArray.
+type amtRoot struct {
+	BitWidth uint64
+	Height   uint64
+	Count    uint64
+	Node     amtNode
+}
+
+// The serialized form of an AMT node, in which only the links or values present in the bitmap are stored.
+type amtNode struct {
+	Bmap   []byte
+	Links  []cid.Cid
+	Values []*cbg.Deferred
+}
+
+func (n *amtNode) present(i uint64) bool {
+	return i/8 < uint64(len(n.Bmap)) && n.Bmap[i/8]&(1<<(i%8)) != 0
+}
+
+// Returns the node's links indexed by slot, with nil for absent slots.
+func (n *amtNode) expandLinks(width uint64) []*cid.Cid {
+	links := make([]*cid.Cid, width)
+	next := 0
+	for i := uint64(0); i < width && next < len(n.Links); i++ {
+		if n.present(i) {
+			links[i] = &n.Links[next]
+			next++
+		}
+	}
+	return links
+}
+
+// Returns the node's values indexed by slot, with nil for absent slots.
+func (n *amtNode) expandValues(width uint64) []*cbg.Deferred {
+	values := make([]*cbg.Deferred, width)
+	next := 0
+	for i := uint64(0); i < width && next < len(n.Values); i++ {
+		if n.present(i) {
+			values[i] = n.Values[next]
+			next++
+		}
+	}
+	return values
+}
+
+func (t *amtRoot) UnmarshalCBOR(r io.Reader) error {
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || extra != 4 {
+		return fmt.Errorf("expected amt root to be an array of 4 fields")
+	}
+	for _, field := range []*uint64{&t.BitWidth, &t.Height, &t.Count} {
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		*field = extra
+	}
+	return t.Node.UnmarshalCBOR(br)
+}
+
+func (t *amtNode) UnmarshalCBOR(r io.Reader) error {
+	*t = amtNode{}
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || extra != 3 {
+		return fmt.Errorf("expected amt node to be an array of 3 fields")
+	}
+
+	// t.Bmap ([]uint8) (slice)
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Bmap: byte array too large (%d)", extra)
+	}
+	t.Bmap = make([]byte, extra)
+	if _, err := io.ReadFull(br, t.Bmap); err != nil {
+		return err
+	}
+
+	// t.Links ([]cid.Cid) (slice)
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Links: array too large (%d)", extra)
+	}
+	for i := 0; i < int(extra); i++ {
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("reading cid field t.Links failed: %w", err)
+		}
+		t.Links = append(t.Links, c)
+	}
+
+	// t.Values ([]*cbg.Deferred) (slice)
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Values: array too large (%d)", extra)
+	}
+	for i := 0; i < int(extra); i++ {
+		var v cbg.Deferred
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+		t.Values = append(t.Values, &v)
+	}
+	return nil
+}
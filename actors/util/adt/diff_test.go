@@ -0,0 +1,180 @@
+package adt_test
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+)
+
+func TestDiffMap(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	prev, err := adt.MakeEmptyMap(store, 3)
+	require.NoError(t, err)
+	for i := uint64(0); i < 200; i++ {
+		require.NoError(t, prev.Put(abi.UIntKey(i), intValue(i)))
+	}
+	prevRoot, err := prev.Root()
+	require.NoError(t, err)
+
+	cur, err := adt.AsMap(store, prevRoot, 3)
+	require.NoError(t, err)
+	require.NoError(t, cur.Put(abi.UIntKey(200), intValue(200)))
+	require.NoError(t, cur.Put(abi.UIntKey(5), intValue(500)))
+	require.NoError(t, cur.Delete(abi.UIntKey(7)))
+	require.NoError(t, cur.Put(abi.UIntKey(9), intValue(9))) // Unchanged.
+	curRoot, err := cur.Root()
+	require.NoError(t, err)
+
+	t.Run("reports changes", func(t *testing.T) {
+		d := &mapDiff{}
+		require.NoError(t, adt.DiffMap(store, prevRoot, curRoot, d))
+		assert.Equal(t, []string{"add 200 200", "modify 5 5 500", "remove 7 7"}, d.sorted())
+	})
+
+	t.Run("reports reverse changes", func(t *testing.T) {
+		d := &mapDiff{}
+		require.NoError(t, adt.DiffMap(store, curRoot, prevRoot, d))
+		assert.Equal(t, []string{"add 7 7", "modify 5 500 5", "remove 200 200"}, d.sorted())
+	})
+
+	t.Run("identical maps", func(t *testing.T) {
+		d := &mapDiff{}
+		require.NoError(t, adt.DiffMap(store, curRoot, curRoot, d))
+		assert.Empty(t, d.changes)
+	})
+
+	t.Run("from empty", func(t *testing.T) {
+		empty, err := adt.StoreEmptyMap(store, 3)
+		require.NoError(t, err)
+		d := &mapDiff{}
+		require.NoError(t, adt.DiffMap(store, empty, prevRoot, d))
+		assert.Len(t, d.changes, 200)
+	})
+}
+
+func TestDiffArray(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	prev, err := adt.MakeEmptyArray(store, 2)
+	require.NoError(t, err)
+	for i := uint64(0); i < 50; i++ {
+		require.NoError(t, prev.Set(i, intValue(i)))
+	}
+	prevRoot, err := prev.Root()
+	require.NoError(t, err)
+
+	cur, err := adt.AsArray(store, prevRoot, 2)
+	require.NoError(t, err)
+	require.NoError(t, cur.Set(3, intValue(30)))
+	require.NoError(t, cur.Delete(20))
+	require.NoError(t, cur.Set(1000, intValue(1000))) // Increases the height.
+	curRoot, err := cur.Root()
+	require.NoError(t, err)
+
+	t.Run("reports changes in index order", func(t *testing.T) {
+		d := &arrayDiff{}
+		require.NoError(t, adt.DiffArray(store, prevRoot, curRoot, d))
+		assert.Equal(t, []string{"modify 3 3 30", "remove 20 20", "add 1000 1000"}, d.changes)
+	})
+
+	t.Run("reports reverse changes", func(t *testing.T) {
+		d := &arrayDiff{}
+		require.NoError(t, adt.DiffArray(store, curRoot, prevRoot, d))
+		assert.Equal(t, []string{"modify 3 30 3", "add 20 20", "remove 1000 1000"}, d.changes)
+	})
+
+	t.Run("from empty", func(t *testing.T) {
+		empty, err := adt.StoreEmptyArray(store, 2)
+		require.NoError(t, err)
+		d := &arrayDiff{}
+		require.NoError(t, adt.DiffArray(store, empty, prevRoot, d))
+		assert.Len(t, d.changes, 50)
+		assert.Equal(t, "add 0 0", d.changes[0])
+		assert.Equal(t, "add 49 49", d.changes[49])
+	})
+
+	t.Run("different bitwidths", func(t *testing.T) {
+		other, err := adt.StoreEmptyArray(store, 3)
+		require.NoError(t, err)
+		require.Error(t, adt.DiffArray(store, other, prevRoot, &arrayDiff{}))
+	})
+}
+
+func intValue(i uint64) *cbg.CborInt {
+	v := cbg.CborInt(i)
+	return &v
+}
+
+func decodeInt(v *cbg.Deferred) int64 {
+	var i cbg.CborInt
+	if err := i.UnmarshalCBOR(bytes.NewReader(v.Raw)); err != nil {
+		panic(err)
+	}
+	return int64(i)
+}
+
+type mapDiff struct {
+	changes []string
+}
+
+func (d *mapDiff) key(k string) uint64 {
+	i, err := abi.ParseUIntKey(k)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (d *mapDiff) Add(key string, val *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("add %d %d", d.key(key), decodeInt(val)))
+	return nil
+}
+
+func (d *mapDiff) Modify(key string, from, to *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("modify %d %d %d", d.key(key), decodeInt(from), decodeInt(to)))
+	return nil
+}
+
+func (d *mapDiff) Remove(key string, val *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("remove %d %d", d.key(key), decodeInt(val)))
+	return nil
+}
+
+// Map changes are reported in HAMT order, so sort them for comparison.
+func (d *mapDiff) sorted() []string {
+	sorted := append([]string{}, d.changes...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+type arrayDiff struct {
+	changes []string
+}
+
+func (d *arrayDiff) Add(key uint64, val *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("add %d %d", key, decodeInt(val)))
+	return nil
+}
+
+func (d *arrayDiff) Modify(key uint64, from, to *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("modify %d %d %d", key, decodeInt(from), decodeInt(to)))
+	return nil
+}
+
+func (d *arrayDiff) Remove(key uint64, val *cbg.Deferred) error {
+	d.changes = append(d.changes, fmt.Sprintf("remove %d %d", key, decodeInt(val)))
+	return nil
+}
@@ -106,6 +106,48 @@ func (mm *Multimap) ForEach(key abi.Keyer, out cbor.Unmarshaler, fn func(i int64
 	return nil
 }
 
+// Iterates up to limit entries for a key in the order they were inserted, starting from the entry at index `cursor`,
+// as for ForEach. Returns the cursor from which to continue, and whether any entries remain.
+// A cursor of zero starts from the first entry.
+func (mm *Multimap) ForEachPage(key abi.Keyer, cursor uint64, limit int, out cbor.Unmarshaler, fn func(i int64) error) (uint64, bool, error) {
+	if limit <= 0 {
+		return 0, false, xerrors.Errorf("invalid page limit %d", limit)
+	}
+	array, found, err := mm.Get(key)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	count := 0
+	next := cursor
+	more := false
+	err = array.ForEachFrom(cursor, out, func(i int64) error {
+		if count == limit {
+			more = true
+			return errPageFull
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+		count++
+		next = uint64(i) + 1
+		return nil
+	})
+	if err != nil && err != errPageFull {
+		return 0, false, err
+	}
+	return next, more, nil
+}
+
+// Returns the number of entries for a key.
+// The count is maintained by the underlying array, so this does not iterate the entries.
+func (mm *Multimap) Count(key abi.Keyer) (uint64, error) {
+	array, found, err := mm.Get(key)
+	if err != nil || !found {
+		return 0, err
+	}
+	return array.Length(), nil
+}
+
 func (mm *Multimap) ForAll(fn func(k string, arr *Array) error) error {
 	var arrRoot cbg.CborCid
 	if err := mm.mp.ForEach(&arrRoot, func(k string) error {
@@ -0,0 +1,80 @@
+package adt_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+)
+
+func TestMultimapPagination(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+	mm, err := adt.MakeEmptyMultimap(store, 5, 3)
+	require.NoError(t, err)
+
+	key := abi.UIntKey(1)
+	for i := int64(0); i < 10; i++ {
+		value := cbg.CborInt(100 + i)
+		require.NoError(t, mm.Add(key, &value))
+	}
+
+	t.Run("counts entries per key", func(t *testing.T) {
+		count, err := mm.Count(key)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), count)
+
+		count, err = mm.Count(abi.UIntKey(2))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+
+	t.Run("pages through entries in insertion order", func(t *testing.T) {
+		var values []int64
+		var value cbg.CborInt
+		cursor := uint64(0)
+		pages := 0
+		for {
+			next, more, err := mm.ForEachPage(key, cursor, 4, &value, func(i int64) error {
+				assert.Equal(t, int64(len(values)), i)
+				values = append(values, int64(value))
+				return nil
+			})
+			require.NoError(t, err)
+			pages++
+			if !more {
+				break
+			}
+			cursor = next
+		}
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, []int64{100, 101, 102, 103, 104, 105, 106, 107, 108, 109}, values)
+	})
+
+	t.Run("exact final page reports no more entries", func(t *testing.T) {
+		next, more, err := mm.ForEachPage(key, 5, 5, nil, func(int64) error { return nil })
+		require.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, uint64(10), next)
+	})
+
+	t.Run("missing key has no entries", func(t *testing.T) {
+		_, more, err := mm.ForEachPage(abi.UIntKey(2), 0, 5, nil, func(int64) error {
+			t.Fatal("unexpected entry")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, more)
+	})
+
+	t.Run("rejects invalid limit", func(t *testing.T) {
+		_, _, err := mm.ForEachPage(key, 0, 0, nil, func(int64) error { return nil })
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package adt
+
+import (
+	"sync"
+
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Visits every entry of the HAMT-based map with root `root`, calling fn from up to `workers` goroutines,
+// and returns fn's results in the order in which Map.ForEach would visit the entries.
+// The subtrees beneath the root node are traversed in parallel, so the store and fn must be safe for
+// concurrent use. This is intended for off-chain processing of large state, and must not be used by actors.
+// If any call fails, the error of the earliest failing subtree is returned.
+func ParForEachMap[T any](s Store, root cid.Cid, workers int, fn func(key string, val *cbg.Deferred) (T, error)) ([]T, error) {
+	var node hamt.Node
+	if err := s.Get(s.Context(), root, &node); err != nil {
+		return nil, xerrors.Errorf("failed to load map root %v: %w", root, err)
+	}
+	return parallelize(len(node.Pointers), workers, func(i int) ([]T, error) {
+		var results []T
+		err := walkHamtPointer(s, node.Pointers[i], func(kv *hamt.KV) error {
+			r, err := fn(string(kv.Key), kv.Value)
+			if err != nil {
+				return err
+			}
+			results = append(results, r)
+			return nil
+		})
+		return results, err
+	})
+}
+
+// Visits every value of the AMT-based array with root `root`, calling fn from up to `workers` goroutines,
+// and returns fn's results in index order.
+// The subtrees beneath the root node are traversed in parallel, so the store and fn must be safe for
+// concurrent use. This is intended for off-chain processing of large state, and must not be used by actors.
+// If any call fails, the error of the earliest failing subtree is returned.
+func ParForEachArray[T any](s Store, root cid.Cid, workers int, fn func(i uint64, val *cbg.Deferred) (T, error)) ([]T, error) {
+	var r amtRoot
+	if err := s.Get(s.Context(), root, &r); err != nil {
+		return nil, xerrors.Errorf("failed to load array root %v: %w", root, err)
+	}
+	width := uint64(1) << r.BitWidth
+	walker := amtWalker{store: s, width: width}
+	visit := func(results *[]T) func(i uint64, val *cbg.Deferred) error {
+		return func(i uint64, val *cbg.Deferred) error {
+			res, err := fn(i, val)
+			if err != nil {
+				return err
+			}
+			*results = append(*results, res)
+			return nil
+		}
+	}
+	if r.Height == 0 {
+		var results []T
+		err := walker.each(&r.Node, 0, 0, visit(&results))
+		return results, err
+	}
+
+	links := r.Node.expandLinks(width)
+	span := pow(width, r.Height)
+	return parallelize(len(links), workers, func(i int) ([]T, error) {
+		child, err := walker.load(links[i])
+		if err != nil {
+			return nil, err
+		}
+		var results []T
+		err = walker.each(child, r.Height-1, uint64(i)*span, visit(&results))
+		return results, err
+	})
+}
+
+// Calls fn for each task index in [0, n) from up to `workers` goroutines, and concatenates the results in task order.
+func parallelize[T any](n, workers int, fn func(i int) ([]T, error)) ([]T, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([][]T, n)
+	errs := make([]error, n)
+	tasks := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				results[i], errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		tasks <- i
+	}
+	close(tasks)
+	wg.Wait()
+
+	var all []T
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, results[i]...)
+	}
+	return all, nil
+}
+
+// Visits every entry held in or beneath a HAMT pointer, in iteration order.
+func walkHamtPointer(s Store, p *hamt.Pointer, fn func(kv *hamt.KV) error) error {
+	if len(p.KVs) > 0 {
+		for _, kv := range p.KVs {
+			if err := fn(kv); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var node hamt.Node
+	if err := s.Get(s.Context(), p.Link, &node); err != nil {
+		return xerrors.Errorf("failed to load map node %v: %w", p.Link, err)
+	}
+	for _, child := range node.Pointers {
+		if err := walkHamtPointer(s, child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package adt_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+func TestParForEachMap(t *testing.T) {
+	store := adt.WrapBlockStore(context.Background(), ipld.NewSyncBlockStore(ipld.NewBlockStoreInMemory()))
+	m, err := adt.MakeEmptyMap(store, 3)
+	require.NoError(t, err)
+	for i := uint64(0); i < 500; i++ {
+		require.NoError(t, m.Put(abi.UIntKey(i), intValue(i)))
+	}
+	root, err := m.Root()
+	require.NoError(t, err)
+
+	// Results are in the same order as sequential iteration.
+	var expected []int64
+	var v cbg.CborInt
+	require.NoError(t, m.ForEach(&v, func(string) error {
+		expected = append(expected, int64(v))
+		return nil
+	}))
+
+	for _, workers := range []int{1, 4, 16} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			values, err := adt.ParForEachMap(store, root, workers, func(_ string, val *cbg.Deferred) (int64, error) {
+				return decodeInt(val), nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, expected, values)
+		})
+	}
+
+	t.Run("returns an error", func(t *testing.T) {
+		_, err := adt.ParForEachMap(store, root, 4, func(key string, _ *cbg.Deferred) (int64, error) {
+			return 0, fmt.Errorf("failed")
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestParForEachArray(t *testing.T) {
+	store := adt.WrapBlockStore(context.Background(), ipld.NewSyncBlockStore(ipld.NewBlockStoreInMemory()))
+
+	for _, n := range []uint64{0, 5, 300} {
+		t.Run(fmt.Sprintf("%d values", n), func(t *testing.T) {
+			arr, err := adt.MakeEmptyArray(store, 3)
+			require.NoError(t, err)
+			var expected []uint64
+			for i := uint64(0); i < n; i++ {
+				if i%3 == 0 {
+					continue // Leave gaps.
+				}
+				require.NoError(t, arr.Set(i, intValue(i*10)))
+				expected = append(expected, i)
+			}
+			root, err := arr.Root()
+			require.NoError(t, err)
+
+			indices, err := adt.ParForEachArray(store, root, 4, func(i uint64, val *cbg.Deferred) (uint64, error) {
+				assert.Equal(t, int64(i*10), decodeInt(val))
+				return i, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, expected, indices)
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package adt
 
 import (
+	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 )
 
 // Set interprets a Map as a set, storing keys (with empty values) in a HAMT.
 type Set struct {
 	m *Map
+	// Number of elements in the set, counted on first request and maintained thereafter.
+	// The count is not persisted, so is unknown (-1) when a set is loaded.
+	count int64
 }
 
 // AsSet interprets a store as a HAMT-based set with root `r`.
@@ -19,7 +24,8 @@ func AsSet(s Store, r cid.Cid, bitwidth int) (*Set, error) {
 	}
 
 	return &Set{
-		m: m,
+		m:     m,
+		count: -1,
 	}, nil
 }
 
@@ -30,7 +36,7 @@ func MakeEmptySet(s Store, bitwidth int) (*Set, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Set{m}, nil
+	return &Set{m: m, count: 0}, nil
 }
 
 // Root return the root cid of HAMT.
@@ -40,6 +46,16 @@ func (h *Set) Root() (cid.Cid, error) {
 
 // Put adds `k` to the set.
 func (h *Set) Put(k abi.Keyer) error {
+	if h.count >= 0 {
+		// Maintaining the count requires knowing whether the key is new.
+		found, err := h.Has(k)
+		if err != nil {
+			return err
+		}
+		if !found {
+			h.count++
+		}
+	}
 	return h.m.Put(k, nil)
 }
 
@@ -51,12 +67,88 @@ func (h *Set) Has(k abi.Keyer) (bool, error) {
 // Removes `k` from the set, if present.
 // Returns whether the key was previously present.
 func (h *Set) TryDelete(k abi.Keyer) (bool, error) {
-	return h.m.TryDelete(k)
+	found, err := h.m.TryDelete(k)
+	if found && h.count > 0 {
+		h.count--
+	}
+	return found, err
 }
 
 // Removes `k` from the set, expecting it to be present.
 func (h *Set) Delete(k abi.Keyer) error {
-	return h.m.Delete(k)
+	if err := h.m.Delete(k); err != nil {
+		return err
+	}
+	if h.count > 0 {
+		h.count--
+	}
+	return nil
+}
+
+// Returns the number of elements in the set.
+// The first call for a loaded set iterates all elements; the count is then maintained as the set is modified.
+func (h *Set) Count() (uint64, error) {
+	if h.count < 0 {
+		count := int64(0)
+		if err := h.ForEach(func(string) error {
+			count++
+			return nil
+		}); err != nil {
+			return 0, xerrors.Errorf("failed to count set elements: %w", err)
+		}
+		h.count = count
+	}
+	return uint64(h.count), nil
+}
+
+// Returns whether every integer in a bitfield is in the set, as keyed by abi.UIntKey.
+// Returns true for an empty bitfield.
+func (h *Set) ContainsAll(bf bitfield.BitField) (bool, error) {
+	allFound := true
+	err := forEachUIntKeyUntil(bf, func(k abi.Keyer) (bool, error) {
+		found, err := h.Has(k)
+		if err != nil {
+			return false, err
+		}
+		allFound = found
+		return !found, nil
+	})
+	return allFound, err
+}
+
+// Returns whether any integer in a bitfield is in the set, as keyed by abi.UIntKey.
+// Returns false for an empty bitfield.
+func (h *Set) ContainsAny(bf bitfield.BitField) (bool, error) {
+	anyFound := false
+	err := forEachUIntKeyUntil(bf, func(k abi.Keyer) (bool, error) {
+		found, err := h.Has(k)
+		if err != nil {
+			return false, err
+		}
+		anyFound = found
+		return found, nil
+	})
+	return anyFound, err
+}
+
+// Calls fn with the key of each integer in a bitfield, in ascending order, until fn returns true.
+func forEachUIntKeyUntil(bf bitfield.BitField, fn func(k abi.Keyer) (bool, error)) error {
+	it, err := bf.BitIterator()
+	if err != nil {
+		return xerrors.Errorf("failed to iterate bitfield: %w", err)
+	}
+	for it.HasNext() {
+		i, err := it.Next()
+		if err != nil {
+			return xerrors.Errorf("failed to iterate bitfield: %w", err)
+		}
+		if stop, err := fn(abi.UIntKey(i)); err != nil {
+			return err
+		} else if stop {
+			return nil
+		}
+	}
+	return nil
 }
 
 // ForEach iterates over all values in the set, calling the callback for each value.
@@ -0,0 +1,81 @@
+package adt_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+)
+
+func TestSetCount(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	set, err := adt.MakeEmptySet(store, 5)
+	require.NoError(t, err)
+	requireCount := func(expected uint64) {
+		count, err := set.Count()
+		require.NoError(t, err)
+		assert.Equal(t, expected, count)
+	}
+	requireCount(0)
+
+	require.NoError(t, set.Put(abi.UIntKey(1)))
+	require.NoError(t, set.Put(abi.UIntKey(2)))
+	require.NoError(t, set.Put(abi.UIntKey(2)))
+	requireCount(2)
+
+	found, err := set.TryDelete(abi.UIntKey(3))
+	require.NoError(t, err)
+	assert.False(t, found)
+	requireCount(2)
+	require.NoError(t, set.Delete(abi.UIntKey(1)))
+	requireCount(1)
+
+	// A loaded set counts its elements.
+	require.NoError(t, set.Put(abi.UIntKey(7)))
+	root, err := set.Root()
+	require.NoError(t, err)
+	set, err = adt.AsSet(store, root, 5)
+	require.NoError(t, err)
+	requireCount(2)
+	require.NoError(t, set.Put(abi.UIntKey(8)))
+	requireCount(3)
+}
+
+func TestSetContains(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	set, err := adt.MakeEmptySet(store, 5)
+	require.NoError(t, err)
+	for _, i := range []uint64{1, 2, 3, 10} {
+		require.NoError(t, set.Put(abi.UIntKey(i)))
+	}
+
+	for _, tc := range []struct {
+		elements []uint64
+		all, any bool
+	}{
+		{nil, true, false},
+		{[]uint64{1}, true, true},
+		{[]uint64{1, 2, 3, 10}, true, true},
+		{[]uint64{1, 4}, false, true},
+		{[]uint64{4, 10}, false, true},
+		{[]uint64{4, 5, 6}, false, false},
+	} {
+		bf := bitfield.NewFromSet(tc.elements)
+		all, err := set.ContainsAll(bf)
+		require.NoError(t, err)
+		assert.Equal(t, tc.all, all, "all of %v", tc.elements)
+		any, err := set.ContainsAny(bf)
+		require.NoError(t, err)
+		assert.Equal(t, tc.any, any, "any of %v", tc.elements)
+	}
+}
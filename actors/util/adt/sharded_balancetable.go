@@ -0,0 +1,264 @@
+package adt
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Number of shards in a sharded balance table.
+const BalanceTableShardCount = 16
+
+// Bitwidth of the AMT holding the shard roots of a sharded balance table.
+const BalanceTableShardsAmtBitwidth = 4
+
+// A balance table partitioned by address prefix into independent sub-tables, each
+// an ordinary BalanceTable HAMT. Updates to addresses in different shards touch
+// disjoint sub-tables, so they may be computed independently.
+// The root is an AMT[ShardIndex]BalanceTable root, with every shard present.
+type ShardedBalanceTable struct {
+	shards *Array
+	store  Store
+	// Sub-tables loaded since the table was last flushed, by shard index.
+	loaded map[uint64]*BalanceTable
+}
+
+// Returns the index of the shard holding the balance for an address.
+// Shards are selected by the first byte of the address payload, which is uniformly distributed
+// for key-based addresses and takes the low-order bits of the ID for ID addresses.
+func BalanceTableShard(key addr.Address) uint64 {
+	payload := key.Payload()
+	if len(payload) == 0 {
+		return 0
+	}
+	return uint64(payload[0]) % BalanceTableShardCount
+}
+
+// Interprets a store as a sharded balance table with root `r`.
+func AsShardedBalanceTable(s Store, r cid.Cid) (*ShardedBalanceTable, error) {
+	shards, err := AsArray(s, r, BalanceTableShardsAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return &ShardedBalanceTable{
+		shards: shards,
+		store:  s,
+		loaded: map[uint64]*BalanceTable{},
+	}, nil
+}
+
+// Creates a new sharded balance table in which every shard is an empty balance table.
+func MakeEmptyShardedBalanceTable(s Store) (*ShardedBalanceTable, error) {
+	shards, err := MakeEmptyArray(s, BalanceTableShardsAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	emptyTable, err := StoreEmptyMap(s, BalanceTableBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	emptyRoot := cbg.CborCid(emptyTable)
+	for i := uint64(0); i < BalanceTableShardCount; i++ {
+		if err := shards.Set(i, &emptyRoot); err != nil {
+			return nil, xerrors.Errorf("failed to set empty shard %d: %w", i, err)
+		}
+	}
+	return &ShardedBalanceTable{
+		shards: shards,
+		store:  s,
+		loaded: map[uint64]*BalanceTable{},
+	}, nil
+}
+
+// Creates and stores a new empty sharded balance table, returning its CID.
+func StoreEmptyShardedBalanceTable(s Store) (cid.Cid, error) {
+	t, err := MakeEmptyShardedBalanceTable(s)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return t.Root()
+}
+
+// Flushes any loaded sub-tables and returns the root cid of the shard AMT.
+func (t *ShardedBalanceTable) Root() (cid.Cid, error) {
+	for i := uint64(0); i < BalanceTableShardCount; i++ {
+		table, ok := t.loaded[i]
+		if !ok {
+			continue
+		}
+		r, err := table.Root()
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("failed to flush shard %d: %w", i, err)
+		}
+		shardRoot := cbg.CborCid(r)
+		if err := t.shards.Set(i, &shardRoot); err != nil {
+			return cid.Undef, xerrors.Errorf("failed to store shard %d root: %w", i, err)
+		}
+	}
+	return t.shards.Root()
+}
+
+// Returns the sub-table for a shard, loading it if necessary.
+func (t *ShardedBalanceTable) Shard(index uint64) (*BalanceTable, error) {
+	if index >= BalanceTableShardCount {
+		return nil, xerrors.Errorf("shard index %d out of range, max %d", index, BalanceTableShardCount-1)
+	}
+	if table, ok := t.loaded[index]; ok {
+		return table, nil
+	}
+	var shardRoot cbg.CborCid
+	found, err := t.shards.Get(index, &shardRoot)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load shard %d root: %w", index, err)
+	}
+	if !found {
+		return nil, xerrors.Errorf("shard %d not found", index)
+	}
+	table, err := AsBalanceTable(t.store, cid.Cid(shardRoot))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load shard %d: %w", index, err)
+	}
+	t.loaded[index] = table
+	return table, nil
+}
+
+// Gets the balance for a key, which is zero if they key has never been added to.
+func (t *ShardedBalanceTable) Get(key addr.Address) (abi.TokenAmount, error) {
+	table, err := t.Shard(BalanceTableShard(key))
+	if err != nil {
+		return big.Zero(), err
+	}
+	return table.Get(key)
+}
+
+// Adds an amount to a balance, requiring the resulting balance to be non-negative.
+func (t *ShardedBalanceTable) Add(key addr.Address, value abi.TokenAmount) error {
+	table, err := t.Shard(BalanceTableShard(key))
+	if err != nil {
+		return err
+	}
+	return table.Add(key, value)
+}
+
+// Subtracts up to the specified amount from a balance, without reducing the balance below some minimum.
+// Returns the amount subtracted.
+func (t *ShardedBalanceTable) SubtractWithMinimum(key addr.Address, req abi.TokenAmount, floor abi.TokenAmount) (abi.TokenAmount, error) {
+	table, err := t.Shard(BalanceTableShard(key))
+	if err != nil {
+		return big.Zero(), err
+	}
+	return table.SubtractWithMinimum(key, req, floor)
+}
+
+// MustSubtract subtracts the given amount from the account's balance.
+// Returns an error if the account has insufficient balance
+func (t *ShardedBalanceTable) MustSubtract(key addr.Address, req abi.TokenAmount) error {
+	table, err := t.Shard(BalanceTableShard(key))
+	if err != nil {
+		return err
+	}
+	return table.MustSubtract(key, req)
+}
+
+// Adds each of a sequence of amounts to the corresponding balance, requiring every resulting balance to be
+// non-negative. Each affected shard is flushed once.
+// If any balance would become negative, returns an error. Shards updated before the failing shard retain their updates.
+func (t *ShardedBalanceTable) AddMany(deltas []BalanceDelta) error {
+	var byShard [BalanceTableShardCount][]BalanceDelta
+	for _, d := range deltas {
+		i := BalanceTableShard(d.Address)
+		byShard[i] = append(byShard[i], d)
+	}
+	for i, shardDeltas := range byShard {
+		if len(shardDeltas) == 0 {
+			continue
+		}
+		table, err := t.Shard(uint64(i))
+		if err != nil {
+			return err
+		}
+		if err := table.AddMany(shardDeltas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subtracts each of a sequence of amounts from the corresponding balance. Each affected shard is flushed once.
+// Returns an error if any amount is negative or any account has insufficient balance, with the same
+// partial-update caveat as AddMany.
+func (t *ShardedBalanceTable) SubtractMany(deltas []BalanceDelta) error {
+	negated := make([]BalanceDelta, len(deltas))
+	for i, d := range deltas {
+		if d.Amount.Sign() < 0 {
+			return xerrors.Errorf("negative amount %v to subtract from %v", d.Amount, d.Address)
+		}
+		negated[i] = BalanceDelta{Address: d.Address, Amount: d.Amount.Neg()}
+	}
+	return t.AddMany(negated)
+}
+
+// Iterates all balances in shard order, calling a function with each address and balance.
+// Iteration halts if the function returns an error.
+func (t *ShardedBalanceTable) ForEach(fn func(key addr.Address, balance abi.TokenAmount) error) error {
+	for i := uint64(0); i < BalanceTableShardCount; i++ {
+		table, err := t.Shard(i)
+		if err != nil {
+			return err
+		}
+		if err := table.ForEach(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Iterates up to limit balances following the balance for an address, in shard order, and returns the address
+// from which to continue, or an undefined address if there are no more balances.
+// An undefined after address starts from the first balance. Each page traverses only the shards from that
+// holding the after address onwards.
+func (t *ShardedBalanceTable) ForEachPage(after addr.Address, limit int, fn func(key addr.Address, balance abi.TokenAmount) error) (addr.Address, error) {
+	if limit <= 0 {
+		return addr.Undef, xerrors.Errorf("invalid page limit %d", limit)
+	}
+	first := uint64(0)
+	if after != addr.Undef {
+		first = BalanceTableShard(after)
+	}
+	p := balancePager{limit: limit, fn: fn}
+	for i := first; i < BalanceTableShardCount; i++ {
+		table, err := t.Shard(i)
+		if err != nil {
+			return addr.Undef, err
+		}
+		shardAfter := addr.Undef
+		if i == first {
+			shardAfter = after
+		}
+		if err := table.forEachAfter(shardAfter, p.visit); err != nil {
+			return p.finish(err)
+		}
+	}
+	return p.finish(nil)
+}
+
+// Returns the total balance held by this ShardedBalanceTable.
+// The first call traverses every shard; the total is then maintained as balances are updated.
+func (t *ShardedBalanceTable) Total() (abi.TokenAmount, error) {
+	total := big.Zero()
+	for i := uint64(0); i < BalanceTableShardCount; i++ {
+		table, err := t.Shard(i)
+		if err != nil {
+			return big.Zero(), err
+		}
+		shardTotal, err := table.Total()
+		if err != nil {
+			return big.Zero(), xerrors.Errorf("failed to total shard %d: %w", i, err)
+		}
+		total = big.Add(total, shardTotal)
+	}
+	return total, nil
+}
@@ -0,0 +1,157 @@
+package adt_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestShardedBalanceTable(t *testing.T) {
+	buildTable := func() (adt.Store, *adt.ShardedBalanceTable) {
+		rt := mock.NewBuilder(address.Undef).Build(t)
+		store := adt.AsStore(rt)
+		bt, err := adt.MakeEmptyShardedBalanceTable(store)
+		require.NoError(t, err)
+		return store, bt
+	}
+
+	t.Run("routes addresses to shards by prefix", func(t *testing.T) {
+		seen := map[uint64]bool{}
+		for i := uint64(0); i < 2*adt.BalanceTableShardCount; i++ {
+			shard := adt.BalanceTableShard(tutil.NewIDAddr(t, 100+i))
+			assert.Less(t, shard, uint64(adt.BalanceTableShardCount))
+			seen[shard] = true
+		}
+		// Consecutive IDs spread over every shard.
+		assert.Len(t, seen, adt.BalanceTableShardCount)
+	})
+
+	t.Run("balances persist across flush", func(t *testing.T) {
+		store, bt := buildTable()
+		addr1 := tutil.NewIDAddr(t, 100)
+		addr2 := tutil.NewIDAddr(t, 101)
+		require.NotEqual(t, adt.BalanceTableShard(addr1), adt.BalanceTableShard(addr2))
+
+		require.NoError(t, bt.Add(addr1, abi.NewTokenAmount(10)))
+		require.NoError(t, bt.Add(addr2, abi.NewTokenAmount(20)))
+		require.NoError(t, bt.MustSubtract(addr2, abi.NewTokenAmount(5)))
+		sub, err := bt.SubtractWithMinimum(addr1, abi.NewTokenAmount(10), abi.NewTokenAmount(4))
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(6), sub)
+
+		root, err := bt.Root()
+		require.NoError(t, err)
+		bt, err = adt.AsShardedBalanceTable(store, root)
+		require.NoError(t, err)
+
+		amount, err := bt.Get(addr1)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(4), amount)
+		amount, err = bt.Get(addr2)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(15), amount)
+		amount, err = bt.Get(tutil.NewIDAddr(t, 102))
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), amount)
+
+		// Each balance is held only in the shard to which its address routes.
+		shard, err := bt.Shard(adt.BalanceTableShard(addr1))
+		require.NoError(t, err)
+		amount, err = shard.Get(addr1)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(4), amount)
+		amount, err = shard.Get(addr2)
+		require.NoError(t, err)
+		assert.Equal(t, big.Zero(), amount)
+
+		total, err := bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(19), total)
+	})
+
+	t.Run("untouched shards keep their roots", func(t *testing.T) {
+		_, bt := buildTable()
+		addr := tutil.NewIDAddr(t, 100)
+		before, err := bt.Shard(adt.BalanceTableShard(addr) + 1)
+		require.NoError(t, err)
+		beforeRoot, err := before.Root()
+		require.NoError(t, err)
+
+		require.NoError(t, bt.Add(addr, abi.NewTokenAmount(1)))
+		_, err = bt.Root()
+		require.NoError(t, err)
+
+		after, err := bt.Shard(adt.BalanceTableShard(addr) + 1)
+		require.NoError(t, err)
+		afterRoot, err := after.Root()
+		require.NoError(t, err)
+		assert.Equal(t, beforeRoot, afterRoot)
+	})
+
+	t.Run("adds many across shards", func(t *testing.T) {
+		_, bt := buildTable()
+		var deltas []adt.BalanceDelta
+		for i := uint64(0); i < 2*adt.BalanceTableShardCount; i++ {
+			deltas = append(deltas, adt.BalanceDelta{Address: tutil.NewIDAddr(t, 100+i), Amount: abi.NewTokenAmount(2)})
+		}
+		require.NoError(t, bt.AddMany(deltas))
+		require.NoError(t, bt.SubtractMany(deltas[:adt.BalanceTableShardCount]))
+
+		for i, d := range deltas {
+			amount, err := bt.Get(d.Address)
+			require.NoError(t, err)
+			if i < adt.BalanceTableShardCount {
+				assert.Equal(t, big.Zero(), amount)
+			} else {
+				assert.Equal(t, abi.NewTokenAmount(2), amount)
+			}
+		}
+		total, err := bt.Total()
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewTokenAmount(2*adt.BalanceTableShardCount), total)
+
+		require.Error(t, bt.SubtractMany(deltas[:1]))
+	})
+
+	t.Run("pages through balances in shard order", func(t *testing.T) {
+		_, bt := buildTable()
+		var all []address.Address
+		for i := uint64(0); i < 3*adt.BalanceTableShardCount; i++ {
+			require.NoError(t, bt.Add(tutil.NewIDAddr(t, 100+i), abi.NewTokenAmount(1)))
+		}
+		require.NoError(t, bt.ForEach(func(key address.Address, _ abi.TokenAmount) error {
+			all = append(all, key)
+			return nil
+		}))
+		require.Len(t, all, 3*adt.BalanceTableShardCount)
+
+		var paged []address.Address
+		after := address.Undef
+		for {
+			next, err := bt.ForEachPage(after, 5, func(key address.Address, _ abi.TokenAmount) error {
+				paged = append(paged, key)
+				return nil
+			})
+			require.NoError(t, err)
+			if next == address.Undef {
+				break
+			}
+			after = next
+		}
+		assert.Equal(t, all, paged)
+	})
+
+	t.Run("rejects out of range shard", func(t *testing.T) {
+		_, bt := buildTable()
+		_, err := bt.Shard(adt.BalanceTableShardCount)
+		require.Error(t, err)
+	})
+}
@@ -40,26 +40,30 @@ func (s *wstore) Context() context.Context {
 
 // Adapter for a Runtime as an ADT Store.
 
-// Adapts a Runtime as an ADT store.
-func AsStore(rt vmr.Runtime) Store {
-	return rtStore{rt}
+// Adapts a Runtime, or any other StateReader, as an ADT store.
+// A read of a missing object aborts if the reader is a Runtime, and otherwise returns an ErrNotFound error.
+func AsStore(sr vmr.StateReader) Store {
+	return rtStore{sr}
 }
 
 type rtStore struct {
-	vmr.Runtime
+	vmr.StateReader
 }
 
 var _ Store = &rtStore{}
 
 func (r rtStore) Context() context.Context {
-	return r.Runtime.Context()
+	return r.StateReader.Context()
 }
 
 func (r rtStore) Get(_ context.Context, c cid.Cid, out interface{}) error {
 	// The Go context is (un/fortunately?) dropped here.
 	// See https://github.com/filecoin-project/specs-actors/issues/140
 	if !r.StoreGet(c, out.(cbor.Unmarshaler)) {
-		r.Abortf(exitcode.ErrNotFound, "not found")
+		if rt, ok := r.StateReader.(vmr.Runtime); ok {
+			rt.Abortf(exitcode.ErrNotFound, "not found")
+		}
+		return exitcode.ErrNotFound.Wrapf("not found: %v", c)
 	}
 	return nil
 }
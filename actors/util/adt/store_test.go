@@ -0,0 +1,73 @@
+package adt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+// A state reader backed by a plain store, as an off-chain tool might provide.
+type storeReader struct {
+	store adt.Store
+}
+
+var _ runtime.StateReader = storeReader{}
+
+func (r storeReader) StoreGet(c cid.Cid, o cbor.Unmarshaler) bool {
+	return r.store.Get(r.store.Context(), c, o) == nil
+}
+
+func (r storeReader) StorePut(x cbor.Marshaler) cid.Cid {
+	c, err := r.store.Put(r.store.Context(), x)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (r storeReader) CurrEpoch() abi.ChainEpoch {
+	return 0
+}
+
+func (r storeReader) ResolveAddress(a address.Address) (address.Address, bool) {
+	return a, a.Protocol() == address.ID
+}
+
+func (r storeReader) Context() context.Context {
+	return r.store.Context()
+}
+
+func TestStateReaderStore(t *testing.T) {
+	store := adt.AsStore(storeReader{ipld.NewADTStore(context.Background())})
+
+	value := cbg.CborInt(7)
+	arr, err := adt.MakeEmptyArray(store, 3)
+	require.NoError(t, err)
+	require.NoError(t, arr.Set(1, &value))
+	root, err := arr.Root()
+	require.NoError(t, err)
+
+	loaded, err := adt.AsArray(store, root, 3)
+	require.NoError(t, err)
+	found, err := loaded.Get(1, &value)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	// A missing object is reported as an error rather than an abort.
+	missing, err := abi.CidBuilder.Sum([]byte("missing"))
+	require.NoError(t, err)
+	_, err = adt.AsArray(store, missing, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,192 @@
+package adt
+
+import (
+	"bytes"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Constrains PV to be a pointer to V which can be (un)marshalled as CBOR, so that typed collections
+// can both decode into a V and encode one.
+type CBORPtr[V any] interface {
+	*V
+	cbor.Er
+}
+
+// TypedMap stores key-value pairs in a HAMT, as for Map, with keys of type K and values of type V.
+// Values are returned by value rather than decoded into an output parameter.
+// The pointer type PV is inferred by the constructors, e.g. AsTypedMap[abi.AddrKey, DataCap](store, root, bitwidth).
+type TypedMap[K abi.Keyer, V any, PV CBORPtr[V]] struct {
+	m *Map
+}
+
+// Interprets a store as a HAMT-based map of V with root `r` and branching factor 2^bitwidth.
+func AsTypedMap[K abi.Keyer, V any, PV CBORPtr[V]](s Store, root cid.Cid, bitwidth int) (*TypedMap[K, V, PV], error) {
+	m, err := AsMap(s, root, bitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedMap[K, V, PV]{m: m}, nil
+}
+
+// Creates a new typed map backed by an empty HAMT.
+func MakeEmptyTypedMap[K abi.Keyer, V any, PV CBORPtr[V]](s Store, bitwidth int) (*TypedMap[K, V, PV], error) {
+	m, err := MakeEmptyMap(s, bitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedMap[K, V, PV]{m: m}, nil
+}
+
+// Returns the root cid of the underlying HAMT.
+func (m *TypedMap[K, V, PV]) Root() (cid.Cid, error) {
+	return m.m.Root()
+}
+
+// Adds value `v` with key `k` to the map.
+func (m *TypedMap[K, V, PV]) Put(k K, v V) error {
+	return m.m.Put(k, PV(&v))
+}
+
+// Retrieves the value at `k`, and whether the key was found.
+// Returns the zero value if the key is not present.
+func (m *TypedMap[K, V, PV]) Get(k K) (V, bool, error) {
+	var v V
+	found, err := m.m.Get(k, PV(&v))
+	return v, found, err
+}
+
+// Checks for the existence of a key without deserializing its value.
+func (m *TypedMap[K, V, PV]) Has(k K) (bool, error) {
+	return m.m.Has(k)
+}
+
+// Sets key `k` to value `v` iff the key is not already present.
+func (m *TypedMap[K, V, PV]) PutIfAbsent(k K, v V) (bool, error) {
+	return m.m.PutIfAbsent(k, PV(&v))
+}
+
+// Removes the value at `k`, if it exists. Returns whether the key was previously present.
+func (m *TypedMap[K, V, PV]) TryDelete(k K) (bool, error) {
+	return m.m.TryDelete(k)
+}
+
+// Removes the value at `k`, expecting it to exist.
+func (m *TypedMap[K, V, PV]) Delete(k K) error {
+	return m.m.Delete(k)
+}
+
+// Retrieves the value at `k` and removes the entry.
+// Returns the zero value if the key is not present.
+func (m *TypedMap[K, V, PV]) Pop(k K) (V, bool, error) {
+	var v V
+	found, err := m.m.Pop(k, PV(&v))
+	return v, found, err
+}
+
+// Iterates all entries in the map, calling a function with each key and a freshly decoded value.
+// Iteration halts if the function returns an error.
+func (m *TypedMap[K, V, PV]) ForEach(fn func(key string, v V) error) error {
+	return m.m.root.ForEach(m.m.store.Context(), func(key string, val *cbg.Deferred) error {
+		var v V
+		if err := PV(&v).UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return err
+		}
+		return fn(key, v)
+	})
+}
+
+// Collects all the keys from the map into a slice of strings.
+func (m *TypedMap[K, V, PV]) CollectKeys() ([]string, error) {
+	return m.m.CollectKeys()
+}
+
+// TypedArray stores a sparse sequence of values of type V in an AMT, as for Array.
+// Values are returned by value rather than decoded into an output parameter.
+type TypedArray[V any, PV CBORPtr[V]] struct {
+	a *Array
+}
+
+// Interprets a store as an AMT-based array of V with root `r`.
+func AsTypedArray[V any, PV CBORPtr[V]](s Store, r cid.Cid, bitwidth int) (*TypedArray[V, PV], error) {
+	a, err := AsArray(s, r, bitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedArray[V, PV]{a: a}, nil
+}
+
+// Creates a new typed array backed by an empty AMT.
+func MakeEmptyTypedArray[V any, PV CBORPtr[V]](s Store, bitwidth int) (*TypedArray[V, PV], error) {
+	a, err := MakeEmptyArray(s, bitwidth)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedArray[V, PV]{a: a}, nil
+}
+
+// Returns the root CID of the underlying AMT.
+func (a *TypedArray[V, PV]) Root() (cid.Cid, error) {
+	return a.a.Root()
+}
+
+// Appends a value to the end of the array. Assumes continuous array.
+func (a *TypedArray[V, PV]) AppendContinuous(v V) error {
+	return a.a.AppendContinuous(PV(&v))
+}
+
+// Sets the value at index `i`.
+func (a *TypedArray[V, PV]) Set(i uint64, v V) error {
+	return a.a.Set(i, PV(&v))
+}
+
+// Retrieves the value at index `i`, and whether it was found.
+// Returns the zero value if the index is not present.
+func (a *TypedArray[V, PV]) Get(i uint64) (V, bool, error) {
+	var v V
+	found, err := a.a.Get(i, PV(&v))
+	return v, found, err
+}
+
+// Removes the value at index `i`, if present. Returns whether it was previously present.
+func (a *TypedArray[V, PV]) TryDelete(i uint64) (bool, error) {
+	return a.a.TryDelete(i)
+}
+
+// Removes the value at index `i`, expecting it to exist.
+func (a *TypedArray[V, PV]) Delete(i uint64) error {
+	return a.a.Delete(i)
+}
+
+// Removes the values at the given indices. If strict, fails if any index is absent.
+func (a *TypedArray[V, PV]) BatchDelete(ix []uint64, strict bool) error {
+	return a.a.BatchDelete(ix, strict)
+}
+
+// Retrieves the value at index `i` and removes it.
+// Returns the zero value if the index is not present.
+func (a *TypedArray[V, PV]) Pop(i uint64) (V, bool, error) {
+	var v V
+	found, err := a.a.Pop(i, PV(&v))
+	return v, found, err
+}
+
+// Returns the number of values in the array.
+func (a *TypedArray[V, PV]) Length() uint64 {
+	return a.a.Length()
+}
+
+// Iterates all values in the array in index order, calling a function with each index and a freshly decoded value.
+// Iteration halts if the function returns an error.
+func (a *TypedArray[V, PV]) ForEach(fn func(i int64, v V) error) error {
+	return a.a.root.ForEach(a.a.store.Context(), func(i uint64, val *cbg.Deferred) error {
+		var v V
+		if err := PV(&v).UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return err
+		}
+		return fn(int64(i), v)
+	})
+}
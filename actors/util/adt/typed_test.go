@@ -0,0 +1,99 @@
+package adt_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestTypedMap(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	m, err := adt.MakeEmptyTypedMap[abi.AddrKey, big.Int](store, 5)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Put(abi.AddrKey(tutil.NewIDAddr(t, 101)), big.NewInt(10)))
+	require.NoError(t, m.Put(abi.AddrKey(tutil.NewIDAddr(t, 102)), big.NewInt(20)))
+	added, err := m.PutIfAbsent(abi.AddrKey(tutil.NewIDAddr(t, 102)), big.NewInt(30))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	root, err := m.Root()
+	require.NoError(t, err)
+	m, err = adt.AsTypedMap[abi.AddrKey, big.Int](store, root, 5)
+	require.NoError(t, err)
+
+	v, found, err := m.Get(abi.AddrKey(tutil.NewIDAddr(t, 102)))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, big.NewInt(20), v)
+
+	_, found, err = m.Get(abi.AddrKey(tutil.NewIDAddr(t, 103)))
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	sum := big.Zero()
+	require.NoError(t, m.ForEach(func(_ string, v big.Int) error {
+		sum = big.Add(sum, v)
+		return nil
+	}))
+	assert.Equal(t, big.NewInt(30), sum)
+
+	v, found, err = m.Pop(abi.AddrKey(tutil.NewIDAddr(t, 101)))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, big.NewInt(10), v)
+	found, err = m.Has(abi.AddrKey(tutil.NewIDAddr(t, 101)))
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestTypedArray(t *testing.T) {
+	rt := mock.NewBuilder(address.Undef).Build(t)
+	store := adt.AsStore(rt)
+
+	a, err := adt.MakeEmptyTypedArray[big.Int](store, 3)
+	require.NoError(t, err)
+
+	require.NoError(t, a.AppendContinuous(big.NewInt(1)))
+	require.NoError(t, a.AppendContinuous(big.NewInt(2)))
+	require.NoError(t, a.Set(7, big.NewInt(8)))
+	assert.Equal(t, uint64(3), a.Length())
+
+	root, err := a.Root()
+	require.NoError(t, err)
+	a, err = adt.AsTypedArray[big.Int](store, root, 3)
+	require.NoError(t, err)
+
+	v, found, err := a.Get(7)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, big.NewInt(8), v)
+
+	var indices []int64
+	var values []big.Int
+	require.NoError(t, a.ForEach(func(i int64, v big.Int) error {
+		indices = append(indices, i)
+		values = append(values, v)
+		return nil
+	}))
+	assert.Equal(t, []int64{0, 1, 7}, indices)
+	assert.Equal(t, []big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(8)}, values)
+
+	v, found, err = a.Pop(1)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, big.NewInt(2), v)
+	_, found, err = a.Get(1)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
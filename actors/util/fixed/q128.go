@@ -0,0 +1,177 @@
+// Package fixed provides signed fixed-point arithmetic for the economic calculations of the builtin actors.
+//
+// Values are held in Q.128 format: an integer scaled by 2^128, i.e. with 128 fractional bits.
+// Operations truncate exactly as the hand-written big.Int shifts they replace, so actor code
+// can adopt them without changing consensus-critical results.
+package fixed
+
+import (
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+// Number of fractional bits in a Q128.
+const Precision = math.Precision128
+
+// Longest magnitude, in bits, of a big.Int that can be serialized in state (128 bytes including the sign byte).
+const MaxBitLen = 127 * 8
+
+// ErrOverflow is returned by checked operations whose result cannot be stored in state.
+var ErrOverflow = xerrors.New("fixed-point overflow")
+
+// ErrDivideByZero is returned by checked division by zero.
+var ErrDivideByZero = xerrors.New("fixed-point division by zero")
+
+// A signed fixed-point number with 128 fractional bits.
+// The zero value is zero.
+type Q128 struct {
+	raw big.Int
+}
+
+// Zero in Q.128.
+func Zero() Q128 {
+	return Q128{raw: big.Zero()}
+}
+
+// One in Q.128.
+func One() Q128 {
+	return FromInt64(1)
+}
+
+// Interprets a big.Int already in Q.128 format, such as a filter estimate or a Q.128 constant.
+func FromRaw(raw big.Int) Q128 {
+	return Q128{raw: raw}
+}
+
+// Converts an integer to Q.128.
+func FromInt(i big.Int) Q128 {
+	return Q128{raw: big.Lsh(i, Precision)} // Q.0 => Q.128
+}
+
+// Converts an int64 to Q.128.
+func FromInt64(i int64) Q128 {
+	return FromInt(big.NewInt(i))
+}
+
+// Returns numerator / denominator in Q.128.
+func FromRatio(numerator, denominator big.Int) Q128 {
+	return Q128{raw: big.Div(big.Lsh(numerator, Precision), denominator)} // Q.128 / Q.0 => Q.128
+}
+
+// Parses a decimal string such as "0.000925" into Q.128.
+func FromDecimal(s string) (Q128, error) {
+	v, err := math.ParseQ128(s)
+	if err != nil {
+		return Q128{}, err
+	}
+	return Q128{raw: big.NewFromGo(v)}, nil
+}
+
+// Returns the Q.128 representation.
+func (q Q128) Raw() big.Int {
+	return q.rawOrZero()
+}
+
+// Returns the largest integer not greater than q.
+func (q Q128) Floor() big.Int {
+	return big.Rsh(q.rawOrZero(), Precision) // Q.128 => Q.0
+}
+
+// Returns a Q.256 representation of q, for use as the numerator of a ratio.
+func (q Q128) Q256() big.Int {
+	return big.Lsh(q.rawOrZero(), Precision) // Q.128 => Q.256
+}
+
+func (q Q128) Sign() int {
+	return q.rawOrZero().Sign()
+}
+
+func (q Q128) Cmp(r Q128) int {
+	return big.Cmp(q.rawOrZero(), r.rawOrZero())
+}
+
+func (q Q128) IsZero() bool {
+	return q.Sign() == 0
+}
+
+func (q Q128) Neg() Q128 {
+	return Q128{raw: big.Sub(big.Zero(), q.rawOrZero())}
+}
+
+func (q Q128) Add(r Q128) Q128 {
+	return Q128{raw: big.Add(q.rawOrZero(), r.rawOrZero())}
+}
+
+func (q Q128) Sub(r Q128) Q128 {
+	return Q128{raw: big.Sub(q.rawOrZero(), r.rawOrZero())}
+}
+
+// Returns q * r, truncating the Q.256 product to Q.128.
+func (q Q128) Mul(r Q128) Q128 {
+	product := big.Mul(q.rawOrZero(), r.rawOrZero()) // Q.128 * Q.128 => Q.256
+	return Q128{raw: big.Rsh(product, Precision)}    // Q.256 => Q.128
+}
+
+// Returns q * i for an integer i.
+func (q Q128) MulInt(i big.Int) Q128 {
+	return Q128{raw: big.Mul(q.rawOrZero(), i)} // Q.128 * Q.0 => Q.128
+}
+
+// Returns q / r. Panics if r is zero.
+func (q Q128) Div(r Q128) Q128 {
+	return Q128{raw: big.Div(q.Q256(), r.rawOrZero())} // Q.256 / Q.128 => Q.128
+}
+
+// Returns q / i for an integer i. Panics if i is zero.
+func (q Q128) DivInt(i big.Int) Q128 {
+	return Q128{raw: big.Div(q.rawOrZero(), i)} // Q.128 / Q.0 => Q.128
+}
+
+// Returns q * r, or ErrOverflow if the product cannot be stored in state.
+func (q Q128) MulChecked(r Q128) (Q128, error) {
+	return q.Mul(r).checked()
+}
+
+// Returns q * i, or ErrOverflow if the product cannot be stored in state.
+func (q Q128) MulIntChecked(i big.Int) (Q128, error) {
+	return q.MulInt(i).checked()
+}
+
+// Returns q + r, or ErrOverflow if the sum cannot be stored in state.
+func (q Q128) AddChecked(r Q128) (Q128, error) {
+	return q.Add(r).checked()
+}
+
+// Returns q - r, or ErrOverflow if the difference cannot be stored in state.
+func (q Q128) SubChecked(r Q128) (Q128, error) {
+	return q.Sub(r).checked()
+}
+
+// Returns q / r, or an error if r is zero or the quotient cannot be stored in state.
+func (q Q128) DivChecked(r Q128) (Q128, error) {
+	if r.IsZero() {
+		return Q128{}, ErrDivideByZero
+	}
+	return q.Div(r).checked()
+}
+
+// Renders q as a decimal with 18 fractional digits.
+func (q Q128) String() string {
+	return math.FormatQ128(q.rawOrZero().Int, 18)
+}
+
+func (q Q128) checked() (Q128, error) {
+	if q.rawOrZero().BitLen() > MaxBitLen {
+		return Q128{}, xerrors.Errorf("result has %d bits: %w", q.raw.BitLen(), ErrOverflow)
+	}
+	return q, nil
+}
+
+func (q Q128) rawOrZero() big.Int {
+	if q.raw.Int == nil {
+		return big.Zero()
+	}
+	return q.raw
+}
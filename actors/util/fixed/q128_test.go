@@ -0,0 +1,73 @@
+package fixed_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/fixed"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+func TestConversions(t *testing.T) {
+	assert.Equal(t, big.Lsh(big.NewInt(3), math.Precision128), fixed.FromInt64(3).Raw())
+	assert.Equal(t, big.NewInt(-7), fixed.FromInt64(-7).Floor())
+	assert.Equal(t, big.Lsh(big.NewInt(1), math.Precision128-1), fixed.FromRatio(big.NewInt(1), big.NewInt(2)).Raw())
+	assert.Equal(t, big.Lsh(big.NewInt(3), 2*math.Precision128), fixed.FromInt64(3).Q256())
+
+	half, err := fixed.FromDecimal("0.5")
+	require.NoError(t, err)
+	assert.Equal(t, fixed.FromRatio(big.NewInt(1), big.NewInt(2)), half)
+	assert.Equal(t, "0.500000000000000000", half.String())
+
+	// floor rounds towards negative infinity
+	assert.Equal(t, big.NewInt(-1), half.Neg().Floor())
+
+	var zero fixed.Q128
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, big.Zero(), zero.Floor())
+	assert.Equal(t, 0, zero.Cmp(fixed.Zero()))
+}
+
+func TestArithmetic(t *testing.T) {
+	three := fixed.FromInt64(3)
+	half := fixed.FromRatio(big.NewInt(1), big.NewInt(2))
+
+	assert.Equal(t, fixed.FromRatio(big.NewInt(7), big.NewInt(2)), three.Add(half))
+	assert.Equal(t, fixed.FromRatio(big.NewInt(5), big.NewInt(2)), three.Sub(half))
+	assert.Equal(t, fixed.FromRatio(big.NewInt(3), big.NewInt(2)), three.Mul(half))
+	assert.Equal(t, fixed.FromInt64(6), three.Div(half))
+	assert.Equal(t, fixed.FromInt64(12), three.MulInt(big.NewInt(4)))
+	assert.Equal(t, fixed.FromRatio(big.NewInt(3), big.NewInt(4)), three.DivInt(big.NewInt(4)))
+	assert.Equal(t, 1, three.Cmp(half))
+
+	// matches the hand-written shifts it replaces
+	a := big.MustFromString("37396271439864487274534522888786")
+	b := big.MustFromString("340282591298641078465964189926313473653")
+	assert.Equal(t, big.Rsh(big.Mul(a, b), math.Precision128), fixed.FromRaw(a).Mul(fixed.FromRaw(b)).Raw())
+}
+
+func TestChecked(t *testing.T) {
+	large := fixed.FromRaw(big.Lsh(big.NewInt(1), fixed.MaxBitLen-1))
+	_, err := large.AddChecked(large)
+	assert.True(t, errors.Is(err, fixed.ErrOverflow))
+	_, err = large.MulIntChecked(big.NewInt(2))
+	assert.True(t, errors.Is(err, fixed.ErrOverflow))
+	_, err = large.MulChecked(fixed.FromInt64(2))
+	assert.True(t, errors.Is(err, fixed.ErrOverflow))
+	_, err = large.Neg().SubChecked(large)
+	assert.True(t, errors.Is(err, fixed.ErrOverflow))
+
+	sum, err := large.AddChecked(large.Neg())
+	require.NoError(t, err)
+	assert.True(t, sum.IsZero())
+
+	_, err = fixed.One().DivChecked(fixed.Zero())
+	assert.True(t, errors.Is(err, fixed.ErrDivideByZero))
+	q, err := fixed.One().DivChecked(fixed.FromInt64(4))
+	require.NoError(t, err)
+	assert.Equal(t, fixed.FromRatio(big.NewInt(1), big.NewInt(4)), q)
+}
@@ -0,0 +1,258 @@
+// Package jsonenc provides the canonical JSON encoding of actor state, parameter and return types.
+// The JSON methods generated by gen/gen.go encode each type as an object of its fields, in declaration order,
+// with big integers as decimal strings, CIDs as {"/": "<cid>"} objects, bitfields as lists of inclusive
+// [first, last] ranges of set bits and filter estimates as smoothing.FilterEstimateJSON.
+// Other field types use their own JSON encoding.
+package jsonenc
+
+import (
+	"bytes"
+	"encoding/json"
+	gomath "math"
+	gobig "math/big"
+	"sort"
+
+	"github.com/filecoin-project/go-bitfield"
+	rlepluslazy "github.com/filecoin-project/go-bitfield/rle"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+// A named field of an object, holding a pointer to the field's value.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// An object encoded as its fields in order.
+type Object []Field
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal field %s: %w", f.Name, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Decodes the fields present in an object into the field values, leaving absent fields unchanged.
+// Fields not in the object's type are an error.
+func (o Object) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for _, f := range o {
+		value, ok := raw[f.Name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(value, f.Value); err != nil {
+			return xerrors.Errorf("failed to unmarshal field %s: %w", f.Name, err)
+		}
+		delete(raw, f.Name)
+	}
+	if len(raw) > 0 {
+		unknown := make([]string, 0, len(raw))
+		for name := range raw {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return xerrors.Errorf("unknown fields %v", unknown)
+	}
+	return nil
+}
+
+// A big integer encoded as a decimal string. The nil integer is encoded as zero.
+type BigInt big.Int
+
+func (bi BigInt) MarshalJSON() ([]byte, error) {
+	if bi.Int == nil {
+		return []byte(`"0"`), nil
+	}
+	return json.Marshal(bi.Int.String())
+}
+
+func (bi *BigInt) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return xerrors.Errorf("big integer must be a decimal string: %w", err)
+	}
+	i, ok := new(gobig.Int).SetString(s, 10)
+	if !ok {
+		return xerrors.Errorf("invalid big integer %q", s)
+	}
+	bi.Int = i
+	return nil
+}
+
+// A list of big integers, each encoded as a decimal string.
+type BigInts []big.Int
+
+func (bis BigInts) MarshalJSON() ([]byte, error) {
+	out := make([]BigInt, len(bis))
+	for i, bi := range bis {
+		out[i] = BigInt(bi)
+	}
+	return json.Marshal(out)
+}
+
+func (bis *BigInts) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil
+	}
+	var in []BigInt
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	out := make([]big.Int, len(in))
+	for i, bi := range in {
+		out[i] = big.Int(bi)
+	}
+	*bis = out
+	return nil
+}
+
+// A bitfield encoded as a list of inclusive [first, last] ranges of set bits, in increasing order.
+// Ranges are neither empty, overlapping nor adjacent, so each bitfield has exactly one encoding.
+type BitField bitfield.BitField
+
+func (bf BitField) MarshalJSON() ([]byte, error) {
+	ranges, err := BitFieldRanges(bitfield.BitField(bf))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ranges)
+}
+
+func (bf *BitField) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil
+	}
+	var ranges [][2]uint64
+	if err := json.Unmarshal(b, &ranges); err != nil {
+		return xerrors.Errorf("bitfield must be a list of [first, last] ranges: %w", err)
+	}
+	decoded, err := BitFieldFromRanges(ranges)
+	if err != nil {
+		return err
+	}
+	*bf = BitField(decoded)
+	return nil
+}
+
+// A list of bitfields, each encoded as a list of ranges.
+type BitFields []bitfield.BitField
+
+func (bfs BitFields) MarshalJSON() ([]byte, error) {
+	out := make([]BitField, len(bfs))
+	for i, bf := range bfs {
+		out[i] = BitField(bf)
+	}
+	return json.Marshal(out)
+}
+
+func (bfs *BitFields) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil
+	}
+	var in []BitField
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	out := make([]bitfield.BitField, len(in))
+	for i, bf := range in {
+		out[i] = bitfield.BitField(bf)
+	}
+	*bfs = out
+	return nil
+}
+
+// A filter estimate encoded as smoothing.FilterEstimateJSON.
+type FilterEstimate smoothing.FilterEstimate
+
+func (fe FilterEstimate) MarshalJSON() ([]byte, error) {
+	j := smoothing.NewFilterEstimateJSON(smoothing.FilterEstimate(fe))
+	return json.Marshal(&j)
+}
+
+func (fe *FilterEstimate) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil
+	}
+	decoded, err := smoothing.UnmarshalEstimateJSON(b)
+	if err != nil {
+		return err
+	}
+	*fe = FilterEstimate(decoded)
+	return nil
+}
+
+// Returns the inclusive [first, last] ranges of the bits set in a bitfield, in increasing order.
+func BitFieldRanges(bf bitfield.BitField) ([][2]uint64, error) {
+	iter, err := bf.RunIterator()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to iterate bitfield: %w", err)
+	}
+	ranges := [][2]uint64{}
+	var pos uint64
+	for iter.HasNext() {
+		run, err := iter.NextRun()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to iterate bitfield: %w", err)
+		}
+		if run.Val {
+			ranges = append(ranges, [2]uint64{pos, pos + run.Len - 1})
+		}
+		pos += run.Len
+	}
+	return ranges, nil
+}
+
+// Builds a bitfield from the inclusive [first, last] ranges of its set bits, as returned by BitFieldRanges.
+func BitFieldFromRanges(ranges [][2]uint64) (bitfield.BitField, error) {
+	runs := make([]rlepluslazy.Run, 0, 2*len(ranges))
+	var pos uint64
+	for i, r := range ranges {
+		first, last := r[0], r[1]
+		if first > last {
+			return bitfield.BitField{}, xerrors.Errorf("range %d [%d, %d] is empty", i, first, last)
+		}
+		if last == gomath.MaxUint64 {
+			return bitfield.BitField{}, xerrors.Errorf("range %d [%d, %d] exceeds the largest bit", i, first, last)
+		}
+		if i > 0 && first <= pos {
+			return bitfield.BitField{}, xerrors.Errorf("range %d [%d, %d] overlaps or adjoins the previous range", i, first, last)
+		}
+		if first > pos {
+			runs = append(runs, rlepluslazy.Run{Val: false, Len: first - pos})
+		}
+		runs = append(runs, rlepluslazy.Run{Val: true, Len: last - first + 1})
+		pos = last + 1
+	}
+	return bitfield.NewFromIter(&rlepluslazy.RunSliceIterator{Runs: runs})
+}
+
+func isNull(b []byte) bool {
+	return string(bytes.TrimSpace(b)) == "null"
+}
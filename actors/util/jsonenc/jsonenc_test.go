@@ -0,0 +1,139 @@
+package jsonenc_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+func TestBigInt(t *testing.T) {
+	t.Run("encoded as string", func(t *testing.T) {
+		out, err := json.Marshal(jsonenc.BigInt(big.NewInt(-1234)))
+		require.NoError(t, err)
+		assert.Equal(t, `"-1234"`, string(out))
+	})
+
+	t.Run("nil encoded as zero", func(t *testing.T) {
+		out, err := json.Marshal(jsonenc.BigInt{})
+		require.NoError(t, err)
+		assert.Equal(t, `"0"`, string(out))
+	})
+
+	t.Run("rejects numbers", func(t *testing.T) {
+		var bi jsonenc.BigInt
+		require.Error(t, json.Unmarshal([]byte(`1234`), &bi))
+		require.Error(t, json.Unmarshal([]byte(`"12.5"`), &bi))
+	})
+}
+
+func TestBitField(t *testing.T) {
+	t.Run("encoded as ranges", func(t *testing.T) {
+		bf := bitfield.NewFromSet([]uint64{0, 1, 2, 5, 7, 8})
+		out, err := json.Marshal(jsonenc.BitField(bf))
+		require.NoError(t, err)
+		assert.Equal(t, `[[0,2],[5,5],[7,8]]`, string(out))
+
+		var decoded jsonenc.BitField
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		assertBitFieldEquals(t, bf, bitfield.BitField(decoded))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		out, err := json.Marshal(jsonenc.BitField(bitfield.New()))
+		require.NoError(t, err)
+		assert.Equal(t, `[]`, string(out))
+
+		var decoded jsonenc.BitField
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		empty, err := bitfield.BitField(decoded).IsEmpty()
+		require.NoError(t, err)
+		assert.True(t, empty)
+	})
+
+	t.Run("rejects non-canonical ranges", func(t *testing.T) {
+		for _, ranges := range []string{
+			`[[3,2]]`,       // empty
+			`[[0,4],[3,6]]`, // overlapping
+			`[[0,4],[5,6]]`, // adjacent
+			`[[5,6],[0,1]]`, // out of order
+			`[0,1]`,         // not a list of ranges
+		} {
+			var decoded jsonenc.BitField
+			assert.Error(t, json.Unmarshal([]byte(ranges), &decoded), ranges)
+		}
+	})
+}
+
+func TestGeneratedEncoding(t *testing.T) {
+	t.Run("expiration set", func(t *testing.T) {
+		es := miner.ExpirationSet{
+			OnTimeSectors: bitfield.NewFromSet([]uint64{1, 2, 3}),
+			EarlySectors:  bitfield.NewFromSet([]uint64{10}),
+			OnTimePledge:  abi.NewTokenAmount(1000),
+			ActivePower:   miner.NewPowerPair(big.NewInt(32), big.NewInt(320)),
+			FaultyPower:   miner.NewPowerPairZero(),
+		}
+		out, err := json.Marshal(es)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"OnTimeSectors": [[1,3]],
+			"EarlySectors": [[10,10]],
+			"OnTimePledge": "1000",
+			"ActivePower": {"Raw": "32", "QA": "320"},
+			"FaultyPower": {"Raw": "0", "QA": "0"}
+		}`, string(out))
+
+		var decoded miner.ExpirationSet
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		assertBitFieldEquals(t, es.OnTimeSectors, decoded.OnTimeSectors)
+		assertBitFieldEquals(t, es.EarlySectors, decoded.EarlySectors)
+		assert.True(t, es.OnTimePledge.Equals(decoded.OnTimePledge))
+		assert.True(t, es.ActivePower.Equals(decoded.ActivePower))
+		assert.True(t, es.FaultyPower.Equals(decoded.FaultyPower))
+	})
+
+	t.Run("state with CIDs and filter estimates", func(t *testing.T) {
+		store := ipld.NewADTStore(context.Background())
+		st, err := power.ConstructState(store)
+		require.NoError(t, err)
+
+		out, err := json.Marshal(st)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(out, &raw))
+		assert.Equal(t, map[string]interface{}{"/": st.Claims.String()}, raw["Claims"])
+		assert.Equal(t, "0", raw["TotalRawBytePower"])
+		assert.Nil(t, raw["ProofValidationBatch"])
+
+		var decoded power.State
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		assert.Equal(t, st.Claims, decoded.Claims)
+		reencoded, err := json.Marshal(decoded)
+		require.NoError(t, err)
+		assert.Equal(t, string(out), string(reencoded))
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		var decoded miner.PowerPair
+		require.Error(t, json.Unmarshal([]byte(`{"Raw": "1", "Quality": "2"}`), &decoded))
+	})
+}
+
+func assertBitFieldEquals(t *testing.T, expected, actual bitfield.BitField) {
+	expectedSet, err := expected.All(1 << 20)
+	require.NoError(t, err)
+	actualSet, err := actual.All(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSet, actualSet)
+}
@@ -0,0 +1,195 @@
+package math
+
+import (
+	"math/big"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// A rational function num(x) / denom(x) approximating some function over a bounded domain.
+// Coefficients are in Q.128 format, ordered from the highest order coefficient to the lowest.
+type RationalApprox struct {
+	Num   []*big.Int
+	Denom []*big.Int
+}
+
+// A point (X, Y) on the function being approximated, in Q.128 format.
+type ReferencePoint struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// Parses coefficients given as decimal strings of Q.128 integers, as they appear in this package's source.
+func ParseRationalApprox(num, denom []string) (RationalApprox, error) {
+	parse := func(coefs []string) ([]*big.Int, error) {
+		out := make([]*big.Int, len(coefs))
+		for i, coef := range coefs {
+			c, ok := new(big.Int).SetString(coef, 10)
+			if !ok {
+				return nil, xerrors.Errorf("invalid coefficient %d: %q", i, coef)
+			}
+			out[i] = c
+		}
+		return out, nil
+	}
+	var err error
+	var a RationalApprox
+	if a.Num, err = parse(num); err != nil {
+		return RationalApprox{}, xerrors.Errorf("numerator: %w", err)
+	}
+	if a.Denom, err = parse(denom); err != nil {
+		return RationalApprox{}, xerrors.Errorf("denominator: %w", err)
+	}
+	return a, nil
+}
+
+// Evaluates the approximation at Q.128 x, evaluating both polynomials with Horner's method.
+// Output is in Q.128.
+func (a *RationalApprox) Eval(x *big.Int) *big.Int {
+	num := Polyval(a.Num, x)     // Q.128
+	denom := Polyval(a.Denom, x) // Q.128
+
+	num = num.Lsh(num, Precision128) // Q.128 => Q.256
+	return num.Div(num, denom)       // Q.256 / Q.128 => Q.128
+}
+
+// Checks that the approximation is well formed and that it evaluates to within tolerance
+// (Q.128, inclusive) of each reference point.
+func (a *RationalApprox) Validate(refs []ReferencePoint, tolerance *big.Int) error {
+	if len(a.Num) == 0 || len(a.Denom) == 0 {
+		return xerrors.Errorf("approximation needs numerator and denominator coefficients")
+	}
+	for i, ref := range refs {
+		if Polyval(a.Denom, ref.X).Sign() == 0 {
+			return xerrors.Errorf("denominator vanishes at reference point %d (x=%s)", i, FormatQ128(ref.X, 6))
+		}
+		diff := new(big.Int).Sub(a.Eval(ref.X), ref.Y)
+		if diff.CmpAbs(tolerance) > 0 {
+			return xerrors.Errorf("error %s at reference point %d (x=%s) exceeds tolerance %s",
+				FormatQ128(diff, 40), i, FormatQ128(ref.X, 6), FormatQ128(tolerance, 40))
+		}
+	}
+	return nil
+}
+
+func (a *RationalApprox) copy() RationalApprox {
+	cp := func(coefs []*big.Int) []*big.Int {
+		out := make([]*big.Int, len(coefs))
+		for i, c := range coefs {
+			out[i] = new(big.Int).Set(c)
+		}
+		return out
+	}
+	return RationalApprox{Num: cp(a.Num), Denom: cp(a.Denom)}
+}
+
+var (
+	// Values of e^-x over [0, 5), in Q.128 format.
+	ExpNegReferencePoints []ReferencePoint
+	// Maximum error of an approximation of e^-x at the reference points: 4.6e-15 in Q.128 format.
+	ExpNegTolerance *big.Int
+
+	// Values of ln(x) over [1, 2], in Q.128 format.
+	LnReferencePoints []ReferencePoint
+	// Maximum error of an approximation of ln(x) at the reference points: 1e-16 in Q.128 format.
+	LnTolerance *big.Int
+)
+
+func init() {
+	// Reference values are floor(f(x) * 2^128), computed at 100 decimal digits of precision.
+	ExpNegReferencePoints = referencePoints([][2]string{
+		{"0", "340282366920938463463374607431768211456"},                                       // 0
+		{"170141183460469231731687303715884105728", "206391688497133195273760705512282642279"}, // 0.5
+		{"340282366920938463463374607431768211456", "125182886983370532117250726298150828301"}, // 1
+		{"510423550381407695195061911147652317184", "75927259026755760213823603619306498527"},  // 1.5
+		{"680564733841876926926749214863536422912", "46052210507670172419625860892627118819"},  // 2
+		{"1020847100762815390390123822295304634368", "16941661466271327126146327822211253888"}, // 3
+		{"1361129467683753853853498429727072845824", "6232488952727653950957829210887653621"},  // 4
+		{"1616341242874457701451029385300899004416", "2944019321596418867909719828734362547"},  // 4.75
+	})
+	ExpNegTolerance = Parse([]string{"1565298887836316931931523"})[0]
+
+	LnReferencePoints = referencePoints([][2]string{
+		{"340282366920938463463374607431768211456", "0"},                                       // 1
+		{"425352958651173079329218259289710264320", "75931815804343184391506054410983916693"},  // 1.25
+		{"510423550381407695195061911147652317184", "137972626690900373465550041896316339718"}, // 1.5
+		{"595494142111642311060905563005594370048", "190427384884991590765427513878300193511"}, // 1.75
+		{"680564733841876926926749214863536422912", "235865763225513294137944142764154484399"}, // 2
+	})
+	LnTolerance = Parse([]string{"34028236692093846346337"})[0]
+}
+
+func referencePoints(pairs [][2]string) []ReferencePoint {
+	out := make([]ReferencePoint, len(pairs))
+	for i, p := range pairs {
+		xy := Parse(p[:])
+		out[i] = ReferencePoint{X: xy[0], Y: xy[1]}
+	}
+	return out
+}
+
+// Fixes the approximations used by ExpNeg and Ln, after which they are never replaced.
+// Every evaluation passes through it first, so configuration cannot take effect after, or concurrently with, any use.
+var approxFixed sync.Once
+
+func fixApproximations() {
+	approxFixed.Do(func() {})
+}
+
+// Returns the approximation used by ExpNeg, fixing it as its first use would.
+func ExpNegApprox() RationalApprox {
+	fixApproximations()
+	return expNegApprox.copy()
+}
+
+// Returns the approximation used by Ln over [1, 2], fixing it as its first use would.
+func LnApprox() RationalApprox {
+	fixApproximations()
+	return lnApprox.copy()
+}
+
+// Returns the built-in approximation of e^-x, from which a custom approximation may be derived.
+func DefaultExpNegApprox() RationalApprox {
+	return defaultExpNegApprox.copy()
+}
+
+// Returns the built-in approximation of ln(x) over [1, 2], from which a custom approximation may be derived.
+func DefaultLnApprox() RationalApprox {
+	return defaultLnApprox.copy()
+}
+
+// Replaces the approximations used by ExpNeg (and the functions built on it) and by Ln, after checking each
+// against its reference points. A nil approximation keeps the default.
+// Custom networks may use this to adjust reward minting; every node on a network must use identical coefficients.
+// The approximations are fixed by their first use or configuration, so this may be called only once, while the
+// process initializes and before any actor executes. It fails if called again or after any evaluation.
+func ConfigureApproximations(expNeg, ln *RationalApprox) error {
+	if expNeg != nil {
+		if err := expNeg.Validate(ExpNegReferencePoints, ExpNegTolerance); err != nil {
+			return xerrors.Errorf("invalid e^-x approximation: %w", err)
+		}
+	}
+	if ln != nil {
+		if err := ln.Validate(LnReferencePoints, LnTolerance); err != nil {
+			return xerrors.Errorf("invalid ln approximation: %w", err)
+		}
+	}
+	configured := false
+	approxFixed.Do(func() {
+		if expNeg != nil {
+			expNegApprox = expNeg.copy()
+		}
+		if ln != nil {
+			lnApprox = ln.copy()
+		}
+		configured = true
+	})
+	if !configured {
+		return ErrApproximationsFixed
+	}
+	return nil
+}
+
+// Returned when configuring approximations that have already been used or configured.
+var ErrApproximationsFixed = xerrors.New("approximations are already fixed by use or earlier configuration")
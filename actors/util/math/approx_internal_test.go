@@ -0,0 +1,80 @@
+package math
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	fbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Runs f as if the approximations had not yet been used or configured, restoring them afterwards.
+func withUnfixedApproximations(t *testing.T, f func(t *testing.T)) {
+	expNeg, ln := expNegApprox, lnApprox
+	defer func() {
+		expNegApprox, lnApprox = expNeg, ln
+		approxFixed = sync.Once{}
+		fixApproximations()
+	}()
+	approxFixed = sync.Once{}
+	f(t)
+}
+
+func TestConfigureApproximations(t *testing.T) {
+	x := ExpNegReferencePoints[2].X // 1
+	before := ExpNeg(x)
+
+	t.Run("rejects inaccurate coefficients", func(t *testing.T) {
+		withUnfixedApproximations(t, func(t *testing.T) {
+			perturbed := DefaultExpNegApprox()
+			perturbed.Num[len(perturbed.Num)-1].Add(perturbed.Num[len(perturbed.Num)-1], new(big.Int).Lsh(big.NewInt(1), 100))
+			require.Error(t, ConfigureApproximations(&perturbed, nil))
+			require.Error(t, ConfigureApproximations(&RationalApprox{}, nil))
+
+			constant, err := ParseRationalApprox([]string{"0"}, []string{"340282366920938463463374607431768211456"})
+			require.NoError(t, err)
+			require.Error(t, ConfigureApproximations(nil, &constant))
+
+			// A rejected configuration leaves the approximations unfixed.
+			require.NoError(t, ConfigureApproximations(nil, nil))
+			assert.Equal(t, before, ExpNeg(x))
+		})
+	})
+
+	t.Run("accepts equivalent coefficients", func(t *testing.T) {
+		withUnfixedApproximations(t, func(t *testing.T) {
+			// scaling numerator and denominator alike preserves the function up to truncation
+			scaled := DefaultExpNegApprox()
+			for _, c := range append(scaled.Num, scaled.Denom...) {
+				c.Lsh(c, 1)
+			}
+			require.NoError(t, ConfigureApproximations(&scaled, nil))
+			assert.Equal(t, scaled, ExpNegApprox())
+			assert.Equal(t, DefaultLnApprox(), LnApprox())
+			diff := new(big.Int).Sub(before, ExpNeg(x))
+			assert.True(t, diff.CmpAbs(big.NewInt(1<<50)) < 0, diff)
+
+			// The configuration is a copy of the argument.
+			scaled.Num[0].SetInt64(0)
+			assert.NotEqual(t, scaled, ExpNegApprox())
+		})
+	})
+
+	t.Run("fails when already configured", func(t *testing.T) {
+		withUnfixedApproximations(t, func(t *testing.T) {
+			require.NoError(t, ConfigureApproximations(nil, nil))
+			expNeg := DefaultExpNegApprox()
+			assert.Equal(t, ErrApproximationsFixed, ConfigureApproximations(&expNeg, nil))
+		})
+	})
+
+	t.Run("fails after first use", func(t *testing.T) {
+		withUnfixedApproximations(t, func(t *testing.T) {
+			ln := DefaultLnApprox()
+			Ln(fbig.Lsh(fbig.NewInt(3), Precision128))
+			assert.Equal(t, ErrApproximationsFixed, ConfigureApproximations(nil, &ln))
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package math_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+func TestDefaultApproximationsValidate(t *testing.T) {
+	expNeg := math.DefaultExpNegApprox()
+	require.NoError(t, expNeg.Validate(math.ExpNegReferencePoints, math.ExpNegTolerance))
+	ln := math.DefaultLnApprox()
+	require.NoError(t, ln.Validate(math.LnReferencePoints, math.LnTolerance))
+	assert.Equal(t, expNeg, math.ExpNegApprox())
+	assert.Equal(t, ln, math.LnApprox())
+
+	for _, ref := range math.ExpNegReferencePoints {
+		assert.Equal(t, math.ExpNeg(ref.X), expNeg.Eval(ref.X))
+	}
+}
+
+func TestParseRationalApprox(t *testing.T) {
+	a, err := math.ParseRationalApprox([]string{"1", "-2"}, []string{"3"})
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(-2)}, a.Num)
+	assert.Equal(t, []*big.Int{big.NewInt(3)}, a.Denom)
+
+	_, err = math.ParseRationalApprox([]string{"1"}, []string{"x"})
+	require.Error(t, err)
+}
+
+func TestConfigureApproximationsAfterUse(t *testing.T) {
+	math.ExpNeg(big.NewInt(0))
+	expNeg := math.DefaultExpNegApprox()
+	err := math.ConfigureApproximations(&expNeg, nil)
+	require.Error(t, err)
+	assert.Equal(t, math.ErrApproximationsFixed, err)
+}
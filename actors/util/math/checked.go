@@ -0,0 +1,97 @@
+package math
+
+import (
+	"math/big"
+
+	gsbig "github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+)
+
+// Bounds of the domain over which ExpNeg is accurate, in Q.128 format.
+var (
+	// Within [0, ExpNegPreciseBound) the absolute error of ExpNeg is less than 3.4e-30.
+	ExpNegPreciseBound = new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1725), Precision128), big.NewInt(1000)) // 1.725
+	// Within [0, ExpNegDomainBound) the absolute error of ExpNeg is less than 4.6e-15.
+	ExpNegDomainBound = new(big.Int).Lsh(big.NewInt(5), Precision128) // 5
+)
+
+// Inputs larger than this are halved before approximation by ExpNegReduced.
+var expNegReductionBound = new(big.Int).Lsh(big.NewInt(1), Precision128) // 1
+
+// ErrOutOfDomain is returned by the checked functions for inputs outside the range
+// where the underlying approximation is valid.
+var ErrOutOfDomain = xerrors.New("input outside domain of approximation")
+
+// ExpNegChecked computes ExpNeg(x) for Q.128 x, returning an error if x is
+// outside [0, 5) where the approximation error is not bounded.
+// Use ExpNegReduced for larger inputs.
+func ExpNegChecked(x *big.Int) (*big.Int, error) {
+	if x.Sign() < 0 || x.Cmp(ExpNegDomainBound) >= 0 {
+		return nil, xerrors.Errorf("e^-x for x=%s: %w", FormatQ128(x, 6), ErrOutOfDomain)
+	}
+	return ExpNeg(x), nil
+}
+
+// ExpNegQ256 accepts x in Q.128 format and computes e^-x in Q.256 format.
+// It uses the same approximation as ExpNeg, and is subject to the same error bounds,
+// but carries intermediate products at higher precision so that the result does not
+// lose significant bits when it is small or will be raised to a power.
+func ExpNegQ256(x *big.Int) *big.Int {
+	fixApproximations()
+	num := PolyvalQ256(expNegApprox.Num, x)    // Q.256
+	deno := PolyvalQ256(expNegApprox.Denom, x) // Q.256
+
+	num = num.Lsh(num, Precision256) // Q.512
+	return num.Div(num, deno)        // Q.512 / Q.256 => Q.256
+}
+
+// ExpNegReduced accepts non-negative x in Q.128 format and computes e^-x in Q.128 format
+// for x of any magnitude.
+// The input is reduced by halving k times into [0, 1), where ExpNeg is most precise,
+// and the result squared k times at Q.256 precision: e^-x = (e^-(x / 2^k))^(2^k).
+// The relative error is less than 2^k * 1e-29, where k = max(0, ⌊log2(x)⌋ + 1), until the
+// result approaches the 2^-128 resolution of the output (x > ~40).
+// Note this is not the approximation used for reward minting and so must not replace ExpNeg in actor code.
+func ExpNegReduced(x *big.Int) *big.Int {
+	r := new(big.Int).Set(x)
+	k := 0
+	for r.Cmp(expNegReductionBound) >= 0 {
+		r = r.Rsh(r, 1)
+		k++
+	}
+
+	y := ExpNegQ256(r) // Q.256
+	for i := 0; i < k; i++ {
+		y = y.Mul(y, y)            // Q.256 * Q.256 => Q.512
+		y = y.Rsh(y, Precision256) // Q.512 => Q.256
+	}
+	return y.Rsh(y, Precision128) // Q.256 => Q.128
+}
+
+// ExpNegReducedChecked computes ExpNegReduced(x), returning an error if x is negative.
+func ExpNegReducedChecked(x *big.Int) (*big.Int, error) {
+	if x.Sign() < 0 {
+		return nil, xerrors.Errorf("e^-x for x=%s: %w", FormatQ128(x, 6), ErrOutOfDomain)
+	}
+	return ExpNegReduced(x), nil
+}
+
+// LnChecked computes the natural log of Q.128 z, returning an error if z is not positive.
+// Ln reduces its input into [1, 2) by powers of two, and over that range the absolute error
+// of the rational approximation is less than 1e-16, so the result has the same absolute
+// error bound for any positive input.
+func LnChecked(z gsbig.Int) (gsbig.Int, error) {
+	if z.Int == nil || z.Sign() <= 0 {
+		return gsbig.Zero(), xerrors.Errorf("ln(z) for z=%s: %w", z, ErrOutOfDomain)
+	}
+	return Ln(z), nil
+}
+
+// ExpBySquaringChecked computes ExpBySquaring(base, n), returning an error if base is zero
+// and n is negative.
+func ExpBySquaringChecked(base gsbig.Int, n int64) (gsbig.Int, error) {
+	if base.Int == nil || (base.Sign() == 0 && n < 0) {
+		return gsbig.Zero(), xerrors.Errorf("b^n for b=%s, n=%d: %w", base, n, ErrOutOfDomain)
+	}
+	return ExpBySquaring(base, n), nil
+}
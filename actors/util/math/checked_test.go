@@ -0,0 +1,89 @@
+package math_test
+
+import (
+	"errors"
+	gmath "math"
+	"math/big"
+	"testing"
+
+	gsbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+func q128(f float64) *big.Int {
+	v, _ := new(big.Float).Mul(big.NewFloat(f), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), math.Precision128))).Int(nil)
+	return v
+}
+
+func toFloat(q *big.Int, precision uint) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(q), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), precision))).Float64()
+	return f
+}
+
+func TestExpNegChecked(t *testing.T) {
+	y, err := math.ExpNegChecked(q128(1.5))
+	require.NoError(t, err)
+	assert.Equal(t, math.ExpNeg(q128(1.5)), y)
+
+	_, err = math.ExpNegChecked(q128(-0.5))
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+	_, err = math.ExpNegChecked(math.ExpNegDomainBound)
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+
+	_, err = math.ExpNegReducedChecked(q128(-0.5))
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+}
+
+func TestExpNegReduced(t *testing.T) {
+	for _, x := range []float64{0, 0.25, 1, 1.724, 3, 4.99, 7.5, 12, 20, 33} {
+		expected := gmath.Exp(-x)
+		actual := toFloat(math.ExpNegReduced(q128(x)), math.Precision128)
+		assert.InEpsilon(t, expected, actual, 1e-14, "x = %v", x)
+	}
+
+	t.Run("matches ExpNeg where it is most precise", func(t *testing.T) {
+		x := q128(0.75)
+		diff := new(big.Int).Sub(math.ExpNeg(x), math.ExpNegReduced(x))
+		assert.True(t, diff.CmpAbs(big.NewInt(1<<30)) < 0, diff)
+	})
+
+	t.Run("Q.256 result", func(t *testing.T) {
+		x := q128(2.5)
+		assert.InEpsilon(t, gmath.Exp(-2.5), toFloat(math.ExpNegQ256(x), math.Precision256), 1e-14)
+	})
+}
+
+func TestLnChecked(t *testing.T) {
+	one := gsbig.Lsh(gsbig.NewInt(1), math.Precision128)
+	ln, err := math.LnChecked(one)
+	require.NoError(t, err)
+	assert.Equal(t, math.Ln(one), ln)
+
+	_, err = math.LnChecked(gsbig.Zero())
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+	_, err = math.LnChecked(gsbig.NewInt(-1))
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+}
+
+func TestExpBySquaringChecked(t *testing.T) {
+	two := gsbig.Lsh(gsbig.NewInt(2), math.Precision128)
+	v, err := math.ExpBySquaringChecked(two, 3)
+	require.NoError(t, err)
+	assert.Equal(t, gsbig.Lsh(gsbig.NewInt(8), math.Precision128), v)
+
+	_, err = math.ExpBySquaringChecked(gsbig.Zero(), -1)
+	assert.True(t, errors.Is(err, math.ErrOutOfDomain))
+}
+
+func TestPolyvalQ256(t *testing.T) {
+	// 2x^2 + 3 at x = 0.5
+	p := math.Parse([]string{"2", "0", "3"})
+	for i := range p {
+		p[i].Lsh(p[i], math.Precision128)
+	}
+	res := math.PolyvalQ256(p, q128(0.5))
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(7), math.Precision256-1), res)
+}
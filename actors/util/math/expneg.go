@@ -4,11 +4,12 @@ import (
 	"math/big"
 )
 
-var (
-	// Coefficents in Q.128 format
-	expNumCoef  []*big.Int
-	expDenoCoef []*big.Int
-)
+// Rational approximation of e^-x with coefficients in Q.128 format.
+// May be replaced with ConfigureApproximations before its first use.
+var expNegApprox RationalApprox
+
+// The built-in coefficients of expNegApprox.
+var defaultExpNegApprox RationalApprox
 
 func init() {
 
@@ -25,7 +26,7 @@ func init() {
 		"-115682590513835356866803355398940131328",
 		"340282366920938463463374607431768211456",
 	}
-	expNumCoef = Parse(num)
+	expNegApprox.Num = Parse(num)
 
 	deno := []string{
 		"1225524182432722209606361",
@@ -43,7 +44,8 @@ func init() {
 		"224599776407103106596571252037123047424",
 		"340282366920938463463374607431768211456",
 	}
-	expDenoCoef = Parse(deno)
+	expNegApprox.Denom = Parse(deno)
+	defaultExpNegApprox = expNegApprox.copy()
 }
 
 // ExpNeg accepts x in Q.128 format and computes e^-x.
@@ -52,10 +54,6 @@ func init() {
 // Output is in Q.128 format.
 func ExpNeg(x *big.Int) *big.Int {
 	// exp is approximated by rational function
-	// polynomials of the rational function are evaluated using Horner's method
-	num := Polyval(expNumCoef, x)   // Q.128
-	deno := Polyval(expDenoCoef, x) // Q.128
-
-	num = num.Lsh(num, Precision128) // Q.256
-	return num.Div(num, deno)        // Q.256 / Q.128 => Q.128
+	fixApproximations()
+	return expNegApprox.Eval(x) // Q.128
 }
@@ -1,16 +1,16 @@
 package math
 
 import (
-	gbig "math/big"
-
 	"github.com/filecoin-project/go-state-types/big"
 )
 
 var (
-	// Coefficients in Q.128 format
-	lnNumCoef   []*gbig.Int
-	lnDenomCoef []*gbig.Int
-	ln2         big.Int
+	// Rational approximation of ln(x) for 1 <= x <= 2, with coefficients in Q.128 format.
+	// May be replaced with ConfigureApproximations before its first use.
+	lnApprox RationalApprox
+	// The built-in coefficients of lnApprox.
+	defaultLnApprox RationalApprox
+	ln2             big.Int
 )
 
 func init() {
@@ -27,7 +27,7 @@ func init() {
 		"-20351202052858059355702509232125230498980",
 		"-1563932590352680681114104005183375350999",
 	}
-	lnNumCoef = Parse(num)
+	lnApprox.Num = Parse(num)
 
 	denom := []string{
 		"49928077726659937662124949977867279384",
@@ -38,7 +38,8 @@ func init() {
 		"9015227820322455780436733526367238305537",
 		"340282366920938463463374607431768211456",
 	}
-	lnDenomCoef = Parse(denom)
+	lnApprox.Denom = Parse(denom)
+	defaultLnApprox = lnApprox.copy()
 
 	constStrs := []string{
 		"235865763225513294137944142764154484399", // ln(2)
@@ -69,10 +70,6 @@ func Ln(z big.Int) big.Int {
 // Output is in Q.128 format.
 func lnBetweenOneAndTwo(x big.Int) big.Int {
 	// ln is approximated by rational function
-	// polynomials of the rational function are evaluated using Horner's method
-	num := Polyval(lnNumCoef, x.Int)     // Q.128
-	denom := Polyval(lnDenomCoef, x.Int) // Q.128
-
-	num = num.Lsh(num, Precision128)          // Q.128 => Q.256
-	return big.NewFromGo(num.Div(num, denom)) // Q.256 / Q.128 => Q.128
+	fixApproximations()
+	return big.NewFromGo(lnApprox.Eval(x.Int)) // Q.128
 }
@@ -1,6 +1,9 @@
 package math
 
-import "math/big"
+import (
+	"fmt"
+	"math/big"
+)
 
 // Parse a slice of strings (representing integers in decimal)
 // Convention: this function is to be applied to strings representing Q.128 fixed-point numbers, and thus returns numbers in binary Q.128 representation
@@ -16,3 +19,36 @@ func Parse(coefs []string) []*big.Int {
 	}
 	return out
 }
+
+// FormatQ128 renders a Q.128 value as a decimal string with the given number of
+// fractional digits. Digits beyond that are truncated towards zero.
+func FormatQ128(v *big.Int, digits int) string {
+	abs := new(big.Int).Abs(v)
+	intPart := new(big.Int).Rsh(abs, Precision128)                         // Q.128 => Q.0
+	frac := new(big.Int).Sub(abs, new(big.Int).Lsh(intPart, Precision128)) // Q.128
+
+	out := intPart.String()
+	if digits > 0 {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+		frac.Mul(frac, scale)
+		frac.Rsh(frac, Precision128) // Q.128 => Q.0
+		out = fmt.Sprintf("%s.%0*s", out, digits, frac.String())
+	} else {
+		frac.SetInt64(0)
+	}
+	if v.Sign() < 0 && (intPart.Sign() != 0 || frac.Sign() != 0) {
+		out = "-" + out
+	}
+	return out
+}
+
+// ParseQ128 parses a decimal string such as "-12.5" into Q.128 representation,
+// truncating any precision below 2^-128 towards zero.
+func ParseQ128(s string) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	num := new(big.Int).Lsh(r.Num(), Precision128) // Q.0 => Q.128
+	return num.Quo(num, r.Denom()), nil
+}
@@ -0,0 +1,44 @@
+package math_test
+
+import (
+	gbig "math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+func TestFormatQ128(t *testing.T) {
+	one := new(gbig.Int).Lsh(gbig.NewInt(1), math.Precision128)
+	half := new(gbig.Int).Rsh(one, 1)
+	oneAndHalf := new(gbig.Int).Add(one, half)
+
+	assert.Equal(t, "1.000", math.FormatQ128(one, 3))
+	assert.Equal(t, "1.500", math.FormatQ128(oneAndHalf, 3))
+	assert.Equal(t, "-1.500", math.FormatQ128(new(gbig.Int).Neg(oneAndHalf), 3))
+	assert.Equal(t, "0.5", math.FormatQ128(half, 1))
+	assert.Equal(t, "1", math.FormatQ128(oneAndHalf, 0))
+	// values below the printed precision lose their sign
+	assert.Equal(t, "0.00", math.FormatQ128(gbig.NewInt(-1), 2))
+}
+
+func TestParseQ128(t *testing.T) {
+	one := new(gbig.Int).Lsh(gbig.NewInt(1), math.Precision128)
+
+	v, err := math.ParseQ128("1")
+	require.NoError(t, err)
+	assert.Equal(t, one, v)
+
+	v, err = math.ParseQ128("-0.25")
+	require.NoError(t, err)
+	assert.Equal(t, new(gbig.Int).Neg(new(gbig.Int).Rsh(one, 2)), v)
+
+	v, err = math.ParseQ128("12.75")
+	require.NoError(t, err)
+	assert.Equal(t, "12.75", math.FormatQ128(v, 2))
+
+	_, err = math.ParseQ128("twelve")
+	require.Error(t, err)
+}
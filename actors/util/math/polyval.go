@@ -20,3 +20,22 @@ func Polyval(p []*big.Int, x *big.Int) *big.Int {
 
 	return res
 }
+
+// Precision of intermediate values that need more headroom than Q.128.
+const Precision256 = 256
+
+// PolyvalQ256 evaluates a polynomial given by coefficients `p` in Q.128 format
+// at point `x` in Q.128 format, carrying the accumulator in Q.256 so that
+// truncation at each step of Horner's method is negligible. Output is in Q.256.
+func PolyvalQ256(p []*big.Int, x *big.Int) *big.Int {
+	res := new(big.Int).Lsh(p[0], Precision128) // Q.128 => Q.256
+	tmp := new(big.Int)
+	c256 := new(big.Int)
+	for _, c := range p[1:] {
+		tmp = tmp.Mul(res, x)            // Q.256 * Q.128 => Q.384
+		res = res.Rsh(tmp, Precision128) // Q.384 >> 128 => Q.256
+		res = res.Add(res, c256.Lsh(c, Precision128))
+	}
+
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	smoothing6 "github.com/filecoin-project/specs-actors/v6/actors/util/smoothing"
 
+	"github.com/filecoin-project/specs-actors/v7/actors/util/fixed"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
 )
 
@@ -45,7 +46,7 @@ type FilterEstimate = smoothing6.FilterEstimate
 
 // Returns the Q.0 position estimate of the filter
 func Estimate(fe *FilterEstimate) big.Int {
-	return big.Rsh(fe.PositionEstimate, math.Precision128) // Q.128 => Q.0
+	return fixed.FromRaw(fe.PositionEstimate).Floor()
 }
 
 // Extrapolate filter "position" delta epochs in the future.
@@ -70,8 +71,8 @@ func DefaultInitialEstimate() FilterEstimate {
 // Create a new filter estimate given two Q.0 format ints.
 func NewEstimate(position, velocity big.Int) FilterEstimate {
 	return FilterEstimate{
-		PositionEstimate: big.Lsh(position, math.Precision128), // Q.0 => Q.128
-		VelocityEstimate: big.Lsh(velocity, math.Precision128), // Q.0 => Q.128
+		PositionEstimate: fixed.FromInt(position).Raw(),
+		VelocityEstimate: fixed.FromInt(velocity).Raw(),
 	}
 }
 
@@ -162,3 +163,60 @@ func ExtrapolatedCumSumOfRatio(delta abi.ChainEpoch, relativeStart abi.ChainEpoc
 	return cumsumRatio
 
 }
+
+// Extrapolate the CumSumRatio given two filters for each of several deltas from the same relative start.
+// The work that depends only on the filters and start is shared between deltas,
+// and each result is identical to that of ExtrapolatedCumSumOfRatio for the same delta.
+// Output is in Q.128 format
+func ExtrapolatedCumSumsOfRatio(deltas []abi.ChainEpoch, relativeStart abi.ChainEpoch, estimateNum, estimateDenom FilterEstimate) []big.Int {
+	t0 := big.Lsh(big.NewInt(int64(relativeStart)), math.Precision128) // Q.0 => Q.128
+	position1 := estimateNum.PositionEstimate
+	position2 := estimateDenom.PositionEstimate
+	velocity1 := estimateNum.VelocityEstimate
+	velocity2 := estimateDenom.VelocityEstimate
+
+	squaredVelocity2 := big.Mul(velocity2, velocity2)               // Q.128 * Q.128 => Q.256
+	squaredVelocity2 = big.Rsh(squaredVelocity2, math.Precision128) // Q.256 => Q.128
+
+	out := make([]big.Int, len(deltas))
+	if squaredVelocity2.GreaterThan(ExtrapolatedCumSumRatioEpsilon) {
+		x2a := big.Mul(t0, velocity2)         // Q.128 * Q.128 => Q.256
+		x2a = big.Rsh(x2a, math.Precision128) // Q.256 => Q.128
+		x2a = big.Sum(position2, x2a)
+		lnX2a := math.Ln(x2a) // Q.128
+
+		for i, delta := range deltas {
+			deltaT := big.Lsh(big.NewInt(int64(delta)), math.Precision128) // Q.0 => Q.128
+			x2b := big.Mul(deltaT, velocity2)                              // Q.128 * Q.128 => Q.256
+			x2b = big.Rsh(x2b, math.Precision128)                          // Q.256 => Q.128
+			x2b = big.Sum(x2a, x2b)
+			lnX2b := math.Ln(x2b) // Q.128
+
+			m1 := big.Sub(lnX2b, lnX2a)
+			m1 = big.Mul(velocity2, big.Mul(position1, m1)) // Q.128 * Q.128 * Q.128 => Q.384
+			m1 = big.Rsh(m1, math.Precision128)             //Q.384 => Q.256
+
+			m2L := big.Sub(lnX2a, lnX2b)
+			m2L = big.Mul(position2, m2L)     // Q.128 * Q.128 => Q.256
+			m2R := big.Mul(velocity2, deltaT) // Q.128 * Q.128 => Q.256
+			m2 := big.Sum(m2L, m2R)
+			m2 = big.Mul(velocity1, m2)         // Q.256 => Q.384
+			m2 = big.Rsh(m2, math.Precision128) //Q.384 => Q.256
+
+			out[i] = big.Div(big.Sum(m1, m2), squaredVelocity2) // Q.256 / Q.128 => Q.128
+		}
+		return out
+	}
+
+	for i, delta := range deltas {
+		deltaT := big.Lsh(big.NewInt(int64(delta)), math.Precision128) // Q.0 => Q.128
+		halfDeltaT := big.Rsh(deltaT, 1)                               // Q.128 / Q.0 => Q.128
+		x1m := big.Mul(velocity1, big.Sum(t0, halfDeltaT))             // Q.128 * Q.128 => Q.256
+		x1m = big.Rsh(x1m, math.Precision128)                          // Q.256 => Q.128
+		x1m = big.Add(position1, x1m)
+
+		cumsumRatio := big.Mul(x1m, deltaT)      // Q.128 * Q.128 => Q.256
+		out[i] = big.Div(cumsumRatio, position2) // Q.256 / Q.128 => Q.128
+	}
+	return out
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
@@ -151,6 +152,24 @@ func TestCumSumRatioProjection(t *testing.T) {
 
 }
 
+func TestExtrapolatedCumSumsOfRatio(t *testing.T) {
+	deltas := []abi.ChainEpoch{1, builtin.EpochsInDay, 20 * builtin.EpochsInDay, 180 * builtin.EpochsInDay}
+	reward := smoothing.TestingEstimate(big.NewInt(36266260308195979), big.NewInt(-1e9))
+	for _, power := range []smoothing.FilterEstimate{
+		smoothing.TestingConstantEstimate(big.NewInt(1 << 60)),               // constant denominator
+		smoothing.TestingEstimate(big.NewInt(1<<60), big.NewInt(1<<40)),      // growing denominator
+		smoothing.TestingEstimate(big.NewInt(1<<60), big.NewInt(-(1 << 40))), // shrinking denominator
+	} {
+		for _, t0 := range []abi.ChainEpoch{0, 1000} {
+			batch := smoothing.ExtrapolatedCumSumsOfRatio(deltas, t0, reward, power)
+			require.Len(t, batch, len(deltas))
+			for i, delta := range deltas {
+				assert.Equal(t, smoothing.ExtrapolatedCumSumOfRatio(delta, t0, reward, power), batch[i])
+			}
+		}
+	}
+}
+
 // Millionths of difference between val1 and val2
 // (val1 - val2) / val1 * 1e6
 // all inputs Q.128, output Q.0
@@ -0,0 +1,65 @@
+package smoothing
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+// An Estimator produces the next smoothed estimate of a value from an observation of that value
+// made epochDelta epochs after the estimate it was loaded with.
+type Estimator interface {
+	NextEstimate(observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate
+}
+
+// Loads the estimator used by the reward and power actors to smooth their per-epoch values.
+// The network always uses the alpha-beta filter with default parameters. Tests and simulations may
+// replace this to compare the behavior of alternative estimators at the same integration points.
+var LoadEstimator = func(prevEstimate FilterEstimate) Estimator {
+	return LoadFilter(prevEstimate, DefaultAlpha, DefaultBeta)
+}
+
+var _ Estimator = (*AlphaBetaFilter)(nil)
+var _ Estimator = (*ExponentialMovingAverage)(nil)
+
+// An exponential moving average of the observed value, with the velocity estimated as an
+// exponential moving average of the change in position per epoch.
+// Each elapsed epoch decays the weight of the previous estimate by a factor of (1 - alpha).
+type ExponentialMovingAverage struct {
+	prevEstimate FilterEstimate
+	alpha        big.Int // Q.128
+}
+
+func LoadExponentialMovingAverage(prevEstimate FilterEstimate, alpha big.Int) *ExponentialMovingAverage {
+	return &ExponentialMovingAverage{
+		prevEstimate: prevEstimate,
+		alpha:        alpha,
+	}
+}
+
+func (f *ExponentialMovingAverage) NextEstimate(observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate {
+	one := big.Lsh(big.NewInt(1), math.Precision128) // Q.128
+	// Weight of the observation after epochDelta epochs of decay: 1 - (1 - alpha)^epochDelta
+	decay := math.ExpBySquaring(big.Sub(one, f.alpha), int64(epochDelta)) // Q.128
+	weight := big.Sub(one, decay)                                         // Q.128
+
+	observation = big.Lsh(observation, math.Precision128) // Q.0 => Q.128
+	residual := big.Sub(observation, f.prevEstimate.PositionEstimate)
+	revisionX := big.Mul(weight, residual)            // Q.128 * Q.128 => Q.256
+	revisionX = big.Rsh(revisionX, math.Precision128) // Q.256 => Q.128
+	position := big.Sum(f.prevEstimate.PositionEstimate, revisionX)
+
+	velocity := f.prevEstimate.VelocityEstimate
+	if epochDelta > 0 {
+		observedV := big.Div(revisionX, big.NewInt(int64(epochDelta))) // Q.128 / Q.0 => Q.128
+		revisionV := big.Mul(weight, big.Sub(observedV, velocity))     // Q.128 * Q.128 => Q.256
+		revisionV = big.Rsh(revisionV, math.Precision128)              // Q.256 => Q.128
+		velocity = big.Sum(velocity, revisionV)
+	}
+
+	return FilterEstimate{
+		PositionEstimate: position,
+		VelocityEstimate: velocity,
+	}
+}
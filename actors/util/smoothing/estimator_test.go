@@ -0,0 +1,61 @@
+package smoothing_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+func TestLoadEstimator(t *testing.T) {
+	prev := smoothing.TestingEstimate(big.NewInt(1000), big.NewInt(1))
+	expected := smoothing.LoadFilter(prev, smoothing.DefaultAlpha, smoothing.DefaultBeta).NextEstimate(big.NewInt(1100), 1)
+	assert.Equal(t, expected, smoothing.LoadEstimator(prev).NextEstimate(big.NewInt(1100), 1))
+
+	t.Run("replaceable", func(t *testing.T) {
+		defaultLoader := smoothing.LoadEstimator
+		defer func() { smoothing.LoadEstimator = defaultLoader }()
+		smoothing.LoadEstimator = func(prev smoothing.FilterEstimate) smoothing.Estimator {
+			return smoothing.LoadExponentialMovingAverage(prev, smoothing.DefaultAlpha)
+		}
+		_, isEMA := smoothing.LoadEstimator(prev).(*smoothing.ExponentialMovingAverage)
+		assert.True(t, isEMA)
+	})
+}
+
+func TestExponentialMovingAverage(t *testing.T) {
+	alpha := big.Div(big.Lsh(big.NewInt(1), 128), big.NewInt(10)) // 0.1
+
+	t.Run("converges to constant observation", func(t *testing.T) {
+		estimate := smoothing.DefaultInitialEstimate()
+		for i := 0; i < 500; i++ {
+			estimate = smoothing.LoadExponentialMovingAverage(estimate, alpha).NextEstimate(big.NewInt(5000), 1)
+		}
+		assert.Equal(t, big.NewInt(4999), smoothing.Estimate(&estimate))
+	})
+
+	t.Run("epoch delta compounds decay", func(t *testing.T) {
+		prev := smoothing.TestingConstantEstimate(big.NewInt(0))
+		stepwise := prev
+		for i := 0; i < 3; i++ {
+			stepwise = smoothing.LoadExponentialMovingAverage(stepwise, alpha).NextEstimate(big.NewInt(1e6), 1)
+		}
+		skipped := smoothing.LoadExponentialMovingAverage(prev, alpha).NextEstimate(big.NewInt(1e6), abi.ChainEpoch(3))
+		// 1 - 0.9^3 = 0.271, less truncation error
+		assert.InDelta(t, 271000, smoothing.Estimate(&skipped).Int64(), 1)
+		assert.InDelta(t, smoothing.Estimate(&stepwise).Int64(), smoothing.Estimate(&skipped).Int64(), 1)
+	})
+
+	t.Run("velocity tracks growth", func(t *testing.T) {
+		estimate := smoothing.DefaultInitialEstimate()
+		for i := int64(1); i <= 200; i++ {
+			estimate = smoothing.LoadExponentialMovingAverage(estimate, alpha).NextEstimate(big.NewInt(i*100), 1)
+		}
+		velocity := big.Rsh(estimate.VelocityEstimate, 128)
+		assert.True(t, velocity.GreaterThanEqual(big.NewInt(90)), velocity)
+		assert.True(t, velocity.LessThanEqual(big.NewInt(100)), velocity)
+	})
+}
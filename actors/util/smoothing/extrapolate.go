@@ -0,0 +1,57 @@
+package smoothing
+
+import (
+	gbig "math/big"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+// Returns the Q.0 position estimate of the filter extrapolated delta epochs in the future.
+func EstimateAt(fe *FilterEstimate, delta abi.ChainEpoch) big.Int {
+	return big.Rsh(Extrapolate(fe, delta), 2*math.Precision128) // Q.256 => Q.0
+}
+
+// Returns the variance of an alpha-beta filter's position estimate extrapolated delta epochs in the future,
+// in squared units of the (Q.0) estimate.
+// The filter with Q.128 parameters alpha and beta is assumed to have reached steady state while tracking
+// observations whose noise has the given Q.0 variance. The variance grows quadratically with delta as errors
+// in the velocity estimate accumulate.
+func ExtrapolationVariance(alpha, beta big.Int, observationVariance big.Int, delta abi.ChainEpoch) big.Int {
+	one := big.Lsh(big.NewInt(1), math.Precision128) // Q.128
+	two := big.NewInt(2)
+	k := big.NewInt(int64(delta)) // Q.0
+
+	// Steady state estimate covariances are proportional to the observation variance (Kalata, 1984):
+	//   Pxx = s * (2a^2 + 2b - 3ab) / d
+	//   Pxv = s * b(2a - b) / d
+	//   Pvv = s * 2b^2 / d
+	// where d = a(4 - 2a - b).
+	// The variance of x + k * v is Pxx + 2k * Pxv + k^2 * Pvv.
+	alphaSq := big.Rsh(big.Mul(alpha, alpha), math.Precision128)  // Q.256 => Q.128
+	betaSq := big.Rsh(big.Mul(beta, beta), math.Precision128)     // Q.256 => Q.128
+	alphaBeta := big.Rsh(big.Mul(alpha, beta), math.Precision128) // Q.256 => Q.128
+
+	d := big.Sub(big.Sub(big.Mul(big.NewInt(4), one), big.Mul(two, alpha)), beta)
+	d = big.Rsh(big.Mul(alpha, d), math.Precision128) // Q.256 => Q.128
+
+	pxx := big.Sub(big.Sum(big.Mul(two, alphaSq), big.Mul(two, beta)), big.Mul(big.NewInt(3), alphaBeta)) // Q.128
+	pxv := big.Sub(big.Mul(two, alphaBeta), betaSq)                                                       // Q.128
+	pvv := big.Mul(two, betaSq)                                                                           // Q.128
+
+	numerator := big.Sum(pxx, big.Mul(big.Mul(two, k), pxv), big.Mul(big.Mul(k, k), pvv)) // Q.128
+	return big.Div(big.Mul(observationVariance, numerator), d)                            // Q.128 / Q.128 => Q.0
+}
+
+// Returns the Q.0 position estimate of a default alpha-beta filter extrapolated delta epochs in the future,
+// with lower and upper bounds at the given number of standard deviations from it.
+// See ExtrapolationVariance for the assumptions behind the bounds.
+func ExtrapolateWithBounds(fe *FilterEstimate, delta abi.ChainEpoch, observationVariance big.Int, sigmas int64) (estimate, lower, upper big.Int) {
+	estimate = EstimateAt(fe, delta)
+	variance := ExtrapolationVariance(DefaultAlpha, DefaultBeta, observationVariance, delta)
+	stdDev := big.NewFromGo(new(gbig.Int).Sqrt(variance.Int))
+	bound := big.Mul(stdDev, big.NewInt(sigmas))
+	return estimate, big.Sub(estimate, bound), big.Add(estimate, bound)
+}
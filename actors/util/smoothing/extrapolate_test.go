@@ -0,0 +1,48 @@
+package smoothing_test
+
+import (
+	gmath "math"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+func TestEstimateAt(t *testing.T) {
+	fe := smoothing.TestingEstimate(big.NewInt(1000), big.NewInt(-3))
+	assert.Equal(t, big.NewInt(1000), smoothing.EstimateAt(&fe, 0))
+	assert.Equal(t, big.NewInt(700), smoothing.EstimateAt(&fe, 100))
+}
+
+func TestExtrapolationVariance(t *testing.T) {
+	// float reference for the steady state alpha-beta filter covariance
+	a, b := 9.25e-4, 2.84e-7
+	d := a * (4 - 2*a - b)
+	reference := func(s float64, k float64) float64 {
+		return s * (2*a*a + 2*b - 3*a*b + 2*k*b*(2*a-b) + k*k*2*b*b) / d
+	}
+
+	observationVariance := big.NewInt(1e12)
+	for _, delta := range []abi.ChainEpoch{0, 1, 2880, 2880 * 180} {
+		actual := smoothing.ExtrapolationVariance(smoothing.DefaultAlpha, smoothing.DefaultBeta, observationVariance, delta)
+		expected := reference(1e12, float64(delta))
+		assert.InEpsilon(t, expected, float64(actual.Int64()), 1e-4, "delta %d", delta)
+	}
+
+	t.Run("bounds", func(t *testing.T) {
+		fe := smoothing.TestingEstimate(big.NewInt(1e12), big.NewInt(1e6))
+		estimate, lower, upper := smoothing.ExtrapolateWithBounds(&fe, 1000, big.NewInt(1e18), 2)
+		assert.Equal(t, big.NewInt(1e12+1e9), estimate)
+		assert.Equal(t, big.Sub(estimate, lower), big.Sub(upper, estimate))
+
+		stdDev := gmath.Sqrt(reference(1e18, 1000))
+		assert.InEpsilon(t, 2*stdDev, float64(big.Sub(upper, estimate).Int64()), 1e-4)
+
+		_, lower, upper = smoothing.ExtrapolateWithBounds(&fe, 1000, big.Zero(), 2)
+		assert.Equal(t, estimate, lower)
+		assert.Equal(t, estimate, upper)
+	})
+}
@@ -0,0 +1,85 @@
+package smoothing
+
+import (
+	"encoding/json"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+)
+
+// Number of fractional digits used when rendering Q.128 estimates as decimals.
+const JSONDecimalDigits = 18
+
+// JSON representation of a FilterEstimate.
+// The raw Q.128 fields are decimal strings of the fixed-point integers and round-trip exactly.
+// Position and Velocity hold the same values converted to decimals (value and value per epoch)
+// so consumers can chart them directly. When decoding, the raw fields take precedence and the
+// decimal fields are only used if the raw fields are absent.
+type FilterEstimateJSON struct {
+	PositionEstimate big.Int // Q.128
+	VelocityEstimate big.Int // Q.128
+	Position         string  // decimal
+	Velocity         string  // decimal
+}
+
+func NewFilterEstimateJSON(fe FilterEstimate) FilterEstimateJSON {
+	return FilterEstimateJSON{
+		PositionEstimate: fe.PositionEstimate,
+		VelocityEstimate: fe.VelocityEstimate,
+		Position:         formatQ128(fe.PositionEstimate),
+		Velocity:         formatQ128(fe.VelocityEstimate),
+	}
+}
+
+// Converts the JSON representation back into a FilterEstimate.
+func (j *FilterEstimateJSON) FilterEstimate() (FilterEstimate, error) {
+	position, err := pickQ128(j.PositionEstimate, j.Position)
+	if err != nil {
+		return FilterEstimate{}, xerrors.Errorf("invalid position: %w", err)
+	}
+	velocity, err := pickQ128(j.VelocityEstimate, j.Velocity)
+	if err != nil {
+		return FilterEstimate{}, xerrors.Errorf("invalid velocity: %w", err)
+	}
+	return FilterEstimate{
+		PositionEstimate: position,
+		VelocityEstimate: velocity,
+	}, nil
+}
+
+func MarshalEstimateJSON(fe FilterEstimate) ([]byte, error) {
+	// Marshal through a pointer so the big.Int fields use their pointer receiver encoding as strings.
+	j := NewFilterEstimateJSON(fe)
+	return json.Marshal(&j)
+}
+
+func UnmarshalEstimateJSON(data []byte) (FilterEstimate, error) {
+	var j FilterEstimateJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return FilterEstimate{}, err
+	}
+	return j.FilterEstimate()
+}
+
+func formatQ128(v big.Int) string {
+	if v.Int == nil {
+		return "0"
+	}
+	return math.FormatQ128(v.Int, JSONDecimalDigits)
+}
+
+func pickQ128(raw big.Int, decimal string) (big.Int, error) {
+	if raw.Int != nil {
+		return raw, nil
+	}
+	if decimal == "" {
+		return big.Zero(), nil
+	}
+	v, err := math.ParseQ128(decimal)
+	if err != nil {
+		return big.Int{}, err
+	}
+	return big.NewFromGo(v), nil
+}
@@ -0,0 +1,55 @@
+package smoothing_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/math"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+func TestFilterEstimateJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		fe := smoothing.TestingEstimate(big.NewInt(5000), big.NewInt(-3))
+		filter := smoothing.LoadFilter(fe, smoothing.DefaultAlpha, smoothing.DefaultBeta)
+		fe = filter.NextEstimate(big.NewInt(5100), 1)
+
+		data, err := smoothing.MarshalEstimateJSON(fe)
+		require.NoError(t, err)
+		decoded, err := smoothing.UnmarshalEstimateJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, fe, decoded)
+	})
+
+	t.Run("decimal fields", func(t *testing.T) {
+		fe := smoothing.TestingEstimate(big.NewInt(42), big.NewInt(-7))
+		j := smoothing.NewFilterEstimateJSON(fe)
+		assert.Equal(t, "42.000000000000000000", j.Position)
+		assert.Equal(t, "-7.000000000000000000", j.Velocity)
+	})
+
+	t.Run("decode from decimals only", func(t *testing.T) {
+		decoded, err := smoothing.UnmarshalEstimateJSON([]byte(`{"Position":"2.5","Velocity":"-0.5"}`))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(2), smoothing.Estimate(&decoded))
+		assert.Equal(t, big.Lsh(big.NewInt(5), math.Precision128-1), decoded.PositionEstimate)
+		assert.Equal(t, big.Lsh(big.NewInt(-1), math.Precision128-1), decoded.VelocityEstimate)
+	})
+
+	t.Run("invalid decimal", func(t *testing.T) {
+		_, err := smoothing.UnmarshalEstimateJSON([]byte(`{"Position":"x"}`))
+		require.Error(t, err)
+	})
+
+	t.Run("raw fields are decimal strings", func(t *testing.T) {
+		data, err := smoothing.MarshalEstimateJSON(smoothing.TestingConstantEstimate(big.NewInt(1)))
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, "340282366920938463463374607431768211456", raw["PositionEstimate"])
+	})
+}
@@ -0,0 +1,38 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+const genesisSeed = 93837778
+
+var largeBalance = big.Mul(big.NewInt(1_000_000), vm.FIL)
+
+// Applies a message b.N times, each to the VM's state as it was when the benchmark began, and reports the gas
+// the message charges. The message must succeed.
+func benchmarkMessage(b *testing.B, v *vm.VM, from, to address.Address, method abi.MethodNum, params interface{}) {
+	snap, err := v.Snapshot()
+	require.NoError(b, err)
+
+	var gas int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := v.ApplyMessage(from, to, big.Zero(), method, params, b.Name())
+		b.StopTimer()
+		require.NoError(b, err)
+		require.Equal(b, exitcode.Ok, result.Code, "benchmarked message failed")
+		gas = result.GasCharged
+		require.NoError(b, v.Revert(snap))
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(gas), "gas/op")
+}
@@ -0,0 +1,11 @@
+// Package benchmarks holds benchmarks of the builtin actors' hottest methods, applied as messages in the test VM
+// to state of realistic size, as a baseline against which to measure performance-motivated changes.
+//
+// The state for each benchmark is built once, from a fixed genesis, and every iteration applies the same message
+// to it, reverting the state after each. Alongside time and allocations, each benchmark reports the gas charged by
+// the message, which is deterministic and so comparable across machines.
+//
+// Run them with:
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem
+package benchmarks
@@ -0,0 +1,128 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+const dealClients = 4
+
+var dealSealProof = abi.RegisteredSealProof_StackedDrg32GiBV1_1
+
+// A market with a storage provider and clients, all with enough escrow to publish thousands of deals.
+type marketFixture struct {
+	v         *vm.VM
+	worker    address.Address
+	minerAddr address.Address
+	clients   []address.Address
+	template  market.DealProposal
+}
+
+func newMarketFixture(b *testing.B) *marketFixture {
+	accounts := make([]vm.GenesisAccount, 1+dealClients)
+	for i := range accounts {
+		accounts[i] = vm.GenesisAccount{Balance: largeBalance}
+	}
+	v, actors := vm.NewVMWithGenesis(context.Background(), b, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: accounts,
+		Seed:     genesisSeed,
+		Miners: []vm.GenesisMiner{{
+			SealProof: dealSealProof,
+			Balance:   big.Mul(big.NewInt(100_000), vm.FIL),
+		}},
+	})
+	f := &marketFixture{worker: actors.Accounts[0], minerAddr: actors.Miners[0].IDAddress, clients: actors.Accounts[1:]}
+	v, err := v.WithEpoch(200)
+	require.NoError(b, err)
+
+	escrow := big.Mul(big.NewInt(100_000), vm.FIL)
+	for _, client := range f.clients {
+		client := client
+		vm.ApplyOk(b, v, client, builtin.StorageMarketActorAddr, escrow, builtin.MethodsMarket.AddBalance, &client)
+	}
+	vm.ApplyOk(b, v, f.worker, builtin.StorageMarketActorAddr, escrow, builtin.MethodsMarket.AddBalance, &f.minerAddr)
+
+	dealStart := v.GetEpoch() + miner.MaxProveCommitDuration[dealSealProof]
+	f.template = market.DealProposal{
+		PieceSize:            4 << 30,
+		Label:                "benchmark",
+		StartEpoch:           dealStart,
+		EndEpoch:             dealStart + 180*builtin.EpochsInDay,
+		StoragePricePerEpoch: abi.NewTokenAmount(1 << 20),
+		ProviderCollateral:   big.Mul(big.NewInt(2), vm.FIL),
+		ClientCollateral:     big.Mul(big.NewInt(1), vm.FIL),
+	}
+	f.v = v
+	return f
+}
+
+// Publishes count deals, in messages of at most publishBatch deals.
+func (f *marketFixture) publishDeals(b *testing.B, count int) []abi.DealID {
+	var dealIDs []abi.DealID
+	for published := 0; published < count; published += publishBatch {
+		size := publishBatch
+		if count-published < size {
+			size = count - published
+		}
+		dealIDs = append(dealIDs, vm.PublishDeals(b, f.v, f.worker, f.minerAddr, f.clients, size, f.template)...)
+	}
+	return dealIDs
+}
+
+const publishBatch = 100
+
+// Benchmarks publishing batches of deals to a market already holding thousands of pending deals.
+func BenchmarkPublishStorageDeals(b *testing.B) {
+	f := newMarketFixture(b)
+	f.publishDeals(b, 5_000)
+
+	for _, size := range []int{1, 10, 100} {
+		params := vm.PublishDealsParams(b, f.v, f.minerAddr, f.clients, size, f.template)
+		b.Run(fmt.Sprintf("deals=%d", size), func(b *testing.B) {
+			benchmarkMessage(b, f.v, f.worker, builtin.StorageMarketActorAddr, builtin.MethodsMarket.PublishStorageDeals, params)
+		})
+	}
+}
+
+// Benchmarks the market's cron tick processing a number of active deals for the first time.
+// The processing of deals is spread over the epochs of a day, so the tick is made a day after the deals start
+// with no tick between, such that it processes every deal, as though they had all been scheduled for one epoch.
+func BenchmarkMarketCronTick(b *testing.B) {
+	for _, count := range []int{100, 1_000} {
+		f := newMarketFixture(b)
+		dealIDs := f.publishDeals(b, count)
+
+		// Activate the deals in sectors each holding as many as fit.
+		sectorSize, err := dealSealProof.SectorSize()
+		require.NoError(b, err)
+		perSector := int(uint64(sectorSize) / uint64(f.template.PieceSize))
+		var dealsPerSector [][]abi.DealID
+		for i := 0; i < len(dealIDs); i += perSector {
+			end := i + perSector
+			if end > len(dealIDs) {
+				end = len(dealIDs)
+			}
+			dealsPerSector = append(dealsPerSector, dealIDs[i:end])
+		}
+		v, _ := vm.OnboardSectors(b, f.v, f.worker, f.minerAddr, dealSealProof, 0, len(dealsPerSector),
+			f.template.EndEpoch+builtin.EpochsInDay, dealsPerSector)
+		v, err = v.WithEpoch(f.template.StartEpoch + market.DealUpdatesInterval)
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprintf("deals=%d", count), func(b *testing.B) {
+			benchmarkMessage(b, v, builtin.CronActorAddr, builtin.StorageMarketActorAddr, builtin.MethodsMarket.CronTick, nil)
+		})
+	}
+}
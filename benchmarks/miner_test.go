@@ -0,0 +1,143 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func init() {
+	// permit 2KiB sectors, with which to fill many partitions of a deadline
+	miner.PreCommitSealProofTypesV8[abi.RegisteredSealProof_StackedDrg2KiBV1_1] = struct{}{}
+	miner.WindowPoStProofTypes[abi.RegisteredPoStProof_StackedDrgWindow2KiBV1] = struct{}{}
+}
+
+// Benchmarks a Window PoSt for a number of partitions of a deadline whose sectors have all been proven before,
+// as in a miner's steady state.
+// Deadlines are filled a partition at a time, so filling many partitions of one deadline with 32GiB sectors would
+// take hundreds of thousands of them. Instead, a single full partition of 32GiB sectors shows the cost of a
+// partition's sectors, while 2KiB sectors, with two to a partition, show how the cost grows with partitions.
+func BenchmarkSubmitWindowedPoSt(b *testing.B) {
+	b.Run("32GiB", func(b *testing.B) {
+		benchmarkWindowPoSt(b, abi.RegisteredSealProof_StackedDrg32GiBV1_1, 1)
+	})
+	b.Run("2KiB", func(b *testing.B) {
+		benchmarkWindowPoSt(b, abi.RegisteredSealProof_StackedDrg2KiBV1_1, 1, 4, 16)
+	})
+}
+
+func benchmarkWindowPoSt(b *testing.B, sealProof abi.RegisteredSealProof, partitionCounts ...int) {
+	maxPartitions := partitionCounts[len(partitionCounts)-1]
+	partitionSectors, err := builtin.SealProofWindowPoStPartitionSectors(sealProof)
+	require.NoError(b, err)
+	postProof, err := sealProof.RegisteredWindowPoStProof()
+	require.NoError(b, err)
+
+	// Genesis sectors fill a partition of one deadline before opening a partition in another, so enough sectors
+	// for maxPartitions in every deadline leave at least as many in the first.
+	sectors := int(partitionSectors) * maxPartitions
+	if maxPartitions > 1 {
+		sectors *= int(miner.WPoStPeriodDeadlines)
+	}
+	v, actors := vm.NewVMWithGenesis(context.Background(), b, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: largeBalance}},
+		Seed:     genesisSeed,
+		Miners: []vm.GenesisMiner{{
+			SealProof: sealProof,
+			Balance:   big.Div(largeBalance, big.NewInt(2)),
+			Sectors:   sectors,
+		}},
+	})
+	worker, minerAddr := actors.Accounts[0], actors.Miners[0].IDAddress
+
+	// Choose the deadline with the most partitions.
+	var dlIdx, partitions uint64
+	for i := uint64(0); i < miner.WPoStPeriodDeadlines; i++ {
+		arr, err := vm.DeadlineState(b, v, minerAddr, i).PartitionsArray(v.Store())
+		require.NoError(b, err)
+		if arr.Length() > partitions {
+			dlIdx, partitions = i, arr.Length()
+		}
+	}
+	require.GreaterOrEqual(b, partitions, uint64(maxPartitions))
+
+	// Prove the deadline's sectors for the first time, then advance to the deadline in the next proving period.
+	v, dlInfo := vm.AdvanceByDeadlineTillIndex(b, v, minerAddr, dlIdx)
+	v, err = v.WithEpoch(dlInfo.Open)
+	require.NoError(b, err)
+	vm.ApplyOk(b, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.SubmitWindowedPoSt,
+		windowPoStParams(v, dlInfo.Index, dlInfo.Challenge, postProof, int(partitions)))
+	v, _ = vm.AdvanceByDeadlineTillIndex(b, v, minerAddr, (dlIdx+1)%miner.WPoStPeriodDeadlines)
+	v, dlInfo = vm.AdvanceByDeadlineTillIndex(b, v, minerAddr, dlIdx)
+	v, err = v.WithEpoch(dlInfo.Open)
+	require.NoError(b, err)
+
+	for _, count := range partitionCounts {
+		params := windowPoStParams(v, dlInfo.Index, dlInfo.Challenge, postProof, count)
+		b.Run(fmt.Sprintf("partitions=%d", count), func(b *testing.B) {
+			benchmarkMessage(b, v, worker, minerAddr, builtin.MethodsMiner.SubmitWindowedPoSt, params)
+		})
+	}
+}
+
+// Benchmarks proving an aggregate of pre-committed sectors, for a miner with many sectors already committed.
+func BenchmarkProveCommitAggregate(b *testing.B) {
+	aggregateSizes := []int{miner.MinAggregatedSectors, 100, miner.MaxAggregatedSectors}
+	sealProof := abi.RegisteredSealProof_StackedDrg32GiBV1_1
+	v, actors := vm.NewVMWithGenesis(context.Background(), b, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: largeBalance}},
+		Seed:     genesisSeed,
+		Miners: []vm.GenesisMiner{{
+			SealProof: sealProof,
+			Balance:   big.Mul(big.NewInt(100_000), vm.FIL),
+			Sectors:   1_000,
+		}},
+	})
+	worker, minerAddr := actors.Accounts[0], actors.Miners[0].IDAddress
+	v, err := v.WithEpoch(200)
+	require.NoError(b, err)
+
+	expiration := v.GetEpoch() + miner.MaxSectorExpirationExtension
+	sectorNumbers := vm.PreCommitSectors(b, v, worker, minerAddr, sealProof, 1_000, miner.MaxAggregatedSectors, expiration, nil)
+	proveEpoch := v.GetEpoch() + miner.PreCommitChallengeDelay + 1
+	v, _ = vm.AdvanceByDeadlineTillEpoch(b, v, minerAddr, proveEpoch)
+	v, err = v.WithEpoch(proveEpoch)
+	require.NoError(b, err)
+
+	for _, size := range aggregateSizes {
+		toProve := make([]uint64, size)
+		for i, sectorNumber := range sectorNumbers[:size] {
+			toProve[i] = uint64(sectorNumber)
+		}
+		params := &miner.ProveCommitAggregateParams{SectorNumbers: bitfield.NewFromSet(toProve)}
+		b.Run(fmt.Sprintf("sectors=%d", size), func(b *testing.B) {
+			benchmarkMessage(b, v, worker, minerAddr, builtin.MethodsMiner.ProveCommitAggregate, params)
+		})
+	}
+}
+
+// Returns parameters of a Window PoSt for the first count partitions of a deadline.
+func windowPoStParams(v *vm.VM, dlIdx uint64, challenge abi.ChainEpoch, postProof abi.RegisteredPoStProof, count int) *miner.SubmitWindowedPoStParams {
+	partitions := make([]miner.PoStPartition, count)
+	for i := range partitions {
+		partitions[i] = miner.PoStPartition{Index: uint64(i), Skipped: bitfield.New()}
+	}
+	return &miner.SubmitWindowedPoStParams{
+		Deadline:         dlIdx,
+		Partitions:       partitions,
+		Proofs:           []proof.PoStProof{{PoStProof: postProof}},
+		ChainCommitEpoch: challenge,
+		ChainCommitRand:  vm.ChainCommitRand(v, challenge),
+	}
+}
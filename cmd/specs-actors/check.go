@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"runtime"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+)
+
+func runCheck(e *env, fs *flag.FlagSet, args []string) error {
+	var sf stateFlags
+	sf.register(fs)
+	var actors stringsFlag
+	fs.Var(&actors, "actor", "address or singleton name of an actor to check; may be repeated (default all actors)")
+	balance := fs.String("balance", builtin.TotalFilecoin.String(), "expected total balance of all actors, in attoFIL")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of actors to check concurrently")
+	asJSON := fs.Bool("json", false, "print the messages as JSON records")
+	epoch := epochFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	expectedBalance, err := big.FromString(*balance)
+	if err != nil {
+		return xerrors.Errorf("invalid balance %q: %w", *balance, err)
+	}
+
+	tree, err := sf.load(context.Background())
+	if err != nil {
+		return err
+	}
+	priorEpoch, err := stateEpoch(tree, *epoch)
+	if err != nil {
+		return err
+	}
+	opts := states.CheckOptions{Workers: *workers}
+	for _, s := range actors {
+		a, _, err := resolveActor(tree, s)
+		if err != nil {
+			return err
+		}
+		opts.Addresses = append(opts.Addresses, a)
+	}
+
+	acc, err := states.CheckStateInvariantsWithOptions(tree, expectedBalance, priorEpoch, opts)
+	if err != nil {
+		return xerrors.Errorf("failed to check state invariants: %w", err)
+	}
+	records := acc.Records()
+	if *asJSON {
+		if err := json.NewEncoder(e.stdout).Encode(records); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range records {
+			fmt.Fprintf(e.stdout, "%s: %s\n", r.Severity, r.Message)
+		}
+	}
+
+	errs := 0
+	for _, r := range records {
+		if r.Severity == builtin.SeverityError {
+			errs++
+		}
+	}
+	if errs > 0 {
+		return xerrors.Errorf("%d of %d messages are errors", errs, len(records))
+	}
+	if !*asJSON {
+		fmt.Fprintf(e.stdout, "ok: %d warnings\n", len(records))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+	"github.com/filecoin-project/specs-actors/v7/support/dump"
+)
+
+func runDiff(e *env, fs *flag.FlagSet, args []string) error {
+	fromRoot := fs.String("from-root", "", "CID of the first state root (default the root of the first CAR file)")
+	toRoot := fs.String("to-root", "", "CID of the second state root (default the root of the second CAR file)")
+	asJSON := fs.Bool("json", false, "print JSON rather than text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+
+	ctx := context.Background()
+	storeA, rootA, err := loadCAR(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	storeB, rootB, err := loadCAR(ctx, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if rootA, err = overrideRoot(rootA, *fromRoot); err != nil {
+		return err
+	}
+	if rootB, err = overrideRoot(rootB, *toRoot); err != nil {
+		return err
+	}
+
+	diff, err := states.Diff(storeA, rootA, storeB, rootB)
+	if err != nil {
+		return xerrors.Errorf("failed to diff %v and %v: %w", rootA, rootB, err)
+	}
+
+	if *asJSON {
+		actors := make([]jsonenc.Object, len(diff.Actors))
+		for i, d := range diff.Actors {
+			actors[i] = jsonenc.Object{
+				{Name: "Address", Value: d.Address},
+				{Name: "Change", Value: d.Change.String()},
+				{Name: "Code", Value: builtin.ActorNameByCode(actorCode(d))},
+				{Name: "ActorFields", Value: fieldsJSON(d.ActorFields)},
+				{Name: "StateFields", Value: fieldsJSON(d.StateFields)},
+			}
+		}
+		return json.NewEncoder(e.stdout).Encode(actors)
+	}
+	for _, d := range diff.Actors {
+		marker := map[states.ActorChange]string{states.ActorAdded: "+", states.ActorRemoved: "-", states.ActorModified: "~"}[d.Change]
+		fmt.Fprintf(e.stdout, "%s %v %s\n", marker, d.Address, builtin.ActorNameByCode(actorCode(d)))
+		for _, f := range d.ActorFields {
+			fmt.Fprintf(e.stdout, "    %s: %s -> %s\n", f.Name, formatValue(f.From), formatValue(f.To))
+		}
+		for _, f := range d.StateFields {
+			fmt.Fprintf(e.stdout, "    state.%s: %s -> %s\n", f.Name, formatValue(f.From), formatValue(f.To))
+		}
+	}
+	fmt.Fprintf(e.stdout, "%d actors differ\n", len(diff.Actors))
+	return nil
+}
+
+func overrideRoot(root cid.Cid, flagValue string) (cid.Cid, error) {
+	if flagValue == "" {
+		return root, nil
+	}
+	c, err := cid.Decode(flagValue)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("invalid state root %q: %w", flagValue, err)
+	}
+	return c, nil
+}
+
+// The code of the actor in the second tree, or in the first if it was removed.
+func actorCode(d states.ActorDiff) cid.Cid {
+	if d.To != nil {
+		return d.To.Code
+	}
+	return d.From.Code
+}
+
+// Renders a field value on a single line, without loading any linked objects.
+func formatValue(v interface{}) string {
+	return strings.Join(strings.Fields(dumpValue(v).String()), " ")
+}
+
+func dumpValue(v interface{}) *dump.Node {
+	return dump.Value(nil, v, dump.Options{MaxDepth: 0, MaxEntries: dump.DefaultOptions.MaxEntries, MaxBytes: dump.DefaultOptions.MaxBytes})
+}
+
+func fieldsJSON(fields []states.FieldDiff) []jsonenc.Object {
+	obj := make([]jsonenc.Object, len(fields))
+	for i, f := range fields {
+		obj[i] = jsonenc.Object{{Name: "Name", Value: f.Name}, {Name: "From", Value: dumpValue(f.From)}, {Name: "To", Value: dumpValue(f.To)}}
+	}
+	return obj
+}
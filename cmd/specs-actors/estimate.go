@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+func runTerminationFee(e *env, fs *flag.FlagSet, args []string) error {
+	var sf stateFlags
+	sf.register(fs)
+	asJSON := fs.Bool("json", false, "print JSON rather than text")
+	epoch := epochFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+	sectorNos, err := parseSectors(fs.Arg(1))
+	if err != nil {
+		return xerrors.Errorf("invalid sectors %q: %v: %w", fs.Arg(1), err, errUsage)
+	}
+
+	tree, err := sf.load(context.Background())
+	if err != nil {
+		return err
+	}
+	currEpoch, err := stateEpoch(tree, *epoch)
+	if err != nil {
+		return err
+	}
+	a, actor, err := resolveActor(tree, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var st miner.State
+	if _, err := loadState(tree, a, builtin.StorageMinerActorCodeID, &st); err != nil {
+		return err
+	}
+	info, err := st.GetInfo(tree.Store)
+	if err != nil {
+		return xerrors.Errorf("failed to load info of miner %v: %w", a, err)
+	}
+	sectors, err := st.LoadSectorInfos(tree.Store, sectorNos)
+	if err != nil {
+		return xerrors.Errorf("failed to load sectors of miner %v: %w", a, err)
+	}
+	est, err := loadEstimates(tree)
+	if err != nil {
+		return err
+	}
+
+	// As computed by the miner actor when terminating sectors early.
+	type sectorFee struct {
+		Sector abi.SectorNumber
+		Fee    abi.TokenAmount
+	}
+	fees := make([]sectorFee, len(sectors))
+	total := big.Zero()
+	for i, s := range sectors {
+		fee := miner.PledgePenaltyForTermination(s.ExpectedDayReward, currEpoch-s.Activation, s.ExpectedStoragePledge,
+			est.networkQAPower, miner.QAPowerForSector(info.SectorSize, s), est.reward, s.ReplacedDayReward, s.ReplacedSectorAge)
+		fees[i] = sectorFee{s.SectorNumber, fee}
+		total = big.Add(total, fee)
+	}
+
+	if *asJSON {
+		return json.NewEncoder(e.stdout).Encode(jsonenc.Object{
+			{Name: "Miner", Value: a},
+			{Name: "Epoch", Value: currEpoch},
+			{Name: "Sectors", Value: fees},
+			{Name: "Total", Value: total},
+			{Name: "Balance", Value: actor.Balance},
+		})
+	}
+	for _, f := range fees {
+		fmt.Fprintf(e.stdout, "sector %d: %s\n", f.Sector, formatFIL(f.Fee))
+	}
+	fmt.Fprintf(e.stdout, "total for %d sectors at epoch %d: %s\n", len(fees), currEpoch, formatFIL(total))
+	return nil
+}
+
+func runInitialPledge(e *env, fs *flag.FlagSet, args []string) error {
+	var sf stateFlags
+	sf.register(fs)
+	size := fs.String("size", "32GiB", "sector size, e.g. 32GiB or 34359738368")
+	duration := fs.Int64("duration", 540*builtin.EpochsInDay, "sector lifetime in epochs")
+	dealWeight := fs.String("deal-weight", "0", "total space-time of unverified deals in the sector, in byte-epochs")
+	verifiedWeight := fs.String("verified-deal-weight", "0", "total space-time of verified deals in the sector, in byte-epochs")
+	circulating := fs.String("circulating", "", "circulating supply in attoFIL (default audited from the state)")
+	asJSON := fs.Bool("json", false, "print JSON rather than text")
+	epoch := epochFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	sectorSize, err := parseSectorSize(*size)
+	if err != nil {
+		return xerrors.Errorf("invalid sector size %q: %v: %w", *size, err, errUsage)
+	}
+	dw, err := big.FromString(*dealWeight)
+	if err != nil {
+		return xerrors.Errorf("invalid deal weight %q: %w", *dealWeight, err)
+	}
+	vw, err := big.FromString(*verifiedWeight)
+	if err != nil {
+		return xerrors.Errorf("invalid verified deal weight %q: %w", *verifiedWeight, err)
+	}
+
+	tree, err := sf.load(context.Background())
+	if err != nil {
+		return err
+	}
+	est, err := loadEstimates(tree)
+	if err != nil {
+		return err
+	}
+	var circSupply abi.TokenAmount
+	if *circulating != "" {
+		if circSupply, err = big.FromString(*circulating); err != nil {
+			return xerrors.Errorf("invalid circulating supply %q: %w", *circulating, err)
+		}
+	} else {
+		currEpoch, err := stateEpoch(tree, *epoch)
+		if err != nil {
+			return err
+		}
+		audit, err := states.AuditSupply(tree, currEpoch)
+		if err != nil {
+			return xerrors.Errorf("failed to audit circulating supply, which may be given with -circulating: %w", err)
+		}
+		circSupply = audit.Circulating
+	}
+
+	qaPower := miner.QAPowerForWeight(sectorSize, abi.ChainEpoch(*duration), dw, vw)
+	deposit := miner.PreCommitDepositForPower(est.reward, est.networkQAPower, qaPower)
+	pledge := miner.InitialPledgeForPower(qaPower, est.baselinePower, est.reward, est.networkQAPower, circSupply)
+
+	if *asJSON {
+		return json.NewEncoder(e.stdout).Encode(jsonenc.Object{
+			{Name: "QAPower", Value: qaPower},
+			{Name: "CirculatingSupply", Value: circSupply},
+			{Name: "PreCommitDeposit", Value: deposit},
+			{Name: "InitialPledge", Value: pledge},
+		})
+	}
+	fmt.Fprintf(e.stdout, "quality-adjusted power: %s\n", qaPower)
+	fmt.Fprintf(e.stdout, "circulating supply: %s\n", formatFIL(circSupply))
+	fmt.Fprintf(e.stdout, "pre-commit deposit: %s\n", formatFIL(deposit))
+	fmt.Fprintf(e.stdout, "initial pledge: %s\n", formatFIL(pledge))
+	return nil
+}
+
+// Network estimates on which miner fees and pledge depend.
+type estimates struct {
+	reward         smoothing.FilterEstimate
+	networkQAPower smoothing.FilterEstimate
+	baselinePower  abi.StoragePower
+}
+
+func loadEstimates(tree *states.Tree) (*estimates, error) {
+	var rst reward.State
+	if _, err := loadState(tree, builtin.RewardActorAddr, builtin.RewardActorCodeID, &rst); err != nil {
+		return nil, err
+	}
+	var pst power.State
+	if _, err := loadState(tree, builtin.StoragePowerActorAddr, builtin.StoragePowerActorCodeID, &pst); err != nil {
+		return nil, err
+	}
+	return &estimates{
+		reward:         rst.ThisEpochRewardSmoothed,
+		networkQAPower: pst.ThisEpochQAPowerSmoothed,
+		baselinePower:  rst.ThisEpochBaselinePower,
+	}, nil
+}
+
+// Parses a list of sector numbers and inclusive ranges, e.g. "1,5-9".
+func parseSectors(s string) (bitfield.BitField, error) {
+	var nos []uint64
+	for _, part := range strings.Split(s, ",") {
+		first, last := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			first, last = part[:i], part[i+1:]
+		}
+		lo, err := strconv.ParseUint(first, 10, 64)
+		if err != nil {
+			return bitfield.BitField{}, err
+		}
+		hi, err := strconv.ParseUint(last, 10, 64)
+		if err != nil {
+			return bitfield.BitField{}, err
+		}
+		if hi < lo {
+			return bitfield.BitField{}, xerrors.Errorf("empty range %s", part)
+		}
+		if hi-lo >= miner.AddressedSectorsMax {
+			return bitfield.BitField{}, xerrors.Errorf("range %s exceeds %d sectors", part, miner.AddressedSectorsMax)
+		}
+		for n := lo; n <= hi; n++ {
+			nos = append(nos, n)
+		}
+	}
+	return bitfield.NewFromSet(nos), nil
+}
+
+// Parses a sector size given in bytes or abbreviated, e.g. "32GiB".
+func parseSectorSize(s string) (abi.SectorSize, error) {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return abi.SectorSize(n), nil
+	}
+	for _, proof := range []abi.RegisteredSealProof{
+		abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+		abi.RegisteredSealProof_StackedDrg8MiBV1_1,
+		abi.RegisteredSealProof_StackedDrg512MiBV1_1,
+		abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+		abi.RegisteredSealProof_StackedDrg64GiBV1_1,
+	} {
+		size, err := proof.SectorSize()
+		if err != nil {
+			return 0, err
+		}
+		if strings.EqualFold(size.ShortString(), s) {
+			return size, nil
+		}
+	}
+	return 0, xerrors.Errorf("not a sector size")
+}
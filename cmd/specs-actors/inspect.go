@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/dump"
+)
+
+func runInspect(e *env, fs *flag.FlagSet, args []string) error {
+	var sf stateFlags
+	sf.register(fs)
+	depth := fs.Int("depth", dump.DefaultOptions.MaxDepth, "number of levels of linked objects to load")
+	entries := fs.Int("entries", dump.DefaultOptions.MaxEntries, "maximum number of entries to list of each collection")
+	asJSON := fs.Bool("json", false, "print JSON rather than text")
+	summary := fs.Bool("summary", false, "print the summary computed while checking the state invariants of a miner, market or power actor, rather than the state")
+	epoch := epochFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	tree, err := sf.load(context.Background())
+	if err != nil {
+		return err
+	}
+	a, actor, err := resolveActor(tree, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	opts := dump.Options{MaxDepth: *depth, MaxEntries: *entries, MaxBytes: dump.DefaultOptions.MaxBytes}
+
+	var n *dump.Node
+	if *summary {
+		currEpoch, err := stateEpoch(tree, *epoch)
+		if err != nil {
+			return err
+		}
+		s, acc, err := summarize(tree, actor, currEpoch)
+		if err != nil {
+			return xerrors.Errorf("failed to summarize %v: %w", a, err)
+		}
+		for _, msg := range acc.Messages() {
+			fmt.Fprintf(e.stderr, "%v: %s\n", a, msg)
+		}
+		n = dump.Value(tree.Store, s, opts)
+	} else if n, err = dump.ActorState(tree.Store, actor, opts); err != nil {
+		return xerrors.Errorf("failed to load state of %v: %w", a, err)
+	}
+
+	if *asJSON {
+		return json.NewEncoder(e.stdout).Encode(n)
+	}
+	fmt.Fprintf(e.stdout, "%v %s balance %s\n", a, builtin.ActorNameByCode(actor.Code), formatFIL(actor.Balance))
+	return n.WriteText(e.stdout)
+}
+
+// Checks the state invariants of an actor, returning the summary of its state computed by the check.
+func summarize(tree *states.Tree, actor *states.Actor, currEpoch abi.ChainEpoch) (interface{}, *builtin.MessageAccumulator, error) {
+	ctx := tree.Store.Context()
+	switch actor.Code {
+	case builtin.StorageMinerActorCodeID:
+		var st miner.State
+		if err := tree.Store.Get(ctx, actor.Head, &st); err != nil {
+			return nil, nil, err
+		}
+		s, acc := miner.CheckStateInvariants(&st, tree.Store, actor.Balance)
+		return s, acc, nil
+	case builtin.StorageMarketActorCodeID:
+		var st market.State
+		if err := tree.Store.Get(ctx, actor.Head, &st); err != nil {
+			return nil, nil, err
+		}
+		s, acc := market.CheckStateInvariants(&st, tree.Store, actor.Balance, currEpoch)
+		return s, acc, nil
+	case builtin.StoragePowerActorCodeID:
+		var st power.State
+		if err := tree.Store.Get(ctx, actor.Head, &st); err != nil {
+			return nil, nil, err
+		}
+		s, acc := power.CheckStateInvariants(&st, tree.Store)
+		return s, acc, nil
+	}
+	return nil, nil, xerrors.Errorf("no summary of %s actors", builtin.ActorNameByCode(actor.Code))
+}
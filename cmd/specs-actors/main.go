@@ -0,0 +1,96 @@
+// Command specs-actors inspects state trees of the builtin actors, as exported to CAR files by node
+// implementations or by the test VM. It renders actor state, checks state invariants, estimates miner
+// termination fees and initial pledge, and diffs two state trees.
+//
+// Usage:
+//
+//	specs-actors <command> [flags] [arguments]
+//
+// Run "specs-actors help" for the list of commands, and "specs-actors <command> -h" for a command's flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	addr "github.com/filecoin-project/go-address"
+)
+
+// A subcommand, which parses its own flags.
+type command struct {
+	name    string
+	args    string // synopsis of the arguments following the flags
+	summary string
+	run     func(env *env, fs *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	{"inspect", "<actor>", "render the state of an actor, identified by address or singleton name", runInspect},
+	{"check", "", "check the state invariants of all or some actors", runCheck},
+	{"termination-fee", "<miner> <sectors>", "estimate the fee to terminate a miner's sectors, e.g. 1,5-9", runTerminationFee},
+	{"initial-pledge", "", "estimate the pre-commit deposit and initial pledge of a sector", runInitialPledge},
+	{"diff", "<from.car> <to.car>", "list the actors that differ between two state trees", runDiff},
+}
+
+// The process environment of a command.
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Returned by commands whose flags or arguments are invalid, after printing usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	addr.CurrentNetwork = addr.Mainnet
+	if err := run(os.Args[1:], &env{stdout: os.Stdout, stderr: os.Stderr}); err != nil {
+		// A bare usage error has been explained by the usage printed, while a wrapped one carries a message.
+		if err != errUsage {
+			fmt.Fprintf(os.Stderr, "specs-actors: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, e *env) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(e.stderr)
+		if len(args) == 0 {
+			return errUsage
+		}
+		return nil
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(e.stderr)
+		fs.Usage = func() {
+			fmt.Fprintf(e.stderr, "usage: specs-actors %s [flags] %s\n\n%s.\n\nflags:\n", cmd.name, cmd.args, cmd.summary)
+			fs.PrintDefaults()
+		}
+		err := cmd.run(e, fs, args[1:])
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+		}
+		return err
+	}
+	fmt.Fprintf(e.stderr, "specs-actors: unknown command %q\n\n", args[0])
+	usage(e.stderr)
+	return errUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: specs-actors <command> [flags] [arguments]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"specs-actors <command> -h\" for a command's flags.\n")
+}
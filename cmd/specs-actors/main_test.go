@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	vm "github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+func TestCommands(t *testing.T) {
+	ctx := context.Background()
+	v, actors := vm.NewVMWithGenesis(ctx, t, ipld.NewBlockStoreInMemory(), vm.Genesis{
+		Accounts: []vm.GenesisAccount{{Balance: big.Mul(big.NewInt(10_000), vm.FIL)}},
+		Seed:     93837778,
+		Miners: []vm.GenesisMiner{{
+			SealProof: abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			Balance:   big.Mul(big.NewInt(1_000), vm.FIL),
+			Sectors:   2,
+		}},
+	})
+	dir := t.TempDir()
+	genesisCAR := exportCAR(t, v, filepath.Join(dir, "genesis.car"))
+
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10), vm.FIL), 1234)
+	accountsCAR := exportCAR(t, v, filepath.Join(dir, "accounts.car"))
+	total, err := v.GetTotalActorBalance()
+	require.NoError(t, err)
+	minerAddr := actors.Miners[0].IDAddress
+
+	t.Run("inspect", func(t *testing.T) {
+		out, err := runCommand(t, "inspect", "-car", accountsCAR, "power")
+		require.NoError(t, err)
+		assert.Contains(t, out, builtin.StoragePowerActorAddr.String()+" fil/7/storagepower balance 0 FIL\n")
+		assert.Contains(t, out, "power.State @")
+		assert.Contains(t, out, "  MinerCount: 1\n")
+
+		out, err = runCommand(t, "inspect", "-car", accountsCAR, addrs[0].String())
+		require.NoError(t, err)
+		assert.Contains(t, out, " fil/7/account balance 10 FIL\n")
+
+		_, err = runCommand(t, "inspect", "-car", accountsCAR)
+		assert.Equal(t, errUsage, err)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		out, err := runCommand(t, "check", "-car", accountsCAR, "-balance", total.String(), "-workers", "2",
+			"-actor", minerAddr.String(), "-actor", "power", "-actor", addrs[0].String())
+		require.NoError(t, err, out)
+		assert.Equal(t, "ok: 0 warnings\n", out)
+
+		_, err = runCommand(t, "check", "-car", accountsCAR, "-balance", big.Add(total, big.NewInt(1)).String(), "-actor", "power")
+		assert.Error(t, err)
+	})
+
+	t.Run("termination-fee", func(t *testing.T) {
+		out, err := runCommand(t, "termination-fee", "-car", accountsCAR, "-epoch", "100", minerAddr.String(), "0-1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "sector 0: ")
+		assert.Contains(t, out, "sector 1: ")
+		assert.Contains(t, out, "total for 2 sectors at epoch 100: ")
+
+		_, err = runCommand(t, "termination-fee", "-car", accountsCAR, minerAddr.String(), "0-")
+		assert.True(t, errors.Is(err, errUsage))
+	})
+
+	t.Run("initial-pledge", func(t *testing.T) {
+		out, err := runCommand(t, "initial-pledge", "-car", accountsCAR, "-size", "32GiB")
+		require.NoError(t, err)
+		assert.Contains(t, out, "quality-adjusted power: 34359738368\n")
+		assert.Contains(t, out, "initial pledge: ")
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		out, err := runCommand(t, "diff", genesisCAR, accountsCAR)
+		require.NoError(t, err)
+		newAddr, found := v.NormalizeAddress(addrs[0])
+		require.True(t, found)
+		assert.Contains(t, out, "+ "+newAddr.String()+" fil/7/account\n")
+		assert.Contains(t, out, "~ "+builtin.InitActorAddr.String()+" fil/7/init\n")
+		assert.Contains(t, out, "    state.NextID: 102 -> 103\n")
+	})
+}
+
+func TestParseSectors(t *testing.T) {
+	bf, err := parseSectors("1,5-7")
+	require.NoError(t, err)
+	set, err := bf.All(10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 5, 6, 7}, set)
+
+	for _, s := range []string{"", "1-", "7-5", "a"} {
+		_, err := parseSectors(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseSectorSize(t *testing.T) {
+	size, err := parseSectorSize("32GiB")
+	require.NoError(t, err)
+	assert.Equal(t, abi.SectorSize(32<<30), size)
+	size, err = parseSectorSize("2048")
+	require.NoError(t, err)
+	assert.Equal(t, abi.SectorSize(2048), size)
+	_, err = parseSectorSize("3GiB")
+	assert.Error(t, err)
+}
+
+func TestFormatFIL(t *testing.T) {
+	assert.Equal(t, "0 FIL", formatFIL(big.Zero()))
+	assert.Equal(t, "1.5 FIL", formatFIL(big.Div(big.Mul(big.NewInt(3), builtin.TokenPrecision), big.NewInt(2))))
+	assert.Equal(t, "-0.000000000000000001 FIL", formatFIL(big.NewInt(-1)))
+}
+
+func exportCAR(t *testing.T, v *vm.VM, path string) string {
+	tree, err := v.GetStateTree()
+	require.NoError(t, err)
+	root, err := tree.Flush()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, states.ExportCAR(v.Store(), root, &buf))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return path
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(args, &env{stdout: &stdout, stderr: &stderr})
+	t.Log(stderr.String())
+	return stdout.String(), err
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+// A flag that may be repeated, collecting its values in order.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Flags locating a state tree in CAR files.
+type stateFlags struct {
+	cars stringsFlag
+	root string
+}
+
+func (f *stateFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.cars, "car", "CAR file of state blocks; may be repeated to load blocks from several files")
+	fs.StringVar(&f.root, "root", "", "CID of the state root (default the root of the first CAR file)")
+}
+
+// Loads the blocks of the CAR files into memory and returns the state tree.
+func (f *stateFlags) load(ctx context.Context) (*states.Tree, error) {
+	if len(f.cars) == 0 {
+		return nil, xerrors.Errorf("no CAR file given with -car: %w", errUsage)
+	}
+	// Synchronized so that invariants may be checked concurrently.
+	bs := ipld.NewSyncBlockStore(ipld.NewBlockStoreInMemory())
+	var root cid.Cid
+	for i, path := range f.cars {
+		carRoot, err := importCAR(bs, path)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			root = carRoot
+		}
+	}
+	if f.root != "" {
+		var err error
+		if root, err = cid.Decode(f.root); err != nil {
+			return nil, xerrors.Errorf("invalid state root %q: %w", f.root, err)
+		}
+	}
+	tree, err := states.LoadTree(adt.WrapBlockStore(ctx, bs), root)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load state tree %v: %w", root, err)
+	}
+	return tree, nil
+}
+
+// Loads the blocks of a single CAR file into memory and returns a store of them and the CAR file's root.
+func loadCAR(ctx context.Context, path string) (adt.Store, cid.Cid, error) {
+	bs := ipld.NewBlockStoreInMemory()
+	root, err := importCAR(bs, path)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return adt.WrapBlockStore(ctx, bs), root, nil
+}
+
+func importCAR(bs ipldcbor.IpldBlockstore, path string) (cid.Cid, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return cid.Undef, err
+	}
+	defer func() { _ = file.Close() }()
+	root, err := states.ImportCAR(bs, file)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to import %s: %w", path, err)
+	}
+	return root, nil
+}
+
+// Singleton actors, by the names accepted in place of their addresses.
+var singletonNames = map[string]addr.Address{
+	"system":   builtin.SystemActorAddr,
+	"init":     builtin.InitActorAddr,
+	"reward":   builtin.RewardActorAddr,
+	"cron":     builtin.CronActorAddr,
+	"power":    builtin.StoragePowerActorAddr,
+	"market":   builtin.StorageMarketActorAddr,
+	"verifreg": builtin.VerifiedRegistryActorAddr,
+	"burnt":    builtin.BurntFundsActorAddr,
+}
+
+// Parses an actor address or singleton name, resolving a non-ID address to the actor's ID address
+// through the init actor.
+func resolveActor(tree *states.Tree, s string) (addr.Address, *states.Actor, error) {
+	a, ok := singletonNames[s]
+	if !ok {
+		var err error
+		if a, err = addr.NewFromString(s); err != nil {
+			return addr.Undef, nil, xerrors.Errorf("invalid actor address %q: %w", s, err)
+		}
+	}
+	if a.Protocol() != addr.ID {
+		var initState init_.State
+		if _, err := loadState(tree, builtin.InitActorAddr, builtin.InitActorCodeID, &initState); err != nil {
+			return addr.Undef, nil, err
+		}
+		idAddr, found, err := initState.ResolveAddress(tree.Store, a)
+		if err != nil {
+			return addr.Undef, nil, xerrors.Errorf("failed to resolve %v: %w", a, err)
+		}
+		if !found {
+			return addr.Undef, nil, xerrors.Errorf("no actor with address %v", a)
+		}
+		a = idAddr
+	}
+	actor, found, err := tree.GetActor(a)
+	if err != nil {
+		return addr.Undef, nil, xerrors.Errorf("failed to load actor %v: %w", a, err)
+	}
+	if !found {
+		return addr.Undef, nil, xerrors.Errorf("no actor with address %v", a)
+	}
+	return a, actor, nil
+}
+
+// Loads the state of an actor, which must have the expected code, i.e. be of the current actors version.
+func loadState(tree *states.Tree, a addr.Address, code cid.Cid, out cbor.Unmarshaler) (*states.Actor, error) {
+	actor, found, err := tree.GetActor(a)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load actor %v: %w", a, err)
+	}
+	if !found {
+		return nil, xerrors.Errorf("no actor with address %v", a)
+	}
+	if !actor.Code.Equals(code) {
+		return nil, xerrors.Errorf("actor %v has code %v, expected %s", a, actor.Code, builtin.ActorNameByCode(code))
+	}
+	if err := tree.Store.Get(tree.Store.Context(), actor.Head, out); err != nil {
+		return nil, xerrors.Errorf("failed to load state of %v: %w", a, err)
+	}
+	return actor, nil
+}
+
+// Registers a flag for the epoch at which to evaluate the state.
+func epochFlag(fs *flag.FlagSet) *int64 {
+	return fs.Int64("epoch", -1, "epoch at which to evaluate the state (default the epoch of the last tipset applied to the state)")
+}
+
+// Returns the epoch given by an epoch flag or, if unset, the epoch of the last tipset applied to the state.
+// The reward actor records the epoch following it, for which it will next be awarded.
+func stateEpoch(tree *states.Tree, flagValue int64) (abi.ChainEpoch, error) {
+	if flagValue >= 0 {
+		return abi.ChainEpoch(flagValue), nil
+	}
+	var st reward.State
+	if _, err := loadState(tree, builtin.RewardActorAddr, builtin.RewardActorCodeID, &st); err != nil {
+		return 0, xerrors.Errorf("failed to determine the state's epoch, which may be given with -epoch: %w", err)
+	}
+	return st.Epoch - 1, nil
+}
+
+// Formats an amount of attoFIL as FIL, e.g. "1.5 FIL".
+func formatFIL(amount abi.TokenAmount) string {
+	if amount.Int == nil {
+		return "0 FIL"
+	}
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount = amount.Abs()
+	}
+	whole, frac := big.Div(amount, builtin.TokenPrecision), big.Mod(amount, builtin.TokenPrecision)
+	if frac.IsZero() {
+		return sign + whole.String() + " FIL"
+	}
+	fracDigits := strings.TrimRight(big.Add(frac, builtin.TokenPrecision).String()[1:], "0")
+	return sign + whole.String() + "." + fracDigits + " FIL"
+}
@@ -0,0 +1,438 @@
+// Generates the catalog of reasons for which builtin actor methods abort, from the actors' Abortf and Require*
+// call sites. Run from the repository root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const outputFile = "./actors/builtin/exported/abort_reasons_gen.go"
+
+// An actor package, with the identifiers of its code CID and method numbers in the builtin package.
+type actorPackage struct {
+	dir     string
+	name    string // package name, used to prefix reason identifiers
+	ref     string // identifier of the package in the generated file
+	code    string // identifier of the actor code CID in the builtin package
+	methods string // identifier of the actor's method numbers in the builtin package, empty if none
+}
+
+var actors = []actorPackage{
+	{"account", "account", "account", "AccountActorCodeID", "MethodsAccount"},
+	{"cron", "cron", "cron", "CronActorCodeID", "MethodsCron"},
+	{"init", "init", "init_", "InitActorCodeID", "MethodsInit"},
+	{"market", "market", "market", "StorageMarketActorCodeID", "MethodsMarket"},
+	{"miner", "miner", "miner", "StorageMinerActorCodeID", "MethodsMiner"},
+	{"multisig", "multisig", "multisig", "MultisigActorCodeID", "MethodsMultisig"},
+	{"paych", "paych", "paych", "PaymentChannelActorCodeID", "MethodsPaych"},
+	{"power", "power", "power", "StoragePowerActorCodeID", "MethodsPower"},
+	{"reward", "reward", "reward", "RewardActorCodeID", "MethodsReward"},
+	{"system", "system", "system", "SystemActorCodeID", ""},
+	{"verifreg", "verifreg", "verifreg", "VerifiedRegistryActorCodeID", "MethodsVerifiedRegistry"},
+}
+
+// Helpers of the builtin package that abort with a message template, and their exit codes.
+// An empty exit code is taken from the argument before the template.
+var requireHelpers = map[string]string{
+	"RequireState":     "exitcode.ErrIllegalState",
+	"RequireParam":     "exitcode.ErrIllegalArgument",
+	"RequirePredicate": "",
+	"RequireNoErr":     "",
+}
+
+// A call site that aborts with a constant exit code and message template.
+type abortSite struct {
+	code     string // exit code expression, qualified for the generated file
+	template string
+}
+
+// A function of a package, with the abort sites in its body and the functions it calls.
+type function struct {
+	sites   []abortSite
+	local   []string // names of functions and methods of the same package called
+	builtin []string // names of functions of the builtin package called
+}
+
+type pkg struct {
+	funcs map[string][]*function // by name, for all receivers
+}
+
+type reason struct {
+	id       string
+	actor    actorPackage
+	code     string
+	template string
+	summary  string
+	methods  []string
+}
+
+func main() {
+	builtinPkg, err := loadPackage("./actors/builtin", nil)
+	if err != nil {
+		fail(err)
+	}
+	methodNames, err := loadMethodNames("./actors/builtin/methods.go")
+	if err != nil {
+		fail(err)
+	}
+
+	var reasons []*reason
+	for _, actor := range actors {
+		p, err := loadPackage(filepath.Join("./actors/builtin", actor.dir), &actor)
+		if err != nil {
+			fail(err)
+		}
+		methods := methodNames[actor.methods]
+		if actor.methods == "" {
+			methods = []string{"Constructor"}
+		}
+		methods = append(methods, "GetActorInfo")
+		reasons = append(reasons, actorReasons(actor, p, builtinPkg, methods)...)
+	}
+
+	src, err := render(reasons)
+	if err != nil {
+		fail(err)
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// Collects the reasons for which each method of an actor may abort, in order of first occurrence.
+func actorReasons(actor actorPackage, p, builtinPkg *pkg, methods []string) []*reason {
+	var reasons []*reason
+	byKey := map[abortSite]*reason{}
+	ids := map[string]int{}
+	for _, method := range methods {
+		for _, site := range reachableSites(p, builtinPkg, method) {
+			r, ok := byKey[site]
+			if !ok {
+				summary := summarize(site.template)
+				id := actor.name + "." + slug(summary)
+				ids[id]++
+				if n := ids[id]; n > 1 {
+					id += "-" + strconv.Itoa(n)
+				}
+				r = &reason{id: id, actor: actor, code: site.code, template: site.template, summary: summary}
+				byKey[site] = r
+				reasons = append(reasons, r)
+			}
+			if len(r.methods) == 0 || r.methods[len(r.methods)-1] != method {
+				r.methods = append(r.methods, method)
+			}
+		}
+	}
+	return reasons
+}
+
+// Returns the abort sites reachable from an actor method through calls within its package and to the builtin package.
+func reachableSites(p, builtinPkg *pkg, method string) []abortSite {
+	type node struct {
+		builtin bool
+		name    string
+	}
+	var sites []abortSite
+	seenSites := map[abortSite]bool{}
+	visited := map[node]bool{}
+	var visit func(n node)
+	visit = func(n node) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		in := p
+		if n.builtin {
+			in = builtinPkg
+		}
+		for _, f := range in.funcs[n.name] {
+			for _, s := range f.sites {
+				if !seenSites[s] {
+					seenSites[s] = true
+					sites = append(sites, s)
+				}
+			}
+			for _, callee := range f.local {
+				visit(node{n.builtin, callee})
+			}
+			for _, callee := range f.builtin {
+				visit(node{true, callee})
+			}
+		}
+	}
+	visit(node{false, method})
+	return sites
+}
+
+// Parses the non-test sources of a package. Exit code constants of an actor package are qualified by its reference.
+func loadPackage(dir string, actor *actorPackage) (*pkg, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{funcs: map[string][]*function{}}
+	for _, astPkg := range pkgs {
+		files := make([]string, 0, len(astPkg.Files))
+		for name := range astPkg.Files {
+			files = append(files, name)
+		}
+		sort.Strings(files)
+		for _, name := range files {
+			file := astPkg.Files[name]
+			imports := importNames(file)
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil {
+					continue
+				}
+				p.funcs[fd.Name.Name] = append(p.funcs[fd.Name.Name], parseFunction(fd, imports, actor))
+			}
+		}
+	}
+	return p, nil
+}
+
+// Returns the names by which a file refers to its imports.
+func importNames(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, imp := range file.Imports {
+		if imp.Name != nil {
+			names[imp.Name.Name] = true
+			continue
+		}
+		path, _ := strconv.Unquote(imp.Path.Value)
+		names[path[strings.LastIndexByte(path, '/')+1:]] = true
+	}
+	return names
+}
+
+func parseFunction(fd *ast.FuncDecl, imports map[string]bool, actor *actorPackage) *function {
+	f := &function{}
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			if site, ok := requireSite(fun.Name, call.Args, actor); ok && actor == nil {
+				f.sites = append(f.sites, site)
+			}
+			f.local = append(f.local, fun.Name)
+		case *ast.SelectorExpr:
+			if x, ok := fun.X.(*ast.Ident); ok && x.Name == "builtin" {
+				if site, ok := requireSite(fun.Sel.Name, call.Args, actor); ok {
+					f.sites = append(f.sites, site)
+				}
+				f.builtin = append(f.builtin, fun.Sel.Name)
+			} else if fun.Sel.Name == "Abortf" && len(call.Args) >= 2 {
+				if site, ok := newSite(call.Args[0], call.Args[1], actor, ""); ok {
+					f.sites = append(f.sites, site)
+				}
+			} else if x, ok := fun.X.(*ast.Ident); !ok || !imports[x.Name] {
+				// a method of some type, assumed to be of this package
+				f.local = append(f.local, fun.Sel.Name)
+			}
+		}
+		return true
+	})
+	return f
+}
+
+// Returns the abort site of a call to one of the builtin Require* helpers.
+func requireSite(name string, args []ast.Expr, actor *actorPackage) (abortSite, bool) {
+	code, ok := requireHelpers[name]
+	if !ok {
+		return abortSite{}, false
+	}
+	switch name {
+	case "RequireState", "RequireParam":
+		if len(args) >= 3 {
+			return newSite(nil, args[2], actor, code)
+		}
+	case "RequirePredicate", "RequireNoErr":
+		if len(args) >= 4 {
+			site, ok := newSite(args[2], args[3], actor, "")
+			if name == "RequireNoErr" {
+				site.template += ": %s"
+			}
+			return site, ok
+		}
+	}
+	return abortSite{}, false
+}
+
+// Returns an abort site for a constant exit code and template, or false if either is computed at run time.
+func newSite(codeExpr, templateExpr ast.Expr, actor *actorPackage, code string) (abortSite, bool) {
+	if code == "" {
+		switch e := codeExpr.(type) {
+		case *ast.SelectorExpr:
+			x, ok := e.X.(*ast.Ident)
+			if !ok || x.Name != "exitcode" {
+				return abortSite{}, false
+			}
+			code = "exitcode." + e.Sel.Name
+		case *ast.Ident:
+			if actor == nil || !ast.IsExported(e.Name) || !strings.HasPrefix(e.Name, "Err") {
+				return abortSite{}, false
+			}
+			code = actor.ref + "." + e.Name
+		default:
+			return abortSite{}, false
+		}
+	}
+	template, ok := stringConstant(templateExpr)
+	if !ok {
+		return abortSite{}, false
+	}
+	return abortSite{code: code, template: template}, true
+}
+
+func stringConstant(e ast.Expr) (string, bool) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := stringConstant(e.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := stringConstant(e.Y)
+		return x + y, ok
+	case *ast.ParenExpr:
+		return stringConstant(e.X)
+	}
+	return "", false
+}
+
+// Returns the field names of each Methods* variable, in order of method number.
+func loadMethodNames(path string) (map[string][]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]string{}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Values) != 1 {
+				continue
+			}
+			lit, ok := vs.Values[0].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			st, ok := lit.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			var names []string
+			for _, field := range st.Fields.List {
+				for _, n := range field.Names {
+					names = append(names, n.Name)
+				}
+			}
+			out[vs.Names[0].Name] = names
+		}
+	}
+	return out, nil
+}
+
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Returns a template without its format verbs, e.g. "deal start epoch has already elapsed".
+func summarize(template string) string {
+	s := formatVerb.ReplaceAllString(template, " ")
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.TrimRight(s, " :,.")
+	return s
+}
+
+// Returns an identifier of at most eight words from a summary, e.g. "deal-start-epoch-has-already-elapsed".
+func slug(summary string) string {
+	words := strings.Fields(nonWord.ReplaceAllString(strings.ToLower(summary), " "))
+	if len(words) > 8 {
+		words = words[:8]
+	}
+	if len(words) == 0 {
+		return "abort"
+	}
+	return strings.Join(words, "-")
+}
+
+func render(reasons []*reason) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by github.com/filecoin-project/specs-actors/v7/gen/aborts. DO NOT EDIT.\n\n")
+	buf.WriteString("package exported\n\n")
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"github.com/filecoin-project/go-state-types/abi\"\n")
+	buf.WriteString("\t\"github.com/filecoin-project/go-state-types/exitcode\"\n\n")
+	buf.WriteString("\t\"github.com/filecoin-project/specs-actors/v7/actors/builtin\"\n")
+	imported := map[string]bool{}
+	for _, r := range reasons {
+		if !strings.HasPrefix(r.code, "exitcode.") && !imported[r.actor.ref] {
+			imported[r.actor.ref] = true
+			alias := ""
+			if r.actor.ref != r.actor.dir {
+				alias = r.actor.ref + " "
+			}
+			fmt.Fprintf(&buf, "\t%s\"github.com/filecoin-project/specs-actors/v7/actors/builtin/%s\"\n", alias, r.actor.dir)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("var abortReasons = []*AbortReason{\n")
+	for _, r := range reasons {
+		methods := make([]string, len(r.methods))
+		for i, m := range r.methods {
+			methods[i] = methodRef(r.actor, m)
+		}
+		fmt.Fprintf(&buf, "\t{\n\t\tID: %q,\n\t\tActor: builtin.%s,\n\t\tMethods: []abi.MethodNum{%s},\n\t\tCode: %s,\n\t\tTemplate: %q,\n\t\tSummary: %q,\n\t},\n",
+			r.id, r.actor.code, strings.Join(methods, ", "), r.code, r.template, r.summary)
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func methodRef(actor actorPackage, method string) string {
+	switch {
+	case method == "GetActorInfo":
+		return "builtin.MethodGetActorInfo"
+	case actor.methods == "":
+		return "builtin.Method" + method
+	}
+	return "builtin." + actor.methods + "." + method
+}
@@ -0,0 +1,479 @@
+// Generates the catalog of callers permitted by each builtin actor method, from the actors'
+// ValidateImmediateCaller* calls. Run from the repository root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const outputFile = "./actors/builtin/exported/callers_gen.go"
+
+// An actor package, with the identifiers of its code CID and method numbers in the builtin package.
+type actorPackage struct {
+	dir     string
+	ref     string // identifier of the package in the generated file
+	code    string // identifier of the actor code CID in the builtin package
+	methods string // identifier of the actor's method numbers in the builtin package, empty if none
+}
+
+var actors = []actorPackage{
+	{"account", "account", "AccountActorCodeID", "MethodsAccount"},
+	{"cron", "cron", "CronActorCodeID", "MethodsCron"},
+	{"init", "init_", "InitActorCodeID", "MethodsInit"},
+	{"market", "market", "StorageMarketActorCodeID", "MethodsMarket"},
+	{"miner", "miner", "StorageMinerActorCodeID", "MethodsMiner"},
+	{"multisig", "multisig", "MultisigActorCodeID", "MethodsMultisig"},
+	{"paych", "paych", "PaymentChannelActorCodeID", "MethodsPaych"},
+	{"power", "power", "StoragePowerActorCodeID", "MethodsPower"},
+	{"reward", "reward", "RewardActorCodeID", "MethodsReward"},
+	{"system", "system", "SystemActorCodeID", ""},
+	{"verifreg", "verifreg", "VerifiedRegistryActorCodeID", "MethodsVerifiedRegistry"},
+}
+
+// A ValidateImmediateCaller* call site.
+type validation struct {
+	kind   string // "AcceptAny", "Is" or "Type"
+	args   []ast.Expr
+	spread bool   // whether the last argument is spread
+	source string // the call as written
+}
+
+// A function of a package, with the validations in its body and the functions it calls.
+type function struct {
+	actorMethod bool // whether the function is an exported method of the actor, i.e. an entry point
+	validations []*validation
+	local       []string // names of functions and methods of the same package called
+	self        []string // names of methods of the actor called on the method's receiver
+	builtin     []string // names of functions of the builtin package called
+}
+
+type pkg struct {
+	fset  *token.FileSet
+	funcs map[string][]*function // by name, for all receivers
+}
+
+// The callers permitted by a method, as expressions in the generated file.
+type policy struct {
+	actor       actorPackage
+	method      string
+	any         bool
+	addresses   []string
+	codes       []string
+	codesSpread string // a slice of code CIDs permitted, if any
+	receiver    bool
+	dynamic     bool
+	validations []string
+}
+
+func main() {
+	builtinPkg, err := loadPackage("./actors/builtin")
+	if err != nil {
+		fail(err)
+	}
+	methodNames, err := loadMethodNames("./actors/builtin/methods.go")
+	if err != nil {
+		fail(err)
+	}
+
+	var policies []*policy
+	for _, actor := range actors {
+		p, err := loadPackage(filepath.Join("./actors/builtin", actor.dir))
+		if err != nil {
+			fail(err)
+		}
+		methods := methodNames[actor.methods]
+		if actor.methods == "" {
+			methods = []string{"Constructor"}
+		}
+		methods = append(methods, "GetActorInfo")
+		for _, method := range methods {
+			if !p.hasActorMethod(method) {
+				continue // a method number that is no longer exported
+			}
+			pol, err := methodPolicy(actor, method, reachableValidations(p, builtinPkg, method))
+			if err != nil {
+				fail(err)
+			}
+			policies = append(policies, pol)
+		}
+	}
+
+	src, err := render(policies)
+	if err != nil {
+		fail(err)
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// Returns the validations reachable from an actor method through calls within its package and to the builtin
+// package, in order of first occurrence.
+func reachableValidations(p, builtinPkg *pkg, method string) []*validation {
+	type node struct {
+		builtin bool
+		name    string
+	}
+	var out []*validation
+	seen := map[string]bool{}
+	visited := map[node]bool{}
+	var visit func(n node, actorMethod bool)
+	visit = func(n node, actorMethod bool) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		in := p
+		if n.builtin {
+			in = builtinPkg
+		}
+		for _, f := range in.funcs[n.name] {
+			if f.actorMethod != actorMethod {
+				// Calls are matched by name, so distinguish the methods of the actor, which validate their callers,
+				// from functions and methods of other types of the same name.
+				continue
+			}
+			for _, v := range f.validations {
+				if !seen[v.source] {
+					seen[v.source] = true
+					out = append(out, v)
+				}
+			}
+			for _, callee := range f.local {
+				visit(node{n.builtin, callee}, false)
+			}
+			for _, callee := range f.self {
+				// a direct call to another method, as when a method delegates to its batch form
+				visit(node{n.builtin, callee}, true)
+			}
+			for _, callee := range f.builtin {
+				visit(node{true, callee}, false)
+			}
+		}
+	}
+	visit(node{false, method}, true)
+	return out
+}
+
+// Combines the validations of a method into the policy they enforce.
+func methodPolicy(actor actorPackage, method string, validations []*validation) (*policy, error) {
+	pol := &policy{actor: actor, method: method}
+	if len(validations) == 0 {
+		// The method checks the caller itself, if at all.
+		fmt.Fprintf(os.Stderr, "warning: %s.%s does not validate its caller\n", actor.dir, method)
+		pol.any = true
+	}
+	for _, v := range validations {
+		pol.validations = append(pol.validations, v.source)
+		switch v.kind {
+		case "AcceptAny":
+			pol.any = true
+		case "Is":
+			for i, arg := range v.args {
+				if v.spread && i == len(v.args)-1 {
+					pol.dynamic = true // a slice of addresses computed at call time
+					continue
+				}
+				if ref, ok := fixedAddress(actor, arg); ok {
+					pol.addresses = appendUnique(pol.addresses, ref)
+				} else if isReceiver(arg) {
+					pol.receiver = true
+				} else {
+					pol.dynamic = true
+				}
+			}
+		case "Type":
+			for i, arg := range v.args {
+				ref, ok := builtinRef(arg)
+				if !ok {
+					return nil, fmt.Errorf("%s.%s validates caller types computed at call time: %s", actor.dir, method, v.source)
+				}
+				if v.spread && i == len(v.args)-1 {
+					if pol.codesSpread != "" && pol.codesSpread != ref {
+						return nil, fmt.Errorf("%s.%s validates several slices of caller types", actor.dir, method)
+					}
+					pol.codesSpread = ref
+					continue
+				}
+				pol.codes = appendUnique(pol.codes, ref)
+			}
+		}
+	}
+	if pol.codesSpread != "" && len(pol.codes) > 0 {
+		return nil, fmt.Errorf("%s.%s validates both a slice of caller types and individual types", actor.dir, method)
+	}
+	return pol, nil
+}
+
+// Returns the reference in the generated file to an address that is fixed for all calls: a variable of the
+// builtin package or an exported variable of the actor's package, such as a governance address.
+func fixedAddress(actor actorPackage, e ast.Expr) (string, bool) {
+	if ref, ok := builtinRef(e); ok {
+		return ref, true
+	}
+	if id, ok := e.(*ast.Ident); ok && ast.IsExported(id.Name) {
+		return actor.ref + "." + id.Name, true
+	}
+	return "", false
+}
+
+func builtinRef(e ast.Expr) (string, bool) {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	if x, ok := sel.X.(*ast.Ident); ok && x.Name == "builtin" {
+		return "builtin." + sel.Sel.Name, true
+	}
+	return "", false
+}
+
+// Checks whether an expression is rt.Receiver().
+func isReceiver(e ast.Expr) bool {
+	call, ok := e.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Receiver"
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, x := range list {
+		if x == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// Parses the non-test sources of a package.
+func loadPackage(dir string) (*pkg, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{fset: fset, funcs: map[string][]*function{}}
+	for _, astPkg := range pkgs {
+		files := make([]string, 0, len(astPkg.Files))
+		for name := range astPkg.Files {
+			files = append(files, name)
+		}
+		sort.Strings(files)
+		for _, name := range files {
+			file := astPkg.Files[name]
+			imports := importNames(file)
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil {
+					continue
+				}
+				p.funcs[fd.Name.Name] = append(p.funcs[fd.Name.Name], p.parseFunction(fd, imports))
+			}
+		}
+	}
+	return p, nil
+}
+
+// Returns the names by which a file refers to its imports.
+func importNames(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, imp := range file.Imports {
+		if imp.Name != nil {
+			names[imp.Name.Name] = true
+			continue
+		}
+		path, _ := strconv.Unquote(imp.Path.Value)
+		names[path[strings.LastIndexByte(path, '/')+1:]] = true
+	}
+	return names
+}
+
+func (p *pkg) hasActorMethod(name string) bool {
+	for _, f := range p.funcs[name] {
+		if f.actorMethod {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pkg) parseFunction(fd *ast.FuncDecl, imports map[string]bool) *function {
+	f := &function{actorMethod: isActorMethod(fd)}
+	recv := ""
+	if f.actorMethod && len(fd.Recv.List[0].Names) == 1 {
+		recv = fd.Recv.List[0].Names[0].Name
+	}
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			f.local = append(f.local, fun.Name)
+		case *ast.SelectorExpr:
+			if kind := strings.TrimPrefix(fun.Sel.Name, "ValidateImmediateCaller"); kind != fun.Sel.Name {
+				f.validations = append(f.validations, &validation{
+					kind:   kind,
+					args:   call.Args,
+					spread: call.Ellipsis.IsValid(),
+					source: p.source(call),
+				})
+			} else if x, ok := fun.X.(*ast.Ident); ok && x.Name == "builtin" {
+				f.builtin = append(f.builtin, fun.Sel.Name)
+			} else if ok && recv != "" && x.Name == recv {
+				f.self = append(f.self, fun.Sel.Name)
+			} else if !ok || !imports[x.Name] {
+				// a method of some type, assumed to be of this package
+				f.local = append(f.local, fun.Sel.Name)
+			}
+		}
+		return true
+	})
+	return f
+}
+
+func isActorMethod(fd *ast.FuncDecl) bool {
+	if fd.Recv == nil || len(fd.Recv.List) != 1 || !fd.Name.IsExported() {
+		return false
+	}
+	recv := fd.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	id, ok := recv.(*ast.Ident)
+	return ok && id.Name == "Actor"
+}
+
+func (p *pkg) source(n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, p.fset, n); err != nil {
+		fail(err)
+	}
+	return buf.String()
+}
+
+// Returns the field names of each Methods* variable, in order of method number.
+func loadMethodNames(path string) (map[string][]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]string{}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Values) != 1 {
+				continue
+			}
+			lit, ok := vs.Values[0].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			st, ok := lit.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			var names []string
+			for _, field := range st.Fields.List {
+				for _, n := range field.Names {
+					names = append(names, n.Name)
+				}
+			}
+			out[vs.Names[0].Name] = names
+		}
+	}
+	return out, nil
+}
+
+func render(policies []*policy) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by github.com/filecoin-project/specs-actors/v7/gen/callers. DO NOT EDIT.\n\n")
+	buf.WriteString("package exported\n\n")
+	buf.WriteString("import (\n")
+	buf.WriteString("\taddr \"github.com/filecoin-project/go-address\"\n")
+	buf.WriteString("\t\"github.com/ipfs/go-cid\"\n\n")
+	buf.WriteString("\t\"github.com/filecoin-project/specs-actors/v7/actors/builtin\"\n")
+	imported := map[string]bool{}
+	for _, pol := range policies {
+		for _, a := range pol.addresses {
+			if strings.HasPrefix(a, pol.actor.ref+".") && !imported[pol.actor.ref] {
+				imported[pol.actor.ref] = true
+				alias := ""
+				if pol.actor.ref != pol.actor.dir {
+					alias = pol.actor.ref + " "
+				}
+				fmt.Fprintf(&buf, "\t%s\"github.com/filecoin-project/specs-actors/v7/actors/builtin/%s\"\n", alias, pol.actor.dir)
+			}
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("var methodCallers = map[methodKey]*CallerPolicy{\n")
+	for _, pol := range policies {
+		fmt.Fprintf(&buf, "\t{builtin.%s, %s}: {\n", pol.actor.code, methodRef(pol.actor, pol.method))
+		if pol.any {
+			buf.WriteString("\t\tAny: true,\n")
+		}
+		if len(pol.addresses) > 0 {
+			fmt.Fprintf(&buf, "\t\tAddresses: []addr.Address{%s},\n", strings.Join(pol.addresses, ", "))
+		}
+		if pol.codesSpread != "" {
+			fmt.Fprintf(&buf, "\t\tCodes: %s,\n", pol.codesSpread)
+		} else if len(pol.codes) > 0 {
+			fmt.Fprintf(&buf, "\t\tCodes: []cid.Cid{%s},\n", strings.Join(pol.codes, ", "))
+		}
+		if pol.receiver {
+			buf.WriteString("\t\tReceiver: true,\n")
+		}
+		if pol.dynamic {
+			buf.WriteString("\t\tDynamic: true,\n")
+		}
+		if len(pol.validations) > 0 {
+			validations := make([]string, len(pol.validations))
+			for i, v := range pol.validations {
+				validations[i] = strconv.Quote(v)
+			}
+			fmt.Fprintf(&buf, "\t\tValidations: []string{%s},\n", strings.Join(validations, ", "))
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func methodRef(actor actorPackage, method string) string {
+	switch {
+	case method == "GetActorInfo":
+		return "builtin.MethodGetActorInfo"
+	case actor.methods == "":
+		return "builtin.Method" + method
+	}
+	return "builtin." + actor.methods + "." + method
+}
@@ -0,0 +1,306 @@
+// Generates a client package for each builtin actor, with a function per exported method that sends a message
+// with the method's number, parameter type and return type. Run from the repository root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath = "github.com/filecoin-project/specs-actors/v7"
+	outputDir  = "./actors/builtin/clients"
+)
+
+// An actor package, with the identifiers of its method numbers in the builtin package.
+type actorPackage struct {
+	dir         string
+	ref         string // identifier of the actor package in generated files
+	description string // description of the actor in doc comments
+	methods     string // identifier of the actor's method numbers in the builtin package, empty if none
+}
+
+var actors = []actorPackage{
+	{"account", "account", "an account actor", "MethodsAccount"},
+	{"cron", "cron", "the cron actor", "MethodsCron"},
+	{"init", "init_", "the init actor", "MethodsInit"},
+	{"market", "market", "the storage market actor", "MethodsMarket"},
+	{"miner", "miner", "a storage miner actor", "MethodsMiner"},
+	{"multisig", "multisig", "a multisig actor", "MethodsMultisig"},
+	{"paych", "paych", "a payment channel actor", "MethodsPaych"},
+	{"power", "power", "the storage power actor", "MethodsPower"},
+	{"reward", "reward", "the reward actor", "MethodsReward"},
+	{"system", "system", "the system actor", ""},
+	{"verifreg", "verifreg", "the verified registry actor", "MethodsVerifiedRegistry"},
+}
+
+// A method exported by an actor, with its parameter and return types qualified for the generated file.
+type method struct {
+	name    string
+	params  string // empty if the method takes no parameters
+	returns string // empty if the method returns no value
+}
+
+func main() {
+	for _, actor := range actors {
+		if err := generate(actor); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", actor.dir, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func generate(actor actorPackage) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, filepath.Join("./actors/builtin", actor.dir), func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	// Index the Actor's methods and the package's type declarations.
+	methodDecls := map[string]*ast.FuncDecl{}
+	methodFiles := map[string]*ast.File{}
+	localTypes := map[string]bool{}
+	var exports []string
+	for _, astPkg := range pkgs {
+		for _, file := range astPkg.Files {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv == nil || len(d.Recv.List) != 1 || exprString(fset, d.Recv.List[0].Type) != "Actor" {
+						continue
+					}
+					methodDecls[d.Name.Name] = d
+					methodFiles[d.Name.Name] = file
+					if d.Name.Name == "Exports" {
+						exports = exportedNames(d)
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							localTypes[ts.Name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	if exports == nil {
+		return fmt.Errorf("no Exports method")
+	}
+
+	imports := map[string]string{ // path by name
+		"addr":     "github.com/filecoin-project/go-address",
+		"abi":      "github.com/filecoin-project/go-state-types/abi",
+		"exitcode": "github.com/filecoin-project/go-state-types/exitcode",
+		"builtin":  modulePath + "/actors/builtin",
+		"runtime":  modulePath + "/actors/runtime",
+	}
+	var methods []method
+	for _, name := range exports {
+		if name == "Constructor" {
+			continue // constructors are invoked only by the init actor, through Exec
+		}
+		decl, ok := methodDecls[name]
+		if !ok {
+			return fmt.Errorf("no declaration of exported method %s", name)
+		}
+		q := &qualifier{fset: fset, actor: actor, localTypes: localTypes, fileImports: fileImports(methodFiles[name]), imports: imports}
+		m := method{name: name}
+		params := fieldTypes(decl.Type.Params)
+		if len(params) > 1 && exprString(fset, params[1]) != "*abi.EmptyValue" {
+			m.params = q.qualify(params[1])
+		}
+		results := fieldTypes(decl.Type.Results)
+		if len(results) > 0 && exprString(fset, results[0]) != "*abi.EmptyValue" {
+			m.returns = q.qualify(results[0])
+		}
+		if q.err != nil {
+			return fmt.Errorf("method %s: %w", name, q.err)
+		}
+		methods = append(methods, m)
+	}
+
+	src, err := render(actor, methods, imports)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(outputDir, actor.dir+"client")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "client_gen.go"), src, 0644)
+}
+
+// Returns the names of the methods in the slice returned by an Exports method, in order of method number.
+func exportedNames(exports *ast.FuncDecl) []string {
+	var names []string
+	ast.Inspect(exports.Body, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if sel, ok := elt.(*ast.SelectorExpr); ok {
+				names = append(names, sel.Sel.Name)
+			}
+		}
+		return false
+	})
+	return names
+}
+
+func fieldTypes(fields *ast.FieldList) []ast.Expr {
+	var out []ast.Expr
+	if fields == nil {
+		return out
+	}
+	for _, f := range fields.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			out = append(out, f.Type)
+		}
+	}
+	return out
+}
+
+// Returns the paths of a file's imports by the name with which the file refers to them.
+func fileImports(file *ast.File) map[string]string {
+	out := map[string]string{}
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := strings.TrimPrefix(path[strings.LastIndexByte(path, '/')+1:], "go-")
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		out[name] = path
+	}
+	return out
+}
+
+// Rewrites type expressions of an actor package to refer to the same types from a client package.
+type qualifier struct {
+	fset        *token.FileSet
+	actor       actorPackage
+	localTypes  map[string]bool
+	fileImports map[string]string
+	imports     map[string]string
+	err         error
+}
+
+func (q *qualifier) qualify(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.StarExpr:
+		return "*" + q.qualify(e.X)
+	case *ast.ArrayType:
+		return "[" + exprString(q.fset, e.Len) + "]" + q.qualify(e.Elt)
+	case *ast.Ident:
+		if q.localTypes[e.Name] {
+			q.addImport(q.actor.ref, modulePath+"/actors/builtin/"+q.actor.dir)
+			return q.actor.ref + "." + e.Name
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		x := e.X.(*ast.Ident).Name
+		path, ok := q.fileImports[x]
+		if !ok {
+			q.err = fmt.Errorf("unknown package %s", x)
+			return ""
+		}
+		q.addImport(x, path)
+		return x + "." + e.Sel.Name
+	}
+	q.err = fmt.Errorf("unsupported type %s", exprString(q.fset, e))
+	return ""
+}
+
+func (q *qualifier) addImport(name, path string) {
+	if existing, ok := q.imports[name]; ok && existing != path {
+		q.err = fmt.Errorf("import name %s refers to both %s and %s", name, existing, path)
+		return
+	}
+	q.imports[name] = path
+}
+
+func exprString(fset *token.FileSet, e ast.Expr) string {
+	if e == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, e)
+	return buf.String()
+}
+
+func render(actor actorPackage, methods []method, imports map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	pkgName := actor.dir + "client"
+	buf.WriteString("// Code generated by github.com/filecoin-project/specs-actors/v7/gen/clients. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "// Package %s sends messages to %s, pairing each method's number with its parameter and return types.\n", pkgName, actor.description)
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return imports[names[i]] < imports[names[j]] })
+	buf.WriteString("import (\n")
+	// third party imports, then those of this module
+	for _, local := range []bool{false, true} {
+		if local {
+			buf.WriteString("\n")
+		}
+		for _, name := range names {
+			path := imports[name]
+			if strings.HasPrefix(path, modulePath+"/") != local {
+				continue
+			}
+			if strings.TrimPrefix(path[strings.LastIndexByte(path, '/')+1:], "go-") == name {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+			}
+		}
+	}
+	buf.WriteString(")\n")
+
+	for _, m := range methods {
+		num := "builtin." + actor.methods + "." + m.name
+		if m.name == "GetActorInfo" {
+			num = "builtin.MethodGetActorInfo"
+		}
+		paramsArg, paramsValue := "", "nil"
+		if m.params != "" {
+			paramsArg, paramsValue = ", params "+m.params, "params"
+		}
+		fmt.Fprintf(&buf, "\n// Sends %s to %s.\n", m.name, actor.description)
+		if m.returns == "" {
+			fmt.Fprintf(&buf, "func %s(rt runtime.Runtime, to addr.Address%s, value abi.TokenAmount) exitcode.ExitCode {\n", m.name, paramsArg)
+			fmt.Fprintf(&buf, "\treturn rt.Send(to, %s, %s, value, &builtin.Discard{})\n}\n", num, paramsValue)
+			continue
+		}
+		ret := strings.TrimPrefix(m.returns, "*")
+		fmt.Fprintf(&buf, "// If the send fails, returns the zero value along with the exit code.\n")
+		fmt.Fprintf(&buf, "func %s(rt runtime.Runtime, to addr.Address%s, value abi.TokenAmount) (%s, exitcode.ExitCode) {\n", m.name, paramsArg, ret)
+		fmt.Fprintf(&buf, "\treturn builtin.SendAndDecode[%s](rt, to, %s, %s, value)\n}\n", ret, num, paramsValue)
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"reflect"
+
+	"golang.org/x/xerrors"
+)
+
+const modulePath = "github.com/filecoin-project/specs-actors/v7"
+
+// Writes a fuzz target for the CBOR decoding of each type to cbor_gen_test.go in a package directory,
+// checking that what each decodes is re-encoded stably.
+func writeFuzzTargetsToFile(dir, pkg string, types ...interface{}) error {
+	importPath := path.Join(modulePath, filepath.ToSlash(filepath.Clean(dir)))
+	// The init package must be imported under another name, as init is reserved for init functions.
+	qualifier := pkg
+	if qualifier == "init" {
+		qualifier = "init_"
+	}
+	importName := ""
+	if path.Base(importPath) != qualifier {
+		importName = qualifier + " "
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s_test\n\n", path.Base(importPath))
+	fmt.Fprintf(&buf, "import (\n\t\"testing\"\n\n\t\"github.com/filecoin-project/go-state-types/cbor\"\n\n")
+	fmt.Fprintf(&buf, "\t%s%q\n", importName, importPath)
+	fmt.Fprintf(&buf, "\ttutil \"github.com/filecoin-project/specs-actors/v7/support/testing\"\n)\n")
+	for _, t := range types {
+		name := reflect.TypeOf(t).Name()
+		fmt.Fprintf(&buf, "\nfunc FuzzCBOR%s(f *testing.F) {\n", name)
+		fmt.Fprintf(&buf, "\ttutil.FuzzCBORRoundTrip(f, func() cbor.Er { return new(%s.%s) })\n}\n", qualifier, name)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return xerrors.Errorf("failed to format fuzz targets for %s: %w", dir, err)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "cbor_gen_test.go"), src, 0644)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	gen "github.com/whyrusleeping/cbor-gen"
 
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
 	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
@@ -14,13 +15,21 @@ import (
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/system"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
 	"github.com/filecoin-project/specs-actors/v7/support/vm"
 )
 
 func main() {
 	// Common types
-	if err := gen.WriteTupleEncodersToFile("./actors/runtime/proof/cbor_gen.go", "proof",
+	if err := writeEncoders("./actors/runtime", "runtime",
+		runtime.ActorEvent{},
+		runtime.EventEntry{},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := writeEncoders("./actors/runtime/proof", "proof",
 		//proof.SectorInfo{}, // Aliased from v0
 		proof.ExtendedSectorInfo{}, // New in v7
 		//proof.SealVerifyInfo{}, // Aliased from v0
@@ -31,14 +40,15 @@ func main() {
 		panic(err)
 	}
 
-	//if err := gen.WriteTupleEncodersToFile("./actors/builtin/cbor_gen.go", "builtin",
-	//	//builtin.MinerAddrs{}, // Aliased from v0
-	//	//builtin.ConfirmSectorProofsParams{}, // Aliased from v6
-	//	//builtin.DeferredCronEventParams{}, // Aliased from v6
-	//	//builtin.ApplyRewardParams{}, // Aliased from v2
-	//); err != nil {
-	//	panic(err)
-	//}
+	if err := writeEncoders("./actors/builtin", "builtin",
+		//builtin.MinerAddrs{}, // Aliased from v0
+		//builtin.ConfirmSectorProofsParams{}, // Aliased from v6
+		//builtin.DeferredCronEventParams{}, // Aliased from v6
+		//builtin.ApplyRewardParams{}, // Aliased from v2
+		builtin.GetActorInfoReturn{},
+	); err != nil {
+		panic(err)
+	}
 
 	// if err := gen.WriteTupleEncodersToFile("./actors/states/cbor_gen.go", "states",
 	// 	states.Actor{}, // Aliased from v0
@@ -47,42 +57,49 @@ func main() {
 	// }
 
 	// Actors
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/system/cbor_gen.go", "system",
+	if err := writeEncoders("./actors/builtin/system", "system",
 		// actor state
 		system.State{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/account/cbor_gen.go", "account",
+	if err := writeEncoders("./actors/builtin/account", "account",
 		// actor state
 		account.State{},
+		account.AuthenticateMessageParams{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/init/cbor_gen.go", "init",
+	if err := writeEncoders("./actors/builtin/init", "init",
 		// actor state
 		init_.State{},
+		init_.ActorAddresses{},
 		// method params and returns
 		//init_.ConstructorParams{}, // Aliased from v0
 		//init_.ExecParams{}, // Aliased from v0
 		//init_.ExecReturn{}, // Aliased from v0
+		init_.ExecWithSaltParams{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/cron/cbor_gen.go", "cron",
+	if err := writeEncoders("./actors/builtin/cron", "cron",
 		// actor state
 		cron.State{},
 		cron.Entry{},
+		cron.TickReport{},
+		cron.TickResult{},
 		// method params and returns
 		//cron.ConstructorParams{}, // Aliased from v0
+		cron.AddEntryParams{},
+		cron.RemoveEntryParams{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/reward/cbor_gen.go", "reward",
+	if err := writeEncoders("./actors/builtin/reward", "reward",
 		// actor state
 		reward.State{},
 		// method params and returns
@@ -92,9 +109,11 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/multisig/cbor_gen.go", "multisig",
+	if err := writeEncoders("./actors/builtin/multisig", "multisig",
 		// actor state
 		multisig.State{},
+		multisig.SpendingLimit{},
+		multisig.TxnMetadata{},
 		//multisig.Transaction{}, // Aliased from v0
 		//multisig.ProposalHashData{}, // Aliased from v0
 		// method params and returns
@@ -108,26 +127,38 @@ func main() {
 		//multisig.ChangeNumApprovalsThresholdParams{}, // Aliased from v0
 		//multisig.SwapSignerParams{}, // Aliased from v0
 		//multisig.LockBalanceParams{}, // Aliased from v0
+		multisig.ProposeManyParams{},
+		multisig.ProposeManyReturn{},
+		multisig.ApproveManyParams{},
+		multisig.ApproveManyReturn{},
+		multisig.SetSpendingLimitParams{},
+		multisig.SwapSignerAndReapproveParams{},
+		multisig.ProposeWithMetadataParams{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/paych/cbor_gen.go", "paych",
+	if err := writeEncoders("./actors/builtin/paych", "paych",
 		// actor state
 		paych.State{},
 		paych.LaneState{},
+		paych.PayeeState{},
 		// method params and returns
-		//paych.ConstructorParams{}, // Aliased from v0
+		//paych.ConstructorParams{}, // Changed in v7, encoded by hand to accept the v6 form
 		paych.UpdateChannelStateParams{}, // Changed in v7
-		paych.SignedVoucher{},            // Changed in v7
+		//paych.SignedVoucher{}, // Changed in v7, encoded by hand to keep the encoding of vouchers without a payee
 		//paych.ModVerifyParams{}, // Aliased from v0
+		paych.CompactLanesParams{},
+		paych.UpdateChannelStateManyParams{},
+		paych.UpdateChannelStateManyReturn{},
+		paych.UpdateChannelStateResult{},
 		// other types
 		//paych.Merge{}, // Aliased from v0
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/power/cbor_gen.go", "power",
+	if err := writeEncoders("./actors/builtin/power", "power",
 		// actors state
 		power.State{},
 		power.Claim{},
@@ -138,16 +169,19 @@ func main() {
 		//power.EnrollCronEventParams{}, // Aliased from v0
 		//power.UpdateClaimedPowerParams{}, // Aliased from v0
 		//power.CurrentTotalPowerReturn{}, // Aliased from v6
+		power.OnNetworkVersionChangeParams{},
 		// other types
 		//power.MinerConstructorParams{}, // Aliased from v3
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/market/cbor_gen.go", "market",
+	if err := writeEncoders("./actors/builtin/market", "market",
 		// actor state
 		market.State{},
 		market.DealState{},
+		market.DealPriceBucket{},
+		market.DealPriceHistogram{},
 		// method params and returns
 		//market.WithdrawBalanceParams{}, // Aliased from v0
 		// market.PublishStorageDealsParams{}, // Aliased from v0
@@ -158,22 +192,28 @@ func main() {
 		//market.ComputeDataCommitmentParams{}, // Aliased from v5
 		//market.ComputeDataCommitmentReturn{}, // Aliased from v5
 		//market.OnMinerSectorsTerminateParams{}, // Aliased from v0
+		market.GetDealPriceStatsReturn{},
+		market.GetDealsParams{},
+		market.DealProposalAndState{},
+		market.GetDealsReturn{},
 		// other types
 		//market.DealProposal{}, // Aliased from v0
 		//market.ClientDealProposal{},
 		//market.SectorDeals{}, // Aliased from v3
 		//market.SectorWeights{}, // Aliased from v3
 		//market.SectorDataSpec{}, // Aliased from v5
+		market.DealPriceSummary{},
 	); err != nil {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/miner/cbor_gen.go", "miner",
+	if err := writeEncoders("./actors/builtin/miner", "miner",
 		// actor state
 		miner.State{},
 		miner.MinerInfo{},
 		miner.Deadlines{},
 		miner.Deadline{},
+		miner.SectorExitCounts{},
 		miner.Partition{},
 		miner.ExpirationSet{},
 		miner.PowerPair{},
@@ -184,12 +224,22 @@ func main() {
 		miner.ChangeBeneficiaryParams{},
 		miner.BeneficiaryTerm{},
 		miner.GetBeneficiaryReturn{},
+		miner.GetFeeDebtStatusReturn{},
 		miner.ActiveBeneficiary{},
 		miner.PendingBeneficiaryChange{},
 		miner.VestingFunds{},
 		miner.VestingFund{},
+		miner.FeeDebtLog{},
+		miner.FeeDebtAccrual{},
+		miner.FeeDebtRepayment{},
 		miner.WindowedPoSt{},
+		miner.RebalanceCursor{},
+		miner.PendingPauseChange{},
+		miner.UnsealingWindows{},
+		miner.UnsealingWindow{},
 		// method params and returns
+		miner.DeclareUnsealingWindowParams{},
+		miner.GetUnsealingWindowsReturn{},
 		// miner.ConstructorParams{}, // in power actor
 		//miner.SubmitWindowedPoStParams{}, // Aliased from v0
 		//miner.TerminateSectorsParams{}, // Aliased from v0
@@ -223,7 +273,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := gen.WriteTupleEncodersToFile("./actors/builtin/verifreg/cbor_gen.go", "verifreg",
+	if err := writeEncoders("./actors/builtin/verifreg", "verifreg",
 		// actor state
 		verifreg.State{},
 
@@ -238,6 +288,9 @@ func main() {
 		verifreg.RemoveDataCapRequest{},  // New in v7
 		verifreg.RemoveDataCapProposal{}, // New in v7
 		verifreg.RmDcProposalID{},        // New in v7
+		verifreg.DataCapAllowance{},
+		verifreg.SetDataCapAllowanceParams{},
+		verifreg.UseBytesDelegatedParams{},
 	); err != nil {
 		panic(err)
 	}
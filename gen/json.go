@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	gen "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+)
+
+var (
+	bigIntType         = reflect.TypeOf(big.Int{})
+	bitFieldType       = reflect.TypeOf(bitfield.BitField{})
+	filterEstimateType = reflect.TypeOf(smoothing.FilterEstimate{})
+)
+
+// Writes the CBOR encoders and canonical JSON encoders of types to cbor_gen.go and json_gen.go in a package directory,
+// and fuzz targets for the CBOR decoders to cbor_gen_test.go.
+func writeEncoders(dir, pkg string, types ...interface{}) error {
+	if err := gen.WriteTupleEncodersToFile(filepath.Join(dir, "cbor_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	if err := writeJSONEncodersToFile(filepath.Join(dir, "json_gen.go"), pkg, types...); err != nil {
+		return err
+	}
+	return writeFuzzTargetsToFile(dir, pkg, types...)
+}
+
+// Writes MarshalJSON and UnmarshalJSON methods for struct types, encoding each as an object of its exported fields
+// with the canonical encodings of the jsonenc package.
+func writeJSONEncodersToFile(path, pkg string, types ...interface{}) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by github.com/filecoin-project/specs-actors/v7/gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n\t\"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc\"\n)\n")
+	for _, t := range types {
+		if err := writeJSONEncoders(&buf, reflect.TypeOf(t)); err != nil {
+			return xerrors.Errorf("failed to generate JSON encoders for %s: %w", path, err)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return xerrors.Errorf("failed to format %s: %w", path, err)
+	}
+	return ioutil.WriteFile(path, src, 0644)
+}
+
+func writeJSONEncoders(buf *bytes.Buffer, t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return xerrors.Errorf("%s is not a struct", t)
+	}
+	name := t.Name()
+	fmt.Fprintf(buf, "\nfunc (t %s) MarshalJSON() ([]byte, error) {\n\treturn t.jsonFields().MarshalJSON()\n}\n", name)
+	fmt.Fprintf(buf, "\nfunc (t *%s) UnmarshalJSON(b []byte) error {\n\treturn t.jsonFields().UnmarshalJSON(b)\n}\n", name)
+	fmt.Fprintf(buf, "\nfunc (t *%s) jsonFields() jsonenc.Object {\n\treturn jsonenc.Object{\n", name)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			return xerrors.Errorf("%s has embedded field %s", t, f.Name)
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		fmt.Fprintf(buf, "\t\t{Name: %q, Value: %s},\n", f.Name, jsonFieldValue(f))
+	}
+	fmt.Fprintf(buf, "\t}\n}\n")
+	return nil
+}
+
+// Returns an expression for a pointer to a field, converted to the jsonenc type encoding it if there is one.
+func jsonFieldValue(f reflect.StructField) string {
+	ref := "&t." + f.Name
+	switch f.Type {
+	case bigIntType:
+		return "(*jsonenc.BigInt)(" + ref + ")"
+	case bitFieldType:
+		return "(*jsonenc.BitField)(" + ref + ")"
+	case filterEstimateType:
+		return "(*jsonenc.FilterEstimate)(" + ref + ")"
+	}
+	if f.Type.Kind() == reflect.Slice {
+		switch f.Type.Elem() {
+		case bigIntType:
+			return "(*jsonenc.BigInts)(" + ref + ")"
+		case bitFieldType:
+			return "(*jsonenc.BitFields)(" + ref + ")"
+		}
+	}
+	return ref
+}
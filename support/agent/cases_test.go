@@ -1,7 +1,10 @@
 package agent_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -9,12 +12,16 @@ import (
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
 	cbor "github.com/ipfs/go-ipld-cbor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
 	"github.com/filecoin-project/specs-actors/v7/actors/states"
 	"github.com/filecoin-project/specs-actors/v7/support/agent"
 	"github.com/filecoin-project/specs-actors/v7/support/ipld"
@@ -350,6 +357,404 @@ func TestCreateDeals(t *testing.T) {
 	}
 }
 
+func TestVerifiedDeals(t *testing.T) {
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	minerCount := 3
+	clientCount := 3
+
+	// set up sim
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSim(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+
+	// create miners
+	workerAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:    0.5,
+			ProofType:        abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:  big.Div(initialBalance, big.NewInt(2)),
+			MinMarketBalance: big.NewInt(1e18),
+			MaxMarketBalance: big.NewInt(2e18),
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+
+	// create a verifier and clients making both regular and verified deals
+	verifier := vm.CreateAccounts(ctx, t, getV5VM(t, sim), 1, initialBalance, rnd.Int63())[0]
+	require.NoError(t, agent.AddVerifier(sim.GetVM(), verifier, big.NewInt(1<<50)))
+
+	clientAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), clientCount, initialBalance, rnd.Int63())
+	dealAgents := agent.AddDealClientsForAccounts(sim, clientAccounts, rnd.Int63(), agent.DealClientConfig{
+		DealRate:         .05,
+		VerifiedDealRate: .05,
+		Verifier:         verifier,
+		DataCapAllowance: big.NewInt(128 << 30),
+		MinPieceSize:     1 << 29,
+		MaxPieceSize:     32 << 30,
+		MinStoragePrice:  big.Zero(),
+		MaxStoragePrice:  abi.NewTokenAmount(200_000_000),
+		MinMarketBalance: big.NewInt(1e18),
+		MaxMarketBalance: big.NewInt(2e18),
+	})
+
+	for i := 0; i < 300; i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	deals, verifiedDeals := 0, 0
+	for _, da := range dealAgents {
+		deals += da.DealCount
+		verifiedDeals += da.VerifiedDealCount
+	}
+	assert.Greater(t, verifiedDeals, 0)
+	assert.Greater(t, deals, verifiedDeals)
+
+	// verified deals have been published
+	var marketSt market.State
+	require.NoError(t, sim.GetState(builtin.StorageMarketActorAddr, &marketSt))
+	proposals, err := market.AsDealProposalArray(sim.Store(), marketSt.Proposals)
+	require.NoError(t, err)
+	publishedVerified := 0
+	var proposal market.DealProposal
+	require.NoError(t, proposals.ForEach(&proposal, func(_ int64) error {
+		if proposal.VerifiedDeal {
+			publishedVerified++
+		}
+		return nil
+	}))
+	assert.Greater(t, publishedVerified, 0)
+
+	stateTree, err := getV5VM(t, sim).GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := getV5VM(t, sim).GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, sim.GetVM().GetEpoch()-1)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
+
+func TestFaultModels(t *testing.T) {
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	minerCount := 3
+
+	// set up sim
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSim(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+
+	// create miners which suffer every kind of fault
+	workerAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:         2.0,
+			FaultRate:             0.0001,
+			RecoveryRate:          0.001,
+			PartitionOutageRate:   0.001,
+			MissedPoStProbability: 0.5,
+			RecoveryDelay:         miner.WPoStChallengeWindow,
+			ProofType:             abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:       big.Div(initialBalance, big.NewInt(2)),
+			MinMarketBalance:      big.Zero(),
+			MaxMarketBalance:      big.Zero(),
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+
+	for i := 0; i < 3000; i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	missedPoSts, outages := uint64(0), uint64(0)
+	for _, a := range sim.Agents {
+		if ma, ok := a.(*agent.MinerAgent); ok {
+			missedPoSts += ma.MissedPoSts
+			outages += ma.PartitionOutages
+		}
+	}
+	assert.Greater(t, missedPoSts, uint64(0))
+	assert.Greater(t, outages, uint64(0))
+
+	var pwrSt power.State
+	require.NoError(t, sim.GetState(builtin.StoragePowerActorAddr, &pwrSt))
+	fmt.Printf("Faults at %d: raw: %v  cmtRaw: %v  missed posts: %d  outages: %d\n",
+		sim.GetEpoch(), pwrSt.TotalRawBytePower, pwrSt.TotalBytesCommitted, missedPoSts, outages)
+
+	stateTree, err := getV5VM(t, sim).GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := getV5VM(t, sim).GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, sim.GetVM().GetEpoch()-1)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
+
+func TestMetricsAndCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	minerCount := 3
+	clientCount := 3
+	epochs := 200
+
+	// set up sim reporting metrics
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSim(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+	var csvOut, jsonOut bytes.Buffer
+	sim.AddReporter(agent.NewCSVReporter(&csvOut))
+	sim.AddReporter(agent.NewJSONReporter(&jsonOut))
+
+	workerAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:    1.0,
+			FaultRate:        0.0001,
+			RecoveryRate:     0.0001,
+			ProofType:        abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:  big.Div(initialBalance, big.NewInt(2)),
+			MinMarketBalance: big.NewInt(1e18),
+			MaxMarketBalance: big.NewInt(2e18),
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+	clientAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), clientCount, initialBalance, rnd.Int63())
+	agent.AddDealClientsForAccounts(sim, clientAccounts, rnd.Int63(), agent.DealClientConfig{
+		DealRate:         .05,
+		MinPieceSize:     1 << 29,
+		MaxPieceSize:     32 << 30,
+		MinStoragePrice:  big.Zero(),
+		MaxStoragePrice:  abi.NewTokenAmount(200_000_000),
+		MinMarketBalance: big.NewInt(1e18),
+		MaxMarketBalance: big.NewInt(2e18),
+	})
+
+	start := sim.GetEpoch()
+	for i := 0; i < epochs; i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	// one CSV row per epoch after the header, and one JSON object per epoch
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, epochs+1)
+	assert.Equal(t, "epoch", rows[0][0])
+	dec := json.NewDecoder(&jsonOut)
+	var last agent.EpochMetrics
+	for i := 0; i < epochs; i++ {
+		require.NoError(t, dec.Decode(&last))
+		assert.Equal(t, start+abi.ChainEpoch(i), last.Epoch)
+	}
+	assert.Equal(t, int64(minerCount), last.Miners)
+	assert.True(t, last.CirculatingSupply.GreaterThan(big.Zero()))
+
+	// resume from a checkpoint
+	dir := t.TempDir()
+	require.NoError(t, sim.WriteCheckpoint(dir))
+	resumed, err := agent.ResumeSim(ctx, t, newBlockStore, dir, rnd.Int63())
+	require.NoError(t, err)
+	assert.Equal(t, sim.GetEpoch(), resumed.GetEpoch())
+	assert.Equal(t, sim.GetVM().StateRoot(), resumed.GetVM().StateRoot())
+	assert.Equal(t, len(sim.Agents), len(resumed.Agents))
+	assert.Equal(t, len(sim.DealProviders), len(resumed.DealProviders))
+
+	for i := 0; i < epochs; i++ {
+		require.NoError(t, resumed.Tick())
+	}
+
+	stateTree, err := getV5VM(t, resumed).GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := getV5VM(t, resumed).GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, resumed.GetVM().GetEpoch()-1)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
+
+func TestMinerStrategies(t *testing.T) {
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	startingBalance := big.Div(initialBalance, big.NewInt(2))
+	minerCount := 3
+
+	// set up sim
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSim(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+
+	// create miners which batch pre-commits and withdraw a tenth of their available balance
+	strategy := &batchRecordingStrategy{BatchingStrategy: agent.BatchingStrategy{
+		BatchSize:        8,
+		MaxBatchDelay:    50,
+		WithdrawFraction: 0.1,
+	}}
+	workerAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:    0.5,
+			ProofType:        abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:  startingBalance,
+			MinMarketBalance: big.Zero(),
+			MaxMarketBalance: big.Zero(),
+			Strategy:         strategy,
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+
+	for i := 0; i < 500; i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	require.Greater(t, len(strategy.batches), 0)
+	for _, b := range strategy.batches {
+		assert.True(t, b.count >= 8 || b.waited >= 50, "batch of %d sent after %d epochs", b.count, b.waited)
+	}
+
+	// every miner has withdrawn funds to its owner
+	for _, a := range sim.Agents {
+		ma, ok := a.(*agent.MinerAgent)
+		if !ok {
+			continue
+		}
+		owner, found, err := sim.GetVM().GetActor(ma.Owner)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.True(t, owner.Balance.GreaterThan(big.Sub(initialBalance, startingBalance)))
+	}
+}
+
+func TestSectorExtensionAndTermination(t *testing.T) {
+	t.Skip("this is slow")
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	minerCount := 3
+
+	// set up sim
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSim(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+
+	// create miners which renew every sector and terminate faulty ones
+	workerAccounts := vm.CreateAccounts(ctx, t, getV5VM(t, sim), minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:    0.2,
+			FaultRate:        0.0001,
+			ProofType:        abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:  big.Div(initialBalance, big.NewInt(2)),
+			MinMarketBalance: big.Zero(),
+			MaxMarketBalance: big.Zero(),
+			Strategy: &agent.BatchingStrategy{
+				ExtendWithin:    miner.MaxSectorExpirationExtension,
+				ExtendBy:        30 * builtin.EpochsInDay,
+				TerminateFaulty: true,
+			},
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+
+	for i := 0; i < 4*int(miner.WPoStProvingPeriod); i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	extended, terminated := uint64(0), uint64(0)
+	for _, a := range sim.Agents {
+		if ma, ok := a.(*agent.MinerAgent); ok {
+			extended += ma.ExtendedSectors
+			terminated += ma.TerminatedSectors
+		}
+	}
+	assert.Greater(t, extended, uint64(0))
+	assert.Greater(t, terminated, uint64(0))
+
+	stateTree, err := getV5VM(t, sim).GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := getV5VM(t, sim).GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, sim.GetVM().GetEpoch()-1)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
+
+func TestNetworkUpgrade(t *testing.T) {
+	ctx := context.Background()
+	initialBalance := big.Mul(big.NewInt(1e8), big.NewInt(1e18))
+	minerCount := 3
+	clientCount := 3
+	upgradeEpoch := abi.ChainEpoch(400)
+
+	// set up sim on v6 actors
+	rnd := rand.New(rand.NewSource(42))
+	sim := agent.NewSimV6(ctx, t, newBlockStore, agent.SimConfig{Seed: rnd.Int63()})
+	v6, ok := sim.GetVM().(*agent.SimVMV6)
+	require.True(t, ok)
+
+	// create miners
+	workerAccounts := vm6.CreateAccounts(ctx, t, v6.VM, minerCount, initialBalance, rnd.Int63())
+	sim.AddAgent(agent.NewMinerGenerator(
+		workerAccounts,
+		agent.MinerAgentConfig{
+			PrecommitRate:    0.1,
+			FaultRate:        0.00001,
+			RecoveryRate:     0.0001,
+			ProofType:        abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+			StartingBalance:  big.Div(initialBalance, big.NewInt(2)),
+			MinMarketBalance: big.NewInt(1e18),
+			MaxMarketBalance: big.NewInt(2e18),
+		},
+		1.0, // create miner probability of 1 means a new miner is created every tick
+		rnd.Int63(),
+	))
+
+	// create deal clients
+	clientAccounts := vm6.CreateAccounts(ctx, t, v6.VM, clientCount, initialBalance, rnd.Int63())
+	dealAgents := agent.AddDealClientsForAccounts(sim, clientAccounts, rnd.Int63(), agent.DealClientConfig{
+		DealRate:         .01,
+		MinPieceSize:     1 << 29,
+		MaxPieceSize:     32 << 30,
+		MinStoragePrice:  big.Zero(),
+		MaxStoragePrice:  abi.NewTokenAmount(200_000_000),
+		MinMarketBalance: big.NewInt(1e18),
+		MaxMarketBalance: big.NewInt(2e18),
+	})
+
+	require.NoError(t, sim.ScheduleUpgrade(agent.UpgradeV6ToV7(upgradeEpoch, nv15.Config{MaxWorkers: 2}, nv15.TestLogger{TB: t})))
+	require.Error(t, sim.ScheduleUpgrade(agent.UpgradeV6ToV7(upgradeEpoch, nv15.Config{}, nv15.TestLogger{TB: t})))
+
+	for sim.GetEpoch() <= upgradeEpoch {
+		require.NoError(t, sim.Tick())
+	}
+
+	// the sim continues on v7 actors from the epoch after the upgrade
+	_, ok = sim.GetVM().(*vm.VM)
+	require.True(t, ok)
+	assert.Equal(t, upgradeEpoch+1, sim.GetEpoch())
+
+	for i := 0; i < 400; i++ {
+		require.NoError(t, sim.Tick())
+	}
+
+	deals := 0
+	for _, da := range dealAgents {
+		deals += da.DealCount
+	}
+	assert.Greater(t, deals, 0)
+
+	stateTree, err := getV5VM(t, sim).GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := getV5VM(t, sim).GetTotalActorBalance()
+	require.NoError(t, err)
+	acc, err := states.CheckStateInvariants(stateTree, totalBalance, sim.GetVM().GetEpoch()-1)
+	require.NoError(t, err)
+	require.True(t, acc.IsEmpty(), strings.Join(acc.Messages(), "\n"))
+}
+
 func TestCCUpgrades(t *testing.T) {
 	t.Skip("this is slow")
 	ctx := context.Background()
@@ -456,3 +861,22 @@ func getV5VM(t *testing.T, sim *agent.Sim) *vm.VM {
 	require.True(t, ok)
 	return vm
 }
+
+// Records the pre-commit batches sent by a batching strategy.
+type batchRecordingStrategy struct {
+	agent.BatchingStrategy
+	batches []recordedBatch
+}
+
+type recordedBatch struct {
+	count  int
+	waited abi.ChainEpoch
+}
+
+func (rs *batchRecordingStrategy) PreCommits(epoch abi.ChainEpoch, ready int, readySince abi.ChainEpoch) (int, bool) {
+	count, batch := rs.BatchingStrategy.PreCommits(epoch, ready, readySince)
+	if count > 0 {
+		rs.batches = append(rs.batches, recordedBatch{count: count, waited: epoch - readySince})
+	}
+	return count, batch
+}
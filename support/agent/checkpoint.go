@@ -0,0 +1,495 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+)
+
+const (
+	checkpointStateFile = "state.car"
+	checkpointFile      = "checkpoint.json"
+)
+
+// An agent which can save its state in a simulation checkpoint, from which it is restored on resumption.
+type checkpointAgent interface {
+	Agent
+	snapshot() (agentSnapshot, error)
+}
+
+var _ checkpointAgent = (*MinerGenerator)(nil)
+var _ checkpointAgent = (*MinerAgent)(nil)
+var _ checkpointAgent = (*DealClientAgent)(nil)
+
+// Writes the state of a simulation between ticks to a directory, from which ResumeSim continues it.
+// The state tree is written as a CAR file alongside the state of the simulation's agents, all of which must be
+// miner generators, miner agents or deal client agents. Miners' strategies must be reference strategies.
+// Only simulations running v7 actors with no network upgrades scheduled can be checkpointed.
+// Random number generators are not saved.
+func (s *Sim) WriteCheckpoint(dir string) error {
+	if _, ok := s.v.(*SimVMV6); ok || len(s.upgrades) > 0 {
+		return xerrors.Errorf("cannot checkpoint a simulation before its network upgrades")
+	}
+	cp := checkpoint{
+		Epoch:        s.v.GetEpoch(),
+		StateRoot:    s.v.StateRoot(),
+		Config:       s.Config,
+		WinCount:     s.WinCount,
+		MessageCount: s.MessageCount,
+	}
+	for _, a := range s.Agents {
+		ca, ok := a.(checkpointAgent)
+		if !ok {
+			return xerrors.Errorf("agent %T cannot be checkpointed", a)
+		}
+		snapshot, err := ca.snapshot()
+		if err != nil {
+			return err
+		}
+		cp.Agents = append(cp.Agents, snapshot)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, checkpointStateFile))
+	if err != nil {
+		return err
+	}
+	if err := states.ExportCAR(s.v.Store(), cp.StateRoot, f); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("failed to export state at epoch %d: %w", cp.Epoch, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(&cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, checkpointFile), data, 0644)
+}
+
+// Resumes a simulation from a checkpoint written by WriteCheckpoint, at the epoch at which it was written.
+// The agents' random number generators are seeded afresh from the seed given, so that runs resumed from one
+// checkpoint with different seeds may be compared.
+func ResumeSim(ctx context.Context, t testing.TB, blockstoreFactory func() ipldcbor.IpldBlockstore, dir string, seed int64) (*Sim, error) {
+	data, err := os.ReadFile(filepath.Join(dir, checkpointFile))
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, xerrors.Errorf("failed to parse checkpoint in %s: %w", dir, err)
+	}
+
+	config := cp.Config
+	config.Seed = seed
+	s := NewSim(ctx, t, blockstoreFactory, config)
+	s.WinCount = cp.WinCount
+	s.MessageCount = cp.MessageCount
+
+	f, err := os.Open(filepath.Join(dir, checkpointStateFile))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	root, err := states.ImportCAR(s.blkStore, f)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to import state from %s: %w", dir, err)
+	}
+	if root != cp.StateRoot {
+		return nil, xerrors.Errorf("checkpoint state root %v does not match imported root %v", cp.StateRoot, root)
+	}
+
+	statsSource := s.v.GetStatsSource()
+	s.v, err = s.vmFactory(s.ctx, s.v.GetActorImpls(), s.v.Store(), root, cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	s.v.SetStatsSource(statsSource)
+
+	for _, snapshot := range cp.Agents {
+		if err := snapshot.restore(s, s.rnd.Int63()); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+type checkpoint struct {
+	Epoch        abi.ChainEpoch
+	StateRoot    cid.Cid
+	Config       SimConfig
+	WinCount     uint64
+	MessageCount uint64
+	Agents       []agentSnapshot
+}
+
+// A miner strategy, which is ImmediateStrategy if no field is set.
+// Only the reference strategies can be saved in a checkpoint.
+type strategySnapshot struct {
+	Batching *BatchingStrategy `json:",omitempty"`
+}
+
+func snapshotStrategy(strategy MinerStrategy) (strategySnapshot, error) {
+	switch st := strategy.(type) {
+	case nil, ImmediateStrategy, *ImmediateStrategy:
+		return strategySnapshot{}, nil
+	case *BatchingStrategy:
+		return strategySnapshot{Batching: st}, nil
+	default:
+		return strategySnapshot{}, xerrors.Errorf("miner strategy %T cannot be checkpointed", strategy)
+	}
+}
+
+func (ss strategySnapshot) restore() MinerStrategy {
+	if ss.Batching != nil {
+		return ss.Batching
+	}
+	return nil
+}
+
+// The state of one agent, of which exactly one field is set.
+type agentSnapshot struct {
+	MinerGenerator *minerGeneratorSnapshot `json:",omitempty"`
+	Miner          *minerAgentSnapshot     `json:",omitempty"`
+	DealClient     *dealClientSnapshot     `json:",omitempty"`
+}
+
+func (as agentSnapshot) restore(s *Sim, seed int64) error {
+	switch {
+	case as.MinerGenerator != nil:
+		s.AddAgent(as.MinerGenerator.restore(seed))
+	case as.Miner != nil:
+		ma, err := as.Miner.restore(seed)
+		if err != nil {
+			return err
+		}
+		s.AddAgent(ma)
+		s.AddDealProvider(ma)
+	case as.DealClient != nil:
+		s.AddAgent(as.DealClient.restore(seed))
+	default:
+		return xerrors.Errorf("empty agent snapshot in checkpoint")
+	}
+	return nil
+}
+
+type rateSnapshot struct {
+	Rate           float64
+	NextOccurrence float64
+}
+
+func (ri *RateIterator) snapshot() rateSnapshot {
+	return rateSnapshot{Rate: ri.rate, NextOccurrence: ri.nextOccurrence}
+}
+
+func (rs rateSnapshot) restore(seed int64) *RateIterator {
+	return &RateIterator{
+		rnd:            rand.New(rand.NewSource(seed)),
+		rate:           rs.Rate,
+		nextOccurrence: rs.NextOccurrence,
+	}
+}
+
+//
+// Miner generator
+//
+
+type minerGeneratorSnapshot struct {
+	Config            MinerAgentConfig
+	Strategy          strategySnapshot
+	CreateMinerEvents rateSnapshot
+	MinersCreated     int
+	Accounts          []address.Address
+}
+
+func (mg *MinerGenerator) snapshot() (agentSnapshot, error) {
+	strategy, err := snapshotStrategy(mg.config.Strategy)
+	if err != nil {
+		return agentSnapshot{}, err
+	}
+	return agentSnapshot{MinerGenerator: &minerGeneratorSnapshot{
+		Config:            mg.config,
+		Strategy:          strategy,
+		CreateMinerEvents: mg.createMinerEvents.snapshot(),
+		MinersCreated:     mg.minersCreated,
+		Accounts:          mg.accounts,
+	}}, nil
+}
+
+func (ss *minerGeneratorSnapshot) restore(seed int64) *MinerGenerator {
+	rnd := rand.New(rand.NewSource(seed))
+	config := ss.Config
+	config.Strategy = ss.Strategy.restore()
+	return &MinerGenerator{
+		config:            config,
+		createMinerEvents: ss.CreateMinerEvents.restore(rnd.Int63()),
+		minersCreated:     ss.MinersCreated,
+		accounts:          ss.Accounts,
+		rnd:               rnd,
+	}
+}
+
+//
+// Miner agent
+//
+
+type minerAgentSnapshot struct {
+	Config        MinerAgentConfig
+	Strategy      strategySnapshot
+	Owner         address.Address
+	Worker        address.Address
+	IDAddress     address.Address
+	RobustAddress address.Address
+
+	UpgradedSectors   uint64
+	PartitionOutages  uint64
+	MissedPoSts       uint64
+	ExtendedSectors   uint64
+	TerminatedSectors uint64
+
+	LiveSectors           []uint64
+	FaultySectors         []uint64
+	CCSectors             []uint64
+	PendingDeals          []market.ClientDealProposal
+	DealsPendingInclusion []pendingDealSnapshot
+	Operations            []operationSnapshot
+	Deadlines             [miner.WPoStPeriodDeadlines][]partitionSnapshot
+	ReadyPreCommits       int
+	ReadySince            abi.ChainEpoch
+	NextWithdrawal        abi.ChainEpoch
+	NextSectorNumber      abi.SectorNumber
+	ExpectedMarketBalance abi.TokenAmount
+
+	PreCommitEvents rateSnapshot
+	FaultEvents     rateSnapshot
+	RecoveryEvents  rateSnapshot
+	OutageEvents    rateSnapshot
+}
+
+type pendingDealSnapshot struct {
+	ID   abi.DealID
+	Size abi.PaddedPieceSize
+	Ends abi.ChainEpoch
+}
+
+type partitionSnapshot struct {
+	Sectors     bitfield.BitField
+	ToBeSkipped bitfield.BitField
+	Faults      bitfield.BitField
+	Expired     bitfield.BitField
+	MissedPoSt  bool
+}
+
+// A scheduled operation, with the fields relevant to its kind set.
+type operationSnapshot struct {
+	Epoch             abi.ChainEpoch
+	Kind              string
+	SectorNumber      abi.SectorNumber
+	DlIdx             uint64
+	PIdx              uint64
+	CommittedCapacity bool
+	Upgrade           bool
+}
+
+const (
+	opProveCommit       = "proveCommit"
+	opRegisterSector    = "registerSector"
+	opRecoverSector     = "recoverSector"
+	opRecoverableFault  = "recoverableFault"
+	opProveDeadline     = "proveDeadline"
+	opSyncDeadlineState = "syncDeadlineState"
+)
+
+func (ma *MinerAgent) snapshot() (agentSnapshot, error) {
+	strategy, err := snapshotStrategy(ma.Config.Strategy)
+	if err != nil {
+		return agentSnapshot{}, err
+	}
+	ss := &minerAgentSnapshot{
+		Config:                ma.Config,
+		Strategy:              strategy,
+		Owner:                 ma.Owner,
+		Worker:                ma.Worker,
+		IDAddress:             ma.IDAddress,
+		RobustAddress:         ma.RobustAddress,
+		UpgradedSectors:       ma.UpgradedSectors,
+		PartitionOutages:      ma.PartitionOutages,
+		MissedPoSts:           ma.MissedPoSts,
+		ExtendedSectors:       ma.ExtendedSectors,
+		TerminatedSectors:     ma.TerminatedSectors,
+		LiveSectors:           ma.liveSectors,
+		FaultySectors:         ma.faultySectors,
+		CCSectors:             ma.ccSectors,
+		PendingDeals:          ma.pendingDeals,
+		ReadyPreCommits:       ma.readyPreCommits,
+		ReadySince:            ma.readySince,
+		NextWithdrawal:        ma.nextWithdrawal,
+		NextSectorNumber:      ma.nextSectorNumber,
+		ExpectedMarketBalance: ma.expectedMarketBalance,
+		PreCommitEvents:       ma.preCommitEvents.snapshot(),
+		FaultEvents:           ma.faultEvents.snapshot(),
+		RecoveryEvents:        ma.recoveryEvents.snapshot(),
+		OutageEvents:          ma.outageEvents.snapshot(),
+	}
+	for _, deal := range ma.dealsPendingInclusion {
+		ss.DealsPendingInclusion = append(ss.DealsPendingInclusion, pendingDealSnapshot{ID: deal.id, Size: deal.size, Ends: deal.ends})
+	}
+	for dlIdx, parts := range ma.deadlines {
+		for _, part := range parts {
+			ss.Deadlines[dlIdx] = append(ss.Deadlines[dlIdx], partitionSnapshot{
+				Sectors:     part.sectors,
+				ToBeSkipped: part.toBeSkipped,
+				Faults:      part.faults,
+				Expired:     part.expired,
+				MissedPoSt:  part.missedPoSt,
+			})
+		}
+	}
+	for _, op := range ma.operationSchedule.ops {
+		opSnapshot := operationSnapshot{Epoch: op.epoch}
+		switch o := op.action.(type) {
+		case proveCommitAction:
+			opSnapshot.Kind = opProveCommit
+			opSnapshot.SectorNumber, opSnapshot.CommittedCapacity, opSnapshot.Upgrade = o.sectorNumber, o.committedCapacity, o.upgrade
+		case registerSectorAction:
+			opSnapshot.Kind = opRegisterSector
+			opSnapshot.SectorNumber, opSnapshot.CommittedCapacity, opSnapshot.Upgrade = o.sectorNumber, o.committedCapacity, o.upgrade
+		case recoverSectorAction:
+			opSnapshot.Kind = opRecoverSector
+			opSnapshot.DlIdx, opSnapshot.PIdx, opSnapshot.SectorNumber = o.dlIdx, o.pIdx, o.sectorNumber
+		case recoverableFaultAction:
+			opSnapshot.Kind = opRecoverableFault
+			opSnapshot.DlIdx, opSnapshot.PIdx, opSnapshot.SectorNumber = o.dlIdx, o.pIdx, o.sectorNumber
+		case proveDeadlineAction:
+			opSnapshot.Kind = opProveDeadline
+			opSnapshot.DlIdx = o.dlIdx
+		case syncDeadlineStateAction:
+			opSnapshot.Kind = opSyncDeadlineState
+			opSnapshot.DlIdx = o.dlIdx
+		default:
+			return agentSnapshot{}, xerrors.Errorf("cannot checkpoint miner operation %T", op.action)
+		}
+		ss.Operations = append(ss.Operations, opSnapshot)
+	}
+	return agentSnapshot{Miner: ss}, nil
+}
+
+func (ss *minerAgentSnapshot) restore(seed int64) (*MinerAgent, error) {
+	config := ss.Config
+	config.Strategy = ss.Strategy.restore()
+	ma := NewMinerAgent(ss.Owner, ss.Worker, ss.IDAddress, ss.RobustAddress, seed, config)
+	ma.UpgradedSectors = ss.UpgradedSectors
+	ma.PartitionOutages = ss.PartitionOutages
+	ma.MissedPoSts = ss.MissedPoSts
+	ma.ExtendedSectors = ss.ExtendedSectors
+	ma.TerminatedSectors = ss.TerminatedSectors
+	ma.liveSectors = ss.LiveSectors
+	ma.faultySectors = ss.FaultySectors
+	ma.ccSectors = ss.CCSectors
+	ma.pendingDeals = ss.PendingDeals
+	ma.readyPreCommits = ss.ReadyPreCommits
+	ma.readySince = ss.ReadySince
+	ma.nextWithdrawal = ss.NextWithdrawal
+	ma.nextSectorNumber = ss.NextSectorNumber
+	ma.expectedMarketBalance = ss.ExpectedMarketBalance
+	ma.preCommitEvents = ss.PreCommitEvents.restore(ma.rnd.Int63())
+	ma.faultEvents = ss.FaultEvents.restore(ma.rnd.Int63())
+	ma.recoveryEvents = ss.RecoveryEvents.restore(ma.rnd.Int63())
+	ma.outageEvents = ss.OutageEvents.restore(ma.rnd.Int63())
+
+	for _, deal := range ss.DealsPendingInclusion {
+		ma.dealsPendingInclusion = append(ma.dealsPendingInclusion, pendingDeal{id: deal.ID, size: deal.Size, ends: deal.Ends})
+	}
+	for dlIdx, parts := range ss.Deadlines {
+		for _, part := range parts {
+			ma.deadlines[dlIdx] = append(ma.deadlines[dlIdx], partition{
+				sectors:     part.Sectors,
+				toBeSkipped: part.ToBeSkipped,
+				faults:      part.Faults,
+				expired:     part.Expired,
+				missedPoSt:  part.MissedPoSt,
+			})
+		}
+	}
+	for _, op := range ss.Operations {
+		var action interface{}
+		switch op.Kind {
+		case opProveCommit:
+			action = proveCommitAction{sectorNumber: op.SectorNumber, committedCapacity: op.CommittedCapacity, upgrade: op.Upgrade}
+		case opRegisterSector:
+			action = registerSectorAction{sectorNumber: op.SectorNumber, committedCapacity: op.CommittedCapacity, upgrade: op.Upgrade}
+		case opRecoverSector:
+			action = recoverSectorAction{dlIdx: op.DlIdx, pIdx: op.PIdx, sectorNumber: op.SectorNumber}
+		case opRecoverableFault:
+			action = recoverableFaultAction{dlIdx: op.DlIdx, pIdx: op.PIdx, sectorNumber: op.SectorNumber}
+		case opProveDeadline:
+			action = proveDeadlineAction{dlIdx: op.DlIdx}
+		case opSyncDeadlineState:
+			action = syncDeadlineStateAction{dlIdx: op.DlIdx}
+		default:
+			return nil, xerrors.Errorf("unknown miner operation %q in checkpoint", op.Kind)
+		}
+		ma.operationSchedule.ScheduleOp(op.Epoch, action)
+	}
+	return ma, nil
+}
+
+//
+// Deal client agent
+//
+
+type dealClientSnapshot struct {
+	Account               address.Address
+	Config                DealClientConfig
+	DealCount             int
+	VerifiedDealCount     int
+	DealEvents            rateSnapshot
+	VerifiedDealEvents    rateSnapshot
+	ExpectedMarketBalance abi.TokenAmount
+	ExpectedDataCap       abi.StoragePower
+	DataCapRequested      bool
+}
+
+func (dca *DealClientAgent) snapshot() (agentSnapshot, error) {
+	return agentSnapshot{DealClient: &dealClientSnapshot{
+		Account:               dca.account,
+		Config:                dca.config,
+		DealCount:             dca.DealCount,
+		VerifiedDealCount:     dca.VerifiedDealCount,
+		DealEvents:            dca.dealEvents.snapshot(),
+		VerifiedDealEvents:    dca.verifiedDealEvents.snapshot(),
+		ExpectedMarketBalance: dca.expectedMarketBalance,
+		ExpectedDataCap:       dca.expectedDataCap,
+		DataCapRequested:      dca.dataCapRequested,
+	}}, nil
+}
+
+func (ss *dealClientSnapshot) restore(seed int64) *DealClientAgent {
+	dca := NewDealClientAgent(ss.Account, seed, ss.Config)
+	dca.DealCount = ss.DealCount
+	dca.VerifiedDealCount = ss.VerifiedDealCount
+	dca.dealEvents = ss.DealEvents.restore(dca.rnd.Int63())
+	dca.verifiedDealEvents = ss.VerifiedDealEvents.restore(dca.rnd.Int63())
+	dca.expectedMarketBalance = ss.ExpectedMarketBalance
+	dca.expectedDataCap = ss.ExpectedDataCap
+	dca.dataCapRequested = ss.DataCapRequested
+	return dca
+}
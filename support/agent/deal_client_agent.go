@@ -6,6 +6,7 @@ import (
 	"math/bits"
 	"math/rand"
 	"strconv"
+	"strings"
 
 	mh "github.com/multiformats/go-multihash"
 
@@ -14,11 +15,15 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
 	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 )
 
 type DealClientConfig struct {
@@ -29,18 +34,28 @@ type DealClientConfig struct {
 	MaxStoragePrice  abi.TokenAmount // maximum price per epoch a client will pay for storage
 	MinMarketBalance abi.TokenAmount // balance below which client will top up funds in market actor
 	MaxMarketBalance abi.TokenAmount // balance to which client will top up funds in market actor
+
+	VerifiedDealRate float64          // verified deals made per epoch, in addition to regular deals
+	Verifier         address.Address  // verifier from which client requests data cap for verified deals (see AddVerifier)
+	DataCapAllowance abi.StoragePower // data cap client requests when it has too little for its largest verified deal
 }
 
 type DealClientAgent struct {
-	DealCount int
+	DealCount         int // all deals made, including verified deals
+	VerifiedDealCount int
 
-	account    address.Address
-	config     DealClientConfig
-	dealEvents *RateIterator
-	rnd        *rand.Rand
+	account            address.Address
+	config             DealClientConfig
+	dealEvents         *RateIterator
+	verifiedDealEvents *RateIterator
+	rnd                *rand.Rand
 
 	// tracks funds expected to be locked for client deal payment
 	expectedMarketBalance abi.TokenAmount
+	// tracks data cap expected to remain after all verified deals made are published
+	expectedDataCap abi.StoragePower
+	// true while a request for data cap is in flight
+	dataCapRequested bool
 }
 
 func AddDealClientsForAccounts(s SimState, accounts []address.Address, seed int64, config DealClientConfig) []*DealClientAgent {
@@ -61,31 +76,54 @@ func NewDealClientAgent(account address.Address, seed int64, config DealClientCo
 		config:                config,
 		rnd:                   rnd,
 		expectedMarketBalance: big.Zero(),
+		expectedDataCap:       big.Zero(),
 		dealEvents:            NewRateIterator(config.DealRate, rnd.Int63()),
+		verifiedDealEvents:    NewRateIterator(config.VerifiedDealRate, rnd.Int63()),
 	}
 }
 
+// Adds a verifier with an allowance from which deal clients may request data cap.
+// The verifier must not be a deal client itself.
+func AddVerifier(v SimVM, verifier address.Address, allowance abi.StoragePower) error {
+	params := verifreg.AddVerifierParams{Address: verifier, Allowance: allowance}
+	result, err := v.ApplyMessage(vm.VerifregRoot, builtin.VerifiedRegistryActorAddr, big.Zero(), builtin.MethodsVerifiedRegistry.AddVerifier, &params, "agent")
+	if err != nil {
+		return err
+	}
+	if result.Code != exitcode.Ok {
+		return xerrors.Errorf("exitcode %d: add verifier message failed:\n%s\n", result.Code, strings.Join(v.GetLogs(), "\n"))
+	}
+	return nil
+}
+
 func (dca *DealClientAgent) Tick(s SimState) ([]message, error) {
-	// aggregate all deals into one message
-	if err := dca.dealEvents.Tick(func() error {
-		provider := s.ChooseDealProvider()
+	makeDeal := func(verified bool) func() error {
+		return func() error {
+			provider := s.ChooseDealProvider()
 
-		// provider will be nil if called before any miners are added to system
-		if provider == nil {
-			return nil
-		}
+			// provider will be nil if called before any miners are added to system
+			if provider == nil {
+				return nil
+			}
 
-		if err := dca.createDeal(s, provider); err != nil {
-			return err
+			return dca.createDeal(s, provider, verified)
 		}
-		return nil
-	}); err != nil {
+	}
+
+	// aggregate all deals into one message
+	if err := dca.dealEvents.Tick(makeDeal(false)); err != nil {
+		return nil, err
+	}
+	if err := dca.verifiedDealEvents.Tick(makeDeal(true)); err != nil {
 		return nil, err
 	}
 
 	// add message to update market balance if necessary
 	messages := dca.updateMarketBalance()
 
+	// add message to request data cap if necessary
+	messages = append(messages, dca.requestDataCap()...)
+
 	return messages, nil
 }
 
@@ -109,9 +147,41 @@ func (dca *DealClientAgent) updateMarketBalance() []message {
 	}}
 }
 
+// Request data cap from the verifier once the client could no longer make its largest verified deal.
+func (dca *DealClientAgent) requestDataCap() []message {
+	if dca.config.VerifiedDealRate <= 0 || dca.dataCapRequested ||
+		dca.expectedDataCap.GreaterThanEqual(dca.minDataCapForDeal(dca.config.MaxPieceSize)) {
+		return []message{}
+	}
+
+	dca.dataCapRequested = true
+	return []message{{
+		From:   dca.config.Verifier,
+		To:     builtin.VerifiedRegistryActorAddr,
+		Value:  big.Zero(),
+		Method: builtin.MethodsVerifiedRegistry.AddVerifiedClient,
+		Params: &verifreg.AddVerifiedClientParams{
+			Address:   dca.account,
+			Allowance: dca.config.DataCapAllowance,
+		},
+		ReturnHandler: func(_ SimState, _ message, _ cbor.Marshaler) error {
+			dca.expectedDataCap = big.Add(dca.expectedDataCap, dca.config.DataCapAllowance)
+			dca.dataCapRequested = false
+			return nil
+		},
+	}}
+}
+
+// The data cap a client must have to make a verified deal of a piece size.
+// The registry removes a client whose data cap falls below the minimum verified deal size, so the client keeps at
+// least that much in reserve. Its actual data cap is never less than expected, so it is never removed.
+func (dca *DealClientAgent) minDataCapForDeal(pieceSize uint64) abi.StoragePower {
+	return big.Add(big.NewIntUnsigned(pieceSize), verifreg.MinVerifiedDealSize)
+}
+
 // Create a proposal
 // Return false if provider if deal can't be performed because client or provider lacks funds
-func (dca *DealClientAgent) createDeal(s SimState, provider DealProvider) error {
+func (dca *DealClientAgent) createDeal(s SimState, provider DealProvider, verified bool) error {
 	pieceCid, err := dca.generatePieceCID()
 	if err != nil {
 		return err
@@ -125,7 +195,12 @@ func (dca *DealClientAgent) createDeal(s SimState, provider DealProvider) error
 		pieceSize = 1 << bits.Len64(pieceSize)
 	}
 
-	providerCollateral, err := calculateProviderCollateral(s, abi.PaddedPieceSize(pieceSize))
+	// if this client does not have enough data cap for a verified deal, just skip this deal
+	if verified && dca.expectedDataCap.LessThan(dca.minDataCapForDeal(pieceSize)) {
+		return nil
+	}
+
+	providerCollateral, err := calculateProviderCollateral(s, abi.PaddedPieceSize(pieceSize), verified)
 	if err != nil {
 		return err
 	}
@@ -155,11 +230,14 @@ func (dca *DealClientAgent) createDeal(s SimState, provider DealProvider) error
 	}
 
 	dca.expectedMarketBalance = big.Sub(dca.expectedMarketBalance, storageFee)
+	if verified {
+		dca.expectedDataCap = big.Sub(dca.expectedDataCap, big.NewIntUnsigned(pieceSize))
+	}
 
 	proposal := market.DealProposal{
 		PieceCID:             pieceCid,
 		PieceSize:            abi.PaddedPieceSize(pieceSize),
-		VerifiedDeal:         false,
+		VerifiedDeal:         verified,
 		Client:               dca.account,
 		Provider:             provider.Address(),
 		Label:                dca.account.String() + ":" + strconv.Itoa(dca.DealCount),
@@ -183,6 +261,9 @@ func (dca *DealClientAgent) createDeal(s SimState, provider DealProvider) error
 			Data: paramBuf.Bytes()},
 	})
 	dca.DealCount++
+	if verified {
+		dca.VerifiedDealCount++
+	}
 	return nil
 }
 
@@ -202,7 +283,7 @@ func (dca *DealClientAgent) generatePieceCID() (cid.Cid, error) {
 
 // Always choose the minimum collateral. This appears to be realistic, and there's is not an obvious way to model a
 // more complex distribution.
-func calculateProviderCollateral(s SimState, pieceSize abi.PaddedPieceSize) (abi.TokenAmount, error) {
+func calculateProviderCollateral(s SimState, pieceSize abi.PaddedPieceSize, verified bool) (abi.TokenAmount, error) {
 	var powerSt power.State
 	if err := s.GetState(builtin.StoragePowerActorAddr, &powerSt); err != nil {
 		return big.Zero(), err
@@ -213,7 +294,7 @@ func calculateProviderCollateral(s SimState, pieceSize abi.PaddedPieceSize) (abi
 		return big.Zero(), err
 	}
 
-	min, _ := market.DealProviderCollateralBounds(pieceSize, false, powerSt.TotalRawBytePower,
+	min, _ := market.DealProviderCollateralBounds(pieceSize, verified, powerSt.TotalRawBytePower,
 		powerSt.TotalQualityAdjPower, rewardSt.ThisEpochBaselinePower, s.NetworkCirculatingSupply())
 	return min, nil
 }
@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+)
+
+// Metrics describing the network at the end of a simulated epoch.
+type EpochMetrics struct {
+	Epoch             abi.ChainEpoch   `json:"epoch"`
+	Miners            int64            `json:"miners"`
+	RawBytePower      abi.StoragePower `json:"rawBytePower"`
+	QualityAdjPower   abi.StoragePower `json:"qualityAdjPower"`
+	TotalPledge       abi.TokenAmount  `json:"totalPledge"`
+	CirculatingSupply abi.TokenAmount  `json:"circulatingSupply"`
+	Burnt             abi.TokenAmount  `json:"burnt"`          // all funds burnt by the end of the epoch, mostly penalties and fees
+	BurntThisEpoch    abi.TokenAmount  `json:"burntThisEpoch"` // funds burnt during the epoch
+	DealsMade         int              `json:"dealsMade"`      // deals proposed by deal client agents
+	DealsPublished    abi.DealID       `json:"dealsPublished"` // deals published to the market actor
+	Messages          int              `json:"messages"`       // messages applied in the epoch, excluding rewards and cron
+}
+
+// Receives the metrics of a simulation at the end of each epoch.
+type Reporter interface {
+	Report(m *EpochMetrics) error
+}
+
+// Writes metrics as CSV, with a header row before the first epoch's.
+type CSVReporter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+var _ Reporter = (*CSVReporter)(nil)
+
+func NewCSVReporter(w io.Writer) *CSVReporter {
+	return &CSVReporter{w: csv.NewWriter(w)}
+}
+
+func (r *CSVReporter) Report(m *EpochMetrics) error {
+	if !r.headerWritten {
+		if err := r.w.Write([]string{"epoch", "miners", "rawBytePower", "qualityAdjPower", "totalPledge",
+			"circulatingSupply", "burnt", "burntThisEpoch", "dealsMade", "dealsPublished", "messages"}); err != nil {
+			return err
+		}
+		r.headerWritten = true
+	}
+	if err := r.w.Write([]string{
+		strconv.FormatInt(int64(m.Epoch), 10),
+		strconv.FormatInt(m.Miners, 10),
+		m.RawBytePower.String(),
+		m.QualityAdjPower.String(),
+		m.TotalPledge.String(),
+		m.CirculatingSupply.String(),
+		m.Burnt.String(),
+		m.BurntThisEpoch.String(),
+		strconv.Itoa(m.DealsMade),
+		strconv.FormatUint(uint64(m.DealsPublished), 10),
+		strconv.Itoa(m.Messages),
+	}); err != nil {
+		return err
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
+
+// Writes metrics as JSON, one object per line.
+type JSONReporter struct {
+	enc *json.Encoder
+}
+
+var _ Reporter = (*JSONReporter)(nil)
+
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{enc: json.NewEncoder(w)}
+}
+
+func (r *JSONReporter) Report(m *EpochMetrics) error {
+	return r.enc.Encode(m)
+}
+
+// Collects the metrics of the epoch just executed, in which a number of messages were applied.
+func (s *Sim) collectMetrics(messages int) (*EpochMetrics, error) {
+	var powerSt power.State
+	if err := s.v.GetState(builtin.StoragePowerActorAddr, &powerSt); err != nil {
+		return nil, err
+	}
+	var marketSt market.State
+	if err := s.v.GetState(builtin.StorageMarketActorAddr, &marketSt); err != nil {
+		return nil, err
+	}
+	burnt, found, err := s.v.GetActor(builtin.BurntFundsActorAddr)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, xerrors.Errorf("burnt actor not found at %v", builtin.BurntFundsActorAddr)
+	}
+
+	dealsMade := 0
+	for _, a := range s.Agents {
+		if client, ok := a.(*DealClientAgent); ok {
+			dealsMade += client.DealCount
+		}
+	}
+
+	burntThisEpoch := big.Zero()
+	if s.lastBurnt.Int != nil {
+		burntThisEpoch = big.Sub(burnt.Balance, s.lastBurnt)
+	}
+	s.lastBurnt = burnt.Balance
+
+	return &EpochMetrics{
+		Epoch:             s.v.GetEpoch(),
+		Miners:            powerSt.MinerCount,
+		RawBytePower:      powerSt.TotalRawBytePower,
+		QualityAdjPower:   powerSt.TotalQualityAdjPower,
+		TotalPledge:       powerSt.TotalPledgeCollateral,
+		CirculatingSupply: s.v.GetCirculatingSupply(),
+		Burnt:             burnt.Balance,
+		BurntThisEpoch:    burntThisEpoch,
+		DealsMade:         dealsMade,
+		DealsPublished:    marketSt.NextID,
+		Messages:          messages,
+	}, nil
+}
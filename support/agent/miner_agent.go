@@ -33,6 +33,14 @@ type MinerAgentConfig struct {
 	MinMarketBalance abi.TokenAmount         // balance below which miner will top up funds in market actor
 	MaxMarketBalance abi.TokenAmount         // balance to which miner will top up funds in market actor
 	UpgradeSectors   bool                    // if true, miner will replace sectors without deals with sectors that do
+
+	// Fault models, in addition to the random sector faults above
+	PartitionOutageRate   float64        // rate at which all live sectors of a partition go faulty together (outages per partition per epoch)
+	MissedPoStProbability float64        // probability that the miner fails to submit a deadline's WindowPoSt at all
+	RecoveryDelay         abi.ChainEpoch // minimum number of epochs a faulty sector stays faulty before it may be recovered
+
+	// Economic decisions of the miner, as ImmediateStrategy if nil
+	Strategy MinerStrategy `json:"-"`
 }
 
 type MinerAgent struct {
@@ -43,12 +51,16 @@ type MinerAgent struct {
 	RobustAddress address.Address
 
 	// Stats
-	UpgradedSectors uint64
+	UpgradedSectors   uint64
+	PartitionOutages  uint64
+	MissedPoSts       uint64
+	ExtendedSectors   uint64
+	TerminatedSectors uint64
 
 	// These slices are used to track counts and for random selections
 	// all committed sectors (including sectors pending proof validation) that are not faulty and have not expired
 	liveSectors []uint64
-	// all sectors expected to be faulty
+	// all sectors expected to be faulty which may be recovered
 	faultySectors []uint64
 	// all sectors that contain no deals (committed capacity sectors)
 	ccSectors []uint64
@@ -66,8 +78,16 @@ type MinerAgent struct {
 	preCommitEvents *RateIterator
 	// iterator to time faults events according to rate
 	faultEvents *RateIterator
+	// iterator to time partition outages according to rate
+	outageEvents *RateIterator
 	// iterator to time recoveries according to rate
 	recoveryEvents *RateIterator
+	// number of PreCommits ready to be sent when the strategy decides
+	readyPreCommits int
+	// epoch at which the oldest ready PreCommit became ready
+	readySince abi.ChainEpoch
+	// epoch at which the strategy next decides on a withdrawal
+	nextWithdrawal abi.ChainEpoch
 	// tracks which sector number to use next
 	nextSectorNumber abi.SectorNumber
 	// tracks funds expected to be locked for miner deal collateral
@@ -95,7 +115,9 @@ func NewMinerAgent(owner address.Address, worker address.Address, idAddress addr
 		faultEvents: NewRateIterator(0.0, rnd.Int63()),
 		// recovery rate is the configured recovery rate times the number of faults or zero.
 		recoveryEvents: NewRateIterator(0.0, rnd.Int63()),
-		rnd:            rnd, // rng for this miner isolated from original source
+		// outage rate is the configured outage rate times the number of partitions or zero.
+		outageEvents: NewRateIterator(0.0, rnd.Int63()),
+		rnd:          rnd, // rng for this miner isolated from original source
 	}
 }
 
@@ -128,36 +150,38 @@ func (ma *MinerAgent) Tick(s SimState) ([]message, error) {
 			if err := ma.syncMinerState(s, o.dlIdx); err != nil {
 				return nil, err
 			}
+			msgs, err := ma.manageSectors(s, o.dlIdx)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, msgs...)
+		case recoverableFaultAction:
+			if err := ma.makeFaultRecoverable(o.dlIdx, o.pIdx, o.sectorNumber); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	// Start PreCommits. PreCommits are triggered with a Poisson distribution at the PreCommit rate.
+	// Ready PreCommits. PreCommits are triggered with a Poisson distribution at the PreCommit rate.
 	// This permits multiple PreCommits per epoch while also allowing multiple epochs to pass
 	// between PreCommits. For now always assume we have enough funds for the PreCommit deposit.
 	if err := ma.preCommitEvents.Tick(func() error {
-		// can't create precommit if in fee debt
-		mSt, err := s.MinerState(ma.IDAddress)
-		if err != nil {
-			return err
-		}
-		feeDebt, err := mSt.FeeDebt(s.Store())
-		if err != nil {
-			return err
+		if ma.readyPreCommits == 0 {
+			ma.readySince = s.GetEpoch()
 		}
-		if feeDebt.GreaterThan(big.Zero()) {
-			return nil
-		}
-
-		msg, err := ma.createPreCommit(s, s.GetEpoch())
-		if err != nil {
-			return err
-		}
-		messages = append(messages, msg)
+		ma.readyPreCommits++
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
+	// Send ready PreCommits when the strategy decides.
+	msgs, err := ma.sendPreCommits(s)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, msgs...)
+
 	// Fault sectors.
 	// Rate must be multiplied by the number of live sectors
 	faultRate := ma.Config.FaultRate * float64(len(ma.liveSectors))
@@ -172,6 +196,20 @@ func (ma *MinerAgent) Tick(s SimState) ([]message, error) {
 		return nil, err
 	}
 
+	// Partition outages.
+	// Rate must be multiplied by the number of partitions
+	outageRate := ma.Config.PartitionOutageRate * float64(ma.partitionCount())
+	if err := ma.outageEvents.TickWithRate(outageRate, func() error {
+		msgs, err := ma.createPartitionOutage(s)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, msgs...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	// Recover sectors.
 	// Rate must be multiplied by the number of faulty sectors
 	recoveryRate := ma.Config.RecoveryRate * float64(len(ma.faultySectors))
@@ -186,6 +224,15 @@ func (ma *MinerAgent) Tick(s SimState) ([]message, error) {
 		return nil, err
 	}
 
+	// withdraw funds once per proving period
+	if s.GetEpoch() >= ma.nextWithdrawal {
+		msgs, err := ma.withdrawBalance(s)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msgs...)
+	}
+
 	// publish pending deals
 	messages = append(messages, ma.publishStorageDeals()...)
 
@@ -228,8 +275,72 @@ func (ma *MinerAgent) AvailableCollateral() abi.TokenAmount {
 //
 ///////////////////////////////////
 
-// create PreCommit message and activation trigger
-func (ma *MinerAgent) createPreCommit(s SimState, currentEpoch abi.ChainEpoch) (message, error) {
+// Send as many ready PreCommits as the strategy decides, individually or in batches.
+func (ma *MinerAgent) sendPreCommits(s SimState) ([]message, error) {
+	if ma.readyPreCommits == 0 {
+		return nil, nil
+	}
+	count, batch := ma.strategy().PreCommits(s.GetEpoch(), ma.readyPreCommits, ma.readySince)
+	if count <= 0 {
+		return nil, nil
+	}
+	if count > ma.readyPreCommits {
+		count = ma.readyPreCommits
+	}
+	ma.readyPreCommits -= count
+
+	// can't create precommit if in fee debt, so drop them
+	mSt, err := s.MinerState(ma.IDAddress)
+	if err != nil {
+		return nil, err
+	}
+	feeDebt, err := mSt.FeeDebt(s.Store())
+	if err != nil {
+		return nil, err
+	}
+	if feeDebt.GreaterThan(big.Zero()) {
+		return nil, nil
+	}
+
+	var messages []message
+	var batchParams []miner.PreCommitSectorParams
+	for i := 0; i < count; i++ {
+		params, err := ma.createPreCommit(s, s.GetEpoch())
+		if err != nil {
+			return nil, err
+		}
+		if !batch {
+			messages = append(messages, message{
+				From:   ma.Worker,
+				To:     ma.IDAddress,
+				Value:  big.Zero(),
+				Method: builtin.MethodsMiner.PreCommitSector,
+				Params: params,
+			})
+			continue
+		}
+		batchParams = append(batchParams, *params)
+	}
+
+	for len(batchParams) > 0 {
+		size := len(batchParams)
+		if size > miner.PreCommitSectorBatchMaxSize {
+			size = miner.PreCommitSectorBatchMaxSize
+		}
+		messages = append(messages, message{
+			From:   ma.Worker,
+			To:     ma.IDAddress,
+			Value:  big.Zero(),
+			Method: builtin.MethodsMiner.PreCommitSectorBatch,
+			Params: &miner.PreCommitSectorBatchParams{Sectors: batchParams[:size]},
+		})
+		batchParams = batchParams[size:]
+	}
+	return messages, nil
+}
+
+// create PreCommit parameters and activation trigger
+func (ma *MinerAgent) createPreCommit(s SimState, currentEpoch abi.ChainEpoch) (*miner.PreCommitSectorParams, error) {
 	// go ahead and choose when we're going to activate this sector
 	sectorActivation := ma.sectorActivation(currentEpoch)
 	sectorNumber := ma.nextSectorNumber
@@ -258,7 +369,7 @@ func (ma *MinerAgent) createPreCommit(s SimState, currentEpoch abi.ChainEpoch) (
 		// // prevent sim from attempting to upgrade to sector with shorter duration
 		// sinfo, err := ma.sectorInfo(s, upgradeNumber)
 		// if err != nil {
-		// 	return message{}, err
+		// 	return nil, err
 		// }
 		// if sinfo.Expiration() > expiration {
 		// 	params.Expiration = sinfo.Expiration()
@@ -266,7 +377,7 @@ func (ma *MinerAgent) createPreCommit(s SimState, currentEpoch abi.ChainEpoch) (
 
 		// dlInfo, pIdx, err := ma.dlInfoForSector(s, upgradeNumber)
 		// if err != nil {
-		// 	return message{}, err
+		// 	return nil, err
 		// }
 
 		// params.ReplaceCapacity = true
@@ -284,13 +395,7 @@ func (ma *MinerAgent) createPreCommit(s SimState, currentEpoch abi.ChainEpoch) (
 		upgrade:           isUpgrade,
 	})
 
-	return message{
-		From:   ma.Worker,
-		To:     ma.IDAddress,
-		Value:  big.Zero(),
-		Method: builtin.MethodsMiner.PreCommitSector,
-		Params: &params,
-	}, nil
+	return &params, nil
 }
 
 // create prove commit message
@@ -327,7 +432,6 @@ func (ma *MinerAgent) createFault(v SimState) ([]message, error) {
 	// choose a live sector to go faulty
 	var faultNumber uint64
 	faultNumber, ma.liveSectors = PopRandom(ma.liveSectors, ma.rnd)
-	ma.faultySectors = append(ma.faultySectors, faultNumber)
 
 	// avoid trying to upgrade a faulty sector
 	ma.ccSectors = filterSlice(ma.ccSectors, map[uint64]bool{faultNumber: true})
@@ -343,6 +447,7 @@ func (ma *MinerAgent) createFault(v SimState) ([]message, error) {
 			faultNumber, faultDlInfo.Index, pIdx)
 	}
 	parts[pIdx].faults.Set(faultNumber)
+	ma.trackFault(v.GetEpoch(), faultDlInfo.Index, pIdx, faultNumber)
 
 	// If it's too late, skip fault rather than declaring it
 	if faultDlInfo.FaultCutoffPassed() {
@@ -368,6 +473,69 @@ func (ma *MinerAgent) createFault(v SimState) ([]message, error) {
 	}}, nil
 }
 
+// Fault all live sectors in a partition, modelling an outage of the storage backing it.
+// This chooses a partition at random and then either declares the faults or skips the sectors in the next PoSt.
+func (ma *MinerAgent) createPartitionOutage(v SimState) ([]message, error) {
+	// opt out if no partitions
+	count := ma.partitionCount()
+	if count == 0 {
+		return nil, nil
+	}
+
+	// choose a partition to go offline
+	choice := ma.rnd.Intn(count)
+	dlIdx := uint64(0)
+	for choice >= len(ma.deadlines[dlIdx]) {
+		choice -= len(ma.deadlines[dlIdx])
+		dlIdx++
+	}
+	pIdx := uint64(choice)
+	part := &ma.deadlines[dlIdx][pIdx]
+
+	live, err := bitfield.SubtractBitField(part.sectors, part.faults)
+	if err != nil {
+		return nil, err
+	}
+	liveNos, err := live.All(uint64(ma.nextSectorNumber))
+	if err != nil {
+		return nil, err
+	}
+	if len(liveNos) == 0 {
+		return nil, nil
+	}
+	ma.PartitionOutages++
+
+	if err := ma.faultSectors(v.GetEpoch(), dlIdx, pIdx, live, liveNos); err != nil {
+		return nil, err
+	}
+
+	// If it's too late, skip faults rather than declaring them
+	dlInfo, err := ma.dlInfoForDeadline(v, dlIdx)
+	if err != nil {
+		return nil, err
+	}
+	if dlInfo.FaultCutoffPassed() {
+		part.toBeSkipped, err = bitfield.MergeBitFields(part.toBeSkipped, live)
+		return nil, err
+	}
+
+	faultParams := miner.DeclareFaultsParams{
+		Faults: []miner.FaultDeclaration{{
+			Deadline:  dlIdx,
+			Partition: pIdx,
+			Sectors:   live,
+		}},
+	}
+
+	return []message{{
+		From:   ma.Worker,
+		To:     ma.IDAddress,
+		Value:  big.Zero(),
+		Method: builtin.MethodsMiner.DeclareFaults,
+		Params: &faultParams,
+	}}, nil
+}
+
 // Recover a sector.
 // This chooses a sector from faulty sectors and then either declare the recovery or schedule one for later
 func (ma *MinerAgent) createRecovery(v SimState) ([]message, error) {
@@ -439,6 +607,16 @@ func (ma *MinerAgent) submitPoStForDeadline(v SimState, dlIdx uint64) ([]message
 		return nil, nil
 	}
 
+	// Miss the PoSt altogether with the configured probability.
+	// The sectors of its partitions will be detected faulty at the end of the deadline.
+	if ma.Config.MissedPoStProbability > 0 && ma.rnd.Float64() < ma.Config.MissedPoStProbability {
+		for _, p := range partitions {
+			ma.deadlines[dlIdx][p.Index].missedPoSt = true
+		}
+		ma.MissedPoSts++
+		return nil, nil
+	}
+
 	postProofType, err := ma.Config.ProofType.RegisteredWindowPoStProof()
 	if err != nil {
 		return nil, err
@@ -522,6 +700,191 @@ func (ma *MinerAgent) updateMarketBalance() []message {
 	}}
 }
 
+// Consult the strategy on extending or terminating each sector of a deadline that has just closed, while
+// the deadline can be modified.
+func (ma *MinerAgent) manageSectors(s SimState, dlIdx uint64) ([]message, error) {
+	if len(ma.deadlines[dlIdx]) == 0 {
+		return nil, nil
+	}
+	mSt, err := s.MinerState(ma.IDAddress)
+	if err != nil {
+		return nil, err
+	}
+	dl, err := mSt.LoadDeadlineState(s.Store(), dlIdx)
+	if err != nil {
+		return nil, err
+	}
+	maxLifetime, err := builtin.SealProofSectorMaximumLifetime(ma.Config.ProofType)
+	if err != nil {
+		return nil, err
+	}
+	canExtend := miner.CanExtendSealProofType(ma.Config.ProofType)
+	epoch := s.GetEpoch()
+
+	var extensions []miner.ExpirationExtension
+	var extensionCounts []uint64
+	var terminations []miner.TerminationDeclaration
+	var terminationCounts []uint64
+	terminated := map[uint64]bool{}
+	for pIdx := range ma.deadlines[dlIdx] {
+		part := &ma.deadlines[dlIdx][pIdx]
+		sectorNos, err := part.sectors.All(uint64(ma.nextSectorNumber))
+		if err != nil {
+			return nil, err
+		}
+		if len(sectorNos) == 0 {
+			continue
+		}
+		partState, err := dl.LoadPartition(s.Store(), uint64(pIdx))
+		if err != nil {
+			return nil, err
+		}
+		active, err := partState.ActiveSectors()
+		if err != nil {
+			return nil, err
+		}
+
+		var toTerminate []uint64
+		toExtend := map[abi.ChainEpoch][]uint64{}
+		var newExpirations []abi.ChainEpoch
+		for _, sectorNo := range sectorNos {
+			info, err := mSt.LoadSectorInfo(s.Store(), sectorNo)
+			if err != nil {
+				return nil, err
+			}
+			faulty, err := part.faults.IsSet(sectorNo)
+			if err != nil {
+				return nil, err
+			}
+			sector := StrategySector{
+				Number:     abi.SectorNumber(sectorNo),
+				Activation: info.Activation(),
+				Expiration: info.Expiration(),
+				Faulty:     faulty,
+			}
+
+			if ma.strategy().TerminateSector(epoch, sector) {
+				toTerminate = append(toTerminate, sectorNo)
+				terminated[sectorNo] = true
+				continue
+			}
+
+			// extend only to a valid expiration
+			newExpiration := ma.strategy().SectorExpiration(epoch, sector)
+			if newExpiration > epoch+miner.MaxSectorExpirationExtension {
+				newExpiration = epoch + miner.MaxSectorExpirationExtension
+			}
+			if newExpiration > sector.Activation+maxLifetime {
+				newExpiration = sector.Activation + maxLifetime
+			}
+			if !canExtend || newExpiration <= sector.Expiration || sector.Expiration < epoch {
+				continue
+			}
+			if isActive, err := active.IsSet(sectorNo); err != nil {
+				return nil, err
+			} else if !isActive || faulty {
+				continue
+			}
+			if _, ok := toExtend[newExpiration]; !ok {
+				newExpirations = append(newExpirations, newExpiration)
+			}
+			toExtend[newExpiration] = append(toExtend[newExpiration], sectorNo)
+		}
+
+		if len(toTerminate) > 0 {
+			sectors := bitfield.NewFromSet(toTerminate)
+			terminations = append(terminations, miner.TerminationDeclaration{
+				Deadline:  dlIdx,
+				Partition: uint64(pIdx),
+				Sectors:   sectors,
+			})
+			terminationCounts = append(terminationCounts, uint64(len(toTerminate)))
+
+			// assume termination succeeds
+			if err := part.expireSectors(sectors); err != nil {
+				return nil, err
+			}
+		}
+		for _, newExpiration := range newExpirations {
+			extensions = append(extensions, miner.ExpirationExtension{
+				Deadline:      dlIdx,
+				Partition:     uint64(pIdx),
+				Sectors:       bitfield.NewFromSet(toExtend[newExpiration]),
+				NewExpiration: newExpiration,
+			})
+			extensionCounts = append(extensionCounts, uint64(len(toExtend[newExpiration])))
+			ma.ExtendedSectors += uint64(len(toExtend[newExpiration]))
+		}
+	}
+
+	// remove terminated sectors from miner agent state to prevent choosing them in the future.
+	if len(terminated) > 0 {
+		ma.liveSectors = filterSlice(ma.liveSectors, terminated)
+		ma.faultySectors = filterSlice(ma.faultySectors, terminated)
+		ma.ccSectors = filterSlice(ma.ccSectors, terminated)
+		ma.TerminatedSectors += uint64(len(terminated))
+	}
+
+	var messages []message
+	start := 0
+	for _, end := range declarationBatches(terminationCounts) {
+		messages = append(messages, message{
+			From:   ma.Worker,
+			To:     ma.IDAddress,
+			Value:  big.Zero(),
+			Method: builtin.MethodsMiner.TerminateSectors,
+			Params: &miner.TerminateSectorsParams{Terminations: terminations[start:end]},
+		})
+		start = end
+	}
+	start = 0
+	for _, end := range declarationBatches(extensionCounts) {
+		messages = append(messages, message{
+			From:   ma.Worker,
+			To:     ma.IDAddress,
+			Value:  big.Zero(),
+			Method: builtin.MethodsMiner.ExtendSectorExpiration,
+			Params: &miner.ExtendSectorExpirationParams{Extensions: extensions[start:end]},
+		})
+		start = end
+	}
+	return messages, nil
+}
+
+// Withdraw as much of the miner's available balance as the strategy decides, and schedule the next decision.
+func (ma *MinerAgent) withdrawBalance(s SimState) ([]message, error) {
+	ma.nextWithdrawal = s.GetEpoch() + miner.WPoStProvingPeriod
+
+	mSt, err := s.MinerState(ma.IDAddress)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.ActorBalance(ma.IDAddress)
+	if err != nil {
+		return nil, err
+	}
+	available, err := mSt.AvailableBalance(s.Store(), balance)
+	if err != nil {
+		return nil, err
+	}
+	if available.LessThanEqual(big.Zero()) {
+		return nil, nil
+	}
+
+	amount := big.Min(ma.strategy().Withdrawal(s.GetEpoch(), available), available)
+	if amount.LessThanEqual(big.Zero()) {
+		return nil, nil
+	}
+
+	return []message{{
+		From:   ma.Owner,
+		To:     ma.IDAddress,
+		Value:  big.Zero(),
+		Method: builtin.MethodsMiner.WithdrawBalance,
+		Params: &miner.WithdrawBalanceParams{AmountRequested: amount},
+	}}, nil
+}
+
 ////////////////////////////////////////////////
 //
 //  Misc methods
@@ -723,9 +1086,110 @@ func (ma *MinerAgent) syncMinerState(s SimState, dlIdx uint64) error {
 		ma.faultySectors = filterSlice(ma.faultySectors, toRemove)
 		ma.ccSectors = filterSlice(ma.ccSectors, toRemove)
 	}
+
+	// all remaining live sectors in partitions that missed their PoSt have been detected faulty
+	for pIdx := range ma.deadlines[dlIdx] {
+		part := &ma.deadlines[dlIdx][pIdx]
+		if !part.missedPoSt {
+			continue
+		}
+		part.missedPoSt = false
+
+		detected, err := bitfield.SubtractBitField(part.sectors, part.faults)
+		if err != nil {
+			return err
+		}
+		detectedNos, err := detected.All(uint64(ma.nextSectorNumber))
+		if err != nil {
+			return err
+		}
+		if err := ma.faultSectors(s.GetEpoch(), dlIdx, uint64(pIdx), detected, detectedNos); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Marks live sectors of a partition faulty.
+func (ma *MinerAgent) faultSectors(epoch abi.ChainEpoch, dlIdx, pIdx uint64, sectors bitfield.BitField, sectorNos []uint64) error {
+	if len(sectorNos) == 0 {
+		return nil
+	}
+	part := &ma.deadlines[dlIdx][pIdx]
+	var err error
+	part.faults, err = bitfield.MergeBitFields(part.faults, sectors)
+	if err != nil {
+		return err
+	}
+
+	faulted := make(map[uint64]bool, len(sectorNos))
+	for _, sectorNo := range sectorNos {
+		faulted[sectorNo] = true
+		ma.trackFault(epoch, dlIdx, pIdx, sectorNo)
+	}
+	ma.liveSectors = filterSlice(ma.liveSectors, faulted)
+	// avoid trying to upgrade a faulty sector
+	ma.ccSectors = filterSlice(ma.ccSectors, faulted)
+	return nil
+}
+
+// Tracks a new fault, which may be recovered once the configured recovery delay has passed.
+func (ma *MinerAgent) trackFault(epoch abi.ChainEpoch, dlIdx, pIdx, sectorNo uint64) {
+	if ma.Config.RecoveryDelay <= 0 {
+		ma.faultySectors = append(ma.faultySectors, sectorNo)
+		return
+	}
+	ma.operationSchedule.ScheduleOp(epoch+ma.Config.RecoveryDelay, recoverableFaultAction{
+		dlIdx:        dlIdx,
+		pIdx:         pIdx,
+		sectorNumber: abi.SectorNumber(sectorNo),
+	})
+}
+
+// Makes a fault available for recovery, unless the sector has since expired.
+func (ma *MinerAgent) makeFaultRecoverable(dlIdx, pIdx uint64, sectorNumber abi.SectorNumber) error {
+	if faulty, err := ma.deadlines[dlIdx][pIdx].faults.IsSet(uint64(sectorNumber)); err != nil {
+		return err
+	} else if faulty {
+		ma.faultySectors = append(ma.faultySectors, uint64(sectorNumber))
+	}
+	return nil
+}
+
+func (ma *MinerAgent) partitionCount() int {
+	count := 0
+	for _, parts := range ma.deadlines {
+		count += len(parts)
+	}
+	return count
+}
+
+func (ma *MinerAgent) strategy() MinerStrategy {
+	if ma.Config.Strategy == nil {
+		return ImmediateStrategy{}
+	}
+	return ma.Config.Strategy
+}
+
+// Splits declarations, each addressing a number of sectors, into messages within the limits on declarations and
+// sectors per message. Returns the end index of each message's declarations.
+func declarationBatches(sectorCounts []uint64) []int {
+	var ends []int
+	start := 0
+	var sectors uint64
+	for i, count := range sectorCounts {
+		if i > start && (uint64(i-start) >= miner.DeclarationsMax || sectors+count > miner.AddressedSectorsMax) {
+			ends = append(ends, i)
+			start, sectors = i, 0
+		}
+		sectors += count
+	}
+	if start < len(sectorCounts) {
+		ends = append(ends, len(sectorCounts))
+	}
+	return ends
+}
+
 func filterSlice(ns []uint64, toRemove map[uint64]bool) []uint64 {
 	var nextLive []uint64
 	for _, sn := range ns {
@@ -761,14 +1225,27 @@ func (ma *MinerAgent) dlInfoForSector(v SimState, sectorNumber uint64) (*dline.I
 		return nil, 0, err
 	}
 
-	dlInfo, err := mSt.DeadlineInfo(v.Store(), v.GetEpoch())
+	sectorDLInfo, err := ma.dlInfoForDeadline(v, dlIdx)
 	if err != nil {
 		return nil, 0, err
 	}
-	sectorDLInfo := miner.NewDeadlineInfo(dlInfo.PeriodStart, dlIdx, v.GetEpoch()).NextNotElapsed()
 	return sectorDLInfo, pIdx, nil
 }
 
+// returns the current or next instance of a deadline
+func (ma *MinerAgent) dlInfoForDeadline(v SimState, dlIdx uint64) (*dline.Info, error) {
+	mSt, err := v.MinerState(ma.IDAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	dlInfo, err := mSt.DeadlineInfo(v.Store(), v.GetEpoch())
+	if err != nil {
+		return nil, err
+	}
+	return miner.NewDeadlineInfo(dlInfo.PeriodStart, dlIdx, v.GetEpoch()).NextNotElapsed(), nil
+}
+
 // create a random valid sector expiration
 func (ma *MinerAgent) sectorExpiration(currentEpoch abi.ChainEpoch) abi.ChainEpoch {
 	// Require sector lifetime meets minimum by assuming activation happens at last epoch permitted for seal proof
@@ -821,6 +1298,7 @@ type partition struct {
 	toBeSkipped bitfield.BitField // sector numbers of sectors to be skipped next PoSt
 	faults      bitfield.BitField // sector numbers of sectors believed to be faulty
 	expired     bitfield.BitField // sector number of sectors believed to have expired
+	missedPoSt  bool              // true if the PoSt for the partition's deadline was missed, until the deadline ends
 }
 
 func (part *partition) expireSectors(newExpired bitfield.BitField) error {
@@ -867,6 +1345,12 @@ type recoverSectorAction struct {
 	sectorNumber abi.SectorNumber
 }
 
+type recoverableFaultAction struct {
+	dlIdx        uint64
+	pIdx         uint64
+	sectorNumber abi.SectorNumber
+}
+
 type proveDeadlineAction struct {
 	dlIdx uint64
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/dline"
 	miner4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/miner"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
 	miner5 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
@@ -83,6 +84,14 @@ func (m *MinerStateV4) FeeDebt(store adt.Store) (abi.TokenAmount, error) {
 	return st.FeeDebt, nil
 }
 
+func (m *MinerStateV4) AvailableBalance(store adt.Store, actorBalance abi.TokenAmount) (abi.TokenAmount, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return big.Zero(), err
+	}
+	return st.GetAvailableBalance(actorBalance)
+}
+
 func (m *MinerStateV4) LoadDeadlineState(store adt.Store, dlIdx uint64) (SimDeadlineState, error) {
 	st, err := m.state(store)
 	if err != nil {
@@ -119,6 +128,10 @@ func (p *PartitionStateV4) Terminated() bitfield.BitField {
 	return p.partition.Terminated
 }
 
+func (p *PartitionStateV4) ActiveSectors() (bitfield.BitField, error) {
+	return p.partition.ActiveSectors()
+}
+
 type SectorInfoV4 struct {
 	info *miner4.SectorOnChainInfo
 }
@@ -127,6 +140,140 @@ func (s *SectorInfoV4) Expiration() abi.ChainEpoch {
 	return s.info.Expiration
 }
 
+func (s *SectorInfoV4) Activation() abi.ChainEpoch {
+	return s.info.Activation
+}
+
+type MinerStateV6 struct {
+	Root cid.Cid
+	Ctx  context.Context
+	st   *miner6.State
+}
+
+func (m *MinerStateV6) state(store adt.Store) (*miner6.State, error) {
+	if m.st == nil {
+		var st miner6.State
+		err := store.Get(m.Ctx, m.Root, &st)
+		if err != nil {
+			return nil, err
+		}
+		m.st = &st
+	}
+	return m.st, nil
+}
+
+func (m *MinerStateV6) HasSectorNo(store adt.Store, sectorNo abi.SectorNumber) (bool, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return false, err
+	}
+	return st.HasSectorNo(store, sectorNo)
+}
+
+func (m *MinerStateV6) FindSector(store adt.Store, sectorNo abi.SectorNumber) (uint64, uint64, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return 0, 0, err
+	}
+	return st.FindSector(store, sectorNo)
+}
+
+func (m *MinerStateV6) ProvingPeriodStart(store adt.Store) (abi.ChainEpoch, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return 0, err
+	}
+	return st.ProvingPeriodStart, nil
+}
+
+func (m *MinerStateV6) LoadSectorInfo(store adt.Store, sectorNo uint64) (SimSectorInfo, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return nil, err
+	}
+	sectors, err := st.LoadSectorInfos(store, bitfield.NewFromSet([]uint64{uint64(sectorNo)}))
+	if err != nil {
+		return nil, err
+	}
+	return &SectorInfoV6{info: sectors[0]}, nil
+}
+
+func (m *MinerStateV6) DeadlineInfo(store adt.Store, currEpoch abi.ChainEpoch) (*dline.Info, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return nil, err
+	}
+	return st.DeadlineInfo(currEpoch), nil
+}
+
+func (m *MinerStateV6) FeeDebt(store adt.Store) (abi.TokenAmount, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return big.Zero(), err
+	}
+	return st.FeeDebt, nil
+}
+
+func (m *MinerStateV6) AvailableBalance(store adt.Store, actorBalance abi.TokenAmount) (abi.TokenAmount, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return big.Zero(), err
+	}
+	return st.GetAvailableBalance(actorBalance)
+}
+
+func (m *MinerStateV6) LoadDeadlineState(store adt.Store, dlIdx uint64) (SimDeadlineState, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return nil, err
+	}
+	dls, err := st.LoadDeadlines(store)
+	if err != nil {
+		return nil, err
+	}
+	dline, err := dls.LoadDeadline(store, dlIdx)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadlineStateV6{deadline: dline}, nil
+}
+
+type DeadlineStateV6 struct {
+	deadline *miner6.Deadline
+}
+
+func (d *DeadlineStateV6) LoadPartition(store adt.Store, partIdx uint64) (SimPartitionState, error) {
+	part, err := d.deadline.LoadPartition(store, partIdx)
+	if err != nil {
+		return nil, err
+	}
+	return &PartitionStateV6{partition: part}, nil
+}
+
+type PartitionStateV6 struct {
+	partition *miner6.Partition
+}
+
+func (p *PartitionStateV6) Terminated() bitfield.BitField {
+	return p.partition.Terminated
+}
+
+func (p *PartitionStateV6) ActiveSectors() (bitfield.BitField, error) {
+	return p.partition.ActiveSectors()
+}
+
+type SectorInfoV6 struct {
+	info *miner6.SectorOnChainInfo
+}
+
+func (s *SectorInfoV6) Expiration() abi.ChainEpoch {
+	return s.info.Expiration
+}
+
+func (s *SectorInfoV6) Activation() abi.ChainEpoch {
+	return s.info.Activation
+}
+
 type MinerStateV5 struct {
 	Root cid.Cid
 	st   *miner5.State
@@ -197,6 +344,14 @@ func (m *MinerStateV5) FeeDebt(store adt.Store) (abi.TokenAmount, error) {
 	return st.FeeDebt, nil
 }
 
+func (m *MinerStateV5) AvailableBalance(store adt.Store, actorBalance abi.TokenAmount) (abi.TokenAmount, error) {
+	st, err := m.state(store)
+	if err != nil {
+		return big.Zero(), err
+	}
+	return st.GetAvailableBalance(actorBalance)
+}
+
 func (m *MinerStateV5) LoadDeadlineState(store adt.Store, dlIdx uint64) (SimDeadlineState, error) {
 	st, err := m.state(store)
 	if err != nil {
@@ -233,6 +388,10 @@ func (p *PartitionStateV5) Terminated() bitfield.BitField {
 	return p.partition.Terminated
 }
 
+func (p *PartitionStateV5) ActiveSectors() (bitfield.BitField, error) {
+	return p.partition.ActiveSectors()
+}
+
 type SectorInfoV5 struct {
 	info *miner5.SectorOnChainInfo
 }
@@ -240,3 +399,7 @@ type SectorInfoV5 struct {
 func (s *SectorInfoV5) Expiration() abi.ChainEpoch {
 	return s.info.Expiration
 }
+
+func (s *SectorInfoV5) Activation() abi.ChainEpoch {
+	return s.info.Activation
+}
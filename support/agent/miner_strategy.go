@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// Makes the economic decisions of a miner agent: when to send its pre-commits, which of its sectors to extend or
+// terminate early, and how much of its balance to withdraw. The agent takes care of turning decisions into valid
+// messages, so researchers can compare policies by plugging in their own strategy without changing the agent.
+// A miner configured without a strategy behaves as with ImmediateStrategy.
+type MinerStrategy interface {
+	// Decides how many of the miner's ready pre-commits to send at an epoch, and whether to send them together in
+	// PreCommitSectorBatch messages rather than a PreCommitSector message each. The oldest of them became ready at readySince.
+	PreCommits(epoch abi.ChainEpoch, ready int, readySince abi.ChainEpoch) (count int, batch bool)
+
+	// Decides a sector's expiration, once per proving period after its deadline closes.
+	// An epoch after the sector's current expiration extends it, up to the maximum permitted.
+	// Faulty and unproven sectors are not extended.
+	SectorExpiration(epoch abi.ChainEpoch, sector StrategySector) abi.ChainEpoch
+
+	// Decides whether to terminate a sector early, once per proving period after its deadline closes.
+	TerminateSector(epoch abi.ChainEpoch, sector StrategySector) bool
+
+	// Decides how much to withdraw from the miner's positive available balance, once per proving period.
+	Withdrawal(epoch abi.ChainEpoch, available abi.TokenAmount) abi.TokenAmount
+}
+
+// A miner's sector, as presented to its strategy.
+type StrategySector struct {
+	Number     abi.SectorNumber
+	Activation abi.ChainEpoch
+	Expiration abi.ChainEpoch
+	Faulty     bool // true if the miner believes the sector to be faulty
+}
+
+// Sends each pre-commit in its own message as soon as it is ready, and leaves sectors and balance alone.
+type ImmediateStrategy struct{}
+
+var _ MinerStrategy = ImmediateStrategy{}
+
+func (ImmediateStrategy) PreCommits(_ abi.ChainEpoch, ready int, _ abi.ChainEpoch) (int, bool) {
+	return ready, false
+}
+
+func (ImmediateStrategy) SectorExpiration(_ abi.ChainEpoch, sector StrategySector) abi.ChainEpoch {
+	return sector.Expiration
+}
+
+func (ImmediateStrategy) TerminateSector(abi.ChainEpoch, StrategySector) bool {
+	return false
+}
+
+func (ImmediateStrategy) Withdrawal(abi.ChainEpoch, abi.TokenAmount) abi.TokenAmount {
+	return big.Zero()
+}
+
+// Batches pre-commits, renews sectors approaching expiration, gives up on faulty sectors and takes a share of the
+// miner's available balance as profit. Each behaviour is off at the zero value of its parameters.
+type BatchingStrategy struct {
+	BatchSize        int            // number of ready pre-commits to send together in a batch
+	MaxBatchDelay    abi.ChainEpoch // epochs after which ready pre-commits are sent without waiting for a full batch
+	ExtendWithin     abi.ChainEpoch // sectors expiring within this many epochs are extended
+	ExtendBy         abi.ChainEpoch // epochs by which sectors are extended
+	TerminateFaulty  bool           // if true, faulty sectors are terminated rather than left to recover
+	WithdrawFraction float64        // fraction of the available balance withdrawn each proving period
+}
+
+var _ MinerStrategy = (*BatchingStrategy)(nil)
+
+func (bs *BatchingStrategy) PreCommits(epoch abi.ChainEpoch, ready int, readySince abi.ChainEpoch) (int, bool) {
+	if ready >= bs.BatchSize || epoch-readySince >= bs.MaxBatchDelay {
+		return ready, true
+	}
+	return 0, true
+}
+
+func (bs *BatchingStrategy) SectorExpiration(epoch abi.ChainEpoch, sector StrategySector) abi.ChainEpoch {
+	if sector.Expiration-epoch <= bs.ExtendWithin {
+		return sector.Expiration + bs.ExtendBy
+	}
+	return sector.Expiration
+}
+
+func (bs *BatchingStrategy) TerminateSector(_ abi.ChainEpoch, sector StrategySector) bool {
+	return bs.TerminateFaulty && sector.Faulty
+}
+
+func (bs *BatchingStrategy) Withdrawal(_ abi.ChainEpoch, available abi.TokenAmount) abi.TokenAmount {
+	// fraction in parts per million to stay in integer arithmetic
+	ppm := int64(bs.WithdrawFraction * 1_000_000)
+	return big.Div(big.Mul(available, big.NewInt(ppm)), big.NewInt(1_000_000))
+}
@@ -48,6 +48,9 @@ type Sim struct {
 	MessageCount  uint64
 
 	v                 SimVM
+	reporters         []Reporter
+	lastBurnt         abi.TokenAmount // burnt funds at the end of the last epoch reported
+	upgrades          map[abi.ChainEpoch]NetworkUpgrade
 	vmFactory         VMFactoryFunc
 	minerStateFactory func(context.Context, cid.Cid) (SimMinerState, error)
 	rnd               *rand.Rand
@@ -64,23 +67,14 @@ func NewSim(ctx context.Context, t testing.TB, blockstoreFactory func() ipldcbor
 	blkStore := blockstoreFactory()
 	metrics := ipld.NewMetricsBlockStore(blkStore)
 	v := vm.NewVMWithSingletons(ctx, t, metrics)
-	vmFactory := func(ctx context.Context, impl vm2.ActorImplLookup, store adt.Store, stateRoot cid.Cid, epoch abi.ChainEpoch) (SimVM, error) {
-		return vm.NewVMAtEpoch(ctx, vm.ActorImplLookup(impl), store, stateRoot, epoch)
-	}
 	v.SetStatsSource(metrics)
-	minerStateFactory := func(ctx context.Context, root cid.Cid) (SimMinerState, error) {
-		return &MinerStateV5{
-			Ctx:  ctx,
-			Root: root,
-		}, nil
-	}
 	return &Sim{
 		Config:            config,
 		Agents:            []Agent{},
 		DealProviders:     []DealProvider{},
 		v:                 v,
-		vmFactory:         vmFactory,
-		minerStateFactory: minerStateFactory,
+		vmFactory:         v7VMFactory,
+		minerStateFactory: v7MinerStateFactory,
 		rnd:               rand.New(rand.NewSource(config.Seed)),
 		blkStore:          blkStore,
 		blkStoreFactory:   blockstoreFactory,
@@ -111,6 +105,17 @@ func NewSimWithVM(ctx context.Context, t testing.TB, v SimVM, vmFactory VMFactor
 	}
 }
 
+func v7VMFactory(ctx context.Context, impl vm2.ActorImplLookup, store adt.Store, stateRoot cid.Cid, epoch abi.ChainEpoch) (SimVM, error) {
+	return vm.NewVMAtEpoch(ctx, vm.ActorImplLookup(impl), store, stateRoot, epoch)
+}
+
+func v7MinerStateFactory(ctx context.Context, root cid.Cid) (SimMinerState, error) {
+	return &MinerStateV5{
+		Ctx:  ctx,
+		Root: root,
+	}, nil
+}
+
 func (s *Sim) SwapVM(v SimVM, vmFactory VMFactoryFunc, minerStateFactory func(context.Context, cid.Cid) (SimMinerState, error),
 ) {
 	s.v = v
@@ -198,11 +203,29 @@ func (s *Sim) Tick() error {
 	// store last stats
 	s.statsByMethod = s.v.GetCallStats()
 
+	// report metrics
+	if len(s.reporters) > 0 {
+		metrics, err := s.collectMetrics(len(blockMessages))
+		if err != nil {
+			return err
+		}
+		for _, r := range s.reporters {
+			if err := r.Report(metrics); err != nil {
+				return xerrors.Errorf("failed to report metrics at epoch %d: %w", metrics.Epoch, err)
+			}
+		}
+	}
+
 	// dump logs if we have them
 	if len(s.v.GetLogs()) > 0 {
 		fmt.Printf("%s\n", strings.Join(s.v.GetLogs(), "\n"))
 	}
 
+	// migrate state for a network upgrade after this epoch, which creates the next vm
+	if upgrade, ok := s.upgrades[s.v.GetEpoch()]; ok {
+		return s.upgrade(upgrade)
+	}
+
 	// create next vm
 	nextEpoch := s.v.GetEpoch() + 1
 	if s.Config.CheckpointEpochs > 0 && uint64(nextEpoch)%s.Config.CheckpointEpochs == 0 {
@@ -262,6 +285,17 @@ func (s *Sim) MinerState(addr address.Address) (SimMinerState, error) {
 	return s.minerStateFactory(s.ctx, act.Head)
 }
 
+func (s *Sim) ActorBalance(addr address.Address) (abi.TokenAmount, error) {
+	act, found, err := s.v.GetActor(addr)
+	if err != nil {
+		return big.Zero(), err
+	}
+	if !found {
+		return big.Zero(), xerrors.Errorf("actor %s not found", addr)
+	}
+	return act.Balance, nil
+}
+
 func (s *Sim) AddAgent(a Agent) {
 	s.Agents = append(s.Agents, a)
 }
@@ -270,6 +304,11 @@ func (s *Sim) AddDealProvider(d DealProvider) {
 	s.DealProviders = append(s.DealProviders, d)
 }
 
+// Adds a reporter to receive metrics at the end of each epoch.
+func (s *Sim) AddReporter(r Reporter) {
+	s.reporters = append(s.reporters, r)
+}
+
 func (s *Sim) GetVM() SimVM {
 	return s.v
 }
@@ -399,6 +438,7 @@ type SimState interface {
 	AddDealProvider(d DealProvider)
 	NetworkCirculatingSupply() abi.TokenAmount
 	MinerState(addr address.Address) (SimMinerState, error)
+	ActorBalance(addr address.Address) (abi.TokenAmount, error)
 	CreateMinerParams(worker, owner address.Address, sealProof abi.RegisteredSealProof) (*power.CreateMinerParams, error)
 
 	// randomly select an agent capable of making deals.
@@ -474,11 +514,13 @@ type SimMinerState interface {
 	LoadSectorInfo(adt.Store, uint64) (SimSectorInfo, error)
 	DeadlineInfo(adt.Store, abi.ChainEpoch) (*dline.Info, error)
 	FeeDebt(adt.Store) (abi.TokenAmount, error)
+	AvailableBalance(adt.Store, abi.TokenAmount) (abi.TokenAmount, error)
 	LoadDeadlineState(adt.Store, uint64) (SimDeadlineState, error)
 }
 
 type SimSectorInfo interface {
 	Expiration() abi.ChainEpoch
+	Activation() abi.ChainEpoch
 }
 
 type SimDeadlineState interface {
@@ -487,4 +529,5 @@ type SimDeadlineState interface {
 
 type SimPartitionState interface {
 	Terminated() bitfield.BitField
+	ActiveSectors() (bitfield.BitField, error)
 }
@@ -0,0 +1,279 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	vm2 "github.com/filecoin-project/specs-actors/v2/support/vm"
+	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
+	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
+	reward6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/reward"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	"github.com/filecoin-project/specs-actors/v7/support/vm"
+)
+
+// A network upgrade scheduled in a simulation.
+// The state is migrated after cron at the upgrade epoch, and the simulation continues from the following epoch
+// with the VM and miner state of the new actors version. Agents keep their state across the upgrade.
+type NetworkUpgrade struct {
+	Epoch             abi.ChainEpoch                                        // last epoch run by the prior actors
+	Migrate           func(ctx context.Context, prior SimVM) (SimVM, error) // returns a VM over the migrated state at the following epoch
+	VMFactory         VMFactoryFunc                                         // creates VMs running the new actors
+	MinerStateFactory func(context.Context, cid.Cid) (SimMinerState, error) // reads miner state of the new actors
+}
+
+// Schedules a network upgrade at an epoch not yet run, at which no other upgrade is scheduled.
+func (s *Sim) ScheduleUpgrade(u NetworkUpgrade) error {
+	if u.Epoch < s.v.GetEpoch() {
+		return xerrors.Errorf("cannot schedule upgrade at epoch %d, simulation is at epoch %d", u.Epoch, s.v.GetEpoch())
+	}
+	if _, ok := s.upgrades[u.Epoch]; ok {
+		return xerrors.Errorf("upgrade already scheduled at epoch %d", u.Epoch)
+	}
+	if s.upgrades == nil {
+		s.upgrades = make(map[abi.ChainEpoch]NetworkUpgrade)
+	}
+	s.upgrades[u.Epoch] = u
+	return nil
+}
+
+// Migrates the state at the end of the current epoch and switches to the new actors for the next.
+func (s *Sim) upgrade(u NetworkUpgrade) error {
+	priorEpoch := s.v.GetEpoch()
+	statsSource := s.v.GetStatsSource()
+	next, err := u.Migrate(s.ctx, s.v)
+	if err != nil {
+		return xerrors.Errorf("network upgrade after epoch %d failed: %w", priorEpoch, err)
+	}
+	if next.GetEpoch() != priorEpoch+1 {
+		return xerrors.Errorf("network upgrade after epoch %d resumed at epoch %d", priorEpoch, next.GetEpoch())
+	}
+	next.SetStatsSource(statsSource)
+	s.SwapVM(next, u.VMFactory, u.MinerStateFactory)
+	delete(s.upgrades, priorEpoch)
+	return nil
+}
+
+//
+// v6 actors
+//
+
+// Adapts a VM running v6 actors to run a simulation, e.g. until an upgrade to v7 actors.
+type SimVMV6 struct {
+	*vm6.VM
+}
+
+var _ SimVM = (*SimVMV6)(nil)
+
+// Applies a message with parameters of v7 types, returning a value of the v7 return type.
+func (v *SimVMV6) ApplyMessage(from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}, info string) (vm.MessageResult, error) {
+	params, err := v.convertParams(to, method, params)
+	if err != nil {
+		return vm.MessageResult{}, err
+	}
+	ret, err := v.VM.ApplyMessage(from, to, value, method, params, info)
+	if err != nil {
+		return vm.MessageResult{Ret: ret.Ret, Code: ret.Code, GasCharged: ret.GasCharged}, err
+	}
+	converted, err := v.convertReturn(to, method, ret.Ret)
+	return vm.MessageResult{Ret: converted, Code: ret.Code, GasCharged: ret.GasCharged}, err
+}
+
+// Re-encodes parameters of a v7 type as the parameter type of the method of the v6 actor receiving them.
+func (v *SimVMV6) convertParams(to address.Address, method abi.MethodNum, params interface{}) (interface{}, error) {
+	m, ok := params.(cbor.Marshaler)
+	if !ok {
+		return params, nil
+	}
+	actor, found, err := v.VM.GetActor(to)
+	if err != nil || !found {
+		return params, err
+	}
+	impl, ok := v.VM.GetActorImpls()[actor.Code]
+	if !ok || int(method) >= len(impl.Exports()) || impl.Exports()[method] == nil {
+		return params, nil
+	}
+	paramsType := reflect.TypeOf(impl.Exports()[method]).In(1)
+	if reflect.TypeOf(params) == paramsType {
+		// a type shared by both versions
+		return params, nil
+	}
+	out, ok := reflect.New(paramsType.Elem()).Interface().(cbor.Er)
+	if !ok {
+		return params, nil
+	}
+	var buf bytes.Buffer
+	if err := m.MarshalCBOR(&buf); err != nil {
+		return nil, xerrors.Errorf("failed to marshal params of method %d: %w", method, err)
+	}
+	if err := out.UnmarshalCBOR(&buf); err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal params of method %d as %v: %w", method, paramsType, err)
+	}
+	return out, nil
+}
+
+// Re-encodes the value returned by a v6 actor method as the return type of the method of the v7 actor.
+func (v *SimVMV6) convertReturn(to address.Address, method abi.MethodNum, ret cbor.Marshaler) (cbor.Marshaler, error) {
+	if ret == nil {
+		return nil, nil
+	}
+	actor, found, err := v.VM.GetActor(to)
+	if err != nil || !found {
+		return ret, err
+	}
+	impl, ok := v7ActorByCode[v7CodeByV6Code[actor.Code]]
+	if !ok || int(method) >= len(impl.Exports()) || impl.Exports()[method] == nil {
+		return ret, nil
+	}
+	retType := reflect.TypeOf(impl.Exports()[method]).Out(0)
+	if reflect.TypeOf(ret) == retType {
+		return ret, nil
+	}
+	out, ok := reflect.New(retType.Elem()).Interface().(cbor.Er)
+	if !ok {
+		return ret, nil
+	}
+	var buf bytes.Buffer
+	if err := ret.MarshalCBOR(&buf); err != nil {
+		return nil, xerrors.Errorf("failed to marshal return of method %d: %w", method, err)
+	}
+	if err := out.UnmarshalCBOR(&buf); err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal return of method %d as %v: %w", method, retType, err)
+	}
+	return out, nil
+}
+
+// The v7 actors by code CID.
+var v7ActorByCode = func() map[cid.Cid]runtime.VMActor {
+	actors := make(map[cid.Cid]runtime.VMActor)
+	for _, a := range exported.BuiltinActors() {
+		actors[a.Code()] = a
+	}
+	return actors
+}()
+
+// Code CIDs of the v7 actors by those of the v6 actors they are migrated from.
+var v7CodeByV6Code = map[cid.Cid]cid.Cid{
+	builtin6.AccountActorCodeID:          builtin.AccountActorCodeID,
+	builtin6.CronActorCodeID:             builtin.CronActorCodeID,
+	builtin6.InitActorCodeID:             builtin.InitActorCodeID,
+	builtin6.MultisigActorCodeID:         builtin.MultisigActorCodeID,
+	builtin6.PaymentChannelActorCodeID:   builtin.PaymentChannelActorCodeID,
+	builtin6.RewardActorCodeID:           builtin.RewardActorCodeID,
+	builtin6.StorageMarketActorCodeID:    builtin.StorageMarketActorCodeID,
+	builtin6.StorageMinerActorCodeID:     builtin.StorageMinerActorCodeID,
+	builtin6.StoragePowerActorCodeID:     builtin.StoragePowerActorCodeID,
+	builtin6.SystemActorCodeID:           builtin.SystemActorCodeID,
+	builtin6.VerifiedRegistryActorCodeID: builtin.VerifiedRegistryActorCodeID,
+}
+
+// Reads actor state as a VM running v7 actors would, converting reward and market state from their v6 form.
+// The market's balance tables keep their v6 encoding.
+func (v *SimVMV6) GetState(addr address.Address, out cbor.Unmarshaler) error {
+	switch o := out.(type) {
+	case *reward.State:
+		var st reward6.State
+		if err := v.VM.GetState(addr, &st); err != nil {
+			return err
+		}
+		*o = reward.State{
+			CumsumBaseline:          st.CumsumBaseline,
+			CumsumRealized:          st.CumsumRealized,
+			EffectiveNetworkTime:    st.EffectiveNetworkTime,
+			EffectiveBaselinePower:  st.EffectiveBaselinePower,
+			ThisEpochReward:         st.ThisEpochReward,
+			ThisEpochRewardSmoothed: st.ThisEpochRewardSmoothed,
+			ThisEpochBaselinePower:  st.ThisEpochBaselinePower,
+			Epoch:                   st.Epoch,
+			TotalStoragePowerReward: st.TotalStoragePowerReward,
+			SimpleTotal:             st.SimpleTotal,
+			BaselineTotal:           st.BaselineTotal,
+			TotalRecycledPenalties:  big.Zero(),
+		}
+		return nil
+	case *market.State:
+		var st market6.State
+		if err := v.VM.GetState(addr, &st); err != nil {
+			return err
+		}
+		*o = market.State{
+			Proposals:                     st.Proposals,
+			States:                        st.States,
+			PendingProposals:              st.PendingProposals,
+			EscrowTable:                   st.EscrowTable,
+			LockedTable:                   st.LockedTable,
+			NextID:                        st.NextID,
+			DealOpsByEpoch:                st.DealOpsByEpoch,
+			LastCron:                      st.LastCron,
+			TotalClientLockedCollateral:   st.TotalClientLockedCollateral,
+			TotalProviderLockedCollateral: st.TotalProviderLockedCollateral,
+			TotalClientStorageFee:         st.TotalClientStorageFee,
+		}
+		return nil
+	}
+	return v.VM.GetState(addr, out)
+}
+
+// Creates a simulation running v6 actors, which may be upgraded with UpgradeV6ToV7.
+func NewSimV6(ctx context.Context, t testing.TB, blockstoreFactory func() ipldcbor.IpldBlockstore, config SimConfig) *Sim {
+	blkStore := blockstoreFactory()
+	metrics := ipld.NewMetricsBlockStore(blkStore)
+	v := &SimVMV6{VM: vm6.NewVMWithSingletons(ctx, t, metrics)}
+	s := NewSimWithVM(ctx, t, v, v6VMFactory, blkStore, blockstoreFactory, v6MinerStateFactory, config)
+	// count the store operations of the VM
+	v.SetStatsSource(metrics)
+	return s
+}
+
+// Returns an upgrade from v6 to v7 actors by the nv15 migration, after an epoch.
+func UpgradeV6ToV7(epoch abi.ChainEpoch, cfg nv15.Config, log nv15.Logger) NetworkUpgrade {
+	return NetworkUpgrade{
+		Epoch: epoch,
+		Migrate: func(ctx context.Context, prior SimVM) (SimVM, error) {
+			v6, ok := prior.(*SimVMV6)
+			if !ok {
+				return nil, xerrors.Errorf("cannot migrate %T from v6 actors", prior)
+			}
+			next, err := vm.UpgradeFromV6(ctx, v6.VM, cfg, log)
+			if err != nil {
+				return nil, err
+			}
+			return next, nil
+		},
+		VMFactory:         v7VMFactory,
+		MinerStateFactory: v7MinerStateFactory,
+	}
+}
+
+func v6VMFactory(ctx context.Context, impl vm2.ActorImplLookup, store adt.Store, stateRoot cid.Cid, epoch abi.ChainEpoch) (SimVM, error) {
+	v, err := vm6.NewVMAtEpoch(ctx, vm6.ActorImplLookup(impl), store, stateRoot, epoch)
+	if err != nil {
+		return nil, err
+	}
+	return &SimVMV6{VM: v}, nil
+}
+
+func v6MinerStateFactory(ctx context.Context, root cid.Cid) (SimMinerState, error) {
+	return &MinerStateV6{
+		Ctx:  ctx,
+		Root: root,
+	}, nil
+}
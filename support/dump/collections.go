@@ -0,0 +1,150 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	adt0 "github.com/filecoin-project/specs-actors/actors/util/adt"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
+	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Returned from iteration callbacks to stop once the entry budget is spent.
+var errBudgetSpent = errors.New("entry budget spent")
+
+// An entry of a collection, with its key formatted for display and its value undecoded.
+type rawEntry struct {
+	key   string
+	value []byte
+}
+
+// Loads up to limit entries of the HAMT at root, in the format of an actors version.
+// Returns whether further entries were left unloaded.
+func loadHamt(store adt.Store, version int, root cid.Cid, l *layout, limit int) ([]rawEntry, bool, error) {
+	var forEach func(out *cbg.Deferred, fn func(key string) error) error
+	switch version {
+	case 0:
+		m, err := adt0.AsMap(store, root)
+		if err != nil {
+			return nil, false, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(string) error) error { return m.ForEach(out, fn) }
+	case 2:
+		m, err := adt2.AsMap(store, root)
+		if err != nil {
+			return nil, false, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(string) error) error { return m.ForEach(out, fn) }
+	default:
+		m, err := adt.AsMap(store, root, l.bitwidth)
+		if err != nil {
+			return nil, false, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(string) error) error { return m.ForEach(out, fn) }
+	}
+
+	// The value's buffer is reused for each entry, so entries copy it.
+	var entries []rawEntry
+	var value cbg.Deferred
+	more := false
+	err := forEach(&value, func(key string) error {
+		if len(entries) == limit {
+			more = true
+			return errBudgetSpent
+		}
+		entries = append(entries, rawEntry{key: formatKey(key, l.key), value: append([]byte(nil), value.Raw...)})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBudgetSpent) {
+		return nil, false, err
+	}
+	return entries, more, nil
+}
+
+// Loads up to limit entries of the AMT at root, in the format of an actors version.
+// Returns the number of entries in the AMT.
+func loadAmt(store adt.Store, version int, root cid.Cid, l *layout, limit int) ([]rawEntry, uint64, error) {
+	var forEach func(out *cbg.Deferred, fn func(i int64) error) error
+	var length uint64
+	switch version {
+	case 0:
+		a, err := adt0.AsArray(store, root)
+		if err != nil {
+			return nil, 0, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(int64) error) error { return a.ForEach(out, fn) }
+		length = a.Length()
+	case 2:
+		a, err := adt2.AsArray(store, root)
+		if err != nil {
+			return nil, 0, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(int64) error) error { return a.ForEach(out, fn) }
+		length = a.Length()
+	default:
+		a, err := adt.AsArray(store, root, l.bitwidth)
+		if err != nil {
+			return nil, 0, err
+		}
+		forEach = func(out *cbg.Deferred, fn func(int64) error) error { return a.ForEach(out, fn) }
+		length = a.Length()
+	}
+
+	var entries []rawEntry
+	var value cbg.Deferred
+	err := forEach(&value, func(i int64) error {
+		if len(entries) == limit {
+			return errBudgetSpent
+		}
+		entries = append(entries, rawEntry{key: formatInt(i), value: append([]byte(nil), value.Raw...)})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBudgetSpent) {
+		return nil, 0, err
+	}
+	return entries, length, nil
+}
+
+// Formats a HAMT key for display, falling back to hex if it doesn't parse in the expected format.
+func formatKey(key string, format keyFormat) string {
+	switch format {
+	case addrKey:
+		if a, err := addr.NewFromBytes([]byte(key)); err == nil {
+			return a.String()
+		}
+	case intKey:
+		if n, err := abi.ParseIntKey(key); err == nil {
+			return formatInt(n)
+		}
+	case uintKey:
+		if n, err := abi.ParseUIntKey(key); err == nil {
+			return formatUint(n)
+		}
+	case cidKey:
+		if c, err := cid.Cast([]byte(key)); err == nil {
+			return c.String()
+		}
+	case addrPairKey:
+		var pair abi.AddrPairKey
+		if err := pair.UnmarshalCBOR(bytes.NewReader([]byte(key))); err == nil {
+			return pair.First.String() + "," + pair.Second.String()
+		}
+	}
+	return "0x" + hex.EncodeToString([]byte(key))
+}
+
+// Decodes the root of a nested collection from a collection value.
+func decodeRoot(raw []byte) (cid.Cid, error) {
+	var root cbg.CborCid
+	if err := root.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to decode collection root: %w", err)
+	}
+	return cid.Cid(root), nil
+}
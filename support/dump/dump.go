@@ -0,0 +1,440 @@
+// Package dump renders the decoded state of builtin actors, of any supported actors version, as indented text
+// or JSON for debugging tools and test failure output.
+// The content of HAMTs, AMTs and other objects linked from the state is loaded and rendered along with it,
+// within a budget of depth and entries per collection.
+package dump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	cid "github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Limits on the content rendered.
+type Options struct {
+	// Number of levels of links to load below the rendered value. Links beyond this depth are rendered as CIDs.
+	MaxDepth int
+	// Number of entries of each collection, slice, map and bitfield to render. Further entries are elided.
+	MaxEntries int
+	// Number of bytes of each byte string to render. Further bytes are elided.
+	MaxBytes int
+}
+
+// Options suited to a terminal: the state and the first entries of the collections it links directly.
+var DefaultOptions = Options{
+	MaxDepth:   2,
+	MaxEntries: 10,
+	MaxBytes:   32,
+}
+
+// The shape of a rendered value.
+type Kind int
+
+const (
+	// A value formatted as a single string.
+	Scalar Kind = iota
+	// A struct, rendered as its exported fields in declaration order.
+	Struct
+	// A HAMT, AMT, slice, array or map, rendered as keyed entries.
+	Collection
+)
+
+// A rendered value.
+type Node struct {
+	Kind Kind
+	// The Go type of the value, or a description of a collection, e.g. "AMT[miner.SectorOnChainInfo]".
+	Type string
+	// The formatted value, for a scalar.
+	Value string
+	// The CID from which the value was loaded, or of the link that was not loaded, undefined if not linked.
+	Link cid.Cid
+	// The fields of a struct.
+	Fields []Field
+	// The rendered entries of a collection.
+	Entries []Entry
+	// The total number of entries of a collection, or -1 if unknown because not all were loaded.
+	Count int64
+	// Whether content was elided: entries beyond the entry budget, or a link beyond the depth budget.
+	Truncated bool
+	// An error loading or decoding the value.
+	Error string
+
+	// Whether the scalar is a number or boolean, rendered unquoted in JSON.
+	literal bool
+}
+
+type Field struct {
+	Name  string
+	Value *Node
+}
+
+type Entry struct {
+	Key   string
+	Value *Node
+}
+
+// Renders the state of a builtin actor of any supported actors version.
+func ActorState(store adt.Store, actor *states.Actor, opts Options) (*Node, error) {
+	view, err := states.LoadActorStateAny(store, actor)
+	if err != nil {
+		return nil, err
+	}
+	d := dumper{store: store, version: view.ActorsVersion(), opts: opts}
+	n := d.value(reflect.ValueOf(view.Raw()), 0)
+	n.Link = actor.Head
+	return n, nil
+}
+
+// Renders a decoded value, such as the state of an actor or a parameter or return value of a method.
+// CID fields of builtin actor state structures are followed, in the format of the actors version
+// from which the value's type comes.
+func Value(store adt.Store, v interface{}, opts Options) *Node {
+	d := dumper{store: store, version: typeVersion(reflect.TypeOf(v)), opts: opts}
+	return d.value(reflect.ValueOf(v), 0)
+}
+
+// Renders a value as indented text, for test failure messages.
+func Sprint(store adt.Store, v interface{}) string {
+	return Value(store, v, DefaultOptions).String()
+}
+
+// Matches the major version of a specs-actors package path, absent for v0.
+var actorsVersionPattern = regexp.MustCompile(`^github\.com/filecoin-project/specs-actors(/v(\d+))?/`)
+
+// Returns the actors version from which a type comes, defaulting to the current version for other types.
+func typeVersion(t reflect.Type) int {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t != nil {
+		if m := actorsVersionPattern.FindStringSubmatch(t.PkgPath()); m != nil {
+			if m[2] == "" {
+				return 0
+			}
+			if v, err := strconv.Atoi(m[2]); err == nil {
+				return v
+			}
+		}
+	}
+	return 7
+}
+
+type dumper struct {
+	store   adt.Store
+	version int
+	opts    Options
+}
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	addressType   = reflect.TypeOf(addr.Address{})
+	cidType       = reflect.TypeOf(cid.Cid{})
+	bitFieldType  = reflect.TypeOf(bitfield.BitField{})
+	cborIntType   = reflect.TypeOf(cbg.CborInt(0))
+	stringerIface = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func (d *dumper) value(v reflect.Value, depth int) *Node {
+	if !v.IsValid() {
+		return &Node{Kind: Scalar, Value: "nil"}
+	}
+	t := v.Type()
+	switch t {
+	case bigIntType:
+		bi := v.Interface().(big.Int)
+		if bi.Int == nil {
+			return &Node{Kind: Scalar, Type: t.String(), Value: "0"}
+		}
+		return &Node{Kind: Scalar, Type: t.String(), Value: bi.String()}
+	case addressType:
+		return &Node{Kind: Scalar, Type: t.String(), Value: v.Interface().(addr.Address).String()}
+	case cidType:
+		c := v.Interface().(cid.Cid)
+		if !c.Defined() {
+			return &Node{Kind: Scalar, Type: t.String(), Value: "undef"}
+		}
+		return &Node{Kind: Scalar, Type: t.String(), Value: c.String()}
+	case bitFieldType:
+		return d.bitField(v.Interface().(bitfield.BitField))
+	case cborIntType:
+		return &Node{Kind: Scalar, Type: t.String(), Value: formatInt(v.Int()), literal: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return &Node{Kind: Scalar, Type: t.String(), Value: "nil"}
+		}
+		return d.value(v.Elem(), depth)
+	case reflect.Bool:
+		return &Node{Kind: Scalar, Type: t.String(), Value: strconv.FormatBool(v.Bool()), literal: true}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return d.named(&Node{Kind: Scalar, Type: t.String(), Value: formatInt(v.Int()), literal: true}, v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return d.named(&Node{Kind: Scalar, Type: t.String(), Value: formatUint(v.Uint()), literal: true}, v)
+	case reflect.String:
+		return &Node{Kind: Scalar, Type: t.String(), Value: v.String()}
+	case reflect.Struct:
+		return d.structure(v, depth)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return d.bytes(t.String(), b)
+		}
+		n := &Node{Kind: Collection, Type: t.String(), Count: int64(v.Len())}
+		for i := 0; i < v.Len(); i++ {
+			if i == d.opts.MaxEntries {
+				n.Truncated = true
+				break
+			}
+			n.Entries = append(n.Entries, Entry{Key: strconv.Itoa(i), Value: d.value(v.Index(i), depth)})
+		}
+		return n
+	case reflect.Map:
+		return d.mapping(v, depth)
+	}
+	return &Node{Kind: Scalar, Type: t.String(), Value: fmt.Sprint(v.Interface())}
+}
+
+// Appends the name of an enumerated value to its number, for integer types with a String method.
+func (d *dumper) named(n *Node, v reflect.Value) *Node {
+	if v.Type().Implements(stringerIface) && v.Type().PkgPath() != "" {
+		if name := v.Interface().(fmt.Stringer).String(); name != n.Value {
+			n.Value = name + " (" + n.Value + ")"
+			n.literal = false
+		}
+	}
+	return n
+}
+
+func (d *dumper) structure(v reflect.Value, depth int) *Node {
+	t := v.Type()
+	n := &Node{Kind: Struct, Type: t.String()}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		var value *Node
+		if l := fieldLayout(t, f.Name, d.version); l != nil {
+			value = d.linkField(v.Field(i), l, depth)
+		}
+		if value == nil {
+			value = d.value(v.Field(i), depth)
+		}
+		n.Fields = append(n.Fields, Field{Name: f.Name, Value: value})
+	}
+	return n
+}
+
+// Renders a field holding a link, a pointer to a link, or an array of links, or returns nil
+// if the field holds none of these.
+func (d *dumper) linkField(v reflect.Value, l *layout, depth int) *Node {
+	switch {
+	case v.Type() == cidType:
+		return d.follow(v.Interface().(cid.Cid), l, depth)
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == cidType:
+		if v.IsNil() {
+			return &Node{Kind: Scalar, Type: l.String(), Value: "nil"}
+		}
+		return d.follow(v.Elem().Interface().(cid.Cid), l, depth)
+	case (v.Kind() == reflect.Array || v.Kind() == reflect.Slice) && v.Type().Elem() == cidType:
+		n := &Node{Kind: Collection, Type: v.Type().String(), Count: int64(v.Len())}
+		for i := 0; i < v.Len(); i++ {
+			if i == d.opts.MaxEntries {
+				n.Truncated = true
+				break
+			}
+			n.Entries = append(n.Entries, Entry{Key: strconv.Itoa(i), Value: d.follow(v.Index(i).Interface().(cid.Cid), l, depth)})
+		}
+		return n
+	}
+	return nil
+}
+
+// Loads and renders the content linked by a CID, if within the depth budget.
+func (d *dumper) follow(c cid.Cid, l *layout, depth int) *Node {
+	if depth >= d.opts.MaxDepth {
+		return &Node{Kind: Scalar, Type: l.String(), Value: c.String(), Link: c, Truncated: true}
+	}
+	var n *Node
+	switch l.kind {
+	case hamtLayout:
+		n = d.hamt(c, l, depth+1)
+	case amtLayout:
+		n = d.amt(c, l, depth+1)
+	default:
+		var raw cbg.Deferred
+		if err := d.store.Get(d.store.Context(), c, &raw); err != nil {
+			n = &Node{Kind: Scalar, Type: l.String(), Value: c.String(), Error: err.Error()}
+		} else {
+			n = d.decode(raw.Raw, l.elem, depth+1)
+		}
+	}
+	n.Link = c
+	return n
+}
+
+func (d *dumper) hamt(root cid.Cid, l *layout, depth int) *Node {
+	n := &Node{Kind: Collection, Type: l.String(), Count: -1}
+	entries, more, err := loadHamt(d.store, d.version, root, l, d.opts.MaxEntries)
+	if err != nil {
+		n.Error = xerrors.Errorf("failed to load HAMT: %w", err).Error()
+		return n
+	}
+	if !more {
+		n.Count = int64(len(entries))
+	}
+	n.Truncated = more
+	n.Entries = d.entries(entries, l, depth)
+	return n
+}
+
+func (d *dumper) amt(root cid.Cid, l *layout, depth int) *Node {
+	n := &Node{Kind: Collection, Type: l.String()}
+	entries, length, err := loadAmt(d.store, d.version, root, l, d.opts.MaxEntries)
+	if err != nil {
+		n.Count = -1
+		n.Error = xerrors.Errorf("failed to load AMT: %w", err).Error()
+		return n
+	}
+	n.Count = int64(length)
+	n.Truncated = uint64(len(entries)) < length
+	n.Entries = d.entries(entries, l, depth)
+	return n
+}
+
+func (d *dumper) entries(entries []rawEntry, l *layout, depth int) []Entry {
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		var value *Node
+		switch {
+		case l.nested != nil:
+			root, err := decodeRoot(e.value)
+			if err != nil {
+				value = &Node{Kind: Scalar, Type: l.nested.String(), Error: err.Error()}
+			} else {
+				value = d.follow(root, l.nested, depth)
+			}
+		case l.elem != nil:
+			value = d.decode(e.value, l.elem, depth)
+		default:
+			value = &Node{Kind: Scalar} // a set member, without a value
+		}
+		out = append(out, Entry{Key: e.key, Value: value})
+	}
+	return out
+}
+
+// Decodes and renders a value of a type, or renders it as generic CBOR if it doesn't decode as the type,
+// as may be the case for values from prior actors versions.
+func (d *dumper) decode(raw []byte, t reflect.Type, depth int) *Node {
+	v := reflect.New(t)
+	if u, ok := v.Interface().(cbor.Unmarshaler); ok {
+		if err := u.UnmarshalCBOR(bytes.NewReader(raw)); err == nil {
+			return d.value(v.Elem(), depth)
+		}
+	}
+	var generic interface{}
+	if err := ipldcbor.DecodeInto(raw, &generic); err != nil {
+		return &Node{Kind: Scalar, Type: t.String(), Error: xerrors.Errorf("failed to decode: %w", err).Error()}
+	}
+	n := d.value(reflect.ValueOf(generic), depth)
+	n.Type = "cbor"
+	return n
+}
+
+func (d *dumper) mapping(v reflect.Value, depth int) *Node {
+	n := &Node{Kind: Collection, Type: v.Type().String(), Count: int64(v.Len())}
+	type keyed struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]keyed, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entries = append(entries, keyed{key: d.value(iter.Key(), depth).Value, value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	for i, e := range entries {
+		if i == d.opts.MaxEntries {
+			n.Truncated = true
+			break
+		}
+		n.Entries = append(n.Entries, Entry{Key: e.key, Value: d.value(e.value, depth)})
+	}
+	return n
+}
+
+// Renders a bitfield as its runs of set bits, e.g. "0-3,7", and the number of bits set.
+func (d *dumper) bitField(bf bitfield.BitField) *Node {
+	n := &Node{Kind: Scalar, Type: bitFieldType.String()}
+	runs, err := bf.RunIterator()
+	if err != nil {
+		n.Error = err.Error()
+		return n
+	}
+	var ranges []string
+	var pos, count uint64
+	for runs.HasNext() {
+		r, err := runs.NextRun()
+		if err != nil {
+			n.Error = err.Error()
+			return n
+		}
+		if r.Val {
+			switch {
+			case len(ranges) == d.opts.MaxEntries:
+				n.Truncated = true
+			case r.Len == 1:
+				ranges = append(ranges, formatUint(pos))
+			default:
+				ranges = append(ranges, formatUint(pos)+"-"+formatUint(pos+r.Len-1))
+			}
+			count += r.Len
+		}
+		pos += r.Len
+	}
+	if n.Truncated {
+		ranges = append(ranges, "...")
+	}
+	n.Value = "[" + strings.Join(ranges, ",") + "] (" + formatUint(count) + " set)"
+	return n
+}
+
+func (d *dumper) bytes(typ string, b []byte) *Node {
+	n := &Node{Kind: Scalar, Type: typ}
+	if len(b) > d.opts.MaxBytes {
+		n.Value = "0x" + hex.EncodeToString(b[:d.opts.MaxBytes]) + "... (" + strconv.Itoa(len(b)) + " bytes)"
+		n.Truncated = true
+		return n
+	}
+	n.Value = "0x" + hex.EncodeToString(b)
+	return n
+}
+
+func formatInt(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func formatUint(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
@@ -0,0 +1,120 @@
+package dump_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/dump"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestActorState(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+
+	lanes, err := adt.MakeEmptyArray(store, paych.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	for _, lane := range []uint64{0, 1, 5} {
+		require.NoError(t, lanes.Set(lane, &paych.LaneState{Redeemed: big.NewInt(int64(lane) + 10), Nonce: lane}))
+	}
+	lanesRoot, err := lanes.Root()
+	require.NoError(t, err)
+	st := paych.ConstructState(tutil.NewIDAddr(t, 100), tutil.NewIDAddr(t, 101), nil, lanesRoot, 10)
+	head, err := store.Put(ctx, st)
+	require.NoError(t, err)
+	actor := &states.Actor{Code: builtin.PaymentChannelActorCodeID, Head: head, Balance: big.Zero()}
+
+	laneStates := func(n *dump.Node) *dump.Node {
+		for _, f := range n.Fields {
+			if f.Name == "LaneStates" {
+				return f.Value
+			}
+		}
+		require.FailNow(t, "no LaneStates field")
+		return nil
+	}
+
+	t.Run("collections within budget", func(t *testing.T) {
+		n, err := dump.ActorState(store, actor, dump.Options{MaxDepth: 1, MaxEntries: 2, MaxBytes: 8})
+		require.NoError(t, err)
+		assert.Equal(t, dump.Struct, n.Kind)
+		assert.Equal(t, "paych.State", n.Type)
+		assert.Equal(t, head, n.Link)
+
+		ls := laneStates(n)
+		assert.Equal(t, dump.Collection, ls.Kind)
+		assert.Equal(t, "AMT[paych.LaneState]", ls.Type)
+		assert.Equal(t, lanesRoot, ls.Link)
+		assert.EqualValues(t, 3, ls.Count)
+		assert.True(t, ls.Truncated)
+		require.Len(t, ls.Entries, 2)
+		assert.Equal(t, "1", ls.Entries[1].Key)
+		assert.Equal(t, "Redeemed", ls.Entries[1].Value.Fields[0].Name)
+		assert.Equal(t, "11", ls.Entries[1].Value.Fields[0].Value.Value)
+
+		text := n.String()
+		assert.Contains(t, text, "paych.State @"+head.String()+"\n")
+		assert.Contains(t, text, "  SettlingAt: 0\n")
+		assert.Contains(t, text, "  LaneStates: AMT[paych.LaneState] @"+lanesRoot.String()+" (3 entries)\n")
+		assert.Contains(t, text, "      Redeemed: 11\n")
+		assert.Contains(t, text, "    ... (1 more)\n")
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(mustJSON(t, n), &decoded))
+		assert.Equal(t, "paych.State", decoded["@type"])
+		assert.Equal(t, map[string]interface{}{"/": head.String()}, decoded["@cid"])
+		assert.Equal(t, float64(0), decoded["SettlingAt"])
+		jsonLanes := decoded["LaneStates"].(map[string]interface{})
+		assert.Equal(t, float64(3), jsonLanes["@count"])
+		assert.Equal(t, true, jsonLanes["@truncated"])
+		assert.Len(t, jsonLanes["@entries"], 2)
+	})
+
+	t.Run("links beyond depth are not loaded", func(t *testing.T) {
+		n, err := dump.ActorState(store, actor, dump.Options{MaxDepth: 0, MaxEntries: 2, MaxBytes: 8})
+		require.NoError(t, err)
+		ls := laneStates(n)
+		assert.Equal(t, dump.Scalar, ls.Kind)
+		assert.Equal(t, lanesRoot, ls.Link)
+		assert.True(t, ls.Truncated)
+		assert.Empty(t, ls.Entries)
+		assert.Contains(t, n.String(), "  LaneStates: "+lanesRoot.String()+" (AMT[paych.LaneState], not loaded)\n")
+	})
+}
+
+func TestValue(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+	st, err := init_.ConstructState(store, "dumpnet")
+	require.NoError(t, err)
+	pubkey := tutil.NewSECP256K1Addr(t, "pubkey")
+	idAddr, err := st.MapAddressToNewID(store, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, tutil.NewIDAddr(t, 100), idAddr)
+
+	text := dump.Value(store, st, dump.DefaultOptions).String()
+	assert.Contains(t, text, "  NetworkName: dumpnet\n")
+	assert.Contains(t, text, "  AddressMap: HAMT[typegen.CborInt] @")
+	assert.Contains(t, text, "    "+pubkey.String()+": 100\n")
+	assert.Contains(t, text, "  ActorAddresses: HAMT[init.ActorAddresses] @")
+	assert.Contains(t, text, "    100: init.ActorAddresses\n")
+	assert.Contains(t, text, "        0: "+pubkey.String()+"\n")
+	assert.Equal(t, dump.Sprint(store, st), text)
+}
+
+func mustJSON(t *testing.T, n *dump.Node) []byte {
+	b, err := json.Marshal(n)
+	require.NoError(t, err)
+	return b
+}
@@ -0,0 +1,128 @@
+package dump
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/util/jsonenc"
+)
+
+const indent = "  "
+
+// Renders the node as indented text, one field or entry per line.
+func (n *Node) String() string {
+	var b strings.Builder
+	n.writeText(&b, "", "")
+	return b.String()
+}
+
+// Writes the node as indented text, one field or entry per line.
+func (n *Node) WriteText(w io.Writer) error {
+	_, err := io.WriteString(w, n.String())
+	return err
+}
+
+func (n *Node) writeText(b *strings.Builder, prefix, label string) {
+	b.WriteString(prefix)
+	b.WriteString(label)
+	switch {
+	case n.Kind == Scalar && n.Link.Defined():
+		// A link beyond the depth budget, or which failed to load.
+		b.WriteString(n.Link.String() + " (" + n.Type)
+		if n.Truncated {
+			b.WriteString(", not loaded")
+		}
+		b.WriteString(")")
+	case n.Kind == Scalar:
+		b.WriteString(n.Value)
+	default:
+		b.WriteString(n.Type)
+		if n.Link.Defined() {
+			b.WriteString(" @" + n.Link.String())
+		}
+		if n.Kind == Collection {
+			b.WriteString(" (" + n.countText() + ")")
+		}
+	}
+	if n.Error != "" {
+		b.WriteString(" (error: " + n.Error + ")")
+	}
+	b.WriteString("\n")
+
+	inner := prefix + indent
+	for _, f := range n.Fields {
+		f.Value.writeText(b, inner, f.Name+": ")
+	}
+	for _, e := range n.Entries {
+		if e.Value.Kind == Scalar && e.Value.Value == "" && e.Value.Error == "" {
+			b.WriteString(inner + e.Key + "\n") // a set member
+			continue
+		}
+		e.Value.writeText(b, inner, e.Key+": ")
+	}
+	if n.Kind == Collection && n.Truncated {
+		if n.Count >= 0 {
+			b.WriteString(inner + "... (" + strconv.FormatInt(n.Count-int64(len(n.Entries)), 10) + " more)\n")
+		} else {
+			b.WriteString(inner + "...\n")
+		}
+	}
+}
+
+func (n *Node) countText() string {
+	switch {
+	case n.Count < 0 && n.Truncated:
+		return "more than " + strconv.Itoa(len(n.Entries)) + " entries"
+	case n.Count < 0:
+		return "unknown entries"
+	case n.Count == 1:
+		return "1 entry"
+	}
+	return strconv.FormatInt(n.Count, 10) + " entries"
+}
+
+// Encodes the node as JSON. A plain scalar is a string, or a number or boolean for those types.
+// A struct is an object of its fields, preceded by "@type" and, if loaded from a link, "@cid".
+// A collection is an object of "@type", "@cid", "@count" and an "@entries" list of {"key", "value"} objects.
+// Links that were not loaded are objects with "@type", "@cid" and "@truncated".
+func (n *Node) MarshalJSON() ([]byte, error) {
+	if n.Kind == Scalar && !n.Link.Defined() && n.Error == "" {
+		if n.literal {
+			return []byte(n.Value), nil
+		}
+		return json.Marshal(n.Value)
+	}
+
+	obj := jsonenc.Object{{Name: "@type", Value: n.Type}}
+	if n.Link.Defined() {
+		obj = append(obj, jsonenc.Field{Name: "@cid", Value: n.Link})
+	}
+	switch n.Kind {
+	case Struct:
+		for _, f := range n.Fields {
+			obj = append(obj, jsonenc.Field{Name: f.Name, Value: f.Value})
+		}
+	case Collection:
+		if n.Count >= 0 {
+			obj = append(obj, jsonenc.Field{Name: "@count", Value: n.Count})
+		}
+		entries := make([]jsonenc.Object, len(n.Entries))
+		for i, e := range n.Entries {
+			entries[i] = jsonenc.Object{{Name: "key", Value: e.Key}, {Name: "value", Value: e.Value}}
+		}
+		obj = append(obj, jsonenc.Field{Name: "@entries", Value: entries})
+	default:
+		if !n.Link.Defined() {
+			obj = append(obj, jsonenc.Field{Name: "@value", Value: n.Value})
+		}
+	}
+	if n.Truncated {
+		obj = append(obj, jsonenc.Field{Name: "@truncated", Value: true})
+	}
+	if n.Error != "" {
+		obj = append(obj, jsonenc.Field{Name: "@error", Value: n.Error})
+	}
+	return obj.MarshalJSON()
+}
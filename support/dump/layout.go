@@ -0,0 +1,179 @@
+package dump
+
+import (
+	"reflect"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	init_ "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/paych"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// How the content linked by a CID is stored.
+type layoutKind int
+
+const (
+	linkLayout layoutKind = iota // a single object
+	hamtLayout
+	amtLayout
+)
+
+// Encoding of HAMT keys.
+type keyFormat int
+
+const (
+	addrKey     keyFormat = iota // abi.AddrKey
+	intKey                       // abi.IntKey, varint
+	uintKey                      // abi.UIntKey, uvarint
+	cidKey                       // abi.CidKey
+	addrPairKey                  // abi.AddrPairKey, a CBOR tuple of two addresses
+)
+
+// The layout of the content linked by a CID field of a state structure.
+type layout struct {
+	kind     layoutKind
+	since    int          // first actors version with this layout
+	bitwidth int          // bitwidth of a HAMT or AMT, for actors v3 and later
+	key      keyFormat    // encoding of HAMT keys
+	elem     reflect.Type // type of the linked object or of collection values, nil for a set or nested collections
+	nested   *layout      // layout of the collections whose roots are the values of a collection
+}
+
+func link(elem interface{}) *layout {
+	return &layout{kind: linkLayout, elem: reflect.TypeOf(elem)}
+}
+
+func hamt(bitwidth int, key keyFormat, elem interface{}) *layout {
+	l := &layout{kind: hamtLayout, bitwidth: bitwidth, key: key}
+	if elem != nil {
+		l.elem = reflect.TypeOf(elem)
+	}
+	return l
+}
+
+func amt(bitwidth int, elem interface{}) *layout {
+	return &layout{kind: amtLayout, bitwidth: bitwidth, elem: reflect.TypeOf(elem)}
+}
+
+// A HAMT whose values are the roots of other collections.
+func hamtOf(bitwidth int, key keyFormat, nested *layout) *layout {
+	return &layout{kind: hamtLayout, bitwidth: bitwidth, key: key, nested: nested}
+}
+
+// An AMT whose values are the roots of other collections.
+func amtOf(bitwidth int, nested *layout) *layout {
+	return &layout{kind: amtLayout, bitwidth: bitwidth, nested: nested}
+}
+
+func (l *layout) from(version int) *layout {
+	l.since = version
+	return l
+}
+
+// Describes the collection or object for display, e.g. "AMT[miner.SectorOnChainInfo]".
+func (l *layout) String() string {
+	var elem string
+	switch {
+	case l.nested != nil:
+		elem = l.nested.String()
+	case l.elem != nil:
+		elem = l.elem.String()
+	}
+	switch l.kind {
+	case hamtLayout:
+		if elem == "" {
+			return "HAMT set"
+		}
+		return "HAMT[" + elem + "]"
+	case amtLayout:
+		return "AMT[" + elem + "]"
+	}
+	return elem
+}
+
+var balanceTableLayout = hamt(adt.BalanceTableBitwidth, addrKey, big.Int{})
+
+// Layouts of the content linked by CID fields of builtin actor state, keyed by the field's
+// package-qualified struct type and name, e.g. "miner.State.Sectors".
+// Keys omit the actors version, so apply to the same field in every version. Fields whose layout
+// changed list each layout in order of the version introducing it.
+// Collection values are decoded as the types of this version; values of prior versions that
+// don't decode as such are rendered as generic CBOR.
+var fieldLayouts = map[string][]*layout{
+	"cron.State.TickReports": {amt(cron.TickReportsAmtBitwidth, cron.TickReport{})},
+
+	"init.State.AddressMap":     {hamt(builtin.DefaultHamtBitwidth, addrKey, cbg.CborInt(0))},
+	"init.State.ActorAddresses": {hamt(builtin.DefaultHamtBitwidth, uintKey, init_.ActorAddresses{})},
+
+	"market.State.Proposals":        {amt(market.ProposalsAmtBitwidth, market.DealProposal{})},
+	"market.State.States":           {amt(market.StatesAmtBitwidth, market.DealState{})},
+	"market.State.PendingProposals": {hamt(builtin.DefaultHamtBitwidth, cidKey, nil)},
+	"market.State.EscrowTable": {
+		balanceTableLayout,
+		amtOf(adt.BalanceTableShardsAmtBitwidth, balanceTableLayout).from(7),
+	},
+	"market.State.LockedTable": {
+		balanceTableLayout,
+		amtOf(adt.BalanceTableShardsAmtBitwidth, balanceTableLayout).from(7),
+	},
+	"market.State.DealOpsByEpoch":   {hamtOf(builtin.DefaultHamtBitwidth, uintKey, hamt(builtin.DefaultHamtBitwidth, uintKey, nil))},
+	"market.State.DealPriceBuckets": {amt(market.DealPriceBucketsAmtBitwidth, market.DealPriceBucket{})},
+
+	"miner.State.Info":                       {link(miner.MinerInfo{})},
+	"miner.State.VestingFunds":               {link(miner.VestingFunds{})},
+	"miner.State.PreCommittedSectors":        {hamt(builtin.DefaultHamtBitwidth, uintKey, miner.SectorPreCommitOnChainInfo{})},
+	"miner.State.PreCommittedSectorsCleanUp": {amt(miner.PrecommitCleanUpAmtBitwidth, bitfield.BitField{})},
+	"miner.State.AllocatedSectors":           {link(bitfield.BitField{})},
+	"miner.State.Sectors":                    {amt(miner.SectorsAmtBitwidth, miner.SectorOnChainInfo{})},
+	"miner.State.Deadlines":                  {link(miner.Deadlines{})},
+	"miner.State.FeeDebtLog":                 {link(miner.FeeDebtLog{})},
+	"miner.State.UnsealingWindows":           {link(miner.UnsealingWindows{})},
+
+	"miner.Deadlines.Due": {link(miner.Deadline{})},
+
+	"miner.Deadline.Partitions":                        {amt(miner.DeadlinePartitionsAmtBitwidth, miner.Partition{})},
+	"miner.Deadline.ExpirationsEpochs":                 {amt(miner.DeadlineExpirationAmtBitwidth, bitfield.BitField{})},
+	"miner.Deadline.OptimisticPoStSubmissions":         {amt(miner.DeadlineOptimisticPoStSubmissionsAmtBitwidth, miner.WindowedPoSt{})},
+	"miner.Deadline.SectorsSnapshot":                   {amt(miner.SectorsAmtBitwidth, miner.SectorOnChainInfo{})},
+	"miner.Deadline.PartitionsSnapshot":                {amt(miner.DeadlinePartitionsAmtBitwidth, miner.Partition{})},
+	"miner.Deadline.OptimisticPoStSubmissionsSnapshot": {amt(miner.DeadlineOptimisticPoStSubmissionsAmtBitwidth, miner.WindowedPoSt{})},
+
+	"miner.Partition.ExpirationsEpochs": {amt(miner.PartitionExpirationAmtBitwidth, miner.ExpirationSet{})},
+	"miner.Partition.EarlyTerminated":   {amt(miner.PartitionEarlyTerminationArrayAmtBitwidth, bitfield.BitField{})},
+
+	"multisig.State.PendingTxns":        {hamt(builtin.DefaultHamtBitwidth, intKey, multisig.Transaction{})},
+	"multisig.State.PendingTxnMetadata": {hamt(builtin.DefaultHamtBitwidth, intKey, multisig.TxnMetadata{})},
+
+	"paych.State.LaneStates": {amt(paych.LaneStatesAmtBitwidth, paych.LaneState{})},
+
+	"power.State.CronEventQueue":       {hamtOf(power.CronQueueHamtBitwidth, intKey, amt(power.CronQueueAmtBitwidth, power.CronEvent{}))},
+	"power.State.Claims":               {hamt(builtin.DefaultHamtBitwidth, addrKey, power.Claim{})},
+	"power.State.ProofValidationBatch": {hamtOf(builtin.DefaultHamtBitwidth, addrKey, amt(power.ProofValidationBatchAmtBitwidth, proof.SealVerifyInfo{}))},
+
+	"verifreg.State.Verifiers":                {hamt(builtin.DefaultHamtBitwidth, addrKey, big.Int{})},
+	"verifreg.State.VerifiedClients":          {hamt(builtin.DefaultHamtBitwidth, addrKey, big.Int{})},
+	"verifreg.State.RemoveDataCapProposalIDs": {hamt(builtin.DefaultHamtBitwidth, addrPairKey, verifreg.RmDcProposalID{})},
+	"verifreg.State.DataCapAllowances":        {hamt(builtin.DefaultHamtBitwidth, addrPairKey, verifreg.DataCapAllowance{})},
+}
+
+// Returns the layout of a field of a struct type in an actors version, or nil if the field isn't a known link.
+func fieldLayout(structType reflect.Type, field string, version int) *layout {
+	var found *layout
+	for _, l := range fieldLayouts[structType.String()+"."+field] {
+		if l.since <= version {
+			found = l
+		}
+	}
+	return found
+}
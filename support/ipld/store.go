@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	block "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
@@ -74,6 +75,7 @@ func (ss *SyncBlockStore) Put(b block.Block) error {
 
 //
 // Metric-recording block store wrapper.
+// The counters are updated atomically, so the store may be shared by VMs running concurrently.
 //
 type MetricsBlockStore struct {
 	bs         ipldcbor.IpldBlockstore
@@ -90,33 +92,33 @@ func NewMetricsBlockStore(underlying ipldcbor.IpldBlockstore) *MetricsBlockStore
 }
 
 func (ms *MetricsBlockStore) Get(c cid.Cid) (block.Block, error) {
-	ms.Reads++
+	atomic.AddUint64(&ms.Reads, 1)
 	blk, err := ms.bs.Get(c)
 	if err != nil {
 		return blk, err
 	}
-	ms.ReadBytes += uint64(len(blk.RawData()))
+	atomic.AddUint64(&ms.ReadBytes, uint64(len(blk.RawData())))
 	return blk, nil
 }
 
 func (ms *MetricsBlockStore) Put(b block.Block) error {
-	ms.Writes++
-	ms.WriteBytes += uint64(len(b.RawData()))
+	atomic.AddUint64(&ms.Writes, 1)
+	atomic.AddUint64(&ms.WriteBytes, uint64(len(b.RawData())))
 	return ms.bs.Put(b)
 }
 
 func (ms *MetricsBlockStore) ReadCount() uint64 {
-	return ms.Reads
+	return atomic.LoadUint64(&ms.Reads)
 }
 
 func (ms *MetricsBlockStore) WriteCount() uint64 {
-	return ms.Writes
+	return atomic.LoadUint64(&ms.Writes)
 }
 
 func (ms *MetricsBlockStore) ReadSize() uint64 {
-	return ms.ReadBytes
+	return atomic.LoadUint64(&ms.ReadBytes)
 }
 
 func (ms *MetricsBlockStore) WriteSize() uint64 {
-	return ms.WriteBytes
+	return atomic.LoadUint64(&ms.WriteBytes)
 }
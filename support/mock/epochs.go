@@ -0,0 +1,129 @@
+package mock
+
+import (
+	"bytes"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/dline"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+)
+
+// Randomness provided for any number of requests, until the current epoch passes its expiry.
+type cachedRandomness struct {
+	expectRandomness
+	expiry abi.ChainEpoch
+}
+
+func (e *expectRandomness) matches(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) bool {
+	return tag == e.tag && epoch == e.epoch && bytes.Equal(entropy, e.entropy)
+}
+
+// Sets the offset of the proving periods of the miner under test, from which deadline information is
+// derived as the epoch advances.
+func (rt *Runtime) SetProvingPeriodOffset(offset abi.ChainEpoch) {
+	rt.provingPeriodOffset = offset
+	rt.provingPeriodOffsetSet = true
+}
+
+// Returns the miner deadline at the current epoch, for the offset set by SetProvingPeriodOffset.
+func (rt *Runtime) DeadlineInfo() *dline.Info {
+	rt.t.Helper()
+	if !rt.provingPeriodOffsetSet {
+		rt.failTestNow("deadline info requested without a proving period offset")
+	}
+	return miner.NewDeadlineInfoFromOffsetAndEpoch(rt.provingPeriodOffset, rt.epoch)
+}
+
+// Advances the epoch by a number of epochs, returning the new epoch.
+func (rt *Runtime) AdvanceEpochs(epochs abi.ChainEpoch) abi.ChainEpoch {
+	rt.t.Helper()
+	return rt.AdvanceToEpoch(rt.epoch + epochs)
+}
+
+// Advances the epoch to a given epoch, which must not precede the current one, expiring cached randomness.
+func (rt *Runtime) AdvanceToEpoch(epoch abi.ChainEpoch) abi.ChainEpoch {
+	rt.t.Helper()
+	if epoch < rt.epoch {
+		rt.failTestNow("cannot advance epoch backwards from %d to %d", rt.epoch, epoch)
+	}
+	return rt.SetEpoch(epoch)
+}
+
+// Advances the epoch to the last epoch of the current deadline, returning that deadline.
+func (rt *Runtime) AdvanceToDeadlineLast() *dline.Info {
+	rt.t.Helper()
+	dlInfo := rt.DeadlineInfo()
+	rt.AdvanceToEpoch(dlInfo.Last())
+	return dlInfo
+}
+
+// Advances the epoch to the opening of the next deadline, returning the new deadline.
+func (rt *Runtime) AdvanceToNextDeadline() *dline.Info {
+	rt.t.Helper()
+	rt.AdvanceToEpoch(rt.DeadlineInfo().NextOpen())
+	return rt.DeadlineInfo()
+}
+
+// Advances the epoch one deadline at a time until the opening of the next instance of a deadline index,
+// returning that deadline. If the current deadline has the index, the epoch is not advanced.
+// The callback, if not nil, is invoked at the last epoch of each deadline passed, e.g. to run deadline cron.
+func (rt *Runtime) AdvanceToDeadline(dlIdx uint64, atLast func(dlInfo *dline.Info)) *dline.Info {
+	rt.t.Helper()
+	dlInfo := rt.DeadlineInfo()
+	for dlInfo.Index != dlIdx {
+		if atLast != nil {
+			atLast(rt.AdvanceToDeadlineLast())
+		}
+		dlInfo = rt.AdvanceToNextDeadline()
+	}
+	return dlInfo
+}
+
+// Provides beacon randomness for any number of matching requests, up to and including an expiry epoch.
+// An expectation set by ExpectGetRandomnessBeacon takes precedence when it matches a request.
+func (rt *Runtime) CacheRandomnessBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte, out abi.Randomness, expiry abi.ChainEpoch) {
+	rt.randomnessBeaconCache = append(rt.randomnessBeaconCache, &cachedRandomness{
+		expectRandomness: expectRandomness{tag: tag, epoch: epoch, entropy: entropy, out: out},
+		expiry:           expiry,
+	})
+}
+
+// Provides ticket randomness for any number of matching requests, up to and including an expiry epoch.
+// An expectation set by ExpectGetRandomnessTickets takes precedence when it matches a request.
+func (rt *Runtime) CacheRandomnessTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte, out abi.Randomness, expiry abi.ChainEpoch) {
+	rt.randomnessTicketsCache = append(rt.randomnessTicketsCache, &cachedRandomness{
+		expectRandomness: expectRandomness{tag: tag, epoch: epoch, entropy: entropy, out: out},
+		expiry:           expiry,
+	})
+}
+
+// Returns cached randomness satisfying a request, unless the next expectation satisfies it.
+func (rt *Runtime) cachedRandomness(cache []*cachedRandomness, expected []*expectRandomness, tag crypto.DomainSeparationTag,
+	epoch abi.ChainEpoch, entropy []byte) (abi.Randomness, bool) {
+	if epoch > rt.epoch || (len(expected) > 0 && expected[0].matches(tag, epoch, entropy)) {
+		return nil, false
+	}
+	for _, c := range cache {
+		if rt.epoch <= c.expiry && c.matches(tag, epoch, entropy) {
+			return c.out, true
+		}
+	}
+	return nil, false
+}
+
+// Drops cached randomness which has expired at the current epoch.
+func (rt *Runtime) expireRandomness() {
+	expire := func(cache []*cachedRandomness) []*cachedRandomness {
+		var live []*cachedRandomness
+		for _, c := range cache {
+			if rt.epoch <= c.expiry {
+				live = append(live, c)
+			}
+		}
+		return live
+	}
+	rt.randomnessBeaconCache = expire(rt.randomnessBeaconCache)
+	rt.randomnessTicketsCache = expire(rt.randomnessTicketsCache)
+}
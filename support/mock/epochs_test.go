@@ -0,0 +1,103 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestAdvanceDeadlines(t *testing.T) {
+	offset := abi.ChainEpoch(100)
+	builder := NewBuilder(tutil.NewIDAddr(t, 100)).WithEpoch(offset + 1)
+
+	t.Run("deadlines derived from epoch", func(t *testing.T) {
+		rt := builder.Build(t)
+		rt.SetProvingPeriodOffset(offset)
+		assert.Equal(t, uint64(0), rt.DeadlineInfo().Index)
+
+		dlInfo := rt.AdvanceToNextDeadline()
+		assert.Equal(t, uint64(1), dlInfo.Index)
+		assert.Equal(t, dlInfo.Open, rt.Epoch())
+
+		last := rt.AdvanceToDeadlineLast()
+		assert.Equal(t, dlInfo.Index, last.Index)
+		assert.Equal(t, dlInfo.Last(), rt.Epoch())
+
+		var passed []uint64
+		dlInfo = rt.AdvanceToDeadline(0, func(dlInfo *dline.Info) {
+			assert.Equal(t, dlInfo.Last(), rt.Epoch())
+			passed = append(passed, dlInfo.Index)
+		})
+		assert.Equal(t, uint64(0), dlInfo.Index)
+		assert.Equal(t, offset+miner.WPoStProvingPeriod, rt.Epoch())
+		assert.Len(t, passed, int(miner.WPoStPeriodDeadlines)-1)
+
+		// Already at the deadline.
+		assert.Equal(t, dlInfo, rt.AdvanceToDeadline(0, nil))
+		assert.Equal(t, offset+miner.WPoStProvingPeriod+10, rt.AdvanceEpochs(10))
+	})
+
+	t.Run("no offset", func(t *testing.T) {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		assert.Panics(t, func() { rt.DeadlineInfo() })
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("backwards", func(t *testing.T) {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		assert.Panics(t, func() { rt.AdvanceToEpoch(offset) })
+		assert.True(t, recorder.failed)
+	})
+}
+
+func TestCachedRandomness(t *testing.T) {
+	builder := NewBuilder(tutil.NewIDAddr(t, 100)).WithEpoch(10)
+	tag := crypto.DomainSeparationTag_WindowedPoStChallengeSeed
+	cached := abi.Randomness("cached")
+	expected := abi.Randomness("expected")
+	getRandomness := func(rt *Runtime, epoch abi.ChainEpoch) abi.Randomness {
+		var out abi.Randomness
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			out = rt.GetRandomnessFromBeacon(tag, epoch, []byte("entropy"))
+			return nil
+		}, nil)
+		return out
+	}
+
+	t.Run("reused until expiry", func(t *testing.T) {
+		rt := builder.Build(t)
+		rt.CacheRandomnessBeacon(tag, 5, []byte("entropy"), cached, 20)
+		assert.Equal(t, cached, getRandomness(rt, 5))
+		assert.Equal(t, cached, getRandomness(rt, 5))
+
+		// A matching expectation takes precedence.
+		rt.ExpectGetRandomnessBeacon(tag, 5, []byte("entropy"), expected)
+		assert.Equal(t, expected, getRandomness(rt, 5))
+		assert.Equal(t, cached, getRandomness(rt, 5))
+		rt.Verify()
+
+		rt.AdvanceToEpoch(20)
+		assert.Equal(t, cached, getRandomness(rt, 5))
+		rt.AdvanceToEpoch(21)
+		require.Empty(t, rt.randomnessBeaconCache)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		rt.CacheRandomnessBeacon(tag, 5, []byte("entropy"), cached, 10)
+		rt.AdvanceEpochs(1)
+		assert.Panics(t, func() { getRandomness(rt, 5) })
+		assert.True(t, recorder.failed)
+	})
+}
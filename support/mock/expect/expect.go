@@ -0,0 +1,135 @@
+// Package expect builds expectations on the mock runtime fluently, and provides the composite expectations
+// common to actor unit tests, such as a burn of funds or an update of a miner's claimed power.
+package expect
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+)
+
+// An expected send, built by chaining its options, then set on a runtime with On.
+type Send struct {
+	spec mock.SendSpec
+}
+
+// Starts building an expected send to an address, of no value, params or return, to the send method by default.
+func OnSend(to addr.Address) *Send {
+	return &Send{spec: mock.SendSpec{To: to, Method: builtin.MethodSend, Value: big.Zero()}}
+}
+
+func (s *Send) Method(method abi.MethodNum) *Send {
+	s.spec.Method = method
+	return s
+}
+
+func (s *Send) WithParams(params cbor.Marshaler) *Send {
+	s.spec.Params = params
+	return s
+}
+
+// Expects params satisfying a matcher, rather than equal to given params.
+func (s *Send) WithParamsMatching(matcher mock.Matcher) *Send {
+	s.spec.ParamsMatcher = matcher
+	return s
+}
+
+func (s *Send) WithValue(value abi.TokenAmount) *Send {
+	s.spec.Value = value
+	return s
+}
+
+func (s *Send) WithGasLimit(gasLimit int64) *Send {
+	s.spec.GasLimit = gasLimit
+	return s
+}
+
+// Sets the value returned to the sender.
+func (s *Send) Return(ret cbor.Er) *Send {
+	s.spec.Return = ret
+	return s
+}
+
+// Sets the exit code returned to the sender.
+func (s *Send) ExitCode(code exitcode.ExitCode) *Send {
+	s.spec.ExitCode = code
+	return s
+}
+
+// Permits the send to be omitted.
+func (s *Send) Optional() *Send {
+	s.spec.Optional = true
+	return s
+}
+
+// Expects the send on a runtime, in order after sends already expected.
+func (s *Send) On(rt *mock.Runtime) {
+	rt.ExpectSendSpec(s.spec)
+}
+
+// Expects sends on a runtime, in order.
+func Sends(rt *mock.Runtime, sends ...*Send) {
+	for _, s := range sends {
+		s.On(rt)
+	}
+}
+
+// Expects sends on a runtime which may be made in any order among themselves.
+func UnorderedSends(rt *mock.Runtime, sends ...*Send) {
+	rt.ExpectSendsUnordered(func() {
+		Sends(rt, sends...)
+	})
+}
+
+//
+// Composite expectations
+//
+
+// Expects funds to be burnt.
+func Burn(amount abi.TokenAmount) *Send {
+	return OnSend(builtin.BurntFundsActorAddr).WithValue(amount)
+}
+
+// Expects a miner to update its claimed power by a delta.
+func PowerUpdate(rawDelta, qaDelta abi.StoragePower) *Send {
+	return OnSend(builtin.StoragePowerActorAddr).
+		Method(builtin.MethodsPower.UpdateClaimedPower).
+		WithParams(&power.UpdateClaimedPowerParams{RawByteDelta: rawDelta, QualityAdjustedDelta: qaDelta})
+}
+
+// Expects a miner to update the network's total pledge by a delta.
+func PledgeUpdate(delta abi.TokenAmount) *Send {
+	return OnSend(builtin.StoragePowerActorAddr).Method(builtin.MethodsPower.UpdatePledgeTotal).WithParams(&delta)
+}
+
+// Expects a miner to enroll a cron event with a serialized payload.
+func EnrollCronEvent(epoch abi.ChainEpoch, payload []byte) *Send {
+	return OnSend(builtin.StoragePowerActorAddr).
+		Method(builtin.MethodsPower.EnrollCronEvent).
+		WithParams(&power.EnrollCronEventParams{EventEpoch: epoch, Payload: payload})
+}
+
+// Expects the current epoch's reward to be requested.
+func ThisEpochReward(ret *reward.ThisEpochRewardReturn) *Send {
+	return OnSend(builtin.RewardActorAddr).Method(builtin.MethodsReward.ThisEpochReward).Return(ret)
+}
+
+// Expects the network's total power to be requested.
+func CurrentTotalPower(ret *power.CurrentTotalPowerReturn) *Send {
+	return OnSend(builtin.StoragePowerActorAddr).Method(builtin.MethodsPower.CurrentTotalPower).Return(ret)
+}
+
+// Expects a miner's control addresses to be requested.
+func ControlAddresses(minerAddr, owner, worker addr.Address, controlAddrs ...addr.Address) *Send {
+	return OnSend(minerAddr).
+		Method(builtin.MethodsMiner.ControlAddresses).
+		Return(&miner.GetControlAddressesReturn{Owner: owner, Worker: worker, ControlAddrs: controlAddrs})
+}
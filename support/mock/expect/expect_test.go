@@ -0,0 +1,86 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/expect"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestExpectations(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	provider := tutil.NewIDAddr(t, 101)
+	owner := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	builder := mock.NewBuilder(receiver).WithBalance(big.NewInt(100), big.Zero())
+
+	t.Run("builder", func(t *testing.T) {
+		rt := builder.Build(t)
+		expect.OnSend(provider).
+			Method(builtin.MethodsMiner.ControlAddresses).
+			WithValue(big.NewInt(1)).
+			Return(&miner.GetControlAddressesReturn{Owner: owner, Worker: worker}).
+			ExitCode(exitcode.ErrForbidden).
+			On(rt)
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			var ret miner.GetControlAddressesReturn
+			code := rt.Send(provider, builtin.MethodsMiner.ControlAddresses, nil, big.NewInt(1), &ret)
+			assert.Equal(t, exitcode.ErrForbidden, code)
+			assert.Equal(t, worker, ret.Worker)
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("composites", func(t *testing.T) {
+		rt := builder.Build(t)
+		delta := big.NewInt(5)
+		expect.Sends(rt,
+			expect.ControlAddresses(provider, owner, worker),
+			expect.PowerUpdate(big.NewInt(1), big.NewInt(10)),
+			expect.PledgeUpdate(delta),
+			expect.Burn(big.NewInt(3)),
+		)
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			var ret miner.GetControlAddressesReturn
+			code := rt.Send(provider, builtin.MethodsMiner.ControlAddresses, nil, big.Zero(), &ret)
+			require.Equal(t, exitcode.Ok, code)
+			assert.Equal(t, owner, ret.Owner)
+			claim := &power.UpdateClaimedPowerParams{RawByteDelta: big.NewInt(1), QualityAdjustedDelta: big.NewInt(10)}
+			rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, claim, big.Zero(), &builtin.Discard{})
+			rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdatePledgeTotal, &delta, big.Zero(), &builtin.Discard{})
+			rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, big.NewInt(3), &builtin.Discard{})
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+
+	t.Run("unordered and optional", func(t *testing.T) {
+		rt := builder.Build(t)
+		expect.UnorderedSends(rt,
+			expect.Burn(big.NewInt(1)),
+			expect.Burn(big.NewInt(2)),
+		)
+		expect.OnSend(owner).Optional().On(rt)
+
+		rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+			rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, big.NewInt(2), &builtin.Discard{})
+			rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, big.NewInt(1), &builtin.Discard{})
+			return nil
+		}, nil)
+		rt.Verify()
+	})
+}
@@ -0,0 +1,189 @@
+// Package harness wraps the mock runtime for tests of a single actor, taking care of the setup
+// that is otherwise repeated by every actor's tests.
+//
+// A test builds a harness for the actor under test, then calls its methods by number:
+//
+//	h := harness.New(t).
+//		WithActor(account.Actor{}).
+//		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+//		Build()
+//	h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+//	h.Call(builtin.MethodsAccount.Constructor, &pubkey)
+package harness
+
+import (
+	"fmt"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// ID of the address at which the actor under test is installed, unless otherwise configured.
+const DefaultReceiverID = 1000
+
+// Builder for fluent initialization of a harness.
+type Builder struct {
+	t        testing.TB
+	actor    runtime.VMActor
+	receiver addr.Address
+	options  []func(b mock.RuntimeBuilder) mock.RuntimeBuilder
+}
+
+// Initializes a new builder.
+func New(t testing.TB) Builder {
+	return Builder{
+		t:        t,
+		receiver: tutil.NewIDAddr(t, DefaultReceiverID),
+	}
+}
+
+// Sets the actor under test, whose methods are invoked by the harness.
+func (b Builder) WithActor(actor runtime.VMActor) Builder {
+	b.actor = actor
+	return b
+}
+
+// Sets the address at which the actor under test is installed.
+func (b Builder) WithReceiver(receiver addr.Address) Builder {
+	b.receiver = receiver
+	return b
+}
+
+func (b Builder) WithCaller(address addr.Address, code cid.Cid) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithCaller(address, code).WithActorType(address, code)
+	})
+}
+
+func (b Builder) WithEpoch(epoch abi.ChainEpoch) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithEpoch(epoch)
+	})
+}
+
+func (b Builder) WithBalance(balance abi.TokenAmount) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithBalance(balance, big.Zero())
+	})
+}
+
+func (b Builder) WithNetworkVersion(version network.Version) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithNetworkVersion(version)
+	})
+}
+
+func (b Builder) WithActorType(address addr.Address, code cid.Cid) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithActorType(address, code)
+	})
+}
+
+func (b Builder) WithHasher(f func(data []byte) [32]byte) Builder {
+	return b.add(func(rb mock.RuntimeBuilder) mock.RuntimeBuilder {
+		return rb.WithHasher(f)
+	})
+}
+
+// Builds a harness with the configured values.
+func (b Builder) Build() *Harness {
+	require.NotNil(b.t, b.actor, "harness has no actor under test")
+
+	rb := mock.NewBuilder(b.receiver).WithActorType(b.receiver, b.actor.Code())
+	for _, opt := range b.options {
+		rb = opt(rb)
+	}
+	return &Harness{
+		Runtime: rb.Build(b.t),
+		Actor:   b.actor,
+		t:       b.t,
+	}
+}
+
+func (b Builder) add(opt func(mock.RuntimeBuilder) mock.RuntimeBuilder) Builder {
+	// Copy the options so that builders derived from a common prefix don't share them.
+	b.options = append(b.options[:len(b.options):len(b.options)], opt)
+	return b
+}
+
+// A mock runtime hosting an actor under test.
+// The embedded runtime remains available for setting expectations and inspecting state.
+type Harness struct {
+	*mock.Runtime
+	Actor runtime.VMActor
+	t     testing.TB
+}
+
+// Invokes a method of the actor under test and verifies that all expectations set before the call were met.
+func (h *Harness) Call(method abi.MethodNum, params interface{}) interface{} {
+	ret := h.Runtime.Call(h.method(method), params)
+	h.Verify()
+	return ret
+}
+
+// Invokes a method of the actor under test from a caller, as Call.
+func (h *Harness) CallAs(caller addr.Address, callerType cid.Cid, method abi.MethodNum, params interface{}) interface{} {
+	h.SetCaller(caller, callerType)
+	return h.Call(method, params)
+}
+
+// Invokes a method of the actor under test, expecting it to abort with an exit code,
+// and verifies that all expectations set before the call were met.
+func (h *Harness) CallExpectAbort(code exitcode.ExitCode, method abi.MethodNum, params interface{}) {
+	m := h.method(method)
+	h.ExpectAbort(code, func() {
+		h.Runtime.Call(m, params)
+	})
+	h.Verify()
+}
+
+// Invokes a method of the actor under test that may abort, returning its return value if it succeeds
+// or the exit code with which it aborts. If the call succeeds, verifies that all expectations set before it
+// were met; if it aborts, expectations it may not have reached are discarded.
+func (h *Harness) CallMayAbort(method abi.MethodNum, params interface{}) (interface{}, exitcode.ExitCode) {
+	ret, code := h.Runtime.CallMayAbort(h.method(method), params)
+	if code != exitcode.Ok {
+		h.Reset()
+		return nil, code
+	}
+	h.Verify()
+	return ret, code
+}
+
+// Advances the current epoch, returning the new epoch.
+func (h *Harness) AdvanceEpochs(n abi.ChainEpoch) abi.ChainEpoch {
+	epoch := h.Epoch() + n
+	h.SetEpoch(epoch)
+	return epoch
+}
+
+// Adds to the balance of the actor under test, returning the new balance.
+func (h *Harness) AddBalance(amt abi.TokenAmount) abi.TokenAmount {
+	balance := big.Add(h.Balance(), amt)
+	h.SetBalance(balance)
+	return balance
+}
+
+// Credits value to the balance of the actor under test as received with the next call.
+func (h *Harness) ReceiveValue(amt abi.TokenAmount) {
+	h.AddBalance(amt)
+	h.SetReceived(amt)
+}
+
+func (h *Harness) method(num abi.MethodNum) interface{} {
+	exports := h.Actor.Exports()
+	if int(num) >= len(exports) || exports[num] == nil {
+		require.FailNow(h.t, fmt.Sprintf("actor %v exports no method %d", h.Actor.Code(), num))
+	}
+	return exports[num]
+}
@@ -0,0 +1,78 @@
+package harness_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v7/support/mock/harness"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestHarness(t *testing.T) {
+	pubkey := tutil.NewBLSAddr(t, 1)
+	anyone := tutil.NewIDAddr(t, 101)
+
+	builder := harness.New(t).
+		WithActor(account.Actor{}).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		WithEpoch(10).
+		WithBalance(abi.NewTokenAmount(100))
+
+	t.Run("calls actor methods", func(t *testing.T) {
+		h := builder.Build()
+		assert.Equal(t, tutil.NewIDAddr(t, harness.DefaultReceiverID), h.Receiver())
+
+		h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		h.Call(builtin.MethodsAccount.Constructor, &pubkey)
+
+		h.ExpectValidateCallerAny()
+		ret := h.CallAs(anyone, builtin.AccountActorCodeID, builtin.MethodsAccount.PubkeyAddress, nil).(*addr.Address)
+		assert.Equal(t, pubkey, *ret)
+	})
+
+	t.Run("expects aborts", func(t *testing.T) {
+		h := builder.Build()
+		idAddr := tutil.NewIDAddr(t, 102)
+
+		h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		h.CallExpectAbort(exitcode.ErrIllegalArgument, builtin.MethodsAccount.Constructor, &idAddr)
+	})
+
+	t.Run("calls that may abort", func(t *testing.T) {
+		h := builder.Build()
+		idAddr := tutil.NewIDAddr(t, 102)
+
+		h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		ret, code := h.CallMayAbort(builtin.MethodsAccount.Constructor, &idAddr)
+		assert.Nil(t, ret)
+		assert.Equal(t, exitcode.ErrIllegalArgument, code)
+
+		h.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		_, code = h.CallMayAbort(builtin.MethodsAccount.Constructor, &pubkey)
+		assert.Equal(t, exitcode.Ok, code)
+	})
+
+	t.Run("controls epoch and balance", func(t *testing.T) {
+		h := builder.Build()
+		assert.Equal(t, abi.ChainEpoch(15), h.AdvanceEpochs(5))
+		assert.Equal(t, abi.ChainEpoch(15), h.Epoch())
+
+		assert.Equal(t, abi.NewTokenAmount(150), h.AddBalance(abi.NewTokenAmount(50)))
+		h.ReceiveValue(abi.NewTokenAmount(25))
+		assert.Equal(t, abi.NewTokenAmount(175), h.Balance())
+		assert.Equal(t, abi.NewTokenAmount(25), h.ValueReceived())
+	})
+
+	t.Run("derived builders are independent", func(t *testing.T) {
+		h1 := builder.WithEpoch(20).Build()
+		h2 := builder.WithEpoch(30).Build()
+		assert.Equal(t, abi.ChainEpoch(20), h1.Epoch())
+		assert.Equal(t, abi.ChainEpoch(30), h2.Epoch())
+	})
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// Matches the actual value of an expected parameter, in place of requiring it to equal an expected value.
+type Matcher interface {
+	Matches(actual interface{}) bool
+	String() string
+}
+
+// Matches any value.
+func Any() Matcher {
+	return anyMatcher{}
+}
+
+type anyMatcher struct{}
+
+func (anyMatcher) Matches(interface{}) bool { return true }
+func (anyMatcher) String() string           { return "any" }
+
+// Matches values satisfying a predicate, described in failure messages by a description.
+func Predicate(description string, predicate func(actual interface{}) bool) Matcher {
+	return predicateMatcher{description, predicate}
+}
+
+type predicateMatcher struct {
+	description string
+	predicate   func(actual interface{}) bool
+}
+
+func (m predicateMatcher) Matches(actual interface{}) bool { return m.predicate(actual) }
+func (m predicateMatcher) String() string                  { return m.description }
+
+// Matches a struct, or pointer to a struct, with the given fields equal to the expected values, ignoring other fields.
+// An expected value may itself be a matcher. Otherwise values which are CBOR-marshalable are compared by their
+// encoding, and others by deep equality, so must be of the field's type.
+func Fields(expected map[string]interface{}) Matcher {
+	return fieldsMatcher(expected)
+}
+
+type fieldsMatcher map[string]interface{}
+
+func (m fieldsMatcher) Matches(actual interface{}) bool {
+	v := reflect.ValueOf(actual)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for name, expected := range m {
+		field := v.FieldByName(name)
+		if !field.IsValid() || !field.CanInterface() {
+			return false
+		}
+		if !matchValue(expected, field.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m fieldsMatcher) String() string {
+	fields := make([]string, 0, len(m))
+	for name, expected := range m {
+		fields = append(fields, fmt.Sprintf("%s: %v", name, expected))
+	}
+	sort.Strings(fields)
+	return "fields {" + strings.Join(fields, ", ") + "}"
+}
+
+func matchValue(expected, actual interface{}) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Matches(actual)
+	}
+	expectedMarshaler, ok1 := expected.(cbor.Marshaler)
+	actualMarshaler, ok2 := actual.(cbor.Marshaler)
+	if ok1 && ok2 {
+		var expectedBuf, actualBuf bytes.Buffer
+		if expectedMarshaler.MarshalCBOR(&expectedBuf) != nil || actualMarshaler.MarshalCBOR(&actualBuf) != nil {
+			return false
+		}
+		return bytes.Equal(expectedBuf.Bytes(), actualBuf.Bytes())
+	}
+	return reflect.DeepEqual(expected, actual)
+}
@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestFieldsMatcher(t *testing.T) {
+	params := &power.UpdateClaimedPowerParams{RawByteDelta: big.NewInt(1), QualityAdjustedDelta: big.NewInt(10)}
+
+	assert.True(t, Fields(map[string]interface{}{"RawByteDelta": big.NewInt(1)}).Matches(params))
+	assert.True(t, Fields(map[string]interface{}{"RawByteDelta": big.NewInt(1)}).Matches(*params))
+	assert.True(t, Fields(map[string]interface{}{"RawByteDelta": Any(), "QualityAdjustedDelta": big.NewInt(10)}).Matches(params))
+	assert.True(t, Fields(nil).Matches(params))
+
+	assert.False(t, Fields(map[string]interface{}{"RawByteDelta": big.NewInt(2)}).Matches(params))
+	assert.False(t, Fields(map[string]interface{}{"Missing": big.NewInt(1)}).Matches(params))
+	assert.False(t, Fields(nil).Matches(abi.DealID(1)))
+	assert.False(t, Fields(nil).Matches((*power.UpdateClaimedPowerParams)(nil)))
+
+	seal := proof.SealVerifyInfo{SectorID: abi.SectorID{Number: 7}, DealIDs: []abi.DealID{1, 2}}
+	assert.True(t, Fields(map[string]interface{}{"SectorID": abi.SectorID{Number: 7}, "DealIDs": []abi.DealID{1, 2}}).Matches(seal))
+	assert.False(t, Fields(map[string]interface{}{"DealIDs": []abi.DealID{1}}).Matches(seal))
+
+	assert.Equal(t, "fields {A: any, B: 1}", Fields(map[string]interface{}{"B": 1, "A": Any()}).String())
+}
+
+func TestSendAndSealMatchers(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	builder := NewBuilder(receiver).WithBalance(big.NewInt(10), big.Zero())
+	params := &power.UpdateClaimedPowerParams{RawByteDelta: big.NewInt(1), QualityAdjustedDelta: big.NewInt(10)}
+	update := func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+		rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, params, big.Zero(), &builtin.Discard{})
+		return nil
+	}
+	positive := Predicate("positive raw byte delta", func(actual interface{}) bool {
+		p, ok := actual.(*power.UpdateClaimedPowerParams)
+		return ok && p.RawByteDelta.GreaterThan(big.Zero())
+	})
+
+	for _, matcher := range []Matcher{Any(), positive, Fields(map[string]interface{}{"QualityAdjustedDelta": big.NewInt(10)})} {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		rt.ExpectSendMatching(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, matcher, big.Zero(), nil, exitcode.Ok)
+		rt.Call(update, nil)
+		rt.Verify()
+		assert.False(t, recorder.failed, "%v", matcher)
+	}
+
+	t.Run("mismatched params", func(t *testing.T) {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		rt.ExpectSendMatching(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower,
+			Fields(map[string]interface{}{"RawByteDelta": big.NewInt(2)}), big.Zero(), nil, exitcode.Ok)
+		assert.Panics(t, func() { rt.Call(update, nil) })
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("seal", func(t *testing.T) {
+		recorder := &failureRecorder{TB: t}
+		rt := builder.Build(recorder)
+		seal := proof.SealVerifyInfo{SectorID: abi.SectorID{Number: 7}, SealProof: abi.RegisteredSealProof_StackedDrg32GiBV1_1}
+		rt.ExpectVerifySealMatching(Fields(map[string]interface{}{"SectorID": abi.SectorID{Number: 7}}), nil)
+		assert.NoError(t, rt.VerifySeal(seal))
+
+		rt.ExpectVerifySealMatching(Fields(map[string]interface{}{"SectorID": abi.SectorID{Number: 8}}), nil)
+		assert.NoError(t, rt.VerifySeal(seal))
+		assert.True(t, recorder.failed)
+	})
+}
@@ -47,6 +47,9 @@ type Runtime struct {
 	newActorAddr      addr.Address
 	circulatingSupply abi.TokenAmount
 	baseFee           abi.TokenAmount
+	// Offset of the proving periods of a miner under test, if set, from which deadlines are derived.
+	provingPeriodOffset    abi.ChainEpoch
+	provingPeriodOffsetSet bool
 
 	// Actor state
 	state   cid.Cid
@@ -61,6 +64,8 @@ type Runtime struct {
 	stateUsedObjs map[cbor.Marshaler]cid.Cid
 	// Syscalls
 	hashfunc func(data []byte) [32]byte
+	// Most recent abort, captured only when abort envelopes are enabled.
+	abortEnvelope *builtin.AbortEnvelope
 
 	// Expectations
 	t                              testing.TB
@@ -69,7 +74,11 @@ type Runtime struct {
 	expectValidateCallerType       []cid.Cid
 	expectRandomnessBeacon         []*expectRandomness
 	expectRandomnessTickets        []*expectRandomness
+	randomnessBeaconCache          []*cachedRandomness
+	randomnessTicketsCache         []*cachedRandomness
 	expectSends                    []*expectedMessage
+	expectSendGroup                int // The unordered group of sends being expected, if any.
+	nextSendGroup                  int
 	expectVerifySigs               []*expectVerifySig
 	expectCreateActor              *expectCreateActor
 	expectVerifySeal               *expectVerifySeal
@@ -83,7 +92,10 @@ type Runtime struct {
 	// Gas charged explicitly through rt.ChargeGas. Note: most charges are implicit
 	expectGasCharged []int64
 
-	logs []string
+	logs   []string
+	events []runtime.ActorEvent
+	// Interactions satisfying expectations, while recording.
+	recording *Script
 }
 
 type expectBatchVerifySeals struct {
@@ -118,6 +130,14 @@ type expectedMessage struct {
 	method abi.MethodNum
 	params cbor.Marshaler
 	value  abi.TokenAmount
+	// Matcher for the params, in place of equality with params, if set.
+	paramsMatcher Matcher
+	// Gas limit of the send, or zero for a send without a limit.
+	gasLimit int64
+	// Whether the send may be omitted.
+	optional bool
+	// Non-zero for a send in an unordered group, which may be matched in any order with the group's other sends.
+	group int
 
 	// returns from applying expectedMessage
 	sendReturn cbor.Er
@@ -134,8 +154,10 @@ type expectVerifySig struct {
 }
 
 type expectVerifySeal struct {
-	seal   proof.SealVerifyInfo
-	result error
+	seal proof.SealVerifyInfo
+	// Matcher for the seal, in place of equality with seal, if set.
+	matcher Matcher
+	result  error
 }
 
 type expectComputeUnsealedSectorCID struct {
@@ -151,6 +173,9 @@ type expectVerifyPoSt struct {
 }
 
 func (m *expectedMessage) Equal(to addr.Address, method abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount) bool {
+	if m.paramsMatcher != nil {
+		return m.to == to && m.method == method && m.value.Equals(value) && m.paramsMatcher.Matches(params)
+	}
 	// avoid nil vs. zero/empty discrepancies that would disappear in serialization
 	paramBuf1 := new(bytes.Buffer)
 	if m.params != nil {
@@ -165,7 +190,15 @@ func (m *expectedMessage) Equal(to addr.Address, method abi.MethodNum, params cb
 }
 
 func (m *expectedMessage) String() string {
-	return fmt.Sprintf("to: %v method: %v value: %v params: %v sendReturn: %v exitCode: %v", m.to, m.method, m.value, m.params, m.sendReturn, m.exitCode)
+	return fmt.Sprintf("to: %v method: %v value: %v params: %v sendReturn: %v exitCode: %v", m.to, m.method, m.value, m.expectedParams(), m.sendReturn, m.exitCode)
+}
+
+// Returns the params matcher, if set, or else the expected params.
+func (m *expectedMessage) expectedParams() interface{} {
+	if m.paramsMatcher != nil {
+		return m.paramsMatcher
+	}
+	return m.params
 }
 
 type expectCreateActor struct {
@@ -207,6 +240,7 @@ func (rt *Runtime) ValidateImmediateCallerAcceptAny() {
 		rt.failTest("unexpected validate-caller-any")
 	}
 	rt.expectValidateCallerAny = false
+	rt.record(Interaction{Kind: InteractionValidateCallerAny})
 }
 
 func (rt *Runtime) ValidateImmediateCallerIs(addrs ...addr.Address) {
@@ -224,6 +258,7 @@ func (rt *Runtime) ValidateImmediateCallerIs(addrs ...addr.Address) {
 	defer func() {
 		rt.expectValidateCallerAddr = nil
 	}()
+	rt.record(Interaction{Kind: InteractionValidateCallerAddr, Addresses: addrs})
 
 	// Implement method.
 	for _, expected := range addrs {
@@ -248,6 +283,7 @@ func (rt *Runtime) ValidateImmediateCallerType(types ...cid.Cid) {
 	defer func() {
 		rt.expectValidateCallerType = nil
 	}()
+	rt.record(Interaction{Kind: InteractionValidateCallerType, Codes: types})
 
 	// Implement method.
 	for _, expected := range types {
@@ -285,6 +321,59 @@ func (rt *Runtime) GetIdAddr(raw addr.Address) (addr.Address, bool) {
 	return a, found
 }
 
+// Finds the expected send matched by a send, failing the test if there is none.
+// Expected sends are matched in order, except that optional expectations may be skipped, and a send may match any
+// expectation in an unordered group. Expectations skipped over are discarded, and the index of the match among
+// those remaining is returned.
+func (rt *Runtime) matchExpectedSend(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount) (*expectedMessage, int) {
+	for start := 0; start < len(rt.expectSends); {
+		end := start + 1
+		if group := rt.expectSends[start].group; group != 0 {
+			for end < len(rt.expectSends) && rt.expectSends[end].group == group {
+				end++
+			}
+		}
+		for i := start; i < end; i++ {
+			if rt.expectSends[i].Equal(toAddr, methodNum, params, value) {
+				rt.expectSends = rt.expectSends[start:]
+				return rt.expectSends[i-start], i - start
+			}
+		}
+		if len(requiredSends(rt.expectSends[start:end])) > 0 {
+			expected := make([]string, end-start)
+			for i, exp := range rt.expectSends[start:end] {
+				expected[i] = "Expected  " + rt.describeSend(exp.to, exp.method, exp.value, exp.expectedParams())
+			}
+			rt.failTestNow("unexpected send\n          %s\n%s",
+				rt.describeSend(toAddr, methodNum, value, params), strings.Join(expected, "\n"))
+		}
+		start = end
+	}
+	rt.failTestNow("unexpected send to: %v method: %v, value: %v, params: %v", toAddr, methodNum, value, params)
+	return nil, 0
+}
+
+func (rt *Runtime) describeSend(toAddr addr.Address, methodNum abi.MethodNum, value abi.TokenAmount, params interface{}) string {
+	toName := "unknown"
+	toMeth := "unknown"
+	if code, ok := rt.GetActorCodeCID(toAddr); ok && builtin.IsBuiltinActor(code) {
+		toName = builtin.ActorNameByCode(code)
+		toMeth = getMethodName(code, methodNum)
+	}
+	return fmt.Sprintf("to: %s (%s) method: %d (%s) value: %v params: %v", toAddr, toName, methodNum, toMeth, value, params)
+}
+
+// Returns the expected sends which are not optional.
+func requiredSends(expected []*expectedMessage) []*expectedMessage {
+	var required []*expectedMessage
+	for _, exp := range expected {
+		if !exp.optional {
+			required = append(required, exp)
+		}
+	}
+	return required
+}
+
 func (rt *Runtime) GetActorCodeCID(addr addr.Address) (ret cid.Cid, ok bool) {
 	rt.requireInCall()
 	ret, ok = rt.actorCodeCIDs[addr]
@@ -293,6 +382,10 @@ func (rt *Runtime) GetActorCodeCID(addr addr.Address) (ret cid.Cid, ok bool) {
 
 func (rt *Runtime) GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
 	rt.requireInCall()
+	if out, ok := rt.cachedRandomness(rt.randomnessBeaconCache, rt.expectRandomnessBeacon, tag, epoch, entropy); ok {
+		rt.record(Interaction{Kind: InteractionRandomnessBeacon, Tag: tag, Epoch: epoch, Entropy: entropy, Return: out})
+		return out
+	}
 	if len(rt.expectRandomnessBeacon) == 0 {
 		rt.failTestNow("unexpected call to get randomness for tag %v, epoch %v", tag, epoch)
 	}
@@ -311,11 +404,16 @@ func (rt *Runtime) GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch
 	defer func() {
 		rt.expectRandomnessBeacon = rt.expectRandomnessBeacon[1:]
 	}()
+	rt.record(Interaction{Kind: InteractionRandomnessBeacon, Tag: tag, Epoch: epoch, Entropy: entropy, Return: exp.out})
 	return exp.out
 }
 
 func (rt *Runtime) GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
 	rt.requireInCall()
+	if out, ok := rt.cachedRandomness(rt.randomnessTicketsCache, rt.expectRandomnessTickets, tag, epoch, entropy); ok {
+		rt.record(Interaction{Kind: InteractionRandomnessTickets, Tag: tag, Epoch: epoch, Entropy: entropy, Return: out})
+		return out
+	}
 	if len(rt.expectRandomnessTickets) == 0 {
 		rt.failTestNow("unexpected call to get randomness for tag %v, epoch %v", tag, epoch)
 	}
@@ -334,37 +432,26 @@ func (rt *Runtime) GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoc
 	defer func() {
 		rt.expectRandomnessTickets = rt.expectRandomnessTickets[1:]
 	}()
+	rt.record(Interaction{Kind: InteractionRandomnessTickets, Tag: tag, Epoch: epoch, Entropy: entropy, Return: exp.out})
 	return exp.out
 }
 
 func (rt *Runtime) Send(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, out cbor.Er) exitcode.ExitCode {
+	return rt.send(toAddr, methodNum, params, value, 0, out)
+}
+
+func (rt *Runtime) SendWithGasLimit(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, gasLimit int64, out cbor.Er) exitcode.ExitCode {
+	return rt.send(toAddr, methodNum, params, value, gasLimit, out)
+}
+
+func (rt *Runtime) send(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, gasLimit int64, out cbor.Er) exitcode.ExitCode {
 	rt.requireInCall()
 	if rt.inTransaction {
 		rt.Abortf(exitcode.SysErrorIllegalActor, "side-effect within transaction")
 	}
-	if len(rt.expectSends) == 0 {
-		rt.failTestNow("unexpected send to: %v method: %v, value: %v, params: %v", toAddr, methodNum, value, params)
-	}
-	exp := rt.expectSends[0]
-
-	if !exp.Equal(toAddr, methodNum, params, value) {
-		toName := "unknown"
-		toMeth := "unknown"
-		expToName := "unknown"
-		expToMeth := "unknown"
-		if code, ok := rt.GetActorCodeCID(toAddr); ok && builtin.IsBuiltinActor(code) {
-			toName = builtin.ActorNameByCode(code)
-			toMeth = getMethodName(code, methodNum)
-		}
-		if code, ok := rt.GetActorCodeCID(exp.to); ok && builtin.IsBuiltinActor(code) {
-			expToName = builtin.ActorNameByCode(code)
-			expToMeth = getMethodName(code, exp.method)
-		}
-
-		rt.failTestNow("unexpected send\n"+
-			"          to: %s (%s) method: %d (%s) value: %v params: %v\n"+
-			"Expected  to: %s (%s) method: %d (%s) value: %v params: %v",
-			toAddr, toName, methodNum, toMeth, value, params, exp.to, expToName, exp.method, expToMeth, exp.value, exp.params)
+	exp, idx := rt.matchExpectedSend(toAddr, methodNum, params, value)
+	if gasLimit != exp.gasLimit {
+		rt.failTestNow("unexpected send gas limit to: %v method: %v, gas limit: %d, expected %d", toAddr, methodNum, gasLimit, exp.gasLimit)
 	}
 
 	fromBalance := rt.Balance()
@@ -374,7 +461,7 @@ func (rt *Runtime) Send(toAddr addr.Address, methodNum abi.MethodNum, params cbo
 
 	// pop the expectedMessage from the queue and modify the mockrt balance to reflect the send.
 	defer func() {
-		rt.expectSends = rt.expectSends[1:]
+		rt.expectSends = append(rt.expectSends[:idx:idx], rt.expectSends[idx+1:]...)
 		rt.balance = big.Sub(rt.balance, value)
 	}()
 
@@ -384,6 +471,16 @@ func (rt *Runtime) Send(toAddr addr.Address, methodNum abi.MethodNum, params cbo
 	if err != nil {
 		rt.failTestNow("error serializing expected send return: %v", err)
 	}
+	if rt.recording != nil {
+		var paramBuf bytes.Buffer
+		if params != nil {
+			if err := params.MarshalCBOR(&paramBuf); err != nil {
+				rt.failTestNow("error serializing send params: %v", err)
+			}
+		}
+		rt.record(Interaction{Kind: InteractionSend, Address: &toAddr, Method: methodNum, Params: paramBuf.Bytes(), Value: &value,
+			GasLimit: gasLimit, Return: buf.Bytes(), ExitCode: exp.exitCode})
+	}
 	err = out.UnmarshalCBOR(&buf)
 	if err != nil {
 		rt.failTestNow("error deserializing send return bytes to output param: %v", err)
@@ -415,6 +512,7 @@ func (rt *Runtime) CreateActor(codeId cid.Cid, address addr.Address) {
 		defer func() {
 			rt.expectCreateActor = nil
 		}()
+		rt.record(Interaction{Kind: InteractionCreateActor, Codes: []cid.Cid{codeId}, Address: &address})
 		return
 	}
 	rt.failTestNow("unexpected call to create actor")
@@ -433,6 +531,7 @@ func (rt *Runtime) DeleteActor(addr addr.Address) {
 		rt.failTestNow("attempt to delete wrong actor. Expected %s, got %s.", rt.expectDeleteActor.String(), addr.String())
 	}
 	rt.expectDeleteActor = nil
+	rt.record(Interaction{Kind: InteractionDeleteActor, Address: &addr})
 }
 
 func (rt *Runtime) TotalFilCircSupply() abi.TokenAmount {
@@ -442,7 +541,28 @@ func (rt *Runtime) TotalFilCircSupply() abi.TokenAmount {
 func (rt *Runtime) Abortf(errExitCode exitcode.ExitCode, msg string, args ...interface{}) {
 	rt.requireInCall()
 	rt.t.Logf("Mock Runtime Abort ExitCode: %v Reason: %s", errExitCode, fmt.Sprintf(msg, args...))
-	panic(abort{errExitCode, fmt.Sprintf(msg, args...)})
+	a := abort{code: errExitCode, msg: fmt.Sprintf(msg, args...)}
+	if builtin.AbortEnvelopesEnabled {
+		file, line := builtin.CaptureAbortSite()
+		a.envelope = &builtin.AbortEnvelope{
+			Actor:    rt.actorCodeCIDs[rt.receiver],
+			Receiver: rt.receiver,
+			Code:     errExitCode,
+			Message:  a.msg,
+			File:     file,
+			Line:     line,
+			States:   []cid.Cid{rt.state},
+		}
+		rt.abortEnvelope = a.envelope
+		rt.t.Logf("Mock Runtime Abort Envelope: %v", a.envelope)
+	}
+	panic(a)
+}
+
+// Returns the envelope describing the most recent abort, or nil if there has been none since the last Reset.
+// Envelopes are only captured in builds with the "abortenvelope" tag.
+func (rt *Runtime) AbortEnvelope() *builtin.AbortEnvelope {
+	return rt.abortEnvelope
 }
 
 func (rt *Runtime) Context() context.Context {
@@ -586,6 +706,11 @@ func (rt *Runtime) VerifySignature(sig crypto.Signature, signer addr.Address, pl
 		defer func() {
 			rt.expectVerifySigs = rt.expectVerifySigs[1:]
 		}()
+		recorded := Interaction{Kind: InteractionVerifySignature, Signature: &sig, Address: &signer, Plaintext: plaintext}
+		if exp.result != nil {
+			recorded.Error = exp.result.Error()
+		}
+		rt.record(recorded)
 		return exp.result
 	}
 	rt.failTestNow("unexpected syscall to verify signature %v, signer %s, plaintext %v", sig, signer, plaintext)
@@ -618,7 +743,12 @@ func (rt *Runtime) ComputeUnsealedSectorCID(reg abi.RegisteredSealProof, pieces
 func (rt *Runtime) VerifySeal(seal proof.SealVerifyInfo) error {
 	exp := rt.expectVerifySeal
 	if exp != nil {
-		if !reflect.DeepEqual(exp.seal, seal) {
+		if exp.matcher != nil && !exp.matcher.Matches(seal) {
+			rt.failTest("unexpected seal verification\n"+
+				"        : %v\n"+
+				"expected: %v",
+				seal, exp.matcher)
+		} else if exp.matcher == nil && !reflect.DeepEqual(exp.seal, seal) {
 			rt.failTest("unexpected seal verification\n"+
 				"        : %v\n"+
 				"expected: %v",
@@ -772,6 +902,21 @@ func (rt *Runtime) Log(level rt.LogLevel, msg string, args ...interface{}) {
 	rt.logs = append(rt.logs, fmt.Sprintf(msg, args...))
 }
 
+func (rt *Runtime) EmitEvent(entries ...runtime.EventEntry) {
+	rt.requireInCall()
+	rt.events = append(rt.events, runtime.ActorEvent{Entries: entries})
+}
+
+// Returns the events emitted by calls since the runtime was built or ClearEvents was called,
+// excluding those emitted by calls which aborted.
+func (rt *Runtime) Events() []runtime.ActorEvent {
+	return rt.events
+}
+
+func (rt *Runtime) ClearEvents() {
+	rt.events = nil
+}
+
 ///// Trace span implementation /////
 
 type TraceSpan struct {
@@ -782,11 +927,15 @@ func (t TraceSpan) End() {
 }
 
 type abort struct {
-	code exitcode.ExitCode
-	msg  string
+	code     exitcode.ExitCode
+	msg      string
+	envelope *builtin.AbortEnvelope
 }
 
 func (a abort) String() string {
+	if a.envelope != nil {
+		return a.envelope.String()
+	}
 	return fmt.Sprintf("abort(%v): %s", a.code, a.msg)
 }
 
@@ -848,6 +997,7 @@ func (rt *Runtime) SetNetworkVersion(v network.Version) network.Version {
 
 func (rt *Runtime) SetEpoch(epoch abi.ChainEpoch) abi.ChainEpoch {
 	rt.epoch = epoch
+	rt.expireRandomness()
 	return epoch
 }
 
@@ -923,9 +1073,67 @@ func (rt *Runtime) ExpectSend(toAddr addr.Address, methodNum abi.MethodNum, para
 		value:      value,
 		sendReturn: ret,
 		exitCode:   exitCode,
+		group:      rt.expectSendGroup,
 	})
 }
 
+// Expects a send with a gas limit, as by SendWithGasLimit.
+func (rt *Runtime) ExpectSendWithGasLimit(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, gasLimit int64, ret cbor.Er, exitCode exitcode.ExitCode) {
+	rt.ExpectSend(toAddr, methodNum, params, value, ret, exitCode)
+	rt.expectSends[len(rt.expectSends)-1].gasLimit = gasLimit
+}
+
+// Describes an expected send in full, for expectations combining several options.
+// A nil Value expects a send of zero value.
+type SendSpec struct {
+	To            addr.Address
+	Method        abi.MethodNum
+	Params        cbor.Marshaler
+	ParamsMatcher Matcher
+	Value         abi.TokenAmount
+	GasLimit      int64
+	Return        cbor.Er
+	ExitCode      exitcode.ExitCode
+	Optional      bool
+}
+
+// Expects a send as described by a spec.
+func (rt *Runtime) ExpectSendSpec(spec SendSpec) {
+	value := spec.Value
+	if value.Int == nil {
+		value = big.Zero()
+	}
+	rt.ExpectSend(spec.To, spec.Method, spec.Params, value, spec.Return, spec.ExitCode)
+	exp := rt.expectSends[len(rt.expectSends)-1]
+	exp.paramsMatcher = spec.ParamsMatcher
+	exp.gasLimit = spec.GasLimit
+	exp.optional = spec.Optional
+}
+
+// Expects a send with params satisfying a matcher, rather than equal to given params.
+func (rt *Runtime) ExpectSendMatching(toAddr addr.Address, methodNum abi.MethodNum, params Matcher, value abi.TokenAmount, ret cbor.Er, exitCode exitcode.ExitCode) {
+	rt.ExpectSend(toAddr, methodNum, nil, value, ret, exitCode)
+	rt.expectSends[len(rt.expectSends)-1].paramsMatcher = params
+}
+
+// Expects a send that may be omitted. If made, it must be made in order with the other expected sends.
+func (rt *Runtime) ExpectSendOptional(toAddr addr.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, ret cbor.Er, exitCode exitcode.ExitCode) {
+	rt.ExpectSend(toAddr, methodNum, params, value, ret, exitCode)
+	rt.expectSends[len(rt.expectSends)-1].optional = true
+}
+
+// Expects the sends expected within f to be made in any order among themselves, at their place in the
+// order of other expected sends. This suits actor code that makes independent sends in an unspecified order.
+func (rt *Runtime) ExpectSendsUnordered(f func()) {
+	if rt.expectSendGroup != 0 {
+		rt.failTestNow("nested unordered send expectations")
+	}
+	rt.nextSendGroup++
+	rt.expectSendGroup = rt.nextSendGroup
+	defer func() { rt.expectSendGroup = 0 }()
+	f()
+}
+
 func (rt *Runtime) ExpectVerifySignature(sig crypto.Signature, signer addr.Address, plaintext []byte, result error) {
 	rt.expectVerifySigs = append(rt.expectVerifySigs, &expectVerifySig{
 		sig:       sig,
@@ -953,6 +1161,14 @@ func (rt *Runtime) ExpectVerifySeal(seal proof.SealVerifyInfo, result error) {
 	}
 }
 
+// Expects a seal verification with seal info satisfying a matcher, rather than equal to given seal info.
+func (rt *Runtime) ExpectVerifySealMatching(seal Matcher, result error) {
+	rt.expectVerifySeal = &expectVerifySeal{
+		matcher: seal,
+		result:  result,
+	}
+}
+
 func (rt *Runtime) ExpectBatchVerifySeals(in map[addr.Address][]proof.SealVerifyInfo, out map[addr.Address][]bool, err error) {
 	rt.expectBatchVerifySeals = &expectBatchVerifySeals{
 		in, out, err,
@@ -1013,8 +1229,8 @@ func (rt *Runtime) Verify() {
 	if len(rt.expectRandomnessTickets) > 0 {
 		rt.failTest("missing expected ticket randomness %v", rt.expectRandomnessTickets)
 	}
-	if len(rt.expectSends) > 0 {
-		rt.failTest("missing expected send %v", rt.expectSends)
+	if missing := requiredSends(rt.expectSends); len(missing) > 0 {
+		rt.failTest("missing expected send %v", missing)
 	}
 	if len(rt.expectVerifySigs) > 0 {
 		rt.failTest("missing expected verify signature %v", rt.expectVerifySigs)
@@ -1070,6 +1286,7 @@ func (rt *Runtime) Reset() {
 	rt.expectVerifySeal = nil
 	rt.expectBatchVerifySeals = nil
 	rt.expectComputeUnsealedSectorCID = nil
+	rt.abortEnvelope = nil
 }
 
 // Calls f() expecting it to invoke Runtime.Abortf() with a specified exit code.
@@ -1081,6 +1298,7 @@ func (rt *Runtime) ExpectAbort(expected exitcode.ExitCode, f func()) {
 func (rt *Runtime) ExpectAbortContainsMessage(expected exitcode.ExitCode, substr string, f func()) {
 	rt.t.Helper()
 	prevState := rt.state
+	prevEvents := len(rt.events)
 
 	defer func() {
 		rt.t.Helper()
@@ -1094,19 +1312,43 @@ func (rt *Runtime) ExpectAbortContainsMessage(expected exitcode.ExitCode, substr
 			panic(r)
 		}
 		if a.code != expected {
-			rt.failTest("abort expected code %v, got %v %s", expected, a.code, a.msg)
+			rt.failTest("abort expected code %v, got %v", expected, a)
 		}
 		if substr != "" {
 			if !strings.Contains(a.msg, substr) {
 				rt.failTest("abort expected message\n'%s'\nto contain\n'%s'\n", a.msg, substr)
 			}
 		}
-		// Roll back state change.
+		// Roll back state change and events.
 		rt.state = prevState
+		rt.events = rt.events[:prevEvents]
 	}()
 	f()
 }
 
+// Calls a method that may abort, returning the method's return value if it succeeds, or the exit code of the
+// abort, with state changes rolled back as by ExpectAbort. Panics other than aborts are not recovered.
+// This suits calls with arbitrary parameters, such as by fuzzers, for which success is not known in advance.
+func (rt *Runtime) CallMayAbort(method interface{}, params interface{}) (ret interface{}, code exitcode.ExitCode) {
+	prevState := rt.state
+	prevEvents := len(rt.events)
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		a, ok := r.(abort)
+		if !ok {
+			panic(r)
+		}
+		rt.state = prevState
+		rt.events = rt.events[:prevEvents]
+		ret, code = nil, a.code
+	}()
+	return rt.Call(method, params), exitcode.Ok
+}
+
 func (rt *Runtime) ExpectLogsContain(substr string) {
 	for _, msg := range rt.logs {
 		if strings.Contains(msg, substr) {
@@ -1212,6 +1454,7 @@ func (rt *Runtime) ChargeGas(_ string, gas, _ int64) {
 	if gas != expectedGas {
 		rt.failTest("expected gas charged: %d, actual gas charged: %d", gas, expectedGas)
 	}
+	rt.record(Interaction{Kind: InteractionChargeGas, Gas: gas})
 }
 
 func getMethodName(code cid.Cid, num abi.MethodNum) string {
@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Records test failures reported by a mock runtime, rather than failing the test.
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+type failedNow struct{}
+
+func (r *failureRecorder) Helper()                       {}
+func (r *failureRecorder) Logf(string, ...interface{})   {}
+func (r *failureRecorder) Fail()                         { r.failed = true }
+func (r *failureRecorder) FailNow()                      { r.failed = true; panic(failedNow{}) }
+func (r *failureRecorder) Errorf(string, ...interface{}) { r.failed = true }
+
+// Calls a method sending one token to each of the targets in turn, returning whether the test failed.
+func sendEach(rt *Runtime, targets ...addr.Address) (failed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(failedNow); !ok {
+				panic(r)
+			}
+			failed = true
+		}
+	}()
+	rt.Call(func(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+		for _, to := range targets {
+			rt.Send(to, builtin.MethodSend, nil, big.NewInt(1), &builtin.Discard{})
+		}
+		return nil
+	}, nil)
+	return false
+}
+
+func TestSendExpectations(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	a, b, c, d := tutil.NewIDAddr(t, 101), tutil.NewIDAddr(t, 102), tutil.NewIDAddr(t, 103), tutil.NewIDAddr(t, 104)
+	builder := NewBuilder(receiver).WithBalance(big.NewInt(10), big.Zero())
+	one := big.NewInt(1)
+	expect := func(rt *Runtime, to addr.Address) {
+		rt.ExpectSend(to, builtin.MethodSend, nil, one, nil, exitcode.Ok)
+	}
+
+	build := func(t *testing.T) (*Runtime, *failureRecorder) {
+		recorder := &failureRecorder{TB: t}
+		return builder.Build(recorder), recorder
+	}
+
+	t.Run("ordered sends must match in order", func(t *testing.T) {
+		rt, recorder := build(t)
+		expect(rt, a)
+		expect(rt, b)
+		assert.True(t, sendEach(rt, b, a))
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("unordered sends match in any order", func(t *testing.T) {
+		rt, recorder := build(t)
+		expect(rt, a)
+		rt.ExpectSendsUnordered(func() {
+			expect(rt, b)
+			expect(rt, c)
+		})
+		expect(rt, d)
+		require.False(t, sendEach(rt, a, c, b, d))
+		rt.Verify()
+		assert.False(t, recorder.failed)
+		assert.Equal(t, big.NewInt(6), rt.Balance())
+	})
+
+	t.Run("unordered group does not match sends outside its place", func(t *testing.T) {
+		rt, recorder := build(t)
+		expect(rt, a)
+		rt.ExpectSendsUnordered(func() {
+			expect(rt, b)
+			expect(rt, c)
+		})
+		assert.True(t, sendEach(rt, b))
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("missing unordered send fails verification", func(t *testing.T) {
+		rt, recorder := build(t)
+		rt.ExpectSendsUnordered(func() {
+			expect(rt, a)
+			expect(rt, b)
+		})
+		require.False(t, sendEach(rt, b))
+		rt.Verify()
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("optional sends may be made or omitted", func(t *testing.T) {
+		for _, sent := range [][]addr.Address{{a, b, c}, {a, c}} {
+			rt, recorder := build(t)
+			expect(rt, a)
+			rt.ExpectSendOptional(b, builtin.MethodSend, nil, one, nil, exitcode.Ok)
+			expect(rt, c)
+			require.False(t, sendEach(rt, sent...))
+			rt.Verify()
+			assert.False(t, recorder.failed)
+		}
+	})
+
+	t.Run("omitted trailing optional send passes verification", func(t *testing.T) {
+		rt, recorder := build(t)
+		expect(rt, a)
+		rt.ExpectSendOptional(b, builtin.MethodSend, nil, one, nil, exitcode.Ok)
+		require.False(t, sendEach(rt, a))
+		rt.Verify()
+		assert.False(t, recorder.failed)
+	})
+
+	t.Run("skipped optional send cannot be made later", func(t *testing.T) {
+		rt, recorder := build(t)
+		rt.ExpectSendOptional(a, builtin.MethodSend, nil, one, nil, exitcode.Ok)
+		expect(rt, b)
+		assert.True(t, sendEach(rt, b, a))
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("optional sends in an unordered group", func(t *testing.T) {
+		rt, recorder := build(t)
+		rt.ExpectSendsUnordered(func() {
+			expect(rt, a)
+			rt.ExpectSendOptional(b, builtin.MethodSend, nil, one, nil, exitcode.Ok)
+		})
+		expect(rt, c)
+		require.False(t, sendEach(rt, a, c))
+		rt.Verify()
+		assert.False(t, recorder.failed)
+	})
+}
@@ -0,0 +1,181 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// The environment variable which, when set, causes golden scripts to be re-recorded rather than replayed.
+const UpdateGoldenEnv = "SPECS_ACTORS_UPDATE_GOLDEN"
+
+// A serializable record of the interactions of an actor with the mock runtime, each of which satisfied an
+// expectation, from which the same expectations may be set again.
+// Only the interactions below are recorded; others must still be expected explicitly when replaying.
+type Script struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type InteractionKind string
+
+const (
+	InteractionValidateCallerAny  InteractionKind = "validate_caller_any"
+	InteractionValidateCallerAddr InteractionKind = "validate_caller_addr"
+	InteractionValidateCallerType InteractionKind = "validate_caller_type"
+	InteractionRandomnessBeacon   InteractionKind = "randomness_beacon"
+	InteractionRandomnessTickets  InteractionKind = "randomness_tickets"
+	InteractionSend               InteractionKind = "send"
+	InteractionVerifySignature    InteractionKind = "verify_signature"
+	InteractionCreateActor        InteractionKind = "create_actor"
+	InteractionDeleteActor        InteractionKind = "delete_actor"
+	InteractionChargeGas          InteractionKind = "charge_gas"
+)
+
+// A single interaction, with the fields relevant to its kind set.
+type Interaction struct {
+	Kind InteractionKind `json:"kind"`
+	// The target of a send, address of a created actor, beneficiary of a deleted actor, or signer of a signature.
+	Address   *addr.Address              `json:"address,omitempty"`
+	Addresses []addr.Address             `json:"addresses,omitempty"`
+	Codes     []cid.Cid                  `json:"codes,omitempty"`
+	Tag       crypto.DomainSeparationTag `json:"tag,omitempty"`
+	Epoch     abi.ChainEpoch             `json:"epoch,omitempty"`
+	Entropy   []byte                     `json:"entropy,omitempty"`
+	Method    abi.MethodNum              `json:"method,omitempty"`
+	Params    []byte                     `json:"params,omitempty"`
+	Value     *abi.TokenAmount           `json:"value,omitempty"`
+	GasLimit  int64                      `json:"gasLimit,omitempty"`
+	Signature *crypto.Signature          `json:"signature,omitempty"`
+	Plaintext []byte                     `json:"plaintext,omitempty"`
+	Gas       int64                      `json:"gas,omitempty"`
+	// Results: randomness, or the return value and exit code of a send, or the error of a signature verification.
+	Return   []byte            `json:"return,omitempty"`
+	ExitCode exitcode.ExitCode `json:"exitCode,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// Starts recording the interactions of actor code with the runtime into a script, which grows as they are made.
+func (rt *Runtime) StartRecording() *Script {
+	rt.recording = &Script{}
+	return rt.recording
+}
+
+// Stops recording, returning the script recorded.
+func (rt *Runtime) StopRecording() *Script {
+	script := rt.recording
+	rt.recording = nil
+	return script
+}
+
+func (rt *Runtime) record(i Interaction) {
+	if rt.recording != nil {
+		rt.recording.Interactions = append(rt.recording.Interactions, i)
+	}
+}
+
+// Sets the expectations satisfied by the interactions of a script, in order.
+func (rt *Runtime) ExpectScript(script *Script) {
+	for _, i := range script.Interactions {
+		switch i.Kind {
+		case InteractionValidateCallerAny:
+			rt.ExpectValidateCallerAny()
+		case InteractionValidateCallerAddr:
+			rt.ExpectValidateCallerAddr(i.Addresses...)
+		case InteractionValidateCallerType:
+			rt.ExpectValidateCallerType(i.Codes...)
+		case InteractionRandomnessBeacon:
+			rt.ExpectGetRandomnessBeacon(i.Tag, i.Epoch, i.Entropy, i.Return)
+		case InteractionRandomnessTickets:
+			rt.ExpectGetRandomnessTickets(i.Tag, i.Epoch, i.Entropy, i.Return)
+		case InteractionSend:
+			var params cbor.Marshaler
+			if len(i.Params) > 0 {
+				params = &cbg.Deferred{Raw: i.Params}
+			}
+			var ret cbor.Er
+			if len(i.Return) > 0 {
+				ret = &cbg.Deferred{Raw: i.Return}
+			}
+			rt.ExpectSendWithGasLimit(*i.Address, i.Method, params, *i.Value, i.GasLimit, ret, i.ExitCode)
+		case InteractionVerifySignature:
+			var result error
+			if i.Error != "" {
+				result = errors.New(i.Error)
+			}
+			rt.ExpectVerifySignature(*i.Signature, *i.Address, i.Plaintext, result)
+		case InteractionCreateActor:
+			rt.ExpectCreateActor(i.Codes[0], *i.Address)
+		case InteractionDeleteActor:
+			rt.ExpectDeleteActor(*i.Address)
+		case InteractionChargeGas:
+			rt.ExpectGasCharged(i.Gas)
+		default:
+			rt.failTestNow("unknown interaction kind %q in script", i.Kind)
+		}
+	}
+}
+
+// Reads a script written by WriteScript.
+func ReadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var script Script
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, xerrors.Errorf("failed to parse script %s: %w", path, err)
+	}
+	return &script, nil
+}
+
+// Writes a script as indented JSON, creating its directory if need be.
+func WriteScript(path string, script *Script) error {
+	data, err := json.MarshalIndent(script, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Sets expectations from the script in a golden file, returning false. If the file doesn't exist, or the
+// SPECS_ACTORS_UPDATE_GOLDEN environment variable is set, instead records a script and returns true, in which case
+// the test must set the expectations itself. The recorded script is written to the file if the test passes.
+func (rt *Runtime) ExpectGolden(path string) bool {
+	rt.t.Helper()
+	_, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		rt.failTestNow("failed to read golden script %s: %v", path, err)
+	}
+	if err == nil && os.Getenv(UpdateGoldenEnv) == "" {
+		script, err := ReadScript(path)
+		if err != nil {
+			rt.failTestNow("%v", err)
+		}
+		rt.ExpectScript(script)
+		return false
+	}
+
+	script := rt.StartRecording()
+	rt.t.Cleanup(func() {
+		if rt.t.Failed() {
+			return
+		}
+		if err := WriteScript(path, script); err != nil {
+			rt.t.Errorf("failed to write golden script %s: %v", path, err)
+		}
+	})
+	return true
+}
@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	tutil "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	receiver := tutil.NewIDAddr(t, 100)
+	signer := tutil.NewIDAddr(t, 101)
+	builder := NewBuilder(receiver).WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).WithEpoch(10)
+	sig := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("sig")}
+	powerReturn := &power.CurrentTotalPowerReturn{
+		RawBytePower:     big.NewInt(1 << 40),
+		QualityAdjPower:  big.NewInt(1 << 41),
+		PledgeCollateral: big.NewInt(7),
+	}
+	claim := &power.UpdateClaimedPowerParams{RawByteDelta: big.NewInt(1), QualityAdjustedDelta: big.NewInt(1)}
+
+	// A method interacting with the runtime in several ways, returning the total power it received.
+	method := func(rt runtime.Runtime, _ *abi.EmptyValue) *power.CurrentTotalPowerReturn {
+		rt.ValidateImmediateCallerIs(builtin.InitActorAddr)
+		rt.GetRandomnessFromBeacon(crypto.DomainSeparationTag_SealRandomness, 5, []byte("entropy"))
+		if err := rt.VerifySignature(sig, signer, []byte("plaintext")); err == nil {
+			rt.Abortf(exitcode.ErrIllegalArgument, "expected invalid signature")
+		}
+		var ret power.CurrentTotalPowerReturn
+		code := rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), &ret)
+		builtin.RequireSuccess(rt, code, "failed to get total power")
+		rt.Send(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, claim, big.Zero(), &builtin.Discard{})
+		return &ret
+	}
+	requireTotalPower := func(t *testing.T, ret interface{}) {
+		require.IsType(t, &power.CurrentTotalPowerReturn{}, ret)
+		assert.Equal(t, powerReturn.RawBytePower, ret.(*power.CurrentTotalPowerReturn).RawBytePower)
+		assert.Equal(t, powerReturn.PledgeCollateral, ret.(*power.CurrentTotalPowerReturn).PledgeCollateral)
+	}
+	expect := func(rt *Runtime) {
+		rt.ExpectValidateCallerAddr(builtin.InitActorAddr)
+		rt.ExpectGetRandomnessBeacon(crypto.DomainSeparationTag_SealRandomness, 5, []byte("entropy"), abi.Randomness("random"))
+		rt.ExpectVerifySignature(sig, signer, []byte("plaintext"), xerrors.New("invalid"))
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.CurrentTotalPower, nil, big.Zero(), powerReturn, exitcode.Ok)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.UpdateClaimedPower, claim, big.Zero(), nil, exitcode.Ok)
+	}
+
+	t.Run("script round trip", func(t *testing.T) {
+		rt := builder.Build(t)
+		script := rt.StartRecording()
+		expect(rt)
+		ret := rt.Call(method, nil)
+		rt.Verify()
+		requireTotalPower(t, ret)
+		assert.Same(t, script, rt.StopRecording())
+		require.Len(t, script.Interactions, 5)
+
+		path := filepath.Join(t.TempDir(), "script.json")
+		require.NoError(t, WriteScript(path, script))
+		read, err := ReadScript(path)
+		require.NoError(t, err)
+
+		rt = builder.Build(t)
+		rt.ExpectScript(read)
+		ret = rt.Call(method, nil)
+		rt.Verify()
+		requireTotalPower(t, ret)
+	})
+
+	t.Run("replay detects changed behaviour", func(t *testing.T) {
+		rt := builder.Build(t)
+		script := rt.StartRecording()
+		expect(rt)
+		rt.Call(method, nil)
+		rt.Verify()
+
+		recorder := &failureRecorder{TB: t}
+		rt = builder.Build(recorder)
+		script.Interactions[4].Params = nil
+		rt.ExpectScript(script)
+		assert.Panics(t, func() { rt.Call(method, nil) })
+		assert.True(t, recorder.failed)
+	})
+
+	t.Run("golden", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "testdata", "golden.json")
+		t.Run("record", func(t *testing.T) {
+			rt := builder.Build(t)
+			require.True(t, rt.ExpectGolden(path))
+			expect(rt)
+			rt.Call(method, nil)
+			rt.Verify()
+		})
+		t.Run("replay", func(t *testing.T) {
+			rt := builder.Build(t)
+			require.False(t, rt.ExpectGolden(path))
+			requireTotalPower(t, rt.Call(method, nil))
+			rt.Verify()
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package testing
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Fuzzes the CBOR decoding of a type, checking that any input it decodes is re-encoded stably: the encoding of
+// a decoded value must itself decode, to a value with the same encoding.
+// Inputs the type fails to decode are ignored, while any panic fails the target.
+// The corpus is seeded with the encoding of a sample value of the type, as made by SampleValue.
+func FuzzCBORRoundTrip(f *testing.F, newValue func() cbor.Er) {
+	f.Add([]byte{})
+	var sample bytes.Buffer
+	if err := SampleValue(reflect.TypeOf(newValue()).Elem()).Addr().Interface().(cbor.Marshaler).MarshalCBOR(&sample); err == nil {
+		f.Add(sample.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded := newValue()
+		if err := decoded.UnmarshalCBOR(bytes.NewReader(data)); err != nil {
+			return
+		}
+		var encoded bytes.Buffer
+		require.NoError(t, decoded.MarshalCBOR(&encoded), "failed to encode decoded value %+v", decoded)
+
+		redecoded := newValue()
+		require.NoError(t, redecoded.UnmarshalCBOR(bytes.NewReader(encoded.Bytes())), "failed to decode encoding %x", encoded.Bytes())
+		var reencoded bytes.Buffer
+		require.NoError(t, redecoded.MarshalCBOR(&reencoded))
+		require.Equal(t, encoded.Bytes(), reencoded.Bytes(), "encoding changed when decoded and encoded again")
+	})
+}
+
+var (
+	addressType  = reflect.TypeOf(addr.Address{})
+	cidType      = reflect.TypeOf(cid.Cid{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bitFieldType = reflect.TypeOf(bitfield.BitField{})
+	deferredType = reflect.TypeOf(cbg.Deferred{})
+)
+
+// Returns an addressable value of a type with every field set to a small non-zero value, and every slice and map
+// holding one element, such as to seed a fuzzer with a value exercising all of a type's encoding.
+// Values of recursive types are truncated with zero values.
+func SampleValue(t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	setSample(v, 0)
+	return v
+}
+
+const maxSampleDepth = 8
+
+func setSample(v reflect.Value, depth int) {
+	if depth > maxSampleDepth {
+		return
+	}
+	switch v.Type() {
+	case addressType:
+		a, _ := addr.NewIDAddress(1000)
+		v.Set(reflect.ValueOf(a))
+		return
+	case cidType:
+		v.Set(reflect.ValueOf(MakeCID("sample", nil)))
+		return
+	case bigIntType:
+		v.Set(reflect.ValueOf(big.NewInt(1)))
+		return
+	case bitFieldType:
+		v.Set(reflect.ValueOf(bitfield.NewFromSet([]uint64{1, 3})))
+		return
+	case deferredType:
+		v.Set(reflect.ValueOf(cbg.Deferred{Raw: cbg.CborNull}))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.String:
+		v.SetString("sample")
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		setSample(v.Elem(), depth+1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		setSample(v.Index(0), depth+1)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			setSample(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		setSample(key, depth+1)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		setSample(elem, depth+1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" { // exported
+				setSample(v.Field(i), depth+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/dline"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
+	actor_testing "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// Onboards count sectors numbered from firstSector: pre-commits them in batches, advances past the
+// pre-commit challenge delay and proves them with aggregate proofs, falling back to individual proofs
+// confirmed by cron when there are too few sectors to aggregate.
+// dealIDs optionally holds the deals to activate in each sector, indexed from firstSector.
+// The miner must hold enough balance for the pre-commit deposits and initial pledge.
+// Returns a VM at the epoch the sectors were proven, and the sector numbers.
+// The sectors gain power when first proven in their deadline, e.g. by SubmitProvingPeriodPoSts.
+func OnboardSectors(t testing.TB, v *VM, worker, minerAddr address.Address, sealProof abi.RegisteredSealProof,
+	firstSector abi.SectorNumber, count int, expiration abi.ChainEpoch, dealIDs [][]abi.DealID,
+) (*VM, []abi.SectorNumber) {
+	sectorNumbers := PreCommitSectors(t, v, worker, minerAddr, sealProof, firstSector, count, expiration, dealIDs)
+
+	proveEpoch := v.GetEpoch() + miner.PreCommitChallengeDelay + 1
+	v, _ = AdvanceByDeadlineTillEpoch(t, v, minerAddr, proveEpoch)
+	v, err := v.WithEpoch(proveEpoch)
+	require.NoError(t, err)
+
+	if count < miner.MinAggregatedSectors {
+		for _, sectorNumber := range sectorNumbers {
+			ApplyOk(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.ProveCommitSector, &miner.ProveCommitSectorParams{
+				SectorNumber: sectorNumber,
+			})
+		}
+		// In the same epoch, trigger cron to confirm the proofs.
+		ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+		return v, sectorNumbers
+	}
+
+	for remaining := sectorNumbers; len(remaining) > 0; {
+		size := len(remaining)
+		if size > miner.MaxAggregatedSectors {
+			size = miner.MaxAggregatedSectors
+			// Leave enough sectors for the last aggregate.
+			if len(remaining)-size < miner.MinAggregatedSectors {
+				size -= miner.MinAggregatedSectors
+			}
+		}
+		toProve := make([]uint64, size)
+		for i, sectorNumber := range remaining[:size] {
+			toProve[i] = uint64(sectorNumber)
+		}
+		remaining = remaining[size:]
+		ApplyOk(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.ProveCommitAggregate, &miner.ProveCommitAggregateParams{
+			SectorNumbers: bitfield.NewFromSet(toProve),
+		})
+	}
+	return v, sectorNumbers
+}
+
+// Pre-commits count sectors numbered from firstSector, in batches, returning their sector numbers.
+// dealIDs optionally holds the deals to be activated in each sector, indexed from firstSector.
+// The miner must hold enough balance for the pre-commit deposits.
+func PreCommitSectors(t testing.TB, v *VM, worker, minerAddr address.Address, sealProof abi.RegisteredSealProof,
+	firstSector abi.SectorNumber, count int, expiration abi.ChainEpoch, dealIDs [][]abi.DealID,
+) []abi.SectorNumber {
+	require.True(t, len(dealIDs) <= count, "deals for %d sectors but pre-committing only %d", len(dealIDs), count)
+	sectorNumbers := make([]abi.SectorNumber, count)
+	for i := range sectorNumbers {
+		sectorNumbers[i] = firstSector + abi.SectorNumber(i)
+	}
+
+	for i := 0; i < count; i += miner.PreCommitSectorBatchMaxSize {
+		var params miner.PreCommitSectorBatchParams
+		for j := i; j < count && j < i+miner.PreCommitSectorBatchMaxSize; j++ {
+			info := miner0.SectorPreCommitInfo{
+				SealProof:     sealProof,
+				SectorNumber:  sectorNumbers[j],
+				SealedCID:     actor_testing.MakeCID(fmt.Sprintf("%s-%d", minerAddr, sectorNumbers[j]), &miner.SealedCIDPrefix),
+				SealRandEpoch: v.GetEpoch() - 1,
+				Expiration:    expiration,
+			}
+			if j < len(dealIDs) {
+				info.DealIDs = dealIDs[j]
+			}
+			params.Sectors = append(params.Sectors, info)
+		}
+		ApplyOk(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.PreCommitSectorBatch, &params)
+	}
+	return sectorNumbers
+}
+
+// Submits Window PoSts for every partition with live sectors not yet proven in each deadline of a full
+// proving period, starting from the current deadline and running cron at the end of each.
+// Returns a VM at the first epoch after the proving period.
+func SubmitProvingPeriodPoSts(t testing.TB, v *VM, minerAddr, worker address.Address) *VM {
+	var err error
+	for i := uint64(0); i < miner.WPoStPeriodDeadlines; i++ {
+		dlInfo := MinerDLInfo(t, v, minerAddr)
+		submitDeadlinePoSts(t, v, minerAddr, worker, dlInfo)
+
+		v, _ = AdvanceByDeadline(t, v, minerAddr, func(next *dline.Info) bool {
+			return next.Index == dlInfo.Index
+		})
+		v, err = v.WithEpoch(v.GetEpoch() + 1)
+		require.NoError(t, err)
+	}
+	return v
+}
+
+func submitDeadlinePoSts(t testing.TB, v *VM, minerAddr, worker address.Address, dlInfo *dline.Info) {
+	var minerState miner.State
+	require.NoError(t, v.GetState(minerAddr, &minerState))
+	info, err := minerState.GetInfo(v.store)
+	require.NoError(t, err)
+
+	dl := DeadlineState(t, v, minerAddr, dlInfo.Index)
+	partitions, err := dl.PartitionsArray(v.store)
+	require.NoError(t, err)
+	var toProve []miner.PoStPartition
+	var partition miner.Partition
+	err = partitions.ForEach(&partition, func(pIdx int64) error {
+		live, err := partition.LiveSectors()
+		if err != nil {
+			return err
+		}
+		if empty, err := live.IsEmpty(); err != nil || empty {
+			return err
+		}
+		if posted, err := dl.PartitionsPoSted.IsSet(uint64(pIdx)); err != nil || posted {
+			return err
+		}
+		toProve = append(toProve, miner.PoStPartition{Index: uint64(pIdx), Skipped: bitfield.New()})
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Each message may address only as many partitions as their sectors fit within the addressed sectors limit.
+	limit := int(miner.AddressedSectorsMax / info.WindowPoStPartitionSectors)
+	if limit < 1 {
+		limit = 1
+	}
+	for len(toProve) > 0 {
+		size := limit
+		if size > len(toProve) {
+			size = len(toProve)
+		}
+		ApplyOk(t, v, worker, minerAddr, big.Zero(), builtin.MethodsMiner.SubmitWindowedPoSt, &miner.SubmitWindowedPoStParams{
+			Deadline:         dlInfo.Index,
+			Partitions:       toProve[:size],
+			Proofs:           []proof.PoStProof{{PoStProof: info.WindowPoStProofType}},
+			ChainCommitEpoch: dlInfo.Challenge,
+			ChainCommitRand:  ChainCommitRand(v, dlInfo.Challenge),
+		})
+		toProve = toProve[size:]
+	}
+}
+
+// Publishes count deals with a miner in a single message sent by its worker, assigning them to the clients
+// in turn. Each deal copies the template, with its client, provider, label and piece CID filled in so that
+// every deal is distinct. The clients and provider must have escrowed enough to cover the deals.
+// Returns the IDs of the published deals, in order.
+func PublishDeals(t testing.TB, v *VM, worker, minerAddr address.Address, clients []address.Address, count int,
+	template market.DealProposal,
+) []abi.DealID {
+	params := PublishDealsParams(t, v, minerAddr, clients, count, template)
+	ret := ApplyOk(t, v, worker, builtin.StorageMarketActorAddr, big.Zero(), builtin.MethodsMarket.PublishStorageDeals, params)
+	ids := ret.(*market.PublishStorageDealsReturn).IDs
+	require.Len(t, ids, count)
+	return ids
+}
+
+// Returns the parameters with which PublishDeals publishes count deals, as of the VM's current state.
+func PublishDealsParams(t testing.TB, v *VM, minerAddr address.Address, clients []address.Address, count int,
+	template market.DealProposal,
+) *market.PublishStorageDealsParams {
+	require.NotEmpty(t, clients)
+	var marketState market.State
+	require.NoError(t, v.GetState(builtin.StorageMarketActorAddr, &marketState))
+
+	var params market.PublishStorageDealsParams
+	for i := 0; i < count; i++ {
+		deal := template
+		deal.Client = clients[i%len(clients)]
+		deal.Provider = minerAddr
+		deal.Label = fmt.Sprintf("%s-%d", template.Label, marketState.NextID+abi.DealID(i))
+		deal.PieceCID = actor_testing.MakeCID(deal.Label, &market.PieceCIDPrefix)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, deal.MarshalCBOR(buf))
+		params.Deals = append(params.Deals, market.ClientDealProposal{
+			Proposal:        deal,
+			ClientSignature: crypto.Signature{Type: crypto.SigTypeBLS, Data: buf.Bytes()},
+		})
+	}
+	return &params
+}
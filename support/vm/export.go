@@ -0,0 +1,99 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Receives the state of a VM at the end of each epoch, as the VM advances to a later one.
+type StateExporter interface {
+	ExportState(store adt.Store, epoch abi.ChainEpoch, root cid.Cid) error
+}
+
+// The state root of a VM at the end of an epoch.
+type EpochState struct {
+	Epoch abi.ChainEpoch
+	Root  cid.Cid
+}
+
+// Records the state root at the end of each epoch, optionally persisting the roots and state trees to a directory:
+// each root is appended to a file named roots.txt, and if CARs is set, each state tree is written to a CAR file
+// named for its epoch, for inspection with the state diff tooling.
+type StateRecorder struct {
+	Dir    string
+	CARs   bool
+	States []EpochState
+}
+
+var _ StateExporter = (*StateRecorder)(nil)
+
+func (r *StateRecorder) ExportState(store adt.Store, epoch abi.ChainEpoch, root cid.Cid) error {
+	r.States = append(r.States, EpochState{Epoch: epoch, Root: root})
+	if r.Dir == "" {
+		return nil
+	}
+	if err := writeFile(filepath.Join(r.Dir, "roots.txt"), os.O_APPEND, func(f *os.File) error {
+		_, err := fmt.Fprintf(f, "%d %s\n", epoch, root)
+		return err
+	}); err != nil {
+		return xerrors.Errorf("failed to record state root at epoch %d: %w", epoch, err)
+	}
+	if r.CARs {
+		if err := writeFile(r.CARPath(epoch), os.O_TRUNC, func(f *os.File) error {
+			return states.ExportCAR(store, root, f)
+		}); err != nil {
+			return xerrors.Errorf("failed to export state at epoch %d: %w", epoch, err)
+		}
+	}
+	return nil
+}
+
+// Returns the path of the CAR file holding the state tree at the end of an epoch.
+func (r *StateRecorder) CARPath(epoch abi.ChainEpoch) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("%d.car", epoch))
+}
+
+// Returns the earliest recorded state for which a predicate holds, such as that an invariant is broken,
+// searching by bisection on the assumption that the predicate continues to hold for all later states.
+func (r *StateRecorder) FirstWhere(predicate func(EpochState) (bool, error)) (EpochState, bool, error) {
+	var predicateErr error
+	i := sort.Search(len(r.States), func(i int) bool {
+		if predicateErr != nil {
+			return true
+		}
+		holds, err := predicate(r.States[i])
+		if err != nil {
+			predicateErr = err
+			return true
+		}
+		return holds
+	})
+	if predicateErr != nil {
+		return EpochState{}, false, predicateErr
+	}
+	if i == len(r.States) {
+		return EpochState{}, false, nil
+	}
+	return r.States[i], true, nil
+}
+
+func writeFile(path string, flag int, write func(f *os.File) error) error {
+	f, err := os.OpenFile(path, flag|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
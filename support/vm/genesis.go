@@ -0,0 +1,241 @@
+package vm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
+	initactor "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/system"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	actor_testing "github.com/filecoin-project/specs-actors/v7/support/testing"
+)
+
+// A declarative description of the actors with which a test VM begins, in addition to the singleton actors.
+// Actors are created in order: accounts, then multisigs, then the verified registry root, then miners,
+// so that later actors may refer to accounts by index.
+type Genesis struct {
+	Accounts []GenesisAccount
+	// Seed from which the accounts' key addresses are generated.
+	Seed      int64
+	Multisigs []GenesisMultisig
+	// A multisig to hold the verified registry's root key.
+	// If nil, the root key is held by an account at VerifregRoot.
+	VerifregRoot *GenesisMultisig
+	Miners       []GenesisMiner
+}
+
+// An account with an initial balance.
+type GenesisAccount struct {
+	Balance abi.TokenAmount
+}
+
+// A multisig funded by its first signer, which vests its balance linearly from genesis if UnlockDuration is positive.
+type GenesisMultisig struct {
+	Signers        []int // Indexes of the signers in Genesis.Accounts.
+	Threshold      uint64
+	Balance        abi.TokenAmount
+	UnlockDuration abi.ChainEpoch
+}
+
+// A miner, owned and worked by an account, with sectors committed at genesis.
+// The pre-sealed sectors have no deals, and are unproven until the miner's first Window PoSt of their deadline,
+// as are sectors committed after genesis.
+type GenesisMiner struct {
+	Owner     int // Index of the owner in Genesis.Accounts.
+	SealProof abi.RegisteredSealProof
+	// Balance transferred from the owner when the miner is created,
+	// which must cover the pre-commit deposit and initial pledge of the pre-sealed sectors.
+	Balance abi.TokenAmount
+	Sectors int
+	// Expiration of the pre-sealed sectors, or zero for about the minimum sector lifetime.
+	Expiration abi.ChainEpoch
+}
+
+// Addresses of the actors created for a genesis, indexed as in the genesis.
+type GenesisActors struct {
+	Accounts     []address.Address // Key addresses.
+	Multisigs    []address.Address // ID addresses.
+	VerifregRoot address.Address
+	Miners       []*power.CreateMinerReturn
+}
+
+// Creates a new VM with the singleton actors and the actors described by a genesis.
+// The genesis epoch, zero, ends with a cron tick as does every epoch, and the VM is returned at the next epoch,
+// so the state satisfies the invariants checked at the end of an epoch without a further tick.
+func NewVMWithGenesis(ctx context.Context, t testing.TB, bs ipldcbor.IpldBlockstore, g Genesis) (*VM, *GenesisActors) {
+	vm := createGenesisSingletons(ctx, t, bs)
+	actors := &GenesisActors{VerifregRoot: VerifregRoot}
+
+	for i, account := range g.Accounts {
+		addrs := CreateAccounts(ctx, t, vm, 1, orZero(account.Balance), g.Seed+int64(i))
+		actors.Accounts = append(actors.Accounts, addrs[0])
+	}
+	for _, ms := range g.Multisigs {
+		actors.Multisigs = append(actors.Multisigs, createGenesisMultisig(t, vm, actors.Accounts, ms))
+	}
+	if g.VerifregRoot != nil {
+		actors.VerifregRoot = createGenesisMultisig(t, vm, actors.Accounts, *g.VerifregRoot)
+		var st verifreg.State
+		require.NoError(t, vm.GetState(builtin.VerifiedRegistryActorAddr, &st))
+		st.RootKey = actors.VerifregRoot
+		require.NoError(t, vm.SetActorState(ctx, builtin.VerifiedRegistryActorAddr, &st))
+	}
+	for _, m := range g.Miners {
+		actors.Miners = append(actors.Miners, createGenesisMiner(t, vm, actors.Accounts, m))
+	}
+	applyGenesisMessage(t, vm, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+
+	vm, err := vm.WithEpoch(vm.GetEpoch() + 1)
+	require.NoError(t, err)
+	return vm, actors
+}
+
+// Creates a new VM with the singleton actors, and the governance account holding the verified registry root key.
+func createGenesisSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBlockstore) *VM {
+	store := adt.WrapBlockStore(ctx, bs)
+	vm := NewVM(ctx, builtinActorImpls(), store)
+
+	initializeActor(ctx, t, vm, &system.State{}, builtin.SystemActorCodeID, builtin.SystemActorAddr, big.Zero())
+
+	initState, err := initactor.ConstructState(store, "scenarios")
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, initState, builtin.InitActorCodeID, builtin.InitActorAddr, big.Zero())
+
+	rewardState := reward.ConstructState(abi.NewStoragePower(0))
+	initializeActor(ctx, t, vm, rewardState, builtin.RewardActorCodeID, builtin.RewardActorAddr, reward.StorageMiningAllocationCheck)
+
+	cronState, err := cron.ConstructState(store, cron.BuiltInEntries())
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, cronState, builtin.CronActorCodeID, builtin.CronActorAddr, big.Zero())
+
+	powerState, err := power.ConstructState(store)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, powerState, builtin.StoragePowerActorCodeID, builtin.StoragePowerActorAddr, big.Zero())
+
+	marketState, err := market.ConstructState(store)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, marketState, builtin.StorageMarketActorCodeID, builtin.StorageMarketActorAddr, big.Zero())
+
+	// The governance actor is an account, which also holds the root key unless the genesis declares a multisig to hold it.
+	initializeActor(ctx, t, vm, &account.State{Address: builtin.GovernanceActorAddr}, builtin.AccountActorCodeID, builtin.GovernanceActorAddr, big.Zero())
+	vrState, err := verifreg.ConstructState(store, VerifregRoot)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, vrState, builtin.VerifiedRegistryActorCodeID, builtin.VerifiedRegistryActorAddr, big.Zero())
+
+	// burnt funds
+	initializeActor(ctx, t, vm, &account.State{Address: builtin.BurntFundsActorAddr}, builtin.AccountActorCodeID, builtin.BurntFundsActorAddr, big.Zero())
+	return vm
+}
+
+func createGenesisMultisig(t testing.TB, vm *VM, accounts []address.Address, ms GenesisMultisig) address.Address {
+	require.NotEmpty(t, ms.Signers, "genesis multisig has no signers")
+	signers := make([]address.Address, len(ms.Signers))
+	for i, idx := range ms.Signers {
+		signers[i] = accounts[idx]
+	}
+	ctorParams := multisig.ConstructorParams{
+		Signers:               signers,
+		NumApprovalsThreshold: ms.Threshold,
+		UnlockDuration:        ms.UnlockDuration,
+		StartEpoch:            vm.GetEpoch(),
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ctorParams.MarshalCBOR(buf))
+
+	ret := applyGenesisMessage(t, vm, signers[0], builtin.InitActorAddr, orZero(ms.Balance), builtin.MethodsInit.Exec, &initactor.ExecParams{
+		CodeCID:           builtin.MultisigActorCodeID,
+		ConstructorParams: buf.Bytes(),
+	})
+	return ret.(*initactor.ExecReturn).IDAddress
+}
+
+// Creates a miner and commits its pre-sealed sectors, which are pre-committed as normal and then confirmed
+// as if their proofs had been verified, skipping the pre-commit challenge delay.
+func createGenesisMiner(t testing.TB, vm *VM, accounts []address.Address, m GenesisMiner) *power.CreateMinerReturn {
+	owner := accounts[m.Owner]
+	wPoStProof, err := m.SealProof.RegisteredWindowPoStProof()
+	require.NoError(t, err)
+	ret := applyGenesisMessage(t, vm, owner, builtin.StoragePowerActorAddr, orZero(m.Balance), builtin.MethodsPower.CreateMiner, &power.CreateMinerParams{
+		Owner:               owner,
+		Worker:              owner,
+		WindowPoStProofType: wPoStProof,
+		Peer:                abi.PeerID("genesis miner"),
+	})
+	minerAddrs := ret.(*power.CreateMinerReturn)
+	if m.Sectors == 0 {
+		return minerAddrs
+	}
+
+	expiration := m.Expiration
+	if expiration == 0 {
+		expiration = vm.GetEpoch() + miner.MaxProveCommitDuration[m.SealProof] + miner.MinSectorExpiration + 100
+	}
+	var sectorNumbers []abi.SectorNumber
+	for i := 0; i < m.Sectors; i += miner.PreCommitSectorBatchMaxSize {
+		var params miner.PreCommitSectorBatchParams
+		for j := i; j < m.Sectors && j < i+miner.PreCommitSectorBatchMaxSize; j++ {
+			sectorNumber := abi.SectorNumber(j)
+			params.Sectors = append(params.Sectors, miner0.SectorPreCommitInfo{
+				SealProof:     m.SealProof,
+				SectorNumber:  sectorNumber,
+				SealedCID:     actor_testing.MakeCID(fmt.Sprintf("%s-%d", minerAddrs.IDAddress, j), &miner.SealedCIDPrefix),
+				SealRandEpoch: vm.GetEpoch() - 1,
+				Expiration:    expiration,
+			})
+			sectorNumbers = append(sectorNumbers, sectorNumber)
+		}
+		applyGenesisMessage(t, vm, owner, minerAddrs.IDAddress, big.Zero(), builtin.MethodsMiner.PreCommitSectorBatch, &params)
+	}
+
+	var rewardSt reward.State
+	require.NoError(t, vm.GetState(builtin.RewardActorAddr, &rewardSt))
+	var powerSt power.State
+	require.NoError(t, vm.GetState(builtin.StoragePowerActorAddr, &powerSt))
+	for i := 0; i < len(sectorNumbers); i += power.MaxMinerProveCommitsPerEpoch {
+		end := i + power.MaxMinerProveCommitsPerEpoch
+		if end > len(sectorNumbers) {
+			end = len(sectorNumbers)
+		}
+		applyGenesisMessage(t, vm, builtin.StoragePowerActorAddr, minerAddrs.IDAddress, big.Zero(), builtin.MethodsMiner.ConfirmSectorProofsValid, &builtin.ConfirmSectorProofsParams{
+			Sectors:                 sectorNumbers[i:end],
+			RewardSmoothed:          rewardSt.ThisEpochRewardSmoothed,
+			RewardBaselinePower:     rewardSt.ThisEpochBaselinePower,
+			QualityAdjPowerSmoothed: powerSt.ThisEpochQAPowerSmoothed,
+		})
+	}
+	return minerAddrs
+}
+
+func applyGenesisMessage(t testing.TB, vm *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}) cbor.Marshaler {
+	result, err := vm.ApplyMessage(from, to, value, method, params, "genesis")
+	require.NoError(t, err)
+	require.Equal(t, exitcode.Ok, result.Code, "genesis message to %s method %d failed", to, method)
+	return result.Ret
+}
+
+func orZero(amount abi.TokenAmount) abi.TokenAmount {
+	if amount.Nil() {
+		return big.Zero()
+	}
+	return amount
+}
@@ -3,7 +3,6 @@ package vm
 import (
 	"bytes"
 	"context"
-	"encoding/binary"
 	"fmt"
 	"reflect"
 	"runtime/debug"
@@ -71,6 +70,7 @@ type topLevelContext struct {
 	gasPrices    Pricelist
 	gasUsed      int64
 	gasAvailable int64
+	gasCharges   []GasCharge // Charges made, excluding any that exceeded the gas available.
 	// Temporary field to workaround test-vector limitations
 	// https://github.com/filecoin-project/specs-actors/issues/1454
 	fakeSyscallsAccessed bool
@@ -83,12 +83,13 @@ func (tc *topLevelContext) chargeGas(gas GasCharge) {
 		tc.gasUsed = tc.gasAvailable
 		panic(
 			abort{
-				exitcode.SysErrOutOfGas,
-				fmt.Sprintf("not enough gas: used=%d, available=%d, attempt to use=%d", gasUsed, tc.gasAvailable, toUse),
+				code: exitcode.SysErrOutOfGas,
+				msg:  fmt.Sprintf("not enough gas: used=%d, available=%d, attempt to use=%d", gasUsed, tc.gasAvailable, toUse),
 			},
 		)
 	}
 	tc.gasUsed += toUse
+	tc.gasCharges = append(tc.gasCharges, gas)
 }
 
 func newInvocationContext(rt *VM, topLevel *topLevelContext, msg InternalMessage, fromActor *states.Actor, emptyObject cid.Cid) invocationContext {
@@ -110,6 +111,7 @@ func newInvocationContext(rt *VM, topLevel *topLevelContext, msg InternalMessage
 var _ runtime.StateHandle = (*invocationContext)(nil)
 
 func (ic *invocationContext) loadState(obj cbor.Unmarshaler) cid.Cid {
+	defer ic.TraceGas(runtime.GasOpStateLoad, fmt.Sprintf("%T", obj))()
 	// The actor must be loaded from store every time since the state may have changed via a different state handle
 	// (e.g. in a recursive call).
 	actr := ic.loadActor()
@@ -147,6 +149,8 @@ func (ic *invocationContext) storeActor(actr *states.Actor) {
 /////////////////////////////////////////////
 
 var _ runtime.Runtime = (*invocationContext)(nil)
+var _ runtime.GasTracer = (*invocationContext)(nil)
+var _ runtime.BatchSignatureVerifier = (*invocationContext)(nil)
 
 // Store implements runtime.Runtime.
 func (ic *invocationContext) StoreGet(c cid.Cid, o cbor.Unmarshaler) bool {
@@ -232,6 +236,15 @@ func (ic *invocationContext) VerifySignature(signature crypto.Signature, signer
 	return ic.Syscalls().VerifySignature(signature, signer, plaintext)
 }
 
+func (ic *invocationContext) BatchVerifySignatures(verifications []runtime.SignatureVerification) []error {
+	// The test VM charges each signature in a batch as if verified alone.
+	results := make([]error, len(verifications))
+	for i, v := range verifications {
+		results[i] = ic.VerifySignature(v.Signature, v.Signer, v.Plaintext)
+	}
+	return results
+}
+
 func (ic *invocationContext) HashBlake2b(data []byte) [32]byte {
 	ic.topLevel.chargeGas(ic.topLevel.gasPrices.OnHashing(len(data)))
 	ic.topLevel.fakeSyscallsAccessed = true
@@ -313,12 +326,12 @@ func (ic *invocationContext) GetActorCodeCID(a address.Address) (cid.Cid, bool)
 	return entry.Code, true
 }
 
-func (ic *invocationContext) GetRandomnessFromBeacon(_ crypto.DomainSeparationTag, _ abi.ChainEpoch, _ []byte) abi.Randomness {
-	return []byte(RandString)
+func (ic *invocationContext) GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	return ic.rt.randomness.GetRandomnessFromBeacon(tag, epoch, entropy)
 }
 
-func (ic *invocationContext) GetRandomnessFromTickets(_ crypto.DomainSeparationTag, _ abi.ChainEpoch, _ []byte) abi.Randomness {
-	return []byte(RandString)
+func (ic *invocationContext) GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	return ic.rt.randomness.GetRandomnessFromTickets(tag, epoch, entropy)
 }
 
 func (ic *invocationContext) ValidateImmediateCallerAcceptAny() {
@@ -349,9 +362,36 @@ func (ic *invocationContext) ValidateImmediateCallerType(types ...cid.Cid) {
 }
 
 func (ic *invocationContext) Abortf(errExitCode exitcode.ExitCode, msg string, args ...interface{}) {
+	if builtin.AbortEnvelopesEnabled {
+		reason := fmt.Sprintf(msg, args...)
+		panic(abort{code: errExitCode, msg: reason, envelope: ic.abortEnvelope(errExitCode, reason)})
+	}
 	ic.rt.Abortf(errExitCode, msg, args...)
 }
 
+// Describes an abort raised by the receiving actor.
+func (ic *invocationContext) abortEnvelope(code exitcode.ExitCode, msg string) *builtin.AbortEnvelope {
+	file, line := builtin.CaptureAbortSite()
+	envelope := &builtin.AbortEnvelope{
+		Receiver: ic.msg.to,
+		Method:   ic.msg.method,
+		Code:     code,
+		Message:  msg,
+		File:     file,
+		Line:     line,
+	}
+	if ic.toActor != nil {
+		envelope.Actor = ic.toActor.Code
+	}
+	if act, found, err := ic.rt.GetActor(ic.msg.to); err == nil && found {
+		envelope.States = append(envelope.States, act.Head)
+	}
+	if root, err := ic.rt.actors.Root(); err == nil {
+		envelope.States = append(envelope.States, root)
+	}
+	return envelope
+}
+
 func (ic *invocationContext) assertf(condition bool, msg string, args ...interface{}) {
 	if !condition {
 		panic(fmt.Errorf(msg, args...))
@@ -363,24 +403,7 @@ func (ic *invocationContext) ResolveAddress(address address.Address) (address.Ad
 }
 
 func (ic *invocationContext) NewActorAddress() address.Address {
-	var buf bytes.Buffer
-
-	err := ic.topLevel.originatorStableAddress.MarshalCBOR(&buf)
-	if err != nil {
-		panic(err)
-	}
-
-	err = binary.Write(&buf, binary.BigEndian, ic.topLevel.originatorCallSeq)
-	if err != nil {
-		panic(err)
-	}
-
-	err = binary.Write(&buf, binary.BigEndian, ic.topLevel.newActorAddressCount)
-	if err != nil {
-		panic(err)
-	}
-
-	actorAddress, err := address.NewActorAddress(buf.Bytes())
+	actorAddress, err := init_.ActorAddress(ic.topLevel.originatorStableAddress, ic.topLevel.originatorCallSeq, ic.topLevel.newActorAddressCount)
 	if err != nil {
 		panic(err)
 	}
@@ -410,7 +433,9 @@ func (ic *invocationContext) Send(toAddr address.Address, methodNum abi.MethodNu
 	}
 
 	newCtx := newInvocationContext(ic.rt, ic.topLevel, newMsg, fromActor, ic.emptyObject)
+	endTrace := ic.TraceGas(runtime.GasOpSend, fmt.Sprintf("%v.%d", toAddr, methodNum))
 	ret, code := newCtx.invoke()
+	endTrace()
 
 	ic.topLevel.gasUsed = newCtx.topLevel.gasUsed
 	ic.stats.MergeSubStat(newCtx.toActor.Code, newMsg.method, newCtx.stats)
@@ -422,6 +447,41 @@ func (ic *invocationContext) Send(toAddr address.Address, methodNum abi.MethodNu
 	return code
 }
 
+func (ic *invocationContext) TraceGas(op runtime.GasOperation, name string) func() {
+	start := ic.topLevel.gasUsed
+	return func() {
+		ic.rt.gasTraces = append(ic.rt.gasTraces, GasTrace{
+			Actor: ic.msg.to,
+			Op:    op,
+			Name:  name,
+			Gas:   ic.topLevel.gasUsed - start,
+		})
+	}
+}
+
+func (ic *invocationContext) EmitEvent(entries ...runtime.EventEntry) {
+	event := runtime.ActorEvent{Entries: entries}
+	ic.rt.events = append(ic.rt.events, EmittedEvent{
+		Emitter: ic.msg.to,
+		Event:   event,
+	})
+	invocation := ic.rt.currentInvocation()
+	invocation.Events = append(invocation.Events, event)
+}
+
+// SendWithGasLimit implements runtime.Runtime.
+func (ic *invocationContext) SendWithGasLimit(toAddr address.Address, methodNum abi.MethodNum, params cbor.Marshaler, value abi.TokenAmount, gasLimit int64, out cbor.Er) exitcode.ExitCode {
+	// Temporarily lower the gas available to the message so the callee runs out at the limit.
+	available := ic.topLevel.gasAvailable
+	if gasLimit < available-ic.topLevel.gasUsed {
+		ic.topLevel.gasAvailable = ic.topLevel.gasUsed + gasLimit
+	}
+	defer func() {
+		ic.topLevel.gasAvailable = available
+	}()
+	return ic.Send(toAddr, methodNum, params, value, out)
+}
+
 // CreateActor implements runtime.ExtendedInvocationContext.
 func (ic *invocationContext) CreateActor(codeID cid.Cid, addr address.Address) {
 	ic.topLevel.chargeGas(ic.topLevel.gasPrices.OnCreateActor())
@@ -646,6 +706,7 @@ func (ic *invocationContext) invoke() (ret returnWrapper, errcode exitcode.ExitC
 	}
 
 	ic.rt.startInvocation(&ic.msg)
+	priorEvents := len(ic.rt.events)
 
 	// Install handler for abort, which rolls back all state changes from this and any nested invocations.
 	// This is the only path by which a non-OK exit code may be returned.
@@ -656,8 +717,12 @@ func (ic *invocationContext) invoke() (ret returnWrapper, errcode exitcode.ExitC
 			if err := ic.rt.rollback(priorRoot); err != nil {
 				panic(err)
 			}
+			ic.rt.events = ic.rt.events[:priorEvents]
 			switch r := r.(type) {
 			case abort:
+				if r.envelope != nil {
+					ic.rt.recordAbortEnvelope(r.envelope)
+				}
 				ic.rt.Log(rt.WARN, "Abort during actor execution. errMsg: %v exitCode: %d sender: %v receiver; %v method: %d value %v",
 					r, r.code, ic.msg.from, ic.msg.to, ic.msg.method, ic.msg.value)
 				ic.rt.endInvocation(r.code, abi.Empty)
@@ -687,6 +752,7 @@ func (ic *invocationContext) invoke() (ret returnWrapper, errcode exitcode.ExitC
 	// 2. load target actor
 	// Note: we replace the "to" address with the normalized version
 	ic.toActor, ic.msg.to = ic.resolveTarget(ic.msg.to)
+	ic.rt.currentInvocation().Code = ic.toActor.Code
 
 	// 3. charge gas for method invocation
 	ic.topLevel.chargeGas(ic.topLevel.gasPrices.OnMethodInvocation(ic.msg.value, ic.msg.method))
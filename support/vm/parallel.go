@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/states"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v7/support/ipld"
+)
+
+// Returns a copy of the VM over a separate block store, into which the current state is copied, so that the copy
+// shares no mutable state with this VM and may run concurrently with it.
+// The copy keeps this VM's epoch, network version, circulating supply, gas configuration and randomness,
+// but not its state exporter, invocations or statistics.
+func (vm *VM) Fork(ctx context.Context, bs ipldcbor.IpldBlockstore) (*VM, error) {
+	root, err := vm.checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := states.ExportCAR(vm.store, root, &buf); err != nil {
+		return nil, xerrors.Errorf("failed to export state %v: %w", root, err)
+	}
+	if _, err := states.ImportCAR(bs, &buf); err != nil {
+		return nil, xerrors.Errorf("failed to import state %v: %w", root, err)
+	}
+
+	fork, err := NewVMAtEpoch(ctx, vm.ActorImpls, adt.WrapBlockStore(ctx, bs), root, vm.currentEpoch)
+	if err != nil {
+		return nil, err
+	}
+	fork.networkVersion = vm.networkVersion
+	fork.circSupply = vm.circSupply
+	fork.gasPrices = vm.gasPrices
+	fork.gasLimit = vm.gasLimit
+	fork.randomness = vm.randomness
+	return fork, nil
+}
+
+// A named scenario to be run against a VM of its own.
+type Scenario struct {
+	Name string
+	Run  func(t *testing.T, v *VM)
+}
+
+// Runs scenarios as parallel subtests, each against a fork of the base VM over its own metered block store,
+// and returns when all have completed. The base VM may be used again once RunParallel returns.
+// Scenarios must not mutate package-level state, such as the policy parameters of the actors.
+func RunParallel(t *testing.T, base *VM, scenarios ...Scenario) {
+	t.Run("parallel", func(t *testing.T) {
+		for _, scenario := range scenarios {
+			scenario := scenario
+			// Fork before pausing, so that the base VM is only read by this goroutine.
+			bs := ipld.NewMetricsBlockStore(ipld.NewBlockStoreInMemory())
+			v, err := base.Fork(base.ctx, bs)
+			require.NoError(t, err)
+			v.SetStatsSource(bs)
+			t.Run(scenario.Name, func(t *testing.T) {
+				t.Parallel()
+				scenario.Run(t, v)
+			})
+		}
+	})
+}
@@ -72,14 +72,17 @@ func newGasCharge(name string, computeGas int64, storageGas int64) GasCharge {
 	}
 }
 
-type scalingCost struct {
-	flat  int64
-	scale int64
+// A cost with a flat component and one proportional to some size.
+type ScalingCost struct {
+	Flat  int64
+	Scale int64
 }
 
-type pricelist struct {
-	computeGasMulti int64
-	storageGasMulti int64
+// A table of gas prices, from which the VM computes the gas charged for each operation.
+// Tests may copy and modify a schedule to explore the effect of price changes.
+type GasSchedule struct {
+	ComputeGasMulti int64
+	StorageGasMulti int64
 	///////////////////////////////////////////////////////////////////////////
 	// System operations
 	///////////////////////////////////////////////////////////////////////////
@@ -90,39 +93,39 @@ type pricelist struct {
 	// Together, these account for the cost of message propagation and validation,
 	// up to but excluding any actual processing by the VM.
 	// This is the cost a block producer burns when including an invalid message.
-	onChainMessageComputeBase    int64
-	onChainMessageStorageBase    int64
-	onChainMessageStoragePerByte int64
+	OnChainMessageComputeBase    int64
+	OnChainMessageStorageBase    int64
+	OnChainMessageStoragePerByte int64
 
 	// Gas cost charged to the originator of a non-nil return value produced
 	// by an on-chain message is given by:
 	//   len(return value)*OnChainReturnValuePerByte
-	onChainReturnValuePerByte int64
+	OnChainReturnValuePerByte int64
 
 	// Gas cost for any message send execution(including the top-level one
 	// initiated by an on-chain message).
 	// This accounts for the cost of loading sender and receiver actors and
 	// (for top-level messages) incrementing the sender's sequence number.
 	// Load and store of actor sub-state is charged separately.
-	sendBase int64
+	SendBase int64
 
 	// Gas cost charged, in addition to SendBase, if a message send
 	// is accompanied by any nonzero currency amount.
 	// Accounts for writing receiver's new balance (the sender's state is
 	// already accounted for).
-	sendTransferFunds int64
+	SendTransferFunds int64
 
 	// Gsa cost charged, in addition to SendBase, if message only transfers funds.
-	sendTransferOnlyPremium int64
+	SendTransferOnlyPremium int64
 
 	// Gas cost charged, in addition to SendBase, if a message invokes
 	// a method on the receiver.
 	// Accounts for the cost of loading receiver code and method dispatch.
-	sendInvokeMethod int64
+	SendInvokeMethod int64
 
 	// Gas cost for any Get operation to the IPLD store
 	// in the runtime VM context.
-	ipldGetBase int64
+	IpldGetBase int64
 
 	// Gas cost (Base + len*PerByte) for any Put operation to the IPLD store
 	// in the runtime VM context.
@@ -130,55 +133,55 @@ type pricelist struct {
 	// Note: these costs should be significantly higher than the costs for Get
 	// operations, since they reflect not only serialization/deserialization
 	// but also persistent storage of chain data.
-	ipldPutBase    int64
-	ipldPutPerByte int64
+	IpldPutBase    int64
+	IpldPutPerByte int64
 
 	// Gas cost for creating a new actor (via InitActor's Exec method).
 	//
 	// Note: this costs assume that the extra will be partially or totally refunded while
 	// the base is covering for the put.
-	createActorCompute int64
-	createActorStorage int64
+	CreateActorCompute int64
+	CreateActorStorage int64
 
 	// Gas cost for deleting an actor.
 	//
 	// Note: this partially refunds the create cost to incentivise the deletion of the actors.
-	deleteActor int64
+	DeleteActor int64
 
-	verifySignature map[crypto.SigType]int64
+	VerifySignature map[crypto.SigType]int64
 
-	hashingBase int64
+	HashingBase int64
 
-	computeUnsealedSectorCidBase int64
-	verifySealBase               int64
-	verifyPostLookup             map[abi.RegisteredPoStProof]scalingCost
-	verifyPostDiscount           bool
-	verifyConsensusFault         int64
+	ComputeUnsealedSectorCidBase int64
+	VerifySealBase               int64
+	VerifyPostLookup             map[abi.RegisteredPoStProof]ScalingCost
+	VerifyPostDiscount           bool
+	VerifyConsensusFault         int64
 }
 
-var _ Pricelist = (*pricelist)(nil)
+var _ Pricelist = (*GasSchedule)(nil)
 
 // OnChainMessage returns the gas used for storing a message of a given size in the chain.
-func (pl *pricelist) OnChainMessage(msgSize int) GasCharge {
-	return newGasCharge("OnChainMessage", pl.onChainMessageComputeBase,
-		(pl.onChainMessageStorageBase+pl.onChainMessageStoragePerByte*int64(msgSize))*pl.storageGasMulti)
+func (pl *GasSchedule) OnChainMessage(msgSize int) GasCharge {
+	return newGasCharge("OnChainMessage", pl.OnChainMessageComputeBase,
+		(pl.OnChainMessageStorageBase+pl.OnChainMessageStoragePerByte*int64(msgSize))*pl.StorageGasMulti)
 }
 
 // OnChainReturnValue returns the gas used for storing the response of a message in the chain.
-func (pl *pricelist) OnChainReturnValue(dataSize int) GasCharge {
-	return newGasCharge("OnChainReturnValue", 0, int64(dataSize)*pl.onChainReturnValuePerByte*pl.storageGasMulti)
+func (pl *GasSchedule) OnChainReturnValue(dataSize int) GasCharge {
+	return newGasCharge("OnChainReturnValue", 0, int64(dataSize)*pl.OnChainReturnValuePerByte*pl.StorageGasMulti)
 }
 
 // OnMethodInvocation returns the gas used when invoking a method.
-func (pl *pricelist) OnMethodInvocation(value abi.TokenAmount, methodNum abi.MethodNum) GasCharge {
-	ret := pl.sendBase
+func (pl *GasSchedule) OnMethodInvocation(value abi.TokenAmount, methodNum abi.MethodNum) GasCharge {
+	ret := pl.SendBase
 	extra := ""
 
 	if big.Cmp(value, abi.NewTokenAmount(0)) != 0 {
-		ret += pl.sendTransferFunds
+		ret += pl.SendTransferFunds
 		if methodNum == builtin.MethodSend {
 			// transfer only
-			ret += pl.sendTransferOnlyPremium
+			ret += pl.SendTransferOnlyPremium
 		}
 		extra += "t"
 	}
@@ -186,36 +189,36 @@ func (pl *pricelist) OnMethodInvocation(value abi.TokenAmount, methodNum abi.Met
 	if methodNum != builtin.MethodSend {
 		extra += "i"
 		// running actors is cheaper becase we hand over to actors
-		ret += pl.sendInvokeMethod
+		ret += pl.SendInvokeMethod
 	}
 	return newGasCharge("OnMethodInvocation", ret, 0).WithExtra(extra)
 }
 
 // OnIpldGet returns the gas used for storing an object
-func (pl *pricelist) OnIpldGet() GasCharge {
-	return newGasCharge("OnIpldGet", pl.ipldGetBase, 0).WithVirtual(114617, 0)
+func (pl *GasSchedule) OnIpldGet() GasCharge {
+	return newGasCharge("OnIpldGet", pl.IpldGetBase, 0).WithVirtual(114617, 0)
 }
 
 // OnIpldPut returns the gas used for storing an object
-func (pl *pricelist) OnIpldPut(dataSize int) GasCharge {
-	return newGasCharge("OnIpldPut", pl.ipldPutBase, int64(dataSize)*pl.ipldPutPerByte*pl.storageGasMulti).
+func (pl *GasSchedule) OnIpldPut(dataSize int) GasCharge {
+	return newGasCharge("OnIpldPut", pl.IpldPutBase, int64(dataSize)*pl.IpldPutPerByte*pl.StorageGasMulti).
 		WithExtra(dataSize).WithVirtual(400000, int64(dataSize)*1300)
 }
 
 // OnCreateActor returns the gas used for creating an actor
-func (pl *pricelist) OnCreateActor() GasCharge {
-	return newGasCharge("OnCreateActor", pl.createActorCompute, pl.createActorStorage*pl.storageGasMulti)
+func (pl *GasSchedule) OnCreateActor() GasCharge {
+	return newGasCharge("OnCreateActor", pl.CreateActorCompute, pl.CreateActorStorage*pl.StorageGasMulti)
 }
 
 // OnDeleteActor returns the gas used for deleting an actor
-func (pl *pricelist) OnDeleteActor() GasCharge {
-	return newGasCharge("OnDeleteActor", 0, pl.deleteActor*pl.storageGasMulti)
+func (pl *GasSchedule) OnDeleteActor() GasCharge {
+	return newGasCharge("OnDeleteActor", 0, pl.DeleteActor*pl.StorageGasMulti)
 }
 
 // OnVerifySignature
 
-func (pl *pricelist) OnVerifySignature(sigType crypto.SigType, planTextSize int) (GasCharge, error) {
-	cost, ok := pl.verifySignature[sigType]
+func (pl *GasSchedule) OnVerifySignature(sigType crypto.SigType, planTextSize int) (GasCharge, error) {
+	cost, ok := pl.VerifySignature[sigType]
 	if !ok {
 		return GasCharge{}, fmt.Errorf("cost function for signature type %d not supported", sigType)
 	}
@@ -229,24 +232,24 @@ func (pl *pricelist) OnVerifySignature(sigType crypto.SigType, planTextSize int)
 }
 
 // OnHashing
-func (pl *pricelist) OnHashing(dataSize int) GasCharge {
-	return newGasCharge("OnHashing", pl.hashingBase, 0).WithExtra(dataSize)
+func (pl *GasSchedule) OnHashing(dataSize int) GasCharge {
+	return newGasCharge("OnHashing", pl.HashingBase, 0).WithExtra(dataSize)
 }
 
 // OnComputeUnsealedSectorCid
-func (pl *pricelist) OnComputeUnsealedSectorCid(proofType abi.RegisteredSealProof, pieces []abi.PieceInfo) GasCharge {
-	return newGasCharge("OnComputeUnsealedSectorCid", pl.computeUnsealedSectorCidBase, 0)
+func (pl *GasSchedule) OnComputeUnsealedSectorCid(proofType abi.RegisteredSealProof, pieces []abi.PieceInfo) GasCharge {
+	return newGasCharge("OnComputeUnsealedSectorCid", pl.ComputeUnsealedSectorCidBase, 0)
 }
 
 // OnVerifySeal
-func (pl *pricelist) OnVerifySeal(info proof.SealVerifyInfo) GasCharge {
+func (pl *GasSchedule) OnVerifySeal(info proof.SealVerifyInfo) GasCharge {
 	// TODO: this needs more cost tunning, check with @lotus
 	// this is not used
-	return newGasCharge("OnVerifySeal", pl.verifySealBase, 0)
+	return newGasCharge("OnVerifySeal", pl.VerifySealBase, 0)
 }
 
 // OnVerifyPost
-func (pl *pricelist) OnVerifyPost(info proof.WindowPoStVerifyInfo) GasCharge {
+func (pl *GasSchedule) OnVerifyPost(info proof.WindowPoStVerifyInfo) GasCharge {
 	sectorSize := "unknown"
 	var proofType abi.RegisteredPoStProof
 
@@ -258,13 +261,13 @@ func (pl *pricelist) OnVerifyPost(info proof.WindowPoStVerifyInfo) GasCharge {
 		}
 	}
 
-	cost, ok := pl.verifyPostLookup[proofType]
+	cost, ok := pl.VerifyPostLookup[proofType]
 	if !ok {
-		cost = pl.verifyPostLookup[abi.RegisteredPoStProof_StackedDrgWindow512MiBV1]
+		cost = pl.VerifyPostLookup[abi.RegisteredPoStProof_StackedDrgWindow512MiBV1]
 	}
 
-	gasUsed := cost.flat + int64(len(info.ChallengedSectors))*cost.scale
-	if pl.verifyPostDiscount {
+	gasUsed := cost.Flat + int64(len(info.ChallengedSectors))*cost.Scale
+	if pl.VerifyPostDiscount {
 		gasUsed /= 2 // XXX: this is an artificial discount
 	}
 
@@ -277,59 +280,59 @@ func (pl *pricelist) OnVerifyPost(info proof.WindowPoStVerifyInfo) GasCharge {
 }
 
 // OnVerifyConsensusFault
-func (pl *pricelist) OnVerifyConsensusFault() GasCharge {
-	return newGasCharge("OnVerifyConsensusFault", pl.verifyConsensusFault, 0)
+func (pl *GasSchedule) OnVerifyConsensusFault() GasCharge {
+	return newGasCharge("OnVerifyConsensusFault", pl.VerifyConsensusFault, 0)
 }
 
 // gas prices as of filecoin v13
 // Note this should be updated to latest next upgrade pricelist before conformance
 // test vector generation to ensure it is up to date with latest protocol.
 // Source of truth here: https://github.com/filecoin-project/lotus/blob/master/chain/vm/gas.go#L82
-var v13PriceList = pricelist{
-	computeGasMulti: 1,
-	storageGasMulti: 1300,
+var GasScheduleV13 = GasSchedule{
+	ComputeGasMulti: 1,
+	StorageGasMulti: 1300,
 
-	onChainMessageComputeBase:    38863,
-	onChainMessageStorageBase:    36,
-	onChainMessageStoragePerByte: 1,
+	OnChainMessageComputeBase:    38863,
+	OnChainMessageStorageBase:    36,
+	OnChainMessageStoragePerByte: 1,
 
-	onChainReturnValuePerByte: 1,
+	OnChainReturnValuePerByte: 1,
 
-	sendBase:                29233,
-	sendTransferFunds:       27500,
-	sendTransferOnlyPremium: 159672,
-	sendInvokeMethod:        -5377,
+	SendBase:                29233,
+	SendTransferFunds:       27500,
+	SendTransferOnlyPremium: 159672,
+	SendInvokeMethod:        -5377,
 
-	ipldGetBase:    114617,
-	ipldPutBase:    353640,
-	ipldPutPerByte: 1,
+	IpldGetBase:    114617,
+	IpldPutBase:    353640,
+	IpldPutPerByte: 1,
 
-	createActorCompute: 1108454,
-	createActorStorage: 36 + 40,
-	deleteActor:        -(36 + 40), // -createActorStorage
+	CreateActorCompute: 1108454,
+	CreateActorStorage: 36 + 40,
+	DeleteActor:        -(36 + 40), // -createActorStorage
 
-	verifySignature: map[crypto.SigType]int64{
+	VerifySignature: map[crypto.SigType]int64{
 		crypto.SigTypeBLS:       16598605,
 		crypto.SigTypeSecp256k1: 1637292,
 	},
 
-	hashingBase:                  31355,
-	computeUnsealedSectorCidBase: 98647,
-	verifySealBase:               2000, // TODO gas , it VerifySeal syscall is not used
-	verifyPostLookup: map[abi.RegisteredPoStProof]scalingCost{
+	HashingBase:                  31355,
+	ComputeUnsealedSectorCidBase: 98647,
+	VerifySealBase:               2000, // TODO gas , it VerifySeal syscall is not used
+	VerifyPostLookup: map[abi.RegisteredPoStProof]ScalingCost{
 		abi.RegisteredPoStProof_StackedDrgWindow512MiBV1: {
-			flat:  117680921,
-			scale: 43780,
+			Flat:  117680921,
+			Scale: 43780,
 		},
 		abi.RegisteredPoStProof_StackedDrgWindow32GiBV1: {
-			flat:  117680921,
-			scale: 43780,
+			Flat:  117680921,
+			Scale: 43780,
 		},
 		abi.RegisteredPoStProof_StackedDrgWindow64GiBV1: {
-			flat:  117680921,
-			scale: 43780,
+			Flat:  117680921,
+			Scale: 43780,
 		},
 	},
-	verifyPostDiscount:   false,
-	verifyConsensusFault: 495422,
+	VerifyPostDiscount:   false,
+	VerifyConsensusFault: 495422,
 }
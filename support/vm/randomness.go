@@ -0,0 +1,105 @@
+package vm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// Provides the beacon and ticket randomness drawn by actors.
+type RandomnessProvider interface {
+	GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness
+	GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness
+}
+
+// Returns RandString for all randomness, the default.
+type fixedRandomness struct{}
+
+func (fixedRandomness) GetRandomnessFromBeacon(_ crypto.DomainSeparationTag, _ abi.ChainEpoch, _ []byte) abi.Randomness {
+	return []byte(RandString)
+}
+
+func (fixedRandomness) GetRandomnessFromTickets(_ crypto.DomainSeparationTag, _ abi.ChainEpoch, _ []byte) abi.Randomness {
+	return []byte(RandString)
+}
+
+// Derives distinct randomness for each source, tag, epoch and entropy by hashing them with a seed,
+// so that values drawn for different purposes differ, as they would on chain.
+type HashRandomness struct {
+	Seed []byte
+}
+
+var _ RandomnessProvider = HashRandomness{}
+
+func (r HashRandomness) GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	return r.derive("beacon", tag, epoch, entropy)
+}
+
+func (r HashRandomness) GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	return r.derive("tickets", tag, epoch, entropy)
+}
+
+func (r HashRandomness) derive(source string, tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	h := sha256.New()
+	h.Write(r.Seed)
+	h.Write([]byte(source))
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(tag))
+	binary.BigEndian.PutUint64(buf[8:], uint64(epoch))
+	h.Write(buf[:])
+	h.Write(entropy)
+	return h.Sum(nil)
+}
+
+type randomnessKey struct {
+	tag   crypto.DomainSeparationTag
+	epoch abi.ChainEpoch
+}
+
+// Returns randomness scripted by a test for each (epoch, domain separation tag), regardless of entropy,
+// falling back to another provider for values not scripted.
+type ScriptedRandomness struct {
+	fallback RandomnessProvider
+	beacon   map[randomnessKey]abi.Randomness
+	tickets  map[randomnessKey]abi.Randomness
+}
+
+var _ RandomnessProvider = (*ScriptedRandomness)(nil)
+
+// Creates scripted randomness with no values scripted. A nil fallback returns RandString.
+func NewScriptedRandomness(fallback RandomnessProvider) *ScriptedRandomness {
+	if fallback == nil {
+		fallback = fixedRandomness{}
+	}
+	return &ScriptedRandomness{
+		fallback: fallback,
+		beacon:   make(map[randomnessKey]abi.Randomness),
+		tickets:  make(map[randomnessKey]abi.Randomness),
+	}
+}
+
+// Scripts the beacon randomness drawn with a tag at an epoch.
+func (r *ScriptedRandomness) SetBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, value abi.Randomness) {
+	r.beacon[randomnessKey{tag, epoch}] = value
+}
+
+// Scripts the ticket randomness drawn with a tag at an epoch.
+func (r *ScriptedRandomness) SetTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, value abi.Randomness) {
+	r.tickets[randomnessKey{tag, epoch}] = value
+}
+
+func (r *ScriptedRandomness) GetRandomnessFromBeacon(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	if value, ok := r.beacon[randomnessKey{tag, epoch}]; ok {
+		return value
+	}
+	return r.fallback.GetRandomnessFromBeacon(tag, epoch, entropy)
+}
+
+func (r *ScriptedRandomness) GetRandomnessFromTickets(tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) abi.Randomness {
+	if value, ok := r.tickets[randomnessKey{tag, epoch}]; ok {
+		return value
+	}
+	return r.fallback.GetRandomnessFromTickets(tag, epoch, entropy)
+}
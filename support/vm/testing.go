@@ -11,6 +11,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/dline"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/ipfs/go-cid"
@@ -20,80 +21,29 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/account"
-	"github.com/filecoin-project/specs-actors/v7/actors/builtin/cron"
-	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
 	initactor "github.com/filecoin-project/specs-actors/v7/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
-	"github.com/filecoin-project/specs-actors/v7/actors/builtin/system"
-	"github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
-	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v7/actors/runtime/proof"
 	"github.com/filecoin-project/specs-actors/v7/actors/states"
-	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
 	actor_testing "github.com/filecoin-project/specs-actors/v7/support/testing"
 )
 
 var FIL = big.NewInt(1e18)
-var VerifregRoot address.Address
 
-func init() {
-	var err error
-	VerifregRoot, err = address.NewIDAddress(80)
-	if err != nil {
-		panic("could not create id address 80")
-	}
-}
+// The holder of the verified registry root key, which is also the network governance.
+var VerifregRoot = builtin.GovernanceActorAddr
 
 //
 // Genesis like setup
 //
 
-// Creates a new VM and initializes all singleton actors plus a root verifier account.
+// Creates a new VM with the singleton actors and a root verifier account, as from an empty genesis.
 func NewVMWithSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBlockstore) *VM {
-	lookup := map[cid.Cid]runtime.VMActor{}
-	for _, ba := range exported.BuiltinActors() {
-		lookup[ba.Code()] = ba
-	}
-
-	store := adt.WrapBlockStore(ctx, bs)
-	vm := NewVM(ctx, lookup, store)
-
-	initializeActor(ctx, t, vm, &system.State{}, builtin.SystemActorCodeID, builtin.SystemActorAddr, big.Zero())
-
-	initState, err := initactor.ConstructState(store, "scenarios")
-	require.NoError(t, err)
-	initializeActor(ctx, t, vm, initState, builtin.InitActorCodeID, builtin.InitActorAddr, big.Zero())
-
-	rewardState := reward.ConstructState(abi.NewStoragePower(0))
-	initializeActor(ctx, t, vm, rewardState, builtin.RewardActorCodeID, builtin.RewardActorAddr, reward.StorageMiningAllocationCheck)
-
-	cronState := cron.ConstructState(cron.BuiltInEntries())
-	initializeActor(ctx, t, vm, cronState, builtin.CronActorCodeID, builtin.CronActorAddr, big.Zero())
-
-	powerState, err := power.ConstructState(store)
-	require.NoError(t, err)
-	initializeActor(ctx, t, vm, powerState, builtin.StoragePowerActorCodeID, builtin.StoragePowerActorAddr, big.Zero())
-
-	marketState, err := market.ConstructState(store)
-	require.NoError(t, err)
-	initializeActor(ctx, t, vm, marketState, builtin.StorageMarketActorCodeID, builtin.StorageMarketActorAddr, big.Zero())
-
-	// this will need to be replaced with the address of a multisig actor for the verified registry to be tested accurately
-	initializeActor(ctx, t, vm, &account.State{Address: VerifregRoot}, builtin.AccountActorCodeID, VerifregRoot, big.Zero())
-	vrState, err := verifreg.ConstructState(store, VerifregRoot)
-	require.NoError(t, err)
-	initializeActor(ctx, t, vm, vrState, builtin.VerifiedRegistryActorCodeID, builtin.VerifiedRegistryActorAddr, big.Zero())
-
-	// burnt funds
-	initializeActor(ctx, t, vm, &account.State{Address: builtin.BurntFundsActorAddr}, builtin.AccountActorCodeID, builtin.BurntFundsActorAddr, big.Zero())
-
-	_, err = vm.checkpoint()
-	require.NoError(t, err)
-
+	vm, _ := NewVMWithGenesis(ctx, t, bs, Genesis{})
 	return vm
 }
 
@@ -147,11 +97,11 @@ type ExpectInvocation struct {
 	SubInvocations []ExpectInvocation
 }
 
-func (ei ExpectInvocation) Matches(t *testing.T, invocations *Invocation) {
+func (ei ExpectInvocation) Matches(t testing.TB, invocations *Invocation) {
 	ei.matches(t, "", invocations)
 }
 
-func (ei ExpectInvocation) matches(t *testing.T, breadcrumb string, invocation *Invocation) {
+func (ei ExpectInvocation) matches(t testing.TB, breadcrumb string, invocation *Invocation) {
 	identifier := fmt.Sprintf("%s[%s:%d]", breadcrumb, invocation.Msg.to, invocation.Msg.method)
 
 	// mismatch of to or method probably indicates skipped message or messages out of order. halt.
@@ -251,7 +201,7 @@ func (oe objectExpectation) matches(obj interface{}) bool {
 var okExitCode = exitcode.Ok
 var ExpectOK = &okExitCode
 
-func ParamsForInvocation(t *testing.T, vm *VM, idxs ...int) interface{} {
+func ParamsForInvocation(t testing.TB, vm *VM, idxs ...int) interface{} {
 	invocations := vm.Invocations()
 	var invocation *Invocation
 	for _, idx := range idxs {
@@ -263,7 +213,7 @@ func ParamsForInvocation(t *testing.T, vm *VM, idxs ...int) interface{} {
 	return invocation.Msg.params
 }
 
-func ValueForInvocation(t *testing.T, vm *VM, idxs ...int) abi.TokenAmount {
+func ValueForInvocation(t testing.TB, vm *VM, idxs ...int) abi.TokenAmount {
 	invocations := vm.Invocations()
 	var invocation *Invocation
 	for _, idx := range idxs {
@@ -281,7 +231,7 @@ func ValueForInvocation(t *testing.T, vm *VM, idxs ...int) abi.TokenAmount {
 
 type advanceDeadlinePredicate func(dlInfo *dline.Info) bool
 
-func MinerDLInfo(t *testing.T, v *VM, minerIDAddr address.Address) *dline.Info {
+func MinerDLInfo(t testing.TB, v *VM, minerIDAddr address.Address) *dline.Info {
 	var minerState miner.State
 	err := v.GetState(minerIDAddr, &minerState)
 	require.NoError(t, err)
@@ -289,7 +239,7 @@ func MinerDLInfo(t *testing.T, v *VM, minerIDAddr address.Address) *dline.Info {
 	return miner.NewDeadlineInfoFromOffsetAndEpoch(minerState.ProvingPeriodStart, v.GetEpoch())
 }
 
-func NextMinerDLInfo(t *testing.T, v *VM, minerIDAddr address.Address) *dline.Info {
+func NextMinerDLInfo(t testing.TB, v *VM, minerIDAddr address.Address) *dline.Info {
 	var minerState miner.State
 	err := v.GetState(minerIDAddr, &minerState)
 	require.NoError(t, err)
@@ -298,7 +248,7 @@ func NextMinerDLInfo(t *testing.T, v *VM, minerIDAddr address.Address) *dline.In
 }
 
 // Advances to the next epoch, running cron.
-func AdvanceOneEpochWithCron(t *testing.T, v *VM) *VM {
+func AdvanceOneEpochWithCron(t testing.TB, v *VM) *VM {
 	result := RequireApplyMessage(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil, t.Name())
 
 	require.Equal(t, exitcode.Ok, result.Code)
@@ -309,7 +259,7 @@ func AdvanceOneEpochWithCron(t *testing.T, v *VM) *VM {
 
 // AdvanceByDeadline creates a new VM advanced to an epoch specified by the predicate while keeping the
 // miner state up-to-date by running a cron at the end of each deadline period.
-func AdvanceByDeadline(t *testing.T, v *VM, minerIDAddr address.Address, predicate advanceDeadlinePredicate) (*VM, *dline.Info) {
+func AdvanceByDeadline(t testing.TB, v *VM, minerIDAddr address.Address, predicate advanceDeadlinePredicate) (*VM, *dline.Info) {
 	dlInfo := MinerDLInfo(t, v, minerIDAddr)
 	var err error
 	for predicate(dlInfo) {
@@ -326,7 +276,7 @@ func AdvanceByDeadline(t *testing.T, v *VM, minerIDAddr address.Address, predica
 
 // Advances by deadline until e is contained within the deadline period represented by the returned deadline info.
 // The VM returned will be set to the last deadline close, not at e.
-func AdvanceByDeadlineTillEpoch(t *testing.T, v *VM, minerIDAddr address.Address, e abi.ChainEpoch) (*VM, *dline.Info) {
+func AdvanceByDeadlineTillEpoch(t testing.TB, v *VM, minerIDAddr address.Address, e abi.ChainEpoch) (*VM, *dline.Info) {
 	return AdvanceByDeadline(t, v, minerIDAddr, func(dlInfo *dline.Info) bool {
 		return dlInfo.Close <= e
 	})
@@ -334,7 +284,7 @@ func AdvanceByDeadlineTillEpoch(t *testing.T, v *VM, minerIDAddr address.Address
 
 // Advances by deadline until the deadline index matches the given index.
 // The vm returned will be set to the close epoch of the previous deadline.
-func AdvanceByDeadlineTillIndex(t *testing.T, v *VM, minerIDAddr address.Address, i uint64) (*VM, *dline.Info) {
+func AdvanceByDeadlineTillIndex(t testing.TB, v *VM, minerIDAddr address.Address, i uint64) (*VM, *dline.Info) {
 	return AdvanceByDeadline(t, v, minerIDAddr, func(dlInfo *dline.Info) bool {
 		return dlInfo.Index != i
 	})
@@ -343,7 +293,7 @@ func AdvanceByDeadlineTillIndex(t *testing.T, v *VM, minerIDAddr address.Address
 // Advance to the epoch when the sector is due to be proven.
 // Returns the deadline info for proving deadline for sector, partition index of sector, and a VM at the opening of
 // the deadline (ready for SubmitWindowedPoSt).
-func AdvanceTillProvingDeadline(t *testing.T, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) (*dline.Info, uint64, *VM) {
+func AdvanceTillProvingDeadline(t testing.TB, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) (*dline.Info, uint64, *VM) {
 	dlIdx, pIdx := SectorDeadline(t, v, minerIDAddress, sectorNumber)
 
 	// advance time to next proving period
@@ -353,7 +303,7 @@ func AdvanceTillProvingDeadline(t *testing.T, v *VM, minerIDAddress address.Addr
 	return dlInfo, pIdx, v
 }
 
-func AdvanceByDeadlineTillEpochWhileProving(t *testing.T, v *VM, minerIDAddress address.Address, workerAddress address.Address, sectorNumber abi.SectorNumber, e abi.ChainEpoch) *VM {
+func AdvanceByDeadlineTillEpochWhileProving(t testing.TB, v *VM, minerIDAddress address.Address, workerAddress address.Address, sectorNumber abi.SectorNumber, e abi.ChainEpoch) *VM {
 	var dlInfo *dline.Info
 	var pIdx uint64
 	for v.GetEpoch() < e {
@@ -365,7 +315,7 @@ func AdvanceByDeadlineTillEpochWhileProving(t *testing.T, v *VM, minerIDAddress
 	return v
 }
 
-func DeclareRecovery(t *testing.T, v *VM, minerAddress, workerAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) {
+func DeclareRecovery(t testing.TB, v *VM, minerAddress, workerAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) {
 	recoverParams := miner.RecoveryDeclaration{
 		Deadline:  deadlineIndex,
 		Partition: partitionIndex,
@@ -377,7 +327,7 @@ func DeclareRecovery(t *testing.T, v *VM, minerAddress, workerAddress address.Ad
 	})
 }
 
-func SubmitPoSt(t *testing.T, v *VM, minerAddress, workerAddress address.Address, dlInfo *dline.Info, partitionIndex uint64) {
+func SubmitPoSt(t testing.TB, v *VM, minerAddress, workerAddress address.Address, dlInfo *dline.Info, partitionIndex uint64) {
 	submitParams := miner.SubmitWindowedPoStParams{
 		Deadline: dlInfo.Index,
 		Partitions: []miner.PoStPartition{{
@@ -388,13 +338,13 @@ func SubmitPoSt(t *testing.T, v *VM, minerAddress, workerAddress address.Address
 			PoStProof: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
 		}},
 		ChainCommitEpoch: dlInfo.Challenge,
-		ChainCommitRand:  []byte(RandString),
+		ChainCommitRand:  ChainCommitRand(v, dlInfo.Challenge),
 	}
 
 	ApplyOk(t, v, workerAddress, minerAddress, big.Zero(), builtin.MethodsMiner.SubmitWindowedPoSt, &submitParams)
 }
 
-func SubmitInvalidPoSt(t *testing.T, v *VM, minerAddress, workerAddress address.Address, dlInfo *dline.Info, partitionIndex uint64) {
+func SubmitInvalidPoSt(t testing.TB, v *VM, minerAddress, workerAddress address.Address, dlInfo *dline.Info, partitionIndex uint64) {
 	submitParams := miner.SubmitWindowedPoStParams{
 		Deadline: dlInfo.Index,
 		Partitions: []miner.PoStPartition{{
@@ -406,14 +356,19 @@ func SubmitInvalidPoSt(t *testing.T, v *VM, minerAddress, workerAddress address.
 			ProofBytes: []byte(InvalidProof),
 		}},
 		ChainCommitEpoch: dlInfo.Challenge,
-		ChainCommitRand:  []byte(RandString),
+		ChainCommitRand:  ChainCommitRand(v, dlInfo.Challenge),
 	}
 
 	ApplyOk(t, v, workerAddress, minerAddress, big.Zero(), builtin.MethodsMiner.SubmitWindowedPoSt, &submitParams)
 }
 
+// Returns the chain commit randomness the VM's miners expect in a Window PoSt committing to an epoch.
+func ChainCommitRand(v *VM, epoch abi.ChainEpoch) abi.Randomness {
+	return v.randomness.GetRandomnessFromTickets(crypto.DomainSeparationTag_PoStChainCommit, epoch, nil)
+}
+
 // find the proving deadline and partition index of a miner's sector
-func SectorDeadline(t *testing.T, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) (uint64, uint64) {
+func SectorDeadline(t testing.TB, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) (uint64, uint64) {
 	var minerState miner.State
 	err := v.GetState(minerIDAddress, &minerState)
 	require.NoError(t, err)
@@ -424,7 +379,7 @@ func SectorDeadline(t *testing.T, v *VM, minerIDAddress address.Address, sectorN
 }
 
 // find the proving deadline and partition index of a miner's sector
-func DeadlineState(t *testing.T, v *VM, minerIDAddress address.Address, dlIndex uint64) *miner.Deadline {
+func DeadlineState(t testing.TB, v *VM, minerIDAddress address.Address, dlIndex uint64) *miner.Deadline {
 	var minerState miner.State
 	err := v.GetState(minerIDAddress, &minerState)
 	require.NoError(t, err)
@@ -439,7 +394,7 @@ func DeadlineState(t *testing.T, v *VM, minerIDAddress address.Address, dlIndex
 }
 
 // find the sector info for the given id
-func SectorInfo(t *testing.T, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) *miner.SectorOnChainInfo {
+func SectorInfo(t testing.TB, v *VM, minerIDAddress address.Address, sectorNumber abi.SectorNumber) *miner.SectorOnChainInfo {
 	var minerState miner.State
 	err := v.GetState(minerIDAddress, &minerState)
 	require.NoError(t, err)
@@ -451,7 +406,7 @@ func SectorInfo(t *testing.T, v *VM, minerIDAddress address.Address, sectorNumbe
 }
 
 // returns true if the sector is healthy
-func CheckSectorActive(t *testing.T, v *VM, minerIDAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) bool {
+func CheckSectorActive(t testing.TB, v *VM, minerIDAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) bool {
 	var minerState miner.State
 	err := v.GetState(minerIDAddress, &minerState)
 	require.NoError(t, err)
@@ -462,7 +417,7 @@ func CheckSectorActive(t *testing.T, v *VM, minerIDAddress address.Address, dead
 }
 
 // returns true if the sector is faulty -- a slightly more specific check than CheckSectorActive
-func CheckSectorFaulty(t *testing.T, v *VM, minerIDAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) bool {
+func CheckSectorFaulty(t testing.TB, v *VM, minerIDAddress address.Address, deadlineIndex uint64, partitionIndex uint64, sectorNumber abi.SectorNumber) bool {
 	var st miner.State
 	require.NoError(t, v.GetState(minerIDAddress, &st))
 
@@ -492,7 +447,7 @@ type MinerBalances struct {
 	PreCommitDeposit abi.TokenAmount
 }
 
-func GetMinerBalances(t *testing.T, vm *VM, minerIdAddr address.Address) MinerBalances {
+func GetMinerBalances(t testing.TB, vm *VM, minerIdAddr address.Address) MinerBalances {
 	var state miner.State
 	a, found, err := vm.GetActor(minerIdAddr)
 	require.NoError(t, err)
@@ -509,7 +464,7 @@ func GetMinerBalances(t *testing.T, vm *VM, minerIdAddr address.Address) MinerBa
 	}
 }
 
-func PowerForMinerSector(t *testing.T, vm *VM, minerIdAddr address.Address, sectorNumber abi.SectorNumber) miner.PowerPair {
+func PowerForMinerSector(t testing.TB, vm *VM, minerIdAddr address.Address, sectorNumber abi.SectorNumber) miner.PowerPair {
 	var state miner.State
 	err := vm.GetState(minerIdAddr, &state)
 	require.NoError(t, err)
@@ -523,7 +478,7 @@ func PowerForMinerSector(t *testing.T, vm *VM, minerIdAddr address.Address, sect
 	return miner.PowerForSector(sectorSize, sector)
 }
 
-func MinerPower(t *testing.T, vm *VM, minerIdAddr address.Address) miner.PowerPair {
+func MinerPower(t testing.TB, vm *VM, minerIdAddr address.Address) miner.PowerPair {
 	var state power.State
 	err := vm.GetState(builtin.StoragePowerActorAddr, &state)
 	require.NoError(t, err)
@@ -556,7 +511,7 @@ type NetworkStats struct {
 	TotalClientStorageFee         abi.TokenAmount
 }
 
-func GetNetworkStats(t *testing.T, vm *VM) NetworkStats {
+func GetNetworkStats(t testing.TB, vm *VM) NetworkStats {
 	var powerState power.State
 	err := vm.GetState(builtin.StoragePowerActorAddr, &powerState)
 	require.NoError(t, err)
@@ -590,7 +545,7 @@ func GetNetworkStats(t *testing.T, vm *VM) NetworkStats {
 	}
 }
 
-func GetDealState(t *testing.T, vm *VM, dealID abi.DealID) (*market.DealState, bool) {
+func GetDealState(t testing.TB, vm *VM, dealID abi.DealID) (*market.DealState, bool) {
 	var marketState market.State
 	err := vm.GetState(builtin.StorageMarketActorAddr, &marketState)
 	require.NoError(t, err)
@@ -608,23 +563,55 @@ func GetDealState(t *testing.T, vm *VM, dealID abi.DealID) (*market.DealState, b
 // Misc. helpers
 //
 
-func ApplyOk(t *testing.T, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}) cbor.Marshaler {
+func ApplyOk(t testing.TB, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}) cbor.Marshaler {
 	return ApplyCode(t, v, from, to, value, method, params, exitcode.Ok)
 }
 
-func ApplyCode(t *testing.T, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}, code exitcode.ExitCode) cbor.Marshaler {
+func ApplyCode(t testing.TB, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}, code exitcode.ExitCode) cbor.Marshaler {
 	result := RequireApplyMessage(t, v, from, to, value, method, params, t.Name())
+	if result.Code != code {
+		for _, envelope := range v.AbortEnvelopes() {
+			t.Logf("%v", envelope)
+		}
+	}
 	require.Equal(t, code, result.Code, "unexpected exit code")
 	return result.Ret
 }
 
-func RequireApplyMessage(t *testing.T, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}, name string) MessageResult {
+func RequireApplyMessage(t testing.TB, v *VM, from, to address.Address, value abi.TokenAmount, method abi.MethodNum, params interface{}, name string) MessageResult {
 	result, err := v.ApplyMessage(from, to, value, method, params, name)
 	require.NoError(t, err)
 	return result
 }
 
-func RequireNormalizeAddress(t *testing.T, addr address.Address, v *VM) address.Address {
+// Asserts that the gas charged for a message is within a range, inclusive, logging the charges if not.
+func ExpectGasBetween(t testing.TB, v *VM, result MessageResult, min, max int64) {
+	if result.GasCharged < min || result.GasCharged > max {
+		for name, gas := range v.GasChargedByName() { // nolint:nomaprange
+			t.Logf("%s: %d", name, gas)
+		}
+	}
+	assert.True(t, min <= result.GasCharged && result.GasCharged <= max,
+		"gas charged %d not between %d and %d", result.GasCharged, min, max)
+}
+
+// Logs the execution trace of the messages applied to a VM as JSON if the test fails.
+// Traces are not carried by the VMs derived with WithEpoch or WithNetworkVersion, which must be registered separately.
+func LogTraceOnFailure(t testing.TB, v *VM) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		var buf bytes.Buffer
+		if err := v.ExportTrace(&buf); err != nil {
+			t.Logf("failed to export execution trace: %s", err)
+			return
+		}
+		t.Logf("execution trace:\n%s", buf.String())
+	})
+}
+
+func RequireNormalizeAddress(t testing.TB, addr address.Address, v *VM) address.Address {
 	idAddr, found := v.NormalizeAddress((addr))
 	require.True(t, found)
 	return idAddr
@@ -0,0 +1,132 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/reward"
+)
+
+// A message to be included in a block.
+type Message struct {
+	From   address.Address
+	To     address.Address
+	Value  abi.TokenAmount
+	Method abi.MethodNum
+	Params interface{}
+}
+
+// A block in a tipset, won by a miner with some number of winning tickets.
+type Block struct {
+	Miner    address.Address
+	WinCount int64
+	Messages []Message
+}
+
+// The outcome of applying a tipset.
+type TipsetResult struct {
+	// Results of the cron ticks for the null rounds preceding the tipset, in epoch order.
+	NullRoundCrons []MessageResult
+	// Results of each block's messages, indexed by block then message.
+	Messages [][]MessageResult
+	// Results of the block reward awarded for each block, indexed by block.
+	Rewards []MessageResult
+	// Result of the cron tick ending the tipset.
+	Cron MessageResult
+}
+
+// Applies a tipset of blocks at an epoch, returning a VM at the following epoch.
+//
+// The VM's current epoch is taken to be the first whose cron tick has not yet run, as after AdvanceOneEpochWithCron.
+// Epochs from it up to the tipset's epoch are null rounds, in which only cron runs.
+// At the tipset's epoch, each block's messages are applied in order, followed by an award of the block reward to
+// the block's miner. Cron runs once at the end of the tipset.
+// As the VM does not charge gas to senders, blocks carry neither gas rewards nor penalties, and messages included
+// in several blocks are applied once for each.
+//
+// An error is returned if a message cannot be applied, or the reward or cron messages fail. Messages failing
+// with a non-zero exit code are recorded in the result.
+func (vm *VM) ApplyTipset(epoch abi.ChainEpoch, blocks []Block) (*VM, TipsetResult, error) {
+	if epoch < vm.currentEpoch {
+		return nil, TipsetResult{}, xerrors.Errorf("tipset epoch %d precedes current epoch %d", epoch, vm.currentEpoch)
+	}
+	var result TipsetResult
+
+	current := vm
+	for current.currentEpoch < epoch {
+		cron, err := current.applyCron()
+		if err != nil {
+			return nil, TipsetResult{}, xerrors.Errorf("null round at epoch %d: %w", current.currentEpoch, err)
+		}
+		result.NullRoundCrons = append(result.NullRoundCrons, cron)
+		if current, err = current.WithEpoch(current.currentEpoch + 1); err != nil {
+			return nil, TipsetResult{}, err
+		}
+	}
+
+	for i, block := range blocks {
+		var messages []MessageResult
+		for j, msg := range block.Messages {
+			ret, err := current.ApplyMessage(msg.From, msg.To, msg.Value, msg.Method, msg.Params, fmt.Sprintf("epoch %d block %d message %d", epoch, i, j))
+			if err != nil {
+				return nil, TipsetResult{}, xerrors.Errorf("failed to apply message %d of block %d: %w", j, i, err)
+			}
+			messages = append(messages, ret)
+		}
+		result.Messages = append(result.Messages, messages)
+
+		award, err := current.applyBlockReward(block.Miner, block.WinCount)
+		if err != nil {
+			return nil, TipsetResult{}, xerrors.Errorf("block %d: %w", i, err)
+		}
+		result.Rewards = append(result.Rewards, award)
+	}
+
+	cron, err := current.applyCron()
+	if err != nil {
+		return nil, TipsetResult{}, xerrors.Errorf("epoch %d: %w", epoch, err)
+	}
+	result.Cron = cron
+
+	next, err := current.WithEpoch(epoch + 1)
+	if err != nil {
+		return nil, TipsetResult{}, err
+	}
+	return next, result, nil
+}
+
+func (vm *VM) applyBlockReward(miner address.Address, winCount int64) (MessageResult, error) {
+	params := reward.AwardBlockRewardParams{
+		Miner:     miner,
+		Penalty:   big.Zero(),
+		GasReward: big.Zero(),
+		WinCount:  winCount,
+	}
+	result, err := vm.ApplyMessage(builtin.SystemActorAddr, builtin.RewardActorAddr, big.Zero(),
+		builtin.MethodsReward.AwardBlockReward, &params, fmt.Sprintf("award block reward to %s", miner))
+	if err != nil {
+		return MessageResult{}, err
+	}
+	if result.Code != exitcode.Ok {
+		return result, xerrors.Errorf("failed to award block reward to %s: exit code %d", miner, result.Code)
+	}
+	return result, nil
+}
+
+func (vm *VM) applyCron() (MessageResult, error) {
+	result, err := vm.ApplyMessage(builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(),
+		builtin.MethodsCron.EpochTick, nil, "cron tick")
+	if err != nil {
+		return MessageResult{}, err
+	}
+	if result.Code != exitcode.Ok {
+		return result, xerrors.Errorf("cron tick failed: exit code %d", result.Code)
+	}
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package vm
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+)
+
+// One invocation in an execution trace, with the invocations it made.
+// Parameters and return values are decoded to the types of the invoked method where known,
+// so the trace can be inspected as JSON without knowledge of actor method numbers or encodings.
+type TraceFrame struct {
+	From       address.Address        `json:"from"`
+	To         address.Address        `json:"to"`
+	Actor      string                 `json:"actor,omitempty"` // Name of the receiving actor's type.
+	Value      abi.TokenAmount        `json:"value"`
+	Method     abi.MethodNum          `json:"method"`
+	MethodName string                 `json:"methodName,omitempty"`
+	Params     interface{}            `json:"params,omitempty"`
+	Return     interface{}            `json:"return,omitempty"`
+	ExitCode   exitcode.ExitCode      `json:"exitCode"`
+	Status     string                 `json:"status"`
+	Events     []runtime.ActorEvent   `json:"events,omitempty"`
+	Abort      *builtin.AbortEnvelope `json:"abort,omitempty"`
+	Subcalls   []*TraceFrame          `json:"subcalls,omitempty"`
+}
+
+// Returns the execution trace of the messages applied since the VM was created or last reverted,
+// with one root frame per message, in the order in which they were applied.
+func (vm *VM) Trace() []*TraceFrame {
+	frames := make([]*TraceFrame, len(vm.invocations))
+	for i, invocation := range vm.invocations {
+		frames[i] = vm.traceFrame(invocation)
+	}
+	return frames
+}
+
+// Writes the execution trace of the messages applied since the VM was created or last reverted as indented JSON.
+func (vm *VM) ExportTrace(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(vm.Trace()); err != nil {
+		return xerrors.Errorf("failed to encode execution trace: %w", err)
+	}
+	return nil
+}
+
+func (vm *VM) traceFrame(invocation *Invocation) *TraceFrame {
+	msg := invocation.Msg
+	frame := &TraceFrame{
+		From:     msg.from,
+		To:       msg.to,
+		Value:    msg.value,
+		Method:   msg.method,
+		ExitCode: invocation.Exitcode,
+		Status:   invocation.Exitcode.String(),
+		Events:   invocation.Events,
+		Abort:    invocation.AbortEnvelope,
+	}
+
+	var signature reflect.Type
+	if invocation.Code.Defined() {
+		frame.Actor = builtin.ActorNameByCode(invocation.Code)
+		if impl, ok := vm.ActorImpls[invocation.Code]; ok {
+			frame.MethodName, signature = exportedMethod(impl, msg.method)
+		}
+	}
+	if msg.method == builtin.MethodSend {
+		frame.MethodName = "Send"
+	}
+	frame.Params = traceValue(msg.params, signature, true)
+	frame.Return = traceValue(invocation.Ret, signature, false)
+
+	for _, sub := range invocation.SubInvocations {
+		frame.Subcalls = append(frame.Subcalls, vm.traceFrame(sub))
+	}
+	return frame
+}
+
+// Returns the name and signature of an actor's exported method, or a nil signature if the method is not exported.
+func exportedMethod(actor runtime.VMActor, method abi.MethodNum) (string, reflect.Type) {
+	exports := actor.Exports()
+	if uint64(len(exports)) <= uint64(method) || exports[method] == nil {
+		return "", nil
+	}
+	entry := reflect.ValueOf(exports[method])
+	name := goruntime.FuncForPC(entry.Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	return name, entry.Type()
+}
+
+// Returns a parameter or return value in a form suitable for encoding as JSON.
+// Raw CBOR is decoded to the type in the method signature, if known and the bytes are well-formed,
+// and otherwise retained as bytes.
+func traceValue(value interface{}, signature reflect.Type, param bool) interface{} {
+	var raw []byte
+	switch v := value.(type) {
+	case nil, *abi.EmptyValue:
+		return nil
+	case []byte:
+		raw = v
+	case builtin.CBORBytes:
+		raw = v
+	default:
+		return value
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var t reflect.Type
+	if signature != nil && param && signature.NumIn() > 1 {
+		t = signature.In(1)
+	} else if signature != nil && !param && signature.NumOut() > 0 {
+		t = signature.Out(0)
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		if obj, err := decodeBytes(t, raw); err == nil {
+			return obj
+		}
+	}
+	return raw
+}
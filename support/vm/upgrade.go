@@ -0,0 +1,45 @@
+package vm
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-state-types/network"
+	vm6 "github.com/filecoin-project/specs-actors/v6/support/vm"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin/exported"
+	"github.com/filecoin-project/specs-actors/v7/actors/migration/nv15"
+	"github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v7/actors/util/adt"
+)
+
+// Migrates the state of a VM running v6 actors with the nv15 migration, returning a VM running v7 actors over
+// the migrated state, so that a scenario begun before the upgrade can continue after it.
+// The v6 VM's epoch is taken to be the last before the upgrade, and the returned VM is at the following epoch.
+// Both VMs share a store, but the v6 VM should not be used after the upgrade.
+func UpgradeFromV6(ctx context.Context, prior *vm6.VM, cfg nv15.Config, log nv15.Logger) (*VM, error) {
+	store := prior.Store()
+	priorEpoch := prior.GetEpoch()
+	root, err := nv15.MigrateStateTree(ctx, store, prior.StateRoot(), priorEpoch, cfg, log, nv15.NewMemMigrationCache())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to migrate state at epoch %d: %w", priorEpoch, err)
+	}
+
+	next, err := NewVMAtEpoch(ctx, builtinActorImpls(), adt.WrapStore(ctx, store), root, priorEpoch+1)
+	if err != nil {
+		return nil, err
+	}
+	next.networkVersion = network.Version15
+	next.SetCirculatingSupply(prior.GetCirculatingSupply())
+	return next, nil
+}
+
+// Returns the implementations of the v7 builtin actors, by code CID.
+func builtinActorImpls() ActorImplLookup {
+	lookup := map[cid.Cid]runtime.VMActor{}
+	for _, ba := range exported.BuiltinActors() {
+		lookup[ba.Code()] = ba
+	}
+	return lookup
+}
@@ -117,7 +117,7 @@ func SetEndStateTree(rawRoot cid.Cid, store adt.Store) Option {
 
 func SetMessage(from, to address.Address, nonce uint64, value big.Int, method abi.MethodNum, params interface{}) Option {
 	return func(tv *testVector) error {
-		msg, err := makeChainMessage(from, to, nonce, value, method, params)
+		msg, err := makeChainMessage(from, to, nonce, value, method, params, defaultGasLimit)
 		if err != nil {
 			return err
 		}
@@ -56,6 +56,10 @@ func (g *vectorGen) after(v *VM, from, to address.Address, value abi.TokenAmount
 	if !g.conformance() && !g.determinism() {
 		return nil
 	}
+	// Vectors are only valid for the canonical gas schedule and limit, and fixed randomness.
+	if _, fixed := v.randomness.(fixedRandomness); !fixed || v.gasLimit != defaultGasLimit || v.gasPrices != Pricelist(&GasScheduleV13) {
+		return nil
+	}
 	// Set test vector message and post application conditions
 	if err := SetMessage(from, to, callSeq, value, method, params)(&(g.vector)); err != nil {
 		return err
@@ -25,8 +25,8 @@ import (
 
 // VM is a simplified message execution framework for the purposes of testing inter-actor communication.
 // The VM maintains actor state and can be used to simulate message validation for a single block or tipset.
-// The VM does not track gas charges, provide working syscalls, validate message nonces and many other things
-// that a compliant VM needs to do.
+// The VM meters gas according to a configurable schedule, but does not charge it to the sender, provide working
+// syscalls, validate message nonces and many other things that a compliant VM needs to do.
 type VM struct {
 	ctx   context.Context
 	store adt.Store
@@ -44,13 +44,21 @@ type VM struct {
 	logs            []string
 	invocationStack []*Invocation
 	invocations     []*Invocation
+	abortEnvelopes  []*builtin.AbortEnvelope
+	events          []EmittedEvent
+	gasTraces       []GasTrace
 
 	statsSource   StatsSource
 	statsByMethod StatsByCall
 
 	circSupply abi.TokenAmount
 
-	gasPrices Pricelist
+	gasPrices  Pricelist
+	gasLimit   int64       // Gas available to each top-level message.
+	gasCharges []GasCharge // Charges made while applying the most recent message.
+
+	randomness    RandomnessProvider
+	stateExporter StateExporter
 }
 
 // VM types
@@ -84,7 +92,7 @@ type ChainMessage struct {
 	Params []byte
 }
 
-func makeChainMessage(from, to address.Address, nonce uint64, value abi.TokenAmount, method abi.MethodNum, params interface{}) (*ChainMessage, error) {
+func makeChainMessage(from, to address.Address, nonce uint64, value abi.TokenAmount, method abi.MethodNum, params interface{}, gasLimit int64) (*ChainMessage, error) {
 	var buf bytes.Buffer
 	if params == nil {
 		if err := abi.Empty.MarshalCBOR(&buf); err != nil {
@@ -102,7 +110,7 @@ func makeChainMessage(from, to address.Address, nonce uint64, value abi.TokenAmo
 		To:         to,
 		Nonce:      nonce,
 		Value:      value,
-		GasLimit:   defaultGasLimit,
+		GasLimit:   gasLimit,
 		GasFeeCap:  big.Zero(),
 		GasPremium: big.Zero(),
 		Method:     method,
@@ -115,6 +123,13 @@ type Invocation struct {
 	Exitcode       exitcode.ExitCode
 	Ret            cbor.Marshaler
 	SubInvocations []*Invocation
+	// Code CID of the receiving actor, or undefined if the receiver could not be resolved.
+	Code cid.Cid
+	// Events emitted by the receiver during this invocation, including those discarded by an abort.
+	Events []runtime.ActorEvent
+	// Describes the abort which ended this invocation, if any.
+	// Only captured in builds with the "abortenvelope" tag.
+	AbortEnvelope *builtin.AbortEnvelope
 }
 
 // NewVM creates a new runtime for executing messages.
@@ -144,7 +159,9 @@ func NewVM(ctx context.Context, actorImpls ActorImplLookup, store adt.Store) *VM
 		networkVersion: network.VersionMax,
 		statsByMethod:  make(StatsByCall),
 		circSupply:     big.Mul(big.NewInt(1e9), big.NewInt(1e18)),
-		gasPrices:      &v13PriceList,
+		gasPrices:      &GasScheduleV13,
+		gasLimit:       defaultGasLimit,
+		randomness:     fixedRandomness{},
 	}
 }
 
@@ -172,15 +189,24 @@ func NewVMAtEpoch(ctx context.Context, actorImpls ActorImplLookup, store adt.Sto
 		networkVersion: network.VersionMax,
 		statsByMethod:  make(StatsByCall),
 		circSupply:     big.Mul(big.NewInt(1e9), big.NewInt(1e18)),
-		gasPrices:      &v13PriceList,
+		gasPrices:      &GasScheduleV13,
+		gasLimit:       defaultGasLimit,
+		randomness:     fixedRandomness{},
 	}, nil
 }
 
+// Returns a new VM over the current state at an epoch.
+// If the epoch is later than the current one, the current state is first passed to the VM's state exporter, if any.
 func (vm *VM) WithEpoch(epoch abi.ChainEpoch) (*VM, error) {
 	_, err := vm.checkpoint()
 	if err != nil {
 		return nil, err
 	}
+	if vm.stateExporter != nil && epoch > vm.currentEpoch {
+		if err := vm.stateExporter.ExportState(vm.store, vm.currentEpoch, vm.stateRoot); err != nil {
+			return nil, err
+		}
+	}
 
 	actors, err := adt.AsMap(vm.store, vm.stateRoot, builtin.DefaultHamtBitwidth)
 	if err != nil {
@@ -200,7 +226,10 @@ func (vm *VM) WithEpoch(epoch abi.ChainEpoch) (*VM, error) {
 		statsSource:    vm.statsSource,
 		statsByMethod:  make(StatsByCall),
 		circSupply:     vm.circSupply,
-		gasPrices:      &v13PriceList,
+		gasPrices:      vm.gasPrices,
+		gasLimit:       vm.gasLimit,
+		randomness:     vm.randomness,
+		stateExporter:  vm.stateExporter,
 	}, nil
 }
 
@@ -228,10 +257,59 @@ func (vm *VM) WithNetworkVersion(nv network.Version) (*VM, error) {
 		statsSource:    vm.statsSource,
 		statsByMethod:  make(StatsByCall),
 		circSupply:     vm.circSupply,
-		gasPrices:      &v13PriceList,
+		gasPrices:      vm.gasPrices,
+		gasLimit:       vm.gasLimit,
+		randomness:     vm.randomness,
+		stateExporter:  vm.stateExporter,
+	}, nil
+}
+
+// A point in a VM's history to which it, or any VM derived from it, may be reverted.
+type Snapshot struct {
+	stateRoot      cid.Cid
+	currentEpoch   abi.ChainEpoch
+	networkVersion network.Version
+	circSupply     abi.TokenAmount
+}
+
+// Commits the VM's state and returns a snapshot of it, so that tests may branch from a common, expensive setup.
+// Taking a snapshot is cheap: state is immutable in the store, so no state is copied.
+func (vm *VM) Snapshot() (Snapshot, error) {
+	root, err := vm.checkpoint()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return Snapshot{
+		stateRoot:      root,
+		currentEpoch:   vm.currentEpoch,
+		networkVersion: vm.networkVersion,
+		circSupply:     vm.circSupply,
 	}, nil
 }
 
+// Reverts the VM's state, epoch, network version and circulating supply to those of a snapshot, which must have
+// been taken from a VM sharing this VM's store. Records of the messages applied since (invocations, events,
+// gas traces and so on) are discarded.
+// A snapshot may be reverted to any number of times.
+func (vm *VM) Revert(snap Snapshot) error {
+	if !snap.stateRoot.Defined() {
+		return xerrors.Errorf("invalid snapshot")
+	}
+	if err := vm.rollback(snap.stateRoot); err != nil {
+		return err
+	}
+	vm.currentEpoch = snap.currentEpoch
+	vm.networkVersion = snap.networkVersion
+	vm.circSupply = snap.circSupply
+	vm.invocationStack = nil
+	vm.invocations = nil
+	vm.abortEnvelopes = nil
+	vm.events = nil
+	vm.gasTraces = nil
+	vm.gasCharges = nil
+	return nil
+}
+
 func (vm *VM) rollback(root cid.Cid) error {
 	var err error
 	vm.actors, err = adt.AsMap(vm.store, root, builtin.DefaultHamtBitwidth)
@@ -364,6 +442,10 @@ func (vm *VM) applyMessageInternal(from, to address.Address, value abi.TokenAmou
 	// but rather deals with the pre/post processing of a message.
 	// (see: `invocationContext.invoke()` for the dispatch and execution)
 	gasCharged := int64(0)
+	vm.abortEnvelopes = nil
+	vm.events = nil
+	vm.gasTraces = nil
+	vm.gasCharges = nil
 
 	// load actor from global state
 	fromID, ok := vm.NormalizeAddress(from)
@@ -401,7 +483,7 @@ func (vm *VM) applyMessageInternal(from, to address.Address, value abi.TokenAmou
 		return MessageResult{}, 0, false, err
 	}
 
-	msg, err := makeChainMessage(from, to, callSeq, value, method, params)
+	msg, err := makeChainMessage(from, to, callSeq, value, method, params, vm.gasLimit)
 	if err != nil {
 		return MessageResult{}, 0, false, err
 	}
@@ -421,7 +503,8 @@ func (vm *VM) applyMessageInternal(from, to address.Address, value abi.TokenAmou
 		circSupply:              vm.circSupply,
 		gasUsed:                 msgGasCharge,
 		gasPrices:               vm.gasPrices,
-		gasAvailable:            defaultGasLimit,
+		gasAvailable:            vm.gasLimit,
+		gasCharges:              []GasCharge{charge},
 		fakeSyscallsAccessed:    false,
 	}
 
@@ -465,6 +548,7 @@ func (vm *VM) applyMessageInternal(from, to address.Address, value abi.TokenAmou
 	}
 	retGasCharge := vm.gasPrices.OnChainReturnValue(len(retBuf.Bytes()))
 	gasCharged = retGasCharge.Total() + ctx.topLevel.gasUsed
+	vm.gasCharges = append(ctx.topLevel.gasCharges, retGasCharge)
 
 	return MessageResult{ret.inner, exitCode, gasCharged}, callSeq, ctx.topLevel.fakeSyscallsAccessed, nil
 }
@@ -523,6 +607,39 @@ func (vm *VM) GetCallStats() map[MethodKey]*CallStats {
 	return vm.statsByMethod
 }
 
+// Sets the prices from which the gas charged for each operation is computed, GasScheduleV13 by default.
+func (vm *VM) SetGasSchedule(prices Pricelist) {
+	vm.gasPrices = prices
+}
+
+func (vm *VM) GetGasSchedule() Pricelist {
+	return vm.gasPrices
+}
+
+// Sets the gas limit of each top-level message. Messages exceeding it exit with SysErrOutOfGas.
+func (vm *VM) SetGasLimit(limit int64) {
+	vm.gasLimit = limit
+}
+
+func (vm *VM) GetGasLimit() int64 {
+	return vm.gasLimit
+}
+
+// Sets an exporter to receive the state at the end of each epoch, as the VM or those derived from it advance
+// to a later epoch with WithEpoch.
+func (vm *VM) SetStateExporter(exporter StateExporter) {
+	vm.stateExporter = exporter
+}
+
+// Sets the provider of the randomness drawn by actors. By default, all randomness is RandString.
+func (vm *VM) SetRandomness(randomness RandomnessProvider) {
+	vm.randomness = randomness
+}
+
+func (vm *VM) GetRandomness() RandomnessProvider {
+	return vm.randomness
+}
+
 // Set the FIL circulating supply passed to actors through runtime
 func (vm *VM) SetCirculatingSupply(supply abi.TokenAmount) {
 	vm.circSupply = supply
@@ -660,6 +777,11 @@ func (vm *VM) endInvocation(code exitcode.ExitCode, ret cbor.Marshaler) {
 	vm.invocationStack = vm.invocationStack[:curIndex]
 }
 
+// Returns the innermost invocation in progress.
+func (vm *VM) currentInvocation() *Invocation {
+	return vm.invocationStack[len(vm.invocationStack)-1]
+}
+
 func (vm *VM) Invocations() []*Invocation {
 	return vm.invocations
 }
@@ -668,6 +790,61 @@ func (vm *VM) LastInvocation() *Invocation {
 	return vm.invocations[len(vm.invocations)-1]
 }
 
+// The gas consumed by a significant operation performed while applying a message.
+type GasTrace struct {
+	Actor address.Address // The receiver of the invocation which performed the operation.
+	Op    runtime.GasOperation
+	Name  string
+	Gas   int64 // Gas consumed by the operation, including that of any nested operations.
+}
+
+// Returns the gas traces of the operations performed while applying the most recent message, in the order
+// in which the operations completed, so nested operations precede those enclosing them.
+func (vm *VM) GasTraces() []GasTrace {
+	return vm.gasTraces
+}
+
+// Returns the gas charges made while applying the most recent message, in the order in which they were made,
+// beginning with the charge for the message's inclusion on chain and ending with that for its return value.
+// The totals of the charges sum to the message result's GasCharged, unless the message ran out of gas.
+func (vm *VM) GasCharges() []GasCharge {
+	return vm.gasCharges
+}
+
+// Returns the total gas charged while applying the most recent message for each kind of charge, by name.
+func (vm *VM) GasChargedByName() map[string]int64 {
+	out := make(map[string]int64)
+	for _, charge := range vm.gasCharges {
+		out[charge.Name] += charge.Total()
+	}
+	return out
+}
+
+// An event emitted by an actor.
+type EmittedEvent struct {
+	Emitter address.Address
+	Event   runtime.ActorEvent
+}
+
+// Returns the events emitted while applying the most recent message, in the order in which they were emitted.
+// Events emitted by invocations which aborted are excluded.
+func (vm *VM) Events() []EmittedEvent {
+	return vm.events
+}
+
+// Returns envelopes describing the aborts raised while applying the most recent message, in the order in which
+// they were raised, so the first is usually the root cause of a failure.
+// Envelopes are only captured in builds with the "abortenvelope" tag.
+func (vm *VM) AbortEnvelopes() []*builtin.AbortEnvelope {
+	return vm.abortEnvelopes
+}
+
+// Records the envelope of an abort which ended the current invocation.
+func (vm *VM) recordAbortEnvelope(envelope *builtin.AbortEnvelope) {
+	vm.currentInvocation().AbortEnvelope = envelope
+	vm.abortEnvelopes = append(vm.abortEnvelopes, envelope)
+}
+
 //
 // implement runtime.Runtime for VM
 //
@@ -681,12 +858,13 @@ func (vm *VM) GetLogs() []string {
 }
 
 type abort struct {
-	code exitcode.ExitCode
-	msg  string
+	code     exitcode.ExitCode
+	msg      string
+	envelope *builtin.AbortEnvelope
 }
 
 func (vm *VM) Abortf(errExitCode exitcode.ExitCode, msg string, args ...interface{}) {
-	panic(abort{errExitCode, fmt.Sprintf(msg, args...)})
+	panic(abort{code: errExitCode, msg: fmt.Sprintf(msg, args...)})
 }
 
 //